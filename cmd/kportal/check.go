@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// checkEntry is one validation result in the -check -output json report.
+type checkEntry struct {
+	Context  map[string]string `json:"context,omitempty"`
+	Field    string            `json:"field"`
+	Message  string            `json:"message"`
+	Severity string            `json:"severity"`
+}
+
+// validateForRun returns the validation errors of cfg followed by its
+// warnings, including contexts missing from kubeconfig.
+func validateForRun(opts runOptions, cfg *config.Config, allowEmpty bool) []config.ValidationError {
+	validator := config.NewValidator()
+	results := validator.ValidateConfigWithOptions(cfg, allowEmpty)
+	results = append(results, validator.Warnings(cfg)...)
+	if known, ok := kubeconfigContexts(opts, cfg); ok {
+		results = append(results, validator.CheckKubeconfigContexts(cfg, known)...)
+	}
+	return results
+}
+
+// runCheckJSON implements -check -output json: it prints every validation
+// result as a JSON array on stdout, [] for a clean config, and returns 1
+// only when one of them is an error. A config that cannot be loaded is
+// reported on stderr; unlike the text mode it is never offered for creation.
+func runCheckJSON(opts runOptions, stdin io.Reader, stdout, stderr io.Writer) int {
+	var cfg *config.Config
+	var err error
+	if opts.configFile == stdinConfigFile {
+		cfg, err = config.ReadConfig(stdin)
+	} else {
+		cfg, err = config.LoadConfig(opts.configFile)
+	}
+	if err != nil {
+		fprintf(stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+
+	results := validateForRun(opts, cfg, cfg.IsEmpty())
+	entries := make([]checkEntry, 0, len(results))
+	for _, r := range results {
+		entries = append(entries, checkEntry{
+			Context:  r.Context,
+			Field:    r.Field,
+			Message:  r.Message,
+			Severity: r.Severity.String(),
+		})
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(entries); err != nil {
+		fprintf(stderr, "Error encoding output: %v\n", err)
+		return 1
+	}
+	if config.HasErrors(results) {
+		return 1
+	}
+	return 0
+}
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/lukaszraczylo/kportal/internal/complete"
 )
@@ -23,6 +24,12 @@ func completionCmd(args []string) int {
 	fs.BoolVar(&uninstall, "uninstall", false, "Uninstall completions")
 	fs.StringVar(&shellFlag, "shell", "", "Shell type: bash, zsh, or fish (auto-detected if empty)")
 
+	// The shell may also be the first argument, e.g. "kportal completion zsh --install"
+	var shellArg string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		shellArg, args = args[0], args[1:]
+	}
+
 	if err := fs.Parse(args); err != nil {
 		if err == flag.ErrHelp {
 			printCompletionHelp()
@@ -30,6 +37,12 @@ func completionCmd(args []string) int {
 		}
 		return 2
 	}
+	if shellFlag == "" {
+		shellFlag = shellArg
+	}
+	if shellFlag == "" && fs.NArg() > 0 {
+		shellFlag = fs.Arg(0)
+	}
 
 	// Determine shell type
 	var shell complete.Shell
@@ -82,7 +95,7 @@ func printCompletionHelp() {
 	fprintf(os.Stdout, `Generate shell completions for kportal.
 
 Usage:
-  kportal completion [flags]
+  kportal completion [bash|zsh|fish] [flags]
 
 Flags:
   --install        Install completions for the current shell
@@ -93,6 +106,9 @@ Examples:
   # Generate and source completions (bash)
   source <(kportal completion)
 
+  # Generate for a specific shell
+  kportal completion zsh > ~/.zsh/completions/_kportal
+
   # Install completions (requires shell restart)
   kportal completion --install
 
@@ -114,6 +130,6 @@ Shell-specific setup:
   Fish (~/.config/fish/config.fish):
     kportal completion --install --shell fish
     # Or manually:
-    kportal completion --shell fish > ~/.config/fish/completions/kportal.fish
+    kportal completion fish > ~/.config/fish/completions/kportal.fish
 `)
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/lukaszraczylo/kportal/internal/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usageFlag matches a flag name in flag.PrintDefaults output
+var usageFlag = regexp.MustCompile(`(?m)^  -([\w-]+)`)
+
+// TestCompletionCoversEveryFlag verifies the completion scripts list every
+// flag of kportal and its subcommands, so a new flag is not forgotten.
+func TestCompletionCoversEveryFlag(t *testing.T) {
+	scripts := make(map[complete.Shell]string)
+	for _, shell := range []complete.Shell{complete.ShellBash, complete.ShellZsh, complete.ShellFish} {
+		script, err := complete.Generate(shell)
+		require.NoError(t, err)
+		scripts[shell] = script
+	}
+
+	for _, args := range [][]string{{"-h"}, {"init", "-h"}, {"list", "-h"}, {"ctl", "-h"}, {"status", "-h"}} {
+		var stdout, stderr bytes.Buffer
+		run(context.Background(), args, strings.NewReader(""), &stdout, &stderr)
+		names := usageFlag.FindAllStringSubmatch(stderr.String(), -1)
+		require.NotEmpty(t, names, args)
+
+		for _, match := range names {
+			name := match[1]
+			option, fish := "--"+name, "-l "+name+" "
+			if len(name) == 1 {
+				option, fish = "-"+name, "-s "+name+" "
+			}
+			assert.Contains(t, scripts[complete.ShellBash], option, "bash %v", args)
+			assert.Contains(t, scripts[complete.ShellZsh], "'"+option+"[", "zsh %v", args)
+			assert.Contains(t, scripts[complete.ShellFish], fish, "fish %v", args)
+		}
+	}
+}
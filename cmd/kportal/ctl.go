@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/control"
+	"github.com/lukaszraczylo/kportal/internal/forward"
+	"github.com/lukaszraczylo/kportal/internal/ui"
+)
+
+// headlessController serves control socket commands from the same entry
+// points the TUI uses.
+type headlessController struct {
+	manager    *forward.Manager
+	table      *ui.TableUI
+	validator  *config.Validator
+	configFile string
+}
+
+func (c *headlessController) EnableForward(id string) error {
+	return c.manager.EnableForward(id)
+}
+
+func (c *headlessController) DisableForward(id string) error {
+	return c.manager.DisableForward(id)
+}
+
+func (c *headlessController) Reload() error {
+	return reloadConfig(c.configFile, c.validator, c.manager)
+}
+
+func (c *headlessController) ListForwards() []control.ForwardInfo {
+	statuses := c.table.Snapshot()
+	forwards := make([]control.ForwardInfo, 0, len(statuses))
+	for id, s := range statuses {
+		forwards = append(forwards, control.ForwardInfo{ID: id, LocalPort: s.LocalPort, Status: s.Status})
+	}
+	sort.Slice(forwards, func(i, j int) bool { return forwards[i].ID < forwards[j].ID })
+	return forwards
+}
+
+// runCtl sends one command to a running headless kportal over its control
+// socket. Returns the process exit code.
+func runCtl(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fprintf(stderr, "Usage: kportal ctl [--config=PATH] [--socket=PATH] <command> [forward-id]\n\n")
+		fprintf(stderr, "Control a kportal running in headless mode with controlSocket set.\n\n")
+		fprintf(stderr, "Commands:\n")
+		fprintf(stderr, "  list          Show every forward with its local port and status\n")
+		fprintf(stderr, "  enable ID     Start a disabled forward\n")
+		fprintf(stderr, "  disable ID    Stop a forward until it is enabled or kportal restarts\n")
+		fprintf(stderr, "  reload        Reload the configuration file, like SIGHUP\n\n")
+		fprintf(stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+	configFlag := fs.String("config", defaultConfigFile, "Path to kportal configuration file, read for controlSocket")
+	socketFlag := fs.String("socket", "", "Control socket path (overrides controlSocket from the config)")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	command, ok := ctlCommand(fs.Args())
+	if !ok {
+		fs.Usage()
+		return 2
+	}
+
+	socket, ok := controlSocketPath(*configFlag, *socketFlag, stderr)
+	if !ok {
+		return 1
+	}
+
+	output, err := control.Send(socket, command)
+	if err != nil {
+		fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	fprint(stdout, output)
+	return 0
+}
+
+// runStatus prints the state of every forward of a running headless kportal,
+// read over its control socket. Returns the process exit code; 1 when no
+// daemon answers.
+func runStatus(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fprintf(stderr, "Usage: kportal status [--config=PATH] [--socket=PATH] [--json]\n\n")
+		fprintf(stderr, "Show the forwards of a kportal running in headless mode with controlSocket set.\n\n")
+		fprintf(stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+	configFlag := fs.String("config", defaultConfigFile, "Path to kportal configuration file, read for controlSocket")
+	socketFlag := fs.String("socket", "", "Control socket path (overrides controlSocket from the config)")
+	jsonFlag := fs.Bool("json", false, "Print the forwards as a JSON array")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	socket, ok := controlSocketPath(*configFlag, *socketFlag, stderr)
+	if !ok {
+		return 1
+	}
+
+	forwards, err := control.Status(socket)
+	if err != nil {
+		fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if *jsonFlag {
+		data, err := json.MarshalIndent(forwards, "", "  ")
+		if err != nil {
+			fprintf(stderr, "Error: failed to encode status: %v\n", err)
+			return 1
+		}
+		fprint(stdout, string(data)+"\n")
+		return 0
+	}
+	fprint(stdout, control.FormatList(forwards))
+	return 0
+}
+
+// controlSocketPath returns socketFlag if set, else the controlSocket of the
+// config file. Failures are reported to stderr.
+func controlSocketPath(configFlag, socketFlag string, stderr io.Writer) (string, bool) {
+	if socketFlag != "" {
+		return socketFlag, true
+	}
+
+	configPath, ok := resolveConfigPath(configFlag, stderr)
+	if !ok {
+		return "", false
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		fprintf(stderr, "Error loading config: %v\n", err)
+		return "", false
+	}
+	if cfg.ControlSocket == "" {
+		fprintf(stderr, "Error: no control socket configured; set controlSocket in %s or pass --socket\n", configPath)
+		return "", false
+	}
+	return cfg.ControlSocket, true
+}
+
+// ctlCommand checks the positional arguments of `kportal ctl` and joins
+// them into a control socket command line.
+func ctlCommand(args []string) (string, bool) {
+	if len(args) == 0 {
+		return "", false
+	}
+	switch args[0] {
+	case control.CommandList, control.CommandReload:
+		if len(args) != 1 {
+			return "", false
+		}
+	case control.CommandEnable, control.CommandDisable:
+		if len(args) != 2 {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	return strings.Join(args, " "), true
+}
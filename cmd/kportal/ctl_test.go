@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCtlCommand(t *testing.T) {
+	tests := []struct {
+		want string
+		args []string
+		ok   bool
+	}{
+		{args: []string{"list"}, want: "list", ok: true},
+		{args: []string{"reload"}, want: "reload", ok: true},
+		{args: []string{"enable", "api:8080"}, want: "enable api:8080", ok: true},
+		{args: []string{"disable", "dev/default/service/api:8080"}, want: "disable dev/default/service/api:8080", ok: true},
+		{args: nil},
+		{args: []string{"enable"}},
+		{args: []string{"list", "extra"}},
+		{args: []string{"restart", "api:8080"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			got, ok := ctlCommand(tt.args)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunCtl_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run(context.Background(), []string{"ctl"}, strings.NewReader(""), &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "Usage: kportal ctl")
+}
+
+func TestRunCtl_NoSocketConfigured(t *testing.T) {
+	cfgPath := writeYAML(t, "v.yaml", "contexts: []\n")
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"ctl", "--config", cfgPath, "list"}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "no control socket configured")
+}
+
+func TestRunCtl_DaemonNotRunning(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	socket := filepath.Join(t.TempDir(), "ctl.sock")
+	code := run(context.Background(), []string{"ctl", "--socket", socket, "list"}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "is kportal running headless")
+}
+
+func TestRunStatus_DaemonNotRunning(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	socket := filepath.Join(t.TempDir(), "ctl.sock")
+	code := run(context.Background(), []string{"status", "--socket", socket}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "is kportal running headless")
+	assert.Empty(t, stdout.String())
+}
+
+func TestRunStatus_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run(context.Background(), []string{"status", "extra"}, strings.NewReader(""), &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "Usage: kportal status")
+}
+
+// TestRun_HeadlessControlSocket drives a running headless kportal through
+// `kportal ctl` and checks the socket is private and removed on shutdown.
+func TestRun_HeadlessControlSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "ctl.sock")
+	cfgPath := writeYAML(t, "v.yaml", "controlSocket: "+socket+"\ncontexts: []\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var daemonOut, daemonErr bytes.Buffer
+	done := make(chan int, 1)
+	go func() {
+		done <- run(ctx, []string{"-headless", "-c", cfgPath}, strings.NewReader(""), &daemonOut, &daemonErr)
+	}()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(socket)
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	ctl := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), append([]string{"ctl", "--config", cfgPath}, args...), strings.NewReader(""), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, _ := ctl("list")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out, "ID")
+
+	status := func(args ...string) (int, string) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), append([]string{"status", "--config", cfgPath}, args...), strings.NewReader(""), &stdout, &stderr)
+		return code, stdout.String()
+	}
+
+	code, out = status()
+	assert.Equal(t, 0, code)
+	assert.Regexp(t, `ID\s+LOCAL\s+STATUS`, out)
+
+	code, out = status("--json")
+	assert.Equal(t, 0, code)
+	assert.JSONEq(t, "[]", out)
+
+	code, _, errOut := ctl("enable", "missing:1234")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errOut, "forward not found in configuration: missing:1234")
+
+	// Reload validates strictly like SIGHUP, so the empty config is rejected
+	// and the reason reaches the client
+	code, _, errOut = ctl("reload")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, errOut, "config validation failed")
+
+	cancel()
+	select {
+	case code := <-done:
+		assert.Equal(t, 0, code)
+	case <-time.After(8 * time.Second):
+		t.Fatal("headless mode did not exit within 8 seconds of ctx cancellation")
+	}
+
+	_, err = os.Stat(socket)
+	assert.True(t, os.IsNotExist(err), "control socket should be removed on shutdown")
+}
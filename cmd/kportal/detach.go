@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const (
+	// readyFDEnv tells a -detach child which file descriptor to report
+	// readiness on
+	readyFDEnv = "KPORTAL_READY_FD"
+	// readyMessage is the line the child writes once every forward is active
+	readyMessage = "ready"
+)
+
+// readinessPipe returns the pipe a -detach child reports readiness on, or
+// nil when kportal was not started by runDetached.
+func readinessPipe() *os.File {
+	fd, err := strconv.Atoi(os.Getenv(readyFDEnv))
+	if err != nil || fd < 3 {
+		return nil
+	}
+	return os.NewFile(uintptr(fd), "readiness")
+}
+
+// runDetached starts kportal again with the same args as a background
+// process in its own session, and waits for it to report that every forward
+// is active. It returns 0 once it has, leaving the child running, and 1 if
+// the child exits first, e.g. after -wait-ready passed. The child logs to
+// the same stderr.
+func runDetached(args []string, stdout, stderr io.Writer) int {
+	exe, err := os.Executable()
+	if err != nil {
+		fprintf(stderr, "Error: cannot find the kportal executable: %v\n", err)
+		return 1
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer func() { _ = r.Close() }()
+
+	cmd := exec.Command(exe, args...)
+	cmd.Env = append(os.Environ(), readyFDEnv+"=3") // ExtraFiles[0] is fd 3
+	cmd.ExtraFiles = []*os.File{w}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.SysProcAttr = detachAttr()
+	startErr := cmd.Start()
+	_ = w.Close() // the child holds the only write end now
+	if startErr != nil {
+		fprintf(stderr, "Error: failed to start kportal in the background: %v\n", startErr)
+		return 1
+	}
+
+	line, _ := bufio.NewReader(r).ReadString('\n')
+	if strings.TrimSpace(line) != readyMessage {
+		// The child closed the pipe by exiting and has logged why
+		_ = cmd.Wait()
+		return 1
+	}
+	fprintf(stdout, "All forwards active; kportal keeps running in the background (pid %d)\n", cmd.Process.Pid)
+	_ = cmd.Process.Release()
+	return 0
+}
@@ -0,0 +1,14 @@
+//go:build !windows
+
+package main
+
+import "syscall"
+
+// detachSupported reports whether -detach works on this platform
+const detachSupported = true
+
+// detachAttr starts the -detach child in its own session, so it outlives
+// the terminal or job that started kportal.
+func detachAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,13 @@
+//go:build windows
+
+package main
+
+import "syscall"
+
+// detachSupported is false on Windows, where a child cannot inherit the
+// readiness pipe.
+const detachSupported = false
+
+func detachAttr() *syscall.SysProcAttr {
+	return nil
+}
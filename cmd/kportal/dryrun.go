@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/k8s"
+)
+
+// dryRunTimeout bounds each cluster call made by -dry-run, so an unreachable
+// API server fails the check instead of hanging it.
+const dryRunTimeout = 10 * time.Second
+
+// dryRunResult is the outcome of resolving one forward without opening it.
+type dryRunResult struct {
+	problems []string // empty when the forward would start
+	target   string   // what the forward would connect to, e.g. "pod/api-7d9f"
+	fwd      config.Forward
+	skipped  bool // disabled forwards are listed but not checked
+}
+
+// runDryRun resolves every forward, or those selected by -profile and
+// -tags, against its cluster and checks its local port, then prints a
+// report without opening any tunnel. Returns 1 when any forward would fail
+// to start.
+func runDryRun(ctx context.Context, opts runOptions, cfg *config.Config, stdout, stderr io.Writer) int {
+	forwards := cfg.FilterByProfile(config.FilterByTags(cfg.GetAllForwards(), opts.tags), opts.profile)
+	if len(forwards) == 0 {
+		fprintln(stdout, "No forwards configured")
+		return 0
+	}
+
+	pool, err := newClientPool(opts, cfg)
+	if err != nil {
+		fprintf(stderr, "Error: failed to load kubeconfig: %v\n", err)
+		return 1
+	}
+	resolver := k8s.NewResourceResolver(pool)
+	portForwarder := k8s.NewPortForwarder(pool, resolver)
+
+	// Check each context once; its forwards are not resolved when unreachable
+	unreachable := make(map[string]error)
+	for _, fwd := range forwards {
+		contextName := fwd.GetContext()
+		if _, checked := unreachable[contextName]; checked {
+			continue
+		}
+		cctx, cancel := context.WithTimeout(ctx, dryRunTimeout)
+		unreachable[contextName] = pool.CheckReachable(cctx, contextName)
+		cancel()
+	}
+
+	results := make([]dryRunResult, 0, len(forwards))
+	checked := 0
+	for _, fwd := range forwards {
+		result := dryRunResult{fwd: fwd}
+		switch {
+		case !fwd.IsEnabled():
+			result.skipped = true
+		case unreachable[fwd.GetContext()] != nil:
+			result.problems = append(result.problems, unreachable[fwd.GetContext()].Error())
+		default:
+			result.target, result.problems = resolveDryRun(ctx, portForwarder, resolver, fwd)
+		}
+		if !result.skipped {
+			result.problems = append(result.problems, checkDryRunPort(fwd)...)
+			checked++
+		}
+		results = append(results, result)
+	}
+
+	failed := printDryRunReport(stdout, results)
+	if failed > 0 {
+		fprintf(stdout, "\n%d of %d forwards would fail to start\n", failed, checked)
+		return 1
+	}
+	fprintf(stdout, "\nAll %d forwards resolved\n", checked)
+	return 0
+}
+
+// resolveDryRun resolves fwd the way the forward worker would and returns its
+// target. Services also show the pod the tunnel would land on.
+func resolveDryRun(ctx context.Context, pf *k8s.PortForwarder, resolver *k8s.ResourceResolver, fwd config.Forward) (string, []string) {
+	if err := k8s.CheckProtocol(fwd.GetProtocol()); err != nil {
+		return "", []string{err.Error()}
+	}
+
+	rctx, cancel := context.WithTimeout(ctx, dryRunTimeout)
+	defer cancel()
+
+	target, err := resolver.Resolve(rctx, fwd.GetContext(), fwd.GetNamespace(), fwd.Resource, fwd.Selector)
+	if err != nil {
+		return "", []string{err.Error()}
+	}
+
+	if strings.HasPrefix(target, "service/") {
+		pod, err := pf.GetPodForResource(rctx, fwd.GetContext(), fwd.GetNamespace(), fwd.Resource, fwd.Selector)
+		if err != nil {
+			return target, []string{err.Error()}
+		}
+		target += " → pod/" + pod
+	}
+	return target, nil
+}
+
+// checkDryRunPort reports a fixed local port that is already taken.
+// Auto-assigned ports are picked at start time and never conflict.
+func checkDryRunPort(fwd config.Forward) []string {
+	if fwd.LocalPort == 0 {
+		return nil
+	}
+	available, usedBy, err := k8s.CheckPortAvailability(fwd.LocalPort)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if !available {
+		return []string{fmt.Sprintf("local port %d is in use: %s", fwd.LocalPort, usedBy)}
+	}
+	return nil
+}
+
+// printDryRunReport writes one row per forward and returns how many have
+// problems.
+func printDryRunReport(w io.Writer, results []dryRunResult) int {
+	failed := 0
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fprintln(tw, "CONTEXT\tNAMESPACE\tRESOURCE\tRESOLVED\tLOCAL\tSTATUS")
+	for _, r := range results {
+		resource := r.fwd.Resource
+		if r.fwd.Selector != "" {
+			resource += "[" + r.fwd.Selector + "]"
+		}
+
+		local := strconv.Itoa(r.fwd.LocalPort)
+		if r.fwd.LocalPort == 0 {
+			local = "auto"
+		}
+
+		target := r.target
+		if target == "" {
+			target = "-"
+		}
+
+		status := "ok"
+		switch {
+		case r.skipped:
+			status = "disabled, not checked"
+		case len(r.problems) > 0:
+			status = "FAIL: " + strings.Join(r.problems, "; ")
+			failed++
+		}
+
+		fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", r.fwd.GetContext(), r.fwd.GetNamespace(), resource, target, local, status)
+	}
+	_ = tw.Flush() // Write errors are non-actionable here, see fprintf
+	return failed
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRun_DryRun_EmptyConfig verifies -dry-run on a config without forwards
+// exits 0 without touching kubeconfig.
+func TestRun_DryRun_EmptyConfig(t *testing.T) {
+	cfgPath := writeYAML(t, "v.yaml", "contexts: []\n")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-dry-run", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout.String(), "No forwards configured")
+}
+
+// TestRun_DryRun_UnreachableContexts verifies forwards on contexts that
+// cannot be reached are reported as failures and the run exits 1.
+func TestRun_DryRun_UnreachableContexts(t *testing.T) {
+	t.Setenv("KUBECONFIG", fakeKubeconfig(t, t.TempDir(), "dev"))
+	cfgPath := writeYAML(t, "dry.yaml", `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 8080
+            localPort: 0
+  - name: missing
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/web
+            port: 80
+            localPort: 0
+          - resource: pod/worker
+            port: 80
+            localPort: 0
+            enabled: false
+`)
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-dry-run", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code, stderr.String())
+
+	out := stdout.String()
+	// The dry run prints the error, so match the sentinel's text
+	assert.Contains(t, out, k8s.ErrContextNotFound.Error()+": missing")
+	assert.Contains(t, out, "cluster for context dev is unreachable")
+	assert.Contains(t, out, "disabled, not checked")
+	assert.Contains(t, out, "2 of 2 forwards would fail to start")
+}
+
+func TestCheckDryRunPort(t *testing.T) {
+	assert.Empty(t, checkDryRunPort(config.Forward{LocalPort: 0}), "auto-assigned ports are not checked")
+
+	ln, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	problems := checkDryRunPort(config.Forward{LocalPort: port})
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0], "is in use")
+}
+
+func TestPrintDryRunReport(t *testing.T) {
+	ok := config.Forward{Resource: "pod", Selector: "app=web", Port: 80, LocalPort: 8080}
+	ok.SetContext("dev", "default")
+	bad := config.Forward{Resource: "service/api", Port: 8080}
+	bad.SetContext("dev", "default")
+
+	var out bytes.Buffer
+	failed := printDryRunReport(&out, []dryRunResult{
+		{fwd: ok, target: "pod/web-7d9f"},
+		{fwd: bad, problems: []string{"service not found", "local port 8080 is in use"}},
+	})
+	assert.Equal(t, 1, failed)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "RESOLVED")
+	assert.Contains(t, lines[1], "pod[app=web]")
+	assert.Contains(t, lines[1], "pod/web-7d9f")
+	assert.True(t, strings.HasSuffix(lines[1], "ok"))
+	assert.Contains(t, lines[2], "auto")
+	assert.Contains(t, lines[2], "FAIL: service not found; local port 8080 is in use")
+}
@@ -21,7 +21,7 @@ func runGenerate(args []string) int {
 	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	fs.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: kportal generate --context=NAME [--config=PATH] [--dry-run]\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: kportal generate --context=NAME [--config=PATH] [--kubeconfig=PATH] [--dry-run]\n\n")
 		fmt.Fprintf(os.Stderr, "Discover services in the chosen Kubernetes context, pick which ones\n")
 		fmt.Fprintf(os.Stderr, "to forward, and append them to the kportal config file.\n\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
@@ -29,6 +29,7 @@ func runGenerate(args []string) int {
 	}
 	contextFlag := fs.String("context", "", "Kubernetes context to scan (required)")
 	configFlag := fs.String("config", defaultConfigFile, "Path to kportal configuration file")
+	kubeconfigFlag := fs.String("kubeconfig", "", "Kubeconfig file to use instead of KUBECONFIG or ~/.kube/config")
 	dryRunFlag := fs.Bool("dry-run", false, "Print the planned forwards but do not modify the config")
 
 	if err := fs.Parse(args); err != nil {
@@ -54,12 +55,21 @@ func runGenerate(args []string) int {
 		return 1
 	}
 
+	kubeconfig := config.ExpandHome(*kubeconfigFlag)
+	if kubeconfig != "" {
+		if err := config.ValidateKubeconfigPath(kubeconfig); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
 	// Build kubernetes client pool and verify the requested context exists.
 	pool, err := k8s.NewClientPool()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to load kubeconfig: %v\n", err)
 		return 1
 	}
+	pool.SetKubeconfig(kubeconfig)
 	contexts, err := pool.ListContexts()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to list kubeconfig contexts: %v\n", err)
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/k8s"
+)
+
+// kubeContexts returns the kubeconfig contexts, sorted, and the current
+// one. Overridden in tests.
+var kubeContexts = func() ([]string, string, error) {
+	pool, err := k8s.NewClientPool()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	discovery := k8s.NewDiscovery(pool)
+	contexts, err := discovery.ListContexts()
+	if err != nil {
+		return nil, "", err
+	}
+	slices.Sort(contexts)
+	current, _ := discovery.GetCurrentContext()
+	return contexts, current, nil
+}
+
+// runInit writes a skeleton config with contexts picked from the
+// kubeconfig, each with no namespaces yet. Returns the process exit code.
+func runInit(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fprintf(stderr, "Usage: kportal init [--config=PATH] [--all | --context=NAME ...]\n\n")
+		fprintf(stderr, "Create a kportal config file with contexts from your kubeconfig, ready\n")
+		fprintf(stderr, "for namespaces and forwards. Without --all or --context, the contexts are\n")
+		fprintf(stderr, "picked from a list.\n\n")
+		fprintf(stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+	configFlag := fs.String("config", defaultConfigFile, "Path of the kportal configuration file to create")
+	allFlag := fs.Bool("all", false, "Add every kubeconfig context")
+	var contextFlags []string
+	fs.Func("context", "Kubeconfig context to add (repeatable)", func(name string) error {
+		contextFlags = append(contextFlags, name)
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *allFlag && len(contextFlags) > 0 {
+		fprintf(stderr, "Error: --all and --context cannot be combined\n")
+		return 2
+	}
+
+	if *configFlag == "" {
+		fprintf(stderr, "Error: --config cannot be empty\n")
+		return 2
+	}
+	configPath, ok := resolveConfigPath(*configFlag, stderr)
+	if !ok {
+		return 1
+	}
+	if _, err := os.Stat(configPath); err == nil {
+		fprintf(stderr, "Error: %s already exists; add contexts to it from the TUI or by hand\n", configPath)
+		return 1
+	}
+
+	contexts, current, err := kubeContexts()
+	if err != nil {
+		fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(contexts) == 0 {
+		fprintf(stderr, "Error: no contexts found in kubeconfig\n")
+		return 1
+	}
+
+	var selected []string
+	switch {
+	case *allFlag:
+		selected = contexts
+	case len(contextFlags) > 0:
+		for _, name := range contextFlags {
+			if !slices.Contains(contexts, name) {
+				fprintf(stderr, "Error: context %q not found in kubeconfig\n", name)
+				fprintf(stderr, "Available contexts: %s\n", strings.Join(contexts, ", "))
+				return 1
+			}
+		}
+		selected = contextFlags
+	default:
+		if selected, err = promptContexts(stdin, stdout, contexts, current); err != nil {
+			fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	if err := config.CreateEmptyConfigFile(configPath); err != nil {
+		fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := config.NewMutator(configPath).AddContexts(selected); err != nil {
+		fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fprintf(stdout, "Created %s with %d contexts: %s\n", configPath, len(selected), strings.Join(selected, ", "))
+	fprintf(stdout, "Add namespaces and forwards to it, or run kportal and press 'n'.\n")
+	return 0
+}
+
+// promptContexts lists contexts as a numbered menu and reads the choice.
+func promptContexts(stdin io.Reader, stdout io.Writer, contexts []string, current string) ([]string, error) {
+	fprintf(stdout, "Kubeconfig contexts:\n")
+	for i, name := range contexts {
+		if name == current {
+			fprintf(stdout, "  %d) %s (current)\n", i+1, name)
+		} else {
+			fprintf(stdout, "  %d) %s\n", i+1, name)
+		}
+	}
+	fprintf(stdout, "Select contexts (e.g. 1,3 or all; empty for the current one): ")
+
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read selection: %w", err)
+	}
+	return parseContextSelection(line, contexts, current)
+}
+
+// parseContextSelection turns a comma- or space-separated list of menu
+// numbers, or "all", into context names. An empty selection picks current.
+func parseContextSelection(input string, contexts []string, current string) ([]string, error) {
+	input = strings.TrimSpace(input)
+	switch {
+	case input == "" && current != "":
+		return []string{current}, nil
+	case input == "":
+		return nil, errors.New("no contexts selected")
+	case strings.EqualFold(input, "all"):
+		return contexts, nil
+	}
+
+	var selected []string
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 1 || n > len(contexts) {
+			return nil, fmt.Errorf("invalid selection %q: pick numbers from 1 to %d", field, len(contexts))
+		}
+		if name := contexts[n-1]; !slices.Contains(selected, name) {
+			selected = append(selected, name)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// stubKubeContexts replaces kubeContexts for the duration of a test
+func stubKubeContexts(t *testing.T, contexts []string, current string, err error) {
+	t.Helper()
+	orig := kubeContexts
+	kubeContexts = func() ([]string, string, error) { return contexts, current, err }
+	t.Cleanup(func() { kubeContexts = orig })
+}
+
+// initContexts returns the context names in the config file at path
+func initContexts(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var cfg config.Config
+	require.NoError(t, yaml.Unmarshal(data, &cfg))
+	var names []string
+	for _, ctx := range cfg.Contexts {
+		names = append(names, ctx.Name)
+	}
+	return names
+}
+
+func TestParseContextSelection(t *testing.T) {
+	contexts := []string{"dev", "prod", "staging"}
+
+	tests := []struct {
+		name    string
+		input   string
+		current string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty picks current", input: "\n", current: "prod", want: []string{"prod"}},
+		{name: "empty without current", input: "", wantErr: true},
+		{name: "all", input: "ALL", want: contexts},
+		{name: "comma separated", input: "1,3", want: []string{"dev", "staging"}},
+		{name: "space separated", input: "3 1", want: []string{"staging", "dev"}},
+		{name: "duplicates", input: "2, 2", want: []string{"prod"}},
+		{name: "out of range", input: "4", wantErr: true},
+		{name: "not a number", input: "dev", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseContextSelection(tt.input, contexts, tt.current)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunInit_All(t *testing.T) {
+	stubKubeContexts(t, []string{"dev", "prod"}, "dev", nil)
+	path := filepath.Join(t.TempDir(), ".kportal.yaml")
+
+	var stdout, stderr bytes.Buffer
+	code := runInit([]string{"--config", path, "--all"}, strings.NewReader(""), &stdout, &stderr)
+
+	require.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, []string{"dev", "prod"}, initContexts(t, path))
+	assert.Contains(t, stdout.String(), "with 2 contexts: dev, prod")
+}
+
+func TestRunInit_Context(t *testing.T) {
+	stubKubeContexts(t, []string{"dev", "prod", "staging"}, "dev", nil)
+	path := filepath.Join(t.TempDir(), ".kportal.yaml")
+
+	var stdout, stderr bytes.Buffer
+	code := runInit([]string{"--config", path, "--context", "staging", "--context", "prod"}, strings.NewReader(""), &stdout, &stderr)
+
+	require.Equal(t, 0, code, stderr.String())
+	assert.Equal(t, []string{"staging", "prod"}, initContexts(t, path))
+}
+
+func TestRunInit_UnknownContext(t *testing.T) {
+	stubKubeContexts(t, []string{"dev"}, "dev", nil)
+	path := filepath.Join(t.TempDir(), ".kportal.yaml")
+
+	var stdout, stderr bytes.Buffer
+	code := runInit([]string{"--config", path, "--context", "qa"}, strings.NewReader(""), &stdout, &stderr)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), `context "qa" not found`)
+	assert.NoFileExists(t, path)
+}
+
+func TestRunInit_Prompt(t *testing.T) {
+	stubKubeContexts(t, []string{"dev", "prod", "staging"}, "prod", nil)
+	path := filepath.Join(t.TempDir(), ".kportal.yaml")
+
+	var stdout, stderr bytes.Buffer
+	code := runInit([]string{"--config", path}, strings.NewReader("1,3\n"), &stdout, &stderr)
+
+	require.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stdout.String(), "2) prod (current)")
+	assert.Equal(t, []string{"dev", "staging"}, initContexts(t, path))
+}
+
+func TestRunInit_ExistingFile(t *testing.T) {
+	stubKubeContexts(t, []string{"dev"}, "dev", nil)
+	path := writeYAML(t, ".kportal.yaml", "contexts: []\n")
+
+	var stdout, stderr bytes.Buffer
+	code := runInit([]string{"--config", path, "--all"}, strings.NewReader(""), &stdout, &stderr)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "already exists")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "contexts: []\n", string(data))
+}
+
+func TestRunInit_Errors(t *testing.T) {
+	t.Run("all with context", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := runInit([]string{"--all", "--context", "dev"}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(t, 2, code)
+	})
+
+	t.Run("kubeconfig error", func(t *testing.T) {
+		stubKubeContexts(t, nil, "", errors.New("no kubeconfig"))
+		path := filepath.Join(t.TempDir(), ".kportal.yaml")
+
+		var stdout, stderr bytes.Buffer
+		code := runInit([]string{"--config", path, "--all"}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr.String(), "no kubeconfig")
+	})
+
+	t.Run("no contexts", func(t *testing.T) {
+		stubKubeContexts(t, nil, "", nil)
+		path := filepath.Join(t.TempDir(), ".kportal.yaml")
+
+		var stdout, stderr bytes.Buffer
+		code := runInit([]string{"--config", path, "--all"}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr.String(), "no contexts found")
+	})
+}
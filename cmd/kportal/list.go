@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// listEntry is the JSON shape of a single forward printed by `kportal list`.
+type listEntry struct {
+	ID         string `json:"id"`
+	Context    string `json:"context"`
+	Namespace  string `json:"namespace"`
+	Resource   string `json:"resource"`
+	Selector   string `json:"selector,omitempty"`
+	Protocol   string `json:"protocol"`
+	Alias      string `json:"alias,omitempty"`
+	PortName   string `json:"portName,omitempty"` // set when the remote port is resolved by name
+	remote     string // remote port or port name, as shown in the table
+	RemotePort int    `json:"remotePort"`
+	LocalPort  int    `json:"localPort"`
+	Enabled    bool   `json:"enabled"`
+}
+
+// runList loads and validates the config and prints its forwards without
+// starting them. Returns the process exit code.
+func runList(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fprintf(stderr, "Usage: kportal list [--config=PATH] [--output=table|json]\n\n")
+		fprintf(stderr, "Print the forwards defined in the kportal config file without starting them.\n\n")
+		fprintf(stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+	configFlag := fs.String("config", defaultConfigFile, "Path to kportal configuration file")
+	outputFlag := fs.String("output", "table", "Output format: table or json")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if *outputFlag != "table" && *outputFlag != "json" {
+		fprintf(stderr, "Error: unknown output format %q (use table or json)\n", *outputFlag)
+		return 2
+	}
+
+	configPath, ok := resolveConfigPath(*configFlag, stderr)
+	if !ok {
+		return 1
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		fprintf(stderr, "Error loading config: %v\n", err)
+		return 1
+	}
+
+	validator := config.NewValidator()
+	if errs := validator.ValidateConfigWithOptions(cfg, cfg.IsEmpty()); len(errs) > 0 {
+		fprint(stderr, config.FormatValidationErrors(errs))
+		return 1
+	}
+
+	forwards := cfg.GetAllForwards()
+	config.SortByOrder(forwards)
+	entries := make([]listEntry, 0, len(forwards))
+	for _, fwd := range forwards {
+		entries = append(entries, listEntry{
+			ID:         fwd.ID(),
+			Context:    fwd.GetContext(),
+			Namespace:  fwd.GetNamespace(),
+			Resource:   fwd.Resource,
+			Selector:   fwd.Selector,
+			Protocol:   fwd.GetProtocol(),
+			Alias:      fwd.Alias,
+			PortName:   fwd.PortName,
+			remote:     fwd.RemotePortLabel(),
+			RemotePort: fwd.Port,
+			LocalPort:  fwd.LocalPort,
+			Enabled:    fwd.IsEnabled(),
+		})
+	}
+
+	if *outputFlag == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(entries); err != nil {
+			fprintf(stderr, "Error encoding output: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	printListTable(stdout, entries)
+	return 0
+}
+
+// printListTable writes entries as aligned columns, mirroring the TUI table.
+func printListTable(w io.Writer, entries []listEntry) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fprintln(tw, "CONTEXT\tNAMESPACE\tRESOURCE\tREMOTE\tLOCAL\tALIAS")
+	for _, e := range entries {
+		resource := e.Resource
+		if e.Selector != "" {
+			resource += "[" + e.Selector + "]"
+		}
+
+		local := strconv.Itoa(e.LocalPort)
+		if e.LocalPort == 0 {
+			local = "auto"
+		}
+
+		alias := e.Alias
+		if alias == "" {
+			alias = "-"
+		}
+		if !e.Enabled {
+			alias += " (disabled)"
+		}
+
+		fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Context, e.Namespace, resource, e.remote, local, alias)
+	}
+	_ = tw.Flush() // Write errors are non-actionable here, see fprintf
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const listConfigYAML = `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/postgres
+            protocol: tcp
+            port: 5432
+            localPort: 5432
+            alias: db
+          - resource: pod
+            selector: app=web
+            protocol: tcp
+            port: 80
+            localPort: 0
+            enabled: false
+`
+
+// TestRunList_Table verifies the default table output.
+func TestRunList_Table(t *testing.T) {
+	cfgPath := writeYAML(t, "list.yaml", listConfigYAML)
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"list", "--config", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"CONTEXT", "NAMESPACE", "RESOURCE", "REMOTE", "LOCAL", "ALIAS"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"dev", "default", "service/postgres", "5432", "5432", "db"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"dev", "default", "pod[app=web]", "80", "auto", "-", "(disabled)"}, strings.Fields(lines[2]))
+}
+
+// TestRunList_JSON verifies --output json emits a structured array.
+func TestRunList_JSON(t *testing.T) {
+	cfgPath := writeYAML(t, "list.yaml", listConfigYAML)
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"list", "--config", cfgPath, "--output", "json"}, strings.NewReader(""), &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	var entries []listEntry
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, listEntry{
+		ID:         "db:5432",
+		Context:    "dev",
+		Namespace:  "default",
+		Resource:   "service/postgres",
+		Protocol:   "tcp",
+		Alias:      "db",
+		RemotePort: 5432,
+		LocalPort:  5432,
+		Enabled:    true,
+	}, entries[0])
+	assert.Equal(t, "app=web", entries[1].Selector)
+	assert.False(t, entries[1].Enabled)
+}
+
+// TestRunList_PortName verifies a forward to a named port shows the name
+// rather than port 0.
+func TestRunList_PortName(t *testing.T) {
+	cfgPath := writeYAML(t, "list.yaml", `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            portName: http
+            localPort: 8080
+`)
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"list", "--config", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, []string{"dev", "default", "service/api", "http", "8080", "-"}, strings.Fields(lines[1]))
+
+	stdout.Reset()
+	code = run(context.Background(), []string{"list", "--config", cfgPath, "--output", "json"}, strings.NewReader(""), &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	var entries []listEntry
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "http", entries[0].PortName)
+	assert.Zero(t, entries[0].RemotePort)
+}
+
+// TestRunList_ConfigDirectory verifies a directory of fragments is merged.
+func TestRunList_ConfigDirectory(t *testing.T) {
+	dir := filepath.Dir(writeYAML(t, "10-db.yaml", listConfigYAML))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "20-api.yaml"), []byte(`contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 80
+            localPort: 8080
+`), 0600))
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"list", "--config", dir, "--output", "json"}, strings.NewReader(""), &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	var entries []listEntry
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+	require.Len(t, entries, 3)
+	assert.Equal(t, "dev/default/service/api:8080", entries[2].ID)
+}
+
+// TestRunList_EmptyConfig verifies an empty config prints an empty JSON array.
+func TestRunList_EmptyConfig(t *testing.T) {
+	cfgPath := writeYAML(t, "empty.yaml", "contexts: []\n")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"list", "--config", cfgPath, "--output", "json"}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "[]", strings.TrimSpace(stdout.String()))
+}
+
+// TestRunList_Errors verifies missing, invalid and misused configs exit non-zero.
+func TestRunList_Errors(t *testing.T) {
+	invalid := writeYAML(t, "bad.yaml", `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: ""
+            port: 0
+            localPort: 8080
+`)
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{name: "missing config", args: []string{"list", "--config", "/nonexistent/kportal.yaml"}, code: 1},
+		{name: "invalid config", args: []string{"list", "--config", invalid}, code: 1},
+		{name: "unknown output", args: []string{"list", "--config", invalid, "--output", "xml"}, code: 2},
+		{name: "system directory", args: []string{"list", "--config", "/etc/kportal.yaml"}, code: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(context.Background(), tt.args, strings.NewReader(""), &stdout, &stderr)
+			assert.Equal(t, tt.code, code)
+			assert.NotEmpty(t, stderr.String())
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -10,18 +12,22 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/control"
 	"github.com/lukaszraczylo/kportal/internal/converter"
 	"github.com/lukaszraczylo/kportal/internal/forward"
 	"github.com/lukaszraczylo/kportal/internal/httplog"
 	"github.com/lukaszraczylo/kportal/internal/k8s"
 	"github.com/lukaszraczylo/kportal/internal/logger"
 	"github.com/lukaszraczylo/kportal/internal/mdns"
+	"github.com/lukaszraczylo/kportal/internal/metrics"
+	"github.com/lukaszraczylo/kportal/internal/notify"
 	"github.com/lukaszraczylo/kportal/internal/ui"
 	"github.com/lukaszraczylo/kportal/internal/version"
 	telemetry "github.com/lukaszraczylo/oss-telemetry"
@@ -30,7 +36,9 @@ import (
 
 const (
 	defaultConfigFile        = ".kportal.yaml"
+	stdinConfigFile          = "-" // -c - reads the config from stdin
 	initialForwardSettleTime = 100 * time.Millisecond
+	shutdownTimeout          = 5 * time.Second
 	tableUpdateInterval      = 2 * time.Second
 
 	// GitHub repository info for update checks
@@ -38,24 +46,54 @@ const (
 	githubRepo  = "kportal"
 )
 
-// appVersion is the build version. Set via ldflags during build:
+// Build metadata. Set via ldflags during build:
 //
-//	-X main.appVersion=v1.2.3
-var appVersion = "0.1.0"
+//	-X main.appVersion=v1.2.3 -X main.gitCommit=abc1234 -X main.buildDate=2024-01-02T15:04:05Z
+var (
+	appVersion = "0.1.0"
+	gitCommit  = "unknown"
+	buildDate  = "unknown"
+)
 
 // runOptions captures the parsed flag values so each mode-specific run* function
 // can be invoked independently of the global flag state. Held by value because
 // it's small and travels through multiple goroutines.
 type runOptions struct {
-	configFile    string
-	logFormat     string
-	convertInput  string
-	convertOutput string
-	verbose       bool
-	headless      bool
-	check         bool
-	showVersion   bool
-	checkUpdate   bool
+	tags           []string // -tags: start only forwards carrying one of these
+	configFile     string
+	profile        string // -profile: start only this config profile's forwards
+	logFormat      string
+	logFile        string
+	logLevel       string
+	output         string
+	auditLog       string
+	convertInput   string
+	convertOutput  string
+	convertKubectl string
+	statusFile     string
+	theme          string
+	kubeconfig     string
+	updateTimeout  time.Duration
+	updateInterval time.Duration
+	waitReady      time.Duration
+	verbose        bool
+	headless       bool
+	check          bool
+	dryRun         bool
+	showVersion    bool
+	checkUpdate    bool
+	fixPorts       bool
+	watch          bool
+	noColor        bool
+	noUpdateCheck  bool
+	httpLog        bool
+	inCluster      bool
+	detach         bool
+	notify         bool // -notify: desktop notifications on forwards going down or recovering
+	readOnly       bool // -read-only: no adding, editing or deleting forwards from the TUI
+	// exitWhenReady is set by -wait-ready without -headless: run headless
+	// and exit 0 once every forward is active
+	exitWhenReady bool
 }
 
 // fprintf is a small wrapper that suppresses the io.Writer write error. We
@@ -117,13 +155,21 @@ func runMain() int {
 // of long-running modes (headless, verbose-loop, interactive).
 func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
 	// Subcommand dispatch must run BEFORE the main flag set is parsed because
-	// generate and completion have their own FlagSets and must not see kportal's top-level flags.
+	// generate, init, list, completion, ctl and status have their own FlagSets and must not see kportal's top-level flags.
 	if len(args) >= 1 {
 		switch args[0] {
 		case "generate":
 			return runGenerate(args[1:])
+		case "init":
+			return runInit(args[1:], stdin, stdout, stderr)
+		case "list":
+			return runList(args[1:], stdout, stderr)
 		case "completion":
 			return completionCmd(args[1:])
+		case "ctl":
+			return runCtl(args[1:], stdout, stderr)
+		case "status":
+			return runStatus(args[1:], stdout, stderr)
 		}
 	}
 
@@ -132,13 +178,23 @@ func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.
 		return code
 	}
 
+	if opts.output != "text" && opts.output != "json" {
+		fprintf(stderr, "Error: unknown output format %q (use text or json)\n", opts.output)
+		return 2
+	}
+
 	// Quick-exit informational modes — these short-circuit before any cluster
 	// work and never need a config file.
 	if opts.showVersion {
-		return runShowVersion(stdout)
+		return runShowVersion(opts.output, stdout, stderr)
 	}
 	if opts.checkUpdate {
-		return runCheckUpdate(stdout, stderr)
+		return runCheckUpdate(opts, stdout, stderr)
+	}
+
+	if opts.output == "json" && !opts.check {
+		fprintln(stderr, "Error: -output json requires -check or -version")
+		return 1
 	}
 
 	// Validate config path security (block system directories, normalise to abs).
@@ -148,18 +204,88 @@ func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.
 	}
 	opts.configFile = resolvedConfig
 
+	// -wait-ready gates scripts on startup, always without a UI. Alone it
+	// exits once the forwards are up; -headless keeps running in the
+	// foreground and -detach in the background.
+	if opts.waitReady < 0 {
+		fprintln(stderr, "Error: -wait-ready must not be negative")
+		return 1
+	}
+	if opts.detach && opts.waitReady == 0 {
+		fprintln(stderr, "Error: -detach requires -wait-ready")
+		return 1
+	}
+	if opts.detach && !detachSupported {
+		fprintln(stderr, "Error: -detach is not supported on this platform")
+		return 1
+	}
+	if opts.waitReady > 0 && !opts.headless {
+		opts.headless = true
+		opts.exitWhenReady = !opts.detach
+	}
+
+	// A config read from stdin leaves no terminal for the interactive UI,
+	// cannot be handed to a -detach child and has no file to watch
+	if opts.configFile == stdinConfigFile {
+		if !opts.headless && !opts.verbose && !opts.check && !opts.dryRun {
+			fprintln(stderr, "Error: -c - needs -headless or -v; the interactive UI reads the terminal from stdin")
+			return 1
+		}
+		if opts.detach {
+			fprintln(stderr, "Error: -detach cannot be used with -c -")
+			return 1
+		}
+		opts.watch = false
+	}
+
+	// A mistyped -kubeconfig would otherwise surface as every forward
+	// failing to connect
+	if opts.kubeconfig != "" {
+		opts.kubeconfig = config.ExpandHome(opts.kubeconfig)
+		if err := config.ValidateKubeconfigPath(opts.kubeconfig); err != nil {
+			fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	// -log-file captures logs in every mode, including the TUI, where they
+	// would otherwise be discarded to keep the screen intact.
+	var logFile io.Writer
+	if opts.logFile != "" {
+		f, err := logger.OpenFile(opts.logFile)
+		if err != nil {
+			fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer func() { _ = f.Close() }()
+		logFile = f
+	}
+
 	// Initialise structured logger / klog routing. These outputs depend on mode,
 	// not on -v alone (see comment block in original implementation).
-	initLoggers(opts, stderr)
+	initLoggers(opts, stderr, logFile)
+
+	// NO_COLOR (https://no-color.org) and -no-color both switch the UIs to plain text.
+	if opts.noColor || ui.NoColorFromEnv() {
+		opts.noColor = true
+		ui.DisableColor()
+	}
 
 	// Conversion mode runs before config load — it does not need a kportal config.
 	if opts.convertInput != "" {
-		return runConvert(opts.convertInput, opts.convertOutput, stdout, stderr)
+		return runConvert(opts.convertInput, opts.convertOutput, opts.fixPorts, stdout, stderr)
+	}
+	if opts.convertKubectl != "" {
+		return runConvertKubectl(opts.convertKubectl, opts.convertOutput, stdout, stderr)
 	}
 
 	// Configure stdlib log destination based on mode.
 	configureStdlibLog(opts)
 
+	if opts.output == "json" {
+		return runCheckJSON(opts, stdin, stdout, stderr)
+	}
+
 	// Load configuration (with optional create-on-missing prompt).
 	cfg, configIsNew, code, handled := loadOrCreateConfig(opts.configFile, stdin, stdout, stderr)
 	if handled {
@@ -167,20 +293,55 @@ func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.
 	}
 
 	// Validate configuration (allow empty for newly created files).
+	// Warnings are reported alongside errors but only errors exit 1.
+	results := validateForRun(opts, cfg, configIsNew || cfg.IsEmpty())
+	fprint(stderr, config.FormatValidationErrors(results))
+	if config.HasErrors(results) {
+		return 1
+	}
 	validator := config.NewValidator()
-	if errs := validator.ValidateConfigWithOptions(cfg, configIsNew || cfg.IsEmpty()); len(errs) > 0 {
-		fprint(stderr, config.FormatValidationErrors(errs))
+	if opts.profile != "" && !cfg.HasProfile(opts.profile) {
+		fprintf(stderr, "Error: profile %q is not defined in the config", opts.profile)
+		if names := cfg.ProfileNames(); len(names) > 0 {
+			fprintf(stderr, " (available: %s)", strings.Join(names, ", "))
+		}
+		fprintln(stderr)
 		return 1
 	}
+	if len(opts.tags) > 0 && !cfg.IsEmpty() && len(config.FilterByTags(cfg.GetAllForwards(), opts.tags)) == 0 {
+		fprintf(stderr, "Warning: no forward is tagged %s\n", strings.Join(opts.tags, " or "))
+	}
 
 	if opts.check {
 		fprintln(stdout, "Configuration is valid")
 		return 0
 	}
+	if opts.dryRun {
+		return runDryRun(ctx, opts, cfg, stdout, stderr)
+	}
+	if opts.detach && readinessPipe() == nil {
+		return runDetached(args, stdout, stderr)
+	}
 
 	if opts.verbose {
 		log.Printf("kportal v%s", appVersion)
-		log.Printf("Loading configuration from: %s", opts.configFile)
+		if opts.configFile == stdinConfigFile {
+			log.Printf("Loading configuration from stdin")
+		} else {
+			log.Printf("Loading configuration from: %s", opts.configFile)
+		}
+	}
+
+	// -audit-log records each forward the wizards add, edit or remove.
+	var auditLog *logger.Logger
+	if opts.auditLog != "" {
+		f, err := logger.OpenFile(opts.auditLog)
+		if err != nil {
+			fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer func() { _ = f.Close() }()
+		auditLog = logger.New(logger.LevelInfo, parseLogFormat(opts.logFormat), f)
 	}
 
 	// Build forward manager + supporting bits, shared by headless / verbose / TUI paths.
@@ -189,10 +350,11 @@ func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.
 		fprintf(stderr, "Error: %v\n", err)
 		return 1
 	}
+	deps.mutator.SetAuditLogger(auditLog)
 
 	switch {
 	case opts.headless:
-		return runHeadless(ctx, opts, cfg, deps, validator, stderr)
+		return runHeadless(ctx, opts, cfg, deps, validator, stdout, stderr)
 	case opts.verbose:
 		return runVerboseTable(ctx, opts, cfg, deps, validator, stderr)
 	default:
@@ -208,15 +370,45 @@ func parseFlags(args []string, stderr io.Writer) (runOptions, int, bool) {
 	fs.SetOutput(stderr)
 
 	var opts runOptions
-	fs.StringVar(&opts.configFile, "c", defaultConfigFile, "Path to configuration file")
+	fs.StringVar(&opts.configFile, "c", defaultConfigFile, "Path to configuration file, a directory of *.yaml fragments, or - to read it from stdin")
 	fs.BoolVar(&opts.verbose, "v", false, "Enable verbose logging")
 	fs.BoolVar(&opts.headless, "headless", false, "Run in headless mode (no UI, for background/daemon use)")
+	fs.DurationVar(&opts.waitReady, "wait-ready", 0, "Wait up to this long, e.g. 60s, for every enabled forward to be active, then exit 0 (keep running with -headless or -detach); exit 1 on timeout")
+	fs.BoolVar(&opts.detach, "detach", false, "With -wait-ready, exit once the forwards are active and keep them running in the background")
+	fs.StringVar(&opts.statusFile, "status-file", "", "File to write the JSON status snapshot to on SIGUSR1 in headless mode (default: stdout)")
 	fs.StringVar(&opts.logFormat, "log-format", "text", "Log format: text or json")
+	fs.Func("log-level", "Log level: debug, info, warn or error (default debug with -v, else info)", func(s string) error {
+		opts.logLevel = s
+		_, err := logger.ParseLevel(s)
+		return err
+	})
+	fs.StringVar(&opts.auditLog, "audit-log", "", "Append an entry to this file for every forward added, edited or removed through kportal")
+	fs.StringVar(&opts.logFile, "log-file", "", "Append structured and Kubernetes client logs to this file instead of the terminal")
 	fs.BoolVar(&opts.check, "check", false, "Validate configuration and exit")
+	fs.StringVar(&opts.output, "output", "text", "Output format of -check and -version: text or json")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "Validate, resolve every forward and check local ports, then exit without opening tunnels")
 	fs.BoolVar(&opts.showVersion, "version", false, "Show version and exit")
 	fs.BoolVar(&opts.checkUpdate, "update", false, "Check for updates and exit")
+	fs.BoolVar(&opts.watch, "watch", true, "Reload the configuration when the file changes (-watch=false to disable)")
+	fs.StringVar(&opts.profile, "profile", "", "Start only the forwards of this profile from the config's profiles")
+	fs.Func("tags", "Start only the forwards tagged with one of these comma-separated tags", func(s string) error {
+		opts.tags = parseTags(s)
+		return nil
+	})
+	fs.StringVar(&opts.kubeconfig, "kubeconfig", "", "Kubeconfig file to use instead of KUBECONFIG or ~/.kube/config (overrides the config's kubeconfig)")
+	fs.BoolVar(&opts.inCluster, "in-cluster", false, "Add the \"in-cluster\" context, using the pod's service account, even when kubeconfig has contexts")
+	fs.StringVar(&opts.theme, "theme", "", "UI color theme: dark or light (overrides the config's theme)")
+	fs.BoolVar(&opts.httpLog, "http-log", false, "Enable HTTP logging for every forward without an httpLog setting of its own (same as httpLog: true at the top of the config)")
+	fs.BoolVar(&opts.readOnly, "read-only", false, "Disable adding, editing and deleting forwards from the TUI (same as readOnly: true in the config)")
+	fs.BoolVar(&opts.notify, "notify", false, "Show a desktop notification when a forward goes down or recovers (same as notify.enabled in the config)")
+	fs.BoolVar(&opts.noColor, "no-color", false, "Disable colors and styling in every UI (also enabled by the NO_COLOR environment variable)")
+	fs.BoolVar(&opts.noUpdateCheck, "no-update-check", false, "Skip the background update check (also enabled by the KPORTAL_NO_UPDATE_CHECK environment variable)")
+	fs.DurationVar(&opts.updateTimeout, "update-timeout", version.DefaultTimeout, "Timeout for each update check request")
+	fs.DurationVar(&opts.updateInterval, "update-interval", version.DefaultCacheTTL, "Reuse the cached update check result until it is this old")
 	fs.StringVar(&opts.convertInput, "convert", "", "Convert kftray JSON config to kportal YAML (provide input file path)")
+	fs.StringVar(&opts.convertKubectl, "convert-kubectl", "", "Convert a file of 'kubectl port-forward' commands to kportal YAML (provide input file path)")
 	fs.StringVar(&opts.convertOutput, "convert-output", ".kportal.yaml", "Output file for converted configuration")
+	fs.BoolVar(&opts.fixPorts, "convert-fix-ports", false, "With -convert, move forwards whose local port collides with an earlier one to the next free port")
 
 	if err := fs.Parse(args); err != nil {
 		if err == flag.ErrHelp {
@@ -227,12 +419,41 @@ func parseFlags(args []string, stderr io.Writer) (runOptions, int, bool) {
 	return opts, 0, false
 }
 
+// updateCheckDisabled reports whether background update checks are turned
+// off by -no-update-check or KPORTAL_NO_UPDATE_CHECK.
+func updateCheckDisabled(opts runOptions) bool {
+	return opts.noUpdateCheck || version.DisabledFromEnv()
+}
+
+// startUpdateCheck checks for a newer release in the background and calls
+// notify when there is one. Best effort: failures are silent. When checks are
+// disabled no goroutine is started, so no request is made. Results are
+// cached, so GitHub is asked at most once per -update-interval.
+func startUpdateCheck(ctx context.Context, opts runOptions, notify func(*version.UpdateInfo)) {
+	if updateCheckDisabled(opts) {
+		return
+	}
+	go func() {
+		checker := version.NewCheckerWithOptions(githubOwner, githubRepo, appVersion, version.Options{
+			CachePath: version.DefaultCachePath(),
+			Timeout:   opts.updateTimeout,
+			CacheTTL:  opts.updateInterval,
+		})
+		if update := checker.CheckForUpdate(ctx); update != nil {
+			notify(update)
+		}
+	}()
+}
+
 // resolveConfigPath validates the user-supplied config path: must resolve to
 // an absolute, cleaned path that is not inside a protected system directory.
 func resolveConfigPath(path string, stderr io.Writer) (string, bool) {
 	if path == "" {
 		return "", true // empty is allowed; caller treats it as "no config"
 	}
+	if path == stdinConfigFile {
+		return path, true // not a file, so there is no directory to check
+	}
 	abs, err := filepath.Abs(path)
 	if err != nil {
 		fprintf(stderr, "Invalid config path: %v\n", err)
@@ -249,36 +470,30 @@ func resolveConfigPath(path string, stderr io.Writer) (string, bool) {
 }
 
 // initLoggers configures the structured logger and klog routing. Output
-// destination depends on run mode (see big comment for rationale).
-func initLoggers(opts runOptions, stderr io.Writer) {
-	var logLevel logger.Level
-	var logFmt logger.Format
+// destination depends on run mode (see big comment for rationale). A non-nil
+// logFile takes both outputs in every mode.
+func initLoggers(opts runOptions, stderr, logFile io.Writer) {
 	var logOutput io.Writer
 
-	if opts.verbose {
-		logLevel = logger.LevelDebug
-	} else {
-		logLevel = logger.LevelInfo
-	}
+	logLevel := resolveLogLevel(opts)
 
-	if opts.headless || opts.verbose {
+	switch {
+	case logFile != nil:
+		logOutput = logFile
+	case opts.headless || opts.verbose:
 		logOutput = stderr
-	} else {
-		logOutput = io.Discard
-	}
-
-	switch opts.logFormat {
-	case "json":
-		logFmt = logger.FormatJSON
 	default:
-		logFmt = logger.FormatText
+		logOutput = io.Discard
 	}
 
+	logFmt := parseLogFormat(opts.logFormat)
 	logger.Init(logLevel, logFmt, logOutput)
 
+	// klog follows the structured logger when logs are wanted: with -v, a log
+	// file, or an explicit -log-level in a mode that prints logs
 	klog.LogToStderr(false)
-	if opts.verbose {
-		klogLogger := logger.New(logger.LevelDebug, logFmt, stderr)
+	if logOutput != io.Discard && (opts.verbose || logFile != nil || opts.logLevel != "") {
+		klogLogger := logger.New(logLevel, logFmt, logOutput)
 		klog.SetOutput(logger.NewKlogWriter(klogLogger))
 		logrSink := logger.NewLogrAdapter(klogLogger)
 		klog.SetLogger(logr.New(logrSink))
@@ -290,6 +505,40 @@ func initLoggers(opts runOptions, stderr io.Writer) {
 	}
 }
 
+// parseTags splits a -tags value on commas, dropping blanks around and
+// between tags.
+func parseTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// parseLogFormat maps -log-format to a logger format; anything but "json" is text.
+func parseLogFormat(s string) logger.Format {
+	if s == "json" {
+		return logger.FormatJSON
+	}
+	return logger.FormatText
+}
+
+// resolveLogLevel returns the -log-level value, falling back to debug with -v
+// and info otherwise. The value was validated when the flags were parsed.
+func resolveLogLevel(opts runOptions) logger.Level {
+	if opts.logLevel != "" {
+		if level, err := logger.ParseLevel(opts.logLevel); err == nil {
+			return level
+		}
+	}
+	if opts.verbose {
+		return logger.LevelDebug
+	}
+	return logger.LevelInfo
+}
+
 // configureStdlibLog matches stdlib log destination to the mode. Only the TUI
 // path needs total silence; daemonised modes keep stderr.
 func configureStdlibLog(opts runOptions) {
@@ -308,6 +557,15 @@ func configureStdlibLog(opts runOptions) {
 // loadOrCreateConfig loads the config, prompting to create an empty file if it
 // doesn't exist. Returns (cfg, configIsNew, exitCode, handled).
 func loadOrCreateConfig(configFile string, stdin io.Reader, stdout, stderr io.Writer) (*config.Config, bool, int, bool) {
+	if configFile == stdinConfigFile {
+		cfg, err := config.ReadConfig(stdin)
+		if err != nil {
+			fprintf(stderr, "Error loading config from stdin: %v\n", err)
+			return nil, false, 1, true
+		}
+		return cfg, false, 0, false
+	}
+
 	cfg, err := config.LoadConfig(configFile)
 	if err == nil {
 		return cfg, false, 0, false
@@ -344,14 +602,55 @@ type runtimeDeps struct {
 	mdnsPub   *mdns.Publisher
 }
 
+// kubeconfigPath returns the kubeconfig file to read: -kubeconfig, else the
+// config's kubeconfig, else "" for KUBECONFIG or ~/.kube/config.
+func kubeconfigPath(opts runOptions, cfg *config.Config) string {
+	if opts.kubeconfig != "" {
+		return opts.kubeconfig
+	}
+	return cfg.GetKubeconfig()
+}
+
+// newClientPool creates a client pool reading the kubeconfig chosen by
+// -kubeconfig or the config, with the in-cluster context when -in-cluster
+// is set.
+func newClientPool(opts runOptions, cfg *config.Config) (*k8s.ClientPool, error) {
+	pool, err := k8s.NewClientPool()
+	if err != nil {
+		return nil, err
+	}
+	pool.SetKubeconfig(kubeconfigPath(opts, cfg))
+	pool.SetInCluster(opts.inCluster)
+	return pool, nil
+}
+
+// kubeconfigContexts lists the contexts of kubeconfig for the startup check.
+// ok is false when there is nothing to check or kubeconfig cannot be read.
+func kubeconfigContexts(opts runOptions, cfg *config.Config) ([]string, bool) {
+	if len(cfg.Contexts) == 0 {
+		return nil, false
+	}
+	pool, err := newClientPool(opts, cfg)
+	if err != nil {
+		return nil, false
+	}
+	known, err := pool.ListContexts()
+	if err != nil {
+		return nil, false
+	}
+	return known, true
+}
+
 // buildRuntimeDeps constructs the kubernetes client pool, forward manager, and
 // helpers used across run modes. Returns an error only on fatal failures
 // (manager creation); a missing kubeconfig is logged but allowed.
 func buildRuntimeDeps(opts runOptions, cfg *config.Config, stderr io.Writer) (*runtimeDeps, error) {
-	pool, err := k8s.NewClientPool()
+	pool, err := newClientPool(opts, cfg)
 	if err != nil {
 		fprintf(stderr, "Warning: Failed to create k8s client pool: %v\n", err)
 		fprintf(stderr, "Add/remove wizards will not be available\n")
+	} else {
+		pool.SetConcurrency(cfg.GetDiscoveryConcurrency(), cfg.GetDiscoveryConcurrencyTotal())
 	}
 	discovery := k8s.NewDiscovery(pool)
 	mutator := config.NewMutator(opts.configFile)
@@ -360,8 +659,20 @@ func buildRuntimeDeps(opts runOptions, cfg *config.Config, stderr io.Writer) (*r
 	if err != nil {
 		return nil, fmt.Errorf("creating forward manager: %w", err)
 	}
+	manager.SetHTTPLogDefault(opts.httpLog)
+	manager.SetKubeconfig(opts.kubeconfig)
+	manager.SetInCluster(opts.inCluster)
+	manager.SetTags(opts.tags)
+	manager.SetProfile(opts.profile)
+	if opts.notify || cfg.IsNotifyEnabled() {
+		manager.AddNotifier(notify.New(notify.Desktop{}, cfg.GetNotifyDebounce()))
+	}
+	for _, hook := range cfg.Webhooks {
+		manager.AddNotifier(notify.NewWebhook(hook.URL, hook.Headers, hook.GetTimeout(), hook.GetRetries()))
+	}
 
 	pub := mdns.NewPublisher(cfg.IsMDNSEnabled())
+	pub.SetServiceType(cfg.GetMDNSServiceType())
 	manager.SetMDNSPublisher(pub)
 	if cfg.IsMDNSEnabled() && opts.verbose {
 		log.Printf("mDNS hostname publishing enabled - aliases will be accessible via <alias>.local")
@@ -376,22 +687,45 @@ func buildRuntimeDeps(opts runOptions, cfg *config.Config, stderr io.Writer) (*r
 	}, nil
 }
 
-// runShowVersion prints the version and exits 0.
-func runShowVersion(stdout io.Writer) int {
-	fprintf(stdout, "kportal version %s\n", appVersion)
+// versionInfo is the build metadata printed by -version -output json.
+type versionInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+}
+
+// runShowVersion prints the version, or the build metadata as JSON with
+// output "json", and exits 0.
+func runShowVersion(output string, stdout, stderr io.Writer) int {
+	if output != "json" {
+		fprintf(stdout, "kportal version %s\n", appVersion)
+		return 0
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(versionInfo{
+		Version:   appVersion,
+		GitCommit: gitCommit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+	}); err != nil {
+		fprintf(stderr, "Error encoding output: %v\n", err)
+		return 1
+	}
 	return 0
 }
 
-// runCheckUpdate checks for available updates and prints the result.
-func runCheckUpdate(stdout, _ io.Writer) int {
+// runCheckUpdate checks for available updates and prints the result. An
+// explicit -update runs even when background checks are disabled, and
+// always asks GitHub rather than the cache.
+func runCheckUpdate(opts runOptions, stdout, _ io.Writer) int {
 	fprintf(stdout, "kportal version %s\n", appVersion)
 	fprintln(stdout, "Checking for updates...")
 
-	checker := version.NewChecker(githubOwner, githubRepo, appVersion)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	update := checker.CheckForUpdate(ctx)
+	checker := version.NewCheckerWithOptions(githubOwner, githubRepo, appVersion, version.Options{Timeout: opts.updateTimeout})
+	update := checker.CheckForUpdate(context.Background())
 	if update == nil {
 		fprintln(stdout, "You are running the latest version.")
 		return 0
@@ -405,13 +739,40 @@ func runCheckUpdate(stdout, _ io.Writer) int {
 }
 
 // runConvert converts a kftray JSON file to a kportal YAML config.
-func runConvert(input, output string, stdout, stderr io.Writer) int {
-	if err := converter.ConvertKFTrayToKPortal(input, output); err != nil {
+func runConvert(input, output string, fixPorts bool, stdout, stderr io.Writer) int {
+	if err := converter.ConvertKFTrayToKPortal(input, output, fixPorts); err != nil {
 		fprintf(stderr, "Error converting configuration: %v\n", err)
 		return 1
 	}
 
-	contextMap, totalForwards, err := converter.GetConversionSummary(input)
+	contextMap, totalForwards, warnings, err := converter.GetConversionSummary(input, fixPorts)
+	if err != nil {
+		fprintf(stderr, "Warning: Could not generate summary: %v\n", err)
+		return 0
+	}
+	for _, warning := range warnings {
+		fprintf(stderr, "Warning: %s\n", warning)
+	}
+	fprintf(stdout, "Successfully converted %d forwards from %s to %s\n", totalForwards, input, output)
+	fprintf(stdout, "Generated configuration with:\n")
+	for ctx, namespaces := range contextMap {
+		fprintf(stdout, "  - Context '%s':\n", ctx)
+		for ns, count := range namespaces {
+			fprintf(stdout, "    - Namespace '%s': %d forwards\n", ns, count)
+		}
+	}
+	return 0
+}
+
+// runConvertKubectl converts a file of kubectl port-forward commands to a
+// kportal YAML config.
+func runConvertKubectl(input, output string, stdout, stderr io.Writer) int {
+	if err := converter.ConvertKubectlCommands(input, output); err != nil {
+		fprintf(stderr, "Error converting kubectl commands: %v\n", err)
+		return 1
+	}
+
+	contextMap, totalForwards, err := converter.GetKubectlConversionSummary(input)
 	if err != nil {
 		fprintf(stderr, "Warning: Could not generate summary: %v\n", err)
 		return 0
@@ -428,33 +789,75 @@ func runConvert(input, output string, stdout, stderr io.Writer) int {
 }
 
 // runHeadless runs the daemon-style mode: no UI, signal-driven SIGHUP reloads,
-// graceful shutdown on ctx.Done() (which is cancelled by SIGINT/SIGTERM).
-func runHeadless(ctx context.Context, opts runOptions, cfg *config.Config, deps *runtimeDeps, validator *config.Validator, stderr io.Writer) int {
+// SIGUSR1 status snapshots, and graceful shutdown on ctx.Done() (which is
+// cancelled by SIGINT/SIGTERM).
+func runHeadless(ctx context.Context, opts runOptions, cfg *config.Config, deps *runtimeDeps, validator *config.Validator, stdout, stderr io.Writer) int {
+	// Track forward status without rendering it, for SIGUSR1 snapshots
+	statusTable := ui.NewTableUI(opts.verbose)
+	deps.manager.SetStatusUI(statusTable)
+
+	// The metrics server is bound before any forward starts so a port
+	// conflict fails fast, and is stopped after the manager on shutdown.
+	if cfg.MetricsAddr != "" {
+		reg := metrics.NewRegistry()
+		deps.manager.SetMetrics(reg)
+		metricsServer := metrics.NewServer(cfg.MetricsAddr, reg)
+		if err := metricsServer.Start(); err != nil {
+			fprintf(stderr, "Error starting metrics server: %v\n", err)
+			return 1
+		}
+		defer shutdownMetricsServer(metricsServer, opts.verbose)
+		if opts.verbose {
+			log.Printf("Serving metrics on http://%s/metrics", metricsServer.Addr())
+		}
+	}
+
 	if startErr := deps.manager.Start(cfg); startErr != nil {
 		fprintf(stderr, "Error starting forwards: %v\n", startErr)
 		return 1
 	}
 
+	// The control socket is stopped when runHeadless returns, after the
+	// manager has shut down
+	if cfg.ControlSocket != "" {
+		controlServer := control.NewServer(cfg.ControlSocket, &headlessController{
+			manager:    deps.manager,
+			table:      statusTable,
+			validator:  validator,
+			configFile: opts.configFile,
+		})
+		if err := controlServer.Start(); err != nil {
+			fprintf(stderr, "Error starting control socket: %v\n", err)
+			shutdownManager(deps.manager, false)
+			return 1
+		}
+		defer controlServer.Stop()
+		if opts.verbose {
+			log.Printf("Accepting control commands on %s", cfg.ControlSocket)
+		}
+	}
+
 	// SIGHUP triggers reload only — separate from the ctx-driven shutdown.
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGHUP)
 	defer signal.Stop(sigChan)
 
-	watcher, watcherErr := config.NewWatcher(opts.configFile, func(newCfg *config.Config) error {
-		return deps.manager.Reload(newCfg)
-	}, opts.verbose)
-	watcherStarted := false
-	if watcherErr != nil {
-		if opts.verbose {
-			log.Printf("Warning: Failed to setup config watcher: %v", watcherErr)
-			log.Printf("Hot-reload will not be available")
-		}
-	} else {
-		watcher.Start()
-		watcherStarted = true
+	// SIGUSR1 dumps a status snapshot. statusChan stays nil where the
+	// platform has no such signal, so its case never fires.
+	var statusChan chan os.Signal
+	if len(statusSignals) > 0 {
+		statusChan = make(chan os.Signal, 1)
+		signal.Notify(statusChan, statusSignals...)
+		defer signal.Stop(statusChan)
+	}
+
+	watcher, watcherErr := startConfigWatcher(opts, deps.manager, nil, nil)
+	if watcherErr != nil && opts.verbose {
+		log.Printf("Warning: Failed to setup config watcher: %v", watcherErr)
+		log.Printf("Hot-reload will not be available")
 	}
 	defer func() {
-		if watcherStarted {
+		if watcher != nil {
 			watcher.Stop()
 		}
 	}()
@@ -463,35 +866,131 @@ func runHeadless(ctx context.Context, opts runOptions, cfg *config.Config, deps
 		log.Printf("Headless mode started. Press Ctrl+C to stop")
 	}
 
+	// Log once every enabled forward has been active; with -wait-ready,
+	// exit non-zero if that takes too long. A -detach child tells the
+	// waiting parent through readyPipe.
+	readyChan := waitReady(ctx, deps.manager, opts.waitReady)
+	readyPipe := readinessPipe()
+	if readyPipe != nil {
+		defer func() { _ = readyPipe.Close() }()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
-			return shutdownManager(ctx, deps.manager, opts.verbose)
-		case <-sigChan:
 			if opts.verbose {
-				log.Printf("Received SIGHUP, reloading configuration...")
+				log.Printf("Received shutdown signal, stopping...")
 			}
-			newCfg, loadErr := config.LoadConfig(opts.configFile)
-			if loadErr != nil {
-				if opts.verbose {
-					log.Printf("Failed to reload config: %v", loadErr)
+			shutdownManager(deps.manager, opts.verbose)
+			return 0
+		case r := <-readyChan:
+			readyChan = nil
+			fields := map[string]any{"active": r.active, "total": r.total}
+			if r.err == nil {
+				logger.Info("All forwards active", fields)
+				if opts.exitWhenReady {
+					shutdownManager(deps.manager, opts.verbose)
+					return 0
 				}
-				continue
-			}
-			if errs := validator.ValidateConfig(newCfg); len(errs) > 0 {
-				if opts.verbose {
-					log.Printf("Config validation failed:")
-					log.Print(config.FormatValidationErrors(errs))
+				if readyPipe != nil {
+					fprintln(readyPipe, readyMessage)
 				}
 				continue
 			}
-			if reloadErr := deps.manager.Reload(newCfg); reloadErr != nil {
-				if opts.verbose {
-					log.Printf("Failed to reload: %v", reloadErr)
-				}
+			if ctx.Err() != nil {
+				continue // shutting down, handled above
 			}
+			fields["wait_ready"] = opts.waitReady.String()
+			logger.Error(fmt.Sprintf("%d of %d forwards active", r.active, r.total), fields)
+			fprintf(stderr, "Error: only %d of %d forwards active after %v\n", r.active, r.total, opts.waitReady)
+			shutdownManager(deps.manager, opts.verbose)
+			return 1
+		case <-statusChan:
+			if err := writeStatusSnapshot(statusTable, opts.statusFile, stdout); err != nil {
+				logger.Error("Failed to write status snapshot", map[string]any{
+					"file":  opts.statusFile,
+					"error": err.Error(),
+				})
+			}
+		case <-sigChan:
+			if opts.verbose {
+				log.Printf("Received SIGHUP, reloading configuration...")
+			}
+			if err := reloadConfig(opts.configFile, validator, deps.manager); err != nil && opts.verbose {
+				log.Print(err)
+			}
+		}
+	}
+}
+
+// readiness is the outcome of waiting for the forwards to become active
+type readiness struct {
+	err    error // context.DeadlineExceeded once -wait-ready passed
+	active int
+	total  int
+}
+
+// waitReady waits in the background for every enabled forward to have been
+// active, giving up after timeout unless it is 0, and delivers the outcome
+// on the returned channel.
+func waitReady(ctx context.Context, manager *forward.Manager, timeout time.Duration) <-chan readiness {
+	result := make(chan readiness, 1)
+	go func() {
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
 		}
+		active, total, err := manager.WaitReady(ctx)
+		result <- readiness{err: err, active: active, total: total}
+	}()
+	return result
+}
+
+// startConfigWatcher hot-reloads the configuration into manager whenever it
+// changes on disk, passing each applied config to onReload and reporting each
+// outcome to onResult, if set. Returns a nil watcher when -watch=false or the
+// watcher cannot be set up.
+func startConfigWatcher(opts runOptions, manager *forward.Manager, onReload func(*config.Config), onResult config.ReloadResultCallback) (*config.Watcher, error) {
+	if !opts.watch {
+		return nil, nil
 	}
+	watcher, err := config.NewWatcher(opts.configFile, func(newCfg *config.Config) error {
+		if err := manager.Reload(newCfg); err != nil {
+			return err
+		}
+		if onReload != nil {
+			onReload(newCfg)
+		}
+		return nil
+	}, opts.verbose)
+	if err != nil {
+		return nil, err
+	}
+	if onResult != nil {
+		watcher.SetResultCallback(onResult)
+	}
+	watcher.Start()
+	return watcher, nil
+}
+
+// reloadConfig loads and validates the config file and applies it to the
+// running forwards. Shared by SIGHUP and the control socket's reload command.
+func reloadConfig(configFile string, validator *config.Validator, manager *forward.Manager) error {
+	if configFile == stdinConfigFile {
+		return errors.New("cannot reload: the config was read from stdin")
+	}
+	newCfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to reload config: %w", err)
+	}
+	if errs := validator.ValidateConfig(newCfg); len(errs) > 0 {
+		return fmt.Errorf("config validation failed:\n%s", config.FormatValidationErrors(errs))
+	}
+	if err := manager.Reload(newCfg); err != nil {
+		return fmt.Errorf("failed to reload: %w", err)
+	}
+	return nil
 }
 
 // runVerboseTable runs the simple table UI with periodic redraws and SIGHUP
@@ -500,16 +999,10 @@ func runVerboseTable(ctx context.Context, opts runOptions, cfg *config.Config, d
 	tableUI := ui.NewTableUI(opts.verbose)
 	deps.manager.SetStatusUI(tableUI)
 
-	// Background update check (best effort).
-	go func() {
-		checker := version.NewChecker(githubOwner, githubRepo, appVersion)
-		uctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-		if update := checker.CheckForUpdate(uctx); update != nil {
-			log.Printf("Update available: v%s (current: v%s) - %s",
-				update.LatestVersion, update.CurrentVersion, update.ReleaseURL)
-		}
-	}()
+	startUpdateCheck(ctx, opts, func(update *version.UpdateInfo) {
+		log.Printf("Update available: v%s (current: v%s) - %s",
+			update.LatestVersion, update.CurrentVersion, update.ReleaseURL)
+	})
 
 	if startErr := deps.manager.Start(cfg); startErr != nil {
 		fprintf(stderr, "Error starting forwards: %v\n", startErr)
@@ -538,19 +1031,13 @@ func runVerboseTable(ctx context.Context, opts runOptions, cfg *config.Config, d
 		}
 	}()
 
-	watcher, watchErr := config.NewWatcher(opts.configFile, func(newCfg *config.Config) error {
-		return deps.manager.Reload(newCfg)
-	}, opts.verbose)
-	watcherActive := false
+	watcher, watchErr := startConfigWatcher(opts, deps.manager, nil, nil)
 	if watchErr != nil {
 		log.Printf("Warning: Failed to setup config watcher: %v", watchErr)
 		log.Printf("Hot-reload will not be available")
-	} else {
-		watcher.Start()
-		watcherActive = true
 	}
 	defer func() {
-		if watcherActive {
+		if watcher != nil {
 			watcher.Stop()
 		}
 	}()
@@ -561,8 +1048,16 @@ func runVerboseTable(ctx context.Context, opts runOptions, cfg *config.Config, d
 		select {
 		case <-ctx.Done():
 			<-tickerDone
-			return shutdownManager(ctx, deps.manager, opts.verbose)
+			if opts.verbose {
+				log.Printf("Received shutdown signal, stopping...")
+			}
+			shutdownManager(deps.manager, opts.verbose)
+			return 0
 		case <-sigChan:
+			if opts.configFile == stdinConfigFile {
+				log.Printf("Received SIGHUP, but the config was read from stdin and cannot be reloaded")
+				continue
+			}
 			log.Printf("Received SIGHUP, reloading configuration...")
 			newCfg, loadErr := config.LoadConfig(opts.configFile)
 			if loadErr != nil {
@@ -581,8 +1076,25 @@ func runVerboseTable(ctx context.Context, opts runOptions, cfg *config.Config, d
 	}
 }
 
+// resolveTheme picks the UI theme: the -theme flag wins over the config's
+// theme key. Colors are dropped when -no-color or NO_COLOR is in effect.
+func resolveTheme(opts runOptions, cfg *config.Config) (ui.Theme, error) {
+	name := opts.theme
+	if name == "" && cfg != nil {
+		name = cfg.Theme
+	}
+	return ui.SelectTheme(name, opts.noColor)
+}
+
 // runInteractive runs the bubbletea TUI. Cannot be exercised in non-TTY tests.
 func runInteractive(ctx context.Context, opts runOptions, cfg *config.Config, deps *runtimeDeps, stderr io.Writer) int {
+	theme, err := resolveTheme(opts, cfg)
+	if err != nil {
+		fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	ui.SetTheme(theme)
+
 	bubbleTeaUI := ui.NewBubbleTeaUI(func(id string, enable bool) {
 		if enable {
 			_ = deps.manager.EnableForward(id)
@@ -590,17 +1102,25 @@ func runInteractive(ctx context.Context, opts runOptions, cfg *config.Config, de
 			_ = deps.manager.DisableForward(id)
 		}
 	}, appVersion)
-	bubbleTeaUI.SetWizardDependencies(deps.discovery, deps.mutator, opts.configFile)
+	if opts.readOnly || cfg.ReadOnly {
+		// Without a mutator the wizards cannot write even if reached
+		bubbleTeaUI.SetWizardDependencies(deps.discovery, nil, opts.configFile)
+		bubbleTeaUI.SetReadOnly(true)
+	} else {
+		bubbleTeaUI.SetWizardDependencies(deps.discovery, deps.mutator, opts.configFile)
+	}
 	bubbleTeaUI.SetHTTPLogSubscriber(makeHTTPLogSubscriber(deps.manager))
-
-	go func() {
-		checker := version.NewChecker(githubOwner, githubRepo, appVersion)
-		uctx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-		if update := checker.CheckForUpdate(uctx); update != nil {
-			bubbleTeaUI.SetUpdateAvailable(update.LatestVersion, update.ReleaseURL)
-		}
-	}()
+	bubbleTeaUI.SetTrafficProvider(makeTrafficProvider(deps.manager))
+	bubbleTeaUI.SetActivityProvider(deps.manager.RequestHistory)
+	bubbleTeaUI.SetTraceProvider(makeTraceProvider(deps.manager))
+	applyUIConfig(bubbleTeaUI, cfg)
+	bubbleTeaUI.SetMDNSEnabled(cfg.IsMDNSEnabled())
+	// Read at startup only, so a reload keeps the columns toggled since
+	bubbleTeaUI.SetHiddenColumns(cfg.HiddenColumns)
+
+	startUpdateCheck(ctx, opts, func(update *version.UpdateInfo) {
+		bubbleTeaUI.SetUpdateAvailable(update.LatestVersion, update.ReleaseURL)
+	})
 
 	deps.manager.SetStatusUI(bubbleTeaUI)
 
@@ -609,17 +1129,14 @@ func runInteractive(ctx context.Context, opts runOptions, cfg *config.Config, de
 		return 1
 	}
 
-	var watcher *config.Watcher
-	watcher, err := config.NewWatcher(opts.configFile, func(newCfg *config.Config) error {
-		return deps.manager.Reload(newCfg)
-	}, opts.verbose)
-	if err == nil {
-		watcher.Start()
-	}
+	// Reload outcomes are shown as a transient banner in the main view
+	watcher, _ := startConfigWatcher(opts, deps.manager, func(newCfg *config.Config) {
+		applyUIConfig(bubbleTeaUI, newCfg)
+	}, bubbleTeaUI.NotifyConfigReload)
 
 	cleanup := func() {
 		bubbleTeaUI.Stop()
-		deps.manager.Stop()
+		shutdownManager(deps.manager, false)
 		if watcher != nil {
 			watcher.Stop()
 		}
@@ -647,6 +1164,55 @@ func runInteractive(ctx context.Context, opts runOptions, cfg *config.Config, de
 	return 0
 }
 
+// makeTrafficProvider builds the traffic counter lookup used by the
+// bubbletea UI's traffic columns.
+func makeTrafficProvider(manager *forward.Manager) ui.TrafficProvider {
+	return func(forwardID string) (ui.TrafficSample, bool) {
+		stats, ok := manager.TrafficStats(forwardID)
+		return ui.TrafficSample{
+			Sent:        stats.BytesSent,
+			Received:    stats.BytesReceived,
+			SendRate:    stats.SendRate,
+			ReceiveRate: stats.ReceiveRate,
+			Tunnels:     stats.Tunnels,
+			Streams:     stats.Streams,
+			SetupTime:   stats.SetupTime,
+			Connections: stats.Connections,
+		}, ok
+	}
+}
+
+// applyUIConfig passes the HTTP log view settings and the forwards' order
+// to the UI, at startup and on every reload.
+func applyUIConfig(bubbleTeaUI *ui.BubbleTeaUI, cfg *config.Config) {
+	bubbleTeaUI.SetHTTPLogMaxEntries(cfg.GetHTTPLogMaxEntries())
+	bubbleTeaUI.SetHTTPLogLatencyThresholds(cfg.GetHTTPLogSlowLatency(), cfg.GetHTTPLogVerySlowLatency())
+	bubbleTeaUI.SetForwardOrder(cfg.GetAllForwards())
+}
+
+// makeTraceProvider adapts the manager's byte traces to the UI's trace panel.
+func makeTraceProvider(manager *forward.Manager) ui.TraceProvider {
+	return func(forwardID string) ([]ui.TraceConn, bool) {
+		traces, ok := manager.Trace(forwardID)
+		conns := make([]ui.TraceConn, 0, len(traces))
+		for _, trace := range traces {
+			chunks := make([]ui.TraceChunk, 0, len(trace.Chunks))
+			for _, chunk := range trace.Chunks {
+				chunks = append(chunks, ui.TraceChunk{Data: chunk.Data, Offset: chunk.Offset, Sent: chunk.Sent})
+			}
+			conns = append(conns, ui.TraceConn{
+				Opened:   trace.Opened,
+				Chunks:   chunks,
+				Sent:     trace.Sent,
+				Received: trace.Received,
+				ID:       trace.ID,
+				Closed:   trace.Closed,
+			})
+		}
+		return conns, ok
+	}
+}
+
 // makeHTTPLogSubscriber builds the subscriber callback used by the bubbletea UI.
 func makeHTTPLogSubscriber(manager *forward.Manager) ui.HTTPLogSubscriber {
 	return func(forwardID string, callback func(entry ui.HTTPLogEntry)) func() {
@@ -674,15 +1240,19 @@ func makeHTTPLogSubscriber(manager *forward.Manager) ui.HTTPLogSubscriber {
 
 		proxyLogger.AddCallback(func(entry httplog.Entry) {
 			uiEntry := ui.HTTPLogEntry{
-				RequestID:  entry.RequestID,
-				Timestamp:  entry.Timestamp.Format("15:04:05"),
-				Direction:  entry.Direction,
-				Method:     entry.Method,
-				Path:       entry.Path,
-				StatusCode: entry.StatusCode,
-				LatencyMs:  entry.LatencyMs,
-				BodySize:   entry.BodySize,
-				Error:      entry.Error,
+				StartedAt:   entry.Timestamp,
+				RequestID:   entry.RequestID,
+				Timestamp:   entry.Timestamp.Format("15:04:05"),
+				Direction:   entry.Direction,
+				Method:      entry.Method,
+				Path:        entry.Path,
+				StatusCode:  entry.StatusCode,
+				LatencyMs:   entry.LatencyMs,
+				BodySize:    entry.BodySize,
+				Error:       entry.Error,
+				GRPCStatus:  entry.GRPCStatus,
+				GRPCMessage: entry.GRPCMessage,
+				GRPC:        entry.GRPC,
 			}
 			switch entry.Direction {
 			case "request":
@@ -701,27 +1271,29 @@ func makeHTTPLogSubscriber(manager *forward.Manager) ui.HTTPLogSubscriber {
 	}
 }
 
-// shutdownManager stops the forward manager with a 5s timeout, returning 0 on
-// success or after timeout (we always exit cleanly from a shutdown signal).
-func shutdownManager(ctx context.Context, manager *forward.Manager, verbose bool) int {
-	if verbose {
-		log.Printf("Received shutdown signal, stopping...")
-	}
-	shutdownDone := make(chan struct{})
-	go func() {
-		manager.Stop()
-		close(shutdownDone)
-	}()
-	select {
-	case <-shutdownDone:
-		if verbose {
-			log.Printf("Graceful shutdown complete")
-		}
-	case <-time.After(5 * time.Second):
+// shutdownManager stops the forward manager, giving its workers up to
+// shutdownTimeout. On timeout the process exits anyway; kportal always exits
+// cleanly from a shutdown signal.
+func shutdownManager(manager *forward.Manager, verbose bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := manager.Shutdown(ctx); err != nil {
 		if verbose {
 			log.Printf("Shutdown timed out, forcing exit...")
 		}
+		return
+	}
+	if verbose {
+		log.Printf("Graceful shutdown complete")
+	}
+}
+
+// shutdownMetricsServer stops the headless metrics server, giving in-flight
+// scrapes up to 5s to finish.
+func shutdownMetricsServer(server *metrics.Server, verbose bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil && verbose {
+		log.Printf("Metrics server shutdown: %v", err)
 	}
-	_ = ctx // ctx may already be done; we still wait up to 5s for graceful stop.
-	return 0
 }
@@ -4,16 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/lukaszraczylo/kportal/internal/config"
 	"github.com/lukaszraczylo/kportal/internal/forward"
+	"github.com/lukaszraczylo/kportal/internal/logger"
 	"github.com/lukaszraczylo/kportal/internal/ui"
 	"github.com/lukaszraczylo/kportal/internal/version"
 	"github.com/stretchr/testify/assert"
@@ -91,6 +97,99 @@ func TestRun_CheckValidConfig(t *testing.T) {
 	assert.Contains(t, stdout.String(), "Configuration is valid")
 }
 
+// TestRun_CheckWarningsOnly verifies warnings are reported by -check without
+// failing it.
+func TestRun_CheckWarningsOnly(t *testing.T) {
+	cfgPath := writeYAML(t, "w.yaml", "contexts: []\nhttpLogMaxEntries: 1000000\n")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-check", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stderr.String(), "Configuration Validation Warnings")
+	assert.NotContains(t, stderr.String(), "Configuration Validation Errors")
+	assert.Contains(t, stdout.String(), "Configuration is valid")
+}
+
+// TestRun_CheckJSON verifies -check -output json prints every result as a
+// JSON array and exits 1 only on errors.
+func TestRun_CheckJSON(t *testing.T) {
+	t.Run("clean", func(t *testing.T) {
+		cfgPath := writeYAML(t, "v.yaml", "contexts: []\n")
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"-check", "-output", "json", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(t, 0, code)
+		assert.JSONEq(t, "[]", stdout.String())
+	})
+
+	t.Run("warnings only", func(t *testing.T) {
+		cfgPath := writeYAML(t, "w.yaml", "contexts: []\nhttpLogMaxEntries: 1000000\n")
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"-check", "-output", "json", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+		assert.Equal(t, 0, code)
+
+		var entries []checkEntry
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+		require.Len(t, entries, 1)
+		assert.Equal(t, "httpLogMaxEntries", entries[0].Field)
+		assert.Equal(t, "warning", entries[0].Severity)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		bad := "contexts:\n  - name: test\n    namespaces:\n      - name: default\n        forwards:\n          - resource: service/api\n            port: 0\n            localPort: 8080\n"
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"-check", "-output", "json", "-c", "-"}, strings.NewReader(bad), &stdout, &stderr)
+		assert.Equal(t, 1, code)
+
+		var entries []checkEntry
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &entries))
+		require.NotEmpty(t, entries)
+		assert.Equal(t, "error", entries[0].Severity)
+		assert.Empty(t, stderr.String())
+	})
+}
+
+// TestRun_OutputFlag verifies -output accepts only text or json, and json
+// only with -check.
+func TestRun_OutputFlag(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-check", "-output", "yaml"}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), `unknown output format "yaml"`)
+
+	stderr.Reset()
+	code = run(context.Background(), []string{"-output", "json"}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "-output json requires -check or -version")
+}
+
+// TestRun_CheckTagsMatchNothing verifies a -tags value no forward carries is
+// reported.
+func TestRun_CheckTagsMatchNothing(t *testing.T) {
+	cfgPath := writeYAML(t, "t.yaml", "contexts:\n  - name: dev\n    namespaces:\n      - name: default\n        forwards:\n          - resource: service/api\n            port: 80\n            localPort: 8080\n            tags: [payments]\n")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-check", "-tags", "billing", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stderr.String(), "Warning: no forward is tagged billing")
+
+	stderr.Reset()
+	code = run(context.Background(), []string{"-check", "-tags", "payments", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.NotContains(t, stderr.String(), "no forward is tagged")
+}
+
+// TestRun_UnknownProfile verifies a -profile missing from the config is
+// rejected with the defined ones listed.
+func TestRun_UnknownProfile(t *testing.T) {
+	cfgPath := writeYAML(t, "p.yaml", "profiles:\n  frontend: []\n  data: []\ncontexts: []\n")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-check", "-profile", "backend", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), `profile "backend" is not defined in the config (available: data, frontend)`)
+
+	stderr.Reset()
+	code = run(context.Background(), []string{"-check", "-profile", "data", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+}
+
 // TestRun_CheckMissingConfig_DeclinePrompt verifies that a missing config with
 // declined prompt (EOF stdin) exits 0 — original behaviour.
 func TestRun_CheckMissingConfig_DeclinePrompt(t *testing.T) {
@@ -144,6 +243,47 @@ func TestRun_CheckInvalidConfigContent(t *testing.T) {
 	assert.NotEmpty(t, stderr.String())
 }
 
+// TestRun_CheckConfigFromStdin verifies -c - validates the config piped on
+// stdin.
+func TestRun_CheckConfigFromStdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-check", "-c", "-"}, strings.NewReader("contexts: []\n"), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout.String(), "Configuration is valid")
+}
+
+// TestRun_ConfigFromStdinNeedsHeadless verifies -c - refuses the interactive
+// UI, which needs stdin for the terminal.
+func TestRun_ConfigFromStdinNeedsHeadless(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-c", "-"}, strings.NewReader("contexts: []\n"), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "-c - needs -headless or -v")
+}
+
+// TestRun_KubeconfigFlagMissingFile verifies a -kubeconfig that does not
+// exist is rejected before the config is loaded.
+func TestRun_KubeconfigFlagMissingFile(t *testing.T) {
+	cfgPath := writeYAML(t, "v.yaml", "contexts: []\n")
+	missing := filepath.Join(t.TempDir(), "kubeconfig")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-check", "-c", cfgPath, "-kubeconfig", missing}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "kubeconfig file '"+missing+"' does not exist")
+	assert.NotContains(t, stdout.String(), "Configuration is valid")
+}
+
+// TestRun_KubeconfigConfigKeyMissingFile verifies the config's kubeconfig
+// key is validated like the flag.
+func TestRun_KubeconfigConfigKeyMissingFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "kubeconfig")
+	cfgPath := writeYAML(t, "v.yaml", "kubeconfig: "+missing+"\ncontexts: []\n")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-check", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "does not exist")
+}
+
 // TestRun_ConvertFlag_HappyPath verifies -convert produces a YAML file from a
 // minimal kftray JSON input.
 func TestRun_ConvertFlag_HappyPath(t *testing.T) {
@@ -239,6 +379,122 @@ func TestRun_HeadlessVerbose(t *testing.T) {
 	}
 }
 
+// TestRun_HeadlessMetrics verifies metricsAddr serves /metrics while headless
+// mode runs and the endpoint goes away on the ctx-driven shutdown.
+func TestRun_HeadlessMetrics(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	cfgPath := writeYAML(t, "m.yaml", "metricsAddr: "+addr+"\ncontexts: []\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	done := make(chan int, 1)
+	go func() {
+		done <- run(ctx, []string{"-headless", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	}()
+
+	var body string
+	require.Eventually(t, func() bool {
+		resp, getErr := http.Get("http://" + addr + "/metrics")
+		if getErr != nil {
+			return false
+		}
+		defer func() { _ = resp.Body.Close() }()
+		raw, _ := io.ReadAll(resp.Body)
+		body = string(raw)
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Contains(t, body, "# TYPE kportal_forward_up gauge")
+
+	cancel()
+	select {
+	case code := <-done:
+		assert.Equal(t, 0, code)
+	case <-time.After(8 * time.Second):
+		t.Fatal("headless mode did not exit within 8 seconds of ctx cancellation")
+	}
+
+	_, err = http.Get("http://" + addr + "/metrics")
+	assert.Error(t, err, "metrics server should be stopped after shutdown")
+}
+
+// TestRun_DetachRequiresWaitReady verifies -detach is rejected without
+// -wait-ready, as nothing would tell it when to return.
+func TestRun_DetachRequiresWaitReady(t *testing.T) {
+	cfgPath := writeYAML(t, "v.yaml", "contexts: []\n")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-detach", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "-detach requires -wait-ready")
+}
+
+// TestRun_WaitReadyExitsWhenReady verifies -wait-ready without -headless
+// exits 0 once every enabled forward is active, here none.
+func TestRun_WaitReadyExitsWhenReady(t *testing.T) {
+	cfgPath := writeYAML(t, "v.yaml", "contexts: []\n")
+	var stdout, stderr bytes.Buffer
+	done := make(chan int, 1)
+	go func() {
+		done <- run(context.Background(), []string{"-wait-ready", "5s", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	}()
+
+	select {
+	case code := <-done:
+		assert.Equal(t, 0, code)
+	case <-time.After(8 * time.Second):
+		t.Fatal("-wait-ready did not exit once ready")
+	}
+}
+
+// TestRun_HeadlessWaitReadyTimeout verifies headless mode exits non-zero
+// when a forward does not become active within -wait-ready.
+func TestRun_HeadlessWaitReadyTimeout(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+	cfgPath := writeYAML(t, "w.yaml", fmt.Sprintf(`contexts:
+  - name: kportal-missing-context
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/app
+            port: 80
+            localPort: %d
+`, port))
+
+	var stdout, stderr bytes.Buffer
+	done := make(chan int, 1)
+	go func() {
+		done <- run(context.Background(), []string{"-headless", "-wait-ready", "200ms", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	}()
+
+	select {
+	case code := <-done:
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr.String(), "only 0 of 1 forwards active after 200ms")
+	case <-time.After(8 * time.Second):
+		t.Fatal("headless mode did not give up after -wait-ready")
+	}
+}
+
+// TestRun_HeadlessMetricsPortInUse verifies a taken metricsAddr fails startup.
+func TestRun_HeadlessMetricsPortInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	cfgPath := writeYAML(t, "m.yaml", "metricsAddr: "+ln.Addr().String()+"\ncontexts: []\n")
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-headless", "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "Error starting metrics server")
+}
+
 // TestRun_VerboseTable exercises the verbose (non-headless) table-UI path. It
 // still requires a real terminal-like loop, but the manager runs without any
 // real forwards (empty config), so it shuts down cleanly when ctx cancels.
@@ -304,6 +560,42 @@ func TestRun_HeadlessSIGHUPReload(t *testing.T) {
 	}
 }
 
+// TestRun_HeadlessSIGUSR1Status verifies SIGUSR1 writes a JSON status
+// snapshot to -status-file while headless mode keeps running.
+func TestRun_HeadlessSIGUSR1Status(t *testing.T) {
+	cfgPath := writeYAML(t, "v.yaml", "contexts: []\n")
+	statusPath := filepath.Join(t.TempDir(), "status.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	done := make(chan int, 1)
+	go func() {
+		done <- run(ctx, []string{"-headless", "-status-file", statusPath, "-c", cfgPath}, strings.NewReader(""), &stdout, &stderr)
+	}()
+
+	// Wait for the headless loop to be running before sending SIGUSR1.
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+
+	var snapshot statusSnapshot
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(statusPath)
+		return err == nil && json.Unmarshal(data, &snapshot) == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.False(t, snapshot.Time.IsZero())
+	assert.Empty(t, snapshot.Forwards)
+
+	cancel()
+	select {
+	case code := <-done:
+		assert.Equal(t, 0, code)
+	case <-time.After(8 * time.Second):
+		t.Fatal("headless SIGUSR1 test did not exit within 8s")
+	}
+}
+
 // TestRun_VerboseTable_SIGHUPReload exercises the SIGHUP reload branch in the
 // verbose-table loop.
 func TestRun_VerboseTable_SIGHUPReload(t *testing.T) {
@@ -378,11 +670,28 @@ func TestRun_HeadlessJSONLogFormat(t *testing.T) {
 func TestRunShowVersion(t *testing.T) {
 	withAppVersion(t, "1.2.3")
 	var stdout bytes.Buffer
-	code := runShowVersion(&stdout)
+	code := runShowVersion("text", &stdout, io.Discard)
 	assert.Equal(t, 0, code)
 	assert.Equal(t, "kportal version 1.2.3\n", stdout.String())
 }
 
+func TestRunShowVersion_JSON(t *testing.T) {
+	withAppVersion(t, "1.2.3")
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-version", "-output", "json"}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stderr.String())
+
+	var info versionInfo
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &info))
+	assert.Equal(t, versionInfo{
+		Version:   "1.2.3",
+		GitCommit: gitCommit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+	}, info)
+}
+
 // ---- runCheckUpdate (via httptest + custom checker plumbing) ----
 
 // TestRunCheckUpdate_LatestRelease verifies the function happy-path output.
@@ -392,7 +701,7 @@ func TestRunShowVersion(t *testing.T) {
 func TestRunCheckUpdate_PrintsHeader(t *testing.T) {
 	withAppVersion(t, "0.0.0")
 	var stdout, stderr bytes.Buffer
-	code := runCheckUpdate(&stdout, &stderr)
+	code := runCheckUpdate(runOptions{updateTimeout: time.Second}, &stdout, &stderr)
 	assert.Equal(t, 0, code)
 	assert.Contains(t, stdout.String(), "kportal version 0.0.0")
 	assert.Contains(t, stdout.String(), "Checking for updates")
@@ -439,17 +748,65 @@ func TestRunConvert_HappyPath(t *testing.T) {
 ]`), 0o600))
 
 	var stdout, stderr bytes.Buffer
-	code := runConvert(in, out, &stdout, &stderr)
+	code := runConvert(in, out, false, &stdout, &stderr)
 	assert.Equal(t, 0, code)
 	assert.Contains(t, stdout.String(), "Successfully converted")
 	assert.FileExists(t, out)
 }
 
+func TestRunConvert_ReportsSkippedEntries(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "k.json")
+	out := filepath.Join(dir, "k.yaml")
+
+	require.NoError(t, os.WriteFile(in, []byte(`[
+  {"context": "ctx", "namespace": "default", "service": "api", "workload_type": "deployment", "local_port": 8080, "remote_port": 80, "protocol": "tcp"},
+  {"context": "ctx", "namespace": "default", "service": "redis", "workload_type": "proxy", "local_port": 6379, "remote_port": 6379, "protocol": "tcp"}
+]`), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	code := runConvert(in, out, false, &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout.String(), "Successfully converted 1 forwards")
+	assert.Contains(t, stderr.String(), "Warning: skipped kftray entry 2 (redis in ctx/default): proxy workloads have no kportal equivalent")
+}
+
+func TestRunConvertKubectl_HappyPath(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "forwards.sh")
+	out := filepath.Join(dir, "k.yaml")
+
+	require.NoError(t, os.WriteFile(in, []byte(`#!/bin/sh
+kubectl --context ctx port-forward svc/api 8080:80 -n web &
+kubectl --context ctx port-forward deploy/worker 9090 -n web &
+`), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	code := runConvertKubectl(in, out, &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout.String(), "Successfully converted 2 forwards")
+	assert.Contains(t, stdout.String(), "Namespace 'web': 2 forwards")
+	assert.FileExists(t, out)
+}
+
+func TestRunConvertKubectl_InvalidInput(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "forwards.sh")
+	out := filepath.Join(dir, "k.yaml")
+	require.NoError(t, os.WriteFile(in, []byte("kubectl port-forward svc/api\n"), 0o600))
+
+	var stdout, stderr bytes.Buffer
+	code := runConvertKubectl(in, out, &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "Error converting kubectl commands")
+	assert.NoFileExists(t, out)
+}
+
 func TestRunConvert_MissingInput(t *testing.T) {
 	dir := t.TempDir()
 	out := filepath.Join(dir, "k.yaml")
 	var stdout, stderr bytes.Buffer
-	code := runConvert("/no/such/file.json", out, &stdout, &stderr)
+	code := runConvert("/no/such/file.json", out, false, &stdout, &stderr)
 	assert.Equal(t, 1, code)
 	assert.Contains(t, stderr.String(), "Error converting")
 }
@@ -505,6 +862,30 @@ func TestBuildRuntimeDeps_VerboseMDNS(t *testing.T) {
 	require.NotNil(t, deps)
 }
 
+func TestStartConfigWatcher_Disabled(t *testing.T) {
+	cfgPath := writeYAML(t, "v.yaml", "contexts: []\n")
+	watcher, err := startConfigWatcher(runOptions{configFile: cfgPath, watch: false}, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, watcher)
+}
+
+func TestResolveTheme(t *testing.T) {
+	theme, err := resolveTheme(runOptions{}, &config.Config{Theme: "light"})
+	require.NoError(t, err)
+	assert.Equal(t, "light", theme.Name, "config theme applies without the flag")
+
+	theme, err = resolveTheme(runOptions{theme: "dark"}, &config.Config{Theme: "light"})
+	require.NoError(t, err)
+	assert.Equal(t, "dark", theme.Name, "flag overrides the config")
+
+	_, err = resolveTheme(runOptions{theme: "neon"}, nil)
+	assert.Error(t, err)
+
+	theme, err = resolveTheme(runOptions{theme: "light", noColor: true}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, theme.Primary, "-no-color drops every color")
+}
+
 // ---- resolveConfigPath ----
 
 func TestResolveConfigPath_Empty(t *testing.T) {
@@ -514,6 +895,13 @@ func TestResolveConfigPath_Empty(t *testing.T) {
 	assert.Empty(t, path)
 }
 
+func TestResolveConfigPath_Stdin(t *testing.T) {
+	var stderr bytes.Buffer
+	path, ok := resolveConfigPath("-", &stderr)
+	assert.True(t, ok)
+	assert.Equal(t, "-", path)
+}
+
 func TestResolveConfigPath_SystemDirs(t *testing.T) {
 	cases := []string{"/etc/foo.yaml", "/sys/x", "/proc/y", "/dev/z"}
 	for _, p := range cases {
@@ -544,12 +932,19 @@ func TestParseFlags_Defaults(t *testing.T) {
 	assert.Equal(t, defaultConfigFile, opts.configFile)
 	assert.False(t, opts.verbose)
 	assert.False(t, opts.headless)
+	assert.True(t, opts.watch, "hot-reload is on unless -watch=false")
+	assert.False(t, opts.noColor)
+	assert.False(t, opts.noUpdateCheck)
+	assert.False(t, opts.httpLog)
+	assert.Equal(t, version.DefaultTimeout, opts.updateTimeout)
+	assert.Equal(t, version.DefaultCacheTTL, opts.updateInterval)
 	assert.Equal(t, "text", opts.logFormat)
+	assert.Equal(t, "text", opts.output)
 }
 
 func TestParseFlags_AllSet(t *testing.T) {
 	var stderr bytes.Buffer
-	args := []string{"-c", "/tmp/x.yaml", "-v", "-headless", "-log-format", "json", "-check", "-version", "-update", "-convert", "in.json", "-convert-output", "out.yaml"}
+	args := []string{"-c", "/tmp/x.yaml", "-v", "-headless", "-log-format", "json", "-check", "-version", "-update", "-convert", "in.json", "-convert-kubectl", "fw.sh", "-convert-output", "out.yaml", "-convert-fix-ports", "-watch=false", "-theme", "light", "-no-color", "-no-update-check", "-http-log", "-update-timeout", "2s", "-update-interval", "1h", "-log-level", "warn", "-log-file", "/tmp/kportal.log", "-audit-log", "/tmp/audit.log", "-kubeconfig", "/tmp/kubeconfig", "-in-cluster", "-wait-ready", "90s", "-detach", "-output", "json", "-tags", "payments,backend", "-profile", "frontend"}
 	opts, code, handled := parseFlags(args, &stderr)
 	assert.False(t, handled)
 	assert.Equal(t, 0, code)
@@ -561,7 +956,40 @@ func TestParseFlags_AllSet(t *testing.T) {
 	assert.True(t, opts.showVersion)
 	assert.True(t, opts.checkUpdate)
 	assert.Equal(t, "in.json", opts.convertInput)
+	assert.Equal(t, "fw.sh", opts.convertKubectl)
 	assert.Equal(t, "out.yaml", opts.convertOutput)
+	assert.True(t, opts.fixPorts)
+	assert.False(t, opts.watch)
+	assert.Equal(t, "light", opts.theme)
+	assert.True(t, opts.noColor)
+	assert.True(t, opts.noUpdateCheck)
+	assert.True(t, opts.httpLog)
+	assert.Equal(t, 2*time.Second, opts.updateTimeout)
+	assert.Equal(t, time.Hour, opts.updateInterval)
+	assert.Equal(t, "warn", opts.logLevel)
+	assert.Equal(t, "/tmp/kportal.log", opts.logFile)
+	assert.Equal(t, "/tmp/audit.log", opts.auditLog)
+	assert.Equal(t, "/tmp/kubeconfig", opts.kubeconfig)
+	assert.True(t, opts.inCluster)
+	assert.Equal(t, 90*time.Second, opts.waitReady)
+	assert.True(t, opts.detach)
+	assert.Equal(t, "json", opts.output)
+	assert.Equal(t, []string{"payments", "backend"}, opts.tags)
+	assert.Equal(t, "frontend", opts.profile)
+}
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, []string{"payments", "team a"}, parseTags(" payments, team a ,,"))
+	assert.Nil(t, parseTags(""))
+}
+
+func TestUpdateCheckDisabled(t *testing.T) {
+	t.Setenv(version.DisableEnv, "")
+	assert.False(t, updateCheckDisabled(runOptions{}))
+	assert.True(t, updateCheckDisabled(runOptions{noUpdateCheck: true}))
+
+	t.Setenv(version.DisableEnv, "1")
+	assert.True(t, updateCheckDisabled(runOptions{}))
 }
 
 func TestParseFlags_HelpReturnsExit0(t *testing.T) {
@@ -593,11 +1021,56 @@ func TestInitLoggers_AllModes(t *testing.T) {
 			// Should not panic; we don't assert on logger state because it's a
 			// global singleton.
 			var stderr bytes.Buffer
-			initLoggers(opts, &stderr)
+			initLoggers(opts, &stderr, nil)
 		})
 	}
 }
 
+func TestInitLoggers_LogFile(t *testing.T) {
+	t.Cleanup(func() { initLoggers(runOptions{}, io.Discard, nil) })
+
+	var stderr, logFile bytes.Buffer
+	initLoggers(runOptions{headless: true, logFormat: "json"}, &stderr, &logFile)
+	logger.Info("to the file")
+
+	assert.Contains(t, logFile.String(), `"message":"to the file"`)
+	assert.Empty(t, stderr.String())
+}
+
+func TestResolveLogLevel(t *testing.T) {
+	assert.Equal(t, logger.LevelInfo, resolveLogLevel(runOptions{}))
+	assert.Equal(t, logger.LevelDebug, resolveLogLevel(runOptions{verbose: true}))
+	assert.Equal(t, logger.LevelWarn, resolveLogLevel(runOptions{logLevel: "warn"}))
+	assert.Equal(t, logger.LevelError, resolveLogLevel(runOptions{verbose: true, logLevel: "error"}), "-log-level wins over -v")
+}
+
+func TestInitLoggers_LogLevelFiltersLogFile(t *testing.T) {
+	t.Cleanup(func() { initLoggers(runOptions{}, io.Discard, nil) })
+
+	var stderr, logFile bytes.Buffer
+	initLoggers(runOptions{logLevel: "warn"}, &stderr, &logFile)
+	logger.Info("dropped")
+	logger.Warn("kept")
+
+	assert.NotContains(t, logFile.String(), "dropped")
+	assert.Contains(t, logFile.String(), "kept")
+}
+
+func TestParseFlags_InvalidLogLevel(t *testing.T) {
+	var stderr bytes.Buffer
+	_, code, handled := parseFlags([]string{"-log-level", "trace"}, &stderr)
+	assert.True(t, handled)
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), "invalid log level")
+}
+
+func TestRun_LogFileOpenError(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-log-file", filepath.Join(t.TempDir(), "missing", "kportal.log"), "-check"}, strings.NewReader(""), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "failed to open log file")
+}
+
 func TestConfigureStdlibLog_AllModes(t *testing.T) {
 	cases := []runOptions{
 		{verbose: true},
@@ -651,3 +1124,12 @@ func TestLoadOrCreateConfig_NotFound_AcceptCreates(t *testing.T) {
 	require.NotNil(t, cfg)
 	assert.FileExists(t, cfgPath)
 }
+
+func TestReadinessPipe(t *testing.T) {
+	t.Setenv(readyFDEnv, "")
+	assert.Nil(t, readinessPipe(), "not started by -detach")
+	t.Setenv(readyFDEnv, "stdout")
+	assert.Nil(t, readinessPipe())
+	t.Setenv(readyFDEnv, "1")
+	assert.Nil(t, readinessPipe(), "stdio is never the readiness pipe")
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// statusSignals request a status snapshot in headless mode.
+var statusSignals = []os.Signal{syscall.SIGUSR1}
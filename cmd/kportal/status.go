@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/ui"
+)
+
+// statusEntry is the JSON shape of a single forward in a headless status
+// snapshot, written on SIGUSR1.
+type statusEntry struct {
+	ConnectedSince *time.Time `json:"connectedSince,omitempty"` // while the tunnel is up
+	LastErrorAt    *time.Time `json:"lastErrorAt,omitempty"`
+	ID             string     `json:"id"`
+	Context        string     `json:"context"`
+	Namespace      string     `json:"namespace"`
+	Type           string     `json:"type"`
+	Resource       string     `json:"resource"`
+	Alias          string     `json:"alias"`
+	State          string     `json:"state"`  // e.g. "active", "reconnecting", "error"
+	Status         string     `json:"status"` // as shown in the TUI, e.g. "Reconnecting (4s, attempt 3)"
+	Pod            string     `json:"pod,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	LastError      string     `json:"lastError,omitempty"` // kept after the forward recovers
+	RemotePort     int        `json:"remotePort"`
+	LocalPort      int        `json:"localPort"`
+}
+
+// statusSnapshot is the document written on SIGUSR1.
+type statusSnapshot struct {
+	Time     time.Time     `json:"time"`
+	Forwards []statusEntry `json:"forwards"`
+}
+
+// buildStatusSnapshot converts the tracked forward statuses into a snapshot
+// sorted by forward ID.
+func buildStatusSnapshot(statuses map[string]ui.ForwardStatus, now time.Time) statusSnapshot {
+	ids := make([]string, 0, len(statuses))
+	for id := range statuses {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	snapshot := statusSnapshot{Time: now, Forwards: make([]statusEntry, 0, len(ids))}
+	for _, id := range ids {
+		s := statuses[id]
+		state, _, _ := strings.Cut(s.Status, " (")
+		entry := statusEntry{
+			ID:         id,
+			Context:    s.Context,
+			Namespace:  s.Namespace,
+			Type:       s.Type,
+			Resource:   s.Resource,
+			Alias:      s.Alias,
+			State:      strings.ToLower(state),
+			Status:     s.Status,
+			Pod:        s.Pod,
+			Error:      s.Error,
+			LastError:  s.LastError,
+			RemotePort: s.RemotePort,
+			LocalPort:  s.LocalPort,
+		}
+		if !s.ConnectedSince.IsZero() {
+			entry.ConnectedSince = &s.ConnectedSince
+		}
+		if !s.LastErrorAt.IsZero() {
+			entry.LastErrorAt = &s.LastErrorAt
+		}
+		snapshot.Forwards = append(snapshot.Forwards, entry)
+	}
+	return snapshot
+}
+
+// writeStatusSnapshot writes the current status of every forward as JSON to
+// path, or to stdout when path is empty. The file is replaced atomically so
+// readers never see a partial snapshot.
+func writeStatusSnapshot(table *ui.TableUI, path string, stdout io.Writer) error {
+	data, err := json.MarshalIndent(buildStatusSnapshot(table.Snapshot(), time.Now()), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode status: %w", err)
+	}
+	data = append(data, '\n')
+
+	if path == "" {
+		_, err = stdout.Write(data)
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() // No-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write status: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write status: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write status: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildStatusSnapshot(t *testing.T) {
+	now := time.Date(2026, 5, 6, 12, 0, 0, 0, time.UTC)
+	snapshot := buildStatusSnapshot(map[string]ui.ForwardStatus{
+		"dev/default/service/api:8080": {
+			Context: "dev", Namespace: "default", Type: "service", Resource: "api", Alias: "api",
+			Status: "Active", Pod: "api-7d9f", RemotePort: 80, LocalPort: 8080,
+			ConnectedSince: now.Add(-time.Minute),
+		},
+		"db:5432": {
+			Context: "dev", Namespace: "data", Type: "pod", Resource: "postgres", Alias: "db",
+			Status: "Reconnecting (4s, attempt 3)", Error: "connection refused", RemotePort: 5432, LocalPort: 5432,
+			LastError: "connection refused", LastErrorAt: now.Add(-4 * time.Second),
+		},
+	}, now)
+
+	assert.Equal(t, now, snapshot.Time)
+	require.Len(t, snapshot.Forwards, 2)
+
+	db := snapshot.Forwards[0] // Sorted by ID
+	assert.Equal(t, "db:5432", db.ID)
+	assert.Equal(t, "reconnecting", db.State)
+	assert.Equal(t, "Reconnecting (4s, attempt 3)", db.Status)
+	assert.Equal(t, "connection refused", db.Error)
+	assert.Equal(t, "connection refused", db.LastError)
+	require.NotNil(t, db.LastErrorAt)
+	assert.Equal(t, now.Add(-4*time.Second), *db.LastErrorAt)
+	assert.Nil(t, db.ConnectedSince)
+
+	api := snapshot.Forwards[1]
+	assert.Equal(t, "active", api.State)
+	assert.Equal(t, "api-7d9f", api.Pod)
+	require.NotNil(t, api.ConnectedSince)
+	assert.Equal(t, now.Add(-time.Minute), *api.ConnectedSince)
+	assert.Nil(t, api.LastErrorAt)
+	assert.Equal(t, 8080, api.LocalPort)
+	assert.Equal(t, 80, api.RemotePort)
+}
+
+func TestWriteStatusSnapshot(t *testing.T) {
+	table := ui.NewTableUI(false)
+	fwd := config.Forward{Resource: "service/api", Port: 80, LocalPort: 8080}
+	fwd.SetContext("dev", "default")
+	table.AddForward(fwd.ID(), &fwd)
+	table.UpdateStatus(fwd.ID(), "Error")
+	table.SetError(fwd.ID(), "service not found")
+
+	t.Run("stdout", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeStatusSnapshot(table, "", &out))
+
+		var snapshot statusSnapshot
+		require.NoError(t, json.Unmarshal(out.Bytes(), &snapshot))
+		require.Len(t, snapshot.Forwards, 1)
+		assert.Equal(t, "dev/default/service/api:8080", snapshot.Forwards[0].ID)
+		assert.Equal(t, "error", snapshot.Forwards[0].State)
+		assert.Equal(t, "service not found", snapshot.Forwards[0].Error)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "status.json")
+		require.NoError(t, os.WriteFile(path, []byte("stale"), 0o600))
+
+		var out bytes.Buffer
+		require.NoError(t, writeStatusSnapshot(table, path, &out))
+		assert.Empty(t, out.String(), "nothing goes to stdout when a file is set")
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var snapshot statusSnapshot
+		require.NoError(t, json.Unmarshal(data, &snapshot))
+		assert.Len(t, snapshot.Forwards, 1)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "temp file should be renamed into place")
+	})
+
+	t.Run("unwritable directory", func(t *testing.T) {
+		err := writeStatusSnapshot(table, filepath.Join(t.TempDir(), "missing", "status.json"), &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
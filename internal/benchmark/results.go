@@ -1,17 +1,23 @@
-// Package benchmark provides HTTP benchmarking capabilities for port forwards.
-// It measures latency, throughput, and reliability of forwarded connections.
+// Package benchmark provides HTTP and TCP benchmarking capabilities for port
+// forwards. It measures latency, throughput, and reliability of forwarded
+// connections.
 //
 // The benchmark runner sends configurable numbers of concurrent requests
 // and collects statistics including:
-//   - Latency percentiles (P50, P95, P99)
+//   - Latency percentiles (P50, P75, P95, P99, P99.9)
 //   - Request success/failure rates
 //   - Throughput (requests/second)
 //   - Status code distribution
 //
+// For forwards that do not speak HTTP, RunTCP opens connections instead and
+// records connect time and, when a payload is given, the round trip until
+// the first response bytes.
+//
 // Results can be displayed in the UI or exported for analysis.
 package benchmark
 
 import (
+	"math"
 	"sort"
 	"time"
 )
@@ -26,6 +32,7 @@ type Results struct {
 	URL           string          `json:"url"`
 	ForwardID     string          `json:"forward_id"`
 	Latencies     []time.Duration `json:"-"`
+	RoundTrips    []time.Duration `json:"-"` // TCP payload round trips
 	TotalRequests int             `json:"total_requests"`
 	Successful    int             `json:"successful"`
 	Failed        int             `json:"failed"`
@@ -35,14 +42,16 @@ type Results struct {
 
 // Stats holds calculated statistics
 type Stats struct {
-	MinLatency time.Duration `json:"min_latency_ms"`
-	MaxLatency time.Duration `json:"max_latency_ms"`
-	AvgLatency time.Duration `json:"avg_latency_ms"`
-	P50Latency time.Duration `json:"p50_latency_ms"`
-	P95Latency time.Duration `json:"p95_latency_ms"`
-	P99Latency time.Duration `json:"p99_latency_ms"`
-	Throughput float64       `json:"throughput_rps"`
-	Duration   time.Duration `json:"duration"`
+	MinLatency  time.Duration `json:"min_latency_ms"`
+	MaxLatency  time.Duration `json:"max_latency_ms"`
+	AvgLatency  time.Duration `json:"avg_latency_ms"`
+	P50Latency  time.Duration `json:"p50_latency_ms"`
+	P75Latency  time.Duration `json:"p75_latency_ms"`
+	P95Latency  time.Duration `json:"p95_latency_ms"`
+	P99Latency  time.Duration `json:"p99_latency_ms"`
+	P999Latency time.Duration `json:"p999_latency_ms"`
+	Throughput  float64       `json:"throughput_rps"`
+	Duration    time.Duration `json:"duration"`
 }
 
 // NewResults creates a new Results instance
@@ -74,6 +83,19 @@ func (r *Results) RecordSuccess(statusCode int, latency time.Duration, bytesRead
 	r.BytesWritten += bytesWritten
 }
 
+// RecordConnection records a successful TCP connection. connect is the
+// connect time; roundTrip is zero when no payload was sent.
+func (r *Results) RecordConnection(connect, roundTrip time.Duration, bytesRead, bytesWritten int64) {
+	r.TotalRequests++
+	r.Successful++
+	r.Latencies = append(r.Latencies, connect)
+	if roundTrip > 0 {
+		r.RoundTrips = append(r.RoundTrips, roundTrip)
+	}
+	r.BytesRead += bytesRead
+	r.BytesWritten += bytesWritten
+}
+
 // RecordFailure records a failed request
 func (r *Results) RecordFailure(err error, latency time.Duration) {
 	r.TotalRequests++
@@ -89,17 +111,33 @@ func (r *Results) Finalize() {
 
 // CalculateStats calculates statistics from the results
 func (r *Results) CalculateStats() Stats {
-	stats := Stats{
-		Duration: r.EndTime.Sub(r.StartTime),
+	stats := latencyStats(r.Latencies)
+	stats.Duration = r.EndTime.Sub(r.StartTime)
+
+	// Calculate throughput
+	if stats.Duration > 0 {
+		stats.Throughput = float64(r.TotalRequests) / stats.Duration.Seconds()
 	}
 
-	if len(r.Latencies) == 0 {
+	return stats
+}
+
+// CalculateRoundTripStats calculates latency statistics for the payload
+// round trips of a TCP benchmark. Throughput and duration are left unset.
+func (r *Results) CalculateRoundTripStats() Stats {
+	return latencyStats(r.RoundTrips)
+}
+
+// latencyStats calculates the latency fields of Stats from latencies
+func latencyStats(latencies []time.Duration) Stats {
+	var stats Stats
+	if len(latencies) == 0 {
 		return stats
 	}
 
 	// Sort latencies for percentile calculation
-	sorted := make([]time.Duration, len(r.Latencies))
-	copy(sorted, r.Latencies)
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
 	sort.Slice(sorted, func(i, j int) bool {
 		return sorted[i] < sorted[j]
 	})
@@ -116,24 +154,26 @@ func (r *Results) CalculateStats() Stats {
 
 	// Calculate percentiles
 	stats.P50Latency = percentile(sorted, 50)
+	stats.P75Latency = percentile(sorted, 75)
 	stats.P95Latency = percentile(sorted, 95)
 	stats.P99Latency = percentile(sorted, 99)
-
-	// Calculate throughput
-	if stats.Duration > 0 {
-		stats.Throughput = float64(r.TotalRequests) / stats.Duration.Seconds()
-	}
+	stats.P999Latency = percentile(sorted, 99.9)
 
 	return stats
 }
 
-// percentile calculates the p-th percentile of sorted durations
-func percentile(sorted []time.Duration, p int) time.Duration {
+// percentile calculates the p-th percentile of sorted durations.
+// p may be fractional (e.g. 99.9). When there are too few samples to
+// resolve p (fewer than 1000 for P99.9) the index clamps to the slowest
+// sample, so the result degrades to the max rather than failing.
+func percentile(sorted []time.Duration, p float64) time.Duration {
 	if len(sorted) == 0 {
 		return 0
 	}
 
-	idx := (p * len(sorted)) / 100
+	// Work in permille so fractional percentiles avoid float rounding
+	permille := int(math.Round(p * 10))
+	idx := (permille * len(sorted)) / 1000
 	if idx >= len(sorted) {
 		idx = len(sorted) - 1
 	}
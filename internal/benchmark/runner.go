@@ -67,8 +67,31 @@ func (r *Runner) Run(ctx context.Context, forwardID string, cfg Config) (*Result
 
 	results := NewResults(forwardID, cfg.URL, cfg.Method)
 
+	var resultsMu sync.Mutex
+	execute(ctx, cfg.Concurrency, cfg.Requests, cfg.Duration, cfg.ProgressCallback, func(ctx context.Context) {
+		start := time.Now()
+		statusCode, bytesRead, bytesWritten, err := r.makeRequestSafe(ctx, cfg)
+		latency := time.Since(start)
+
+		resultsMu.Lock()
+		defer resultsMu.Unlock()
+		if err != nil {
+			results.RecordFailure(err, latency)
+		} else {
+			results.RecordSuccess(statusCode, latency, bytesRead, bytesWritten)
+		}
+	})
+
+	results.Finalize()
+	return results, nil
+}
+
+// execute calls do from concurrency workers, either requests times or, when
+// duration is set, until it has passed. It returns once every call has
+// finished or ctx is cancelled.
+func execute(ctx context.Context, concurrency, requests int, duration time.Duration, progress ProgressCallback, do func(ctx context.Context)) {
 	// Create work channel
-	workCh := make(chan struct{}, cfg.Concurrency*2)
+	workCh := make(chan struct{}, concurrency*2)
 
 	// Create context for cancellation
 	runCtx, cancel := context.WithCancel(ctx)
@@ -77,18 +100,17 @@ func (r *Runner) Run(ctx context.Context, forwardID string, cfg Config) (*Result
 	// Start workers
 	var wg sync.WaitGroup
 	var completed int64
-	var resultsMu sync.Mutex // Shared mutex for results access
 
-	for i := 0; i < cfg.Concurrency; i++ {
+	for i := 0; i < concurrency; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			r.worker(runCtx, cfg, results, &resultsMu, workCh, &completed)
+			worker(runCtx, workCh, &completed, do)
 		}()
 	}
 
 	// Start progress reporter if callback is provided
-	if cfg.ProgressCallback != nil {
+	if progress != nil {
 		go func() {
 			ticker := time.NewTicker(100 * time.Millisecond)
 			defer ticker.Stop()
@@ -97,23 +119,24 @@ func (r *Runner) Run(ctx context.Context, forwardID string, cfg Config) (*Result
 				case <-runCtx.Done():
 					return
 				case <-ticker.C:
-					cfg.ProgressCallback(int(atomic.LoadInt64(&completed)), cfg.Requests)
+					progress(int(atomic.LoadInt64(&completed)), requests)
 				}
 			}
 		}()
 	}
 
 	// Determine how to dispatch work
-	if cfg.Duration > 0 {
+	if duration > 0 {
 		// Duration-based: keep sending work until duration expires
-		timer := time.NewTimer(cfg.Duration)
+		timer := time.NewTimer(duration)
 		defer timer.Stop()
 
 	dispatchLoop:
 		for {
 			select {
 			case <-timer.C:
-				cancel()
+				// Stop dispatching but let in-flight requests complete;
+				// cancelling them would record them as failures
 				break dispatchLoop
 			case <-ctx.Done():
 				cancel()
@@ -122,10 +145,20 @@ func (r *Runner) Run(ctx context.Context, forwardID string, cfg Config) (*Result
 				// Work dispatched
 			}
 		}
+
+		// Discard queued work that no worker has picked up yet
+	drainLoop:
+		for {
+			select {
+			case <-workCh:
+			default:
+				break drainLoop
+			}
+		}
 	} else {
 		// Request-based: send exactly N requests
 	requestLoop:
-		for i := 0; i < cfg.Requests; i++ {
+		for i := 0; i < requests; i++ {
 			select {
 			case <-ctx.Done():
 				cancel()
@@ -139,13 +172,10 @@ func (r *Runner) Run(ctx context.Context, forwardID string, cfg Config) (*Result
 	// Close work channel and wait for workers
 	close(workCh)
 	wg.Wait()
-
-	results.Finalize()
-	return results, nil
 }
 
 // worker processes requests from the work channel
-func (r *Runner) worker(ctx context.Context, cfg Config, results *Results, resultsMu *sync.Mutex, workCh <-chan struct{}, completed *int64) {
+func worker(ctx context.Context, workCh <-chan struct{}, completed *int64, do func(ctx context.Context)) {
 	for range workCh {
 		select {
 		case <-ctx.Done():
@@ -153,18 +183,7 @@ func (r *Runner) worker(ctx context.Context, cfg Config, results *Results, resul
 		default:
 		}
 
-		start := time.Now()
-		statusCode, bytesRead, bytesWritten, err := r.makeRequestSafe(ctx, cfg)
-		latency := time.Since(start)
-
-		resultsMu.Lock()
-		if err != nil {
-			results.RecordFailure(err, latency)
-		} else {
-			results.RecordSuccess(statusCode, latency, bytesRead, bytesWritten)
-		}
-		resultsMu.Unlock()
-
+		do(ctx)
 		atomic.AddInt64(completed, 1)
 	}
 }
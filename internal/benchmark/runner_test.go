@@ -99,6 +99,21 @@ func TestPercentile(t *testing.T) {
 	assert.Equal(t, 10*time.Millisecond, percentile(sorted, 95))
 	// P99 = index 9 (99*10/100 = 9) = 10ms
 	assert.Equal(t, 10*time.Millisecond, percentile(sorted, 99))
+	// P75 = index 7 (750*10/1000 = 7) = 8ms
+	assert.Equal(t, 8*time.Millisecond, percentile(sorted, 75))
+	// P99.9 with 10 samples clamps to the slowest sample
+	assert.Equal(t, 10*time.Millisecond, percentile(sorted, 99.9))
+}
+
+func TestPercentileP999LargeSample(t *testing.T) {
+	sorted := make([]time.Duration, 2000)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	// P99.9 = index 1998 (999*2000/1000) = 1999ms, one below the max
+	assert.Equal(t, 1999*time.Millisecond, percentile(sorted, 99.9))
+	assert.Equal(t, 0*time.Millisecond, percentile(nil, 99.9))
 }
 
 func TestRunner(t *testing.T) {
@@ -154,6 +169,31 @@ func TestRunnerWithDuration(t *testing.T) {
 	assert.Equal(t, results.Successful, results.StatusCodes[200])
 }
 
+func TestRunnerWithDuration_InFlightRequestsSucceed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	runner := NewRunner()
+
+	cfg := Config{
+		URL:         server.URL,
+		Method:      "GET",
+		Concurrency: 4,
+		Duration:    100 * time.Millisecond,
+		Timeout:     1 * time.Second,
+	}
+
+	results, err := runner.Run(context.Background(), "test-forward", cfg)
+	require.NoError(t, err)
+
+	// Requests still running when the duration ends must not count as failures
+	assert.Greater(t, results.TotalRequests, 0)
+	assert.Equal(t, 0, results.Failed)
+}
+
 func TestRunnerWithHeaders(t *testing.T) {
 	var receivedHeader string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
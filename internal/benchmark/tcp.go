@@ -0,0 +1,102 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// defaultTCPTimeout bounds a single TCP connection, from dial to the end of
+// its round trip, when TCPConfig.Timeout is not set.
+const defaultTCPTimeout = 10 * time.Second
+
+// TCPConfig holds the configuration of a TCP benchmark
+type TCPConfig struct {
+	ProgressCallback ProgressCallback
+	Address          string // host:port to connect to
+	Payload          []byte // optional; sent on each connection to time a round trip
+	Concurrency      int
+	Connections      int
+	Duration         time.Duration
+	Timeout          time.Duration // per connection
+}
+
+// RunTCP executes a TCP benchmark: it opens cfg.Connections connections,
+// cfg.Concurrency at a time, and records how long each took to connect.
+// With a payload, it is written once connected and the time until the
+// first response bytes arrive is recorded as the round trip, which works
+// for echo servers and request/response protocols such as Redis' PING.
+func (r *Runner) RunTCP(ctx context.Context, forwardID string, cfg TCPConfig) (*Results, error) {
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+
+	// Ensure concurrency doesn't exceed number of connections (for count-based mode)
+	if cfg.Duration == 0 && cfg.Connections > 0 && cfg.Concurrency > cfg.Connections {
+		cfg.Concurrency = cfg.Connections
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTCPTimeout
+	}
+
+	results := NewResults(forwardID, "tcp://"+cfg.Address, "TCP")
+
+	var resultsMu sync.Mutex
+	execute(ctx, cfg.Concurrency, cfg.Connections, cfg.Duration, cfg.ProgressCallback, func(ctx context.Context) {
+		start := time.Now()
+		connect, roundTrip, bytesRead, err := probeTCP(ctx, cfg)
+
+		resultsMu.Lock()
+		defer resultsMu.Unlock()
+		if err != nil {
+			results.RecordFailure(err, time.Since(start))
+		} else {
+			results.RecordConnection(connect, roundTrip, bytesRead, int64(len(cfg.Payload)))
+		}
+	})
+
+	results.Finalize()
+	return results, nil
+}
+
+// probeTCP opens one connection and, if there is a payload, times its
+// round trip
+func probeTCP(ctx context.Context, cfg TCPConfig) (connect, roundTrip time.Duration, bytesRead int64, err error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", cfg.Address)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	defer func() { _ = conn.Close() }()
+	connect = time.Since(start)
+
+	if len(cfg.Payload) == 0 {
+		return connect, 0, 0, nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	sent := time.Now()
+	if _, err := conn.Write(cfg.Payload); err != nil {
+		return connect, 0, 0, err
+	}
+	buf := make([]byte, max(len(cfg.Payload), 512))
+	n, err := conn.Read(buf)
+	if n == 0 && err != nil {
+		return connect, 0, 0, fmt.Errorf("no response: %w", err)
+	}
+	return connect, time.Since(sent), int64(n), nil
+}
@@ -0,0 +1,130 @@
+package benchmark
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startEchoServer starts a TCP server that echoes everything it reads
+func startEchoServer(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestRunTCP_Connect(t *testing.T) {
+	addr := startEchoServer(t)
+	runner := NewRunner()
+
+	results, err := runner.RunTCP(context.Background(), "test-forward", TCPConfig{
+		Address:     addr,
+		Concurrency: 3,
+		Connections: 12,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, results.TotalRequests)
+	assert.Equal(t, 12, results.Successful)
+	assert.Equal(t, "TCP", results.Method)
+	assert.Len(t, results.Latencies, 12)
+	assert.Empty(t, results.RoundTrips, "no payload means no round trips")
+}
+
+func TestRunTCP_Payload(t *testing.T) {
+	addr := startEchoServer(t)
+	runner := NewRunner()
+
+	results, err := runner.RunTCP(context.Background(), "test-forward", TCPConfig{
+		Address:     addr,
+		Payload:     []byte("PING\r\n"),
+		Concurrency: 2,
+		Connections: 5,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, results.Successful)
+	assert.Len(t, results.RoundTrips, 5)
+	assert.Equal(t, int64(30), results.BytesWritten)
+	assert.Equal(t, int64(30), results.BytesRead)
+	assert.Greater(t, results.CalculateRoundTripStats().MaxLatency, time.Duration(0))
+}
+
+func TestRunTCP_Failures(t *testing.T) {
+	// A listener that is closed straight away leaves a port nothing accepts on
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	results, err := NewRunner().RunTCP(context.Background(), "test-forward", TCPConfig{
+		Address:     addr,
+		Connections: 3,
+		Timeout:     time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, results.Failed)
+	assert.NotEmpty(t, results.Errors)
+}
+
+func TestRunTCP_NoResponse(t *testing.T) {
+	// The server accepts but never answers, so the round trip times out
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		if conn, err := ln.Accept(); err == nil {
+			accepted <- conn
+		}
+	}()
+
+	results, err := NewRunner().RunTCP(context.Background(), "test-forward", TCPConfig{
+		Address:     ln.Addr().String(),
+		Payload:     []byte("hello"),
+		Connections: 1,
+		Timeout:     50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, results.Failed)
+	_ = (<-accepted).Close()
+}
+
+func TestRunTCP_Duration(t *testing.T) {
+	addr := startEchoServer(t)
+
+	results, err := NewRunner().RunTCP(context.Background(), "test-forward", TCPConfig{
+		Address:     addr,
+		Concurrency: 2,
+		Duration:    100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	assert.Greater(t, results.TotalRequests, 0)
+	assert.Equal(t, 0, results.Failed)
+}
+
+func TestRunTCP_RequiresAddress(t *testing.T) {
+	_, err := NewRunner().RunTCP(context.Background(), "test-forward", TCPConfig{})
+	assert.Error(t, err)
+}
@@ -165,144 +165,109 @@ func Generate(shell Shell) (string, error) {
 func generateBash() (string, error) {
 	var sb strings.Builder
 
-	bashScript := `# kportal shell completion - bash
+	sb.WriteString(`# kportal shell completion - bash
 # Generated by kportal
 
-# Don't interfere with other completions
-if [[ -n "${BASH_COMPLETION_VERSINFO:-}" ]]; then
-    return
-fi
+__kportal_contexts()
+{
+    command -v kubectl &> /dev/null && kubectl config get-contexts -o name 2>/dev/null
+}
 
 _kportal()
 {
-    local cur prev words cword split=false
+    local cur prev words cword
     _init_completion -s || return
 
-    # Complete the value expected after the previous flag
-    case "$prev" in
-        -c|--config)
-            _filedir yaml
-            return
-            ;;
-        --log-format)
-            COMPREPLY=( $(compgen -W "text json" -- "$cur") )
-            return
-            ;;
-        --convert)
-            _filedir json
-            return
-            ;;
-        --convert-output)
-            _filedir yaml
-            return
-            ;;
-        --context)
-            # Complete from kubectl contexts
-            if command -v kubectl &> /dev/null; then
-                COMPREPLY=( $(compgen -W "$(kubectl config get-contexts -o name 2>/dev/null)" -- "$cur") )
-            fi
-            return
-            ;;
-        --shell)
-            COMPREPLY=( $(compgen -W "bash zsh fish" -- "$cur") )
-            return
-            ;;
-    esac
-
-    # Handle option splitting (e.g., -c=value)
-    [[ "$cur" == -*=* ]] && split=true
-    [[ "$split" == true ]] && prev="${cur%%=*}"
-
-    # Subcommand-specific completion
-    case "${words[1]}" in
-        generate)
-            if [[ "$cur" == -* ]]; then
-                COMPREPLY=( $(compgen -W "--context --config --dry-run" -- "$cur") )
-            elif command -v kubectl &> /dev/null; then
-                COMPREPLY=( $(compgen -W "$(kubectl config get-contexts -o name 2>/dev/null)" -- "$cur") )
-            fi
-            return
-            ;;
-        completion)
-            COMPREPLY=( $(compgen -W "--install --uninstall --shell" -- "$cur") )
-            return
-            ;;
-    esac
+    # Subcommands have their own flags and take no top-level ones
+    if [[ $cword -gt 1 ]]; then
+        case "${words[1]}" in
+`)
+	for _, cmd := range subcommands {
+		fmt.Fprintf(&sb, "            %s)\n", cmd.name)
+		writeBashCompletions(&sb, "                ", cmd.flags, cmd.args, 2)
+		sb.WriteString("                return\n                ;;\n")
+	}
+	sb.WriteString("        esac\n    fi\n\n")
 
-    # Top-level options
-    if [[ "$cur" == -* ]]; then
-        COMPREPLY=( $(compgen -W "-c -v --check --headless --log-format --version --update --convert --convert-output" -- "$cur") )
-        return
-    fi
+	writeBashCompletions(&sb, "    ", topLevelFlags, subcommandNames(), 1)
 
-    # Top-level subcommands
-    COMPREPLY=( $(compgen -W "generate completion" -- "$cur") )
-}
+	sb.WriteString(`}
 
 # Register completion
 complete -F _kportal kportal
 
 # Also complete for common aliases
 complete -F _kportal kp
-`
+`)
 
-	sb.WriteString(bashScript)
 	return sb.String(), nil
 }
 
+// writeBashCompletions writes the completion of flags' values, the flags
+// themselves, and args as the word at position argIndex.
+func writeBashCompletions(sb *strings.Builder, indent string, flags []flagSpec, args string, argIndex int) {
+	fmt.Fprintf(sb, "%scase \"$prev\" in\n", indent)
+	for _, f := range flags {
+		if f.kind == valueNone {
+			continue
+		}
+		fmt.Fprintf(sb, "%s    -%s|--%s)\n", indent, f.name, f.name)
+		switch f.kind {
+		case valueFile:
+			fmt.Fprintf(sb, "%s        %s\n", indent, strings.TrimSpace("_filedir "+f.ext))
+		case valueWords:
+			fmt.Fprintf(sb, "%s        COMPREPLY=( $(compgen -W \"%s\" -- \"$cur\") )\n", indent, f.words)
+		case valueContext:
+			fmt.Fprintf(sb, "%s        COMPREPLY=( $(compgen -W \"$(__kportal_contexts)\" -- \"$cur\") )\n", indent)
+		}
+		fmt.Fprintf(sb, "%s        return\n%s        ;;\n", indent, indent)
+	}
+	fmt.Fprintf(sb, "%sesac\n\n", indent)
+
+	options := make([]string, 0, len(flags))
+	for _, f := range flags {
+		options = append(options, f.option())
+	}
+	fmt.Fprintf(sb, "%sif [[ \"$cur\" == -* ]]; then\n", indent)
+	fmt.Fprintf(sb, "%s    COMPREPLY=( $(compgen -W \"%s\" -- \"$cur\") )\n", indent, strings.Join(options, " "))
+	if args != "" {
+		fmt.Fprintf(sb, "%selif [[ $cword -eq %d ]]; then\n", indent, argIndex)
+		fmt.Fprintf(sb, "%s    COMPREPLY=( $(compgen -W \"%s\" -- \"$cur\") )\n", indent, args)
+	}
+	fmt.Fprintf(sb, "%sfi\n", indent)
+}
+
 // generateZsh generates zsh completion script
 func generateZsh() (string, error) {
 	var sb strings.Builder
 
-	// Per-flag zsh _arguments descriptors. Completion scripts are static text, so
-	// these are literals (single source of truth — keep in sync with the CLI flags).
-	flagDescs := []string{
-		`'-c[Path to configuration file]:config file:_files -g "*.yaml"'`,
-		`'-v[Enable verbose logging]'`,
-		`'--version[Show version and exit]'`,
-		`'--update[Check for updates]'`,
-		`'--check[Validate configuration]'`,
-		`'--headless[Run without UI]'`,
-		`'--log-format[Log format: text or json]:format:(text json)'`,
-		`'--convert[Convert kftray config]:input file:_files -g "*.json"'`,
-		`'--convert-output[Output file]:output file:_files -g "*.yaml"'`,
-	}
-
-	sb.WriteString(`#compdef kportal
+	sb.WriteString(`#compdef kportal kp
 
 # kportal shell completion - zsh
 # Generated by kportal
 
+__kportal_contexts()
+{
+    local -a contexts
+    contexts=(${(f)"$(kubectl config get-contexts -o name 2>/dev/null)"})
+    _describe 'context' contexts
+}
+
 _kportal()
 {
-    local -a commands flags generate_flags completion_flags
+    local -a commands flags
 
     commands=(
-        'generate:Interactively generate forwards from cluster'
-        'completion:Generate shell completion scripts'
-    )
-
-    flags=(
 `)
-
-	for _, desc := range flagDescs {
-		fmt.Fprintf(&sb, "        %s\n", desc)
+	for _, cmd := range subcommands {
+		fmt.Fprintf(&sb, "        '%s:%s'\n", cmd.name, cmd.desc)
+	}
+	sb.WriteString("    )\n\n    flags=(\n")
+	for _, f := range topLevelFlags {
+		fmt.Fprintf(&sb, "        %s\n", zshFlag(f))
 	}
-
 	sb.WriteString(`    )
 
-    generate_flags=(
-        '--context[Kubernetes context]:context:->ctx'
-        '--config[Config file]:file:_files -g "*.yaml"'
-        '--dry-run[Print without saving]'
-    )
-
-    completion_flags=(
-        '--install[Install completions for the shell]'
-        '--uninstall[Remove installed completions]'
-        '--shell[Shell type]:shell:(bash zsh fish)'
-    )
-
     _arguments -s $flags '1: :->command' '*:: :->args'
 
     case $state in
@@ -311,20 +276,18 @@ _kportal()
             ;;
         args)
             case ${words[1]} in
-                generate)
-                    _arguments -s $generate_flags
-                    if [[ "$words[CURRENT]" == --context ]]; then
-                        if command -v kubectl &> /dev/null; then
-                            local -a contexts
-                            contexts=(${(f)"$(kubectl config get-contexts -o name 2>/dev/null)"})
-                            _describe 'context' contexts
-                        fi
-                    fi
-                    ;;
-                completion)
-                    _arguments -s $completion_flags
-                    ;;
-            esac
+`)
+	for _, cmd := range subcommands {
+		fmt.Fprintf(&sb, "                %s)\n                    _arguments -s", cmd.name)
+		for _, f := range cmd.flags {
+			fmt.Fprintf(&sb, " \\\n                        %s", zshFlag(f))
+		}
+		if cmd.args != "" {
+			fmt.Fprintf(&sb, " \\\n                        '1:argument:(%s)'", cmd.args)
+		}
+		sb.WriteString("\n                    ;;\n")
+	}
+	sb.WriteString(`            esac
             ;;
     esac
 }
@@ -335,6 +298,27 @@ _kportal "$@"
 	return sb.String(), nil
 }
 
+// zshFlag returns the _arguments spec of f
+func zshFlag(f flagSpec) string {
+	var action string
+	switch f.kind {
+	case valueNone:
+		return fmt.Sprintf("'%s[%s]'", f.option(), f.desc)
+	case valueFile:
+		action = "file:_files"
+		if f.ext != "" {
+			action += fmt.Sprintf(` -g "*.%s"`, f.ext)
+		}
+	case valueWords:
+		action = fmt.Sprintf("%s:(%s)", f.name, f.words)
+	case valueContext:
+		action = "context:__kportal_contexts"
+	default:
+		action = f.name + ": "
+	}
+	return fmt.Sprintf("'%s[%s]:%s'", f.option(), f.desc, action)
+}
+
 // generateFish generates fish completion script
 func generateFish() (string, error) {
 	var sb strings.Builder
@@ -342,41 +326,79 @@ func generateFish() (string, error) {
 	sb.WriteString(`# kportal shell completion - fish
 # Generated by kportal
 
+function __kportal_contexts
+    kubectl config get-contexts -o name 2>/dev/null
+end
+
 # Main completion
 complete -c kportal -f
 
 # Subcommands
-complete -c kportal -n '__fish_use_subcommand' -a 'generate' -d 'Interactively generate forwards from cluster'
-complete -c kportal -n '__fish_use_subcommand' -a 'completion' -d 'Generate shell completion scripts'
-
-# Global flags (main command uses single-dash -c and -v; words accept --)
-complete -c kportal -s c -r -f -a '( __fish_complete_suffix .yaml )' -d 'Path to configuration file'
-complete -c kportal -s v -d 'Enable verbose logging'
-complete -c kportal -l version -d 'Show version and exit'
-complete -c kportal -l update -d 'Check for updates'
-complete -c kportal -l check -d 'Validate configuration'
-complete -c kportal -l headless -d 'Run without UI'
-complete -c kportal -l log-format -d 'Log format' -a 'text json' -f
-complete -c kportal -l convert -r -f -a '( __fish_complete_suffix .json )' -d 'Convert kftray config'
-complete -c kportal -l convert-output -r -f -a '( __fish_complete_suffix .yaml )' -d 'Output file'
-
-# generate subcommand flags
-complete -c kportal -n '__fish_seen_subcommand_from generate' -l context -d 'Kubernetes context' -a '(kubectl config get-contexts -o name 2>/dev/null)' -f
-complete -c kportal -n '__fish_seen_subcommand_from generate' -l config -r -f -a '( __fish_complete_suffix .yaml )' -d 'Config file'
-complete -c kportal -n '__fish_seen_subcommand_from generate' -l dry-run -d 'Print without saving'
-
-# completion subcommand flags
-complete -c kportal -n '__fish_seen_subcommand_from completion' -l install -d 'Install completions for the shell'
-complete -c kportal -n '__fish_seen_subcommand_from completion' -l uninstall -d 'Remove installed completions'
-complete -c kportal -n '__fish_seen_subcommand_from completion' -l shell -d 'Shell type' -a 'bash zsh fish' -f
+`)
+	for _, cmd := range subcommands {
+		fmt.Fprintf(&sb, "complete -c kportal -n '__fish_use_subcommand' -a '%s' -d '%s'\n", cmd.name, cmd.desc)
+	}
 
+	sb.WriteString("\n# Top-level flags\n")
+	for _, f := range topLevelFlags {
+		sb.WriteString(fishFlag("__fish_use_subcommand", f))
+	}
+
+	for _, cmd := range subcommands {
+		fmt.Fprintf(&sb, "\n# %s subcommand\n", cmd.name)
+		condition := "__fish_seen_subcommand_from " + cmd.name
+		for _, f := range cmd.flags {
+			sb.WriteString(fishFlag(condition, f))
+		}
+		if cmd.args != "" {
+			fmt.Fprintf(&sb, "complete -c kportal -n '%s' -a '%s'\n", condition, cmd.args)
+		}
+	}
+
+	sb.WriteString(`
 # Aliases
-complete -c kp -f
+complete -c kp -w kportal
 `)
 
 	return sb.String(), nil
 }
 
+// fishFlag returns the complete command of f, offered while condition holds
+func fishFlag(condition string, f flagSpec) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "complete -c kportal -n '%s'", condition)
+	if len(f.name) == 1 {
+		fmt.Fprintf(&sb, " -s %s", f.name)
+	} else {
+		fmt.Fprintf(&sb, " -l %s", f.name)
+	}
+	switch f.kind {
+	case valueAny:
+		sb.WriteString(" -r")
+	case valueFile:
+		if f.ext != "" {
+			fmt.Fprintf(&sb, " -r -f -a '(__fish_complete_suffix .%s)'", f.ext)
+		} else {
+			sb.WriteString(" -r -F")
+		}
+	case valueWords:
+		fmt.Fprintf(&sb, " -r -f -a '%s'", f.words)
+	case valueContext:
+		sb.WriteString(" -r -f -a '(__kportal_contexts)'")
+	}
+	fmt.Fprintf(&sb, " -d '%s'\n", f.desc)
+	return sb.String()
+}
+
+// subcommandNames returns the names of the subcommands, space separated
+func subcommandNames() string {
+	names := make([]string, 0, len(subcommands))
+	for _, cmd := range subcommands {
+		names = append(names, cmd.name)
+	}
+	return strings.Join(names, " ")
+}
+
 // InstallCompletions installs completions for the specified shell
 // Prints instructions for manual installation if auto-install fails
 func InstallCompletions(shell Shell) error {
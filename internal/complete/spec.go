@@ -0,0 +1,118 @@
+package complete
+
+// valueKind is what a flag's value is completed with
+type valueKind int
+
+const (
+	valueNone    valueKind = iota // boolean flag, takes no value
+	valueAny                      // takes a value that is not completed
+	valueFile                     // file path, filtered by ext when set
+	valueWords                    // one of the flag's words
+	valueContext                  // kubeconfig context name
+)
+
+// flagSpec describes a flag for the completion scripts. Descriptions must
+// not contain quotes, brackets or colons, which the zsh and fish scripts
+// would have to escape.
+type flagSpec struct {
+	name  string // without dashes, as registered with the flag package
+	desc  string
+	ext   string // file extension of a valueFile, e.g. "yaml"
+	words string // space separated values of a valueWords
+	kind  valueKind
+}
+
+// commandSpec describes a subcommand for the completion scripts
+type commandSpec struct {
+	name  string
+	desc  string
+	args  string // space separated words completed as the first argument
+	flags []flagSpec
+}
+
+// topLevelFlags are kportal's own flags, in the order of -help. Keep in
+// sync with parseFlags in cmd/kportal; a test there checks every flag is
+// listed.
+var topLevelFlags = []flagSpec{
+	{name: "c", desc: "Path to configuration file or directory", kind: valueFile},
+	{name: "v", desc: "Enable verbose logging"},
+	{name: "headless", desc: "Run without UI"},
+	{name: "wait-ready", desc: "Wait this long for every forward to be active, then exit", kind: valueAny},
+	{name: "detach", desc: "With -wait-ready, keep the forwards running in the background"},
+	{name: "status-file", desc: "File to write the status snapshot to on SIGUSR1", kind: valueFile},
+	{name: "log-format", desc: "Log format", kind: valueWords, words: "text json"},
+	{name: "log-level", desc: "Log level", kind: valueWords, words: "debug info warn error"},
+	{name: "audit-log", desc: "Append config changes to this file", kind: valueFile},
+	{name: "log-file", desc: "Append logs to this file", kind: valueFile},
+	{name: "check", desc: "Validate configuration and exit"},
+	{name: "output", desc: "Output format of -check and -version", kind: valueWords, words: "text json"},
+	{name: "dry-run", desc: "Resolve every forward and check local ports, then exit"},
+	{name: "version", desc: "Show version and exit"},
+	{name: "update", desc: "Check for updates and exit"},
+	{name: "watch", desc: "Reload the configuration when the file changes"},
+	{name: "profile", desc: "Start only the forwards of this profile", kind: valueAny},
+	{name: "tags", desc: "Start only the forwards with one of these tags", kind: valueAny},
+	{name: "kubeconfig", desc: "Kubeconfig file to use", kind: valueFile},
+	{name: "in-cluster", desc: "Add the in-cluster context"},
+	{name: "theme", desc: "UI color theme", kind: valueWords, words: "dark light"},
+	{name: "http-log", desc: "Enable HTTP logging for every forward"},
+	{name: "read-only", desc: "Disable config changes from the TUI"},
+	{name: "notify", desc: "Show desktop notifications when forwards go down or recover"},
+	{name: "no-color", desc: "Disable colors and styling"},
+	{name: "no-update-check", desc: "Skip the background update check"},
+	{name: "update-timeout", desc: "Timeout for each update check request", kind: valueAny},
+	{name: "update-interval", desc: "Reuse the cached update check result until it is this old", kind: valueAny},
+	{name: "convert", desc: "Convert kftray config", kind: valueFile, ext: "json"},
+	{name: "convert-kubectl", desc: "Convert a file of kubectl port-forward commands", kind: valueFile},
+	{name: "convert-output", desc: "Output file of the conversion", kind: valueFile, ext: "yaml"},
+	{name: "convert-fix-ports", desc: "Move colliding local ports of converted forwards"},
+}
+
+// configFlag and socketFlag are shared by several subcommands
+var (
+	configFlag = flagSpec{name: "config", desc: "Path to configuration file", kind: valueFile, ext: "yaml"}
+	socketFlag = flagSpec{name: "socket", desc: "Control socket path", kind: valueFile}
+)
+
+// subcommands are kportal's subcommands with their flags
+var subcommands = []commandSpec{
+	{name: "generate", desc: "Interactively generate forwards from cluster", flags: []flagSpec{
+		{name: "context", desc: "Kubernetes context to scan", kind: valueContext},
+		configFlag,
+		{name: "kubeconfig", desc: "Kubeconfig file to use", kind: valueFile},
+		{name: "dry-run", desc: "Print without saving"},
+	}},
+	{name: "init", desc: "Create a config with contexts from your kubeconfig", flags: []flagSpec{
+		configFlag,
+		{name: "all", desc: "Add every kubeconfig context"},
+		{name: "context", desc: "Kubeconfig context to add", kind: valueContext},
+	}},
+	{name: "list", desc: "List the forwards of the config", flags: []flagSpec{
+		configFlag,
+		{name: "output", desc: "Output format", kind: valueWords, words: "table json"},
+	}},
+	{name: "completion", desc: "Generate shell completion scripts", args: "bash zsh fish", flags: []flagSpec{
+		{name: "install", desc: "Install completions for the shell"},
+		{name: "uninstall", desc: "Remove installed completions"},
+		{name: "shell", desc: "Shell type", kind: valueWords, words: "bash zsh fish"},
+	}},
+	{name: "ctl", desc: "Control a headless kportal", args: "list enable disable reload", flags: []flagSpec{
+		configFlag,
+		socketFlag,
+	}},
+	{name: "status", desc: "Show the forwards of a headless kportal", flags: []flagSpec{
+		configFlag,
+		socketFlag,
+		{name: "json", desc: "Print the forwards as JSON"},
+	}},
+}
+
+// option returns the flag as typed on the command line: "-c" for single
+// letters and "--name" otherwise. The flag package accepts either dash
+// count.
+func (f flagSpec) option() string {
+	if len(f.name) == 1 {
+		return "-" + f.name
+	}
+	return "--" + f.name
+}
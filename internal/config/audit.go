@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/logger"
+)
+
+// Audit operations recorded by the mutator
+const (
+	AuditAdd    = "add"
+	AuditUpdate = "update"
+	AuditRemove = "remove"
+)
+
+// SetAuditLogger makes the mutator record every successful forward add,
+// update and removal on l, for an audit trail of a shared config. A nil
+// logger, the default, records nothing.
+func (m *Mutator) SetAuditLogger(l *logger.Logger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.auditLog = l
+	if l != nil && m.auditUser == "" {
+		m.auditUser = currentUser()
+	}
+}
+
+// audit records one successful mutation of the forward id. extra fields are
+// added to the entry. Caller must hold m.mu.
+func (m *Mutator) audit(operation, id string, extra map[string]interface{}) {
+	if m.auditLog == nil {
+		return
+	}
+	fields := map[string]interface{}{
+		"operation":  operation,
+		"forward_id": id,
+		"config":     m.configPath,
+		"user":       m.auditUser,
+		// The text format has no timestamp of its own
+		"timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	m.auditLog.Info("config mutation", fields)
+}
+
+// currentUser returns the login name of the user running kportal, falling
+// back to $USER when it cannot be looked up
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/kportal/internal/logger"
+)
+
+const auditConfig = `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/app
+            protocol: tcp
+            port: 8080
+            localPort: 8080
+`
+
+// auditEntries decodes the JSON audit entries written to buf
+func auditEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry struct {
+			Fields  map[string]interface{} `json:"fields"`
+			Message string                 `json:"message"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, "config mutation", entry.Message)
+		entries = append(entries, entry.Fields)
+	}
+	return entries
+}
+
+// auditMutator returns a mutator for a copy of auditConfig that audits to buf
+func auditMutator(t *testing.T, buf *bytes.Buffer) *Mutator {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(auditConfig), 0600))
+
+	mutator := NewMutator(configPath)
+	mutator.SetAuditLogger(logger.New(logger.LevelInfo, logger.FormatJSON, buf))
+	return mutator
+}
+
+func TestMutator_Audit(t *testing.T) {
+	var buf bytes.Buffer
+	mutator := auditMutator(t, &buf)
+
+	require.NoError(t, mutator.AddForwards("dev", "default", []Forward{
+		{Resource: "service/db", Protocol: "tcp", Port: 5432, LocalPort: 5432},
+		{Resource: "service/cache", Protocol: "tcp", Port: 6379, LocalPort: 6379},
+	}))
+	require.NoError(t, mutator.UpdateForward("dev/default/pod/app:8080", "dev", "default",
+		Forward{Resource: "pod/app", Protocol: "tcp", Port: 9090, LocalPort: 9090}))
+	require.NoError(t, mutator.RemoveForwardByID("dev/default/service/db:5432"))
+
+	entries := auditEntries(t, &buf)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, AuditAdd, entries[0]["operation"])
+	assert.Equal(t, "dev/default/service/db:5432", entries[0]["forward_id"])
+	assert.Equal(t, AuditAdd, entries[1]["operation"])
+	assert.Equal(t, "dev/default/service/cache:6379", entries[1]["forward_id"])
+
+	assert.Equal(t, AuditUpdate, entries[2]["operation"])
+	assert.Equal(t, "dev/default/pod/app:9090", entries[2]["forward_id"])
+	assert.Equal(t, "dev/default/pod/app:8080", entries[2]["previous_id"])
+
+	assert.Equal(t, AuditRemove, entries[3]["operation"])
+	assert.Equal(t, "dev/default/service/db:5432", entries[3]["forward_id"])
+
+	for _, entry := range entries {
+		assert.Equal(t, mutator.configPath, entry["config"])
+		assert.NotEmpty(t, entry["timestamp"])
+		assert.Contains(t, entry, "user")
+	}
+}
+
+func TestMutator_Audit_FailedMutationNotRecorded(t *testing.T) {
+	var buf bytes.Buffer
+	mutator := auditMutator(t, &buf)
+
+	err := mutator.AddForward("dev", "default", Forward{Resource: "service/db", Protocol: "tcp", Port: 5432, LocalPort: 8080})
+	require.Error(t, err)
+	err = mutator.UpdateForward("dev/default/pod/missing:1", "dev", "default", Forward{Resource: "pod/x", Protocol: "tcp", Port: 1, LocalPort: 9999})
+	require.Error(t, err)
+
+	assert.Empty(t, buf.String())
+}
+
+func TestMutator_Audit_NoSink(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(auditConfig), 0600))
+
+	mutator := NewMutator(configPath)
+	require.NoError(t, mutator.AddForward("dev", "default", Forward{Resource: "service/db", Protocol: "tcp", Port: 5432, LocalPort: 5432}))
+	assert.Nil(t, mutator.auditLog)
+}
@@ -23,9 +23,15 @@ package config
 
 import (
 	"bytes"
+	"cmp"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,13 +52,64 @@ const (
 	DefaultMaxConnectionAge    = 25 * time.Minute // Reconnect before k8s 30min timeout
 	DefaultMaxIdleTime         = 10 * time.Minute // Reconnect if no activity
 
+	// Default per-forward HTTP health probe settings
+	DefaultProbeInterval       = 10 * time.Second // How often to probe the forwarded app
+	DefaultProbeExpectedStatus = 200              // HTTP status that counts as healthy
+
 	// Default reliability settings
 	DefaultTCPKeepalive   = 30 * time.Second // OS-level TCP keepalive interval
 	DefaultDialTimeout    = 30 * time.Second // Connection establishment timeout
 	DefaultWatchdogPeriod = 30 * time.Second // Goroutine health check interval
 
+	// DefaultNetworkWatchInterval is how often the network interfaces are
+	// polled for changes such as a Wi-Fi switch or a VPN coming up
+	DefaultNetworkWatchInterval = 5 * time.Second
+
+	// Default reconnect backoff settings: 1s → 2s → 4s → 8s → 10s (max)
+	DefaultReconnectBaseDelay = 1 * time.Second  // Delay before the first reconnect attempt
+	DefaultReconnectMaxDelay  = 10 * time.Second // Upper bound for the reconnect delay
+	DefaultReconnectJitter    = 0.1              // Random ±10% added to each delay
+
+	// Kubernetes API call limits
+	DefaultDiscoveryConcurrency = 5 // Concurrent pod/service lookups per context
+
+	// Default resolved-pod cache settings
+	DefaultResolveCacheTTL        = 30 * time.Second // How long a resolved pod is reused
+	DefaultResolveCacheMaxEntries = 1000             // Cached resolutions kept at most
+
 	// Default HTTP logging settings
-	DefaultHTTPLogMaxBodySize = 1024 * 1024 // 1MB max body size for logging
+	DefaultHTTPLogMaxBodySize = 64 * 1024 // 64KB of each body captured for logging
+	DefaultHTTPLogMaxFileSize = 50        // Rotate log files at 50MB
+	DefaultHTTPLogMaxFiles    = 5         // Rotated log files to keep
+	DefaultHTTPLogMaxEntries  = 10000     // Entries the TUI's HTTP log view keeps
+	HighHTTPLogMaxEntries     = 100000    // httpLogMaxEntries above this draws a memory warning
+
+	// Default HTTP log latency coloring thresholds
+	DefaultHTTPLogSlowLatency     = 500 * time.Millisecond // Rows shown as a warning
+	DefaultHTTPLogVerySlowLatency = 2 * time.Second        // Rows shown as an error
+
+	// Default mDNS settings
+	DefaultMDNSServiceType = "_kportal._tcp"
+
+	// Default webhook delivery settings
+	DefaultWebhookTimeout = 5 * time.Second // Per attempt
+	DefaultWebhookRetries = 2               // Retries after a failed attempt
+
+	// DefaultNotifyDebounce is how long a forward must stay down, or back
+	// up, before a desktop notification is shown
+	DefaultNotifyDebounce = 10 * time.Second
+
+	// Supported forward protocols
+	ProtocolTCP = "tcp"
+	ProtocolUDP = "udp"
+
+	// Supported URL schemes for opening a forward in the browser
+	SchemeHTTP  = "http"
+	SchemeHTTPS = "https"
+
+	// How privileged local ports are reported by the validator
+	PrivilegedPortsWarn  = "warn"
+	PrivilegedPortsError = "error"
 )
 
 // Config represents the root configuration structure from .kportal.yaml
@@ -60,13 +117,111 @@ type Config struct {
 	HealthCheck *HealthCheckSpec `yaml:"healthCheck,omitempty"`
 	Reliability *ReliabilitySpec `yaml:"reliability,omitempty"`
 	MDNS        *MDNSSpec        `yaml:"mdns,omitempty"`
-	Contexts    []Context        `yaml:"contexts"`
+	Notify      *NotifySpec      `yaml:"notify,omitempty"`
+	// HTTPLogLatency sets the thresholds of the HTTP log view's latency
+	// coloring. Unset fields use the defaults.
+	HTTPLogLatency *HTTPLogLatencySpec `yaml:"httpLogLatency,omitempty"`
+	// Profiles names subsets of forwards that -profile starts on their
+	// own. A member is a forward ID, e.g. "api:8080", or "tag:<name>" for
+	// every forward with that tag.
+	Profiles map[string][]string `yaml:"profiles,omitempty"`
+	// Webhooks are POSTed a JSON payload whenever a forward changes state.
+	Webhooks []WebhookSpec `yaml:"webhooks,omitempty"`
+	// HiddenColumns lists the TUI forwards table columns hidden at
+	// startup: "context", "namespace", "type", "resource" or "uptime".
+	// The 1-5 keys toggle them while running.
+	HiddenColumns []string `yaml:"hiddenColumns,omitempty"`
+	// MetricsAddr is the host:port the headless-mode Prometheus endpoint
+	// listens on, e.g. ":9109". Empty disables metrics.
+	MetricsAddr string `yaml:"metricsAddr,omitempty"`
+	// ControlSocket is the unix socket path headless mode accepts
+	// `kportal ctl` commands on. Empty disables the control channel.
+	ControlSocket string `yaml:"controlSocket,omitempty"`
+	// Kubeconfig is the kubeconfig file to read instead of KUBECONFIG or
+	// ~/.kube/config. A leading "~/" is the home directory. The
+	// -kubeconfig flag overrides it.
+	Kubeconfig string `yaml:"kubeconfig,omitempty"`
+	// Theme is the interactive UI color palette: "dark" (default) or
+	// "light". The -theme flag overrides it.
+	Theme string `yaml:"theme,omitempty"`
+	// PrivilegedPorts controls how a localPort the process lacks the
+	// privilege to bind (below 1024 for non-root users) is reported:
+	// "warn" (default) or "error".
+	PrivilegedPorts string `yaml:"privilegedPorts,omitempty"`
+	// ResolveCacheTTL is how long a resolved pod is reused before the
+	// resource is looked up again, e.g. "30s".
+	ResolveCacheTTL string    `yaml:"resolveCacheTTL,omitempty"`
+	Contexts        []Context `yaml:"contexts"`
+	// Include lists config files, relative to this one, whose contexts
+	// are merged into it.
+	Include []string `yaml:"include,omitempty"`
+	// includes holds the absolute paths of every file merged through
+	// Include, nested ones too, so the watcher can track them.
+	includes []string
+	// ResolveCacheMaxEntries caps the resolved-pod cache; the least
+	// recently used entries are dropped first. 0 means the default.
+	ResolveCacheMaxEntries int `yaml:"resolveCacheMaxEntries,omitempty"`
+	// HTTPLogMaxEntries caps the entries the TUI's HTTP log view keeps;
+	// the oldest are dropped first. 0 means the default.
+	HTTPLogMaxEntries int `yaml:"httpLogMaxEntries,omitempty"`
+	// Interpolate enables ${VAR} / $VAR expansion of environment variables in
+	// context names, namespace names, and forward resource/selector/alias.
+	Interpolate bool `yaml:"interpolate,omitempty"`
+	// SortOnWrite makes config mutations write contexts and namespaces
+	// sorted by name and forwards by local port.
+	SortOnWrite bool `yaml:"sortOnWrite,omitempty"`
+	// ReadOnly stops kportal from changing the config file, e.g. when it
+	// is managed in git: the TUI's add, edit and delete are disabled and
+	// the Mutator refuses to write it. The -read-only flag does the same.
+	ReadOnly bool `yaml:"readOnly,omitempty"`
+	// Prewarm resolves every forward's pod at startup, before the workers
+	// start, so their first connects hit the resolver cache.
+	Prewarm bool `yaml:"prewarm,omitempty"`
+	// HTTPLog enables HTTP logging for every forward without an httpLog
+	// key of its own. A forward opts out with `httpLog: false`.
+	HTTPLog bool `yaml:"httpLog,omitempty"`
 }
 
 // MDNSSpec configures mDNS (multicast DNS) hostname publishing
 // When enabled, forwards with aliases can be accessed via <alias>.local hostnames
 type MDNSSpec struct {
-	Enabled bool `yaml:"enabled"` // Enable mDNS hostname publishing
+	ServiceType string `yaml:"serviceType,omitempty"` // DNS-SD service type for SRV records, e.g. "_http._tcp"
+	Enabled     bool   `yaml:"enabled"`               // Enable mDNS hostname publishing
+}
+
+// NotifySpec configures desktop notifications for forwards going down and
+// recovering
+type NotifySpec struct {
+	Debounce string `yaml:"debounce,omitempty"` // e.g., "10s" - how long a change must last to be notified
+	Enabled  bool   `yaml:"enabled"`
+}
+
+// WebhookSpec configures a URL POSTed every forward state change
+type WebhookSpec struct {
+	Retries *int              `yaml:"retries,omitempty"` // retries after a failed attempt; nil means default, 0 none
+	Headers map[string]string `yaml:"headers,omitempty"` // e.g., Authorization
+	URL     string            `yaml:"url"`
+	Timeout string            `yaml:"timeout,omitempty"` // e.g., "5s" - per attempt
+}
+
+// GetTimeout returns the per-attempt timeout or default
+func (w WebhookSpec) GetTimeout() time.Duration {
+	return parseDurationOrDefault(w.Timeout, DefaultWebhookTimeout)
+}
+
+// GetRetries returns the retries after a failed attempt or default
+func (w WebhookSpec) GetRetries() int {
+	if w.Retries == nil {
+		return DefaultWebhookRetries
+	}
+	return *w.Retries
+}
+
+// HTTPLogLatencySpec configures when the HTTP log view, with latency
+// coloring on, marks a request as slow
+type HTTPLogLatencySpec struct {
+	Slow     string `yaml:"slow,omitempty"`     // e.g., "500ms" - colored as a warning
+	VerySlow string `yaml:"verySlow,omitempty"` // e.g., "2s" - colored as an error
 }
 
 // HealthCheckSpec configures health check behavior
@@ -80,10 +235,17 @@ type HealthCheckSpec struct {
 
 // ReliabilitySpec configures connection reliability features
 type ReliabilitySpec struct {
-	TCPKeepalive   string `yaml:"tcpKeepalive,omitempty"`
-	DialTimeout    string `yaml:"dialTimeout,omitempty"`
-	WatchdogPeriod string `yaml:"watchdogPeriod,omitempty"`
-	RetryOnStale   bool   `yaml:"retryOnStale,omitempty"`
+	ReconnectJitter           *float64 `yaml:"reconnectJitter,omitempty"`      // fraction of the delay, e.g. 0.2; nil means default
+	DiscoveryConcurrency      *int     `yaml:"discoveryConcurrency,omitempty"` // pod/service lookups per context; nil means default, 0 unlimited
+	TCPKeepalive              string   `yaml:"tcpKeepalive,omitempty"`
+	DialTimeout               string   `yaml:"dialTimeout,omitempty"`
+	WatchdogPeriod            string   `yaml:"watchdogPeriod,omitempty"`
+	ReconnectBaseDelay        string   `yaml:"reconnectBaseDelay,omitempty"`        // e.g., "1s" - first reconnect delay
+	ReconnectMaxDelay         string   `yaml:"reconnectMaxDelay,omitempty"`         // e.g., "30s" - delay cap
+	StartupStagger            string   `yaml:"startupStagger,omitempty"`            // e.g., "50ms" - spacing between worker starts
+	NetworkWatchInterval      string   `yaml:"networkWatchInterval,omitempty"`      // e.g., "5s" - "0" disables the network watch
+	DiscoveryConcurrencyTotal int      `yaml:"discoveryConcurrencyTotal,omitempty"` // lookups across all contexts; 0 means unlimited
+	RetryOnStale              bool     `yaml:"retryOnStale,omitempty"`
 }
 
 // parseDurationOrDefault parses a duration string and returns the default if empty or invalid.
@@ -169,11 +331,162 @@ func (c *Config) GetDialTimeout() time.Duration {
 	return parseDurationOrDefault(c.Reliability.DialTimeout, DefaultDialTimeout)
 }
 
+// GetReconnectBaseDelay returns the first reconnect backoff delay or default
+func (c *Config) GetReconnectBaseDelay() time.Duration {
+	if c.Reliability == nil {
+		return DefaultReconnectBaseDelay
+	}
+	return parseDurationOrDefault(c.Reliability.ReconnectBaseDelay, DefaultReconnectBaseDelay)
+}
+
+// GetReconnectMaxDelay returns the reconnect backoff delay cap or default
+func (c *Config) GetReconnectMaxDelay() time.Duration {
+	if c.Reliability == nil {
+		return DefaultReconnectMaxDelay
+	}
+	return parseDurationOrDefault(c.Reliability.ReconnectMaxDelay, DefaultReconnectMaxDelay)
+}
+
+// GetStartupStagger returns the spacing between the first connects of
+// forwards started together, or 0 to start them all at once.
+func (c *Config) GetStartupStagger() time.Duration {
+	if c.Reliability == nil {
+		return 0
+	}
+	return parseDurationOrDefault(c.Reliability.StartupStagger, 0)
+}
+
+// GetNetworkWatchInterval returns how often the network interfaces are
+// polled for changes, or 0 when the network watch is disabled.
+func (c *Config) GetNetworkWatchInterval() time.Duration {
+	if c.Reliability == nil {
+		return DefaultNetworkWatchInterval
+	}
+	return parseDurationOrDefault(c.Reliability.NetworkWatchInterval, DefaultNetworkWatchInterval)
+}
+
+// GetDiscoveryConcurrency returns the per-context lookup limit or default.
+// 0 means unlimited.
+func (c *Config) GetDiscoveryConcurrency() int {
+	if c.Reliability == nil || c.Reliability.DiscoveryConcurrency == nil {
+		return DefaultDiscoveryConcurrency
+	}
+	return *c.Reliability.DiscoveryConcurrency
+}
+
+// GetDiscoveryConcurrencyTotal returns the lookup limit across all
+// contexts. 0 means unlimited.
+func (c *Config) GetDiscoveryConcurrencyTotal() int {
+	if c.Reliability == nil {
+		return 0
+	}
+	return c.Reliability.DiscoveryConcurrencyTotal
+}
+
+// GetReconnectJitter returns the reconnect backoff jitter fraction or default
+func (c *Config) GetReconnectJitter() float64 {
+	if c.Reliability == nil || c.Reliability.ReconnectJitter == nil {
+		return DefaultReconnectJitter
+	}
+	return *c.Reliability.ReconnectJitter
+}
+
+// GetPrivilegedPorts returns how privileged local ports are reported,
+// defaulting to a warning.
+func (c *Config) GetPrivilegedPorts() string {
+	if c.PrivilegedPorts == "" {
+		return PrivilegedPortsWarn
+	}
+	return c.PrivilegedPorts
+}
+
+// GetKubeconfig returns the kubeconfig path with a leading "~/" expanded,
+// or "" to use the default discovery.
+func (c *Config) GetKubeconfig() string {
+	return ExpandHome(c.Kubeconfig)
+}
+
+// ExpandHome replaces a leading "~/" in path with the user's home
+// directory. Other paths, and paths when the home directory is unknown,
+// are returned unchanged.
+func ExpandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
+
+// GetResolveCacheTTL returns the resolved-pod cache TTL or default
+func (c *Config) GetResolveCacheTTL() time.Duration {
+	return parseDurationOrDefault(c.ResolveCacheTTL, DefaultResolveCacheTTL)
+}
+
+// GetResolveCacheMaxEntries returns the resolved-pod cache cap or default
+func (c *Config) GetResolveCacheMaxEntries() int {
+	if c.ResolveCacheMaxEntries <= 0 {
+		return DefaultResolveCacheMaxEntries
+	}
+	return c.ResolveCacheMaxEntries
+}
+
+// GetHTTPLogMaxEntries returns the HTTP log view's entry cap or default
+func (c *Config) GetHTTPLogMaxEntries() int {
+	if c.HTTPLogMaxEntries <= 0 {
+		return DefaultHTTPLogMaxEntries
+	}
+	return c.HTTPLogMaxEntries
+}
+
+// GetHTTPLogSlowLatency returns the latency at which the HTTP log view
+// colors a request as slow, or default
+func (c *Config) GetHTTPLogSlowLatency() time.Duration {
+	if c.HTTPLogLatency == nil {
+		return DefaultHTTPLogSlowLatency
+	}
+	return parseDurationOrDefault(c.HTTPLogLatency.Slow, DefaultHTTPLogSlowLatency)
+}
+
+// GetHTTPLogVerySlowLatency returns the latency at which the HTTP log view
+// colors a request as very slow, or default
+func (c *Config) GetHTTPLogVerySlowLatency() time.Duration {
+	if c.HTTPLogLatency == nil {
+		return DefaultHTTPLogVerySlowLatency
+	}
+	return parseDurationOrDefault(c.HTTPLogLatency.VerySlow, DefaultHTTPLogVerySlowLatency)
+}
+
 // IsMDNSEnabled returns whether mDNS hostname publishing is enabled
 func (c *Config) IsMDNSEnabled() bool {
 	return c.MDNS != nil && c.MDNS.Enabled
 }
 
+// IsNotifyEnabled returns true if desktop notifications are enabled in config
+func (c *Config) IsNotifyEnabled() bool {
+	return c.Notify != nil && c.Notify.Enabled
+}
+
+// GetNotifyDebounce returns how long a forward must stay down, or back up,
+// before it is notified
+func (c *Config) GetNotifyDebounce() time.Duration {
+	if c.Notify == nil {
+		return DefaultNotifyDebounce
+	}
+	return parseDurationOrDefault(c.Notify.Debounce, DefaultNotifyDebounce)
+}
+
+// GetMDNSServiceType returns the DNS-SD service type advertised for forwards
+func (c *Config) GetMDNSServiceType() string {
+	if c.MDNS == nil || c.MDNS.ServiceType == "" {
+		return DefaultMDNSServiceType
+	}
+	return c.MDNS.ServiceType
+}
+
 // Context represents a Kubernetes context with its namespaces
 type Context struct {
 	Name       string      `yaml:"name"`
@@ -191,6 +504,8 @@ type HTTPLogSpec struct {
 	LogFile        string `yaml:"logFile,omitempty"`
 	FilterPath     string `yaml:"filterPath,omitempty"`
 	MaxBodySize    int    `yaml:"maxBodySize,omitempty"`
+	MaxFileSize    int    `yaml:"maxFileSize,omitempty"` // Megabytes; 0 uses the default
+	MaxFiles       int    `yaml:"maxFiles,omitempty"`    // Rotated files kept; 0 uses the default
 	Enabled        bool   `yaml:"enabled"`
 	IncludeHeaders bool   `yaml:"includeHeaders,omitempty"`
 }
@@ -215,40 +530,248 @@ func (h *HTTPLogSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// ProbeSpec configures a per-forward HTTP health probe. The probe requests
+// path on the forward's local port and marks the forward Unhealthy when the
+// response status differs from ExpectedStatus.
+type ProbeSpec struct {
+	Path           string `yaml:"path"`                     // e.g., "/healthz"
+	Interval       string `yaml:"interval,omitempty"`       // e.g., "10s"
+	ExpectedStatus int    `yaml:"expectedStatus,omitempty"` // default 200
+}
+
+// GetInterval returns the probe interval or default value
+func (p *ProbeSpec) GetInterval() time.Duration {
+	return parseDurationOrDefault(p.Interval, DefaultProbeInterval)
+}
+
+// GetExpectedStatus returns the expected HTTP status or default value
+func (p *ProbeSpec) GetExpectedStatus() int {
+	if p.ExpectedStatus == 0 {
+		return DefaultProbeExpectedStatus
+	}
+	return p.ExpectedStatus
+}
+
+// PortMapping is one entry of a forward's ports list
+type PortMapping struct {
+	Port      int `yaml:"port"`
+	LocalPort int `yaml:"localPort"` // 0 picks a free port at start time
+}
+
 // Forward represents a single port-forward configuration
 type Forward struct {
-	HTTPLog       *HTTPLogSpec `yaml:"httpLog,omitempty"`
-	Resource      string       `yaml:"resource"`
-	Selector      string       `yaml:"selector"`
-	Protocol      string       `yaml:"protocol"`
-	Alias         string       `yaml:"alias,omitempty"`
-	contextName   string
-	namespaceName string
-	Port          int `yaml:"port"`
-	LocalPort     int `yaml:"localPort"`
+	HTTPLog     *HTTPLogSpec `yaml:"httpLog,omitempty"`
+	HealthCheck *ProbeSpec   `yaml:"healthCheck,omitempty"`
+	Enabled     *bool        `yaml:"enabled,omitempty"`     // nil means enabled
+	MDNSPublish *bool        `yaml:"mdnsPublish,omitempty"` // nil means publish when mDNS is enabled
+	// Ports forwards several remote ports of the resource, in place of
+	// port and localPort. GetAllForwards expands it into one forward per
+	// entry.
+	Ports []PortMapping `yaml:"ports,omitempty"`
+	// Tags group forwards, e.g. by team or app, for -tags and the main
+	// view filter. Any string is accepted.
+	Tags                []string `yaml:"tags,omitempty"`
+	Resource            string   `yaml:"resource"`
+	Selector            string   `yaml:"selector"`
+	Container           string   `yaml:"container,omitempty"` // container whose ports are used
+	PortName            string   `yaml:"portName,omitempty"`  // named service or container port, e.g. "http"
+	Protocol            string   `yaml:"protocol"`
+	Alias               string   `yaml:"alias,omitempty"`
+	Scheme              string   `yaml:"scheme,omitempty"`       // URL scheme the UI opens and copies, default http
+	TCPKeepalive        string   `yaml:"tcpKeepalive,omitempty"` // overrides reliability.tcpKeepalive
+	DialTimeout         string   `yaml:"dialTimeout,omitempty"`  // overrides reliability.dialTimeout
+	BindAddress         string   `yaml:"bindAddress,omitempty"`  // local IP to listen on, default both loopbacks
+	contextName         string
+	namespaceName       string
+	Port                int `yaml:"port,omitempty"`                // 0 when portName is looked up at connect time
+	LocalPort           int `yaml:"localPort"`                     // 0 picks a free port at start time
+	ReconnectMaxRetries int `yaml:"reconnectMaxRetries,omitempty"` // 0 retries forever
+	IdleTimeout         int `yaml:"idleTimeout,omitempty"`         // seconds without traffic before reconnecting; 0 disables
+	Order               int `yaml:"order,omitempty"`               // display position, lowest first; 0 lists the forward after the ordered ones
+	// TraceBytes keeps a bounded capture of the first bytes of each client
+	// connection in both directions, shown in the byte trace panel.
+	TraceBytes    bool `yaml:"traceBytes,omitempty"`
+	autoLocalPort bool
 }
 
 // ID returns a unique identifier for this forward configuration.
 // Format: alias:localPort (if alias provided) or context/namespace/resource:localPort
+// Forwards with an auto-assigned local port use auto-<remote port> in place
+// of the local port, so forwards to different ports of one resource differ
+// and the ID does not change once a port is assigned.
 func (f *Forward) ID() string {
+	port := strconv.Itoa(f.LocalPort)
+	if f.IsAutoLocalPort() {
+		port = "auto-" + f.RemotePortLabel()
+	}
 	if f.Alias != "" {
-		return fmt.Sprintf("%s:%d", f.Alias, f.LocalPort)
+		return fmt.Sprintf("%s:%s", f.Alias, port)
 	}
-	return fmt.Sprintf("%s/%s/%s:%d", f.contextName, f.namespaceName, f.Resource, f.LocalPort)
+	return fmt.Sprintf("%s/%s/%s:%s", f.contextName, f.namespaceName, f.Resource, port)
+}
+
+// IsAutoLocalPort returns true if the local port is picked at start time
+// rather than fixed in config.
+func (f *Forward) IsAutoLocalPort() bool {
+	return f.LocalPort == 0 || f.autoLocalPort
+}
+
+// AssignLocalPort sets the runtime local port of a forward configured with
+// localPort 0. The forward's ID is unchanged so it still matches its config
+// entry across reloads.
+func (f *Forward) AssignLocalPort(port int) {
+	f.LocalPort = port
+	f.autoLocalPort = true
 }
 
 // String returns a human-readable representation of the forward.
 // Format: alias:port→localPort (if alias provided) or context/namespace/resource:port→localPort
 func (f *Forward) String() string {
 	if f.Alias != "" {
-		return fmt.Sprintf("%s:%d→%d", f.Alias, f.Port, f.LocalPort)
+		return fmt.Sprintf("%s:%s→%d", f.Alias, f.RemotePortLabel(), f.LocalPort)
 	}
 	if f.Selector != "" {
-		return fmt.Sprintf("%s/%s/%s[%s]:%d→%d",
-			f.contextName, f.namespaceName, f.Resource, f.Selector, f.Port, f.LocalPort)
+		return fmt.Sprintf("%s/%s/%s[%s]:%s→%d",
+			f.contextName, f.namespaceName, f.Resource, f.Selector, f.RemotePortLabel(), f.LocalPort)
+	}
+	return fmt.Sprintf("%s/%s/%s:%s→%d",
+		f.contextName, f.namespaceName, f.Resource, f.RemotePortLabel(), f.LocalPort)
+}
+
+// RemotePortLabel returns the remote port for display: the port number, or
+// the port name when the number is resolved from the pod at start time.
+func (f *Forward) RemotePortLabel() string {
+	if f.Port == 0 && f.PortName != "" {
+		return f.PortName
 	}
-	return fmt.Sprintf("%s/%s/%s:%d→%d",
-		f.contextName, f.namespaceName, f.Resource, f.Port, f.LocalPort)
+	return strconv.Itoa(f.Port)
+}
+
+// Expand returns the forwards a config entry stands for: one per entry of
+// its ports list, each with that port and localPort, or the forward itself
+// when it has no ports list.
+func (f *Forward) Expand() []Forward {
+	if len(f.Ports) == 0 {
+		return []Forward{*f}
+	}
+	forwards := make([]Forward, 0, len(f.Ports))
+	for _, mapping := range f.Ports {
+		expanded := *f
+		expanded.Ports = nil
+		expanded.Port = mapping.Port
+		expanded.LocalPort = mapping.LocalPort
+		forwards = append(forwards, expanded)
+	}
+	return forwards
+}
+
+// ExpandsTo reports whether id is the ID of one of the forwards Expand
+// returns.
+func (f *Forward) ExpandsTo(id string) bool {
+	for _, expanded := range f.Expand() {
+		if expanded.ID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyTag reports whether the forward carries one of tags. Every forward
+// matches an empty list.
+func (f *Forward) HasAnyTag(tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, tag := range tags {
+		if slices.Contains(f.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByTags returns the forwards carrying one of tags, or forwards
+// unchanged when tags is empty.
+func FilterByTags(forwards []Forward, tags []string) []Forward {
+	if len(tags) == 0 {
+		return forwards
+	}
+	var filtered []Forward
+	for i := range forwards {
+		if forwards[i].HasAnyTag(tags) {
+			filtered = append(filtered, forwards[i])
+		}
+	}
+	return filtered
+}
+
+// SortByOrder sorts forwards by their order field, lowest first, followed
+// by the forwards without one. Forwards with the same order keep their
+// config order.
+func SortByOrder(forwards []Forward) {
+	slices.SortStableFunc(forwards, func(a, b Forward) int {
+		return cmp.Compare(OrderKey(a.Order), OrderKey(b.Order))
+	})
+}
+
+// OrderKey returns the sort key of a forward's order field, placing
+// forwards without one last.
+func OrderKey(order int) int {
+	if order <= 0 {
+		return math.MaxInt
+	}
+	return order
+}
+
+// ProfileTagPrefix marks a profile member that selects forwards by tag,
+// e.g. "tag:payments".
+const ProfileTagPrefix = "tag:"
+
+// HasProfile reports whether the config defines the profile name.
+func (c *Config) HasProfile(name string) bool {
+	_, ok := c.Profiles[name]
+	return ok
+}
+
+// ProfileNames returns the names of the defined profiles, sorted.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// FilterByProfile returns the forwards that are members of the profile
+// name, or forwards unchanged when name is empty.
+func (c *Config) FilterByProfile(forwards []Forward, name string) []Forward {
+	if name == "" {
+		return forwards
+	}
+	members := c.Profiles[name]
+	var filtered []Forward
+	for i := range forwards {
+		if inProfile(members, &forwards[i]) {
+			filtered = append(filtered, forwards[i])
+		}
+	}
+	return filtered
+}
+
+// inProfile reports whether one of a profile's members selects fwd.
+func inProfile(members []string, fwd *Forward) bool {
+	id := fwd.ID()
+	for _, member := range members {
+		if tag, ok := strings.CutPrefix(member, ProfileTagPrefix); ok {
+			if slices.Contains(fwd.Tags, tag) {
+				return true
+			}
+		} else if member == id {
+			return true
+		}
+	}
+	return false
 }
 
 // SetContext sets the context and namespace names for this forward.
@@ -268,11 +791,65 @@ func (f *Forward) GetNamespace() string {
 	return f.namespaceName
 }
 
+// GetProtocol returns the forward protocol, defaulting to tcp when omitted.
+func (f *Forward) GetProtocol() string {
+	if f.Protocol == "" {
+		return ProtocolTCP
+	}
+	return f.Protocol
+}
+
+// GetScheme returns the URL scheme used to open the forward in a browser
+func (f *Forward) GetScheme() string {
+	if f.Scheme == "" {
+		return SchemeHTTP
+	}
+	return f.Scheme
+}
+
+// GetIdleTimeout returns how long the tunnel may carry no traffic before it
+// is reconnected, or 0 when the idle timeout is disabled.
+func (f *Forward) GetIdleTimeout() time.Duration {
+	return time.Duration(f.IdleTimeout) * time.Second
+}
+
+// GetTCPKeepalive returns the forward's TCP keepalive override, or 0 to use
+// reliability.tcpKeepalive.
+func (f *Forward) GetTCPKeepalive() time.Duration {
+	return parseDurationOrDefault(f.TCPKeepalive, 0)
+}
+
+// GetDialTimeout returns the forward's dial timeout override, or 0 to use
+// reliability.dialTimeout.
+func (f *Forward) GetDialTimeout() time.Duration {
+	return parseDurationOrDefault(f.DialTimeout, 0)
+}
+
+// IsEnabled returns true unless the forward is disabled with `enabled: false`.
+func (f *Forward) IsEnabled() bool {
+	return f.Enabled == nil || *f.Enabled
+}
+
+// IsMDNSPublished returns false if the forward opts out of mDNS publishing
+// with `mdnsPublish: false`. The global mdns.enabled setting still applies.
+func (f *Forward) IsMDNSPublished() bool {
+	return f.MDNSPublish == nil || *f.MDNSPublish
+}
+
 // IsHTTPLogEnabled returns true if HTTP logging is enabled for this forward
 func (f *Forward) IsHTTPLogEnabled() bool {
 	return f.HTTPLog != nil && f.HTTPLog.Enabled
 }
 
+// ApplyHTTPLogDefault enables HTTP logging with default settings when
+// enabled is set and the forward has no httpLog setting of its own, so an
+// explicit `httpLog: false` still wins.
+func (f *Forward) ApplyHTTPLogDefault(enabled bool) {
+	if enabled && f.HTTPLog == nil {
+		f.HTTPLog = &HTTPLogSpec{Enabled: true}
+	}
+}
+
 // GetHTTPLogMaxBodySize returns the max body size for HTTP logging
 func (f *Forward) GetHTTPLogMaxBodySize() int {
 	if f.HTTPLog == nil || f.HTTPLog.MaxBodySize <= 0 {
@@ -281,6 +858,22 @@ func (f *Forward) GetHTTPLogMaxBodySize() int {
 	return f.HTTPLog.MaxBodySize
 }
 
+// GetHTTPLogMaxFileSize returns the HTTP log file size in bytes at which it is rotated
+func (f *Forward) GetHTTPLogMaxFileSize() int64 {
+	if f.HTTPLog == nil || f.HTTPLog.MaxFileSize <= 0 {
+		return DefaultHTTPLogMaxFileSize * 1024 * 1024
+	}
+	return int64(f.HTTPLog.MaxFileSize) * 1024 * 1024
+}
+
+// GetHTTPLogMaxFiles returns how many rotated HTTP log files to keep
+func (f *Forward) GetHTTPLogMaxFiles() int {
+	if f.HTTPLog == nil || f.HTTPLog.MaxFiles <= 0 {
+		return DefaultHTTPLogMaxFiles
+	}
+	return f.HTTPLog.MaxFiles
+}
+
 // GetMDNSAlias returns the alias to use for mDNS hostname registration.
 // If an explicit alias is set, it returns that.
 // Otherwise, it generates one from the resource name (e.g., "service/logto" -> "logto").
@@ -301,8 +894,70 @@ func (f *Forward) GetMDNSAlias() string {
 }
 
 // LoadConfig loads and parses the configuration file from the given path.
+// If path is a directory, every *.yaml fragment inside is loaded and merged
+// in filename order. Files listed under include are merged in after the
+// file that lists them. Environment variable references are expanded when
+// interpolation is enabled.
 func LoadConfig(path string) (*Config, error) {
-	// Validate file size before reading
+	return loadConfig(path, true)
+}
+
+// loadConfig reads and parses the configuration file. When expand is false,
+// environment variable references are left as written, which is what the
+// Mutator needs so that writing the file back does not bake in their values.
+func loadConfig(path string, expand bool) (*Config, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrConfigNotFound
+		}
+		return nil, fmt.Errorf("failed to stat config file: %w", err)
+	}
+
+	if fileInfo.IsDir() {
+		return loadConfigDir(path, expand)
+	}
+
+	data, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := parseConfig(data, expand)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.loadIncludes(path, expand); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ReadConfig parses a configuration read from r, e.g. standard input, with
+// the same rules as LoadConfig. Relative include paths are resolved against
+// the working directory. Input over maxConfigSize is rejected.
+func ReadConfig(r io.Reader) (*Config, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxConfigSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	if len(data) > maxConfigSize {
+		return nil, fmt.Errorf("config too large (max %d bytes)", maxConfigSize)
+	}
+
+	cfg, err := parseConfig(data, true)
+	if err != nil {
+		return nil, err
+	}
+	// loadIncludes resolves against the directory of the file it is given
+	if err := cfg.loadIncludes("stdin", true); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// readConfigFile reads a config file, rejecting files over maxConfigSize.
+func readConfigFile(path string) ([]byte, error) {
 	fileInfo, err := os.Stat(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -320,13 +975,20 @@ func LoadConfig(path string) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
-
-	return ParseConfig(data)
+	return data, nil
 }
 
 // ParseConfig parses YAML configuration data into a Config struct.
 // It uses strict parsing that rejects unknown keys to catch typos.
+// If the config sets `interpolate: true`, environment variable references
+// are expanded and an unset variable without a default is an error.
 func ParseConfig(data []byte) (*Config, error) {
+	return parseConfig(data, true)
+}
+
+// parseConfig parses YAML configuration data, optionally expanding
+// environment variable references.
+func parseConfig(data []byte, expand bool) (*Config, error) {
 	var cfg Config
 
 	// Use decoder with KnownFields to reject unknown keys (catches typos)
@@ -337,6 +999,12 @@ func ParseConfig(data []byte) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
 
+	if expand && cfg.Interpolate {
+		if err := cfg.interpolate(os.LookupEnv); err != nil {
+			return nil, fmt.Errorf("failed to interpolate config: %w", err)
+		}
+	}
+
 	// Populate runtime fields (context and namespace names)
 	for i := range cfg.Contexts {
 		ctx := &cfg.Contexts[i]
@@ -352,13 +1020,16 @@ func ParseConfig(data []byte) (*Config, error) {
 	return &cfg, nil
 }
 
-// GetAllForwards returns a flat list of all forwards across all contexts and namespaces.
+// GetAllForwards returns a flat list of all forwards across all contexts and
+// namespaces, with each ports list expanded into one forward per port.
 func (c *Config) GetAllForwards() []Forward {
 	var forwards []Forward
 
 	for _, ctx := range c.Contexts {
 		for _, ns := range ctx.Namespaces {
-			forwards = append(forwards, ns.Forwards...)
+			for i := range ns.Forwards {
+				forwards = append(forwards, ns.Forwards[i].Expand()...)
+			}
 		}
 	}
 
@@ -375,6 +375,121 @@ func TestConfig_GetDialTimeout(t *testing.T) {
 	}
 }
 
+// TestConfig_GetReconnectBackoff tests reconnect backoff getters
+func TestConfig_GetReconnectBackoff(t *testing.T) {
+	tests := []struct {
+		config       *Config
+		name         string
+		expectBase   time.Duration
+		expectMax    time.Duration
+		expectJitter float64
+	}{
+		{
+			name:         "nil reliability returns defaults",
+			config:       &Config{},
+			expectBase:   DefaultReconnectBaseDelay,
+			expectMax:    DefaultReconnectMaxDelay,
+			expectJitter: DefaultReconnectJitter,
+		},
+		{
+			name:         "empty values return defaults",
+			config:       &Config{Reliability: &ReliabilitySpec{}},
+			expectBase:   DefaultReconnectBaseDelay,
+			expectMax:    DefaultReconnectMaxDelay,
+			expectJitter: DefaultReconnectJitter,
+		},
+		{
+			name: "custom values",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					ReconnectBaseDelay: "500ms",
+					ReconnectMaxDelay:  "1m",
+					ReconnectJitter:    new(0.25),
+				},
+			},
+			expectBase:   500 * time.Millisecond,
+			expectMax:    time.Minute,
+			expectJitter: 0.25,
+		},
+		{
+			name: "explicit zero jitter disables it",
+			config: &Config{
+				Reliability: &ReliabilitySpec{ReconnectJitter: new(0.0)},
+			},
+			expectBase:   DefaultReconnectBaseDelay,
+			expectMax:    DefaultReconnectMaxDelay,
+			expectJitter: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectBase, tt.config.GetReconnectBaseDelay())
+			assert.Equal(t, tt.expectMax, tt.config.GetReconnectMaxDelay())
+			assert.Equal(t, tt.expectJitter, tt.config.GetReconnectJitter())
+		})
+	}
+}
+
+func TestConfig_GetStartupStagger(t *testing.T) {
+	assert.Zero(t, (&Config{}).GetStartupStagger())
+	assert.Zero(t, (&Config{Reliability: &ReliabilitySpec{}}).GetStartupStagger())
+	assert.Equal(t, 50*time.Millisecond, (&Config{Reliability: &ReliabilitySpec{StartupStagger: "50ms"}}).GetStartupStagger())
+}
+
+func TestConfig_GetNetworkWatchInterval(t *testing.T) {
+	assert.Equal(t, DefaultNetworkWatchInterval, (&Config{}).GetNetworkWatchInterval())
+	assert.Equal(t, DefaultNetworkWatchInterval, (&Config{Reliability: &ReliabilitySpec{}}).GetNetworkWatchInterval())
+	assert.Equal(t, 2*time.Second, (&Config{Reliability: &ReliabilitySpec{NetworkWatchInterval: "2s"}}).GetNetworkWatchInterval())
+	assert.Zero(t, (&Config{Reliability: &ReliabilitySpec{NetworkWatchInterval: "0"}}).GetNetworkWatchInterval())
+}
+
+// TestConfig_GetDiscoveryConcurrency tests discovery concurrency getters
+func TestConfig_GetDiscoveryConcurrency(t *testing.T) {
+	tests := []struct {
+		config      *Config
+		name        string
+		expectLimit int
+		expectTotal int
+	}{
+		{
+			name:        "nil reliability returns defaults",
+			config:      &Config{},
+			expectLimit: DefaultDiscoveryConcurrency,
+		},
+		{
+			name:        "empty values return defaults",
+			config:      &Config{Reliability: &ReliabilitySpec{}},
+			expectLimit: DefaultDiscoveryConcurrency,
+		},
+		{
+			name: "custom values",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					DiscoveryConcurrency:      new(2),
+					DiscoveryConcurrencyTotal: 8,
+				},
+			},
+			expectLimit: 2,
+			expectTotal: 8,
+		},
+		{
+			name: "explicit zero removes the limit",
+			config: &Config{
+				Reliability: &ReliabilitySpec{DiscoveryConcurrency: new(0)},
+			},
+			expectLimit: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectLimit, tt.config.GetDiscoveryConcurrency())
+			assert.Equal(t, tt.expectTotal, tt.config.GetDiscoveryConcurrencyTotal())
+		})
+	}
+}
+
 // TestConfig_IsMDNSEnabled tests mDNS enabled getter
 func TestConfig_IsMDNSEnabled(t *testing.T) {
 	tests := []struct {
@@ -411,6 +526,55 @@ func TestConfig_IsMDNSEnabled(t *testing.T) {
 	}
 }
 
+// TestConfig_GetMDNSServiceType tests mDNS service type getter
+func TestConfig_GetMDNSServiceType(t *testing.T) {
+	tests := []struct {
+		config   *Config
+		name     string
+		expected string
+	}{
+		{
+			name:     "nil MDNS returns default",
+			config:   &Config{},
+			expected: DefaultMDNSServiceType,
+		},
+		{
+			name:     "empty service type returns default",
+			config:   &Config{MDNS: &MDNSSpec{Enabled: true}},
+			expected: "_kportal._tcp",
+		},
+		{
+			name:     "custom service type",
+			config:   &Config{MDNS: &MDNSSpec{Enabled: true, ServiceType: "_http._tcp"}},
+			expected: "_http._tcp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.GetMDNSServiceType())
+		})
+	}
+}
+
+func TestConfig_Notify(t *testing.T) {
+	assert.False(t, (&Config{}).IsNotifyEnabled())
+	assert.False(t, (&Config{Notify: &NotifySpec{}}).IsNotifyEnabled())
+	assert.True(t, (&Config{Notify: &NotifySpec{Enabled: true}}).IsNotifyEnabled())
+
+	assert.Equal(t, DefaultNotifyDebounce, (&Config{}).GetNotifyDebounce())
+	assert.Equal(t, DefaultNotifyDebounce, (&Config{Notify: &NotifySpec{Enabled: true}}).GetNotifyDebounce())
+	assert.Equal(t, 30*time.Second, (&Config{Notify: &NotifySpec{Debounce: "30s"}}).GetNotifyDebounce())
+	assert.Zero(t, (&Config{Notify: &NotifySpec{Debounce: "0s"}}).GetNotifyDebounce())
+}
+
+func TestWebhookSpec_Getters(t *testing.T) {
+	assert.Equal(t, DefaultWebhookTimeout, WebhookSpec{}.GetTimeout())
+	assert.Equal(t, 3*time.Second, WebhookSpec{Timeout: "3s"}.GetTimeout())
+	assert.Equal(t, DefaultWebhookRetries, WebhookSpec{}.GetRetries())
+	assert.Equal(t, 0, WebhookSpec{Retries: new(0)}.GetRetries())
+}
+
 // TestForward_IsHTTPLogEnabled tests HTTP log enabled check
 func TestForward_IsHTTPLogEnabled(t *testing.T) {
 	tests := []struct {
@@ -505,6 +669,26 @@ func TestForward_GetHTTPLogMaxBodySize(t *testing.T) {
 	}
 }
 
+// TestForward_GetHTTPLogRotation tests HTTP log file rotation settings
+func TestForward_GetHTTPLogRotation(t *testing.T) {
+	t.Run("nil HTTPLog returns defaults", func(t *testing.T) {
+		fwd := Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080}
+		assert.Equal(t, int64(DefaultHTTPLogMaxFileSize*1024*1024), fwd.GetHTTPLogMaxFileSize())
+		assert.Equal(t, DefaultHTTPLogMaxFiles, fwd.GetHTTPLogMaxFiles())
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		fwd := Forward{
+			Resource:  "pod/app",
+			Port:      8080,
+			LocalPort: 8080,
+			HTTPLog:   &HTTPLogSpec{MaxFileSize: 10, MaxFiles: 2},
+		}
+		assert.Equal(t, int64(10*1024*1024), fwd.GetHTTPLogMaxFileSize())
+		assert.Equal(t, 2, fwd.GetHTTPLogMaxFiles())
+	})
+}
+
 // TestForward_GetMDNSAlias tests mDNS alias generation
 func TestForward_GetMDNSAlias(t *testing.T) {
 	tests := []struct {
@@ -701,3 +885,48 @@ func TestHTTPLogSpec_FullStruct(t *testing.T) {
 	assert.True(t, fwd.HTTPLog.IncludeHeaders)
 	assert.Equal(t, "/api/*", fwd.HTTPLog.FilterPath)
 }
+
+// TestForward_GetScheme tests the browser URL scheme default
+func TestForward_GetScheme(t *testing.T) {
+	assert.Equal(t, SchemeHTTP, (&Forward{}).GetScheme())
+	assert.Equal(t, SchemeHTTPS, (&Forward{Scheme: SchemeHTTPS}).GetScheme())
+}
+
+// TestConfig_GetPrivilegedPorts tests the privileged port mode default
+func TestConfig_GetPrivilegedPorts(t *testing.T) {
+	assert.Equal(t, PrivilegedPortsWarn, (&Config{}).GetPrivilegedPorts())
+	assert.Equal(t, PrivilegedPortsError, (&Config{PrivilegedPorts: PrivilegedPortsError}).GetPrivilegedPorts())
+}
+
+func TestConfig_GetKubeconfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	assert.Empty(t, (&Config{}).GetKubeconfig())
+	assert.Equal(t, "/etc/kube/config", (&Config{Kubeconfig: "/etc/kube/config"}).GetKubeconfig())
+	assert.Equal(t, filepath.Join(home, ".kube", "staging"), (&Config{Kubeconfig: "~/.kube/staging"}).GetKubeconfig())
+	assert.Equal(t, "~user/config", ExpandHome("~user/config"), "only the current user's home is expanded")
+}
+
+func TestConfig_GetResolveCache(t *testing.T) {
+	assert.Equal(t, DefaultResolveCacheTTL, (&Config{}).GetResolveCacheTTL())
+	assert.Equal(t, DefaultResolveCacheMaxEntries, (&Config{}).GetResolveCacheMaxEntries())
+
+	cfg := &Config{ResolveCacheTTL: "2m", ResolveCacheMaxEntries: 50}
+	assert.Equal(t, 2*time.Minute, cfg.GetResolveCacheTTL())
+	assert.Equal(t, 50, cfg.GetResolveCacheMaxEntries())
+}
+
+func TestConfig_GetHTTPLogMaxEntries(t *testing.T) {
+	assert.Equal(t, DefaultHTTPLogMaxEntries, (&Config{}).GetHTTPLogMaxEntries())
+	assert.Equal(t, 500, (&Config{HTTPLogMaxEntries: 500}).GetHTTPLogMaxEntries())
+}
+
+func TestConfig_GetHTTPLogLatency(t *testing.T) {
+	assert.Equal(t, DefaultHTTPLogSlowLatency, (&Config{}).GetHTTPLogSlowLatency())
+	assert.Equal(t, DefaultHTTPLogVerySlowLatency, (&Config{}).GetHTTPLogVerySlowLatency())
+
+	cfg := &Config{HTTPLogLatency: &HTTPLogLatencySpec{Slow: "200ms"}}
+	assert.Equal(t, 200*time.Millisecond, cfg.GetHTTPLogSlowLatency())
+	assert.Equal(t, DefaultHTTPLogVerySlowLatency, cfg.GetHTTPLogVerySlowLatency())
+}
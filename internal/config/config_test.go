@@ -1,11 +1,15 @@
 package config
 
 import (
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLoadConfig_ValidYAML(t *testing.T) {
@@ -92,6 +96,48 @@ func TestLoadConfig_InvalidYAML(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to parse YAML", "error should mention YAML parsing")
 }
 
+func TestReadConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "shared.yaml"), []byte(`contexts:
+  - name: staging
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/db
+            port: 5432
+            localPort: 5432
+`), 0o600))
+
+	cfg, err := ReadConfig(strings.NewReader(`include:
+  - shared.yaml
+contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 80
+            localPort: 8080
+`))
+	require.NoError(t, err)
+	assert.Len(t, cfg.GetAllForwards(), 2, "relative includes resolve against the working directory")
+
+	_, err = ReadConfig(strings.NewReader("contexts: [\n"))
+	assert.ErrorContains(t, err, "failed to parse YAML")
+
+	_, err = ReadConfig(io.LimitReader(zeroReader{}, maxConfigSize+1))
+	assert.ErrorContains(t, err, "config too large")
+}
+
+// zeroReader is an endless stream of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
 func TestLoadConfig_FileNotFound(t *testing.T) {
 	// Try to load a non-existent file
 	cfg, err := LoadConfig("/non/existent/path/.kportal.yaml")
@@ -190,6 +236,18 @@ func TestForward_String(t *testing.T) {
 			},
 			expectedString: "prod-cluster/database/service/postgres:5432→5433",
 		},
+		{
+			name: "named container port",
+			forward: Forward{
+				Resource:      "pod/my-app",
+				Container:     "app",
+				PortName:      "http",
+				LocalPort:     8080,
+				contextName:   "dev-cluster",
+				namespaceName: "default",
+			},
+			expectedString: "dev-cluster/default/pod/my-app:http→8080",
+		},
 		{
 			name: "pod with selector",
 			forward: Forward{
@@ -298,6 +356,155 @@ func TestConfig_GetAllForwards(t *testing.T) {
 	assert.Len(t, forwards, 4, "should return all forwards from all contexts and namespaces")
 }
 
+func TestConfig_GetAllForwards_ExpandsPorts(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            alias: api
+            ports:
+              - port: 8080
+                localPort: 8080
+              - port: 9090
+                localPort: 9090
+              - port: 50051
+                localPort: 0
+`))
+	require.NoError(t, err)
+
+	forwards := cfg.GetAllForwards()
+	require.Len(t, forwards, 3)
+	assert.Equal(t, []string{"api:8080", "api:9090", "api:auto-50051"}, []string{forwards[0].ID(), forwards[1].ID(), forwards[2].ID()})
+	assert.Equal(t, 9090, forwards[1].Port)
+	assert.Equal(t, "service/api", forwards[1].Resource)
+	assert.Equal(t, "dev", forwards[1].GetContext())
+	assert.Nil(t, forwards[1].Ports, "expanded forwards are single-port")
+
+	entry := cfg.Contexts[0].Namespaces[0].Forwards[0]
+	assert.True(t, entry.ExpandsTo("api:9090"))
+	assert.False(t, entry.ExpandsTo("api:7070"))
+}
+
+func TestConfig_FilterByTags(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 8080
+            localPort: 8080
+            tags: [payments, backend]
+          - resource: service/web
+            port: 80
+            localPort: 8081
+            tags: ["team frontend"]
+          - resource: service/db
+            port: 5432
+            localPort: 5432
+`))
+	require.NoError(t, err)
+	forwards := cfg.GetAllForwards()
+	assert.Equal(t, []string{"payments", "backend"}, forwards[0].Tags)
+	assert.Empty(t, NewValidator().ValidateConfig(cfg), "any tag string is valid")
+
+	assert.Len(t, FilterByTags(forwards, nil), 3, "no tags keeps every forward")
+
+	filtered := FilterByTags(forwards, []string{"backend", "team frontend"})
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "service/api", filtered[0].Resource)
+	assert.Equal(t, "service/web", filtered[1].Resource)
+
+	assert.Empty(t, FilterByTags(forwards, []string{"Payments"}), "tags are case-sensitive")
+	assert.True(t, forwards[2].HasAnyTag(nil))
+	assert.False(t, forwards[2].HasAnyTag([]string{"backend"}))
+}
+
+func TestSortByOrder(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 8080
+            localPort: 8080
+          - resource: service/web
+            port: 80
+            localPort: 8081
+            order: 2
+  - name: prod
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/db
+            port: 5432
+            localPort: 5432
+          - resource: service/cache
+            port: 6379
+            localPort: 6379
+            order: 1
+          - resource: service/queue
+            port: 5672
+            localPort: 5672
+            order: 2
+`))
+	require.NoError(t, err)
+	forwards := cfg.GetAllForwards()
+
+	SortByOrder(forwards)
+	var resources []string
+	for _, fwd := range forwards {
+		resources = append(resources, fwd.Resource)
+	}
+	assert.Equal(t, []string{"service/cache", "service/web", "service/queue", "service/api", "service/db"}, resources,
+		"ordered forwards come first, ties and the rest keep config order")
+}
+
+func TestConfig_FilterByProfile(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`profiles:
+  frontend: [web:3000, "tag:ui"]
+  data: [dev/default/service/postgres:5432]
+contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/web
+            port: 80
+            localPort: 3000
+            alias: web
+          - resource: service/admin
+            port: 80
+            localPort: 3001
+            alias: admin
+            tags: [ui]
+          - resource: service/postgres
+            port: 5432
+            localPort: 5432
+`))
+	require.NoError(t, err)
+	forwards := cfg.GetAllForwards()
+
+	ids := func(forwards []Forward) []string {
+		var ids []string
+		for i := range forwards {
+			ids = append(ids, forwards[i].ID())
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"web:3000", "admin:3001"}, ids(cfg.FilterByProfile(forwards, "frontend")))
+	assert.Equal(t, []string{"dev/default/service/postgres:5432"}, ids(cfg.FilterByProfile(forwards, "data")))
+	assert.Len(t, cfg.FilterByProfile(forwards, ""), 3, "no profile keeps every forward")
+	assert.Empty(t, cfg.FilterByProfile(forwards, "missing"))
+
+	assert.True(t, cfg.HasProfile("data"))
+	assert.False(t, cfg.HasProfile("missing"))
+	assert.Equal(t, []string{"data", "frontend"}, cfg.ProfileNames())
+}
+
 func TestForward_SetContext(t *testing.T) {
 	fwd := Forward{
 		Resource:  "pod/my-app",
@@ -314,6 +521,113 @@ func TestForward_SetContext(t *testing.T) {
 	assert.Equal(t, "my-namespace", fwd.GetNamespace())
 }
 
+func TestForward_IsMDNSPublished(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`mdns:
+  enabled: true
+contexts:
+  - name: test
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/implicit
+            port: 80
+            localPort: 8080
+          - resource: service/public
+            port: 80
+            localPort: 8081
+            mdnsPublish: true
+          - resource: service/internal
+            port: 80
+            localPort: 8082
+            mdnsPublish: false
+`))
+	assert.NoError(t, err)
+
+	forwards := cfg.Contexts[0].Namespaces[0].Forwards
+	assert.True(t, forwards[0].IsMDNSPublished(), "omitted mdnsPublish should default to true")
+	assert.True(t, forwards[1].IsMDNSPublished())
+	assert.False(t, forwards[2].IsMDNSPublished())
+}
+
+func TestProbeSpec_Defaults(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`contexts:
+  - name: test
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 80
+            localPort: 8080
+            healthCheck:
+              path: /healthz
+          - resource: service/web
+            port: 80
+            localPort: 8081
+            healthCheck:
+              path: /ready
+              interval: 30s
+              expectedStatus: 204
+`))
+	require.NoError(t, err)
+
+	forwards := cfg.Contexts[0].Namespaces[0].Forwards
+	require.NotNil(t, forwards[0].HealthCheck)
+	assert.Equal(t, "/healthz", forwards[0].HealthCheck.Path)
+	assert.Equal(t, DefaultProbeInterval, forwards[0].HealthCheck.GetInterval())
+	assert.Equal(t, DefaultProbeExpectedStatus, forwards[0].HealthCheck.GetExpectedStatus())
+
+	require.NotNil(t, forwards[1].HealthCheck)
+	assert.Equal(t, 30*time.Second, forwards[1].HealthCheck.GetInterval())
+	assert.Equal(t, 204, forwards[1].HealthCheck.GetExpectedStatus())
+}
+
+func TestForward_IsEnabled(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`contexts:
+  - name: test
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/implicit
+            port: 80
+            localPort: 8080
+          - resource: service/on
+            port: 80
+            localPort: 8081
+            enabled: true
+          - resource: service/off
+            port: 80
+            localPort: 8082
+            enabled: false
+`))
+	assert.NoError(t, err)
+
+	forwards := cfg.Contexts[0].Namespaces[0].Forwards
+	assert.True(t, forwards[0].IsEnabled(), "omitted enabled should default to true")
+	assert.True(t, forwards[1].IsEnabled())
+	assert.False(t, forwards[2].IsEnabled())
+
+	// Disabled forwards are still part of the config and validated
+	assert.Len(t, cfg.GetAllForwards(), 3)
+}
+
+func TestForward_AssignLocalPort(t *testing.T) {
+	fwd := Forward{Resource: "service/api", Port: 80}
+	fwd.SetContext("dev", "default")
+
+	assert.True(t, fwd.IsAutoLocalPort())
+	assert.Equal(t, "dev/default/service/api:auto-80", fwd.ID())
+
+	fwd.AssignLocalPort(41234)
+
+	assert.Equal(t, 41234, fwd.LocalPort)
+	assert.True(t, fwd.IsAutoLocalPort())
+	assert.Equal(t, "dev/default/service/api:auto-80", fwd.ID(), "ID must not change once a port is assigned")
+	assert.Equal(t, "dev/default/service/api:80→41234", fwd.String())
+
+	fixed := Forward{Resource: "service/api", Port: 80, LocalPort: 8080}
+	assert.False(t, fixed.IsAutoLocalPort())
+}
+
 func TestHTTPLogSpec_UnmarshalYAML(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -396,6 +710,36 @@ func TestHTTPLogSpec_UnmarshalYAML(t *testing.T) {
 	}
 }
 
+func TestForward_ApplyHTTPLogDefault(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`httpLog: true
+contexts:
+  - name: test
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 8080
+            localPort: 8080
+          - resource: service/db
+            port: 5432
+            localPort: 5432
+            httpLog: false
+`))
+	require.NoError(t, err)
+	assert.True(t, cfg.HTTPLog)
+
+	forwards := cfg.GetAllForwards()
+	for i := range forwards {
+		forwards[i].ApplyHTTPLogDefault(cfg.HTTPLog)
+	}
+	assert.True(t, forwards[0].IsHTTPLogEnabled(), "forwards without httpLog follow the default")
+	assert.False(t, forwards[1].IsHTTPLogEnabled(), "httpLog: false opts out")
+
+	fwd := Forward{Resource: "pod/app", Port: 80, LocalPort: 8080}
+	fwd.ApplyHTTPLogDefault(false)
+	assert.Nil(t, fwd.HTTPLog)
+}
+
 func TestNewEmptyConfig(t *testing.T) {
 	cfg := NewEmptyConfig()
 	assert.NotNil(t, cfg, "NewEmptyConfig should return non-nil config")
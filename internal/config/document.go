@@ -0,0 +1,344 @@
+package config
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultIndent is the indent width for files with no indented line to take
+// it from. It matches yaml.Marshal.
+const defaultIndent = 4
+
+// document is the YAML node tree of a config file. The mutator edits it in
+// place instead of re-marshaling a Config, so comments, key order and the
+// quoting of untouched entries survive a write.
+//
+// Contexts, namespaces and forwards are addressed by index. A Config decoded
+// from the same file lists them in the same order.
+type document struct {
+	doc    *yaml.Node // document node, holds comments around the root
+	root   *yaml.Node // top-level mapping
+	indent int
+}
+
+// newDocument returns a document with an empty top-level mapping
+func newDocument() *document {
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	return &document{
+		doc:    &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}},
+		root:   root,
+		indent: defaultIndent,
+	}
+}
+
+// parseDocument parses config file data into a document
+func parseDocument(data []byte) (*document, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if doc.Kind == 0 {
+		// Empty or comment-only file
+		d := newDocument()
+		d.doc.HeadComment = doc.HeadComment
+		return d, nil
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("failed to parse config: top level is not a mapping")
+	}
+	return &document{doc: &doc, root: doc.Content[0], indent: detectIndent(data)}, nil
+}
+
+// detectIndent returns the indent of the first indented line, so a
+// rewritten file keeps the width the user chose.
+func detectIndent(data []byte) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed == line || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if indent := len(line) - len(trimmed); indent >= 2 {
+			return indent
+		}
+		break
+	}
+	return defaultIndent
+}
+
+// bytes encodes the document
+func (d *document) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(d.indent)
+	if err := enc.Encode(d.doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// forwards returns the forwards sequence of the named context and
+// namespace, appending either when missing. Names are compared after
+// resolve, as in Mutator.findOrCreateContext.
+func (d *document) forwards(contextName, namespaceName string, resolve func(string) string) *yaml.Node {
+	ctx := findOrAppendNamed(sequenceValue(d.root, "contexts"), contextName, resolve)
+	ns := findOrAppendNamed(sequenceValue(ctx, "namespaces"), namespaceName, resolve)
+	return sequenceValue(ns, "forwards")
+}
+
+// forwardsAt returns the forwards sequence of namespace ni in context ci
+func (d *document) forwardsAt(ci, ni int) *yaml.Node {
+	contexts := sequenceValue(d.root, "contexts")
+	namespaces := sequenceValue(contexts.Content[ci], "namespaces")
+	return sequenceValue(namespaces.Content[ni], "forwards")
+}
+
+// appendForward appends fwd to a forwards sequence
+func (d *document) appendForward(seq *yaml.Node, fwd Forward) error {
+	node, err := forwardNode(fwd)
+	if err != nil {
+		return err
+	}
+	appendItem(seq, node)
+	return nil
+}
+
+// updateForward rewrites forward fi of namespace ni in context ci to fwd.
+// Only keys whose values changed are replaced, and comments stay in place.
+func (d *document) updateForward(ci, ni, fi int, fwd Forward) error {
+	node, err := forwardNode(fwd)
+	if err != nil {
+		return err
+	}
+	old := d.forwardsAt(ci, ni).Content[fi]
+
+	// Keep the `httpLog: true` shorthand when only the toggle is set
+	if v := mappingValue(old, "httpLog"); v != nil && v.Kind == yaml.ScalarNode &&
+		fwd.HTTPLog != nil && *fwd.HTTPLog == (HTTPLogSpec{Enabled: fwd.HTTPLog.Enabled}) {
+		setMappingValue(node, "httpLog", boolNode(fwd.HTTPLog.Enabled))
+	}
+
+	mergeMapping(old, node)
+	return nil
+}
+
+// removeForward removes forward fi of namespace ni in context ci
+func (d *document) removeForward(ci, ni, fi int) {
+	seq := d.forwardsAt(ci, ni)
+	seq.Content = append(seq.Content[:fi], seq.Content[fi+1:]...)
+}
+
+// filterForwards keeps the forwards for which keep returns true and drops
+// namespaces left without forwards, matching Mutator.RemoveForwards.
+func (d *document) filterForwards(keep func(ci, ni, fi int) bool) {
+	contexts := mappingValue(d.root, "contexts")
+	if contexts == nil {
+		return
+	}
+	for ci, ctx := range contexts.Content {
+		namespaces := mappingValue(ctx, "namespaces")
+		if namespaces == nil {
+			continue
+		}
+		keptNamespaces := namespaces.Content[:0]
+		for ni, ns := range namespaces.Content {
+			var kept []*yaml.Node
+			if seq := mappingValue(ns, "forwards"); seq != nil {
+				for fi, fwd := range seq.Content {
+					if keep(ci, ni, fi) {
+						kept = append(kept, fwd)
+					}
+				}
+				seq.Content = kept
+			}
+			if len(kept) > 0 {
+				keptNamespaces = append(keptNamespaces, ns)
+			}
+		}
+		namespaces.Content = keptNamespaces
+	}
+}
+
+// sort orders contexts and namespaces by resolved name and forwards by
+// local port. The sort is stable, so auto-assigned ports (0) keep their
+// relative order. Comments move with the entry they belong to.
+func (d *document) sort(resolve func(string) string) {
+	name := func(item *yaml.Node) string {
+		if n := mappingValue(item, "name"); n != nil {
+			return resolve(n.Value)
+		}
+		return ""
+	}
+	localPort := func(item *yaml.Node) int {
+		if n := mappingValue(item, "localPort"); n != nil {
+			port, _ := strconv.Atoi(n.Value)
+			return port
+		}
+		return 0
+	}
+
+	contexts := mappingValue(d.root, "contexts")
+	if contexts == nil {
+		return
+	}
+	sortItems(contexts, name)
+	for _, ctx := range contexts.Content {
+		namespaces := mappingValue(ctx, "namespaces")
+		if namespaces == nil {
+			continue
+		}
+		sortItems(namespaces, name)
+		for _, ns := range namespaces.Content {
+			if seq := mappingValue(ns, "forwards"); seq != nil {
+				sortItems(seq, localPort)
+			}
+		}
+	}
+}
+
+// sortItems stably sorts the items of seq by key
+func sortItems[K cmp.Ordered](seq *yaml.Node, key func(*yaml.Node) K) {
+	slices.SortStableFunc(seq.Content, func(a, b *yaml.Node) int {
+		return cmp.Compare(key(a), key(b))
+	})
+}
+
+// forwardNode encodes fwd as a mapping node. Empty strings are left out:
+// they decode the same as a missing key and would only add noise such as
+// `selector: ""`.
+func forwardNode(fwd Forward) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(fwd); err != nil {
+		return nil, fmt.Errorf("failed to marshal forward: %w", err)
+	}
+	dropEmptyStrings(&node)
+	return &node, nil
+}
+
+// dropEmptyStrings removes keys with an empty string value from a mapping
+// and the mappings nested in it
+func dropEmptyStrings(m *yaml.Node) {
+	content := m.Content[:0]
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		key, value := m.Content[i], m.Content[i+1]
+		if value.Kind == yaml.ScalarNode && value.ShortTag() == "!!str" && value.Value == "" {
+			continue
+		}
+		if value.Kind == yaml.MappingNode {
+			dropEmptyStrings(value)
+		}
+		content = append(content, key, value)
+	}
+	m.Content = content
+}
+
+// mergeMapping makes dst hold the keys and values of src. Keys of dst keep
+// their order, comments and, when unchanged, their values. Keys missing
+// from src are removed and new keys are appended.
+func mergeMapping(dst, src *yaml.Node) {
+	content := make([]*yaml.Node, 0, len(src.Content))
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		key := dst.Content[i]
+		if value := mappingValue(src, key.Value); value != nil {
+			content = append(content, key, mergeValue(dst.Content[i+1], value))
+		}
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		if mappingValue(dst, src.Content[i].Value) == nil {
+			content = append(content, src.Content[i], src.Content[i+1])
+		}
+	}
+	dst.Content = content
+}
+
+// mergeValue returns the node to store in place of old for the value next.
+// An equal scalar keeps its original quoting; a changed value keeps the
+// comments of the one it replaces.
+func mergeValue(old, next *yaml.Node) *yaml.Node {
+	switch {
+	case old.Kind == yaml.ScalarNode && next.Kind == yaml.ScalarNode &&
+		old.Value == next.Value && old.ShortTag() == next.ShortTag():
+		return old
+	case old.Kind == yaml.MappingNode && next.Kind == yaml.MappingNode:
+		mergeMapping(old, next)
+		return old
+	}
+	next.HeadComment, next.LineComment, next.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	return next
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets the value of key in a mapping node
+func setMappingValue(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, stringNode(key), value)
+}
+
+// sequenceValue returns the sequence under key in a mapping node. A
+// missing key or one with no value (`forwards:`) gets an empty sequence.
+func sequenceValue(m *yaml.Node, key string) *yaml.Node {
+	value := mappingValue(m, key)
+	if value == nil {
+		value = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		m.Content = append(m.Content, stringNode(key), value)
+	} else if value.Kind == yaml.ScalarNode && value.ShortTag() == "!!null" {
+		value.Kind, value.Tag, value.Value = yaml.SequenceNode, "!!seq", ""
+	}
+	return value
+}
+
+// findOrAppendNamed returns the item of seq whose resolved name is name,
+// appending a new `name:` mapping when there is none
+func findOrAppendNamed(seq *yaml.Node, name string, resolve func(string) string) *yaml.Node {
+	for _, item := range seq.Content {
+		if n := mappingValue(item, "name"); n != nil && resolve(n.Value) == name {
+			return item
+		}
+	}
+	item := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{stringNode("name"), stringNode(name)}}
+	appendItem(seq, item)
+	return item
+}
+
+// appendItem appends a block item to seq. An empty flow sequence such as
+// `forwards: []` is switched to block style so the item is not written
+// inline.
+func appendItem(seq, item *yaml.Node) {
+	seq.Style &^= yaml.FlowStyle
+	seq.Content = append(seq.Content, item)
+}
+
+// stringNode returns a string scalar, quoted on output when needed
+func stringNode(s string) *yaml.Node {
+	node := &yaml.Node{}
+	node.SetString(s)
+	return node
+}
+
+// boolNode returns a bool scalar
+func boolNode(b bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(b)}
+}
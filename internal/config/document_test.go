@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectIndent(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want int
+	}{
+		{name: "two spaces", data: "contexts:\n  - name: dev\n", want: 2},
+		{name: "compact sequence", data: "contexts:\n- name: dev\n  namespaces: []\n", want: 2},
+		{name: "skips comments", data: "# top\n    # indented comment\ncontexts:\n    - name: dev\n", want: 4},
+		{name: "nothing indented", data: "contexts: []\n", want: defaultIndent},
+		{name: "empty", data: "", want: defaultIndent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectIndent([]byte(tt.data)))
+		})
+	}
+}
+
+// TestDocument_AppendToEmptyFlowSequence verifies `contexts: []` becomes a
+// block sequence when the first context is added
+func TestDocument_AppendToEmptyFlowSequence(t *testing.T) {
+	doc, err := parseDocument([]byte("# forwards\ncontexts: []\n"))
+	require.NoError(t, err)
+
+	seq := doc.forwards("dev", "default", envResolver(nil))
+	require.NoError(t, doc.appendForward(seq, Forward{Resource: "pod/app", Protocol: "tcp", Port: 80, LocalPort: 8080}))
+
+	data, err := doc.bytes()
+	require.NoError(t, err)
+	assert.Equal(t, `# forwards
+contexts:
+    - name: dev
+      namespaces:
+        - name: default
+          forwards:
+            - resource: pod/app
+              protocol: tcp
+              port: 80
+              localPort: 8080
+`, string(data))
+}
+
+func TestParseDocument_RejectsNonMapping(t *testing.T) {
+	_, err := parseDocument([]byte("- a\n- b\n"))
+	assert.Error(t, err)
+}
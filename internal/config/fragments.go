@@ -0,0 +1,149 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// fragmentPattern selects the files loaded from a config directory.
+const fragmentPattern = "*.yaml"
+
+// ErrConfigIsDirectory is returned by the Mutator when the configuration is a
+// directory of fragments, which it cannot rewrite as a single file.
+var ErrConfigIsDirectory = errors.New("configuration is a directory of fragments; edit the fragment files directly")
+
+// IsFragment reports whether name is a file loaded from a config directory.
+// Hidden files, such as editor swap files and the Mutator's temp file, are skipped.
+func IsFragment(name string) bool {
+	base := filepath.Base(name)
+	if base == "" || base[0] == '.' {
+		return false
+	}
+	ok, _ := filepath.Match(fragmentPattern, base)
+	return ok
+}
+
+// fragmentPaths returns the fragments in dir sorted by filename.
+func fragmentPaths(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config directory: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !IsFragment(entry.Name()) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// loadConfigDir loads every fragment in dir and merges them in filename order.
+// A directory without fragments yields an empty configuration.
+func loadConfigDir(dir string, expand bool) (*Config, error) {
+	paths, err := fragmentPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := NewEmptyConfig()
+	for _, path := range paths {
+		fragment, err := loadConfig(path, expand)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		merged.merge(fragment)
+	}
+	return merged, nil
+}
+
+// merge folds a later fragment into c. Contexts and namespaces with the same
+// name are combined and their forwards appended; top-level settings set in
+// the fragment replace earlier ones, and so do its profiles by name.
+func (c *Config) merge(fragment *Config) {
+	if fragment.HealthCheck != nil {
+		c.HealthCheck = fragment.HealthCheck
+	}
+	if fragment.Reliability != nil {
+		c.Reliability = fragment.Reliability
+	}
+	if fragment.MDNS != nil {
+		c.MDNS = fragment.MDNS
+	}
+	if fragment.Notify != nil {
+		c.Notify = fragment.Notify
+	}
+	if len(fragment.Webhooks) > 0 {
+		c.Webhooks = fragment.Webhooks
+	}
+	if len(fragment.HiddenColumns) > 0 {
+		c.HiddenColumns = fragment.HiddenColumns
+	}
+	if fragment.MetricsAddr != "" {
+		c.MetricsAddr = fragment.MetricsAddr
+	}
+	if fragment.ControlSocket != "" {
+		c.ControlSocket = fragment.ControlSocket
+	}
+	if fragment.Theme != "" {
+		c.Theme = fragment.Theme
+	}
+	if fragment.PrivilegedPorts != "" {
+		c.PrivilegedPorts = fragment.PrivilegedPorts
+	}
+	for name, members := range fragment.Profiles {
+		if c.Profiles == nil {
+			c.Profiles = make(map[string][]string)
+		}
+		c.Profiles[name] = members
+	}
+	c.Interpolate = c.Interpolate || fragment.Interpolate
+	c.SortOnWrite = c.SortOnWrite || fragment.SortOnWrite
+	c.ReadOnly = c.ReadOnly || fragment.ReadOnly
+	c.includes = append(c.includes, fragment.includes...)
+	c.mergeContexts(fragment)
+}
+
+// mergeContexts appends the forwards of other to c. Contexts and namespaces
+// with the same name are combined.
+func (c *Config) mergeContexts(other *Config) {
+	for _, ctx := range other.Contexts {
+		target := c.findContext(ctx.Name)
+		if target == nil {
+			c.Contexts = append(c.Contexts, Context{Name: ctx.Name})
+			target = &c.Contexts[len(c.Contexts)-1]
+		}
+		for _, ns := range ctx.Namespaces {
+			targetNS := target.findNamespace(ns.Name)
+			if targetNS == nil {
+				target.Namespaces = append(target.Namespaces, Namespace{Name: ns.Name})
+				targetNS = &target.Namespaces[len(target.Namespaces)-1]
+			}
+			targetNS.Forwards = append(targetNS.Forwards, ns.Forwards...)
+		}
+	}
+}
+
+func (c *Config) findContext(name string) *Context {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i]
+		}
+	}
+	return nil
+}
+
+func (ctx *Context) findNamespace(name string) *Namespace {
+	for i := range ctx.Namespaces {
+		if ctx.Namespaces[i].Name == name {
+			return &ctx.Namespaces[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFragment(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+}
+
+func TestLoadConfig_Directory(t *testing.T) {
+	dir := t.TempDir()
+	writeFragment(t, dir, "20-db.yaml", `healthCheck:
+  interval: "10s"
+contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/postgres
+            port: 5432
+            localPort: 5432
+`)
+	writeFragment(t, dir, "10-api.yaml", `healthCheck:
+  interval: "5s"
+metricsAddr: ":9109"
+contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 80
+            localPort: 8080
+  - name: prod
+    namespaces:
+      - name: web
+        forwards:
+          - resource: service/web
+            port: 80
+            localPort: 9080
+`)
+	// Skipped: wrong extension, hidden file, subdirectory
+	writeFragment(t, dir, "notes.txt", "not yaml")
+	writeFragment(t, dir, ".10-api.yaml.swp", "garbage")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "old.yaml"), 0700))
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Contexts, 2)
+	assert.Equal(t, "dev", cfg.Contexts[0].Name)
+	assert.Equal(t, "prod", cfg.Contexts[1].Name)
+
+	// Same context and namespace across fragments are combined in filename order
+	require.Len(t, cfg.Contexts[0].Namespaces, 1)
+	forwards := cfg.Contexts[0].Namespaces[0].Forwards
+	require.Len(t, forwards, 2)
+	assert.Equal(t, "service/api", forwards[0].Resource)
+	assert.Equal(t, "service/postgres", forwards[1].Resource)
+	assert.Equal(t, "dev/default/service/postgres:5432", forwards[1].ID())
+
+	// Later fragments override top-level settings they set
+	require.NotNil(t, cfg.HealthCheck)
+	assert.Equal(t, "10s", cfg.HealthCheck.Interval)
+	assert.Equal(t, ":9109", cfg.MetricsAddr)
+
+	assert.Empty(t, NewValidator().ValidateConfig(cfg))
+}
+
+func TestLoadConfig_DirectoryMergesProfiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFragment(t, dir, "10-a.yaml", `profiles:
+  frontend: [web:3000]
+  data: [db:5432]
+contexts: []
+`)
+	writeFragment(t, dir, "20-b.yaml", `profiles:
+  frontend: ["tag:ui"]
+contexts: []
+`)
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"frontend": {"tag:ui"},
+		"data":     {"db:5432"},
+	}, cfg.Profiles)
+}
+
+func TestLoadConfig_DirectoryEmpty(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	require.NoError(t, err)
+	assert.True(t, cfg.IsEmpty())
+}
+
+func TestLoadConfig_DirectoryInvalidFragment(t *testing.T) {
+	dir := t.TempDir()
+	writeFragment(t, dir, "10-api.yaml", "contexts: []\n")
+	writeFragment(t, dir, "20-broken.yaml", "contexts: [this is invalid\n")
+
+	_, err := LoadConfig(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "20-broken.yaml")
+	assert.Contains(t, err.Error(), "failed to parse YAML")
+}
+
+func TestLoadConfig_DirectoryInterpolatesPerFragment(t *testing.T) {
+	t.Setenv("KPORTAL_TEST_NS", "staging")
+
+	dir := t.TempDir()
+	writeFragment(t, dir, "10-env.yaml", `interpolate: true
+contexts:
+  - name: dev
+    namespaces:
+      - name: ${KPORTAL_TEST_NS}
+        forwards:
+          - resource: service/api
+            port: 80
+            localPort: 8080
+`)
+	writeFragment(t, dir, "20-literal.yaml", `contexts:
+  - name: dev
+    namespaces:
+      - name: $literal
+        forwards:
+          - resource: service/web
+            port: 80
+            localPort: 9080
+`)
+
+	cfg, err := LoadConfig(dir)
+	require.NoError(t, err)
+	require.Len(t, cfg.Contexts[0].Namespaces, 2)
+	assert.Equal(t, "staging", cfg.Contexts[0].Namespaces[0].Name)
+	assert.Equal(t, "$literal", cfg.Contexts[0].Namespaces[1].Name)
+}
+
+func TestIsFragment(t *testing.T) {
+	assert.True(t, IsFragment("/etc/kportal.d/10-api.yaml"))
+	assert.False(t, IsFragment("/etc/kportal.d/10-api.yml"))
+	assert.False(t, IsFragment("/etc/kportal.d/.kportal.yaml.tmp"))
+	assert.False(t, IsFragment("/etc/kportal.d/.hidden.yaml"))
+	assert.False(t, IsFragment("/etc/kportal.d/README.md"))
+}
+
+func TestMutator_RejectsDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFragment(t, dir, "10-api.yaml", "contexts: []\n")
+	m := NewMutator(dir)
+
+	err := m.AddForward("dev", "default", Forward{Resource: "service/api", Port: 80, LocalPort: 8080})
+	assert.True(t, errors.Is(err, ErrConfigIsDirectory))
+	assert.True(t, errors.Is(m.RemoveForwardByID("api:8080"), ErrConfigIsDirectory))
+	assert.True(t, errors.Is(m.UpdateForward("api:8080", "dev", "default", Forward{}), ErrConfigIsDirectory))
+
+	data, err := os.ReadFile(filepath.Join(dir, "10-api.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "contexts: []\n", string(data))
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// loadIncludes merges the contexts of the files c.Include lists into c.
+// Relative paths are resolved against the directory of path, the file that
+// lists them. Included files may include others: a file reached twice is
+// merged once, and a file that ends up including itself is an error. Only
+// contexts and nested includes are read from an included file.
+func (c *Config) loadIncludes(path string, expand bool) error {
+	if len(c.Include) == 0 {
+		return nil
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve config path: %w", err)
+	}
+	return c.mergeIncludes(c, []string{abs}, map[string]bool{abs: true}, expand)
+}
+
+// mergeIncludes merges the includes of from, the last file in stack.
+// stack is the chain of files being included, used to report cycles;
+// seen holds every file merged so far.
+func (c *Config) mergeIncludes(from *Config, stack []string, seen map[string]bool, expand bool) error {
+	dir := filepath.Dir(stack[len(stack)-1])
+	for _, include := range from.Include {
+		target := include
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(dir, target)
+		}
+		target = filepath.Clean(target)
+
+		if slices.Contains(stack, target) {
+			return fmt.Errorf("include cycle: %s", strings.Join(append(slices.Clone(stack), target), " -> "))
+		}
+		if seen[target] {
+			continue
+		}
+		seen[target] = true
+
+		data, err := readConfigFile(target)
+		if err != nil {
+			return fmt.Errorf("include %s: %w", include, err)
+		}
+		included, err := parseConfig(data, expand)
+		if err != nil {
+			return fmt.Errorf("include %s: %w", include, err)
+		}
+
+		c.includes = append(c.includes, target)
+		c.mergeContexts(included)
+		if err := c.mergeIncludes(included, append(slices.Clone(stack), target), seen, expand); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles writes name -> content pairs under dir, creating subdirectories
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func TestLoadConfig_Include(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".kportal.yaml": `include:
+  - shared/observability.yaml
+contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            protocol: tcp
+            port: 80
+            localPort: 8080
+`,
+		"shared/observability.yaml": `include:
+  - tracing.yaml
+contexts:
+  - name: dev
+    namespaces:
+      - name: monitoring
+        forwards:
+          - resource: service/grafana
+            protocol: tcp
+            port: 3000
+            localPort: 3000
+`,
+		"shared/tracing.yaml": `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/jaeger
+            protocol: tcp
+            port: 16686
+            localPort: 16686
+`,
+	})
+
+	cfg, err := LoadConfig(filepath.Join(dir, ".kportal.yaml"))
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Contexts, 1)
+	namespaces := cfg.Contexts[0].Namespaces
+	require.Len(t, namespaces, 2)
+	assert.Equal(t, "default", namespaces[0].Name)
+	require.Len(t, namespaces[0].Forwards, 2)
+	assert.Equal(t, "service/api", namespaces[0].Forwards[0].Resource)
+	assert.Equal(t, "service/jaeger", namespaces[0].Forwards[1].Resource)
+	assert.Equal(t, "dev/default/service/jaeger:16686", namespaces[0].Forwards[1].ID())
+	assert.Equal(t, "monitoring", namespaces[1].Name)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "shared/observability.yaml"),
+		filepath.Join(dir, "shared/tracing.yaml"),
+	}, cfg.includes)
+}
+
+func TestLoadConfig_IncludeMergedOnce(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.yaml": "include: [a.yaml, b.yaml]\ncontexts: []\n",
+		"a.yaml":    "include: [common.yaml]\ncontexts: []\n",
+		"b.yaml":    "include: [common.yaml]\ncontexts: []\n",
+		"common.yaml": `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            protocol: tcp
+            port: 80
+            localPort: 8080
+`,
+	})
+
+	cfg, err := LoadConfig(filepath.Join(dir, "main.yaml"))
+	require.NoError(t, err)
+	assert.Len(t, cfg.GetAllForwards(), 1)
+}
+
+func TestLoadConfig_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		files   map[string]string
+		name    string
+		wantErr string
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"main.yaml": "include: [a.yaml]\ncontexts: []\n",
+				"a.yaml":    "include: [b.yaml]\ncontexts: []\n",
+				"b.yaml":    "include: [a.yaml]\ncontexts: []\n",
+			},
+			wantErr: "include cycle: ",
+		},
+		{
+			name: "self",
+			files: map[string]string{
+				"main.yaml": "include: [./main.yaml]\ncontexts: []\n",
+			},
+			wantErr: "include cycle: ",
+		},
+		{
+			name: "missing",
+			files: map[string]string{
+				"main.yaml": "include: [missing.yaml]\ncontexts: []\n",
+			},
+			wantErr: "include missing.yaml: config file not found",
+		},
+		{
+			name: "invalid",
+			files: map[string]string{
+				"main.yaml":    "include: [invalid.yaml]\ncontexts: []\n",
+				"invalid.yaml": "contexts: [\n",
+			},
+			wantErr: "include invalid.yaml: failed to parse YAML",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			_, err := LoadConfig(filepath.Join(dir, "main.yaml"))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestMutator_Include verifies the mutator leaves included forwards in their
+// file but rejects clashes with them
+func TestMutator_Include(t *testing.T) {
+	dir := t.TempDir()
+	main := "include: [shared.yaml]\ncontexts: []\n"
+	writeFiles(t, dir, map[string]string{
+		".kportal.yaml": main,
+		"shared.yaml": `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            protocol: tcp
+            port: 80
+            localPort: 8080
+`,
+	})
+	configPath := filepath.Join(dir, ".kportal.yaml")
+	mutator := NewMutator(configPath)
+
+	clash := Forward{Resource: "service/web", Protocol: "tcp", Port: 80, LocalPort: 8080}
+	require.Error(t, mutator.AddForward("dev", "default", clash))
+
+	fwd := Forward{Resource: "service/web", Protocol: "tcp", Port: 80, LocalPort: 8081}
+	require.NoError(t, mutator.AddForward("dev", "default", fwd))
+
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	assert.Len(t, cfg.GetAllForwards(), 2)
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "service/api")
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// expandEnv expands environment variable references in s using lookup.
+// Supported forms:
+//   - $VAR and ${VAR}: replaced with the variable's value, error if unset
+//   - ${VAR:-default}: replaced with default if VAR is unset or empty
+//   - $$: a literal "$"
+//
+// A "$" not followed by a variable name is kept as-is.
+func expandEnv(s string, lookup func(string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		next := s[i+1]
+		switch {
+		case next == '$':
+			// Escaped dollar sign
+			b.WriteByte('$')
+			i++
+
+		case next == '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference in %q", s)
+			}
+			expr := s[i+2 : i+2+end]
+			name, def, hasDefault := strings.Cut(expr, ":-")
+			if !isValidEnvName(name) {
+				return "", fmt.Errorf("invalid variable name %q in %q", name, s)
+			}
+			value, ok := lookup(name)
+			switch {
+			case hasDefault && value == "":
+				value = def
+			case !ok:
+				return "", fmt.Errorf("environment variable %s is not set", name)
+			}
+			b.WriteString(value)
+			i += 2 + end
+
+		case isEnvNameStart(next):
+			j := i + 1
+			for j < len(s) && isEnvNameChar(s[j]) {
+				j++
+			}
+			name := s[i+1 : j]
+			value, ok := lookup(name)
+			if !ok {
+				return "", fmt.Errorf("environment variable %s is not set", name)
+			}
+			b.WriteString(value)
+			i = j - 1
+
+		default:
+			b.WriteByte('$')
+		}
+	}
+
+	return b.String(), nil
+}
+
+// interpolate expands environment variable references in the context names,
+// namespace names, forward string fields and webhook URLs and headers of the
+// configuration.
+func (c *Config) interpolate(lookup func(string) (string, bool)) error {
+	expand := func(field string, value *string) error {
+		expanded, err := expandEnv(*value, lookup)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+		*value = expanded
+		return nil
+	}
+
+	for i := range c.Contexts {
+		ctx := &c.Contexts[i]
+		if err := expand(fmt.Sprintf("contexts[%d].name", i), &ctx.Name); err != nil {
+			return err
+		}
+		for j := range ctx.Namespaces {
+			ns := &ctx.Namespaces[j]
+			prefix := fmt.Sprintf("contexts[%d].namespaces[%d]", i, j)
+			if err := expand(prefix+".name", &ns.Name); err != nil {
+				return err
+			}
+			for k := range ns.Forwards {
+				fwd := &ns.Forwards[k]
+				fwdPrefix := fmt.Sprintf("%s.forwards[%d]", prefix, k)
+				if err := expand(fwdPrefix+".resource", &fwd.Resource); err != nil {
+					return err
+				}
+				if err := expand(fwdPrefix+".selector", &fwd.Selector); err != nil {
+					return err
+				}
+				if err := expand(fwdPrefix+".alias", &fwd.Alias); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for i := range c.Webhooks {
+		hook := &c.Webhooks[i]
+		prefix := fmt.Sprintf("webhooks[%d]", i)
+		if err := expand(prefix+".url", &hook.URL); err != nil {
+			return err
+		}
+		for name, value := range hook.Headers {
+			if err := expand(fmt.Sprintf("%s.headers.%s", prefix, name), &value); err != nil {
+				return err
+			}
+			hook.Headers[name] = value
+		}
+	}
+
+	return nil
+}
+
+// envResolver returns a function mapping raw config strings to the values
+// kportal sees at runtime. When interpolation is disabled, or a reference
+// cannot be expanded, the string is returned unchanged.
+func envResolver(cfg *Config) func(string) string {
+	if cfg == nil || !cfg.Interpolate {
+		return func(s string) string { return s }
+	}
+	return func(s string) string {
+		if expanded, err := expandEnv(s, os.LookupEnv); err == nil {
+			return expanded
+		}
+		return s
+	}
+}
+
+// resolveForward returns a copy of fwd with its string fields passed through resolve.
+func resolveForward(fwd Forward, resolve func(string) string) Forward {
+	fwd.Resource = resolve(fwd.Resource)
+	fwd.Selector = resolve(fwd.Selector)
+	fwd.Alias = resolve(fwd.Alias)
+	return fwd
+}
+
+// isValidEnvName returns true if name is a valid environment variable name.
+func isValidEnvName(name string) bool {
+	if name == "" || !isEnvNameStart(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isEnvNameChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEnvNameStart returns true if c can start an environment variable name.
+func isEnvNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isEnvNameChar returns true if c can appear in an environment variable name.
+func isEnvNameChar(c byte) bool {
+	return isEnvNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{
+		"APP":   "api",
+		"NS":    "staging",
+		"EMPTY": "",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "no references", input: "service/api", want: "service/api"},
+		{name: "bare variable", input: "service/$APP", want: "service/api"},
+		{name: "braced variable", input: "service/${APP}-v2", want: "service/api-v2"},
+		{name: "multiple variables", input: "$NS/${APP}", want: "staging/api"},
+		{name: "default when unset", input: "${MISSING:-default}", want: "default"},
+		{name: "default when empty", input: "${EMPTY:-fallback}", want: "fallback"},
+		{name: "default ignored when set", input: "${NS:-default}", want: "staging"},
+		{name: "empty default", input: "x${MISSING:-}y", want: "xy"},
+		{name: "escaped dollar", input: "price$$5", want: "price$5"},
+		{name: "escaped reference", input: "$${APP}", want: "${APP}"},
+		{name: "trailing dollar", input: "value$", want: "value$"},
+		{name: "dollar before non-name", input: "a$-b", want: "a$-b"},
+		{name: "set but empty", input: "x${EMPTY}y", want: "xy"},
+		{name: "unset bare", input: "$MISSING", wantErr: "environment variable MISSING is not set"},
+		{name: "unset braced", input: "${MISSING}", wantErr: "environment variable MISSING is not set"},
+		{name: "unterminated", input: "${APP", wantErr: "unterminated variable reference"},
+		{name: "invalid name", input: "${1APP}", wantErr: "invalid variable name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandEnv(tt.input, lookup)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+const interpolatedYAML = `interpolate: true
+contexts:
+  - name: ${KPORTAL_TEST_CONTEXT}
+    namespaces:
+      - name: ${KPORTAL_TEST_NS:-default}
+        forwards:
+          - resource: service/$KPORTAL_TEST_APP
+            port: 8080
+            localPort: 8080
+            alias: ${KPORTAL_TEST_APP}-local
+`
+
+func TestParseConfig_Interpolate(t *testing.T) {
+	t.Setenv("KPORTAL_TEST_CONTEXT", "prod")
+	t.Setenv("KPORTAL_TEST_APP", "api")
+
+	cfg, err := ParseConfig([]byte(interpolatedYAML))
+	require.NoError(t, err)
+
+	fwd := cfg.Contexts[0].Namespaces[0].Forwards[0]
+	assert.Equal(t, "prod", cfg.Contexts[0].Name)
+	assert.Equal(t, "default", cfg.Contexts[0].Namespaces[0].Name)
+	assert.Equal(t, "service/api", fwd.Resource)
+	assert.Equal(t, "api-local", fwd.Alias)
+	assert.Equal(t, "api-local:8080", fwd.ID(), "aliased forwards are identified by the expanded alias")
+}
+
+func TestParseConfig_InterpolateWebhooks(t *testing.T) {
+	t.Setenv("KPORTAL_TEST_HOOK_HOST", "hooks.example.com")
+	t.Setenv("KPORTAL_TEST_HOOK_TOKEN", "s3cret")
+
+	cfg, err := ParseConfig([]byte(`interpolate: true
+webhooks:
+  - url: https://${KPORTAL_TEST_HOOK_HOST}/kportal
+    headers:
+      Authorization: Bearer ${KPORTAL_TEST_HOOK_TOKEN}
+contexts: []
+`))
+	require.NoError(t, err)
+	require.Len(t, cfg.Webhooks, 1)
+	assert.Equal(t, "https://hooks.example.com/kportal", cfg.Webhooks[0].URL)
+	assert.Equal(t, "Bearer s3cret", cfg.Webhooks[0].Headers["Authorization"])
+}
+
+func TestParseConfig_InterpolateUnsetVariable(t *testing.T) {
+	t.Setenv("KPORTAL_TEST_APP", "api")
+	t.Setenv("KPORTAL_TEST_CONTEXT", "")
+	require.NoError(t, os.Unsetenv("KPORTAL_TEST_CONTEXT"))
+
+	_, err := ParseConfig([]byte(interpolatedYAML))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "contexts[0].name")
+	assert.Contains(t, err.Error(), "KPORTAL_TEST_CONTEXT is not set")
+}
+
+func TestParseConfig_InterpolateDisabledByDefault(t *testing.T) {
+	t.Setenv("KPORTAL_TEST_APP", "api")
+
+	cfg, err := ParseConfig([]byte(`contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/$KPORTAL_TEST_APP
+            port: 8080
+            localPort: 8080
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "service/$KPORTAL_TEST_APP", cfg.Contexts[0].Namespaces[0].Forwards[0].Resource)
+}
+
+func TestMutator_PreservesEnvReferences(t *testing.T) {
+	t.Setenv("KPORTAL_TEST_CONTEXT", "prod")
+	t.Setenv("KPORTAL_TEST_APP", "api")
+
+	configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(interpolatedYAML), 0600))
+
+	mutator := NewMutator(configPath)
+	err := mutator.AddForward("prod", "default", Forward{
+		Resource:  "service/web",
+		Protocol:  "tcp",
+		Port:      80,
+		LocalPort: 8081,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "${KPORTAL_TEST_CONTEXT}")
+	assert.Contains(t, string(data), "service/$KPORTAL_TEST_APP")
+
+	// The new forward joins the existing context and namespace
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	require.Len(t, cfg.Contexts, 1)
+	require.Len(t, cfg.Contexts[0].Namespaces, 1)
+	assert.Len(t, cfg.Contexts[0].Namespaces[0].Forwards, 2)
+
+	// Removal matches on the resolved (expanded alias) ID
+	require.NoError(t, mutator.RemoveForwardByID("api-local:8080"))
+	cfg, err = LoadConfig(configPath)
+	require.NoError(t, err)
+	require.Len(t, cfg.Contexts[0].Namespaces[0].Forwards, 1)
+	assert.Equal(t, "service/web", cfg.Contexts[0].Namespaces[0].Forwards[0].Resource)
+}
@@ -1,19 +1,31 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/lukaszraczylo/kportal/internal/logger"
 )
 
+// ErrConfigReadOnly is returned by the Mutator when the configuration sets
+// readOnly: true.
+var ErrConfigReadOnly = errors.New("configuration is read-only (readOnly: true); edit the file directly")
+
 // Mutator provides safe, atomic mutations to the kportal configuration file.
 // All operations use atomic file writes (write to temp, then rename) to prevent
 // corruption and ensure the file watcher picks up changes.
+// Only the affected YAML nodes are edited, so comments and the layout of the
+// rest of the file are preserved.
 type Mutator struct {
+	auditLog   *logger.Logger // Records successful mutations; nil disables
 	configPath string
+	auditUser  string
 	mu         sync.Mutex // Ensure only one mutation at a time
 }
 
@@ -24,10 +36,43 @@ func NewMutator(configPath string) *Mutator {
 	}
 }
 
+// checkWritable rejects mutations of a config directory, whose merged view
+// cannot be written back to a single file.
+func (m *Mutator) checkWritable() error {
+	if info, err := os.Stat(m.configPath); err == nil && info.IsDir() {
+		return ErrConfigIsDirectory
+	}
+	return nil
+}
+
+// load reads the config file as a Config, which mutations are validated
+// against, and as a document, which is what gets written back. Included
+// files are not merged: only forwards of this file can be changed. A
+// read-only config is not loaded, so it is never written.
+func (m *Mutator) load() (*Config, *document, error) {
+	data, err := readConfigFile(m.configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, err := parseConfig(data, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.ReadOnly {
+		return nil, nil, ErrConfigReadOnly
+	}
+	doc, err := parseDocument(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, doc, nil
+}
+
 // findOrCreateContext finds an existing context or creates a new one
 func (m *Mutator) findOrCreateContext(cfg *Config, contextName string) *Context {
+	resolve := envResolver(cfg)
 	for i := range cfg.Contexts {
-		if cfg.Contexts[i].Name == contextName {
+		if resolve(cfg.Contexts[i].Name) == contextName {
 			return &cfg.Contexts[i]
 		}
 	}
@@ -40,10 +85,11 @@ func (m *Mutator) findOrCreateContext(cfg *Config, contextName string) *Context
 	return &cfg.Contexts[len(cfg.Contexts)-1]
 }
 
-// findOrCreateNamespace finds an existing namespace or creates a new one
-func (m *Mutator) findOrCreateNamespace(ctx *Context, namespaceName string) *Namespace {
+// findOrCreateNamespace finds an existing namespace or creates a new one.
+// resolve maps raw namespace names to their runtime values.
+func (m *Mutator) findOrCreateNamespace(ctx *Context, namespaceName string, resolve func(string) string) *Namespace {
 	for i := range ctx.Namespaces {
-		if ctx.Namespaces[i].Name == namespaceName {
+		if resolve(ctx.Namespaces[i].Name) == namespaceName {
 			return &ctx.Namespaces[i]
 		}
 	}
@@ -61,15 +107,27 @@ func (m *Mutator) findOrCreateNamespace(ctx *Context, namespaceName string) *Nam
 // The new configuration is validated before writing.
 // Returns an error if the port is already in use or validation fails.
 func (m *Mutator) AddForward(contextName, namespaceName string, fwd Forward) error {
+	return m.AddForwards(contextName, namespaceName, []Forward{fwd})
+}
+
+// AddForwards adds several port forwards to one namespace in a single write,
+// so the file watcher reloads once. Either all forwards are added or, on a
+// port clash or validation failure, none are.
+func (m *Mutator) AddForwards(contextName, namespaceName string, fwds []Forward) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+
 	// Load current config
-	cfg, err := LoadConfig(m.configPath)
+	cfg, doc, err := m.load()
 	if err != nil {
 		// If file doesn't exist, create empty config
 		if os.IsNotExist(err) {
 			cfg = &Config{Contexts: []Context{}}
+			doc = newDocument()
 		} else {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
@@ -77,61 +135,127 @@ func (m *Mutator) AddForward(contextName, namespaceName string, fwd Forward) err
 
 	// Find or create context and namespace
 	targetContext := m.findOrCreateContext(cfg, contextName)
-	targetNamespace := m.findOrCreateNamespace(targetContext, namespaceName)
+	targetNamespace := m.findOrCreateNamespace(targetContext, namespaceName, envResolver(cfg))
+	forwardsNode := doc.forwards(contextName, namespaceName, envResolver(cfg))
 
-	// Set context/namespace on the forward for validation
-	fwd.SetContext(contextName, namespaceName)
-
-	// Check for duplicate local port
 	allForwards := cfg.GetAllForwards()
-	for _, existing := range allForwards {
-		if existing.LocalPort == fwd.LocalPort {
-			return fmt.Errorf("port %d is already in use by %s", fwd.LocalPort, existing.String())
+	ids := make([]string, 0, len(fwds))
+	for _, fwd := range fwds {
+		// Set context/namespace on the forward for validation
+		fwd.SetContext(contextName, namespaceName)
+
+		// Check for duplicate local port, including earlier forwards of this batch
+		for _, existing := range allForwards {
+			if fwd.LocalPort != 0 && existing.LocalPort == fwd.LocalPort {
+				return fmt.Errorf("port %d is already in use by %s", fwd.LocalPort, existing.String())
+			}
+		}
+		allForwards = append(allForwards, fwd)
+		ids = append(ids, fwd.ID())
+
+		// Add the forward
+		targetNamespace.Forwards = append(targetNamespace.Forwards, fwd)
+		if err := doc.appendForward(forwardsNode, fwd); err != nil {
+			return err
 		}
 	}
 
-	// Add the forward
-	targetNamespace.Forwards = append(targetNamespace.Forwards, fwd)
+	// Validate the new configuration
+	if err := m.validate(cfg, false); err != nil {
+		return err
+	}
+
+	// Write atomically
+	if err := m.write(cfg, doc); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		m.audit(AuditAdd, id, nil)
+	}
+	return nil
+}
+
+// AddContexts adds contexts with no namespaces yet, as a skeleton to fill
+// in. Contexts already in the configuration are left as they are. A context
+// without namespaces only validates while the config has no forwards, so
+// this is meant for new configs.
+func (m *Mutator) AddContexts(contextNames []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+
+	// Load current config
+	cfg, doc, err := m.load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	resolve := envResolver(cfg)
+	contexts := sequenceValue(doc.root, "contexts")
+	for _, name := range contextNames {
+		m.findOrCreateContext(cfg, name)
+		sequenceValue(findOrAppendNamed(contexts, name, resolve), "namespaces")
+	}
 
 	// Validate the new configuration
-	validator := NewValidator()
-	if errs := validator.ValidateConfig(cfg); len(errs) > 0 {
-		return fmt.Errorf("validation failed: %s", FormatValidationErrors(errs))
+	if err := m.validate(cfg, true); err != nil {
+		return err
 	}
 
 	// Write atomically
-	return m.writeAtomic(cfg)
+	return m.write(cfg, doc)
 }
 
 // RemoveForwards removes forwards matching the predicate function.
 // The predicate receives the context, namespace, and forward, and should return true
-// to remove that forward.
+// to remove that forward. It sees each port of a ports list as its own
+// forward, and a match on any of them removes the whole entry.
 // Empty namespaces and contexts are preserved (not automatically removed).
 func (m *Mutator) RemoveForwards(predicate func(ctx, ns string, fwd Forward) bool) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+
 	// Load current config
-	cfg, err := LoadConfig(m.configPath)
+	cfg, doc, err := m.load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Iterate and filter
+	// Iterate and filter. The predicate sees resolved names so IDs match what
+	// the manager reports; the raw forwards are what get written back.
+	resolve := envResolver(cfg)
+	removed := make(map[[3]int]bool)
+	var removedIDs []string
 	for i := range cfg.Contexts {
 		ctx := &cfg.Contexts[i]
+		ctxName := resolve(ctx.Name)
 		filteredNamespaces := []Namespace{}
 
 		for j := range ctx.Namespaces {
 			ns := &ctx.Namespaces[j]
+			nsName := resolve(ns.Name)
 
 			// Filter forwards
 			filtered := []Forward{}
-			for _, fwd := range ns.Forwards {
+			for k, fwd := range ns.Forwards {
 				// CRITICAL: Set context/namespace so fwd.ID() generates correct ID
-				fwd.SetContext(ctx.Name, ns.Name)
-
-				if !predicate(ctx.Name, ns.Name, fwd) {
+				resolved := resolveForward(fwd, resolve)
+				resolved.SetContext(ctxName, nsName)
+
+				expanded := resolved.Expand()
+				if slices.ContainsFunc(expanded, func(f Forward) bool { return predicate(ctxName, nsName, f) }) {
+					removed[[3]int{i, j, k}] = true
+					for _, f := range expanded {
+						removedIDs = append(removedIDs, f.ID())
+					}
+				} else {
 					// Keep this forward
 					filtered = append(filtered, fwd)
 				}
@@ -149,13 +273,21 @@ func (m *Mutator) RemoveForwards(predicate func(ctx, ns string, fwd Forward) boo
 	}
 
 	// Validate the new configuration
-	validator := NewValidator()
-	if errs := validator.ValidateConfig(cfg); len(errs) > 0 {
-		return fmt.Errorf("validation failed: %s", FormatValidationErrors(errs))
+	if err := m.validate(cfg, false); err != nil {
+		return err
 	}
 
 	// Write atomically
-	return m.writeAtomic(cfg)
+	doc.filterForwards(func(ci, ni, fi int) bool {
+		return !removed[[3]int{ci, ni, fi}]
+	})
+	if err := m.write(cfg, doc); err != nil {
+		return err
+	}
+	for _, id := range removedIDs {
+		m.audit(AuditRemove, id, nil)
+	}
+	return nil
 }
 
 // RemoveForwardByID removes a specific forward by its ID.
@@ -167,20 +299,29 @@ func (m *Mutator) RemoveForwardByID(id string) error {
 
 // UpdateForward atomically replaces an existing forward with a new one.
 // This is used for editing - it removes the old forward and adds the new one in a single transaction.
+// A forward that stays in the same namespace keeps its position; one moved
+// elsewhere is appended to the target namespace.
 // If the old forward doesn't exist, returns an error.
 // If the new forward validation fails, the operation is rolled back (old forward remains).
 func (m *Mutator) UpdateForward(oldID, newContextName, newNamespaceName string, newFwd Forward) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if err := m.checkWritable(); err != nil {
+		return err
+	}
+
 	// Load current config
-	cfg, err := LoadConfig(m.configPath)
+	cfg, doc, err := m.load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// First, verify the old forward exists and remove it
 	oldForwardFound := false
+	var oldContextName, oldNamespaceName string
+	var oldContextIndex, oldNamespaceIndex, oldIndex int
+	resolve := envResolver(cfg)
 	for i := range cfg.Contexts {
 		ctx := &cfg.Contexts[i]
 		for j := range ctx.Namespaces {
@@ -190,10 +331,16 @@ func (m *Mutator) UpdateForward(oldID, newContextName, newNamespaceName string,
 			filtered := []Forward{}
 			for _, fwd := range ns.Forwards {
 				// CRITICAL: Set context/namespace so fwd.ID() generates correct ID
-				fwd.SetContext(ctx.Name, ns.Name)
+				resolved := resolveForward(fwd, resolve)
+				resolved.SetContext(resolve(ctx.Name), resolve(ns.Name))
 
-				if fwd.ID() == oldID {
+				if resolved.ExpandsTo(oldID) {
+					if len(resolved.Ports) > 0 {
+						return fmt.Errorf("forward %s is one port of a ports list; edit the list in the config file", oldID)
+					}
 					oldForwardFound = true
+					oldContextName, oldNamespaceName = resolve(ctx.Name), resolve(ns.Name)
+					oldContextIndex, oldNamespaceIndex, oldIndex = i, j, len(filtered)
 					// Skip this forward (remove it)
 					continue
 				}
@@ -213,7 +360,7 @@ func (m *Mutator) UpdateForward(oldID, newContextName, newNamespaceName string,
 	// Now add the new forward
 	// Find or create context and namespace
 	targetContext := m.findOrCreateContext(cfg, newContextName)
-	targetNamespace := m.findOrCreateNamespace(targetContext, newNamespaceName)
+	targetNamespace := m.findOrCreateNamespace(targetContext, newNamespaceName, envResolver(cfg))
 
 	// Set context/namespace on the forward for validation
 	newFwd.SetContext(newContextName, newNamespaceName)
@@ -221,38 +368,89 @@ func (m *Mutator) UpdateForward(oldID, newContextName, newNamespaceName string,
 	// Check for duplicate local port (excluding the one we just removed)
 	allForwards := cfg.GetAllForwards()
 	for _, existing := range allForwards {
-		if existing.LocalPort == newFwd.LocalPort && existing.ID() != oldID {
+		if newFwd.LocalPort != 0 && existing.LocalPort == newFwd.LocalPort && existing.ID() != oldID {
 			return fmt.Errorf("port %d is already in use by %s", newFwd.LocalPort, existing.String())
 		}
 	}
 
-	// Add the new forward
-	targetNamespace.Forwards = append(targetNamespace.Forwards, newFwd)
+	// Add the new forward, in place of the old one when it stays put
+	inPlace := newContextName == oldContextName && newNamespaceName == oldNamespaceName
+	if inPlace {
+		targetNamespace.Forwards = slices.Insert(targetNamespace.Forwards, oldIndex, newFwd)
+	} else {
+		targetNamespace.Forwards = append(targetNamespace.Forwards, newFwd)
+	}
 
 	// Validate the new configuration
+	if err := m.validate(cfg, false); err != nil {
+		return err
+	}
+
+	// Edit the forward's node in place, or move it
+	if inPlace {
+		err = doc.updateForward(oldContextIndex, oldNamespaceIndex, oldIndex, newFwd)
+	} else {
+		doc.removeForward(oldContextIndex, oldNamespaceIndex, oldIndex)
+		err = doc.appendForward(doc.forwards(newContextName, newNamespaceName, resolve), newFwd)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Write atomically
+	if err := m.write(cfg, doc); err != nil {
+		return err
+	}
+	m.audit(AuditUpdate, newFwd.ID(), map[string]interface{}{"previous_id": oldID})
+	return nil
+}
+
+// validate checks the configuration as it will be loaded, expanding
+// environment variable references first when interpolation is enabled and
+// merging included files, so clashes with their forwards are caught.
+// allowEmpty accepts a config without forwards.
+func (m *Mutator) validate(cfg *Config, allowEmpty bool) error {
+	resolved := cfg
+	if cfg.Interpolate || len(cfg.Include) > 0 {
+		data, err := yaml.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal config: %w", err)
+		}
+		if resolved, err = ParseConfig(data); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
+		if err := resolved.loadIncludes(m.configPath, true); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
+	}
+
 	validator := NewValidator()
-	if errs := validator.ValidateConfig(cfg); len(errs) > 0 {
+	if errs := validator.ValidateConfigWithOptions(resolved, allowEmpty); len(errs) > 0 {
 		return fmt.Errorf("validation failed: %s", FormatValidationErrors(errs))
 	}
+	return nil
+}
 
-	// Write atomically
-	return m.writeAtomic(cfg)
+// write encodes the document and writes it atomically, sorted first when
+// the config sets sortOnWrite
+func (m *Mutator) write(cfg *Config, doc *document) error {
+	if cfg.SortOnWrite {
+		doc.sort(envResolver(cfg))
+	}
+	data, err := doc.bytes()
+	if err != nil {
+		return err
+	}
+	return m.writeAtomic(data)
 }
 
 // writeAtomic writes the configuration atomically to prevent corruption.
 // Steps:
-// 1. Marshal config to YAML
-// 2. Write to temporary file (.kportal.yaml.tmp)
-// 3. Atomic rename to actual config file
+// 1. Write to temporary file (.kportal.yaml.tmp)
+// 2. Atomic rename to actual config file
 //
 // This ensures the file watcher picks up a complete, valid file.
-func (m *Mutator) writeAtomic(cfg *Config) error {
-	// Marshal to YAML
-	data, err := yaml.Marshal(cfg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
-	}
-
+func (m *Mutator) writeAtomic(data []byte) error {
 	// Create temporary file in same directory as config
 	dir := filepath.Dir(m.configPath)
 	tmpFile := filepath.Join(dir, ".kportal.yaml.tmp")
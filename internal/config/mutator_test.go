@@ -3,10 +3,12 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 // TestNewMutator tests mutator creation
@@ -16,6 +18,31 @@ func TestNewMutator(t *testing.T) {
 	assert.Equal(t, "/path/to/config.yaml", mutator.configPath)
 }
 
+func TestMutator_ReadOnlyConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	original := `readOnly: true
+contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 80
+            localPort: 8080
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(original), 0600))
+
+	mutator := NewMutator(configPath)
+	assert.ErrorIs(t, mutator.AddForward("dev", "default", Forward{Resource: "service/web", Port: 80, LocalPort: 8081}), ErrConfigReadOnly)
+	assert.ErrorIs(t, mutator.AddContexts([]string{"prod"}), ErrConfigReadOnly)
+	assert.ErrorIs(t, mutator.RemoveForwardByID("dev/default/service/api:8080"), ErrConfigReadOnly)
+	assert.ErrorIs(t, mutator.UpdateForward("dev/default/service/api:8080", "dev", "default", Forward{Resource: "service/api", Port: 80, LocalPort: 9090}), ErrConfigReadOnly)
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(data), "the file is left untouched")
+}
+
 // TestMutator_AddForward_NewFile tests adding a forward to a new file
 // Note: Due to how LoadConfig wraps errors, os.IsNotExist check in AddForward
 // doesn't work with wrapped errors. This documents the current behavior.
@@ -184,6 +211,82 @@ func TestMutator_AddForward_DuplicatePort(t *testing.T) {
 	assert.Contains(t, err.Error(), "port 8080 is already in use")
 }
 
+// TestMutator_AddForwards tests adding a batch of forwards in one write
+func TestMutator_AddForwards(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".kportal.yaml")
+
+	initial := `contexts:
+  - name: dev-cluster
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/app
+            protocol: tcp
+            port: 8080
+            localPort: 8080
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(initial), 0600))
+
+	mutator := NewMutator(configPath)
+
+	fwds := []Forward{
+		{Resource: "service/api", Protocol: "tcp", Port: 80, LocalPort: 10000, Alias: "api"},
+		{Resource: "service/db", Protocol: "tcp", Port: 5432, LocalPort: 5432, Alias: "db"},
+	}
+	require.NoError(t, mutator.AddForwards("dev-cluster", "staging", fwds))
+
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	require.Len(t, cfg.Contexts[0].Namespaces, 2)
+	staging := cfg.Contexts[0].Namespaces[1]
+	assert.Equal(t, "staging", staging.Name)
+	if assert.Len(t, staging.Forwards, 2) {
+		assert.Equal(t, "service/api", staging.Forwards[0].Resource)
+		assert.Equal(t, "service/db", staging.Forwards[1].Resource)
+	}
+}
+
+// TestMutator_AddForwards_AllOrNothing tests a clash anywhere in the batch
+// leaves the file untouched
+func TestMutator_AddForwards_AllOrNothing(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".kportal.yaml")
+
+	initial := `contexts:
+  - name: dev-cluster
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/app
+            protocol: tcp
+            port: 8080
+            localPort: 8080
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(initial), 0600))
+
+	mutator := NewMutator(configPath)
+
+	// Clash within the batch
+	err := mutator.AddForwards("dev-cluster", "default", []Forward{
+		{Resource: "service/api", Protocol: "tcp", Port: 80, LocalPort: 10000},
+		{Resource: "service/web", Protocol: "tcp", Port: 80, LocalPort: 10000},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "port 10000 is already in use")
+
+	// Clash with the existing config
+	err = mutator.AddForwards("dev-cluster", "default", []Forward{
+		{Resource: "service/api", Protocol: "tcp", Port: 80, LocalPort: 10000},
+		{Resource: "service/web", Protocol: "tcp", Port: 80, LocalPort: 8080},
+	})
+	require.Error(t, err)
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, initial, string(data))
+}
+
 // TestMutator_AddForward_InvalidForward tests rejecting invalid forward
 func TestMutator_AddForward_InvalidForward(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -354,6 +457,41 @@ func TestMutator_RemoveForwardByID(t *testing.T) {
 	assert.Equal(t, "pod/app2", cfg.Contexts[0].Namespaces[0].Forwards[0].Resource)
 }
 
+func TestMutator_PortList(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	initial := `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            ports:
+              - port: 8080
+                localPort: 8080
+              - port: 9090
+                localPort: 9090
+          - resource: pod/db
+            port: 5432
+            localPort: 5432
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(initial), 0600))
+	mutator := NewMutator(configPath)
+
+	// A port of the list collides like any other forward
+	err := mutator.AddForward("dev", "default", Forward{Resource: "pod/web", Protocol: "tcp", Port: 80, LocalPort: 9090})
+	assert.ErrorContains(t, err, "port 9090 is already in use")
+
+	err = mutator.UpdateForward("dev/default/service/api:9090", "dev", "default", Forward{Resource: "service/api", Protocol: "tcp", Port: 9090, LocalPort: 9191})
+	assert.ErrorContains(t, err, "one port of a ports list")
+
+	// Removing one port removes the whole entry
+	require.NoError(t, mutator.RemoveForwardByID("dev/default/service/api:9090"))
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	require.Len(t, cfg.Contexts[0].Namespaces[0].Forwards, 1)
+	assert.Equal(t, "pod/db", cfg.Contexts[0].Namespaces[0].Forwards[0].Resource)
+}
+
 // TestMutator_UpdateForward tests updating an existing forward
 func TestMutator_UpdateForward(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -394,6 +532,46 @@ func TestMutator_UpdateForward(t *testing.T) {
 	assert.Equal(t, 9090, cfg.Contexts[0].Namespaces[0].Forwards[0].LocalPort)
 }
 
+// TestMutator_UpdateForward_KeepsPosition tests an edited forward stays where
+// it was in its namespace
+func TestMutator_UpdateForward_KeepsPosition(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".kportal.yaml")
+
+	initial := `contexts:
+  - name: dev-cluster
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/app1
+            protocol: tcp
+            port: 8080
+            localPort: 8080
+          - resource: pod/app2
+            protocol: tcp
+            port: 8080
+            localPort: 8081
+          - resource: pod/app3
+            protocol: tcp
+            port: 8080
+            localPort: 8082
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(initial), 0600))
+
+	mutator := NewMutator(configPath)
+
+	newFwd := Forward{Resource: "service/api", Protocol: "tcp", Port: 80, LocalPort: 8081}
+	require.NoError(t, mutator.UpdateForward("dev-cluster/default/pod/app2:8081", "dev-cluster", "default", newFwd))
+
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	fwds := cfg.Contexts[0].Namespaces[0].Forwards
+	require.Len(t, fwds, 3)
+	assert.Equal(t, "pod/app1", fwds[0].Resource)
+	assert.Equal(t, "service/api", fwds[1].Resource)
+	assert.Equal(t, "pod/app3", fwds[2].Resource)
+}
+
 // TestMutator_UpdateForward_MoveToNewContext tests moving forward to new context
 func TestMutator_UpdateForward_MoveToNewContext(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -540,7 +718,9 @@ func TestMutator_WriteAtomic(t *testing.T) {
 		},
 	}
 
-	err := mutator.writeAtomic(cfg)
+	data, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	err = mutator.writeAtomic(data)
 	require.NoError(t, err)
 
 	// Verify file was created with correct permissions
@@ -595,7 +775,7 @@ func TestMutator_FindOrCreateNamespace(t *testing.T) {
 			},
 		}
 
-		ns := mutator.findOrCreateNamespace(ctx, "existing")
+		ns := mutator.findOrCreateNamespace(ctx, "existing", envResolver(nil))
 		assert.Equal(t, "existing", ns.Name)
 		assert.Len(t, ctx.Namespaces, 1)
 	})
@@ -608,7 +788,7 @@ func TestMutator_FindOrCreateNamespace(t *testing.T) {
 			},
 		}
 
-		ns := mutator.findOrCreateNamespace(ctx, "new-namespace")
+		ns := mutator.findOrCreateNamespace(ctx, "new-namespace", envResolver(nil))
 		assert.Equal(t, "new-namespace", ns.Name)
 		assert.Len(t, ctx.Namespaces, 2)
 	})
@@ -662,3 +842,217 @@ func TestMutator_Concurrent(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 }
+
+// commentedConfig is a hand-maintained config with comments, quoting and
+// 2-space indentation the mutator must keep
+const commentedConfig = `# Local dev forwards
+contexts:
+  # Development cluster
+  - name: dev
+    namespaces:
+      - name: default # main namespace
+        forwards:
+          # The API, used by the frontend
+          - resource: "service/api"
+            protocol: tcp
+            port: 8080 # http
+            localPort: 8080
+            alias: api
+          # Database, read-only replica
+          - resource: service/db
+            protocol: tcp
+            port: 5432
+            localPort: 5432
+`
+
+// TestMutator_PreservesComments verifies add, update and remove only touch
+// the affected forward
+func TestMutator_PreservesComments(t *testing.T) {
+	setup := func(t *testing.T) (*Mutator, string) {
+		configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(commentedConfig), 0600))
+		return NewMutator(configPath), configPath
+	}
+	read := func(t *testing.T, path string) string {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("add", func(t *testing.T) {
+		mutator, path := setup(t)
+		fwd := Forward{Resource: "service/cache", Protocol: "tcp", Port: 6379, LocalPort: 6379}
+		require.NoError(t, mutator.AddForward("dev", "default", fwd))
+
+		assert.Equal(t, commentedConfig+`          - resource: service/cache
+            protocol: tcp
+            port: 6379
+            localPort: 6379
+`, read(t, path))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mutator, path := setup(t)
+		fwd := Forward{Resource: "service/api", Protocol: "tcp", Port: 8080, LocalPort: 9090, Alias: "api"}
+		require.NoError(t, mutator.UpdateForward("api:8080", "dev", "default", fwd))
+
+		got := read(t, path)
+		assert.Equal(t, strings.Replace(commentedConfig, "localPort: 8080", "localPort: 9090", 1), got)
+	})
+
+	t.Run("update keeps comment of changed value", func(t *testing.T) {
+		mutator, path := setup(t)
+		fwd := Forward{Resource: "service/api", Protocol: "tcp", Port: 8081, LocalPort: 8080}
+		require.NoError(t, mutator.UpdateForward("api:8080", "dev", "default", fwd))
+
+		got := read(t, path)
+		assert.Contains(t, got, "port: 8081 # http\n")
+		assert.NotContains(t, got, "alias: api")
+		assert.Contains(t, got, "# The API, used by the frontend\n")
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		mutator, path := setup(t)
+		require.NoError(t, mutator.RemoveForwardByID("dev/default/service/db:5432"))
+
+		got := read(t, path)
+		assert.NotContains(t, got, "service/db")
+		assert.True(t, strings.HasPrefix(commentedConfig, got), "unexpected rewrite:\n%s", got)
+	})
+}
+
+// TestMutator_UpdateForward_KeepsHTTPLogShorthand verifies `httpLog: true`
+// is not expanded to a mapping when only the toggle is set
+func TestMutator_UpdateForward_KeepsHTTPLogShorthand(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	initial := `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/app
+            protocol: tcp
+            port: 8080
+            localPort: 8080
+            httpLog: true
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(initial), 0600))
+
+	fwd := Forward{
+		Resource: "pod/app", Protocol: "tcp", Port: 8080, LocalPort: 8080,
+		HTTPLog: &HTTPLogSpec{Enabled: false},
+	}
+	require.NoError(t, NewMutator(configPath).UpdateForward("dev/default/pod/app:8080", "dev", "default", fwd))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(initial, "httpLog: true", "httpLog: false", 1), string(data))
+}
+
+// TestMutator_SortOnWrite verifies sortOnWrite orders contexts, namespaces
+// and forwards, and that comments move with their entries
+func TestMutator_SortOnWrite(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	initial := `sortOnWrite: true
+contexts:
+  - name: prod
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            protocol: tcp
+            port: 80
+            localPort: 9000
+  - name: dev
+    namespaces:
+      - name: web
+        forwards:
+          # frontend
+          - resource: service/web
+            protocol: tcp
+            port: 80
+            localPort: 8080
+      - name: db
+        forwards:
+          - resource: service/db
+            protocol: tcp
+            port: 5432
+            localPort: 5432
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(initial), 0600))
+
+	fwd := Forward{Resource: "service/cache", Protocol: "tcp", Port: 6379, LocalPort: 6379}
+	require.NoError(t, NewMutator(configPath).AddForward("dev", "web", fwd))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, `sortOnWrite: true
+contexts:
+  - name: dev
+    namespaces:
+      - name: db
+        forwards:
+          - resource: service/db
+            protocol: tcp
+            port: 5432
+            localPort: 5432
+      - name: web
+        forwards:
+          - resource: service/cache
+            protocol: tcp
+            port: 6379
+            localPort: 6379
+          # frontend
+          - resource: service/web
+            protocol: tcp
+            port: 80
+            localPort: 8080
+  - name: prod
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            protocol: tcp
+            port: 80
+            localPort: 9000
+`, string(data))
+}
+
+// TestMutator_AddContexts tests scaffolding contexts into a new config
+func TestMutator_AddContexts(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	require.NoError(t, CreateEmptyConfigFile(configPath))
+
+	mutator := NewMutator(configPath)
+	require.NoError(t, mutator.AddContexts([]string{"dev", "prod"}))
+	require.NoError(t, mutator.AddContexts([]string{"prod"}))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "# kportal configuration file\n"))
+	assert.Contains(t, string(data), `contexts:
+    - name: dev
+      namespaces: []
+    - name: prod
+      namespaces: []
+`)
+
+	info, err := os.Stat(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	assert.Empty(t, NewValidator().ValidateConfigWithOptions(cfg, cfg.IsEmpty()))
+}
+
+// TestMutator_AddContexts_RejectsWithForwards tests a namespace-less context
+// is not added next to existing forwards, where it would fail validation
+func TestMutator_AddContexts_RejectsWithForwards(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(commentedConfig), 0600))
+
+	err := NewMutator(configPath).AddContexts([]string{"prod"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must have at least one namespace")
+}
@@ -0,0 +1,57 @@
+//go:build linux
+
+package config
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// capNetBindService is the CAP_NET_BIND_SERVICE capability bit.
+const capNetBindService = 10
+
+// privilegedPortLimit returns the lowest port this process can bind, or 0
+// when it may bind any port (root or CAP_NET_BIND_SERVICE).
+func privilegedPortLimit() int {
+	if os.Geteuid() == 0 {
+		return 0
+	}
+	if status, err := os.ReadFile("/proc/self/status"); err == nil && hasEffectiveCap(string(status), capNetBindService) {
+		return 0
+	}
+	start, err := os.ReadFile("/proc/sys/net/ipv4/ip_unprivileged_port_start")
+	if err != nil {
+		return defaultUnprivilegedPortStart
+	}
+	return parseUnprivilegedPortStart(string(start))
+}
+
+// hasEffectiveCap reports whether the CapEff mask in a /proc/<pid>/status
+// dump has the given capability bit set.
+func hasEffectiveCap(status string, capBit uint) bool {
+	scanner := bufio.NewScanner(strings.NewReader(status))
+	for scanner.Scan() {
+		value, ok := strings.CutPrefix(scanner.Text(), "CapEff:")
+		if !ok {
+			continue
+		}
+		mask, err := strconv.ParseUint(strings.TrimSpace(value), 16, 64)
+		if err != nil {
+			return false
+		}
+		return mask&(1<<capBit) != 0
+	}
+	return false
+}
+
+// parseUnprivilegedPortStart parses net.ipv4.ip_unprivileged_port_start,
+// falling back to the kernel default on malformed input.
+func parseUnprivilegedPortStart(value string) int {
+	port, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || port < 0 {
+		return defaultUnprivilegedPortStart
+	}
+	return port
+}
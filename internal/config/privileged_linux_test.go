@@ -0,0 +1,27 @@
+//go:build linux
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasEffectiveCap(t *testing.T) {
+	status := "Name:\tkportal\nCapInh:\t0000000000000000\nCapPrm:\t0000000000000400\nCapEff:\t0000000000000400\n"
+	assert.True(t, hasEffectiveCap(status, capNetBindService))
+	assert.False(t, hasEffectiveCap(status, 12))
+
+	assert.False(t, hasEffectiveCap("CapEff:\t0000000000000000\n", capNetBindService))
+	assert.False(t, hasEffectiveCap("CapEff:\tzz\n", capNetBindService))
+	assert.False(t, hasEffectiveCap("Name:\tkportal\n", capNetBindService))
+}
+
+func TestParseUnprivilegedPortStart(t *testing.T) {
+	assert.Equal(t, 1024, parseUnprivilegedPortStart("1024\n"))
+	assert.Equal(t, 80, parseUnprivilegedPortStart("80\n"))
+	assert.Equal(t, 0, parseUnprivilegedPortStart("0\n"))
+	assert.Equal(t, defaultUnprivilegedPortStart, parseUnprivilegedPortStart("garbage"))
+	assert.Equal(t, defaultUnprivilegedPortStart, parseUnprivilegedPortStart("-1"))
+}
@@ -0,0 +1,22 @@
+//go:build !linux
+
+package config
+
+import (
+	"os"
+	"runtime"
+)
+
+// privilegedPortLimit returns the lowest port this process can bind, or 0
+// when it may bind any port.
+func privilegedPortLimit() int {
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		// Windows has no privileged ports and macOS dropped them in 10.14
+		return 0
+	}
+	if os.Geteuid() == 0 {
+		return 0
+	}
+	return defaultUnprivilegedPortStart
+}
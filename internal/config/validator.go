@@ -1,8 +1,15 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -11,6 +18,14 @@ const (
 	MinPort = 1
 	MaxPort = 65535
 
+	// defaultUnprivilegedPortStart is the first port non-root users may bind
+	// on systems that restrict low ports
+	defaultUnprivilegedPortStart = 1024
+
+	// MaxControlSocketPathLength is the longest unix socket path usable on
+	// every supported platform: macOS sun_path holds 104 bytes including NUL
+	MaxControlSocketPathLength = 103
+
 	// DNS1123LabelMaxLength is the maximum length of a DNS label (RFC 1123)
 	DNS1123LabelMaxLength = 63
 	// DNS1123SubdomainMaxLength is the maximum length of a DNS subdomain name
@@ -36,11 +51,38 @@ var (
 	// Must start and end with an alphanumeric character.
 	contextNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._:/@_-]*[a-zA-Z0-9])?$`)
 
+	// mdnsServiceTypeRegexp matches DNS-SD service types (RFC 6763 section 7),
+	// e.g. _kportal._tcp or _http._tcp
+	mdnsServiceTypeRegexp = regexp.MustCompile(`^_[A-Za-z0-9]([A-Za-z0-9-]{0,13}[A-Za-z0-9])?\._(tcp|udp)$`)
+
+	// portNameRegexp matches Kubernetes container port names (IANA_SVC_NAME):
+	// at most 15 lowercase alphanumeric characters or '-', containing a letter
+	portNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,13}[a-z0-9])?$`)
+
 	// validResourceTypes contains the allowed Kubernetes resource types
-	validResourceTypes = []string{"pod", "service"}
+	validResourceTypes = []string{"pod", "service", "deployment", "statefulset"}
+
+	// validProtocols contains the allowed forward protocols
+	validProtocols = []string{ProtocolTCP, ProtocolUDP}
+
+	// validSchemes contains the allowed forward URL schemes
+	validSchemes = []string{SchemeHTTP, SchemeHTTPS}
 
 	// validHealthCheckMethods contains the allowed health check methods
 	validHealthCheckMethods = []string{"tcp-dial", "data-transfer"}
+
+	// validThemes contains the built-in UI themes
+	validThemes = []string{"dark", "light"}
+
+	// validHiddenColumns contains the TUI table columns that can be hidden
+	validHiddenColumns = []string{"context", "namespace", "type", "resource", "uptime"}
+
+	// validPrivilegedPortModes contains the allowed privilegedPorts values
+	validPrivilegedPortModes = []string{PrivilegedPortsWarn, PrivilegedPortsError}
+
+	// portLimit reports the lowest local port this process may bind.
+	// Overridden in tests.
+	portLimit = privilegedPortLimit
 )
 
 // IsValidPort returns true if the port number is within the valid range (1-65535).
@@ -48,11 +90,40 @@ func IsValidPort(port int) bool {
 	return port >= MinPort && port <= MaxPort
 }
 
+// Severity tells whether a ValidationError aborts startup.
+type Severity int
+
+const (
+	// SeverityError rejects the configuration. It is the zero value.
+	SeverityError Severity = iota
+	// SeverityWarning is reported but does not reject the configuration.
+	SeverityWarning
+)
+
+// String returns "error" or "warning".
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
 // ValidationError represents a configuration validation error with context.
 type ValidationError struct {
-	Context map[string]string
-	Field   string
-	Message string
+	Context  map[string]string
+	Field    string
+	Message  string
+	Severity Severity
+}
+
+// HasErrors reports whether errs contains anything other than warnings.
+func HasErrors(errs []ValidationError) bool {
+	for _, err := range errs {
+		if err.Severity != SeverityWarning {
+			return true
+		}
+	}
+	return false
 }
 
 // Validator validates configuration files.
@@ -85,6 +156,18 @@ func (v *Validator) ValidateConfigWithOptions(cfg *Config, allowEmpty bool) []Va
 	if allowEmpty && cfg.IsEmpty() {
 		// Still validate health check and reliability if present (they don't require forwards)
 		errs = append(errs, v.validateSpecDurations(cfg)...)
+		errs = append(errs, v.validateMetricsAddr(cfg)...)
+		errs = append(errs, v.validateControlSocket(cfg)...)
+		errs = append(errs, v.validateKubeconfig(cfg)...)
+		errs = append(errs, v.validateTheme(cfg)...)
+		errs = append(errs, v.validateHiddenColumns(cfg)...)
+		errs = append(errs, v.validatePrivilegedPortsMode(cfg)...)
+		errs = append(errs, v.validateResolveCache(cfg)...)
+		errs = append(errs, v.validateHTTPLogMaxEntries(cfg)...)
+		errs = append(errs, v.validateHTTPLogLatency(cfg)...)
+		errs = append(errs, v.validateProfiles(cfg)...)
+		errs = append(errs, v.validateNotify(cfg)...)
+		errs = append(errs, v.validateWebhooks(cfg)...)
 		return errs
 	}
 
@@ -111,9 +194,436 @@ func (v *Validator) ValidateConfigWithOptions(cfg *Config, allowEmpty bool) []Va
 	// Validate duration fields in specs
 	errs = append(errs, v.validateSpecDurations(cfg)...)
 
+	errs = append(errs, v.validateMetricsAddr(cfg)...)
+	errs = append(errs, v.validateControlSocket(cfg)...)
+	errs = append(errs, v.validateKubeconfig(cfg)...)
+	errs = append(errs, v.validateTheme(cfg)...)
+	errs = append(errs, v.validateHiddenColumns(cfg)...)
+	errs = append(errs, v.validatePrivilegedPortsMode(cfg)...)
+	errs = append(errs, v.validateResolveCache(cfg)...)
+	errs = append(errs, v.validateHTTPLogMaxEntries(cfg)...)
+	errs = append(errs, v.validateHTTPLogLatency(cfg)...)
+	errs = append(errs, v.validateProfiles(cfg)...)
+	errs = append(errs, v.validateNotify(cfg)...)
+	errs = append(errs, v.validateWebhooks(cfg)...)
+
+	if cfg.GetPrivilegedPorts() == PrivilegedPortsError {
+		errs = append(errs, v.CheckPrivilegedPorts(cfg)...)
+	}
+
+	return errs
+}
+
+// Warnings returns the checks that are reported without rejecting the
+// configuration: privileged ports (unless privilegedPorts is "error"), a
+// high httpLogMaxEntries, aliases that are not valid hostnames and profile
+// members that select no forward. Each has SeverityWarning.
+func (v *Validator) Warnings(cfg *Config) []ValidationError {
+	var warnings []ValidationError
+	if cfg.GetPrivilegedPorts() == PrivilegedPortsWarn {
+		warnings = append(warnings, v.CheckPrivilegedPorts(cfg)...)
+	}
+	warnings = append(warnings, v.CheckHTTPLogMaxEntries(cfg)...)
+	warnings = append(warnings, v.CheckAliases(cfg)...)
+	warnings = append(warnings, v.CheckProfiles(cfg)...)
+	for i := range warnings {
+		warnings[i].Severity = SeverityWarning
+	}
+	return warnings
+}
+
+// CheckPrivilegedPorts reports forwards whose localPort this process cannot
+// bind without extra privileges. It returns nothing when running as root,
+// with CAP_NET_BIND_SERVICE, or on platforms without privileged ports.
+// Depending on the privilegedPorts setting, callers surface the result as
+// warnings or ValidateConfig includes it as errors.
+func (v *Validator) CheckPrivilegedPorts(cfg *Config) []ValidationError {
+	limit := portLimit()
+	if limit <= MinPort {
+		return nil
+	}
+
+	var errs []ValidationError
+	for _, ctx := range cfg.Contexts {
+		for _, ns := range ctx.Namespaces {
+			for i := range ns.Forwards {
+				for _, fwd := range ns.Forwards[i].Expand() {
+					if fwd.LocalPort == 0 || fwd.IsAutoLocalPort() || fwd.LocalPort >= limit {
+						continue
+					}
+					errs = append(errs, ValidationError{
+						Field: "localPort",
+						Message: fmt.Sprintf("Local port %d is privileged and cannot be bound by this user (use a port of %d or above, or run kportal with permission to bind it)",
+							fwd.LocalPort, limit),
+						Context: map[string]string{
+							"context":   ctx.Name,
+							"namespace": ns.Name,
+							"forward":   fwd.ID(),
+						},
+					})
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// validatePrivilegedPortsMode checks privilegedPorts is "warn" or "error".
+func (v *Validator) validatePrivilegedPortsMode(cfg *Config) []ValidationError {
+	if cfg.PrivilegedPorts == "" || isValidPrivilegedPortsMode(cfg.PrivilegedPorts) {
+		return nil
+	}
+	return []ValidationError{{
+		Field: "privilegedPorts",
+		Message: fmt.Sprintf("Invalid privilegedPorts '%s' (must be one of: %s)",
+			cfg.PrivilegedPorts, strings.Join(validPrivilegedPortModes, ", ")),
+	}}
+}
+
+// validateResolveCache checks resolveCacheTTL is a non-negative duration
+// and resolveCacheMaxEntries is not negative.
+func (v *Validator) validateResolveCache(cfg *Config) []ValidationError {
+	var errs []ValidationError
+	if cfg.ResolveCacheTTL != "" {
+		if _, err := parseNonNegativeDuration(cfg.ResolveCacheTTL); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   "resolveCacheTTL",
+				Message: fmt.Sprintf("Invalid resolve cache TTL '%s': %v", cfg.ResolveCacheTTL, err),
+			})
+		}
+	}
+	if cfg.ResolveCacheMaxEntries < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "resolveCacheMaxEntries",
+			Message: fmt.Sprintf("Invalid resolve cache max entries %d (must not be negative)", cfg.ResolveCacheMaxEntries),
+		})
+	}
+	return errs
+}
+
+// validateNotify checks notify.debounce is a non-negative duration.
+func (v *Validator) validateNotify(cfg *Config) []ValidationError {
+	if cfg.Notify == nil || cfg.Notify.Debounce == "" {
+		return nil
+	}
+	if _, err := parseNonNegativeDuration(cfg.Notify.Debounce); err != nil {
+		return []ValidationError{{
+			Field:   "notify.debounce",
+			Message: fmt.Sprintf("Invalid notify debounce '%s': %v", cfg.Notify.Debounce, err),
+		}}
+	}
+	return nil
+}
+
+// validateWebhooks checks each webhook has an http or https URL, a positive
+// timeout and non-negative retries.
+func (v *Validator) validateWebhooks(cfg *Config) []ValidationError {
+	var errs []ValidationError
+	for i, hook := range cfg.Webhooks {
+		field := fmt.Sprintf("webhooks[%d]", i)
+		if u, err := url.Parse(hook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, ValidationError{
+				Field:   field + ".url",
+				Message: fmt.Sprintf("Invalid webhook URL '%s' (expected http:// or https:// with a host)", hook.URL),
+			})
+		}
+		if hook.Timeout != "" {
+			if d, err := time.ParseDuration(hook.Timeout); err != nil || d <= 0 {
+				errs = append(errs, ValidationError{
+					Field:   field + ".timeout",
+					Message: fmt.Sprintf("Invalid webhook timeout '%s' (must be a positive duration)", hook.Timeout),
+				})
+			}
+		}
+		if hook.Retries != nil && *hook.Retries < 0 {
+			errs = append(errs, ValidationError{
+				Field:   field + ".retries",
+				Message: fmt.Sprintf("Invalid webhook retries %d (must not be negative)", *hook.Retries),
+			})
+		}
+	}
+	return errs
+}
+
+// validateHTTPLogMaxEntries checks httpLogMaxEntries is not negative.
+func (v *Validator) validateHTTPLogMaxEntries(cfg *Config) []ValidationError {
+	if cfg.HTTPLogMaxEntries >= 0 {
+		return nil
+	}
+	return []ValidationError{{
+		Field:   "httpLogMaxEntries",
+		Message: fmt.Sprintf("Invalid HTTP log max entries %d (must not be negative)", cfg.HTTPLogMaxEntries),
+	}}
+}
+
+// validateHTTPLogLatency checks the latency coloring thresholds are
+// non-negative durations with slow below verySlow.
+func (v *Validator) validateHTTPLogLatency(cfg *Config) []ValidationError {
+	spec := cfg.HTTPLogLatency
+	if spec == nil {
+		return nil
+	}
+
+	var errs []ValidationError
+	for _, field := range []struct{ name, value string }{
+		{"httpLogLatency.slow", spec.Slow},
+		{"httpLogLatency.verySlow", spec.VerySlow},
+	} {
+		if field.value == "" {
+			continue
+		}
+		if _, err := parseNonNegativeDuration(field.value); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   field.name,
+				Message: fmt.Sprintf("Invalid latency threshold '%s': %v", field.value, err),
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if slow, verySlow := cfg.GetHTTPLogSlowLatency(), cfg.GetHTTPLogVerySlowLatency(); slow >= verySlow {
+		errs = append(errs, ValidationError{
+			Field:   "httpLogLatency",
+			Message: fmt.Sprintf("httpLogLatency.slow (%s) must be below httpLogLatency.verySlow (%s)", slow, verySlow),
+		})
+	}
+	return errs
+}
+
+// CheckHTTPLogMaxEntries warns when httpLogMaxEntries is above
+// HighHTTPLogMaxEntries. Each entry can hold a request and a response body
+// of up to maxBodySize, so a busy forward's log view can grow to gigabytes.
+// Callers surface the result as warnings.
+func (v *Validator) CheckHTTPLogMaxEntries(cfg *Config) []ValidationError {
+	if cfg.HTTPLogMaxEntries <= HighHTTPLogMaxEntries {
+		return nil
+	}
+	worstCase := float64(cfg.HTTPLogMaxEntries) * 2 * DefaultHTTPLogMaxBodySize / (1 << 30)
+	return []ValidationError{{
+		Field: "httpLogMaxEntries",
+		Message: fmt.Sprintf("httpLogMaxEntries %d is above %d; with the default %dKB body capture an open HTTP log view may use up to %.1f GB of memory",
+			cfg.HTTPLogMaxEntries, HighHTTPLogMaxEntries, DefaultHTTPLogMaxBodySize/1024, worstCase),
+		Severity: SeverityWarning,
+	}}
+}
+
+// CheckAliases warns about aliases that are not valid RFC 1123 hostnames and
+// would fail validation once mDNS is enabled. Aliases that validateMDNS
+// already rejects are skipped. Callers surface the result as warnings.
+func (v *Validator) CheckAliases(cfg *Config) []ValidationError {
+	var errs []ValidationError
+	for _, ctx := range cfg.Contexts {
+		for _, ns := range ctx.Namespaces {
+			for _, fwd := range ns.Forwards {
+				if fwd.Alias == "" || isValidHostname(fwd.Alias) {
+					continue
+				}
+				if cfg.IsMDNSEnabled() && fwd.IsMDNSPublished() {
+					continue
+				}
+				errs = append(errs, ValidationError{
+					Field:   "alias",
+					Message: fmt.Sprintf("Alias '%s' of forward %s is not a valid RFC 1123 hostname and will fail validation if mDNS is enabled", fwd.Alias, fwd.ID()),
+					Context: map[string]string{
+						"context":   ctx.Name,
+						"namespace": ns.Name,
+						"forward":   fwd.ID(),
+					},
+					Severity: SeverityWarning,
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// validateProfiles checks every profile has a name and no empty members.
+func (v *Validator) validateProfiles(cfg *Config) []ValidationError {
+	var errs []ValidationError
+	for _, name := range cfg.ProfileNames() {
+		if strings.TrimSpace(name) == "" {
+			errs = append(errs, ValidationError{
+				Field:   "profiles",
+				Message: "Profile name cannot be empty",
+			})
+			continue
+		}
+		for _, member := range cfg.Profiles[name] {
+			if member == "" || member == ProfileTagPrefix {
+				errs = append(errs, ValidationError{
+					Field:   "profiles",
+					Message: fmt.Sprintf("Profile '%s' has an empty member (expected a forward ID or tag:<name>)", name),
+					Context: map[string]string{"profile": name},
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// CheckProfiles warns about profile members that select no forward, such
+// as the ID of a forward whose alias was renamed. Callers surface the
+// result as warnings.
+func (v *Validator) CheckProfiles(cfg *Config) []ValidationError {
+	if len(cfg.Profiles) == 0 {
+		return nil
+	}
+
+	forwards := cfg.GetAllForwards()
+	var errs []ValidationError
+	for _, name := range cfg.ProfileNames() {
+		for _, member := range cfg.Profiles[name] {
+			if member == "" || member == ProfileTagPrefix {
+				continue // rejected by validateProfiles
+			}
+			matched := slices.ContainsFunc(forwards, func(fwd Forward) bool {
+				return inProfile([]string{member}, &fwd)
+			})
+			if matched {
+				continue
+			}
+			errs = append(errs, ValidationError{
+				Field:    "profiles",
+				Message:  fmt.Sprintf("Profile '%s' member '%s' matches no forward", name, member),
+				Context:  map[string]string{"profile": name},
+				Severity: SeverityWarning,
+			})
+		}
+	}
+	return errs
+}
+
+// CheckKubeconfigContexts returns a warning for each context in cfg that is
+// not among known, the contexts of kubeconfig. Such forwards start but show
+// "Context not found" until the context is added back.
+func (v *Validator) CheckKubeconfigContexts(cfg *Config, known []string) []ValidationError {
+	available := make(map[string]bool, len(known))
+	for _, name := range known {
+		available[name] = true
+	}
+
+	var errs []ValidationError
+	for _, ctx := range cfg.Contexts {
+		if available[ctx.Name] {
+			continue
+		}
+		errs = append(errs, ValidationError{
+			Field:    "contexts",
+			Message:  fmt.Sprintf("Context '%s' is not in kubeconfig; its forwards will show \"Context not found\" until it is added", ctx.Name),
+			Context:  map[string]string{"context": ctx.Name},
+			Severity: SeverityWarning,
+		})
+	}
+	return errs
+}
+
+// validateTheme checks the theme names one of the built-in palettes.
+func (v *Validator) validateTheme(cfg *Config) []ValidationError {
+	if cfg.Theme == "" || isValidTheme(cfg.Theme) {
+		return nil
+	}
+	return []ValidationError{{
+		Field:   "theme",
+		Message: fmt.Sprintf("Invalid theme '%s' (must be one of: %s)", cfg.Theme, strings.Join(validThemes, ", ")),
+	}}
+}
+
+// validateHiddenColumns checks every hidden column is one that can be
+// hidden.
+func (v *Validator) validateHiddenColumns(cfg *Config) []ValidationError {
+	var errs []ValidationError
+	for i, column := range cfg.HiddenColumns {
+		if slices.Contains(validHiddenColumns, column) {
+			continue
+		}
+		errs = append(errs, ValidationError{
+			Field:   fmt.Sprintf("hiddenColumns[%d]", i),
+			Message: fmt.Sprintf("Invalid hidden column '%s' (must be one of: %s)", column, strings.Join(validHiddenColumns, ", ")),
+		})
+	}
 	return errs
 }
 
+// validateControlSocket checks the control socket path fits in a unix
+// socket address on every platform kportal runs on.
+func (v *Validator) validateControlSocket(cfg *Config) []ValidationError {
+	if len(cfg.ControlSocket) > MaxControlSocketPathLength {
+		return []ValidationError{{
+			Field:   "controlSocket",
+			Message: fmt.Sprintf("Control socket path is too long (%d bytes, max %d)", len(cfg.ControlSocket), MaxControlSocketPathLength),
+		}}
+	}
+	return nil
+}
+
+// validateKubeconfig checks the kubeconfig file, when set, can be read.
+func (v *Validator) validateKubeconfig(cfg *Config) []ValidationError {
+	if cfg.Kubeconfig == "" {
+		return nil
+	}
+	if err := ValidateKubeconfigPath(cfg.GetKubeconfig()); err != nil {
+		return []ValidationError{{
+			Field:   "kubeconfig",
+			Message: err.Error(),
+		}}
+	}
+	return nil
+}
+
+// ValidateKubeconfigPath checks path names a readable kubeconfig file, so a
+// typo is reported at startup rather than as every forward failing to
+// connect.
+func ValidateKubeconfigPath(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			return fmt.Errorf("kubeconfig file '%s' does not exist", path)
+		case errors.Is(err, fs.ErrPermission):
+			return fmt.Errorf("kubeconfig file '%s' is not readable (permission denied)", path)
+		}
+		return fmt.Errorf("cannot open kubeconfig file '%s': %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot stat kubeconfig file '%s': %w", path, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("kubeconfig path '%s' is a directory, not a file", path)
+	}
+	return nil
+}
+
+// validateMetricsAddr checks metricsAddr is a host:port the metrics server
+// can listen on. The host may be empty to listen on all interfaces.
+func (v *Validator) validateMetricsAddr(cfg *Config) []ValidationError {
+	if cfg.MetricsAddr == "" {
+		return nil
+	}
+
+	_, portStr, err := net.SplitHostPort(cfg.MetricsAddr)
+	if err != nil {
+		return []ValidationError{{
+			Field:   "metricsAddr",
+			Message: fmt.Sprintf("Invalid metrics address '%s' (expected host:port, e.g. \":9109\")", cfg.MetricsAddr),
+		}}
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < MinPort || port > MaxPort {
+		return []ValidationError{{
+			Field:   "metricsAddr",
+			Message: fmt.Sprintf("Invalid metrics port '%s' (must be between %d and %d)", portStr, MinPort, MaxPort),
+		}}
+	}
+
+	return nil
+}
+
 // validateStructure validates the basic structure of the configuration.
 func (v *Validator) validateStructure(cfg *Config) []ValidationError {
 	var errs []ValidationError
@@ -186,38 +696,125 @@ func (v *Validator) validateForward(fwd *Forward) []ValidationError {
 		errs = append(errs, v.validateResource(fwd)...)
 	}
 
-	// Validate protocol - only "tcp" is currently supported
-	if fwd.Protocol != "" && fwd.Protocol != "tcp" {
+	// Validate protocol
+	if fwd.Protocol != "" && !isValidProtocol(fwd.Protocol) {
 		errs = append(errs, ValidationError{
 			Field:   "protocol",
-			Message: fmt.Sprintf("Invalid protocol '%s' for forward %s (only 'tcp' is supported)", fwd.Protocol, fwd.ID()),
+			Message: fmt.Sprintf("Invalid protocol '%s' for forward %s (must be one of: %s)", fwd.Protocol, fwd.ID(), strings.Join(validProtocols, ", ")),
+		})
+	}
+
+	// Validate ports. A named port may leave port unset (0): the number is
+	// looked up on the service or pod each time the forward connects.
+	if fwd.PortName != "" {
+		if strings.HasPrefix(fwd.Resource, "service/") {
+			// Service port names are DNS labels, longer than container port names
+			if len(fwd.PortName) > DNS1123LabelMaxLength || !dns1123LabelRegexp.MatchString(fwd.PortName) {
+				errs = append(errs, ValidationError{
+					Field:   "portName",
+					Message: fmt.Sprintf("Invalid portName '%s' for forward %s (must be a valid DNS label)", fwd.PortName, fwd.ID()),
+				})
+			}
+		} else if !portNameRegexp.MatchString(fwd.PortName) || strings.Trim(fwd.PortName, "0123456789-") == "" {
+			errs = append(errs, ValidationError{
+				Field:   "portName",
+				Message: fmt.Sprintf("Invalid portName '%s' for forward %s (must be at most 15 lowercase alphanumeric characters or '-', containing a letter)", fwd.PortName, fwd.ID()),
+			})
+		}
+	}
+	if fwd.Container != "" && !dns1123LabelRegexp.MatchString(fwd.Container) {
+		errs = append(errs, ValidationError{
+			Field:   "container",
+			Message: fmt.Sprintf("Invalid container '%s' for forward %s (must be a valid DNS label)", fwd.Container, fwd.ID()),
+		})
+	}
+
+	if len(fwd.Ports) > 0 {
+		errs = append(errs, v.validatePortList(fwd)...)
+	} else {
+		if (fwd.PortName == "" || fwd.Port != 0) && !IsValidPort(fwd.Port) {
+			errs = append(errs, ValidationError{
+				Field:   "port",
+				Message: fmt.Sprintf("Invalid port %d for forward %s (must be between %d and %d)", fwd.Port, fwd.ID(), MinPort, MaxPort),
+			})
+		}
+
+		// localPort 0 means "pick a free port"
+		if fwd.LocalPort != 0 && !IsValidPort(fwd.LocalPort) {
+			errs = append(errs, ValidationError{
+				Field:   "localPort",
+				Message: fmt.Sprintf("Invalid localPort %d for forward %s (must be between %d and %d, or 0 to auto-assign)", fwd.LocalPort, fwd.ID(), MinPort, MaxPort),
+			})
+		}
+	}
+
+	if fwd.BindAddress != "" && !isValidBindAddress(fwd.BindAddress) {
+		errs = append(errs, ValidationError{
+			Field:   "bindAddress",
+			Message: fmt.Sprintf("Invalid bindAddress '%s' for forward %s (must be localhost or an IPv4 or IPv6 address)", fwd.BindAddress, fwd.ID()),
+		})
+	}
+
+	if fwd.ReconnectMaxRetries < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "reconnectMaxRetries",
+			Message: fmt.Sprintf("Invalid reconnectMaxRetries %d for forward %s (must be 0 for unlimited or positive)", fwd.ReconnectMaxRetries, fwd.ID()),
 		})
 	}
 
-	// Validate ports
-	if !IsValidPort(fwd.Port) {
+	if fwd.IdleTimeout < 0 {
 		errs = append(errs, ValidationError{
-			Field:   "port",
-			Message: fmt.Sprintf("Invalid port %d for forward %s (must be between %d and %d)", fwd.Port, fwd.ID(), MinPort, MaxPort),
+			Field:   "idleTimeout",
+			Message: fmt.Sprintf("Invalid idleTimeout %d for forward %s (must be 0 to disable or a positive number of seconds)", fwd.IdleTimeout, fwd.ID()),
 		})
 	}
 
-	if !IsValidPort(fwd.LocalPort) {
+	if fwd.Order < 0 {
 		errs = append(errs, ValidationError{
-			Field:   "localPort",
-			Message: fmt.Sprintf("Invalid localPort %d for forward %s (must be between %d and %d)", fwd.LocalPort, fwd.ID(), MinPort, MaxPort),
+			Field:   "order",
+			Message: fmt.Sprintf("Invalid order %d for forward %s (must be a positive position, or 0 to list it after the ordered forwards)", fwd.Order, fwd.ID()),
 		})
 	}
 
+	if fwd.Scheme != "" && !isValidScheme(fwd.Scheme) {
+		errs = append(errs, ValidationError{
+			Field:   "scheme",
+			Message: fmt.Sprintf("Invalid scheme '%s' for forward %s (must be one of: %s)", fwd.Scheme, fwd.ID(), strings.Join(validSchemes, ", ")),
+		})
+	}
+
+	if fwd.TCPKeepalive != "" {
+		if _, err := parseNonNegativeDuration(fwd.TCPKeepalive); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   "tcpKeepalive",
+				Message: fmt.Sprintf("Invalid TCP keepalive duration '%s' for forward %s: %v", fwd.TCPKeepalive, fwd.ID(), err),
+			})
+		}
+	}
+
+	if fwd.DialTimeout != "" {
+		if _, err := parseNonNegativeDuration(fwd.DialTimeout); err != nil {
+			errs = append(errs, ValidationError{
+				Field:   "dialTimeout",
+				Message: fmt.Sprintf("Invalid dial timeout '%s' for forward %s: %v", fwd.DialTimeout, fwd.ID(), err),
+			})
+		}
+	}
+
 	// Note: Alias validation is handled in validateMDNS since aliases are primarily
-	// used for mDNS hostname registration. We only validate alias format when mDNS
-	// is enabled to avoid unnecessary restrictions on non-mDNS usage.
+	// used for mDNS hostname registration. We only reject alias format when mDNS
+	// is enabled to avoid unnecessary restrictions on non-mDNS usage; otherwise
+	// CheckAliases reports it as a warning.
 
 	// Validate HTTP log configuration if enabled
 	if fwd.HTTPLog != nil && fwd.HTTPLog.Enabled {
 		errs = append(errs, v.validateHTTPLog(fwd)...)
 	}
 
+	if fwd.HealthCheck != nil {
+		errs = append(errs, v.validateProbe(fwd)...)
+	}
+
 	return errs
 }
 
@@ -249,6 +846,10 @@ func (v *Validator) validateResource(fwd *Forward) []ValidationError {
 				entityType = "Pod"
 			case "service":
 				entityType = "Service"
+			case "deployment":
+				entityType = "Deployment"
+			case "statefulset":
+				entityType = "StatefulSet"
 			}
 			errs = append(errs, ValidationError{
 				Field:   "resource",
@@ -282,19 +883,26 @@ func (v *Validator) validateResource(fwd *Forward) []ValidationError {
 		}
 	}
 
-	// For service resources
-	if resourceType == "service" {
+	// For service, deployment and statefulset resources
+	if resourceType != "pod" {
 		if len(parts) < 2 || parts[1] == "" {
+			entityType := "Service"
+			switch resourceType {
+			case "deployment":
+				entityType = "Deployment"
+			case "statefulset":
+				entityType = "StatefulSet"
+			}
 			errs = append(errs, ValidationError{
 				Field:   "resource",
-				Message: fmt.Sprintf("Service name cannot be empty for forward %s (format: service/name)", fwd.ID()),
+				Message: fmt.Sprintf("%s name cannot be empty for forward %s (format: %s/name)", entityType, fwd.ID(), resourceType),
 			})
 		}
 
 		if fwd.Selector != "" {
 			errs = append(errs, ValidationError{
 				Field:   "selector",
-				Message: fmt.Sprintf("Forward %s uses service resource and should not have a selector", fwd.ID()),
+				Message: fmt.Sprintf("Forward %s uses %s resource and should not have a selector", fwd.ID(), resourceType),
 			})
 		}
 	}
@@ -302,20 +910,84 @@ func (v *Validator) validateResource(fwd *Forward) []ValidationError {
 	return errs
 }
 
+// validatePortList checks a forward's ports list, which replaces port,
+// localPort and portName.
+func (v *Validator) validatePortList(fwd *Forward) []ValidationError {
+	var errs []ValidationError
+
+	if fwd.Port != 0 || fwd.LocalPort != 0 || fwd.PortName != "" {
+		errs = append(errs, ValidationError{
+			Field:   "ports",
+			Message: fmt.Sprintf("Forward %s sets both ports and port, localPort or portName (use one or the other)", fwd.Resource),
+		})
+	}
+
+	autoPorts := 0
+	for i, mapping := range fwd.Ports {
+		if mapping.LocalPort == 0 {
+			autoPorts++
+		}
+		if !IsValidPort(mapping.Port) {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("ports[%d].port", i),
+				Message: fmt.Sprintf("Invalid port %d for forward %s (must be between %d and %d)", mapping.Port, fwd.Resource, MinPort, MaxPort),
+			})
+		}
+		if mapping.LocalPort != 0 && !IsValidPort(mapping.LocalPort) {
+			errs = append(errs, ValidationError{
+				Field:   fmt.Sprintf("ports[%d].localPort", i),
+				Message: fmt.Sprintf("Invalid localPort %d for forward %s (must be between %d and %d, or 0 to auto-assign)", mapping.LocalPort, fwd.Resource, MinPort, MaxPort),
+			})
+		}
+	}
+
+	// Auto-assigned forwards keep localPort 0 in their ID, so two of them
+	// would share one
+	if autoPorts > 1 {
+		errs = append(errs, ValidationError{
+			Field:   "ports",
+			Message: fmt.Sprintf("Forward %s auto-assigns %d local ports; at most one entry of ports may leave localPort 0", fwd.Resource, autoPorts),
+		})
+	}
+	return errs
+}
+
 // validateDuplicatePorts checks for duplicate local ports across all forwards.
 func (v *Validator) validateDuplicatePorts(cfg *Config) []ValidationError {
 	var errs []ValidationError
 
 	portMap := make(map[int][]string) // port -> list of forward IDs
+	autoIDs := make(map[string]int)   // ID -> count of auto-port forwards
 
 	for _, ctx := range cfg.Contexts {
 		for _, ns := range ctx.Namespaces {
-			for _, fwd := range ns.Forwards {
-				portMap[fwd.LocalPort] = append(portMap[fwd.LocalPort], fwd.ID())
+			for i := range ns.Forwards {
+				for _, fwd := range ns.Forwards[i].Expand() {
+					// Auto-assigned ports never collide, but two auto-port
+					// forwards of one resource and remote port share an ID,
+					// and only the first would be started
+					if fwd.LocalPort == 0 {
+						autoIDs[fwd.ID()]++
+						continue
+					}
+					portMap[fwd.LocalPort] = append(portMap[fwd.LocalPort], fwd.ID())
+				}
 			}
 		}
 	}
 
+	for id, count := range autoIDs {
+		if count > 1 {
+			errs = append(errs, ValidationError{
+				Field:   "localPort",
+				Message: fmt.Sprintf("Forward %s is defined %d times with localPort 0", id, count),
+				Context: map[string]string{
+					"forward": id,
+				},
+			})
+		}
+	}
+
 	// Find duplicates
 	for port, forwards := range portMap {
 		if len(forwards) > 1 {
@@ -387,7 +1059,7 @@ func (v *Validator) validateSpecDurations(cfg *Config) []ValidationError {
 	// Validate Reliability durations
 	if cfg.Reliability != nil {
 		if cfg.Reliability.TCPKeepalive != "" {
-			if _, err := time.ParseDuration(cfg.Reliability.TCPKeepalive); err != nil {
+			if _, err := parseNonNegativeDuration(cfg.Reliability.TCPKeepalive); err != nil {
 				errs = append(errs, ValidationError{
 					Field:   "reliability.tcpKeepalive",
 					Message: fmt.Sprintf("Invalid TCP keepalive duration '%s': %v", cfg.Reliability.TCPKeepalive, err),
@@ -396,7 +1068,7 @@ func (v *Validator) validateSpecDurations(cfg *Config) []ValidationError {
 		}
 
 		if cfg.Reliability.DialTimeout != "" {
-			if _, err := time.ParseDuration(cfg.Reliability.DialTimeout); err != nil {
+			if _, err := parseNonNegativeDuration(cfg.Reliability.DialTimeout); err != nil {
 				errs = append(errs, ValidationError{
 					Field:   "reliability.dialTimeout",
 					Message: fmt.Sprintf("Invalid dial timeout '%s': %v", cfg.Reliability.DialTimeout, err),
@@ -412,11 +1084,84 @@ func (v *Validator) validateSpecDurations(cfg *Config) []ValidationError {
 				})
 			}
 		}
+
+		if cfg.Reliability.ReconnectBaseDelay != "" {
+			if d, err := time.ParseDuration(cfg.Reliability.ReconnectBaseDelay); err != nil || d <= 0 {
+				errs = append(errs, ValidationError{
+					Field:   "reliability.reconnectBaseDelay",
+					Message: fmt.Sprintf("Invalid reconnect base delay '%s' (must be a positive duration)", cfg.Reliability.ReconnectBaseDelay),
+				})
+			}
+		}
+
+		if cfg.Reliability.ReconnectMaxDelay != "" {
+			if d, err := time.ParseDuration(cfg.Reliability.ReconnectMaxDelay); err != nil || d <= 0 {
+				errs = append(errs, ValidationError{
+					Field:   "reliability.reconnectMaxDelay",
+					Message: fmt.Sprintf("Invalid reconnect max delay '%s' (must be a positive duration)", cfg.Reliability.ReconnectMaxDelay),
+				})
+			} else if base := cfg.GetReconnectBaseDelay(); d < base {
+				errs = append(errs, ValidationError{
+					Field:   "reliability.reconnectMaxDelay",
+					Message: fmt.Sprintf("Reconnect max delay %v must not be shorter than the base delay %v", d, base),
+				})
+			}
+		}
+
+		if cfg.Reliability.StartupStagger != "" {
+			if _, err := parseNonNegativeDuration(cfg.Reliability.StartupStagger); err != nil {
+				errs = append(errs, ValidationError{
+					Field:   "reliability.startupStagger",
+					Message: fmt.Sprintf("Invalid startup stagger '%s': %v", cfg.Reliability.StartupStagger, err),
+				})
+			}
+		}
+
+		if cfg.Reliability.NetworkWatchInterval != "" {
+			if _, err := parseNonNegativeDuration(cfg.Reliability.NetworkWatchInterval); err != nil {
+				errs = append(errs, ValidationError{
+					Field:   "reliability.networkWatchInterval",
+					Message: fmt.Sprintf("Invalid network watch interval '%s': %v", cfg.Reliability.NetworkWatchInterval, err),
+				})
+			}
+		}
+
+		if jitter := cfg.Reliability.ReconnectJitter; jitter != nil && (*jitter < 0 || *jitter > 1) {
+			errs = append(errs, ValidationError{
+				Field:   "reliability.reconnectJitter",
+				Message: fmt.Sprintf("Invalid reconnect jitter %v (must be between 0 and 1)", *jitter),
+			})
+		}
+
+		if n := cfg.Reliability.DiscoveryConcurrency; n != nil && *n < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "reliability.discoveryConcurrency",
+				Message: fmt.Sprintf("Invalid discovery concurrency %d (must not be negative)", *n),
+			})
+		}
+		if n := cfg.Reliability.DiscoveryConcurrencyTotal; n < 0 {
+			errs = append(errs, ValidationError{
+				Field:   "reliability.discoveryConcurrencyTotal",
+				Message: fmt.Sprintf("Invalid discovery concurrency total %d (must not be negative)", n),
+			})
+		}
 	}
 
 	return errs
 }
 
+// parseNonNegativeDuration parses a duration that must not be negative.
+func parseNonNegativeDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return d, nil
+}
+
 // validateHTTPLog validates HTTP log configuration.
 func (v *Validator) validateHTTPLog(fwd *Forward) []ValidationError {
 	var errs []ValidationError
@@ -433,30 +1178,107 @@ func (v *Validator) validateHTTPLog(fwd *Forward) []ValidationError {
 		})
 	}
 
+	if fwd.HTTPLog.MaxFileSize < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "httpLog.maxFileSize",
+			Message: fmt.Sprintf("Invalid maxFileSize %d for forward %s (must be non-negative)", fwd.HTTPLog.MaxFileSize, fwd.ID()),
+		})
+	}
+
+	if fwd.HTTPLog.MaxFiles < 0 {
+		errs = append(errs, ValidationError{
+			Field:   "httpLog.maxFiles",
+			Message: fmt.Sprintf("Invalid maxFiles %d for forward %s (must be non-negative)", fwd.HTTPLog.MaxFiles, fwd.ID()),
+		})
+	}
+
+	// The logging proxy is an HTTP server, so it cannot sit in front of UDP traffic
+	if fwd.GetProtocol() == ProtocolUDP {
+		errs = append(errs, ValidationError{
+			Field:   "httpLog",
+			Message: fmt.Sprintf("HTTP logging is not supported for UDP forward %s", fwd.ID()),
+		})
+	}
+
 	return errs
 }
 
-// FormatValidationErrors formats validation errors into a human-readable string.
+// validateProbe validates a forward's HTTP health probe.
+func (v *Validator) validateProbe(fwd *Forward) []ValidationError {
+	var errs []ValidationError
+	probe := fwd.HealthCheck
+
+	if !strings.HasPrefix(probe.Path, "/") {
+		errs = append(errs, ValidationError{
+			Field:   "healthCheck.path",
+			Message: fmt.Sprintf("Invalid healthCheck path '%s' for forward %s (must start with '/')", probe.Path, fwd.ID()),
+		})
+	}
+
+	if probe.Interval != "" {
+		if d, err := time.ParseDuration(probe.Interval); err != nil || d <= 0 {
+			errs = append(errs, ValidationError{
+				Field:   "healthCheck.interval",
+				Message: fmt.Sprintf("Invalid healthCheck interval '%s' for forward %s (must be a positive duration, e.g. 10s)", probe.Interval, fwd.ID()),
+			})
+		}
+	}
+
+	if probe.ExpectedStatus != 0 && (probe.ExpectedStatus < 100 || probe.ExpectedStatus > 599) {
+		errs = append(errs, ValidationError{
+			Field:   "healthCheck.expectedStatus",
+			Message: fmt.Sprintf("Invalid healthCheck expectedStatus %d for forward %s (must be between 100 and 599)", probe.ExpectedStatus, fwd.ID()),
+		})
+	}
+
+	// The probe speaks HTTP over TCP
+	if fwd.GetProtocol() == ProtocolUDP {
+		errs = append(errs, ValidationError{
+			Field:   "healthCheck",
+			Message: fmt.Sprintf("HTTP health checks are not supported for UDP forward %s", fwd.ID()),
+		})
+	}
+
+	return errs
+}
+
+// FormatValidationErrors formats validation errors into a human-readable
+// string, listing warnings in a separate section after the errors.
 func FormatValidationErrors(errs []ValidationError) string {
-	if len(errs) == 0 {
-		return ""
+	var failures, warnings []ValidationError
+	for _, err := range errs {
+		if err.Severity == SeverityWarning {
+			warnings = append(warnings, err)
+		} else {
+			failures = append(failures, err)
+		}
 	}
 
 	var sb strings.Builder
-	sb.WriteString("\nConfiguration Validation Errors:\n")
+	writeValidationSection(&sb, "Configuration Validation Errors", failures)
+	writeValidationSection(&sb, "Configuration Validation Warnings", warnings)
+	return sb.String()
+}
+
+// writeValidationSection writes a numbered list of errs under title, or
+// nothing when errs is empty.
+func writeValidationSection(sb *strings.Builder, title string, errs []ValidationError) {
+	if len(errs) == 0 {
+		return
+	}
+
+	fmt.Fprintf(sb, "\n%s:\n", title)
 	sb.WriteString(strings.Repeat("=", 50) + "\n\n")
 
 	for i, err := range errs {
-		fmt.Fprintf(&sb, "%d. %s\n", i+1, err.Message)
+		fmt.Fprintf(sb, "%d. %s\n", i+1, err.Message)
 		if len(err.Context) > 0 {
 			for k, v := range err.Context {
-				fmt.Fprintf(&sb, "   %s: %s\n", k, v)
+				fmt.Fprintf(sb, "   %s: %s\n", k, v)
 			}
 		}
 		sb.WriteString("\n")
 	}
-
-	return sb.String()
 }
 
 // validateMDNS validates mDNS configuration when enabled.
@@ -465,11 +1287,23 @@ func FormatValidationErrors(errs []ValidationError) string {
 func (v *Validator) validateMDNS(cfg *Config) []ValidationError {
 	var errs []ValidationError
 
+	if st := cfg.MDNS.ServiceType; st != "" && !mdnsServiceTypeRegexp.MatchString(st) {
+		errs = append(errs, ValidationError{
+			Field:   "mdns.serviceType",
+			Message: fmt.Sprintf("Invalid mDNS service type '%s' (expected _name._tcp or _name._udp, name up to 15 letters, digits or hyphens)", st),
+		})
+	}
+
 	aliasMap := make(map[string][]string) // alias -> list of forward IDs using it
 
 	for _, ctx := range cfg.Contexts {
 		for _, ns := range ctx.Namespaces {
 			for _, fwd := range ns.Forwards {
+				// Forwards opted out with mdnsPublish: false are never published
+				if !fwd.IsMDNSPublished() {
+					continue
+				}
+
 				// Get the mDNS alias (explicit or generated from resource name)
 				mdnsAlias := fwd.GetMDNSAlias()
 				if mdnsAlias == "" {
@@ -551,6 +1385,38 @@ func isValidResourceType(resourceType string) bool {
 	return false
 }
 
+// isValidProtocol returns true if the protocol is valid.
+func isValidProtocol(protocol string) bool {
+	for _, p := range validProtocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidScheme returns true if the URL scheme is valid.
+func isValidScheme(scheme string) bool {
+	for _, s := range validSchemes {
+		if s == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidBindAddress returns true if addr is localhost or an IP literal.
+// IPv6 literals may be bracketed, as in URLs.
+func isValidBindAddress(addr string) bool {
+	if addr == "localhost" {
+		return true
+	}
+	if strings.HasPrefix(addr, "[") && strings.HasSuffix(addr, "]") {
+		addr = addr[1 : len(addr)-1]
+	}
+	return net.ParseIP(addr) != nil
+}
+
 // isValidHealthCheckMethod returns true if the health check method is valid.
 func isValidHealthCheckMethod(method string) bool {
 	for _, m := range validHealthCheckMethods {
@@ -561,6 +1427,26 @@ func isValidHealthCheckMethod(method string) bool {
 	return false
 }
 
+// isValidPrivilegedPortsMode returns true if mode is a known privilegedPorts value.
+func isValidPrivilegedPortsMode(mode string) bool {
+	for _, m := range validPrivilegedPortModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidTheme returns true if the theme is one of the built-in themes.
+func isValidTheme(theme string) bool {
+	for _, t := range validThemes {
+		if t == theme {
+			return true
+		}
+	}
+	return false
+}
+
 // validateContextName validates that a context name follows the allowed format.
 // Context names must consist of alphanumeric characters, hyphens, or underscores,
 // and must start and end with an alphanumeric character.
@@ -2,10 +2,14 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidator_ValidateConfig(t *testing.T) {
@@ -167,7 +171,7 @@ func TestValidator_ValidateConfig(t *testing.T) {
 				},
 			},
 			expectErrors:  true,
-			errorContains: []string{"Invalid protocol 'http'", "only 'tcp' is supported"},
+			errorContains: []string{"Invalid protocol 'http'", "must be one of: tcp, udp"},
 		},
 		{
 			name: "empty resource",
@@ -269,14 +273,14 @@ func TestValidator_ValidateResourceFormat(t *testing.T) {
 		{
 			name: "invalid resource type",
 			forward: Forward{
-				Resource:      "deployment/my-app",
+				Resource:      "daemonset/my-app",
 				Port:          8080,
 				LocalPort:     8080,
 				contextName:   "dev",
 				namespaceName: "default",
 			},
 			expectErrors:  true,
-			errorContains: []string{"Invalid resource type 'deployment'"},
+			errorContains: []string{"Invalid resource type 'daemonset'"},
 		},
 		{
 			name: "pod with name and selector (invalid)",
@@ -340,6 +344,53 @@ func TestValidator_ValidateResourceFormat(t *testing.T) {
 			expectErrors:  true,
 			errorContains: []string{"Pod name cannot be empty"},
 		},
+		{
+			name: "valid deployment",
+			forward: Forward{
+				Resource:      "deployment/api",
+				Port:          8080,
+				LocalPort:     8080,
+				contextName:   "dev",
+				namespaceName: "default",
+			},
+			expectErrors: false,
+		},
+		{
+			name: "valid statefulset",
+			forward: Forward{
+				Resource:      "statefulset/postgres",
+				Port:          5432,
+				LocalPort:     5432,
+				contextName:   "dev",
+				namespaceName: "default",
+			},
+			expectErrors: false,
+		},
+		{
+			name: "statefulset without name (invalid)",
+			forward: Forward{
+				Resource:      "statefulset",
+				Port:          5432,
+				LocalPort:     5432,
+				contextName:   "dev",
+				namespaceName: "default",
+			},
+			expectErrors:  true,
+			errorContains: []string{"StatefulSet name cannot be empty", "format: statefulset/name"},
+		},
+		{
+			name: "deployment with selector (invalid)",
+			forward: Forward{
+				Resource:      "deployment/api",
+				Selector:      "app=api",
+				Port:          8080,
+				LocalPort:     8080,
+				contextName:   "dev",
+				namespaceName: "default",
+			},
+			expectErrors:  true,
+			errorContains: []string{"uses deployment resource and should not have a selector"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -441,6 +492,38 @@ func TestValidator_CheckDuplicatePorts(t *testing.T) {
 			expectErrors:  true,
 			errorContains: []string{"Duplicate local port 8080"},
 		},
+		{
+			name: "multiple auto-assigned ports",
+			config: &Config{
+				Contexts: []Context{
+					{
+						Name: "dev-cluster",
+						Namespaces: []Namespace{
+							{
+								Name: "default",
+								Forwards: []Forward{
+									{
+										Resource:      "pod/app1",
+										Port:          8080,
+										LocalPort:     0,
+										contextName:   "dev-cluster",
+										namespaceName: "default",
+									},
+									{
+										Resource:      "pod/app2",
+										Port:          8081,
+										LocalPort:     0,
+										contextName:   "dev-cluster",
+										namespaceName: "default",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErrors: false,
+		},
 		{
 			name: "duplicate ports across namespaces",
 			config: &Config{
@@ -549,6 +632,41 @@ func TestValidator_CheckDuplicatePorts(t *testing.T) {
 	}
 }
 
+func TestValidator_AutoLocalPortIDs(t *testing.T) {
+	validator := NewValidator()
+	parse := func(forwards string) *Config {
+		t.Helper()
+		cfg, err := ParseConfig([]byte(`contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+` + forwards))
+		require.NoError(t, err)
+		return cfg
+	}
+
+	cfg := parse(`          - resource: service/api
+            port: 80
+          - resource: service/api
+            port: 9090
+`)
+	assert.Empty(t, validator.ValidateConfig(cfg))
+	forwards := cfg.GetAllForwards()
+	require.Len(t, forwards, 2)
+	assert.Equal(t, []string{"dev/default/service/api:auto-80", "dev/default/service/api:auto-9090"}, []string{forwards[0].ID(), forwards[1].ID()},
+		"auto-port forwards to different remote ports of one resource get their own IDs")
+
+	errs := validator.ValidateConfig(parse(`          - resource: service/api
+            port: 80
+          - resource: service/api
+            port: 80
+`))
+	require.Len(t, errs, 1)
+	assert.Equal(t, "localPort", errs[0].Field)
+	assert.Contains(t, errs[0].Message, "Forward dev/default/service/api:auto-80 is defined 2 times")
+}
+
 func TestFormatValidationErrors(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -602,6 +720,18 @@ func TestFormatValidationErrors(t *testing.T) {
 			expectEmpty:    false,
 			expectContains: []string{"Configuration Validation Errors", "Duplicate local port 8080", "port:", "8080", "forwards:"},
 		},
+		{
+			name: "errors and warnings",
+			errors: []ValidationError{
+				{Field: "alias", Message: "Alias 'a b' is not a valid hostname", Severity: SeverityWarning},
+				{Field: "port", Message: "Invalid port 0"},
+			},
+			expectEmpty: false,
+			expectContains: []string{
+				"Configuration Validation Errors:\n" + strings.Repeat("=", 50) + "\n\n1. Invalid port 0",
+				"Configuration Validation Warnings:\n" + strings.Repeat("=", 50) + "\n\n1. Alias 'a b'",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -696,6 +826,7 @@ func TestValidator_ValidateStructure(t *testing.T) {
 
 func TestValidator_ValidateMDNS(t *testing.T) {
 	validator := NewValidator()
+	falseVal := false
 
 	tests := []struct {
 		config        *Config
@@ -743,6 +874,42 @@ func TestValidator_ValidateMDNS(t *testing.T) {
 			},
 			expectErrors: false,
 		},
+		{
+			name: "mDNS enabled - custom service type",
+			config: &Config{
+				MDNS: &MDNSSpec{Enabled: true, ServiceType: "_http._tcp"},
+				Contexts: []Context{
+					{
+						Name: "dev",
+						Namespaces: []Namespace{
+							{
+								Name: "default",
+								Forwards: []Forward{
+									{Resource: "pod/app", Port: 8080, LocalPort: 8080, Alias: "app", contextName: "dev", namespaceName: "default"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErrors: false,
+		},
+		{
+			name: "mDNS enabled - invalid service type",
+			config: &Config{
+				MDNS: &MDNSSpec{Enabled: true, ServiceType: "http.tcp"},
+			},
+			expectErrors:  true,
+			errorContains: []string{"Invalid mDNS service type"},
+		},
+		{
+			name: "mDNS enabled - service type name too long",
+			config: &Config{
+				MDNS: &MDNSSpec{Enabled: true, ServiceType: "_averyveryverylongname._tcp"},
+			},
+			expectErrors:  true,
+			errorContains: []string{"Invalid mDNS service type"},
+		},
 		{
 			name: "mDNS enabled - no alias (allowed)",
 			config: &Config{
@@ -784,6 +951,47 @@ func TestValidator_ValidateMDNS(t *testing.T) {
 			expectErrors:  true,
 			errorContains: []string{"invalid mDNS hostname", "RFC 1123"},
 		},
+		{
+			name: "mDNS enabled - invalid alias on opted-out forward is ignored",
+			config: &Config{
+				MDNS: &MDNSSpec{Enabled: true},
+				Contexts: []Context{
+					{
+						Name: "dev",
+						Namespaces: []Namespace{
+							{
+								Name: "default",
+								Forwards: []Forward{
+									{Resource: "pod/app", Port: 8080, LocalPort: 8080, Alias: "my_app", MDNSPublish: &falseVal, contextName: "dev", namespaceName: "default"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErrors: false,
+		},
+		{
+			name: "mDNS enabled - duplicate alias on opted-out forward is ignored",
+			config: &Config{
+				MDNS: &MDNSSpec{Enabled: true},
+				Contexts: []Context{
+					{
+						Name: "dev",
+						Namespaces: []Namespace{
+							{
+								Name: "default",
+								Forwards: []Forward{
+									{Resource: "pod/app1", Port: 8080, LocalPort: 8080, Alias: "myapp", contextName: "dev", namespaceName: "default"},
+									{Resource: "pod/app2", Port: 8081, LocalPort: 8081, Alias: "myapp", MDNSPublish: &falseVal, contextName: "dev", namespaceName: "default"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErrors: false,
+		},
 		{
 			name: "mDNS enabled - alias starts with hyphen",
 			config: &Config{
@@ -1145,7 +1353,9 @@ func TestValidateResourceFormat(t *testing.T) {
 		{name: "valid pod with subdomain", resource: "pod/my-app.example.com", errorMsg: "", expectError: false},
 		{name: "missing slash", resource: "pod", errorMsg: "must be in format 'type/name'", expectError: true},
 		{name: "empty string", resource: "", errorMsg: "must be in format 'type/name'", expectError: true},
-		{name: "invalid type", resource: "deployment/my-app", errorMsg: "invalid resource type", expectError: true},
+		{name: "valid deployment", resource: "deployment/my-app", errorMsg: "", expectError: false},
+		{name: "valid statefulset", resource: "statefulset/db", errorMsg: "", expectError: false},
+		{name: "invalid type", resource: "daemonset/my-app", errorMsg: "invalid resource type", expectError: true},
 		{name: "empty name", resource: "pod/", errorMsg: "resource name cannot be empty", expectError: true},
 		{name: "multiple slashes", resource: "pod/name/extra", errorMsg: "", expectError: false}, // First slash separates type/name, rest is part of name
 	}
@@ -1366,6 +1576,17 @@ func TestValidator_ValidateSpecDurations(t *testing.T) {
 			expectErrors:  true,
 			errorContains: []string{"Invalid dial timeout"},
 		},
+		{
+			name: "negative TCP keepalive and dial timeout",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					TCPKeepalive: "-30s",
+					DialTimeout:  "-1s",
+				},
+			},
+			expectErrors:  true,
+			errorContains: []string{"Invalid TCP keepalive duration '-30s': must not be negative", "Invalid dial timeout '-1s': must not be negative"},
+		},
 		{
 			name: "invalid watchdog period",
 			config: &Config{
@@ -1376,6 +1597,89 @@ func TestValidator_ValidateSpecDurations(t *testing.T) {
 			expectErrors:  true,
 			errorContains: []string{"Invalid watchdog period"},
 		},
+		{
+			name: "valid reconnect backoff",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					ReconnectBaseDelay: "500ms",
+					ReconnectMaxDelay:  "30s",
+					ReconnectJitter:    new(0.2),
+				},
+			},
+			expectErrors: false,
+		},
+		{
+			name: "invalid reconnect base delay",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					ReconnectBaseDelay: "0s",
+				},
+			},
+			expectErrors:  true,
+			errorContains: []string{"Invalid reconnect base delay"},
+		},
+		{
+			name: "invalid reconnect max delay",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					ReconnectMaxDelay: "soon",
+				},
+			},
+			expectErrors:  true,
+			errorContains: []string{"Invalid reconnect max delay"},
+		},
+		{
+			name: "reconnect max delay shorter than base",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					ReconnectBaseDelay: "5s",
+					ReconnectMaxDelay:  "2s",
+				},
+			},
+			expectErrors:  true,
+			errorContains: []string{"must not be shorter than the base delay"},
+		},
+		{
+			name: "reconnect jitter out of range",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					ReconnectJitter: new(1.5),
+				},
+			},
+			expectErrors:  true,
+			errorContains: []string{"Invalid reconnect jitter"},
+		},
+		{
+			name: "negative startup stagger",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					StartupStagger: "-50ms",
+				},
+			},
+			expectErrors:  true,
+			errorContains: []string{"Invalid startup stagger"},
+		},
+		{
+			name: "invalid network watch interval",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					NetworkWatchInterval: "often",
+				},
+			},
+			expectErrors:  true,
+			errorContains: []string{"Invalid network watch interval"},
+		},
+		{
+			name: "negative discovery concurrency",
+			config: &Config{
+				Reliability: &ReliabilitySpec{
+					DiscoveryConcurrency:      new(-1),
+					DiscoveryConcurrencyTotal: -2,
+				},
+			},
+			expectErrors:  true,
+			errorContains: []string{"Invalid discovery concurrency -1", "Invalid discovery concurrency total -2"},
+		},
 		{
 			name: "multiple invalid durations",
 			config: &Config{
@@ -1742,7 +2046,9 @@ func TestIsValidResourceType(t *testing.T) {
 	}{
 		{resourceType: "pod", expected: true},
 		{resourceType: "service", expected: true},
-		{resourceType: "deployment", expected: false},
+		{resourceType: "deployment", expected: true},
+		{resourceType: "statefulset", expected: true},
+		{resourceType: "daemonset", expected: false},
 		{resourceType: "configmap", expected: false},
 		{resourceType: "", expected: false},
 		{resourceType: "POD", expected: false}, // case sensitive
@@ -1845,6 +2151,23 @@ func TestValidator_ValidateHTTPLog(t *testing.T) {
 			expectErrors:  true,
 			errorContains: []string{"maxBodySize", "non-negative"},
 		},
+		{
+			name: "invalid negative rotation settings",
+			forward: Forward{
+				Resource:      "pod/app",
+				Port:          8080,
+				LocalPort:     8080,
+				contextName:   "dev",
+				namespaceName: "default",
+				HTTPLog: &HTTPLogSpec{
+					Enabled:     true,
+					MaxFileSize: -1,
+					MaxFiles:    -1,
+				},
+			},
+			expectErrors:  true,
+			errorContains: []string{"maxFileSize", "maxFiles"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1871,6 +2194,203 @@ func TestValidator_ValidateHTTPLog(t *testing.T) {
 	}
 }
 
+func TestValidator_ValidateContainerPort(t *testing.T) {
+	validator := NewValidator()
+
+	tests := []struct {
+		name          string
+		errorContains string
+		forward       Forward
+	}{
+		{
+			name:    "named port without number",
+			forward: Forward{Resource: "pod/app", PortName: "http", LocalPort: 8080},
+		},
+		{
+			name:    "named port on container with number",
+			forward: Forward{Resource: "deployment/app", Container: "app", PortName: "http", Port: 8080, LocalPort: 8080},
+		},
+		{
+			name:    "container with numeric port",
+			forward: Forward{Resource: "pod/app", Container: "sidecar", Port: 9090, LocalPort: 9090},
+		},
+		{
+			name:          "missing port and port name",
+			forward:       Forward{Resource: "pod/app", Container: "app", LocalPort: 8080},
+			errorContains: "Invalid port 0",
+		},
+		{
+			name:          "port name too long",
+			forward:       Forward{Resource: "pod/app", PortName: "a-very-long-port-name", LocalPort: 8080},
+			errorContains: "Invalid portName",
+		},
+		{
+			name:          "numeric port name",
+			forward:       Forward{Resource: "pod/app", PortName: "8080", LocalPort: 8080},
+			errorContains: "Invalid portName",
+		},
+		{
+			name:    "long service port name",
+			forward: Forward{Resource: "service/api", PortName: "grpc-web-external", LocalPort: 8080},
+		},
+		{
+			name:          "invalid service port name",
+			forward:       Forward{Resource: "service/api", PortName: "Grpc_Web", LocalPort: 8080},
+			errorContains: "must be a valid DNS label",
+		},
+		{
+			name:          "invalid container name",
+			forward:       Forward{Resource: "pod/app", Container: "App_1", Port: 8080, LocalPort: 8080},
+			errorContains: "Invalid container",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.forward.SetContext("dev", "default")
+			errs := validator.validateForward(&tt.forward)
+
+			if tt.errorContains == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.Contains(t, errs[0].Message, tt.errorContains)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateProbe(t *testing.T) {
+	validator := NewValidator()
+
+	tests := []struct {
+		probe         *ProbeSpec
+		name          string
+		protocol      string
+		errorContains string
+	}{
+		{name: "path only", probe: &ProbeSpec{Path: "/healthz"}},
+		{name: "all fields", probe: &ProbeSpec{Path: "/ready", Interval: "30s", ExpectedStatus: 204}},
+		{name: "relative path", probe: &ProbeSpec{Path: "healthz"}, errorContains: "must start with '/'"},
+		{name: "invalid interval", probe: &ProbeSpec{Path: "/healthz", Interval: "soon"}, errorContains: "Invalid healthCheck interval"},
+		{name: "zero interval", probe: &ProbeSpec{Path: "/healthz", Interval: "0s"}, errorContains: "Invalid healthCheck interval"},
+		{name: "invalid expected status", probe: &ProbeSpec{Path: "/healthz", ExpectedStatus: 42}, errorContains: "Invalid healthCheck expectedStatus 42"},
+		{name: "udp forward", probe: &ProbeSpec{Path: "/healthz"}, protocol: "udp", errorContains: "not supported for UDP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fwd := Forward{Resource: "pod/app", Protocol: tt.protocol, Port: 8080, LocalPort: 8080, HealthCheck: tt.probe}
+			fwd.SetContext("dev", "default")
+			errs := validator.validateForward(&fwd)
+
+			if tt.errorContains == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.Contains(t, errs[0].Message, tt.errorContains)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateReconnectMaxRetries(t *testing.T) {
+	validator := NewValidator()
+
+	for _, retries := range []int{0, 1, 10} {
+		fwd := Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080, ReconnectMaxRetries: retries}
+		fwd.SetContext("dev", "default")
+		assert.Empty(t, validator.validateForward(&fwd), "reconnectMaxRetries %d should be valid", retries)
+	}
+
+	fwd := Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080, ReconnectMaxRetries: -1}
+	fwd.SetContext("dev", "default")
+	errs := validator.validateForward(&fwd)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "reconnectMaxRetries", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "Invalid reconnectMaxRetries -1")
+	}
+}
+
+func TestValidator_ValidateForwardTuning(t *testing.T) {
+	validator := NewValidator()
+
+	fwd := Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080, TCPKeepalive: "10s", DialTimeout: "5s"}
+	fwd.SetContext("dev", "default")
+	assert.Empty(t, validator.validateForward(&fwd))
+	assert.Equal(t, 10*time.Second, fwd.GetTCPKeepalive())
+	assert.Equal(t, 5*time.Second, fwd.GetDialTimeout())
+
+	unset := Forward{}
+	assert.Zero(t, unset.GetTCPKeepalive(), "unset falls back to reliability.tcpKeepalive")
+	assert.Zero(t, unset.GetDialTimeout(), "unset falls back to reliability.dialTimeout")
+
+	fwd = Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080, TCPKeepalive: "-10s", DialTimeout: "soon"}
+	fwd.SetContext("dev", "default")
+	errs := validator.validateForward(&fwd)
+	if assert.Len(t, errs, 2) {
+		assert.Equal(t, "tcpKeepalive", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "must not be negative")
+		assert.Equal(t, "dialTimeout", errs[1].Field)
+		assert.Contains(t, errs[1].Message, "Invalid dial timeout 'soon'")
+	}
+}
+
+func TestValidator_ValidateIdleTimeout(t *testing.T) {
+	validator := NewValidator()
+
+	for _, seconds := range []int{0, 1, 300} {
+		fwd := Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080, IdleTimeout: seconds}
+		fwd.SetContext("dev", "default")
+		assert.Empty(t, validator.validateForward(&fwd), "idleTimeout %d should be valid", seconds)
+		assert.Equal(t, time.Duration(seconds)*time.Second, fwd.GetIdleTimeout())
+	}
+
+	fwd := Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080, IdleTimeout: -5}
+	fwd.SetContext("dev", "default")
+	errs := validator.validateForward(&fwd)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "idleTimeout", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "Invalid idleTimeout -5")
+	}
+}
+
+func TestValidator_ValidateOrder(t *testing.T) {
+	validator := NewValidator()
+
+	fwd := Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080, Order: 3}
+	fwd.SetContext("dev", "default")
+	assert.Empty(t, validator.validateForward(&fwd))
+
+	fwd.Order = -1
+	errs := validator.validateForward(&fwd)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "order", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "Invalid order -1")
+	}
+}
+
+func TestValidator_ValidateBindAddress(t *testing.T) {
+	validator := NewValidator()
+
+	for _, addr := range []string{"", "localhost", "127.0.0.1", "0.0.0.0", "::1", "[::1]", "::", "fd00::5"} {
+		fwd := Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080, BindAddress: addr}
+		fwd.SetContext("dev", "default")
+		assert.Empty(t, validator.validateForward(&fwd), "bindAddress %q should be valid", addr)
+	}
+
+	for _, addr := range []string{"example.com", "[::1", "127.0.0.1:8080", "256.0.0.1"} {
+		fwd := Forward{Resource: "pod/app", Port: 8080, LocalPort: 8080, BindAddress: addr}
+		fwd.SetContext("dev", "default")
+		errs := validator.validateForward(&fwd)
+		if assert.Len(t, errs, 1, "bindAddress %q should be invalid", addr) {
+			assert.Equal(t, "bindAddress", errs[0].Field)
+		}
+	}
+}
+
 func TestValidateContextName(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -2013,13 +2533,13 @@ func TestValidateProtocol(t *testing.T) {
 		// Valid protocols
 		{name: "valid tcp", protocol: "tcp", errorContains: "", expectErrors: false},
 		{name: "valid empty", protocol: "", errorContains: "", expectErrors: false},
+		{name: "valid udp", protocol: "udp", errorContains: "", expectErrors: false},
 
 		// Invalid protocols
-		{name: "invalid udp", protocol: "udp", errorContains: "only 'tcp' is supported", expectErrors: true},
-		{name: "invalid http", protocol: "http", errorContains: "only 'tcp' is supported", expectErrors: true},
-		{name: "invalid https", protocol: "https", errorContains: "only 'tcp' is supported", expectErrors: true},
-		{name: "invalid uppercase TCP", protocol: "TCP", errorContains: "only 'tcp' is supported", expectErrors: true},
-		{name: "invalid mixed case", protocol: "Tcp", errorContains: "only 'tcp' is supported", expectErrors: true},
+		{name: "invalid http", protocol: "http", errorContains: "must be one of: tcp, udp", expectErrors: true},
+		{name: "invalid https", protocol: "https", errorContains: "must be one of: tcp, udp", expectErrors: true},
+		{name: "invalid uppercase TCP", protocol: "TCP", errorContains: "must be one of: tcp, udp", expectErrors: true},
+		{name: "invalid mixed case", protocol: "Tcp", errorContains: "must be one of: tcp, udp", expectErrors: true},
 	}
 
 	for _, tt := range tests {
@@ -2050,3 +2570,477 @@ func TestValidateProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateScheme(t *testing.T) {
+	validator := NewValidator()
+
+	tests := []struct {
+		name         string
+		scheme       string
+		expectErrors bool
+	}{
+		{name: "empty defaults to http", scheme: "", expectErrors: false},
+		{name: "http", scheme: "http", expectErrors: false},
+		{name: "https", scheme: "https", expectErrors: false},
+		{name: "unsupported ftp", scheme: "ftp", expectErrors: true},
+		{name: "uppercase HTTPS", scheme: "HTTPS", expectErrors: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fwd := Forward{
+				Resource:      "pod/my-app",
+				Port:          8443,
+				LocalPort:     8443,
+				Scheme:        tt.scheme,
+				contextName:   "dev",
+				namespaceName: "default",
+			}
+			errs := validator.validateForward(&fwd)
+
+			if tt.expectErrors {
+				if assert.Len(t, errs, 1) {
+					assert.Equal(t, "scheme", errs[0].Field)
+					assert.Contains(t, errs[0].Message, "must be one of: http, https")
+				}
+			} else {
+				assert.Empty(t, errs, "expected no validation errors, got: %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateHTTPLog_RejectsUDP(t *testing.T) {
+	validator := NewValidator()
+
+	fwd := Forward{
+		Resource:      "service/coredns",
+		Protocol:      ProtocolUDP,
+		Port:          53,
+		LocalPort:     5353,
+		HTTPLog:       &HTTPLogSpec{Enabled: true},
+		contextName:   "dev",
+		namespaceName: "kube-system",
+	}
+	errs := validator.validateForward(&fwd)
+
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "httpLog", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "not supported for UDP")
+	}
+}
+
+func TestValidateMetricsAddr(t *testing.T) {
+	tests := []struct {
+		name          string
+		addr          string
+		errorContains string
+	}{
+		{name: "unset", addr: ""},
+		{name: "port only", addr: ":9109"},
+		{name: "host and port", addr: "127.0.0.1:9109"},
+		{name: "ipv6 host", addr: "[::1]:9109"},
+		{name: "missing port", addr: "localhost", errorContains: "expected host:port"},
+		{name: "non-numeric port", addr: ":metrics", errorContains: "must be between"},
+		{name: "port zero", addr: ":0", errorContains: "must be between"},
+		{name: "port too high", addr: ":65536", errorContains: "must be between"},
+	}
+
+	validator := NewValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.ValidateConfigWithOptions(&Config{MetricsAddr: tt.addr}, true)
+			if tt.errorContains == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.Equal(t, "metricsAddr", errs[0].Field)
+				assert.Contains(t, errs[0].Message, tt.errorContains)
+			}
+		})
+	}
+}
+
+func TestValidateControlSocket(t *testing.T) {
+	validator := NewValidator()
+
+	assert.Empty(t, validator.ValidateConfigWithOptions(&Config{}, true))
+	assert.Empty(t, validator.ValidateConfigWithOptions(&Config{ControlSocket: "/tmp/kportal.sock"}, true))
+
+	long := "/tmp/" + strings.Repeat("a", MaxControlSocketPathLength)
+	errs := validator.ValidateConfigWithOptions(&Config{ControlSocket: long}, true)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "controlSocket", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "too long")
+	}
+}
+
+func TestValidateKubeconfig(t *testing.T) {
+	validator := NewValidator()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kubeconfig")
+	require.NoError(t, os.WriteFile(path, []byte("apiVersion: v1\nkind: Config\n"), 0o600))
+
+	assert.Empty(t, validator.ValidateConfigWithOptions(&Config{Kubeconfig: path}, true))
+
+	errs := validator.ValidateConfigWithOptions(&Config{Kubeconfig: filepath.Join(dir, "missing")}, true)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "kubeconfig", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "does not exist")
+	}
+
+	err := ValidateKubeconfigPath(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is a directory")
+
+	if os.Geteuid() != 0 {
+		unreadable := filepath.Join(dir, "unreadable")
+		require.NoError(t, os.WriteFile(unreadable, nil, 0o000))
+		err = ValidateKubeconfigPath(unreadable)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not readable")
+	}
+}
+
+func TestValidatePortList(t *testing.T) {
+	validator := NewValidator()
+	parse := func(forward string) *Config {
+		t.Helper()
+		cfg, err := ParseConfig([]byte(`contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/db
+            port: 5432
+            localPort: 5432
+` + forward))
+		require.NoError(t, err)
+		return cfg
+	}
+	fields := func(errs []ValidationError) []string {
+		var names []string
+		for _, err := range errs {
+			names = append(names, err.Field)
+		}
+		return names
+	}
+
+	valid := parse(`          - resource: service/api
+            ports:
+              - port: 8080
+                localPort: 8080
+              - port: 9090
+                localPort: 0
+`)
+	assert.Empty(t, validator.ValidateConfig(valid))
+
+	errs := validator.ValidateConfig(parse(`          - resource: service/api
+            port: 80
+            ports:
+              - port: 70000
+                localPort: 8080
+              - port: 9090
+                localPort: 5432
+`))
+	assert.ElementsMatch(t, []string{"ports", "ports[0].port", "localPort"}, fields(errs), "the expansion's local ports are checked for duplicates")
+
+	errs = validator.ValidateConfig(parse(`          - resource: service/api
+            ports:
+              - port: 8080
+                localPort: 0
+              - port: 9090
+                localPort: 0
+`))
+	assert.Equal(t, []string{"ports"}, fields(errs))
+	assert.Contains(t, errs[0].Message, "at most one entry of ports may leave localPort 0")
+}
+
+func TestValidateTheme(t *testing.T) {
+	validator := NewValidator()
+
+	for _, theme := range []string{"", "dark", "light"} {
+		assert.Empty(t, validator.ValidateConfigWithOptions(&Config{Theme: theme}, true), theme)
+	}
+
+	errs := validator.ValidateConfigWithOptions(&Config{Theme: "solarized"}, true)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "theme", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "must be one of: dark, light")
+	}
+}
+
+func TestValidateHiddenColumns(t *testing.T) {
+	validator := NewValidator()
+
+	assert.Empty(t, validator.ValidateConfigWithOptions(&Config{HiddenColumns: []string{"context", "namespace", "uptime"}}, true))
+
+	errs := validator.ValidateConfigWithOptions(&Config{HiddenColumns: []string{"type", "status"}}, true)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "hiddenColumns[1]", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "Invalid hidden column 'status'")
+	}
+}
+
+func TestValidateResolveCache(t *testing.T) {
+	validator := NewValidator()
+
+	assert.Empty(t, validator.ValidateConfigWithOptions(&Config{ResolveCacheTTL: "1m", ResolveCacheMaxEntries: 10}, true))
+
+	errs := validator.ValidateConfigWithOptions(&Config{ResolveCacheTTL: "-1s", ResolveCacheMaxEntries: -1}, true)
+	if assert.Len(t, errs, 2) {
+		assert.Equal(t, "resolveCacheTTL", errs[0].Field)
+		assert.Equal(t, "resolveCacheMaxEntries", errs[1].Field)
+	}
+}
+
+func TestValidateNotify(t *testing.T) {
+	validator := NewValidator()
+
+	assert.Empty(t, validator.ValidateConfigWithOptions(&Config{Notify: &NotifySpec{Enabled: true, Debounce: "30s"}}, true))
+
+	errs := validator.ValidateConfigWithOptions(&Config{Notify: &NotifySpec{Enabled: true, Debounce: "-5s"}}, true)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "notify.debounce", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "Invalid notify debounce")
+	}
+}
+
+func TestValidateWebhooks(t *testing.T) {
+	validator := NewValidator()
+
+	assert.Empty(t, validator.ValidateConfigWithOptions(&Config{Webhooks: []WebhookSpec{
+		{URL: "https://hooks.example.com/kportal", Timeout: "3s", Retries: new(0)},
+		{URL: "http://localhost:9000/hook"},
+	}}, true))
+
+	errs := validator.ValidateConfigWithOptions(&Config{Webhooks: []WebhookSpec{
+		{URL: "hooks.example.com"},
+		{URL: "ftp://example.com", Timeout: "0s", Retries: new(-1)},
+	}}, true)
+	fields := make([]string, 0, len(errs))
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	assert.Equal(t, []string{"webhooks[0].url", "webhooks[1].url", "webhooks[1].timeout", "webhooks[1].retries"}, fields)
+}
+
+func TestValidateHTTPLogMaxEntries(t *testing.T) {
+	validator := NewValidator()
+
+	assert.Empty(t, validator.ValidateConfigWithOptions(&Config{HTTPLogMaxEntries: 500}, true))
+
+	errs := validator.ValidateConfigWithOptions(&Config{HTTPLogMaxEntries: -1}, true)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "httpLogMaxEntries", errs[0].Field)
+	}
+}
+
+func TestValidateHTTPLogLatency(t *testing.T) {
+	tests := []struct {
+		spec  *HTTPLogLatencySpec
+		name  string
+		field string
+	}{
+		{name: "unset"},
+		{name: "valid", spec: &HTTPLogLatencySpec{Slow: "100ms", VerySlow: "1s"}},
+		{name: "only slow", spec: &HTTPLogLatencySpec{Slow: "1s"}},
+		{name: "invalid slow", spec: &HTTPLogLatencySpec{Slow: "fast"}, field: "httpLogLatency.slow"},
+		{name: "negative verySlow", spec: &HTTPLogLatencySpec{VerySlow: "-1s"}, field: "httpLogLatency.verySlow"},
+		{name: "slow above verySlow", spec: &HTTPLogLatencySpec{Slow: "3s"}, field: "httpLogLatency"},
+	}
+
+	validator := NewValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.ValidateConfigWithOptions(&Config{HTTPLogLatency: tt.spec}, true)
+			if tt.field == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.Len(t, errs, 1) {
+				assert.Equal(t, tt.field, errs[0].Field)
+			}
+		})
+	}
+}
+
+func TestCheckHTTPLogMaxEntries(t *testing.T) {
+	validator := NewValidator()
+
+	assert.Empty(t, validator.CheckHTTPLogMaxEntries(&Config{}))
+	assert.Empty(t, validator.CheckHTTPLogMaxEntries(&Config{HTTPLogMaxEntries: HighHTTPLogMaxEntries}))
+
+	warnings := validator.CheckHTTPLogMaxEntries(&Config{HTTPLogMaxEntries: 1000000})
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "httpLogMaxEntries", warnings[0].Field)
+		assert.Contains(t, warnings[0].Message, "122.1 GB")
+	}
+}
+
+func TestFormatValidationErrors_WarningsOnly(t *testing.T) {
+	output := FormatValidationErrors([]ValidationError{{Message: "Context 'old' is not in kubeconfig", Severity: SeverityWarning}})
+	assert.Contains(t, output, "Configuration Validation Warnings")
+	assert.NotContains(t, output, "Configuration Validation Errors")
+}
+
+func TestHasErrors(t *testing.T) {
+	assert.False(t, HasErrors(nil))
+	assert.False(t, HasErrors([]ValidationError{{Message: "w", Severity: SeverityWarning}}))
+	assert.True(t, HasErrors([]ValidationError{{Message: "w", Severity: SeverityWarning}, {Message: "e"}}))
+
+	assert.Equal(t, "error", SeverityError.String())
+	assert.Equal(t, "warning", SeverityWarning.String())
+}
+
+func TestValidator_Warnings(t *testing.T) {
+	validator := NewValidator()
+	cfg := &Config{
+		HTTPLogMaxEntries: HighHTTPLogMaxEntries + 1,
+		Contexts: []Context{{
+			Name: "dev",
+			Namespaces: []Namespace{{
+				Name:     "default",
+				Forwards: []Forward{{Resource: "service/web", Port: 80, LocalPort: 8081, Alias: "my web"}},
+			}},
+		}},
+	}
+
+	warnings := validator.Warnings(cfg)
+	require.Len(t, warnings, 2)
+	for _, w := range warnings {
+		assert.Equal(t, SeverityWarning, w.Severity)
+	}
+	assert.False(t, HasErrors(warnings))
+	assert.Empty(t, validator.ValidateConfig(cfg))
+}
+
+func TestCheckAliases(t *testing.T) {
+	validator := NewValidator()
+	cfg := &Config{Contexts: []Context{{
+		Name: "dev",
+		Namespaces: []Namespace{{
+			Name: "default",
+			Forwards: []Forward{
+				{Resource: "service/api", Port: 80, LocalPort: 8080, Alias: "api"},
+				{Resource: "service/web", Port: 80, LocalPort: 8081, Alias: "my web/ui"},
+				{Resource: "service/db", Port: 5432, LocalPort: 5432},
+			},
+		}},
+	}}}
+
+	warnings := validator.CheckAliases(cfg)
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "alias", warnings[0].Field)
+		assert.Contains(t, warnings[0].Message, "my web/ui")
+	}
+	assert.Empty(t, validator.ValidateConfig(cfg), "an invalid alias is not an error while mDNS is off")
+
+	// With mDNS on, validateMDNS rejects the alias, so it is not warned twice
+	cfg.MDNS = &MDNSSpec{Enabled: true}
+	assert.Empty(t, validator.CheckAliases(cfg))
+}
+
+func TestValidateProfiles(t *testing.T) {
+	validator := NewValidator()
+	cfg := &Config{
+		Profiles: map[string][]string{
+			"frontend": {"web:3000", "tag:ui", "old:4000", "tag:nothing"},
+			"broken":   {"", "tag:"},
+		},
+		Contexts: []Context{{
+			Name: "dev",
+			Namespaces: []Namespace{{
+				Name: "default",
+				Forwards: []Forward{
+					{Resource: "service/web", Port: 80, LocalPort: 3000, Alias: "web"},
+					{Resource: "service/admin", Port: 80, LocalPort: 3001, Alias: "admin", Tags: []string{"ui"}},
+				},
+			}},
+		}},
+	}
+
+	errs := validator.ValidateConfig(cfg)
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		assert.Equal(t, "profiles", err.Field)
+		assert.Contains(t, err.Message, "Profile 'broken' has an empty member")
+	}
+
+	warnings := validator.CheckProfiles(cfg)
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0].Message, "member 'old:4000' matches no forward")
+	assert.Contains(t, warnings[1].Message, "member 'tag:nothing' matches no forward")
+	assert.Equal(t, SeverityWarning, warnings[0].Severity)
+}
+
+func TestCheckKubeconfigContexts(t *testing.T) {
+	validator := NewValidator()
+	cfg := &Config{Contexts: []Context{{Name: "dev"}, {Name: "prod-old"}}}
+
+	assert.Empty(t, validator.CheckKubeconfigContexts(cfg, []string{"prod-old", "dev", "staging"}))
+
+	warnings := validator.CheckKubeconfigContexts(cfg, []string{"dev", "prod"})
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "prod-old", warnings[0].Context["context"])
+		assert.Contains(t, warnings[0].Message, "Context 'prod-old' is not in kubeconfig")
+	}
+}
+
+func TestValidatePrivilegedPorts(t *testing.T) {
+	origLimit := portLimit
+	t.Cleanup(func() { portLimit = origLimit })
+
+	newConfig := func(mode string, localPort int) *Config {
+		return &Config{
+			PrivilegedPorts: mode,
+			Contexts: []Context{{
+				Name: "dev",
+				Namespaces: []Namespace{{
+					Name: "default",
+					Forwards: []Forward{{
+						Resource:      "pod/web",
+						Protocol:      "tcp",
+						Port:          80,
+						LocalPort:     localPort,
+						contextName:   "dev",
+						namespaceName: "default",
+					}},
+				}},
+			}},
+		}
+	}
+	validator := NewValidator()
+
+	portLimit = func() int { return 1024 }
+
+	// Warn mode leaves privileged ports out of the validation errors
+	assert.Empty(t, validator.ValidateConfig(newConfig("", 80)))
+	warnings := validator.CheckPrivilegedPorts(newConfig("", 80))
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "localPort", warnings[0].Field)
+		assert.Contains(t, warnings[0].Message, "Local port 80 is privileged")
+		assert.Equal(t, "dev/default/pod/web:80", warnings[0].Context["forward"])
+	}
+
+	// Error mode makes them fatal
+	errs := validator.ValidateConfig(newConfig(PrivilegedPortsError, 80))
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Message, "use a port of 1024 or above")
+	}
+
+	// Unprivileged and auto-assigned ports are never reported
+	assert.Empty(t, validator.CheckPrivilegedPorts(newConfig(PrivilegedPortsError, 1024)))
+	assert.Empty(t, validator.CheckPrivilegedPorts(newConfig(PrivilegedPortsError, 0)))
+
+	// Root, CAP_NET_BIND_SERVICE and Windows report no limit
+	portLimit = func() int { return 0 }
+	assert.Empty(t, validator.ValidateConfig(newConfig(PrivilegedPortsError, 80)))
+
+	errs = validator.ValidateConfigWithOptions(&Config{PrivilegedPorts: "ignore"}, true)
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "privilegedPorts", errs[0].Field)
+		assert.Contains(t, errs[0].Message, "must be one of: warn, error")
+	}
+}
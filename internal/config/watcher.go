@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"os"
 	"path/filepath"
 	"sync"
 
@@ -14,18 +15,29 @@ import (
 // It receives the new configuration and should return an error if the reload fails.
 type ReloadCallback func(*Config) error
 
-// Watcher watches a configuration file for changes and triggers hot-reload.
+// ReloadResultCallback is called after every reload attempt with nil on
+// success, or the reason the previous configuration was kept.
+type ReloadResultCallback func(error)
+
+// Watcher watches a configuration file, or a directory of config fragments,
+// for changes and triggers hot-reload. Files merged through include are
+// watched too.
 type Watcher struct {
 	callback   ReloadCallback
+	onResult   ReloadResultCallback // optional
 	watcher    *fsnotify.Watcher
 	done       chan struct{}
+	includes   map[string]bool // files merged through include, by absolute path
 	configPath string
 	wg         sync.WaitGroup
 	stopOnce   sync.Once
 	verbose    bool
+	isDir      bool // configPath is a directory of fragments
 }
 
-// NewWatcher creates a new file watcher for the given config file.
+// NewWatcher creates a new file watcher for the given config file. When
+// configPath is a directory, adding, removing or changing any fragment in it
+// triggers a reload of the merged configuration.
 func NewWatcher(configPath string, callback ReloadCallback, verbose bool) (*Watcher, error) {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
@@ -41,18 +53,46 @@ func NewWatcher(configPath string, callback ReloadCallback, verbose bool) (*Watc
 	// Watch the directory instead of the file to handle atomic writes
 	// (many editors delete and recreate files on save)
 	dir := filepath.Dir(absPath)
+	info, err := os.Stat(absPath)
+	isDir := err == nil && info.IsDir()
+	if isDir {
+		dir = absPath
+	}
 	if err := watcher.Add(dir); err != nil {
 		_ = watcher.Close() // Cleanup on error path; already returning error
 		return nil, fmt.Errorf("failed to watch directory %s: %w", dir, err)
 	}
 
-	return &Watcher{
+	w := &Watcher{
 		configPath: absPath,
 		callback:   callback,
 		watcher:    watcher,
 		done:       make(chan struct{}),
 		verbose:    verbose,
-	}, nil
+		isDir:      isDir,
+	}
+	if cfg, err := LoadConfig(absPath); err == nil {
+		w.trackIncludes(cfg.includes)
+	}
+	return w, nil
+}
+
+// trackIncludes watches the directories of the included files. The set is
+// replaced on every successful load, as includes can be added or removed.
+func (w *Watcher) trackIncludes(paths []string) {
+	w.includes = make(map[string]bool, len(paths))
+	for _, path := range paths {
+		w.includes[path] = true
+		if err := w.watcher.Add(filepath.Dir(path)); err != nil && w.verbose {
+			log.Printf("Failed to watch included file %s: %v", path, err)
+		}
+	}
+}
+
+// SetResultCallback registers fn to be told the outcome of each reload.
+// Must be called before Start.
+func (w *Watcher) SetResultCallback(fn ReloadResultCallback) {
+	w.onResult = fn
 }
 
 // Start begins watching the configuration file for changes.
@@ -95,12 +135,18 @@ func (w *Watcher) watch() {
 				continue
 			}
 
-			if eventPath != w.configPath {
+			if !w.isConfigEvent(eventPath) {
 				continue
 			}
 
-			// Handle write and create events (create happens on atomic writes)
-			if event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create {
+			// Handle write and create events (create happens on atomic writes).
+			// In a directory, removing or renaming a fragment changes the
+			// merged config too.
+			reload := event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create
+			if w.isDir {
+				reload = reload || event.Op&fsnotify.Remove == fsnotify.Remove || event.Op&fsnotify.Rename == fsnotify.Rename
+			}
+			if reload {
 				if w.verbose {
 					log.Printf("Configuration file changed, reloading...")
 				}
@@ -119,8 +165,29 @@ func (w *Watcher) watch() {
 	}
 }
 
-// handleReload loads and validates the new configuration, then calls the callback.
+// isConfigEvent reports whether eventPath is the watched config file, an
+// included file or, in directory mode, one of its fragments.
+func (w *Watcher) isConfigEvent(eventPath string) bool {
+	if w.includes[eventPath] {
+		return true
+	}
+	if w.isDir {
+		return filepath.Dir(eventPath) == w.configPath && IsFragment(eventPath)
+	}
+	return eventPath == w.configPath
+}
+
+// handleReload loads and validates the new configuration, then calls the
+// callback. The outcome is reported to the result callback, if set.
 func (w *Watcher) handleReload() {
+	err := w.reload()
+	if w.onResult != nil {
+		w.onResult(err)
+	}
+}
+
+// reload applies the configuration on disk, returning why it was rejected.
+func (w *Watcher) reload() error {
 	// Load new configuration
 	newCfg, err := LoadConfig(w.configPath)
 	if err != nil {
@@ -129,8 +196,9 @@ func (w *Watcher) handleReload() {
 			"error":       err.Error(),
 		})
 		logger.Info("Keeping previous configuration active", nil)
-		return
+		return err
 	}
+	w.trackIncludes(newCfg.includes)
 
 	// Validate new configuration
 	validator := NewValidator()
@@ -140,7 +208,10 @@ func (w *Watcher) handleReload() {
 			"validation_errors": len(errs),
 		})
 		logger.Info("Keeping previous configuration active", nil)
-		return
+		if len(errs) > 1 {
+			return fmt.Errorf("config validation failed: %s (and %d more)", errs[0].Message, len(errs)-1)
+		}
+		return fmt.Errorf("config validation failed: %s", errs[0].Message)
 	}
 
 	// Call reload callback
@@ -150,11 +221,12 @@ func (w *Watcher) handleReload() {
 			"error":       err.Error(),
 		})
 		logger.Info("Keeping previous configuration active", nil)
-		return
+		return err
 	}
 
 	logger.Info("Configuration reloaded successfully", map[string]interface{}{
 		"config_path":    w.configPath,
 		"forwards_count": len(newCfg.GetAllForwards()),
 	})
+	return nil
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"sync"
@@ -226,6 +227,51 @@ func TestWatcher_DetectsFileChange(t *testing.T) {
 	}
 }
 
+// TestWatcher_DetectsIncludedFileChange tests that editing a file merged
+// through include, in another directory, triggers a reload
+func TestWatcher_DetectsIncludedFileChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".kportal.yaml")
+	sharedPath := filepath.Join(tmpDir, "shared", "common.yaml")
+	require.NoError(t, os.Mkdir(filepath.Dir(sharedPath), 0700))
+	require.NoError(t, os.WriteFile(configPath, []byte("include: [shared/common.yaml]\ncontexts: []\n"), 0600))
+	require.NoError(t, os.WriteFile(sharedPath, []byte("contexts: []\n"), 0600))
+
+	reloaded := make(chan *Config, 1)
+	watcher, err := NewWatcher(configPath, func(cfg *Config) error {
+		select {
+		case reloaded <- cfg:
+		default:
+		}
+		return nil
+	}, false)
+	require.NoError(t, err)
+	defer watcher.Stop()
+	watcher.Start()
+
+	// Give watcher time to start
+	time.Sleep(100 * time.Millisecond)
+
+	updated := `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            protocol: tcp
+            port: 80
+            localPort: 8080
+`
+	require.NoError(t, os.WriteFile(sharedPath, []byte(updated), 0600))
+
+	select {
+	case cfg := <-reloaded:
+		assert.Len(t, cfg.GetAllForwards(), 1)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Callback was not called after included file change")
+	}
+}
+
 // TestWatcher_IgnoresInvalidConfig tests that invalid configs are rejected
 func TestWatcher_IgnoresInvalidConfig(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -392,6 +438,76 @@ func TestWatcher_IgnoresOtherFiles(t *testing.T) {
 	mu.Unlock()
 }
 
+// TestWatcher_Directory tests that fragments added, changed or removed in a
+// config directory reload the merged config, and that a broken fragment
+// keeps the last good config active
+func TestWatcher_Directory(t *testing.T) {
+	tmpDir := t.TempDir()
+	fragment := func(port string) string {
+		return `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/app` + port + `
+            port: ` + port + `
+            localPort: ` + port + `
+`
+	}
+	apiPath := filepath.Join(tmpDir, "10-api.yaml")
+	dbPath := filepath.Join(tmpDir, "20-db.yaml")
+	require.NoError(t, os.WriteFile(apiPath, []byte(fragment("8080")), 0600))
+
+	var mu sync.Mutex
+	var counts []int
+	callback := func(cfg *Config) error {
+		mu.Lock()
+		defer mu.Unlock()
+		counts = append(counts, len(cfg.GetAllForwards()))
+		return nil
+	}
+	lastCount := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(counts) == 0 {
+			return 0
+		}
+		return counts[len(counts)-1]
+	}
+	calls := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(counts)
+	}
+
+	watcher, err := NewWatcher(tmpDir, callback, false)
+	require.NoError(t, err)
+	defer watcher.Stop()
+	assert.True(t, watcher.isDir)
+
+	watcher.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	// Adding a fragment reloads the merged config
+	require.NoError(t, os.WriteFile(dbPath, []byte(fragment("5432")), 0600))
+	require.Eventually(t, func() bool { return lastCount() == 2 }, 2*time.Second, 20*time.Millisecond)
+
+	// A broken fragment is rejected; nothing reaches the callback
+	before := calls()
+	require.NoError(t, os.WriteFile(dbPath, []byte("contexts: [this is invalid\n"), 0600))
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, before, calls(), "broken fragment should keep the last good config")
+
+	// Non-fragment files are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "notes.txt"), []byte("x"), 0600))
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, before, calls())
+
+	// Removing the broken fragment reloads with what is left
+	require.NoError(t, os.Remove(dbPath))
+	require.Eventually(t, func() bool { return calls() > before && lastCount() == 1 }, 2*time.Second, 20*time.Millisecond)
+}
+
 // TestWatcher_HandleReload_LoadError tests handleReload with load error
 func TestWatcher_HandleReload_LoadError(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -432,6 +548,66 @@ func TestWatcher_HandleReload_LoadError(t *testing.T) {
 	assert.False(t, callbackCalled)
 }
 
+// TestWatcher_ResultCallback tests that every reload outcome is reported
+func TestWatcher_ResultCallback(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".kportal.yaml")
+	valid := `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/app
+            port: 8080
+            localPort: 8080
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(valid), 0600))
+
+	var applyErr error
+	watcher, err := NewWatcher(configPath, func(cfg *Config) error { return applyErr }, false)
+	require.NoError(t, err)
+	defer watcher.Stop()
+
+	var results []error
+	watcher.SetResultCallback(func(err error) { results = append(results, err) })
+
+	watcher.handleReload()
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0])
+
+	// Validation failures are summarised on a single line
+	invalid := `contexts:
+  - name: dev
+    namespaces:
+      - name: default
+        forwards:
+          - resource: pod/app
+            port: 0
+            localPort: 8080
+          - resource: pod/other
+            port: 0
+            localPort: 8081
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(invalid), 0600))
+	watcher.handleReload()
+	require.Len(t, results, 2)
+	require.Error(t, results[1])
+	assert.Contains(t, results[1].Error(), "config validation failed: ")
+	assert.Contains(t, results[1].Error(), "(and 1 more)")
+	assert.NotContains(t, results[1].Error(), "\n")
+
+	require.NoError(t, os.WriteFile(configPath, []byte(valid), 0600))
+	applyErr = errors.New("port 8080 is in use")
+	watcher.handleReload()
+	require.Len(t, results, 3)
+	assert.EqualError(t, results[2], "port 8080 is in use")
+
+	require.NoError(t, os.Remove(configPath))
+	watcher.handleReload()
+	require.Len(t, results, 4)
+	assert.ErrorIs(t, results[3], ErrConfigNotFound)
+}
+
 // TestWatcher_DoubleStop tests that double stop doesn't panic
 func TestWatcher_DoubleStop(t *testing.T) {
 	tmpDir := t.TempDir()
@@ -0,0 +1,270 @@
+// Package control implements the headless-mode control channel: a unix
+// domain socket accepting one text command per connection, used by
+// `kportal ctl` to drive a running daemon.
+//
+// A request is a single line, "<command> [forward-id]". The reply starts with
+// "OK" or "ERROR: <message>" on its own line, followed by any output:
+//
+//	$ echo "disable dev/default/service/api:8080" | nc -U /tmp/kportal.sock
+//	OK
+//
+// The status command returns the same forwards as list, as a JSON array, for
+// `kportal status`.
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/logger"
+)
+
+const (
+	// requestTimeout bounds how long a client may take to send its command.
+	requestTimeout = 5 * time.Second
+	// responseTimeout bounds a whole exchange; reloads restart workers.
+	responseTimeout = 30 * time.Second
+	// socketMode keeps the socket private to the user running kportal.
+	socketMode = 0o600
+)
+
+// Commands accepted on the control socket.
+const (
+	CommandList    = "list"
+	CommandEnable  = "enable"
+	CommandDisable = "disable"
+	CommandReload  = "reload"
+	CommandStatus  = "status"
+)
+
+// ForwardInfo is one row of the list command's output, and one element of
+// the status command's JSON array.
+type ForwardInfo struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	LocalPort int    `json:"localPort"`
+}
+
+// Handler executes control commands against the running forwards.
+type Handler interface {
+	EnableForward(id string) error
+	DisableForward(id string) error
+	Reload() error
+	ListForwards() []ForwardInfo
+}
+
+// Server accepts control commands on a unix domain socket.
+type Server struct {
+	handler  Handler
+	listener net.Listener
+	path     string
+	wg       sync.WaitGroup
+	mu       sync.Mutex // serializes commands
+}
+
+// NewServer creates a Server for the socket at path.
+func NewServer(path string, handler Handler) *Server {
+	return &Server{path: path, handler: handler}
+}
+
+// Start creates the socket with 0600 permissions and serves in the
+// background. A stale socket left by a crashed process is replaced; one
+// owned by a running kportal is an error.
+func (s *Server) Start() error {
+	if err := removeStaleSocket(s.path); err != nil {
+		return err
+	}
+
+	ln, err := listen(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.path, err)
+	}
+	s.listener = ln
+
+	s.wg.Add(1)
+	go s.serve()
+	return nil
+}
+
+// Stop closes the socket, removes its file, and waits for in-flight
+// commands to finish.
+func (s *Server) Stop() {
+	if s.listener == nil {
+		return
+	}
+	_ = s.listener.Close() // Also unlinks the socket file
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logger.Error("Control socket stopped accepting connections", map[string]any{
+					"socket": s.path,
+					"error":  err.Error(),
+				})
+			}
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+// handle reads one command from conn and writes the reply.
+func (s *Server) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	_ = conn.SetReadDeadline(time.Now().Add(requestTimeout))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return
+	}
+
+	_ = conn.SetWriteDeadline(time.Now().Add(responseTimeout))
+	output, err := s.execute(strings.TrimSpace(line))
+	if err != nil {
+		_, _ = fmt.Fprintf(conn, "ERROR: %v\n", err)
+		return
+	}
+	_, _ = io.WriteString(conn, "OK\n"+output)
+}
+
+// execute runs a single command line and returns its output.
+func (s *Server) execute(line string) (string, error) {
+	command, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	logger.Info("Control command received", map[string]any{
+		"command": command,
+		"forward": arg,
+	})
+
+	switch command {
+	case CommandList:
+		return FormatList(s.handler.ListForwards()), nil
+	case CommandStatus:
+		data, err := json.Marshal(s.handler.ListForwards())
+		if err != nil {
+			return "", fmt.Errorf("failed to encode forwards: %w", err)
+		}
+		return string(data) + "\n", nil
+	case CommandEnable, CommandDisable:
+		if arg == "" {
+			return "", fmt.Errorf("%s requires a forward ID", command)
+		}
+		if command == CommandEnable {
+			return "", s.handler.EnableForward(arg)
+		}
+		return "", s.handler.DisableForward(arg)
+	case CommandReload:
+		return "", s.handler.Reload()
+	case "":
+		return "", fmt.Errorf("empty command")
+	default:
+		return "", fmt.Errorf("unknown command %q (use list, status, enable, disable or reload)", command)
+	}
+}
+
+// FormatList renders forwards as aligned columns, as the list command does.
+func FormatList(forwards []ForwardInfo) string {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tLOCAL\tSTATUS")
+	for _, f := range forwards {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\n", f.ID, f.LocalPort, f.Status)
+	}
+	_ = tw.Flush()
+	return b.String()
+}
+
+// removeStaleSocket deletes a socket file nobody is listening on, so a
+// crashed kportal does not block the next start.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %w", path, err)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("%s is in use by another kportal", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
+
+// Send delivers a command line to the socket at path and returns the
+// command's output. A command the daemon rejects is returned as an error.
+func Send(path, command string) (string, error) {
+	conn, err := net.DialTimeout("unix", path, requestTimeout)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s (is kportal running headless with controlSocket set?): %w", path, err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	_ = conn.SetDeadline(time.Now().Add(responseTimeout))
+	if _, err := io.WriteString(conn, command+"\n"); err != nil {
+		return "", fmt.Errorf("failed to send command: %w", err)
+	}
+
+	reply, err := io.ReadAll(conn)
+	if err != nil {
+		return "", fmt.Errorf("failed to read reply: %w", err)
+	}
+
+	status, output, _ := strings.Cut(string(reply), "\n")
+	switch {
+	case status == "OK":
+		return output, nil
+	case strings.HasPrefix(status, "ERROR: "):
+		// Multi-line messages, such as validation errors, continue below
+		msg := strings.TrimPrefix(status, "ERROR: ")
+		if output = strings.TrimRight(output, "\n"); output != "" {
+			msg += "\n" + output
+		}
+		return "", errors.New(msg)
+	default:
+		return "", fmt.Errorf("unexpected reply from %s: %q", path, status)
+	}
+}
+
+// Status asks the daemon at path for every forward's state, decoded from the
+// status command's JSON.
+func Status(path string) ([]ForwardInfo, error) {
+	output, err := Send(path, CommandStatus)
+	if err != nil {
+		return nil, err
+	}
+	var forwards []ForwardInfo
+	if err := json.Unmarshal([]byte(output), &forwards); err != nil {
+		return nil, fmt.Errorf("failed to decode status from %s: %w", path, err)
+	}
+	return forwards, nil
+}
@@ -0,0 +1,192 @@
+package control
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHandler struct {
+	enabled  map[string]bool
+	failNext error
+	reloads  int
+	mu       sync.Mutex
+}
+
+func newFakeHandler() *fakeHandler {
+	return &fakeHandler{enabled: map[string]bool{"api:8080": true, "db:5432": false}}
+}
+
+func (h *fakeHandler) toggle(id string, on bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.enabled[id]; !ok {
+		return errors.New("forward not found in configuration: " + id)
+	}
+	h.enabled[id] = on
+	return nil
+}
+
+func (h *fakeHandler) EnableForward(id string) error  { return h.toggle(id, true) }
+func (h *fakeHandler) DisableForward(id string) error { return h.toggle(id, false) }
+
+func (h *fakeHandler) Reload() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.reloads++
+	err := h.failNext
+	h.failNext = nil
+	return err
+}
+
+func (h *fakeHandler) ListForwards() []ForwardInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	status := func(id string) string {
+		if h.enabled[id] {
+			return "Active"
+		}
+		return "Disabled"
+	}
+	return []ForwardInfo{
+		{ID: "api:8080", LocalPort: 8080, Status: status("api:8080")},
+		{ID: "db:5432", LocalPort: 5432, Status: status("db:5432")},
+	}
+}
+
+func startServer(t *testing.T, h Handler) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ctl.sock")
+	srv := NewServer(path, h)
+	require.NoError(t, srv.Start())
+	t.Cleanup(srv.Stop)
+	return path
+}
+
+func TestServer_Commands(t *testing.T) {
+	h := newFakeHandler()
+	path := startServer(t, h)
+
+	out, err := Send(path, "disable api:8080")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	out, err = Send(path, "enable db:5432")
+	require.NoError(t, err)
+	assert.Empty(t, out)
+
+	out, err = Send(path, "list")
+	require.NoError(t, err)
+	assert.Regexp(t, `ID\s+LOCAL\s+STATUS`, out)
+	assert.Regexp(t, `api:8080\s+8080\s+Disabled`, out)
+	assert.Regexp(t, `db:5432\s+5432\s+Active`, out)
+
+	_, err = Send(path, "reload")
+	require.NoError(t, err)
+	assert.Equal(t, 1, h.reloads)
+}
+
+func TestStatus(t *testing.T) {
+	path := startServer(t, newFakeHandler())
+
+	out, err := Send(path, "status")
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":"api:8080","status":"Active","localPort":8080},{"id":"db:5432","status":"Disabled","localPort":5432}]`, out)
+
+	forwards, err := Status(path)
+	require.NoError(t, err)
+	assert.Equal(t, []ForwardInfo{
+		{ID: "api:8080", LocalPort: 8080, Status: "Active"},
+		{ID: "db:5432", LocalPort: 5432, Status: "Disabled"},
+	}, forwards)
+}
+
+func TestServer_Errors(t *testing.T) {
+	h := newFakeHandler()
+	path := startServer(t, h)
+
+	tests := []struct {
+		command string
+		errMsg  string
+	}{
+		{"enable missing:1", "forward not found in configuration: missing:1"},
+		{"disable", "disable requires a forward ID"},
+		{"restart api:8080", `unknown command "restart"`},
+		{"", "empty command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			_, err := Send(path, tt.command)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	h.mu.Lock()
+	h.failNext = errors.New("config validation failed:\n  - contexts: must have at least one context")
+	h.mu.Unlock()
+	_, err := Send(path, "reload")
+	require.Error(t, err)
+	assert.Equal(t, "config validation failed:\n  - contexts: must have at least one context", err.Error())
+}
+
+func TestServer_SocketPermissions(t *testing.T) {
+	path := startServer(t, newFakeHandler())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestServer_StopRemovesSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctl.sock")
+	srv := NewServer(path, newFakeHandler())
+	require.NoError(t, srv.Start())
+	srv.Stop()
+
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "socket file should be removed on stop")
+
+	_, err = Send(path, "list")
+	assert.Error(t, err)
+}
+
+func TestServer_StaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctl.sock")
+
+	// Leave a socket file behind with nobody listening on it
+	ln, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, ln.Close())
+
+	srv := NewServer(path, newFakeHandler())
+	require.NoError(t, srv.Start(), "stale socket should be replaced")
+	t.Cleanup(srv.Stop)
+
+	// A second server must not steal the live socket
+	err = NewServer(path, newFakeHandler()).Start()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "in use by another kportal")
+}
+
+func TestServer_RefusesRegularFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ctl.sock")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+
+	err := NewServer(path, newFakeHandler()).Start()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a socket")
+}
+
+func TestSend_NoServer(t *testing.T) {
+	_, err := Send(filepath.Join(t.TempDir(), "missing.sock"), "list")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is kportal running headless")
+}
@@ -0,0 +1,23 @@
+//go:build !unix
+
+package control
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// listen creates the socket at path with socketMode permissions. Without a
+// umask to set, the permissions are restricted once the socket exists.
+func listen(path string) (net.Listener, error) {
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, socketMode); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to restrict permissions: %w", err)
+	}
+	return ln, nil
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package control
+
+import (
+	"net"
+	"syscall"
+)
+
+// listen creates the socket at path with socketMode permissions. The umask
+// is narrowed around the bind, so the socket never exists with looser
+// permissions for another user to connect through.
+func listen(path string) (net.Listener, error) {
+	oldMask := syscall.Umask(0o777 &^ socketMode)
+	defer syscall.Umask(oldMask)
+	return net.Listen("unix", path)
+}
@@ -1,5 +1,6 @@
 // Package converter provides configuration migration from other port-forwarding
-// tools to kportal's YAML format. Currently supports kftray JSON format.
+// tools to kportal's YAML format. Supports kftray JSON and files of
+// `kubectl port-forward` command lines.
 //
 // Basic usage:
 //
@@ -7,13 +8,17 @@
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
+//
+//	err = converter.ConvertKubectlCommands("forwards.sh", ".kportal.yaml")
 package converter
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/lukaszraczylo/kportal/internal/config"
 	"gopkg.in/yaml.v3"
@@ -27,12 +32,16 @@ type KFTrayConfig struct {
 	WorkloadType string `json:"workload_type"`
 	Protocol     string `json:"protocol"`
 	Alias        string `json:"alias"`
+	Target       string `json:"target"` // label selector of a pod workload
 	LocalPort    int    `json:"local_port"`
 	RemotePort   int    `json:"remote_port"`
 }
 
-// ConvertKFTrayToKPortal converts kftray JSON configuration to kportal YAML format
-func ConvertKFTrayToKPortal(inputFile, outputFile string) error {
+// ConvertKFTrayToKPortal converts kftray JSON configuration to kportal YAML format.
+// With fixPorts, forwards whose local port collides with an earlier one are
+// moved to the next free port; otherwise they are kept, and the output fails
+// validation until they are changed. GetConversionSummary describes either.
+func ConvertKFTrayToKPortal(inputFile, outputFile string, fixPorts bool) error {
 	// Read kftray JSON config
 	// #nosec G304 -- inputFile is from command line argument for explicit conversion
 	data, err := os.ReadFile(inputFile)
@@ -47,15 +56,19 @@ func ConvertKFTrayToKPortal(inputFile, outputFile string) error {
 
 	// Convert to kportal format
 	kportalConfig := convertToKPortal(kftrayConfigs)
+	checkLocalPorts(&kportalConfig, fixPorts)
+
+	header := "# kportal configuration converted from kftray format\n# Generated by kportal --convert\n\n"
+	return writeConfig(kportalConfig, header, outputFile)
+}
 
-	// Write kportal YAML config
-	yamlData, err := yaml.Marshal(kportalConfig)
+// writeConfig marshals cfg to YAML, prefixes header and writes it to outputFile.
+func writeConfig(cfg config.Config, header, outputFile string) error {
+	yamlData, err := yaml.Marshal(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to generate YAML: %w", err)
 	}
 
-	// Add header comment
-	header := "# kportal configuration converted from kftray format\n# Generated by kportal --convert\n\n"
 	yamlData = append([]byte(header), yamlData...)
 
 	if err := os.WriteFile(outputFile, yamlData, 0600); err != nil {
@@ -65,52 +78,147 @@ func ConvertKFTrayToKPortal(inputFile, outputFile string) error {
 	return nil
 }
 
-// GetConversionSummary returns statistics about the kftray configuration
-func GetConversionSummary(inputFile string) (map[string]map[string]int, int, error) {
+// GetConversionSummary returns statistics about the kftray configuration:
+// converted forwards per namespace per context, the number of converted
+// forwards, and warnings for entries that could not be converted and for
+// local port collisions, remapped or not as fixPorts says.
+func GetConversionSummary(inputFile string, fixPorts bool) (map[string]map[string]int, int, []string, error) {
 	// #nosec G304 -- inputFile is from command line argument for explicit conversion
 	data, err := os.ReadFile(inputFile)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to read input file: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to read input file: %w", err)
 	}
 
 	var kftrayConfigs []KFTrayConfig
 	if err := json.Unmarshal(data, &kftrayConfigs); err != nil {
-		return nil, 0, fmt.Errorf("failed to parse JSON: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
 	contextMap := make(map[string]map[string]int)
-	for _, cfg := range kftrayConfigs {
+	converted := 0
+	var warnings []string
+	for i, cfg := range kftrayConfigs {
+		if _, err := convertKFTrayEntry(cfg); err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipped kftray entry %d (%s in %s/%s): %v", i+1, cfg.Service, cfg.Context, cfg.Namespace, err))
+			continue
+		}
 		if _, ok := contextMap[cfg.Context]; !ok {
 			contextMap[cfg.Context] = make(map[string]int)
 		}
 		contextMap[cfg.Context][cfg.Namespace]++
+		converted++
+	}
+
+	kportalConfig := convertToKPortal(kftrayConfigs)
+	warnings = append(warnings, checkLocalPorts(&kportalConfig, fixPorts)...)
+
+	return contextMap, converted, warnings, nil
+}
+
+// checkLocalPorts finds forwards whose local port is already used by an
+// earlier forward in cfg. With fix, each is moved to the next port above
+// it that no forward uses. Returns a warning per collision. Local port 0
+// is picked at start time and never collides.
+func checkLocalPorts(cfg *config.Config, fix bool) []string {
+	used := make(map[int]bool)
+	for _, fwd := range cfg.GetAllForwards() {
+		used[fwd.LocalPort] = true
 	}
 
-	return contextMap, len(kftrayConfigs), nil
+	var warnings []string
+	owners := make(map[int]string)
+	for i := range cfg.Contexts {
+		ctx := &cfg.Contexts[i]
+		for j := range ctx.Namespaces {
+			ns := &ctx.Namespaces[j]
+			for k := range ns.Forwards {
+				fwd := &ns.Forwards[k]
+				name := fmt.Sprintf("%s in %s/%s", fwd.Resource, ctx.Name, ns.Name)
+				owner, taken := owners[fwd.LocalPort]
+				if fwd.LocalPort == 0 || !taken {
+					owners[fwd.LocalPort] = name
+					continue
+				}
+
+				if !fix {
+					warnings = append(warnings, fmt.Sprintf("%s: local port %d is also used by %s; change it before using the config, or convert with -convert-fix-ports", name, fwd.LocalPort, owner))
+					continue
+				}
+				port := fwd.LocalPort + 1
+				for port <= config.MaxPort && used[port] {
+					port++
+				}
+				if port > config.MaxPort {
+					warnings = append(warnings, fmt.Sprintf("%s: local port %d is also used by %s and no free port is left above it", name, fwd.LocalPort, owner))
+					continue
+				}
+				warnings = append(warnings, fmt.Sprintf("%s: local port %d is also used by %s, remapped to %d", name, fwd.LocalPort, owner, port))
+				fwd.LocalPort = port
+				used[port] = true
+				owners[port] = name
+			}
+		}
+	}
+	return warnings
 }
 
+// convertKFTrayEntry maps a kftray entry to a forward. kftray's service,
+// pod, deployment and statefulset workloads have kportal equivalents; a pod
+// with a target is found by that label selector. An empty workload_type is
+// a service, as in kftray. Proxy workloads are not supported.
+func convertKFTrayEntry(cfg KFTrayConfig) (forwardEntry, error) {
+	forward := forwardEntry{
+		Protocol:  cfg.Protocol,
+		Port:      cfg.RemotePort,
+		LocalPort: cfg.LocalPort,
+		Alias:     cfg.Alias,
+	}
+
+	workloadType := strings.ToLower(strings.TrimSpace(cfg.WorkloadType))
+	if workloadType == "" {
+		workloadType = "service"
+	}
+	switch workloadType {
+	case "service", "deployment", "statefulset":
+		if cfg.Service == "" {
+			return forwardEntry{}, fmt.Errorf("no %s name", workloadType)
+		}
+		forward.Resource = workloadType + "/" + cfg.Service
+	case "pod":
+		switch {
+		case cfg.Target != "":
+			forward.Resource = "pod"
+			forward.Selector = cfg.Target
+		case cfg.Service != "":
+			forward.Resource = "pod/" + cfg.Service
+		default:
+			return forwardEntry{}, errors.New("pod has neither a name nor a target selector")
+		}
+	case "proxy":
+		return forwardEntry{}, errors.New("proxy workloads have no kportal equivalent")
+	default:
+		return forwardEntry{}, fmt.Errorf("unsupported workload_type %q", cfg.WorkloadType)
+	}
+	return forward, nil
+}
+
+// convertToKPortal converts the entries that can be mapped, see
+// convertKFTrayEntry. GetConversionSummary reports the others.
 func convertToKPortal(kftrayConfigs []KFTrayConfig) config.Config {
 	// Group by context and namespace
 	contextMap := make(map[string]map[string][]forwardEntry)
 
 	for _, cfg := range kftrayConfigs {
+		forward, err := convertKFTrayEntry(cfg)
+		if err != nil {
+			continue
+		}
+
 		// Initialize context if not exists
 		if _, ok := contextMap[cfg.Context]; !ok {
 			contextMap[cfg.Context] = make(map[string][]forwardEntry)
 		}
 
-		// Build resource string based on workload type
-		resource := fmt.Sprintf("%s/%s", cfg.WorkloadType, cfg.Service)
-
-		// Create forward entry
-		forward := forwardEntry{
-			Resource:  resource,
-			Protocol:  cfg.Protocol,
-			Port:      cfg.RemotePort,
-			LocalPort: cfg.LocalPort,
-			Alias:     cfg.Alias,
-		}
-
 		// Add to namespace
 		contextMap[cfg.Context][cfg.Namespace] = append(
 			contextMap[cfg.Context][cfg.Namespace],
@@ -118,7 +226,12 @@ func convertToKPortal(kftrayConfigs []KFTrayConfig) config.Config {
 		)
 	}
 
-	// Convert map to structured config
+	return buildConfig(contextMap)
+}
+
+// buildConfig turns forwards grouped by context and namespace into a config
+// with contexts, namespaces and forwards in a stable order.
+func buildConfig(contextMap map[string]map[string][]forwardEntry) config.Config {
 	var contexts []contextEntry
 
 	// Sort contexts for consistent output
@@ -143,7 +256,7 @@ func convertToKPortal(kftrayConfigs []KFTrayConfig) config.Config {
 			forwards := namespaceMap[namespaceName]
 
 			// Sort forwards by local port for consistent output
-			sort.Slice(forwards, func(i, j int) bool {
+			sort.SliceStable(forwards, func(i, j int) bool {
 				return forwards[i].LocalPort < forwards[j].LocalPort
 			})
 
@@ -177,6 +290,7 @@ type namespaceEntry struct {
 
 type forwardEntry struct {
 	Resource  string `yaml:"resource"`
+	Selector  string `yaml:"selector,omitempty"`
 	Protocol  string `yaml:"protocol"`
 	Alias     string `yaml:"alias,omitempty"`
 	Port      int    `yaml:"port"`
@@ -193,6 +307,7 @@ func convertToConfigContexts(contexts []contextEntry) []config.Context {
 			for _, fwd := range ns.Forwards {
 				forwards = append(forwards, config.Forward{
 					Resource:  fwd.Resource,
+					Selector:  fwd.Selector,
 					Protocol:  fwd.Protocol,
 					Port:      fwd.Port,
 					LocalPort: fwd.LocalPort,
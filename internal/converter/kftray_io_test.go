@@ -42,7 +42,7 @@ func TestConvertKFTrayToKPortal_HappyPath(t *testing.T) {
 	})
 	output := filepath.Join(dir, "out.yaml")
 
-	err := ConvertKFTrayToKPortal(input, output)
+	err := ConvertKFTrayToKPortal(input, output, false)
 	require.NoError(t, err)
 
 	raw, err := os.ReadFile(output)
@@ -72,7 +72,7 @@ func TestConvertKFTrayToKPortal_HappyPath(t *testing.T) {
 
 func TestConvertKFTrayToKPortal_MissingInputFile(t *testing.T) {
 	dir := t.TempDir()
-	err := ConvertKFTrayToKPortal(filepath.Join(dir, "nonexistent.json"), filepath.Join(dir, "out.yaml"))
+	err := ConvertKFTrayToKPortal(filepath.Join(dir, "nonexistent.json"), filepath.Join(dir, "out.yaml"), false)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to read input file")
 }
@@ -82,7 +82,7 @@ func TestConvertKFTrayToKPortal_MalformedJSON(t *testing.T) {
 	input := filepath.Join(dir, "bad.json")
 	require.NoError(t, os.WriteFile(input, []byte("{not json}"), 0600))
 
-	err := ConvertKFTrayToKPortal(input, filepath.Join(dir, "out.yaml"))
+	err := ConvertKFTrayToKPortal(input, filepath.Join(dir, "out.yaml"), false)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse JSON")
 }
@@ -92,7 +92,7 @@ func TestConvertKFTrayToKPortal_EmptyArray(t *testing.T) {
 	input := writeJSON(t, dir, "empty.json", []KFTrayConfig{})
 	output := filepath.Join(dir, "out.yaml")
 
-	err := ConvertKFTrayToKPortal(input, output)
+	err := ConvertKFTrayToKPortal(input, output, false)
 	require.NoError(t, err)
 
 	raw, err := os.ReadFile(output)
@@ -112,7 +112,7 @@ func TestConvertKFTrayToKPortal_UnwritableOutputDir(t *testing.T) {
 	// Use a path that cannot be created (sub-dir of a non-existing dir)
 	output := filepath.Join(dir, "no-such-subdir", "out.yaml")
 
-	err := ConvertKFTrayToKPortal(input, output)
+	err := ConvertKFTrayToKPortal(input, output, false)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to write output file")
 }
@@ -127,7 +127,7 @@ func TestConvertKFTrayToKPortal_MultipleEntries_YAMLRoundtrip(t *testing.T) {
 	input := writeJSON(t, dir, "in.json", entries)
 	output := filepath.Join(dir, "out.yaml")
 
-	require.NoError(t, ConvertKFTrayToKPortal(input, output))
+	require.NoError(t, ConvertKFTrayToKPortal(input, output, false))
 
 	raw, err := os.ReadFile(output)
 	require.NoError(t, err)
@@ -158,7 +158,7 @@ func TestConvertKFTrayToKPortal_OutputFilePermissions(t *testing.T) {
 	})
 	output := filepath.Join(dir, "out.yaml")
 
-	require.NoError(t, ConvertKFTrayToKPortal(input, output))
+	require.NoError(t, ConvertKFTrayToKPortal(input, output, false))
 
 	info, err := os.Stat(output)
 	require.NoError(t, err)
@@ -177,7 +177,7 @@ func TestGetConversionSummary_HappyPath(t *testing.T) {
 	}
 	input := writeJSON(t, dir, "in.json", entries)
 
-	contextMap, total, err := GetConversionSummary(input)
+	contextMap, total, _, err := GetConversionSummary(input, false)
 	require.NoError(t, err)
 
 	assert.Equal(t, 3, total)
@@ -193,7 +193,7 @@ func TestGetConversionSummary_HappyPath(t *testing.T) {
 
 func TestGetConversionSummary_MissingFile(t *testing.T) {
 	dir := t.TempDir()
-	_, _, err := GetConversionSummary(filepath.Join(dir, "ghost.json"))
+	_, _, _, err := GetConversionSummary(filepath.Join(dir, "ghost.json"), false)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to read input file")
 }
@@ -203,7 +203,7 @@ func TestGetConversionSummary_MalformedJSON(t *testing.T) {
 	path := filepath.Join(dir, "bad.json")
 	require.NoError(t, os.WriteFile(path, []byte("not-json"), 0600))
 
-	_, _, err := GetConversionSummary(path)
+	_, _, _, err := GetConversionSummary(path, false)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to parse JSON")
 }
@@ -212,7 +212,7 @@ func TestGetConversionSummary_EmptyArray(t *testing.T) {
 	dir := t.TempDir()
 	input := writeJSON(t, dir, "empty.json", []KFTrayConfig{})
 
-	contextMap, total, err := GetConversionSummary(input)
+	contextMap, total, _, err := GetConversionSummary(input, false)
 	require.NoError(t, err)
 	assert.Equal(t, 0, total)
 	assert.Empty(t, contextMap)
@@ -227,7 +227,7 @@ func TestGetConversionSummary_SameNamespaceDifferentContexts(t *testing.T) {
 	}
 	input := writeJSON(t, dir, "in.json", entries)
 
-	contextMap, total, err := GetConversionSummary(input)
+	contextMap, total, _, err := GetConversionSummary(input, false)
 	require.NoError(t, err)
 	assert.Equal(t, 3, total)
 	// ctx-a/default has 2 services
@@ -236,6 +236,77 @@ func TestGetConversionSummary_SameNamespaceDifferentContexts(t *testing.T) {
 	assert.Equal(t, 1, contextMap["ctx-b"]["default"])
 }
 
+func TestGetConversionSummary_ReportsSkipped(t *testing.T) {
+	dir := t.TempDir()
+	entries := []KFTrayConfig{
+		{Service: "api", Namespace: "default", Context: "prod", WorkloadType: "deployment", LocalPort: 8080, RemotePort: 8080},
+		{Service: "redis", Namespace: "cache", Context: "prod", WorkloadType: "proxy", LocalPort: 6379, RemotePort: 6379},
+	}
+	input := writeJSON(t, dir, "in.json", entries)
+
+	contextMap, total, skipped, err := GetConversionSummary(input, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, map[string]map[string]int{"prod": {"default": 1}}, contextMap)
+	assert.Equal(t, []string{"skipped kftray entry 2 (redis in prod/cache): proxy workloads have no kportal equivalent"}, skipped)
+}
+
+// collidingEntries use local port 8080 three times, and 8081 once
+var collidingEntries = []KFTrayConfig{
+	{Service: "api", Namespace: "default", Context: "prod", WorkloadType: "service", Protocol: "tcp", LocalPort: 8080, RemotePort: 80},
+	{Service: "web", Namespace: "default", Context: "prod", WorkloadType: "service", Protocol: "tcp", LocalPort: 8080, RemotePort: 80},
+	{Service: "admin", Namespace: "default", Context: "prod", WorkloadType: "service", Protocol: "tcp", LocalPort: 8081, RemotePort: 80},
+	{Service: "api", Namespace: "default", Context: "staging", WorkloadType: "service", Protocol: "tcp", LocalPort: 8080, RemotePort: 80},
+}
+
+func TestConvertKFTrayToKPortal_FixPorts(t *testing.T) {
+	dir := t.TempDir()
+	input := writeJSON(t, dir, "in.json", collidingEntries)
+	output := filepath.Join(dir, "out.yaml")
+
+	require.NoError(t, ConvertKFTrayToKPortal(input, output, true))
+
+	cfg, err := config.LoadConfig(output)
+	require.NoError(t, err)
+	assert.Empty(t, config.NewValidator().ValidateConfig(cfg))
+
+	ports := make(map[string]int)
+	for _, fwd := range cfg.GetAllForwards() {
+		ports[fwd.GetContext()+"/"+fwd.Resource] = fwd.LocalPort
+	}
+	assert.Equal(t, map[string]int{
+		"prod/service/api":    8080,
+		"prod/service/admin":  8081,
+		"prod/service/web":    8082,
+		"staging/service/api": 8083,
+	}, ports)
+
+	_, _, warnings, err := GetConversionSummary(input, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"service/web in prod/default: local port 8080 is also used by service/api in prod/default, remapped to 8082",
+		"service/api in staging/default: local port 8080 is also used by service/api in prod/default, remapped to 8083",
+	}, warnings)
+}
+
+func TestConvertKFTrayToKPortal_ReportsPortCollisions(t *testing.T) {
+	dir := t.TempDir()
+	input := writeJSON(t, dir, "in.json", collidingEntries)
+	output := filepath.Join(dir, "out.yaml")
+
+	require.NoError(t, ConvertKFTrayToKPortal(input, output, false))
+
+	cfg, err := config.LoadConfig(output)
+	require.NoError(t, err)
+	assert.NotEmpty(t, config.NewValidator().ValidateConfig(cfg))
+
+	_, total, warnings, err := GetConversionSummary(input, false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	require.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "local port 8080 is also used by service/api in prod/default; change it before using the config")
+}
+
 // ─── convertToKPortal edge cases ─────────────────────────────────────────────
 
 func TestConvertToKPortal_EmptyInput(t *testing.T) {
@@ -254,12 +325,12 @@ func TestConvertToKPortal_ZeroPorts(t *testing.T) {
 }
 
 func TestConvertToKPortal_EmptyWorkloadType(t *testing.T) {
-	// WorkloadType="" → resource becomes "/svc"
+	// WorkloadType="" is a service, as in kftray
 	result := convertToKPortal([]KFTrayConfig{
 		{Service: "svc", Namespace: "ns", Context: "ctx", WorkloadType: "", Protocol: "tcp", LocalPort: 80, RemotePort: 80},
 	})
 	fwd := result.Contexts[0].Namespaces[0].Forwards[0]
-	assert.Equal(t, "/svc", fwd.Resource)
+	assert.Equal(t, "service/svc", fwd.Resource)
 }
 
 func TestConvertToKPortal_ForwardsSortedByLocalPort(t *testing.T) {
@@ -210,3 +210,41 @@ func TestConvertToKPortal_DifferentPorts(t *testing.T) {
 	assert.Equal(t, 3000, forward.Port, "Remote port should be 3000")
 	assert.Equal(t, 8080, forward.LocalPort, "Local port should be 8080")
 }
+
+func TestConvertToKPortal_WorkloadTypes(t *testing.T) {
+	tests := []struct {
+		name         string
+		entry        KFTrayConfig
+		wantResource string
+		wantSelector string
+	}{
+		{name: "deployment", entry: KFTrayConfig{Service: "api", WorkloadType: "deployment"}, wantResource: "deployment/api"},
+		{name: "statefulset", entry: KFTrayConfig{Service: "pg", WorkloadType: "StatefulSet"}, wantResource: "statefulset/pg"},
+		{name: "pod by target", entry: KFTrayConfig{Service: "ignored", Target: "app=web", WorkloadType: "pod"}, wantResource: "pod", wantSelector: "app=web"},
+		{name: "pod by name", entry: KFTrayConfig{Service: "web-0", WorkloadType: "pod"}, wantResource: "pod/web-0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.entry.Context, tt.entry.Namespace = "ctx", "ns"
+			tt.entry.LocalPort, tt.entry.RemotePort, tt.entry.Alias = 8080, 80, "my-alias"
+
+			result := convertToKPortal([]KFTrayConfig{tt.entry})
+			forward := result.Contexts[0].Namespaces[0].Forwards[0]
+			assert.Equal(t, tt.wantResource, forward.Resource)
+			assert.Equal(t, tt.wantSelector, forward.Selector)
+			assert.Equal(t, "my-alias", forward.Alias)
+			assert.Equal(t, 8080, forward.LocalPort)
+			assert.Equal(t, 80, forward.Port)
+		})
+	}
+}
+
+func TestConvertToKPortal_SkipsUnmappable(t *testing.T) {
+	result := convertToKPortal([]KFTrayConfig{
+		{Service: "redis", Context: "ctx", Namespace: "ns", WorkloadType: "proxy", LocalPort: 6379, RemotePort: 6379},
+		{Service: "job", Context: "ctx", Namespace: "ns", WorkloadType: "cronjob", LocalPort: 80, RemotePort: 80},
+		{Context: "ctx", Namespace: "ns", WorkloadType: "pod", LocalPort: 81, RemotePort: 81},
+	})
+	assert.Empty(t, result.Contexts)
+}
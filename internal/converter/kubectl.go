@@ -0,0 +1,326 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// DefaultKubectlNamespace is used for commands without -n/--namespace.
+const DefaultKubectlNamespace = "default"
+
+// KubectlForward is a single port mapping parsed from a
+// `kubectl port-forward` command line.
+type KubectlForward struct {
+	Context   string
+	Namespace string
+	Resource  string
+	Line      int
+	Port      int
+	LocalPort int
+}
+
+// kubectlValueFlags lists kubectl flags that take a separate value argument
+// and do not affect the generated config.
+var kubectlValueFlags = map[string]bool{
+	"--address":             true,
+	"--pod-running-timeout": true,
+	"--kubeconfig":          true,
+	"--cluster":             true,
+	"--user":                true,
+	"-s":                    true,
+	"--server":              true,
+	"--token":               true,
+	"--as":                  true,
+	"--as-group":            true,
+	"--request-timeout":     true,
+	"-v":                    true,
+}
+
+// currentKubeContext returns the kubeconfig current-context, used for
+// commands without --context. Overridden in tests.
+var currentKubeContext = func() string {
+	rawConfig, err := clientcmd.NewDefaultClientConfigLoadingRules().Load()
+	if err != nil || rawConfig.CurrentContext == "" {
+		return "default"
+	}
+	return rawConfig.CurrentContext
+}
+
+// ConvertKubectlCommands converts a file of `kubectl port-forward` command
+// lines (one per line, e.g. a shell script) to kportal YAML format.
+// Lines that are not kubectl port-forward commands are ignored.
+func ConvertKubectlCommands(inputFile, outputFile string) error {
+	forwards, err := loadKubectlCommands(inputFile)
+	if err != nil {
+		return err
+	}
+
+	contextMap := make(map[string]map[string][]forwardEntry)
+	for _, fwd := range forwards {
+		if _, ok := contextMap[fwd.Context]; !ok {
+			contextMap[fwd.Context] = make(map[string][]forwardEntry)
+		}
+		contextMap[fwd.Context][fwd.Namespace] = append(contextMap[fwd.Context][fwd.Namespace], forwardEntry{
+			Resource:  fwd.Resource,
+			Protocol:  "tcp",
+			Port:      fwd.Port,
+			LocalPort: fwd.LocalPort,
+		})
+	}
+
+	header := "# kportal configuration converted from kubectl port-forward commands\n# Generated by kportal --convert-kubectl\n\n"
+	return writeConfig(buildConfig(contextMap), header, outputFile)
+}
+
+// GetKubectlConversionSummary returns statistics about the kubectl commands
+// in inputFile: forwards per namespace per context and the total number of forwards.
+func GetKubectlConversionSummary(inputFile string) (map[string]map[string]int, int, error) {
+	forwards, err := loadKubectlCommands(inputFile)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	contextMap := make(map[string]map[string]int)
+	for _, fwd := range forwards {
+		if _, ok := contextMap[fwd.Context]; !ok {
+			contextMap[fwd.Context] = make(map[string]int)
+		}
+		contextMap[fwd.Context][fwd.Namespace]++
+	}
+
+	return contextMap, len(forwards), nil
+}
+
+// loadKubectlCommands reads and parses inputFile, filling in the current
+// kubeconfig context for commands without --context.
+func loadKubectlCommands(inputFile string) ([]KubectlForward, error) {
+	// #nosec G304 -- inputFile is from command line argument for explicit conversion
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	forwards, err := ParseKubectlCommands(string(data), "")
+	if err != nil {
+		return nil, err
+	}
+	if len(forwards) == 0 {
+		return nil, fmt.Errorf("no kubectl port-forward commands found in %s", inputFile)
+	}
+
+	var defaultContext string
+	for i := range forwards {
+		if forwards[i].Context == "" {
+			if defaultContext == "" {
+				defaultContext = currentKubeContext()
+			}
+			forwards[i].Context = defaultContext
+		}
+	}
+
+	return forwards, nil
+}
+
+// ParseKubectlCommands parses `kubectl port-forward` command lines from input.
+// Blank lines, comments and lines that are not kubectl port-forward commands
+// are skipped; lines ending in a backslash are joined with the next one.
+// Commands without --context use defaultContext.
+func ParseKubectlCommands(input, defaultContext string) ([]KubectlForward, error) {
+	var forwards []KubectlForward
+
+	var pending strings.Builder
+	startLine := 0
+	for i, raw := range strings.Split(input, "\n") {
+		line := strings.TrimSpace(raw)
+		if pending.Len() == 0 {
+			startLine = i + 1
+		}
+
+		if strings.HasSuffix(line, "\\") {
+			pending.WriteString(strings.TrimSuffix(line, "\\"))
+			pending.WriteString(" ")
+			continue
+		}
+		pending.WriteString(line)
+		command := pending.String()
+		pending.Reset()
+
+		parsed, err := parseKubectlLine(command, startLine, defaultContext)
+		if err != nil {
+			return nil, err
+		}
+		forwards = append(forwards, parsed...)
+	}
+
+	return forwards, nil
+}
+
+// parseKubectlLine parses a single logical command line. It returns nil if
+// the line is not a kubectl port-forward command.
+func parseKubectlLine(line string, lineNum int, defaultContext string) ([]KubectlForward, error) {
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+
+	tokens := shellTokens(line)
+
+	start := -1
+	for i, tok := range tokens {
+		if path.Base(tok) == "kubectl" {
+			start = i + 1
+			break
+		}
+	}
+	if start < 0 {
+		return nil, nil
+	}
+
+	contextName := defaultContext
+	namespace := DefaultKubectlNamespace
+	var positional []string
+
+	for i := start; i < len(tokens); i++ {
+		tok := tokens[i]
+
+		flagName, value, hasValue := strings.Cut(tok, "=")
+		switch {
+		case flagName == "-n" || flagName == "--namespace" || flagName == "--context":
+			if !hasValue {
+				if i+1 >= len(tokens) {
+					return nil, fmt.Errorf("line %d: flag %s requires a value", lineNum, flagName)
+				}
+				i++
+				value = tokens[i]
+			}
+			if flagName == "--context" {
+				contextName = value
+			} else {
+				namespace = value
+			}
+		case strings.HasPrefix(tok, "-n") && !strings.HasPrefix(tok, "--"):
+			// Short form without separator: -nmy-namespace
+			namespace = strings.TrimPrefix(tok, "-n")
+		case strings.HasPrefix(tok, "-"):
+			if !hasValue && kubectlValueFlags[flagName] {
+				i++
+			}
+		default:
+			positional = append(positional, tok)
+		}
+	}
+
+	if len(positional) == 0 || positional[0] != "port-forward" {
+		return nil, nil
+	}
+	positional = positional[1:]
+	if len(positional) < 2 {
+		return nil, fmt.Errorf("line %d: expected a target and at least one port", lineNum)
+	}
+
+	target := KubectlForward{
+		Context:   contextName,
+		Namespace: namespace,
+		Line:      lineNum,
+	}
+	if err := parseKubectlTarget(positional[0], &target); err != nil {
+		return nil, fmt.Errorf("line %d: %w", lineNum, err)
+	}
+
+	forwards := make([]KubectlForward, 0, len(positional)-1)
+	for _, spec := range positional[1:] {
+		localPort, port, err := parseKubectlPorts(spec)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		fwd := target
+		fwd.LocalPort = localPort
+		fwd.Port = port
+		forwards = append(forwards, fwd)
+	}
+
+	return forwards, nil
+}
+
+// parseKubectlTarget maps a kubectl target (pod name, pod/x, svc/x,
+// deploy/x, sts/x, ...) to a kportal resource.
+func parseKubectlTarget(target string, fwd *KubectlForward) error {
+	kind, name, found := strings.Cut(target, "/")
+	if !found {
+		// A bare name is a pod
+		kind, name = "pod", target
+	}
+	if name == "" {
+		return fmt.Errorf("missing resource name in %q", target)
+	}
+
+	// Drop an API group suffix: deployment.apps/x -> deployment/x
+	kind, _, _ = strings.Cut(strings.ToLower(kind), ".")
+
+	switch kind {
+	case "pod", "pods", "po":
+		fwd.Resource = "pod/" + name
+	case "service", "services", "svc":
+		fwd.Resource = "service/" + name
+	case "deployment", "deployments", "deploy":
+		fwd.Resource = "deployment/" + name
+	case "statefulset", "statefulsets", "sts":
+		fwd.Resource = "statefulset/" + name
+	default:
+		return fmt.Errorf("unsupported resource type %q (expected pod, service, deployment or statefulset)", kind)
+	}
+
+	return nil
+}
+
+// parseKubectlPorts parses a kubectl port spec: "LOCAL:REMOTE", "PORT"
+// (same local and remote port) or ":REMOTE" (local port picked at start).
+func parseKubectlPorts(spec string) (int, int, error) {
+	localStr, remoteStr, found := strings.Cut(spec, ":")
+	if !found {
+		localStr, remoteStr = spec, spec
+	}
+
+	remote, err := strconv.Atoi(remoteStr)
+	if err != nil || remote < 1 || remote > 65535 {
+		return 0, 0, fmt.Errorf("invalid remote port in %q", spec)
+	}
+
+	if localStr == "" {
+		return 0, remote, nil
+	}
+	local, err := strconv.Atoi(localStr)
+	if err != nil || local < 1 || local > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port in %q", spec)
+	}
+
+	return local, remote, nil
+}
+
+// shellTokens splits a command line on whitespace, strips simple quoting and
+// stops at shell control operators (&, &&, ;, |, redirections).
+func shellTokens(line string) []string {
+	var tokens []string
+	for _, field := range strings.Fields(line) {
+		if strings.HasPrefix(field, "#") {
+			break
+		}
+		if strings.ContainsAny(field[:1], "&;|<>") || strings.HasPrefix(field, "2>") || strings.HasPrefix(field, "1>") {
+			break
+		}
+
+		// Trailing operator glued to the last argument: `8080:80&`, `80;`
+		trimmed := strings.TrimRight(field, "&;")
+		if tok := strings.Trim(trimmed, `"'`); tok != "" {
+			tokens = append(tokens, tok)
+		}
+		if len(trimmed) < len(field) {
+			break
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,170 @@
+package converter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+func TestParseKubectlCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []KubectlForward
+	}{
+		{
+			name:  "service with namespace",
+			input: "kubectl port-forward svc/foo 8080:80 -n bar",
+			expected: []KubectlForward{
+				{Context: "ctx", Namespace: "bar", Resource: "service/foo", Port: 80, LocalPort: 8080, Line: 1},
+			},
+		},
+		{
+			name:  "long flags before subcommand",
+			input: "kubectl --context=prod --namespace api port-forward service/api 9000:9000",
+			expected: []KubectlForward{
+				{Context: "prod", Namespace: "api", Resource: "service/api", Port: 9000, LocalPort: 9000, Line: 1},
+			},
+		},
+		{
+			name:  "pod forms and default namespace",
+			input: "kubectl port-forward pod/web 3000:3000\nkubectl port-forward worker 5000",
+			expected: []KubectlForward{
+				{Context: "ctx", Namespace: "default", Resource: "pod/web", Port: 3000, LocalPort: 3000, Line: 1},
+				{Context: "ctx", Namespace: "default", Resource: "pod/worker", Port: 5000, LocalPort: 5000, Line: 2},
+			},
+		},
+		{
+			name:  "deployment and statefulset",
+			input: "kubectl port-forward deployment.apps/backend 8081:8080 -n apps\nkubectl port-forward sts/db 5432 -n apps",
+			expected: []KubectlForward{
+				{Context: "ctx", Namespace: "apps", Resource: "deployment/backend", Port: 8080, LocalPort: 8081, Line: 1},
+				{Context: "ctx", Namespace: "apps", Resource: "statefulset/db", Port: 5432, LocalPort: 5432, Line: 2},
+			},
+		},
+		{
+			name:  "multiple ports and random local port",
+			input: "kubectl port-forward svc/db 5432:5432 :6379",
+			expected: []KubectlForward{
+				{Context: "ctx", Namespace: "default", Resource: "service/db", Port: 5432, LocalPort: 5432, Line: 1},
+				{Context: "ctx", Namespace: "default", Resource: "service/db", Port: 6379, LocalPort: 0, Line: 1},
+			},
+		},
+		{
+			name: "shell script noise",
+			input: `#!/bin/bash
+set -e
+# forward the api
+/usr/local/bin/kubectl -n web port-forward svc/api 8080:80 --address 0.0.0.0 > /dev/null 2>&1 &
+kubectl get pods
+kubectl port-forward \
+  --context staging \
+  svc/queue 5672:5672 &
+`,
+			expected: []KubectlForward{
+				{Context: "ctx", Namespace: "web", Resource: "service/api", Port: 80, LocalPort: 8080, Line: 4},
+				{Context: "staging", Namespace: "default", Resource: "service/queue", Port: 5672, LocalPort: 5672, Line: 6},
+			},
+		},
+		{
+			name:  "quoted arguments and glued operator",
+			input: `kubectl port-forward -n "ops" 'svc/grafana' 3001:3000&`,
+			expected: []KubectlForward{
+				{Context: "ctx", Namespace: "ops", Resource: "service/grafana", Port: 3000, LocalPort: 3001, Line: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forwards, err := ParseKubectlCommands(tt.input, "ctx")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, forwards)
+		})
+	}
+}
+
+func TestParseKubectlCommands_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		errorContains string
+	}{
+		{"missing ports", "kubectl port-forward svc/foo", "line 1: expected a target and at least one port"},
+		{"unsupported type", "kubectl port-forward daemonset/agent 5432", "unsupported resource type"},
+		{"invalid port", "kubectl port-forward svc/foo 8080:http", "invalid remote port"},
+		{"invalid local port", "\nkubectl port-forward svc/foo 99999:80", "line 2: invalid local port"},
+		{"missing flag value", "kubectl port-forward svc/foo 80 -n", "requires a value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseKubectlCommands(tt.input, "ctx")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestConvertKubectlCommands(t *testing.T) {
+	orig := currentKubeContext
+	currentKubeContext = func() string { return "minikube" }
+	t.Cleanup(func() { currentKubeContext = orig })
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "forwards.sh")
+	require.NoError(t, os.WriteFile(input, []byte(`kubectl port-forward svc/api 8080:80 -n web
+kubectl --context prod port-forward deploy/worker 9090:9090
+`), 0600))
+	output := filepath.Join(dir, "out.yaml")
+
+	require.NoError(t, ConvertKubectlCommands(input, output))
+
+	raw, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(raw), "# kportal configuration converted from kubectl port-forward commands"))
+
+	var cfg config.Config
+	require.NoError(t, yaml.Unmarshal(raw, &cfg))
+	require.Len(t, cfg.Contexts, 2)
+
+	assert.Equal(t, "minikube", cfg.Contexts[0].Name)
+	fwd := cfg.Contexts[0].Namespaces[0].Forwards[0]
+	assert.Equal(t, "web", cfg.Contexts[0].Namespaces[0].Name)
+	assert.Equal(t, "service/api", fwd.Resource)
+	assert.Equal(t, "tcp", fwd.Protocol)
+
+	assert.Equal(t, "prod", cfg.Contexts[1].Name)
+	fwd = cfg.Contexts[1].Namespaces[0].Forwards[0]
+	assert.Equal(t, "deployment/worker", fwd.Resource)
+	assert.Empty(t, fwd.Selector)
+
+	contextMap, total, err := GetKubectlConversionSummary(input)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 1, contextMap["minikube"]["web"])
+	assert.Equal(t, 1, contextMap["prod"]["default"])
+}
+
+func TestConvertKubectlCommands_NoCommands(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "empty.sh")
+	require.NoError(t, os.WriteFile(input, []byte("#!/bin/sh\necho hi\n"), 0600))
+
+	err := ConvertKubectlCommands(input, filepath.Join(dir, "out.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no kubectl port-forward commands found")
+}
+
+func TestConvertKubectlCommands_MissingInput(t *testing.T) {
+	err := ConvertKubectlCommands("/nonexistent/forwards.sh", filepath.Join(t.TempDir(), "out.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read input file")
+}
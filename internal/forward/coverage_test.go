@@ -440,7 +440,7 @@ func TestForwardWorker_SleepWithBackoff_WaitsDelay(t *testing.T) {
 
 	b := retry.NewBackoff()
 	start := time.Now()
-	w.sleepWithBackoff(b)
+	w.sleepWithBackoff(b, nil)
 	assert.GreaterOrEqual(t, time.Since(start), 500*time.Millisecond)
 }
 
@@ -451,7 +451,7 @@ func TestForwardWorker_SleepWithBackoff_CancelReturnsEarly(t *testing.T) {
 
 	b := retry.NewBackoff()
 	start := time.Now()
-	w.sleepWithBackoff(b)
+	w.sleepWithBackoff(b, nil)
 	assert.Less(t, time.Since(start), 2*time.Second, "cancelled worker should not sleep")
 }
 
@@ -461,7 +461,7 @@ func TestForwardWorker_SleepWithBackoff_Verbose(t *testing.T) {
 	w.cancel()
 
 	b := retry.NewBackoff()
-	w.sleepWithBackoff(b) // must not panic in verbose mode
+	w.sleepWithBackoff(b, nil) // must not panic in verbose mode
 }
 
 // ---------------------------------------------------------------------------
@@ -631,36 +631,6 @@ func TestForwardWorker_Start_TerminatesOnCancel(t *testing.T) {
 	}
 }
 
-// ---------------------------------------------------------------------------
-// getProcessUsingPortUnix – internal branch coverage
-// ---------------------------------------------------------------------------
-
-// TestGetProcessUsingPortUnix_EmptyOutput exercises the pidStr=="" branch.
-// Port 2 is a privileged port that nothing listens on in a test environment.
-// lsof returns either empty (→ "unknown") or a PID if some process owns it.
-// Either way the function must not panic.
-func TestGetProcessUsingPortUnix_NothingListening(t *testing.T) {
-	pc := NewPortChecker()
-	// Port 2 is almost never bound; lsof will return empty → "unknown".
-	result := pc.getProcessUsingPortUnix(2)
-	assert.NotEmpty(t, result)
-}
-
-// TestGetProcessUsingPortUnix_ActivePort exercises the pid-parsing path by
-// using a port that the test binary itself is actively listening on.
-func TestGetProcessUsingPortUnix_ActivePort(t *testing.T) {
-	// #nosec G102 -- test binds to all interfaces intentionally
-	l, err := net.Listen("tcp", ":0")
-	require.NoError(t, err)
-	defer func() { _ = l.Close() }()
-	port := l.Addr().(*net.TCPAddr).Port
-
-	pc := NewPortChecker()
-	result := pc.getProcessUsingPortUnix(port)
-	// Should be a process string or "unknown" – must not panic.
-	assert.NotEmpty(t, result)
-}
-
 // ---------------------------------------------------------------------------
 // startWorker callbacks – exercise watchdog hung callback and health callback
 // ---------------------------------------------------------------------------
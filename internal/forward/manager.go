@@ -15,6 +15,7 @@
 package forward
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sync"
@@ -24,8 +25,12 @@ import (
 	"github.com/lukaszraczylo/kportal/internal/events"
 	"github.com/lukaszraczylo/kportal/internal/healthcheck"
 	"github.com/lukaszraczylo/kportal/internal/k8s"
+	"github.com/lukaszraczylo/kportal/internal/localaddr"
 	"github.com/lukaszraczylo/kportal/internal/logger"
 	"github.com/lukaszraczylo/kportal/internal/mdns"
+	"github.com/lukaszraczylo/kportal/internal/metrics"
+	"github.com/lukaszraczylo/kportal/internal/notify"
+	"github.com/lukaszraczylo/kportal/internal/retry"
 )
 
 // StatusUpdater is an interface for updating forward status
@@ -35,26 +40,54 @@ type StatusUpdater interface {
 	Remove(id string)
 }
 
+// Notifier is told when a forward's status changes kind, e.g. from Active to
+// Reconnecting but not between two reconnect attempts, to raise desktop
+// notifications or call webhooks. Calls come from worker and health check
+// goroutines and must not block.
+type Notifier interface {
+	StatusChanged(change notify.Change)
+	Removed(id string)
+}
+
 // Manager orchestrates all port-forward workers.
 // It handles starting, stopping, and hot-reloading forwards.
 type Manager struct {
 	statusUI      StatusUpdater
+	states        *stateTracker // records every status update for Status
 	healthChecker *healthcheck.Checker
 	clientPool    *k8s.ClientPool
 	resolver      *k8s.ResourceResolver
 	portForwarder *k8s.PortForwarder
 	portChecker   *PortChecker
 	workers       map[string]*ForwardWorker
+	// assignedPorts remembers the local port picked for each localPort: 0
+	// forward so it survives reloads and disable/enable. Guarded by workersMu.
+	assignedPorts map[string]int
 	watchdog      *Watchdog
+	netWatch      *networkWatcher // nil when reliability.networkWatchInterval is 0
 	mdnsPublisher *mdns.Publisher
+	metrics       *metrics.Registry
 	eventBus      *events.Bus
 	// currentConfig holds the active configuration. Access MUST be guarded by
 	// workersMu — it is read from the health-checker callback goroutine
 	// (registered in startWorker) and written by Start/Reload.
 	currentConfig *config.Config
+	stopped       chan struct{} // closed when Shutdown's teardown finishes
 	workersMu     sync.RWMutex
 	stopOnce      sync.Once
-	verbose       bool
+	// kubeconfig is the kubeconfig file set by -kubeconfig; it overrides
+	// the config's kubeconfig key
+	kubeconfig string
+	// profile limits the forwards started to the members of this config
+	// profile, as -profile does; empty starts every forward
+	profile string
+	// tags limits the forwards started to those carrying one of them, as
+	// -tags does; empty starts every forward
+	tags    []string
+	verbose bool
+	// httpLogAll enables HTTP logging for every forward without an httpLog
+	// setting, on top of the config's top-level httpLog.
+	httpLogAll bool
 }
 
 // NewManager creates a new forward Manager.
@@ -86,6 +119,8 @@ func NewManager(verbose bool) (*Manager, error) {
 
 	return &Manager{
 		workers:       make(map[string]*ForwardWorker),
+		assignedPorts: make(map[string]int),
+		states:        newStateTracker(),
 		clientPool:    clientPool,
 		resolver:      resolver,
 		portForwarder: portForwarder,
@@ -136,6 +171,11 @@ func (m *Manager) configureHealthChecker(cfg *config.Config) {
 	m.portForwarder.SetTCPKeepalive(tcpKeepalive)
 	m.portForwarder.SetDialTimeout(dialTimeout)
 
+	// Smooth the burst of pod/service lookups when many forwards start
+	m.clientPool.SetConcurrency(cfg.GetDiscoveryConcurrency(), cfg.GetDiscoveryConcurrencyTotal())
+	m.resolver.SetCacheTTL(cfg.GetResolveCacheTTL())
+	m.resolver.SetCacheMaxEntries(cfg.GetResolveCacheMaxEntries())
+
 	logger.Info("Health checker and reliability configured", map[string]interface{}{
 		"interval":           cfg.GetHealthCheckIntervalOrDefault().String(),
 		"timeout":            cfg.GetHealthCheckTimeoutOrDefault().String(),
@@ -144,12 +184,32 @@ func (m *Manager) configureHealthChecker(cfg *config.Config) {
 		"max_idle_time":      cfg.GetMaxIdleTime().String(),
 		"tcp_keepalive":      tcpKeepalive.String(),
 		"dial_timeout":       dialTimeout.String(),
+		"discovery_limit":    cfg.GetDiscoveryConcurrency(),
 	})
 }
 
 // SetStatusUI sets the status updater for the manager
 func (m *Manager) SetStatusUI(ui StatusUpdater) {
 	m.statusUI = ui
+	if m.states != nil {
+		m.states.setNext(ui)
+	}
+}
+
+// AddNotifier adds a notifier told about forward status changes
+func (m *Manager) AddNotifier(notifier Notifier) {
+	if m.states != nil {
+		m.states.addNotifier(notifier)
+	}
+}
+
+// updater returns where status updates go: the state tracker, which passes
+// them on to statusUI, or statusUI alone when there is no tracker.
+func (m *Manager) updater() StatusUpdater {
+	if m.states != nil {
+		return m.states
+	}
+	return m.statusUI
 }
 
 // SetMDNSPublisher sets the mDNS publisher for the manager
@@ -157,16 +217,78 @@ func (m *Manager) SetMDNSPublisher(publisher *mdns.Publisher) {
 	m.mdnsPublisher = publisher
 }
 
+// SetMetrics sets the registry forward metrics are reported to. Must be
+// called before Start.
+func (m *Manager) SetMetrics(reg *metrics.Registry) {
+	m.metrics = reg
+}
+
+// SetHTTPLogDefault enables HTTP logging for every forward that has no
+// httpLog setting of its own, whatever the config says, as -http-log does.
+// Must be called before Start.
+func (m *Manager) SetHTTPLogDefault(enabled bool) {
+	m.httpLogAll = enabled
+}
+
+// SetKubeconfig makes the manager read only the kubeconfig file at path,
+// whatever the config's kubeconfig key says, as -kubeconfig does. Must be
+// called before Start.
+func (m *Manager) SetKubeconfig(path string) {
+	m.kubeconfig = path
+}
+
+// SetTags limits the forwards the manager starts, now and on reload, to
+// those carrying one of tags, as -tags does. Must be called before Start.
+func (m *Manager) SetTags(tags []string) {
+	m.tags = tags
+}
+
+// SetProfile limits the forwards the manager starts, now and on reload, to
+// the members of the config profile name, as -profile does. Must be called
+// before Start.
+func (m *Manager) SetProfile(name string) {
+	m.profile = name
+}
+
+// configForwards returns the forwards of cfg that the manager runs: every
+// forward, or those in the -profile and carrying one of the -tags, sorted
+// by their order so they are started and listed in display order.
+func (m *Manager) configForwards(cfg *config.Config) []config.Forward {
+	forwards := cfg.FilterByProfile(config.FilterByTags(cfg.GetAllForwards(), m.tags), m.profile)
+	config.SortByOrder(forwards)
+	return forwards
+}
+
+// SetInCluster makes the in-cluster context, backed by the pod's service
+// account, available next to the kubeconfig contexts, as -in-cluster does.
+func (m *Manager) SetInCluster(enabled bool) {
+	m.clientPool.SetInCluster(enabled)
+}
+
+// kubeconfigFor returns the kubeconfig file to use with cfg: the one set by
+// SetKubeconfig, else the config's, else "" for the default discovery.
+func (m *Manager) kubeconfigFor(cfg *config.Config) string {
+	if m.kubeconfig != "" {
+		return m.kubeconfig
+	}
+	return cfg.GetKubeconfig()
+}
+
 // Start initializes and starts all port-forwards from the configuration.
 func (m *Manager) Start(cfg *config.Config) error {
 	if cfg == nil {
 		return fmt.Errorf("configuration is nil")
 	}
+	if m.profile != "" && !cfg.HasProfile(m.profile) {
+		return fmt.Errorf("profile %q is not defined in the configuration", m.profile)
+	}
 
 	m.workersMu.Lock()
 	m.currentConfig = cfg
 	m.workersMu.Unlock()
 
+	m.clientPool.SetKubeconfig(m.kubeconfigFor(cfg))
+
 	// Configure health checker with settings from config
 	m.configureHealthChecker(cfg)
 
@@ -181,15 +303,28 @@ func (m *Manager) Start(cfg *config.Config) error {
 		"hang_threshold": (watchdogPeriod * 2).String(),
 	})
 
+	// Reconnect forwards when the network changes under them
+	if interval := cfg.GetNetworkWatchInterval(); interval > 0 {
+		m.netWatch = newNetworkWatcher(interval, m.onNetworkChange)
+		m.netWatch.Start()
+	}
+
 	// Get all forwards from config
-	forwards := cfg.GetAllForwards()
+	forwards, disabled := splitEnabled(m.configForwards(cfg))
 
 	// Empty config is valid - user can add forwards later via TUI
-	if len(forwards) == 0 {
+	if len(forwards) == 0 && len(disabled) == 0 {
 		log.Printf("No forwards configured - use 'n' to add forwards")
 		return nil
 	}
 
+	// Forwards disabled in config are listed but never started
+	for _, fwd := range disabled {
+		m.showDisabled(fwd)
+	}
+
+	m.warnMissingContexts(forwards)
+
 	// Check port availability before starting
 	ports := m.extractPorts(forwards)
 	conflicts := m.portChecker.CheckAvailability(ports, nil)
@@ -201,11 +336,22 @@ func (m *Manager) Start(cfg *config.Config) error {
 		return fmt.Errorf("port conflicts detected:\n%s", FormatConflicts(conflicts))
 	}
 
-	// Start all workers
-	log.Printf("Starting %d port-forward(s)...", len(forwards))
+	// Fill the resolver cache so the first connects skip the lookup
+	if cfg.Prewarm {
+		m.prewarm(forwards)
+	}
 
-	for _, fwd := range forwards {
-		if err := m.startWorker(fwd); err != nil {
+	// Start all workers. With a startup stagger every forward is listed as
+	// Starting at once, but their first connects are spread out
+	stagger := cfg.GetStartupStagger()
+	if stagger > 0 {
+		log.Printf("Starting %d port-forward(s), %v apart...", len(forwards), stagger)
+	} else {
+		log.Printf("Starting %d port-forward(s)...", len(forwards))
+	}
+
+	for i, fwd := range forwards {
+		if err := m.startWorkerAfter(fwd, staggerDelay(i, stagger)); err != nil {
 			logger.Error("Failed to start worker", map[string]interface{}{
 				"forward_id": fwd.ID(),
 				"context":    fwd.GetContext(),
@@ -222,56 +368,107 @@ func (m *Manager) Start(cfg *config.Config) error {
 	return nil
 }
 
-// Stop gracefully stops all port-forward workers.
+// onNetworkChange reconnects every forward with an open tunnel after the
+// network watch saw the host's network change.
+func (m *Manager) onNetworkChange(reason string) {
+	m.workersMu.RLock()
+	workers := make([]*ForwardWorker, 0, len(m.workers))
+	for _, worker := range m.workers {
+		workers = append(workers, worker)
+	}
+	m.workersMu.RUnlock()
+
+	reconnected := 0
+	for _, worker := range workers {
+		if worker.reconnectForNetworkChange() {
+			reconnected++
+		}
+	}
+
+	logger.Info("Network change detected, reconnecting forwards", map[string]interface{}{
+		"reason":      reason,
+		"reconnected": reconnected,
+	})
+}
+
+// Stop gracefully stops all port-forward workers, waiting for them without
+// a deadline. It is Shutdown with a background context.
 func (m *Manager) Stop() {
+	_ = m.Shutdown(context.Background())
+}
+
+// Shutdown stops the health checker, watchdog, network watch, mDNS publisher
+// and all workers, and waits for the workers to stop. If ctx is done first it
+// returns ctx.Err() while the shutdown carries on in the background. Safe to
+// call more than once; later calls wait for the first shutdown.
+func (m *Manager) Shutdown(ctx context.Context) error {
 	m.stopOnce.Do(func() {
-		log.Printf("Stopping all port-forwards...")
+		m.stopped = make(chan struct{})
+		go func() {
+			defer close(m.stopped)
+			m.stop()
+		}()
+	})
 
-		// Stop health checker and watchdog first
-		m.healthChecker.Stop()
-		m.watchdog.Stop()
+	select {
+	case <-m.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-		// Close event bus
-		if m.eventBus != nil {
-			m.eventBus.Close()
-		}
+// stop tears down the manager. Called once, by Shutdown.
+func (m *Manager) stop() {
+	log.Printf("Stopping all port-forwards...")
 
-		// Stop mDNS publisher
-		if m.mdnsPublisher != nil {
-			m.mdnsPublisher.Stop()
-		}
+	// Stop health checker and watchdog first
+	m.healthChecker.Stop()
+	m.watchdog.Stop()
+	if m.netWatch != nil {
+		m.netWatch.Stop()
+	}
 
-		m.workersMu.Lock()
-		workers := make([]*ForwardWorker, 0, len(m.workers))
-		for _, worker := range m.workers {
-			workers = append(workers, worker)
-		}
-		m.workersMu.Unlock()
+	// Close event bus
+	if m.eventBus != nil {
+		m.eventBus.Close()
+	}
+
+	// Stop mDNS publisher
+	if m.mdnsPublisher != nil {
+		m.mdnsPublisher.Stop()
+	}
 
-		// Stop all workers with limited concurrency to avoid unbounded goroutine creation
-		var wg sync.WaitGroup
-		sem := make(chan struct{}, 10) // Limit to 10 concurrent stops
+	m.workersMu.Lock()
+	workers := make([]*ForwardWorker, 0, len(m.workers))
+	for _, worker := range m.workers {
+		workers = append(workers, worker)
+	}
+	m.workersMu.Unlock()
 
-		for _, worker := range workers {
-			wg.Add(1)
-			sem <- struct{}{} // Acquire semaphore
+	// Stop all workers with limited concurrency to avoid unbounded goroutine creation
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 10) // Limit to 10 concurrent stops
 
-			go func(w *ForwardWorker) {
-				defer wg.Done()
-				defer func() { <-sem }() // Release semaphore
-				w.Stop()
-			}(worker)
-		}
+	for _, worker := range workers {
+		wg.Add(1)
+		sem <- struct{}{} // Acquire semaphore
 
-		wg.Wait()
+		go func(w *ForwardWorker) {
+			defer wg.Done()
+			defer func() { <-sem }() // Release semaphore
+			w.Stop()
+		}(worker)
+	}
 
-		// Clear workers map
-		m.workersMu.Lock()
-		m.workers = make(map[string]*ForwardWorker)
-		m.workersMu.Unlock()
+	wg.Wait()
 
-		log.Printf("All port-forwards stopped")
-	})
+	// Clear workers map
+	m.workersMu.Lock()
+	m.workers = make(map[string]*ForwardWorker)
+	m.workersMu.Unlock()
+
+	log.Printf("All port-forwards stopped")
 }
 
 // Reload applies a new configuration with hot-reload logic.
@@ -283,15 +480,23 @@ func (m *Manager) Reload(newCfg *config.Config) error {
 	if newCfg == nil {
 		return fmt.Errorf("new configuration is nil")
 	}
+	if m.profile != "" && !newCfg.HasProfile(m.profile) {
+		return fmt.Errorf("profile %q is not defined in the new configuration", m.profile)
+	}
 
 	logger.Info("Reloading configuration", map[string]interface{}{
-		"new_forwards_count": len(newCfg.GetAllForwards()),
+		"new_forwards_count": len(m.configForwards(newCfg)),
 	})
 
+	// Re-read kubeconfig too, so contexts renamed or removed since the last
+	// load are reported instead of served from cached clients, and a changed
+	// kubeconfig key takes effect
+	m.clientPool.SetKubeconfig(m.kubeconfigFor(newCfg))
+
 	// Get all forwards from new config
-	newForwards := newCfg.GetAllForwards()
+	newForwards, newDisabled := splitEnabled(m.configForwards(newCfg))
 
-	if len(newForwards) == 0 {
+	if len(newForwards) == 0 && len(newDisabled) == 0 {
 		log.Printf("New configuration has no forwards, stopping all workers")
 		// Do NOT call m.Stop() here: it tears down healthChecker, watchdog
 		// and eventBus, which must remain alive so subsequent
@@ -311,8 +516,17 @@ func (m *Manager) Reload(newCfg *config.Config) error {
 		}
 
 		m.workersMu.Lock()
+		oldCfg := m.currentConfig
 		m.currentConfig = newCfg
 		m.workersMu.Unlock()
+
+		// Drop forwards that were only listed as disabled
+		if ui := m.updater(); oldCfg != nil && ui != nil {
+			_, oldDisabled := splitEnabled(m.configForwards(oldCfg))
+			for _, fwd := range oldDisabled {
+				ui.Remove(fwd.ID())
+			}
+		}
 		return nil
 	}
 
@@ -322,17 +536,32 @@ func (m *Manager) Reload(newCfg *config.Config) error {
 		newForwardsMap[fwd.ID()] = fwd
 	}
 
+	newDisabledMap := make(map[string]config.Forward)
+	for _, fwd := range newDisabled {
+		newDisabledMap[fwd.ID()] = fwd
+	}
+
 	m.workersMu.RLock()
 	currentForwardsMap := make(map[string]config.Forward)
 	for id, worker := range m.workers {
 		currentForwardsMap[id] = worker.GetForward()
 	}
+	var oldDisabled []config.Forward
+	if m.currentConfig != nil {
+		_, oldDisabled = splitEnabled(m.configForwards(m.currentConfig))
+	}
 	m.workersMu.RUnlock()
 
+	oldDisabledMap := make(map[string]bool)
+	for _, fwd := range oldDisabled {
+		oldDisabledMap[fwd.ID()] = true
+	}
+
 	// Determine changes
 	var toAdd []config.Forward
 	var toRemove []string
 	var toKeep []string
+	var toDisable []string
 
 	// Find forwards to add and keep
 	for id, fwd := range newForwardsMap {
@@ -343,13 +572,26 @@ func (m *Manager) Reload(newCfg *config.Config) error {
 		}
 	}
 
-	// Find forwards to remove
+	// Find forwards to remove. A running forward that is now disabled in
+	// config is stopped but stays listed; one that was already disabled in
+	// config was enabled from the TUI and is left running.
 	for id := range currentForwardsMap {
-		if _, exists := newForwardsMap[id]; !exists {
-			toRemove = append(toRemove, id)
+		if _, exists := newForwardsMap[id]; exists {
+			continue
 		}
+		if _, disabled := newDisabledMap[id]; disabled {
+			if oldDisabledMap[id] {
+				toKeep = append(toKeep, id)
+			} else {
+				toDisable = append(toDisable, id)
+			}
+			continue
+		}
+		toRemove = append(toRemove, id)
 	}
 
+	m.warnMissingContexts(newForwards)
+
 	// Check port availability for new forwards
 	if len(toAdd) > 0 {
 		// Get currently managed ports to skip in availability check
@@ -373,8 +615,8 @@ func (m *Manager) Reload(newCfg *config.Config) error {
 	}
 
 	// Apply changes
-	log.Printf("Configuration diff: %d to add, %d to remove, %d to keep",
-		len(toAdd), len(toRemove), len(toKeep))
+	log.Printf("Configuration diff: %d to add, %d to remove, %d to keep, %d to disable",
+		len(toAdd), len(toRemove), len(toKeep), len(toDisable))
 
 	// Stop removed forwards
 	for _, id := range toRemove {
@@ -385,26 +627,60 @@ func (m *Manager) Reload(newCfg *config.Config) error {
 		}
 	}
 
-	// Start new forwards
-	for _, fwd := range toAdd {
-		if err := m.startWorker(fwd); err != nil {
-			log.Printf("Failed to start worker for %s: %v", fwd.ID(), err)
+	// Stop forwards newly disabled in config, keeping them listed
+	for _, id := range toDisable {
+		if err := m.stopWorkerInternal(id, false); err != nil {
+			log.Printf("Failed to stop worker %s: %v", id, err)
 		} else {
-			log.Printf("Started: %s", fwd.ID())
+			log.Printf("Disabled: %s", id)
+		}
+	}
+
+	// List newly disabled forwards and drop ones no longer in config.
+	// Running workers are handled above.
+	for id, fwd := range newDisabledMap {
+		if _, running := currentForwardsMap[id]; !running && !oldDisabledMap[id] {
+			m.showDisabled(fwd)
+		}
+	}
+	if ui := m.updater(); ui != nil {
+		for id := range oldDisabledMap {
+			_, running := currentForwardsMap[id]
+			_, stillDisabled := newDisabledMap[id]
+			_, nowEnabled := newForwardsMap[id]
+			if !running && !stillDisabled && !nowEnabled {
+				ui.Remove(id)
+			}
 		}
 	}
 
-	// Update current config
+	// Update current config first, so new workers pick up its settings
 	m.workersMu.Lock()
 	m.currentConfig = newCfg
 	m.workersMu.Unlock()
 
+	// Start new forwards
+	stagger := newCfg.GetStartupStagger()
+	for i, fwd := range toAdd {
+		if err := m.startWorkerAfter(fwd, staggerDelay(i, stagger)); err != nil {
+			log.Printf("Failed to start worker for %s: %v", fwd.ID(), err)
+		} else {
+			log.Printf("Started: %s", fwd.ID())
+		}
+	}
+
 	log.Printf("Configuration reloaded successfully")
 	return nil
 }
 
 // startWorker creates and starts a new forward worker.
 func (m *Manager) startWorker(fwd config.Forward) error {
+	return m.startWorkerAfter(fwd, 0)
+}
+
+// startWorkerAfter creates and starts a new forward worker that waits delay
+// before its first connect. The forward is listed in the UI right away.
+func (m *Manager) startWorkerAfter(fwd config.Forward, delay time.Duration) error {
 	m.workersMu.Lock()
 	defer m.workersMu.Unlock()
 
@@ -413,13 +689,40 @@ func (m *Manager) startWorker(fwd config.Forward) error {
 		return fmt.Errorf("worker already exists for %s", fwd.ID())
 	}
 
+	// Resolve localPort: 0 before anything records the port
+	if fwd.IsAutoLocalPort() {
+		port, err := m.assignLocalPort(fwd.ID())
+		if err != nil {
+			return err
+		}
+		fwd.AssignLocalPort(port)
+	}
+
 	// Notify UI about new forward
-	if m.statusUI != nil {
-		m.statusUI.AddForward(fwd.ID(), &fwd)
+	ui := m.updater()
+	if ui != nil {
+		ui.AddForward(fwd.ID(), &fwd)
 	}
 
+	// The global httpLog default only shapes the worker's proxy; the UI
+	// keeps the forward as configured so edits do not write it back
+	workerFwd := fwd
+	workerFwd.ApplyHTTPLogDefault(m.httpLogAll || (m.currentConfig != nil && m.currentConfig.HTTPLog))
+
 	// Create worker first so we can pass it to watchdog
-	worker := NewForwardWorker(fwd, m.portForwarder, m.verbose, m.statusUI, m.healthChecker, m.watchdog)
+	worker := NewForwardWorker(workerFwd, m.portForwarder, m.verbose, ui, m.healthChecker, m.watchdog)
+	if m.metrics != nil {
+		m.metrics.SetUp(fwd.ID(), false)
+		worker.metrics = m.metrics
+		worker.traffic.next = m.metrics.Traffic(fwd.ID())
+	}
+	if m.currentConfig != nil {
+		worker.backoffOpts = retry.Options{
+			InitialDelay: m.currentConfig.GetReconnectBaseDelay(),
+			MaxDelay:     m.currentConfig.GetReconnectMaxDelay(),
+			Jitter:       m.currentConfig.GetReconnectJitter(),
+		}
+	}
 
 	// Register with watchdog using the new responder interface
 	// This allows the watchdog to poll the worker for heartbeats centrally
@@ -439,40 +742,63 @@ func (m *Manager) startWorker(fwd config.Forward) error {
 		}
 	})
 
-	// Register with health checker
-	m.healthChecker.Register(fwd.ID(), fwd.LocalPort, func(forwardID string, status healthcheck.Status, errorMsg string) {
-		if m.statusUI != nil {
-			m.statusUI.UpdateStatus(forwardID, string(status))
-
-			// Send error separately if there is one
-			if (status == healthcheck.StatusUnhealthy || status == healthcheck.StatusStale) && errorMsg != "" {
-				if ui, ok := m.statusUI.(interface{ SetError(id, msg string) }); ok {
-					ui.SetError(forwardID, errorMsg)
-				}
+	// Register with health checker. Probes dial TCP, so UDP forwards are
+	// skipped and their status is reported by the worker instead.
+	if fwd.GetProtocol() != config.ProtocolUDP {
+		var probe *healthcheck.HTTPProbe
+		if fwd.HealthCheck != nil {
+			probe = &healthcheck.HTTPProbe{
+				Path:           fwd.HealthCheck.Path,
+				Interval:       fwd.HealthCheck.GetInterval(),
+				ExpectedStatus: fwd.HealthCheck.GetExpectedStatus(),
 			}
 		}
 
-		// Handle stale connections: trigger reconnection if retryOnStale is enabled.
-		// Read currentConfig and worker map under a single lock acquisition
-		// to avoid racing with Reload/Start writes.
-		if status == healthcheck.StatusStale {
-			m.workersMu.RLock()
-			retryOnStale := m.currentConfig != nil && m.currentConfig.GetRetryOnStale()
-			staleWorker, exists := m.workers[forwardID]
-			m.workersMu.RUnlock()
-
-			if retryOnStale {
-				logger.Info("Stale connection detected, triggering reconnection", map[string]interface{}{
-					"forward_id": forwardID,
-					"reason":     errorMsg,
-				})
+		host := localaddr.DialHost(fwd.BindAddress)
+		m.healthChecker.RegisterAt(fwd.ID(), host, fwd.LocalPort, probe, func(forwardID string, status healthcheck.Status, errorMsg string) {
+			if m.metrics != nil {
+				m.metrics.SetUp(forwardID, status == healthcheck.StatusHealthy)
+			}
 
-				if exists {
-					staleWorker.TriggerReconnect("stale connection")
+			if ui != nil {
+				ui.UpdateStatus(forwardID, string(status))
+
+				// Send error separately if there is one
+				if (status == healthcheck.StatusUnhealthy || status == healthcheck.StatusStale || status == healthcheck.StatusProbeFailed) && errorMsg != "" {
+					if errUI, ok := ui.(interface{ SetError(id, msg string) }); ok {
+						errUI.SetError(forwardID, errorMsg)
+					}
 				}
 			}
-		}
-	})
+
+			// Handle stale connections: trigger reconnection if retryOnStale is enabled.
+			// Read currentConfig and worker map under a single lock acquisition
+			// to avoid racing with Reload/Start writes.
+			if status == healthcheck.StatusStale {
+				m.workersMu.RLock()
+				retryOnStale := m.currentConfig != nil && m.currentConfig.GetRetryOnStale()
+				staleWorker, exists := m.workers[forwardID]
+				m.workersMu.RUnlock()
+
+				if retryOnStale {
+					logger.Info("Stale connection detected, triggering reconnection", map[string]interface{}{
+						"forward_id": forwardID,
+						"reason":     errorMsg,
+					})
+
+					if exists {
+						staleWorker.TriggerReconnect("stale connection")
+					}
+				}
+			}
+		})
+	}
+
+	// Keep the forward Starting, rather than Error, while it waits its turn
+	if delay > 0 {
+		m.healthChecker.DelayStart(fwd.ID(), delay)
+		worker.startDelay = delay
+	}
 
 	// Start the worker (already created above)
 	worker.Start()
@@ -480,12 +806,12 @@ func (m *Manager) startWorker(fwd config.Forward) error {
 	// Store worker
 	m.workers[fwd.ID()] = worker
 
-	// Register mDNS hostname if enabled
+	// Register mDNS hostname if enabled and not opted out per forward
 	// Uses explicit alias if set, otherwise generates from resource name
-	if m.mdnsPublisher != nil {
+	if m.mdnsPublisher != nil && fwd.IsMDNSPublished() {
 		mdnsAlias := fwd.GetMDNSAlias()
 		if mdnsAlias != "" {
-			if err := m.mdnsPublisher.Register(fwd.ID(), mdnsAlias, fwd.LocalPort); err != nil {
+			if err := m.mdnsPublisher.Register(fwd.ID(), mdnsAlias, fwd.GetContext(), fwd.GetNamespace(), fwd.LocalPort); err != nil {
 				logger.Warn("Failed to register mDNS hostname", map[string]interface{}{
 					"forward_id": fwd.ID(),
 					"alias":      mdnsAlias,
@@ -513,6 +839,10 @@ func (m *Manager) stopWorkerInternal(id string, removeFromUI bool) error {
 		return fmt.Errorf("worker not found: %s", id)
 	}
 	delete(m.workers, id)
+	if removeFromUI {
+		// Forward is gone from config; a re-add may pick a new port
+		delete(m.assignedPorts, id)
+	}
 	m.workersMu.Unlock()
 
 	// Unregister from health checker and watchdog
@@ -524,12 +854,21 @@ func (m *Manager) stopWorkerInternal(id string, removeFromUI bool) error {
 		m.mdnsPublisher.Unregister(id)
 	}
 
+	// Drop metrics for removed forwards; disabled ones stay visible as down
+	if m.metrics != nil {
+		if removeFromUI {
+			m.metrics.Remove(id)
+		} else {
+			m.metrics.SetUp(id, false)
+		}
+	}
+
 	// Notify UI - either remove or update to disabled status
-	if m.statusUI != nil {
+	if ui := m.updater(); ui != nil {
 		if removeFromUI {
-			m.statusUI.Remove(id)
+			ui.Remove(id)
 		} else {
-			m.statusUI.UpdateStatus(id, "Disabled")
+			ui.UpdateStatus(id, "Disabled")
 		}
 	}
 
@@ -547,11 +886,119 @@ func (m *Manager) GetWorker(id string) *ForwardWorker {
 	return m.workers[id]
 }
 
-// extractPorts extracts all local ports from a list of forwards.
+// TrafficStats returns the traffic counters of a running forward. It reports
+// false when the forward has no worker, e.g. because it is disabled.
+func (m *Manager) TrafficStats(id string) (TrafficStats, bool) {
+	worker := m.GetWorker(id)
+	if worker == nil {
+		return TrafficStats{}, false
+	}
+	return worker.TrafficStats(), true
+}
+
+// RequestHistory returns the HTTP requests per second of a running forward
+// over the last 30 seconds, oldest first. ok is false when the forward is
+// not running or does not have HTTP logging.
+func (m *Manager) RequestHistory(id string) ([]int64, bool) {
+	worker := m.GetWorker(id)
+	if worker == nil {
+		return nil, false
+	}
+	return worker.RequestHistory()
+}
+
+// Trace returns the byte traces of a running forward's recent client
+// connections. ok is false when the forward is not running or does not
+// have traceBytes set.
+func (m *Manager) Trace(id string) ([]ConnTrace, bool) {
+	worker := m.GetWorker(id)
+	if worker == nil {
+		return nil, false
+	}
+	return worker.Trace()
+}
+
+// splitEnabled partitions forwards into those to start and those disabled
+// in config with `enabled: false`.
+func splitEnabled(forwards []config.Forward) (enabled, disabled []config.Forward) {
+	for _, fwd := range forwards {
+		if fwd.IsEnabled() {
+			enabled = append(enabled, fwd)
+		} else {
+			disabled = append(disabled, fwd)
+		}
+	}
+	return enabled, disabled
+}
+
+// warnMissingContexts logs each context of forwards that kubeconfig does not
+// have. Their workers still start and show "Context not found" until the
+// context is back.
+func (m *Manager) warnMissingContexts(forwards []config.Forward) {
+	affected := make(map[string][]string)
+	var contexts []string
+	for _, fwd := range forwards {
+		name := fwd.GetContext()
+		if _, seen := affected[name]; !seen {
+			contexts = append(contexts, name)
+		}
+		affected[name] = append(affected[name], fwd.ID())
+	}
+
+	for _, name := range contexts {
+		exists, err := m.clientPool.HasContext(name)
+		if err != nil || exists {
+			continue // an unreadable kubeconfig is reported by each worker
+		}
+		logger.Warn("Context not found in kubeconfig", map[string]interface{}{
+			"context":  name,
+			"forwards": affected[name],
+		})
+		log.Printf("Warning: context %q not found in kubeconfig; %d forward(s) will retry until it is back", name, len(affected[name]))
+	}
+}
+
+// showDisabled lists a forward in the UI with "Disabled" status without
+// starting a worker. It can still be enabled from the TUI.
+func (m *Manager) showDisabled(fwd config.Forward) {
+	ui := m.updater()
+	if ui == nil {
+		return
+	}
+	ui.AddForward(fwd.ID(), &fwd)
+	ui.UpdateStatus(fwd.ID(), "Disabled")
+}
+
+// assignLocalPort returns the local port for an auto-assigned forward,
+// reusing the previous assignment while it is still free.
+// Caller must hold workersMu.
+func (m *Manager) assignLocalPort(id string) (int, error) {
+	if port, ok := m.assignedPorts[id]; ok {
+		if len(m.portChecker.CheckAvailability([]int{port}, nil)) == 0 {
+			return port, nil
+		}
+		logger.Warn("Previously assigned local port is taken, picking a new one", map[string]interface{}{
+			"forward_id": id,
+			"local_port": port,
+		})
+	}
+
+	port, err := m.portChecker.FindFreePort()
+	if err != nil {
+		return 0, err
+	}
+	m.assignedPorts[id] = port
+	return port, nil
+}
+
+// extractPorts extracts all fixed local ports from a list of forwards.
+// Auto-assigned ports (localPort 0) are skipped.
 func (m *Manager) extractPorts(forwards []config.Forward) []int {
-	ports := make([]int, len(forwards))
-	for i, fwd := range forwards {
-		ports[i] = fwd.LocalPort
+	ports := make([]int, 0, len(forwards))
+	for _, fwd := range forwards {
+		if fwd.LocalPort != 0 {
+			ports = append(ports, fwd.LocalPort)
+		}
 	}
 	return ports
 }
@@ -586,7 +1033,7 @@ func (m *Manager) EnableForward(id string) error {
 		return fmt.Errorf("no configuration available")
 	}
 
-	forwards := cfg.GetAllForwards()
+	forwards := m.configForwards(cfg)
 	var targetFwd *config.Forward
 	for _, fwd := range forwards {
 		if fwd.ID() == id {
@@ -1,7 +1,9 @@
 package forward
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -9,6 +11,7 @@ import (
 	"github.com/lukaszraczylo/kportal/internal/config"
 	"github.com/lukaszraczylo/kportal/internal/events"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestNewManager tests manager creation
@@ -107,6 +110,60 @@ func TestManager_Reload_NilConfig(t *testing.T) {
 	assert.Contains(t, err.Error(), "new configuration is nil")
 }
 
+// TestManager_Reload_UnknownProfile tests a reload that drops the -profile
+// is rejected
+func TestManager_Reload_UnknownProfile(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+	defer manager.Stop()
+	manager.SetProfile("frontend")
+
+	err = manager.Reload(&config.Config{Profiles: map[string][]string{"data": {"db:5432"}}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "frontend" is not defined`)
+}
+
+// TestManager_configForwards tests -profile and -tags both narrow the
+// forwards the manager runs
+func TestManager_configForwards(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+	defer manager.Stop()
+
+	cfg := &config.Config{
+		Profiles: map[string][]string{"frontend": {"web:3000", "tag:ui"}},
+		Contexts: []config.Context{{
+			Name: "dev",
+			Namespaces: []config.Namespace{{
+				Name: "default",
+				Forwards: []config.Forward{
+					{Resource: "service/web", Port: 80, LocalPort: 3000, Alias: "web"},
+					{Resource: "service/admin", Port: 80, LocalPort: 3001, Alias: "admin", Tags: []string{"ui", "internal"}},
+					{Resource: "service/db", Port: 5432, LocalPort: 5432, Alias: "db", Tags: []string{"internal"}},
+				},
+			}},
+		}},
+	}
+
+	ids := func() []string {
+		var ids []string
+		for _, fwd := range manager.configForwards(cfg) {
+			ids = append(ids, fwd.ID())
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"web:3000", "admin:3001", "db:5432"}, ids())
+	manager.SetProfile("frontend")
+	assert.Equal(t, []string{"web:3000", "admin:3001"}, ids())
+	manager.SetTags([]string{"internal"})
+	assert.Equal(t, []string{"admin:3001"}, ids())
+}
+
 // TestManager_EnableForward_NoConfig tests enabling without config
 func TestManager_EnableForward_NoConfig(t *testing.T) {
 	manager, err := NewManager(false)
@@ -281,6 +338,43 @@ func TestManager_Stop(t *testing.T) {
 	}
 }
 
+// TestManager_Shutdown tests that Shutdown returns once the manager has
+// stopped and that repeated calls are safe
+func TestManager_Shutdown(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, manager.Shutdown(ctx))
+	assert.NoError(t, manager.Shutdown(ctx))
+	manager.Stop()
+}
+
+// TestManager_Shutdown_ContextDone tests that Shutdown returns the context
+// error when workers take longer to stop than the deadline allows
+func TestManager_Shutdown_ContextDone(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+
+	// A worker that was never started only gives up on Stop after its
+	// timeout, so the shutdown outlasts the deadline
+	slow := buildForward("c", "n", "pod/slow", 20131, 80)
+	inject(manager, slow)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, manager.Shutdown(ctx), context.DeadlineExceeded)
+
+	// The shutdown carries on; a call without a deadline waits for it
+	assert.NoError(t, manager.Shutdown(context.Background()))
+	assert.Nil(t, manager.GetWorker(slow.ID()))
+}
+
 // TestManager_Reload_EmptyToEmpty tests reloading from empty to empty config
 func TestManager_Reload_EmptyToEmpty(t *testing.T) {
 	manager, err := NewManager(false)
@@ -389,3 +483,261 @@ func TestManager_Stop_WithManyWorkers(t *testing.T) {
 	manager.workersMu.RUnlock()
 	assert.Equal(t, 0, workerCount, "Workers map should be empty after Stop")
 }
+
+// TestManager_StartWorker_UDPReportsError verifies that a UDP forward is
+// accepted by the manager but reported as failed, since the Kubernetes
+// port-forward API cannot tunnel datagrams.
+func TestManager_StartWorker_UDPReportsError(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+	defer manager.Stop()
+
+	ui := &MockStatusUpdater{}
+	manager.SetStatusUI(ui)
+
+	fwd := config.Forward{
+		Resource:  "service/coredns",
+		Protocol:  config.ProtocolUDP,
+		Port:      53,
+		LocalPort: 20153,
+	}
+	fwd.SetContext("test-ctx", "kube-system")
+
+	assert.NoError(t, manager.startWorker(fwd))
+	assert.NotNil(t, manager.GetWorker(fwd.ID()), "UDP forward should still be tracked")
+
+	// Health checks dial TCP, so the forward must not be registered
+	_, registered := manager.healthChecker.GetStatus(fwd.ID())
+	assert.False(t, registered)
+
+	assert.Eventually(t, func() bool {
+		ui.mu.Lock()
+		defer ui.mu.Unlock()
+		var sawStatus, sawError bool
+		for _, u := range ui.updates {
+			if u.ID == fwd.ID() && u.Status == "Error" {
+				sawStatus = true
+			}
+		}
+		for _, e := range ui.errorSets {
+			if e.ID == fwd.ID() && strings.Contains(e.Msg, "UDP forwarding is not supported") {
+				sawError = true
+			}
+		}
+		return sawStatus && sawError
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// parseTestConfig parses a config from YAML as LoadConfig does, so its
+// forwards know their context and namespace and have their real IDs.
+func parseTestConfig(t *testing.T, yamlText string) *config.Config {
+	t.Helper()
+	cfg, err := config.ParseConfig([]byte(yamlText))
+	require.NoError(t, err)
+	return cfg
+}
+
+// TestManager_Start_DisabledForward verifies that forwards with
+// `enabled: false` are listed as Disabled but never started.
+func TestManager_Start_DisabledForward(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+	defer manager.Stop()
+
+	ui := &MockStatusUpdater{}
+	manager.SetStatusUI(ui)
+
+	cfg := parseTestConfig(t, `contexts:
+  - name: test-ctx
+    namespaces:
+      - name: default
+        forwards:
+          - resource: service/api
+            port: 8080
+            localPort: 20180
+            enabled: false
+`)
+
+	assert.NoError(t, manager.Start(cfg))
+
+	id := "test-ctx/default/service/api:20180"
+	assert.Nil(t, manager.GetWorker(id), "disabled forward must not be started")
+
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if assert.Len(t, ui.adds, 1) {
+		assert.Equal(t, id, ui.adds[0].ID)
+	}
+	assert.Contains(t, ui.updates, StatusUpdate{ID: id, Status: "Disabled"})
+}
+
+// TestManager_Reload_DisablesForward verifies that flipping a running
+// forward to `enabled: false` stops it while keeping it listed.
+func TestManager_Reload_DisablesForward(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+	defer manager.Stop()
+
+	ui := &MockStatusUpdater{}
+	manager.SetStatusUI(ui)
+
+	// UDP forwards fail fast without contacting the cluster
+	newCfg := func(enabled bool) *config.Config {
+		return parseTestConfig(t, fmt.Sprintf(`contexts:
+  - name: test-ctx
+    namespaces:
+      - name: kube-system
+        forwards:
+          - resource: service/coredns
+            protocol: udp
+            port: 53
+            localPort: 20153
+            enabled: %t
+`, enabled))
+	}
+
+	assert.NoError(t, manager.Start(newCfg(true)))
+	id := "test-ctx/kube-system/service/coredns:20153"
+	assert.NotNil(t, manager.GetWorker(id))
+
+	assert.NoError(t, manager.Reload(newCfg(false)))
+	assert.Nil(t, manager.GetWorker(id))
+
+	ui.mu.Lock()
+	assert.NotContains(t, ui.removes, id, "disabled forward should stay listed")
+	assert.Contains(t, ui.updates, StatusUpdate{ID: id, Status: "Disabled"})
+	ui.mu.Unlock()
+
+	// Removing it from config drops it from the UI
+	assert.NoError(t, manager.Reload(&config.Config{}))
+	ui.mu.Lock()
+	assert.Contains(t, ui.removes, id)
+	ui.mu.Unlock()
+}
+
+// TestManager_AutoLocalPort verifies that localPort: 0 is resolved to a free
+// port at start and that the same port is kept across a Reload.
+func TestManager_AutoLocalPort(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+	defer manager.Stop()
+
+	ui := &MockStatusUpdater{}
+	manager.SetStatusUI(ui)
+
+	// No localPort, so one is assigned at start
+	cfg := parseTestConfig(t, `contexts:
+  - name: test-ctx
+    namespaces:
+      - name: kube-system
+        forwards:
+          - resource: service/coredns
+            protocol: udp
+            port: 53
+`)
+
+	assert.NoError(t, manager.Start(cfg))
+
+	id := "test-ctx/kube-system/service/coredns:auto-53"
+	worker := manager.GetWorker(id)
+	if !assert.NotNil(t, worker, "worker should be keyed by the configured ID") {
+		return
+	}
+	assigned := worker.GetForward().LocalPort
+	assert.NotZero(t, assigned)
+
+	ui.mu.Lock()
+	if assert.Len(t, ui.adds, 1) {
+		assert.Equal(t, assigned, ui.adds[0].Fwd.LocalPort, "UI should see the real port")
+	}
+	ui.mu.Unlock()
+
+	// Unchanged forward keeps its worker and port
+	assert.NoError(t, manager.Reload(cfg))
+	assert.Same(t, worker, manager.GetWorker(id))
+
+	// Disable/enable reuses the assignment
+	assert.NoError(t, manager.DisableForward(id))
+	assert.NoError(t, manager.EnableForward(id))
+	if w := manager.GetWorker(id); assert.NotNil(t, w) {
+		assert.Equal(t, assigned, w.GetForward().LocalPort)
+	}
+}
+
+// TestManager_HTTPLogDefault verifies that the top-level httpLog setting
+// turns on logging for forwards without their own, and that the UI still
+// sees the forward as configured.
+func TestManager_HTTPLogDefault(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+	defer manager.Stop()
+
+	ui := &MockStatusUpdater{}
+	manager.SetStatusUI(ui)
+
+	cfg := parseTestConfig(t, `httpLog: true
+contexts:
+  - name: test-ctx
+    namespaces:
+      - name: kube-system
+        forwards:
+          - resource: service/coredns
+            protocol: udp
+            port: 53
+            localPort: 20153
+          - resource: service/dns
+            protocol: udp
+            port: 53
+            localPort: 20154
+            httpLog:
+              enabled: false
+`)
+
+	assert.NoError(t, manager.Start(cfg))
+
+	if w := manager.GetWorker("test-ctx/kube-system/service/coredns:20153"); assert.NotNil(t, w) {
+		fwd := w.GetForward()
+		assert.True(t, fwd.IsHTTPLogEnabled(), "forwards without httpLog follow the default")
+	}
+	if w := manager.GetWorker("test-ctx/kube-system/service/dns:20154"); assert.NotNil(t, w) {
+		fwd := w.GetForward()
+		assert.False(t, fwd.IsHTTPLogEnabled(), "httpLog: false opts out")
+	}
+
+	ui.mu.Lock()
+	for _, add := range ui.adds {
+		if add.ID == "test-ctx/kube-system/service/coredns:20153" {
+			assert.Nil(t, add.Fwd.HTTPLog, "the UI keeps the forward as configured")
+		}
+	}
+	ui.mu.Unlock()
+}
+
+func TestManager_SetHTTPLogDefault(t *testing.T) {
+	manager, err := NewManager(false)
+	if err != nil {
+		t.Skip("Skipping test - no kubeconfig available")
+	}
+	defer manager.Stop()
+	manager.SetStatusUI(&MockStatusUpdater{})
+	manager.SetHTTPLogDefault(true)
+
+	fwd := config.Forward{Resource: "service/coredns", Protocol: config.ProtocolUDP, Port: 53, LocalPort: 20153}
+	fwd.SetContext("test-ctx", "kube-system")
+	assert.NoError(t, manager.startWorker(fwd))
+
+	if w := manager.GetWorker(fwd.ID()); assert.NotNil(t, w) {
+		forward := w.GetForward()
+		assert.True(t, forward.IsHTTPLogEnabled())
+	}
+}
@@ -0,0 +1,148 @@
+package forward
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/logger"
+)
+
+const (
+	// reconnectReasonNetworkChange is the TriggerReconnect reason used when
+	// the network watch sees the host's network change
+	reconnectReasonNetworkChange = "network change"
+
+	// networkWatchSleepFactor is how many poll intervals the wall clock may
+	// advance between two polls before the host is assumed to have slept.
+	networkWatchSleepFactor = 3
+)
+
+// networkWatcher polls the host's network interfaces and calls onChange when
+// their addresses change, e.g. on a Wi-Fi switch or a VPN coming up, or when
+// the host resumes from sleep. A tunnel opened over the old network usually
+// hangs instead of failing, so the manager reconnects forwards proactively.
+// Polling keeps it portable: there is no cross-platform change notification.
+type networkWatcher struct {
+	ctx       context.Context
+	lastCheck time.Time // wall clock of the previous poll
+	snapshot  func() (string, error)
+	onChange  func(reason string)
+	cancel    context.CancelFunc
+	last      string // interface snapshot of the previous poll
+	wg        sync.WaitGroup
+	interval  time.Duration
+}
+
+// newNetworkWatcher creates a watcher polling every interval.
+func newNetworkWatcher(interval time.Duration, onChange func(reason string)) *networkWatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &networkWatcher{
+		ctx:      ctx,
+		cancel:   cancel,
+		snapshot: interfaceSnapshot,
+		onChange: onChange,
+		interval: interval,
+	}
+}
+
+// Start takes the baseline snapshot and begins polling.
+func (n *networkWatcher) Start() {
+	n.check(wallClock())
+
+	n.wg.Add(1)
+	go n.pollLoop()
+}
+
+// Stop stops polling and waits for the loop to exit.
+func (n *networkWatcher) Stop() {
+	n.cancel()
+	n.wg.Wait()
+}
+
+func (n *networkWatcher) pollLoop() {
+	defer n.wg.Done()
+
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-ticker.C:
+			if reason, changed := n.check(wallClock()); changed {
+				n.onChange(reason)
+			}
+		}
+	}
+}
+
+// check polls the interfaces at now and reports whether the network changed
+// since the previous poll, and why. The first poll only records a baseline.
+// A failed snapshot is skipped, keeping the previous one.
+func (n *networkWatcher) check(now time.Time) (string, bool) {
+	snap, err := n.snapshot()
+	if err != nil {
+		logger.Debug("Network watch could not list interfaces", map[string]any{
+			"error": err.Error(),
+		})
+		return "", false
+	}
+
+	first := n.lastCheck.IsZero()
+	gap := now.Sub(n.lastCheck)
+	previous := n.last
+	n.last, n.lastCheck = snap, now
+
+	switch {
+	case first:
+		return "", false
+	case snap != previous:
+		return "network interfaces changed", true
+	case gap > networkWatchSleepFactor*n.interval:
+		// Timers stop while the host sleeps, so a long gap on the wall
+		// clock means it just resumed, likely on another network
+		return fmt.Sprintf("resumed after %v", gap.Round(time.Second)), true
+	}
+	return "", false
+}
+
+// wallClock returns the current time without its monotonic reading, which
+// does not advance while the host sleeps on every platform.
+func wallClock() time.Time {
+	return time.Now().Round(0)
+}
+
+// interfaceSnapshot describes the addresses of the host's up, non-loopback
+// interfaces as a stable string, so two snapshots compare equal exactly when
+// the network looks the same.
+func interfaceSnapshot() (string, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, len(ifaces))
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return "", err
+		}
+		names := make([]string, 0, len(addrs))
+		for _, addr := range addrs {
+			names = append(names, addr.String())
+		}
+		slices.Sort(names)
+		lines = append(lines, iface.Name+"="+strings.Join(names, ","))
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, ";"), nil
+}
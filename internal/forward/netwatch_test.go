@@ -0,0 +1,75 @@
+package forward
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkWatcher_Check(t *testing.T) {
+	snap := "en0=192.168.1.10/24"
+	var snapErr error
+	n := newNetworkWatcher(5*time.Second, nil)
+	n.snapshot = func() (string, error) { return snap, snapErr }
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	_, changed := n.check(now)
+	assert.False(t, changed, "the first poll is the baseline")
+
+	now = now.Add(5 * time.Second)
+	_, changed = n.check(now)
+	assert.False(t, changed)
+
+	snap = "en0=10.0.0.7/24"
+	now = now.Add(5 * time.Second)
+	reason, changed := n.check(now)
+	assert.True(t, changed)
+	assert.Equal(t, "network interfaces changed", reason)
+
+	snapErr = errors.New("boom")
+	now = now.Add(5 * time.Second)
+	_, changed = n.check(now)
+	assert.False(t, changed, "a failed snapshot is skipped")
+
+	snapErr = nil
+	now = now.Add(5 * time.Second)
+	_, changed = n.check(now)
+	assert.False(t, changed, "the previous snapshot is kept across a failure")
+
+	now = now.Add(10 * time.Minute)
+	reason, changed = n.check(now)
+	assert.True(t, changed, "a wall clock jump means the host slept")
+	assert.Equal(t, "resumed after 10m0s", reason)
+}
+
+func TestNetworkWatcher_StartStop(t *testing.T) {
+	changes := make(chan string, 10)
+	snaps := []string{"a", "a", "b"}
+	n := newNetworkWatcher(10*time.Millisecond, func(reason string) { changes <- reason })
+	n.snapshot = func() (string, error) {
+		s := snaps[0]
+		if len(snaps) > 1 {
+			snaps = snaps[1:]
+		}
+		return s, nil
+	}
+
+	n.Start()
+	select {
+	case reason := <-changes:
+		assert.Equal(t, "network interfaces changed", reason)
+	case <-time.After(time.Second):
+		t.Fatal("expected a network change")
+	}
+	n.Stop()
+}
+
+func TestInterfaceSnapshot(t *testing.T) {
+	first, err := interfaceSnapshot()
+	assert.NoError(t, err)
+	second, err := interfaceSnapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, first, second, "an unchanged network gives the same snapshot")
+}
@@ -0,0 +1,62 @@
+package forward
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/logger"
+)
+
+// prewarmTimeout bounds how long Start waits for the resolver cache to be
+// warmed before starting the workers.
+const prewarmTimeout = 10 * time.Second
+
+// prewarm resolves the target of every forward so the resolver cache holds
+// its pod before the workers start, making the first connect and early
+// reconnects skip the lookup. Services need no lookup and UDP forwards never
+// connect, so both are skipped. Failures are logged; the worker resolves
+// again when it starts. Lookups run concurrently within the client pool's
+// per-context limit.
+func (m *Manager) prewarm(forwards []config.Forward) {
+	ctx, cancel := context.WithTimeout(context.Background(), prewarmTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var attempted, resolved atomic.Int32
+	for _, fwd := range forwards {
+		if strings.HasPrefix(fwd.Resource, "service/") || fwd.GetProtocol() == config.ProtocolUDP {
+			continue
+		}
+		attempted.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pod, err := m.resolver.Resolve(ctx, fwd.GetContext(), fwd.GetNamespace(), fwd.Resource, fwd.Selector)
+			if err != nil {
+				logger.Warn("Failed to pre-resolve forward", map[string]interface{}{
+					"forward_id": fwd.ID(),
+					"resource":   fwd.Resource,
+					"error":      err.Error(),
+				})
+				return
+			}
+			resolved.Add(1)
+			logger.Debug("Pre-resolved forward", map[string]interface{}{
+				"forward_id": fwd.ID(),
+				"target":     pod,
+			})
+		}()
+	}
+	wg.Wait()
+
+	if attempted.Load() > 0 {
+		logger.Info("Resolver cache warmed", map[string]interface{}{
+			"resolved": resolved.Load(),
+			"attempts": attempted.Load(),
+		})
+	}
+}
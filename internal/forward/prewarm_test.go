@@ -0,0 +1,47 @@
+package forward
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// TestManager_Prewarm_FailuresDoNotBlock tests that forwards whose target
+// cannot be resolved are logged and skipped rather than failing prewarm
+func TestManager_Prewarm_FailuresDoNotBlock(t *testing.T) {
+	m := newCovManager(t)
+
+	udp := buildForward("missing-ctx", "default", "pod/dns", 20141, 53)
+	udp.Protocol = config.ProtocolUDP
+	forwards := []config.Forward{
+		buildForward("missing-ctx", "default", "pod/app", 20142, 80),
+		buildForward("missing-ctx", "default", "deployment/api", 20143, 80),
+		buildForward("missing-ctx", "default", "service/api", 20144, 80),
+		udp,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.prewarm(forwards)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(prewarmTimeout + 5*time.Second):
+		t.Fatal("prewarm did not return")
+	}
+}
+
+// TestManager_Prewarm_NoForwards tests prewarm with nothing to resolve
+func TestManager_Prewarm_NoForwards(t *testing.T) {
+	m := newCovManager(t)
+
+	assert.NotPanics(t, func() {
+		m.prewarm(nil)
+		m.prewarm([]config.Forward{buildForward("c", "n", "service/api", 20145, 80)})
+	})
+}
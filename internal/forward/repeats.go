@@ -0,0 +1,53 @@
+package forward
+
+import (
+	"fmt"
+	"time"
+)
+
+// repeatedErrorLogInterval is how often an error that keeps repeating is
+// logged again, with its count, while a forward fails the same way.
+const repeatedErrorLogInterval = 30 * time.Second
+
+// errorRepeats coalesces identical consecutive errors of a forward, so a
+// crash-looping target shows "connection refused (x42 in last 30s)" instead
+// of flooding the errors section and log file. Only the worker goroutine
+// uses it, so it is not locked.
+type errorRepeats struct {
+	since  time.Time // first occurrence of msg in the current run
+	logged time.Time // when msg was last logged
+	msg    string
+	count  int
+}
+
+// record counts an occurrence of msg and reports whether it should be
+// logged: always when it differs from the previous error, otherwise at most
+// once per repeatedErrorLogInterval.
+func (r *errorRepeats) record(msg string, now time.Time) bool {
+	if msg != r.msg || r.count == 0 {
+		*r = errorRepeats{msg: msg, count: 1, since: now, logged: now}
+		return true
+	}
+
+	r.count++
+	if now.Sub(r.logged) < repeatedErrorLogInterval {
+		return false
+	}
+	r.logged = now
+	return true
+}
+
+// annotate appends the repeat count to text once the current error has
+// repeated, e.g. "connection refused (x42 in last 30s)".
+func (r *errorRepeats) annotate(text string, now time.Time) string {
+	if r.count < 2 {
+		return text
+	}
+	window := max(now.Sub(r.since).Round(time.Second), time.Second)
+	return fmt.Sprintf("%s (x%d in last %v)", text, r.count, window)
+}
+
+// reset forgets the current error, e.g. once the forward has recovered.
+func (r *errorRepeats) reset() {
+	*r = errorRepeats{}
+}
@@ -0,0 +1,60 @@
+package forward
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorRepeats(t *testing.T) {
+	var r errorRepeats
+	start := time.Now()
+
+	assert.True(t, r.record("connection refused", start), "the first occurrence is logged")
+	assert.Equal(t, "connection refused", r.annotate("connection refused", start))
+
+	for i := 1; i < 42; i++ {
+		assert.False(t, r.record("connection refused", start.Add(time.Duration(i)*500*time.Millisecond)))
+	}
+	now := start.Add(30 * time.Second)
+	assert.True(t, r.record("connection refused", now), "repeats are logged again after the interval")
+	assert.Equal(t, "connection refused (x43 in last 30s)", r.annotate("connection refused", now))
+
+	// A different error starts a new count
+	assert.True(t, r.record("pod not found", now.Add(time.Second)))
+	assert.Equal(t, "pod not found", r.annotate("pod not found", now.Add(time.Second)))
+	assert.False(t, r.record("pod not found", now.Add(1200*time.Millisecond)))
+	assert.Equal(t, "pod not found (x2 in last 1s)", r.annotate("pod not found", now.Add(1200*time.Millisecond)))
+
+	// As does recovering
+	r.reset()
+	assert.True(t, r.record("pod not found", now.Add(2*time.Second)))
+	assert.Equal(t, "pod not found", r.annotate("pod not found", now.Add(2*time.Second)))
+}
+
+func TestSleepWithBackoff_CoalescesRepeatedErrors(t *testing.T) {
+	fwd := config.Forward{Resource: "pod/app", Port: 80, LocalPort: 8080}
+	status := &MockStatusUpdater{}
+	worker := NewForwardWorker(fwd, nil, false, status, nil, nil)
+	backoff := retry.NewBackoffWithOptions(retry.Options{
+		InitialDelay: time.Millisecond,
+		MaxDelay:     time.Millisecond,
+	})
+	cause := errors.New("connection refused")
+
+	for range 3 {
+		_, _ = worker.repeatedError(cause)
+		worker.sleepWithBackoff(backoff, cause)
+	}
+
+	status.mu.Lock()
+	defer status.mu.Unlock()
+	require.Len(t, status.errorSets, 3)
+	assert.Equal(t, "connection refused", status.errorSets[0].Msg)
+	assert.Equal(t, "connection refused (x3 in last 1s)", status.errorSets[2].Msg)
+}
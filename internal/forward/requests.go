@@ -0,0 +1,71 @@
+package forward
+
+import (
+	"sync"
+	"time"
+)
+
+// requestHistorySeconds is how many seconds of HTTP request counts a
+// forward keeps for the UI's activity sparkline.
+const requestHistorySeconds = 30
+
+// requestCounter counts a forward's proxied HTTP requests per second over
+// the last requestHistorySeconds. Counting is the extra bookkeeping of the
+// activity column, so requests are only counted while the history is being
+// read: once it has not been read for requestHistorySeconds, observe does
+// nothing until the next read.
+type requestCounter struct {
+	buckets [requestHistorySeconds]int64 // ring of per-second counts, indexed by unix second
+	newest  int64                        // unix second of the newest bucket
+	watched int64                        // unix second history was last read
+	mu      sync.Mutex
+}
+
+// observe counts one request at now.
+func (c *requestCounter) observe(now time.Time) {
+	sec := now.Unix()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if sec-c.watched > requestHistorySeconds || sec <= c.newest-requestHistorySeconds {
+		return
+	}
+	c.advance(sec)
+	c.buckets[sec%requestHistorySeconds]++
+}
+
+// history returns the request counts of the last requestHistorySeconds,
+// oldest first and ending with the current second, and keeps requests
+// counted for another requestHistorySeconds.
+func (c *requestCounter) history(now time.Time) []int64 {
+	sec := now.Unix()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.watched = sec
+	c.advance(sec)
+	counts := make([]int64, requestHistorySeconds)
+	for i := range counts {
+		s := sec - requestHistorySeconds + 1 + int64(i)
+		counts[i] = c.buckets[s%requestHistorySeconds]
+	}
+	return counts
+}
+
+// advance clears the buckets of the seconds between the newest bucket and
+// sec, so they read as no requests. Callers must hold c.mu.
+func (c *requestCounter) advance(sec int64) {
+	if sec <= c.newest {
+		return
+	}
+	if sec-c.newest >= requestHistorySeconds {
+		c.buckets = [requestHistorySeconds]int64{}
+	} else {
+		for s := c.newest + 1; s <= sec; s++ {
+			c.buckets[s%requestHistorySeconds] = 0
+		}
+	}
+	c.newest = sec
+}
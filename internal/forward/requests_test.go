@@ -0,0 +1,41 @@
+package forward
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestCounter(t *testing.T) {
+	var c requestCounter
+	start := time.Unix(1_000_000, 0)
+
+	c.observe(start)
+	assert.Equal(t, make([]int64, requestHistorySeconds), c.history(start), "requests are not counted until the history is read")
+
+	c.observe(start)
+	c.observe(start)
+	c.observe(start.Add(2 * time.Second))
+
+	counts := c.history(start.Add(2 * time.Second))
+	assert.Len(t, counts, requestHistorySeconds)
+	assert.Equal(t, []int64{2, 0, 1}, counts[requestHistorySeconds-3:], "oldest first, ending with the current second")
+
+	counts = c.history(start.Add(requestHistorySeconds * time.Second))
+	assert.Equal(t, int64(1), counts[1], "counts move back as time passes")
+	assert.Zero(t, counts[0])
+
+	counts = c.history(start.Add(time.Hour))
+	assert.Equal(t, make([]int64, requestHistorySeconds), counts, "stale seconds are cleared")
+}
+
+func TestRequestCounter_StopsWhenUnwatched(t *testing.T) {
+	var c requestCounter
+	start := time.Unix(1_000_000, 0)
+	c.history(start)
+
+	later := start.Add((requestHistorySeconds + 1) * time.Second)
+	c.observe(later)
+	assert.Equal(t, make([]int64, requestHistorySeconds), c.history(later))
+}
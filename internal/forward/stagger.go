@@ -0,0 +1,23 @@
+package forward
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// startupStaggerJitter is the fraction of reliability.startupStagger each
+// staggered start is moved earlier or later by, so starts do not line up on
+// exact multiples of it.
+const startupStaggerJitter = 0.2
+
+// staggerDelay returns how long the i-th of a batch of forwards started
+// together waits before its first connect: i times stagger, moved by up to
+// startupStaggerJitter of stagger either way. The first forward, and every
+// forward when stagger is 0, starts at once.
+func staggerDelay(i int, stagger time.Duration) time.Duration {
+	if i == 0 || stagger <= 0 {
+		return 0
+	}
+	jitter := (rand.Float64()*2 - 1) * startupStaggerJitter * float64(stagger)
+	return time.Duration(i)*stagger + time.Duration(jitter)
+}
@@ -0,0 +1,19 @@
+package forward
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaggerDelay(t *testing.T) {
+	assert.Zero(t, staggerDelay(0, 50*time.Millisecond), "the first forward starts at once")
+	assert.Zero(t, staggerDelay(5, 0), "no stagger starts every forward at once")
+
+	for range 100 {
+		d := staggerDelay(3, 100*time.Millisecond)
+		assert.GreaterOrEqual(t, d, 280*time.Millisecond)
+		assert.LessOrEqual(t, d, 320*time.Millisecond)
+	}
+}
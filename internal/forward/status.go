@@ -0,0 +1,317 @@
+package forward
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/healthcheck"
+	"github.com/lukaszraczylo/kportal/internal/notify"
+)
+
+// ForwardState is a point-in-time snapshot of a forward, as returned by
+// Manager.Status and Manager.StatusAll.
+type ForwardState struct {
+	ConnectedSince time.Time    // When the current tunnel came up; zero while not connected
+	LastErrorAt    time.Time    // When LastError was reported; zero if never
+	ID             string       // Forward ID, as in config.Forward.ID
+	Context        string       // Kubernetes context
+	Namespace      string       // Kubernetes namespace
+	Resource       string       // Target resource, e.g. "service/api"
+	Alias          string       // Alias from config, if any
+	Status         string       // Status as shown in the TUI, e.g. "Active" or "Disabled"
+	Pod            string       // Pod the forward last resolved to; empty before the first attempt
+	LastError      string       // Most recent error message, kept after recovery
+	Traffic        TrafficStats // Traffic counters; zero for disabled forwards
+	LocalPort      int          // Local port, including auto-assigned ones
+	RemotePort     int          // Port on the pod or service
+	Reconnects     int          // Times the forward has gone into Reconnecting
+}
+
+// stateTracker records the status updates sent by the manager and its
+// workers so they can be queried, and passes each one on to next.
+type stateTracker struct {
+	next      StatusUpdater // optional, the TUI or another listener
+	states    map[string]*ForwardState
+	activated map[string]bool      // forwards that have been Active at least once
+	lastUp    map[string]time.Time // when each forward was last seen connected
+	notifiers []Notifier           // told about status changes
+	changed   chan struct{}        // closed and replaced on every status change
+	mu        sync.RWMutex
+}
+
+func newStateTracker() *stateTracker {
+	return &stateTracker{
+		states:    make(map[string]*ForwardState),
+		activated: make(map[string]bool),
+		lastUp:    make(map[string]time.Time),
+		changed:   make(chan struct{}),
+	}
+}
+
+// notify wakes the readiness waiters. Caller must hold mu.
+func (t *stateTracker) notify() {
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// setNext sets the updater that receives the updates after they are recorded
+func (t *stateTracker) setNext(next StatusUpdater) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.next = next
+}
+
+// addNotifier adds a notifier told about status changes
+func (t *stateTracker) addNotifier(notifier Notifier) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.notifiers = append(t.notifiers, notifier)
+}
+
+// listener returns next, read under the lock so setNext may race with updates
+func (t *stateTracker) listener() StatusUpdater {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.next
+}
+
+func (t *stateTracker) AddForward(id string, fwd *config.Forward) {
+	t.mu.Lock()
+	state, exists := t.states[id]
+	if !exists {
+		state = &ForwardState{ID: id}
+		t.states[id] = state
+	}
+	state.Context = fwd.GetContext()
+	state.Namespace = fwd.GetNamespace()
+	state.Resource = fwd.Resource
+	state.Alias = fwd.Alias
+	state.LocalPort = fwd.LocalPort
+	state.RemotePort = fwd.Port
+	t.mu.Unlock()
+
+	if next := t.listener(); next != nil {
+		next.AddForward(id, fwd)
+	}
+}
+
+func (t *stateTracker) UpdateStatus(id string, status string) {
+	var notifiers []Notifier
+	var change notify.Change
+
+	t.mu.Lock()
+	if state, exists := t.states[id]; exists {
+		if len(t.notifiers) > 0 && statusKind(state.Status) != statusKind(status) {
+			notifiers = t.notifiers
+			change = t.change(state, status)
+		}
+		if isReconnecting(status) && !isReconnecting(state.Status) {
+			state.Reconnects++
+		}
+		if isConnected(status) {
+			if state.ConnectedSince.IsZero() {
+				state.ConnectedSince = time.Now()
+			}
+			t.lastUp[id] = time.Now()
+		} else {
+			state.ConnectedSince = time.Time{}
+		}
+		state.Status = status
+		if status == string(healthcheck.StatusHealthy) {
+			t.activated[id] = true
+		}
+		t.notify()
+	}
+	t.mu.Unlock()
+
+	for _, notifier := range notifiers {
+		notifier.StatusChanged(change)
+	}
+	if next := t.listener(); next != nil {
+		next.UpdateStatus(id, status)
+	}
+}
+
+// change describes state moving to status for the notifiers. The error is
+// the latest one reported since the forward was last connected, so an
+// error it recovered from is not repeated. Caller must hold mu.
+func (t *stateTracker) change(state *ForwardState, status string) notify.Change {
+	name := state.Alias
+	if name == "" {
+		name = state.Resource
+	}
+	change := notify.Change{
+		Time: time.Now(),
+		ID:   state.ID,
+		Name: fmt.Sprintf("%s (:%d)", name, state.LocalPort),
+		From: state.Status,
+		To:   status,
+	}
+	if !isConnected(status) && state.LastErrorAt.After(t.lastUp[state.ID]) {
+		change.Error = state.LastError
+	}
+	return change
+}
+
+func (t *stateTracker) Remove(id string) {
+	t.mu.Lock()
+	delete(t.states, id)
+	delete(t.activated, id)
+	delete(t.lastUp, id)
+	t.notify()
+	notifiers := t.notifiers
+	t.mu.Unlock()
+
+	for _, notifier := range notifiers {
+		notifier.Removed(id)
+	}
+
+	if next := t.listener(); next != nil {
+		next.Remove(id)
+	}
+}
+
+func (t *stateTracker) SetError(id, msg string) {
+	t.mu.Lock()
+	if state, exists := t.states[id]; exists && msg != "" {
+		state.LastError = msg
+		state.LastErrorAt = time.Now()
+	}
+	t.mu.Unlock()
+
+	if ui, ok := t.listener().(interface{ SetError(id, msg string) }); ok {
+		ui.SetError(id, msg)
+	}
+}
+
+func (t *stateTracker) SetPod(id, pod string) {
+	t.mu.Lock()
+	if state, exists := t.states[id]; exists {
+		state.Pod = pod
+	}
+	t.mu.Unlock()
+
+	if ui, ok := t.listener().(interface{ SetPod(id, pod string) }); ok {
+		ui.SetPod(id, pod)
+	}
+}
+
+// get returns a copy of the state of id
+func (t *stateTracker) get(id string) (ForwardState, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	state, exists := t.states[id]
+	if !exists {
+		return ForwardState{}, false
+	}
+	return *state, true
+}
+
+// all returns a copy of every state, sorted by ID
+func (t *stateTracker) all() []ForwardState {
+	t.mu.RLock()
+	states := make([]ForwardState, 0, len(t.states))
+	for _, state := range t.states {
+		states = append(states, *state)
+	}
+	t.mu.RUnlock()
+
+	slices.SortFunc(states, func(a, b ForwardState) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return states
+}
+
+// readiness counts the enabled forwards and those of them that have been
+// Active at least once, and returns the channel closed on the next change.
+func (t *stateTracker) readiness() (active, total int, changed <-chan struct{}) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for id, state := range t.states {
+		if state.Status == "Disabled" {
+			continue
+		}
+		total++
+		if t.activated[id] {
+			active++
+		}
+	}
+	return active, total, t.changed
+}
+
+// statusKind strips the detail from a status, so "Reconnecting (4s,
+// attempt 3)" is "Reconnecting"
+func statusKind(status string) string {
+	kind, _, _ := strings.Cut(status, " (")
+	return kind
+}
+
+// isReconnecting matches "Reconnecting" and the worker's
+// "Reconnecting (4s, attempt 3)" form
+func isReconnecting(status string) bool {
+	return strings.HasPrefix(status, string(healthcheck.StatusReconnect))
+}
+
+// isConnected reports whether the tunnel is up in the given status. Stale
+// and probe-failed forwards still have a tunnel.
+func isConnected(status string) bool {
+	switch healthcheck.Status(status) {
+	case healthcheck.StatusHealthy, healthcheck.StatusStale, healthcheck.StatusProbeFailed:
+		return true
+	}
+	return false
+}
+
+// Status returns the state of a forward, running or disabled. It returns
+// an error if the manager does not know the forward.
+func (m *Manager) Status(id string) (ForwardState, error) {
+	if m.states == nil {
+		return ForwardState{}, fmt.Errorf("forward not found: %s", id)
+	}
+	state, exists := m.states.get(id)
+	if !exists {
+		return ForwardState{}, fmt.Errorf("forward not found: %s", id)
+	}
+	state.Traffic, _ = m.TrafficStats(id)
+	return state, nil
+}
+
+// StatusAll returns the state of every forward the manager knows, sorted
+// by ID.
+func (m *Manager) StatusAll() []ForwardState {
+	if m.states == nil {
+		return nil
+	}
+	states := m.states.all()
+	for i := range states {
+		states[i].Traffic, _ = m.TrafficStats(states[i].ID)
+	}
+	return states
+}
+
+// WaitReady blocks until every enabled forward has been Active at least
+// once, or ctx is done. It returns how many enabled forwards have been
+// Active and how many there are, with ctx.Err() when ctx ended first.
+// Forwards added or removed by a reload while waiting are counted. Call it
+// after Start.
+func (m *Manager) WaitReady(ctx context.Context) (active, total int, err error) {
+	if m.states == nil {
+		return 0, 0, nil
+	}
+	for {
+		active, total, changed := m.states.readiness()
+		if active == total {
+			return active, total, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return active, total, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package forward
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/notify"
+)
+
+func TestStateTracker_RecordsAndForwards(t *testing.T) {
+	ui := &MockStatusUpdater{}
+	tracker := newStateTracker()
+	tracker.setNext(ui)
+
+	fwd := config.Forward{Resource: "service/api", Alias: "api", Port: 8080, LocalPort: 18080}
+	fwd.SetContext("dev", "default")
+	id := fwd.ID()
+
+	tracker.AddForward(id, &fwd)
+	tracker.SetPod(id, "api-0")
+	tracker.UpdateStatus(id, "Active")
+
+	state, ok := tracker.get(id)
+	require.True(t, ok)
+	assert.Equal(t, "dev", state.Context)
+	assert.Equal(t, "default", state.Namespace)
+	assert.Equal(t, "service/api", state.Resource)
+	assert.Equal(t, "api", state.Alias)
+	assert.Equal(t, 18080, state.LocalPort)
+	assert.Equal(t, 8080, state.RemotePort)
+	assert.Equal(t, "api-0", state.Pod)
+	assert.Equal(t, "Active", state.Status)
+	assert.False(t, state.ConnectedSince.IsZero())
+
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	require.Len(t, ui.adds, 1)
+	require.Len(t, ui.updates, 1)
+	assert.Equal(t, "Active", ui.updates[0].Status)
+}
+
+func TestStateTracker_ReconnectsAndErrors(t *testing.T) {
+	tracker := newStateTracker()
+	fwd := config.Forward{Resource: "pod/db", Port: 5432, LocalPort: 15432}
+	fwd.SetContext("dev", "default")
+	id := fwd.ID()
+	tracker.AddForward(id, &fwd)
+
+	tracker.UpdateStatus(id, "Active")
+	connected, _ := tracker.get(id)
+
+	// The health checker and the worker both report the same reconnect
+	tracker.UpdateStatus(id, "Reconnecting")
+	tracker.UpdateStatus(id, "Reconnecting (1s, attempt 1)")
+	tracker.SetError(id, "connection refused")
+
+	state, _ := tracker.get(id)
+	assert.Equal(t, 1, state.Reconnects)
+	assert.True(t, state.ConnectedSince.IsZero())
+	assert.Equal(t, "connection refused", state.LastError)
+	assert.False(t, state.LastErrorAt.IsZero())
+
+	tracker.UpdateStatus(id, "Active")
+	tracker.UpdateStatus(id, "Stale")
+	tracker.UpdateStatus(id, "Reconnecting")
+
+	state, _ = tracker.get(id)
+	assert.Equal(t, 2, state.Reconnects)
+	assert.Equal(t, "connection refused", state.LastError, "last error is kept after recovery")
+	assert.False(t, connected.ConnectedSince.IsZero())
+}
+
+func TestStateTracker_UnknownAndRemove(t *testing.T) {
+	tracker := newStateTracker()
+
+	// Updates for forwards that were never added are passed on but not recorded
+	tracker.UpdateStatus("missing", "Active")
+	_, ok := tracker.get("missing")
+	assert.False(t, ok)
+
+	fwd := config.Forward{Resource: "pod/a", Port: 80, LocalPort: 18081}
+	fwd.SetContext("dev", "default")
+	tracker.AddForward(fwd.ID(), &fwd)
+	tracker.Remove(fwd.ID())
+	_, ok = tracker.get(fwd.ID())
+	assert.False(t, ok)
+}
+
+// recordingNotifier records the calls a Notifier gets
+type recordingNotifier struct {
+	changes []notify.Change
+	removed []string
+}
+
+func (r *recordingNotifier) StatusChanged(change notify.Change) {
+	r.changes = append(r.changes, change)
+}
+
+func (r *recordingNotifier) Removed(id string) {
+	r.removed = append(r.removed, id)
+}
+
+func TestStateTracker_Notifiers(t *testing.T) {
+	first, second := &recordingNotifier{}, &recordingNotifier{}
+	tracker := newStateTracker()
+	tracker.addNotifier(first)
+	tracker.addNotifier(second)
+
+	fwd := config.Forward{Resource: "service/api", Alias: "api", Port: 80, LocalPort: 8080}
+	fwd.SetContext("dev", "default")
+	id := fwd.ID()
+	tracker.AddForward(id, &fwd)
+
+	tracker.SetError(id, "an error from before the forward came up")
+	tracker.UpdateStatus(id, "Active")
+	tracker.UpdateStatus(id, "Active")
+	tracker.UpdateStatus(id, "Reconnecting")
+	tracker.SetError(id, "connection refused")
+	tracker.UpdateStatus(id, "Reconnecting (1s, attempt 1)")
+	tracker.UpdateStatus(id, "Error")
+	tracker.UpdateStatus("missing", "Error")
+	tracker.Remove(id)
+
+	require.Len(t, first.changes, 3, "only changes of kind for a known forward are notified")
+	assert.Equal(t, "api (:8080)", first.changes[0].Name)
+	assert.Equal(t, id, first.changes[0].ID)
+	assert.Empty(t, first.changes[0].From)
+	assert.Equal(t, "Active", first.changes[0].To)
+	assert.Empty(t, first.changes[0].Error)
+	assert.False(t, first.changes[0].Time.IsZero())
+
+	assert.Equal(t, "Active", first.changes[1].From)
+	assert.Equal(t, "Reconnecting", first.changes[1].To)
+	assert.Empty(t, first.changes[1].Error, "the error from before the forward came up is not repeated")
+
+	assert.Equal(t, "Reconnecting (1s, attempt 1)", first.changes[2].From)
+	assert.Equal(t, "Error", first.changes[2].To)
+	assert.Equal(t, "connection refused", first.changes[2].Error)
+
+	assert.Equal(t, first.changes, second.changes)
+	assert.Equal(t, []string{id}, first.removed)
+	assert.Equal(t, []string{id}, second.removed)
+}
+
+func TestManager_Status(t *testing.T) {
+	m := &Manager{workers: make(map[string]*ForwardWorker), states: newStateTracker()}
+
+	b := config.Forward{Resource: "pod/b", Port: 80, LocalPort: 18082}
+	b.SetContext("dev", "default")
+	a := config.Forward{Resource: "pod/a", Port: 80, LocalPort: 18083}
+	a.SetContext("dev", "default")
+	m.showDisabled(b)
+	m.showDisabled(a)
+
+	state, err := m.Status(b.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Disabled", state.Status)
+	assert.Equal(t, TrafficStats{}, state.Traffic)
+
+	_, err = m.Status("missing")
+	assert.ErrorContains(t, err, "forward not found")
+
+	all := m.StatusAll()
+	require.Len(t, all, 2)
+	assert.Equal(t, a.ID(), all[0].ID)
+	assert.Equal(t, b.ID(), all[1].ID)
+}
+
+func TestManager_WaitReady(t *testing.T) {
+	m := &Manager{workers: make(map[string]*ForwardWorker), states: newStateTracker()}
+
+	api := config.Forward{Resource: "service/api", Port: 80, LocalPort: 18084}
+	api.SetContext("dev", "default")
+	db := config.Forward{Resource: "pod/db", Port: 5432, LocalPort: 15433}
+	db.SetContext("dev", "default")
+	off := config.Forward{Resource: "pod/off", Port: 80, LocalPort: 18085}
+	off.SetContext("dev", "default")
+	m.showDisabled(off)
+	m.states.AddForward(api.ID(), &api)
+	m.states.AddForward(db.ID(), &db)
+	m.states.UpdateStatus(api.ID(), "Active")
+	m.states.UpdateStatus(api.ID(), "Reconnecting")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	active, total, err := m.WaitReady(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, active, "a forward counts once it has been Active")
+	assert.Equal(t, 2, total, "disabled forwards are not waited for")
+
+	go m.states.UpdateStatus(db.ID(), "Active")
+	active, total, err = m.WaitReady(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, active)
+	assert.Equal(t, 2, total)
+}
@@ -0,0 +1,137 @@
+package forward
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/k8s"
+)
+
+const (
+	// traceBytesPerDirection is how much of each traced connection is kept
+	// in each direction. Later bytes are counted but not stored.
+	traceBytesPerDirection = 4096
+	// traceConnections is how many connections a forward keeps traces of;
+	// the oldest is dropped as each new one opens.
+	traceConnections = 20
+)
+
+// TraceChunk is a run of bytes moving in one direction on a traced
+// connection. Consecutive reads or writes in the same direction are merged.
+type TraceChunk struct {
+	Data   []byte
+	Offset time.Duration // since the connection opened
+	Sent   bool          // client to pod; false for pod to client
+}
+
+// ConnTrace is a copy of what was captured from one client connection.
+type ConnTrace struct {
+	Opened   time.Time
+	Chunks   []TraceChunk // in the order they passed
+	Sent     int64        // all bytes sent, including those past the capture limit
+	Received int64        // all bytes received, likewise
+	ID       int          // 1 for the forward's first traced connection
+	Closed   bool
+}
+
+// byteTracer records the first bytes of each client connection of a forward
+// for the trace panel. Memory is bounded by traceConnections times twice
+// traceBytesPerDirection.
+type byteTracer struct {
+	conns []*connTrace // oldest first
+	seq   int
+	mu    sync.Mutex // guards conns and seq
+}
+
+// connTrace captures one connection. It satisfies k8s.StreamTrace.
+type connTrace struct {
+	trace        ConnTrace
+	sentKept     int
+	receivedKept int
+	mu           sync.Mutex
+}
+
+// TraceConnection starts the trace of a new client connection, dropping the
+// oldest one when traceConnections are already kept.
+func (t *byteTracer) TraceConnection() k8s.StreamTrace {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.seq++
+	conn := &connTrace{trace: ConnTrace{ID: t.seq, Opened: time.Now()}}
+	if len(t.conns) >= traceConnections {
+		t.conns = append(t.conns[:0], t.conns[1:]...)
+	}
+	t.conns = append(t.conns, conn)
+	return conn
+}
+
+// snapshot returns a copy of every kept trace, oldest first.
+func (t *byteTracer) snapshot() []ConnTrace {
+	t.mu.Lock()
+	conns := append([]*connTrace(nil), t.conns...)
+	t.mu.Unlock()
+
+	traces := make([]ConnTrace, 0, len(conns))
+	for _, conn := range conns {
+		traces = append(traces, conn.snapshot())
+	}
+	return traces
+}
+
+func (c *connTrace) Sent(p []byte) {
+	c.record(p, true)
+}
+
+func (c *connTrace) Received(p []byte) {
+	c.record(p, false)
+}
+
+func (c *connTrace) Close() {
+	c.mu.Lock()
+	c.trace.Closed = true
+	c.mu.Unlock()
+}
+
+// record counts p and keeps as much of it as the direction's limit allows.
+func (c *connTrace) record(p []byte, sent bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	total, kept := &c.trace.Received, &c.receivedKept
+	if sent {
+		total, kept = &c.trace.Sent, &c.sentKept
+	}
+	*total += int64(len(p))
+
+	n := min(len(p), traceBytesPerDirection-*kept)
+	if n <= 0 {
+		return
+	}
+	*kept += n
+
+	chunks := c.trace.Chunks
+	if last := len(chunks) - 1; last >= 0 && chunks[last].Sent == sent {
+		chunks[last].Data = append(chunks[last].Data, p[:n]...)
+		return
+	}
+	c.trace.Chunks = append(chunks, TraceChunk{
+		Data:   append([]byte(nil), p[:n]...),
+		Offset: time.Since(c.trace.Opened),
+		Sent:   sent,
+	})
+}
+
+// snapshot returns a deep copy of the trace, as chunks keep growing.
+func (c *connTrace) snapshot() ConnTrace {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	trace := c.trace
+	trace.Chunks = make([]TraceChunk, len(c.trace.Chunks))
+	for i, chunk := range c.trace.Chunks {
+		chunk.Data = append([]byte(nil), chunk.Data...)
+		trace.Chunks[i] = chunk
+	}
+	return trace
+}
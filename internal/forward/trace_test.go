@@ -0,0 +1,79 @@
+package forward
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteTracer_CapturesBothDirections(t *testing.T) {
+	tracer := &byteTracer{}
+	conn := tracer.TraceConnection()
+
+	conn.Sent([]byte("PING"))
+	conn.Sent([]byte("\r\n"))
+	conn.Received([]byte("+PONG\r\n"))
+	conn.Sent([]byte("QUIT\r\n"))
+	conn.Close()
+
+	traces := tracer.snapshot()
+	require.Len(t, traces, 1)
+	trace := traces[0]
+	assert.Equal(t, 1, trace.ID)
+	assert.True(t, trace.Closed)
+	assert.Equal(t, int64(12), trace.Sent)
+	assert.Equal(t, int64(7), trace.Received)
+
+	require.Len(t, trace.Chunks, 3, "consecutive writes in one direction are merged")
+	assert.Equal(t, TraceChunk{Data: []byte("PING\r\n"), Offset: trace.Chunks[0].Offset, Sent: true}, trace.Chunks[0])
+	assert.Equal(t, []byte("+PONG\r\n"), trace.Chunks[1].Data)
+	assert.False(t, trace.Chunks[1].Sent)
+	assert.Equal(t, []byte("QUIT\r\n"), trace.Chunks[2].Data)
+}
+
+func TestByteTracer_Bounded(t *testing.T) {
+	tracer := &byteTracer{}
+	conn := tracer.TraceConnection()
+
+	big := bytes.Repeat([]byte{0xab}, traceBytesPerDirection-10)
+	conn.Sent(big)
+	conn.Sent(bytes.Repeat([]byte{0xcd}, 100))
+	conn.Received(bytes.Repeat([]byte{0xef}, 3*traceBytesPerDirection))
+
+	trace := tracer.snapshot()[0]
+	assert.Equal(t, int64(traceBytesPerDirection+90), trace.Sent, "totals count every byte")
+	assert.Len(t, trace.Chunks[0].Data, traceBytesPerDirection)
+	assert.Len(t, trace.Chunks[1].Data, traceBytesPerDirection)
+
+	// Once the limit is reached nothing more is kept
+	conn.Sent([]byte("more"))
+	assert.Len(t, tracer.snapshot()[0].Chunks, 2)
+
+	for range traceConnections + 5 {
+		tracer.TraceConnection()
+	}
+	traces := tracer.snapshot()
+	assert.Len(t, traces, traceConnections, "only the latest connections are kept")
+	assert.Equal(t, 7, traces[0].ID)
+}
+
+func TestByteTracer_SnapshotIsACopy(t *testing.T) {
+	tracer := &byteTracer{}
+	conn := tracer.TraceConnection()
+	conn.Sent([]byte("abc"))
+
+	before := tracer.snapshot()[0]
+	conn.Sent([]byte("def"))
+	assert.Equal(t, []byte("abc"), before.Chunks[0].Data)
+	assert.Equal(t, []byte("abcdef"), tracer.snapshot()[0].Chunks[0].Data)
+}
+
+func TestTrafficCounter_TraceConnection(t *testing.T) {
+	assert.Nil(t, (&trafficCounter{}).TraceConnection(), "no tracer, no trace")
+
+	counter := &trafficCounter{tracer: &byteTracer{}}
+	assert.NotNil(t, counter.TraceConnection())
+	assert.Len(t, counter.tracer.snapshot(), 1)
+}
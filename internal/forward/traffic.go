@@ -0,0 +1,146 @@
+package forward
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/k8s"
+)
+
+const (
+	// rateSampleInterval is the minimum spacing of the samples throughput is
+	// computed from.
+	rateSampleInterval = time.Second
+	// rateWindow is the period throughput is averaged over.
+	rateWindow = 10 * time.Second
+)
+
+// TrafficStats is a point-in-time copy of a forward's traffic counters.
+type TrafficStats struct {
+	BytesSent     int64         // Bytes written from local clients to the pod
+	BytesReceived int64         // Bytes read from the pod back to local clients
+	SendRate      int64         // Bytes/sec sent, averaged over rateWindow
+	ReceiveRate   int64         // Bytes/sec received, averaged over rateWindow
+	Tunnels       int64         // Port-forward connections opened, one per (re)connect
+	Streams       int64         // Client connections carried by those tunnels, each a stream pair
+	SetupTime     time.Duration // Time the latest connection's stream took to open
+	Connections   int           // Client connections currently open
+}
+
+// rateSample is the byte totals at one point in time
+type rateSample struct {
+	at       time.Time
+	sent     int64
+	received int64
+}
+
+// trafficCounter accumulates a worker's traffic across reconnects, so the
+// totals only start over when the forward's worker is recreated. Bytes are
+// also passed on to next, if set, to keep the metrics registry in step.
+//
+// Throughput is not tracked on the copy path: snapshot samples the totals
+// when it is called and derives the rates from earlier samples, so rates
+// only need reading often enough, as the UI's traffic refresh does.
+type trafficCounter struct {
+	next     k8s.TrafficCounter // optional, set before the worker starts
+	tracer   *byteTracer        // optional, set for forwards with traceBytes
+	samples  []rateSample       // oldest first, spanning about rateWindow
+	sent     atomic.Int64
+	received atomic.Int64
+	open     atomic.Int64
+	streams  atomic.Int64
+	tunnels  atomic.Int64
+	setup    atomic.Int64 // nanoseconds
+	mu       sync.Mutex   // guards samples
+}
+
+func (c *trafficCounter) AddSent(n int) {
+	if n > 0 {
+		c.sent.Add(int64(n))
+	}
+	if c.next != nil {
+		c.next.AddSent(n)
+	}
+}
+
+func (c *trafficCounter) AddReceived(n int) {
+	if n > 0 {
+		c.received.Add(int64(n))
+	}
+	if c.next != nil {
+		c.next.AddReceived(n)
+	}
+}
+
+func (c *trafficCounter) ConnOpened() {
+	c.open.Add(1)
+	c.streams.Add(1)
+	if conns, ok := c.next.(k8s.ConnectionCounter); ok {
+		conns.ConnOpened()
+	}
+}
+
+func (c *trafficCounter) ConnClosed() {
+	c.open.Add(-1)
+	if conns, ok := c.next.(k8s.ConnectionCounter); ok {
+		conns.ConnClosed()
+	}
+}
+
+func (c *trafficCounter) TunnelOpened() {
+	c.tunnels.Add(1)
+	if tunnels, ok := c.next.(k8s.TunnelCounter); ok {
+		tunnels.TunnelOpened()
+	}
+}
+
+func (c *trafficCounter) StreamSetup(d time.Duration) {
+	c.setup.Store(int64(d))
+}
+
+// TraceConnection hands new connections to the tracer, if the forward has
+// one.
+func (c *trafficCounter) TraceConnection() k8s.StreamTrace {
+	if c.tracer == nil {
+		return nil
+	}
+	return c.tracer.TraceConnection()
+}
+
+// snapshot returns the current counter values.
+func (c *trafficCounter) snapshot() TrafficStats {
+	return c.snapshotAt(time.Now())
+}
+
+// snapshotAt returns the counter values with rates computed as of now.
+// Rates are zero until two samples at least rateSampleInterval apart exist.
+func (c *trafficCounter) snapshotAt(now time.Time) TrafficStats {
+	stats := TrafficStats{
+		BytesSent:     c.sent.Load(),
+		BytesReceived: c.received.Load(),
+		Tunnels:       c.tunnels.Load(),
+		Streams:       c.streams.Load(),
+		SetupTime:     time.Duration(c.setup.Load()),
+		Connections:   int(max(c.open.Load(), 0)),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if n := len(c.samples); n == 0 || now.Sub(c.samples[n-1].at) >= rateSampleInterval {
+		c.samples = append(c.samples, rateSample{at: now, sent: stats.BytesSent, received: stats.BytesReceived})
+	}
+	// Keep the newest sample that is at least rateWindow old as the baseline
+	for len(c.samples) > 1 && now.Sub(c.samples[1].at) >= rateWindow {
+		c.samples = c.samples[1:]
+	}
+
+	oldest := c.samples[0]
+	if elapsed := now.Sub(oldest.at); elapsed >= rateSampleInterval {
+		secs := elapsed.Seconds()
+		stats.SendRate = int64(float64(stats.BytesSent-oldest.sent) / secs)
+		stats.ReceiveRate = int64(float64(stats.BytesReceived-oldest.received) / secs)
+	}
+	return stats
+}
@@ -0,0 +1,86 @@
+package forward
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/k8s"
+	"github.com/lukaszraczylo/kportal/internal/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrafficCounter_Snapshot(t *testing.T) {
+	c := &trafficCounter{}
+	c.AddSent(100)
+	c.AddReceived(2048)
+	c.AddSent(-1) // short reads report 0 or less and are ignored
+	c.TunnelOpened()
+	c.ConnOpened()
+	c.ConnOpened()
+	c.ConnClosed()
+
+	assert.Equal(t, TrafficStats{BytesSent: 100, BytesReceived: 2048, Tunnels: 1, Streams: 2, Connections: 1}, c.snapshot())
+}
+
+func TestTrafficCounter_ForwardsToMetrics(t *testing.T) {
+	reg := metrics.NewRegistry()
+	c := &trafficCounter{next: reg.Traffic("fwd")}
+	c.AddSent(5)
+	c.AddReceived(7)
+	c.TunnelOpened()
+	c.ConnOpened()
+	c.ConnOpened()
+
+	var out strings.Builder
+	require.NoError(t, reg.Write(&out))
+	assert.Contains(t, out.String(), `kportal_forward_bytes_total{forward="fwd",direction="sent"} 5`)
+	assert.Contains(t, out.String(), `kportal_forward_bytes_total{forward="fwd",direction="received"} 7`)
+	assert.Contains(t, out.String(), `kportal_forward_tunnels_total{forward="fwd"} 1`)
+	assert.Contains(t, out.String(), `kportal_forward_streams_total{forward="fwd"} 2`)
+}
+
+func TestForwardWorker_TrafficStatsStartAtZero(t *testing.T) {
+	fwd := config.Forward{Resource: "pod/app", Port: 80, LocalPort: 8080}
+	worker := NewForwardWorker(fwd, nil, false, nil, nil, nil)
+	worker.traffic.AddReceived(10)
+
+	assert.Equal(t, int64(10), worker.TrafficStats().BytesReceived)
+
+	// A recreated worker, as after a reload or disable/enable, starts over
+	recreated := NewForwardWorker(fwd, nil, false, nil, nil, nil)
+	assert.Equal(t, TrafficStats{}, recreated.TrafficStats())
+}
+
+func TestTrafficCounter_Rates(t *testing.T) {
+	c := &trafficCounter{}
+	start := time.Now()
+
+	c.AddSent(1000)
+	stats := c.snapshotAt(start)
+	assert.Zero(t, stats.SendRate, "a single sample has no rate")
+
+	c.AddSent(4000)
+	c.AddReceived(2000)
+	stats = c.snapshotAt(start.Add(2 * time.Second))
+	assert.Equal(t, int64(2000), stats.SendRate)
+	assert.Equal(t, int64(1000), stats.ReceiveRate)
+
+	// Once the traffic stops the rate decays to zero after rateWindow
+	c.snapshotAt(start.Add(rateWindow))
+	stats = c.snapshotAt(start.Add(2*time.Second + rateWindow))
+	assert.Zero(t, stats.SendRate)
+	assert.Zero(t, stats.ReceiveRate)
+	assert.LessOrEqual(t, len(c.samples), int(rateWindow/rateSampleInterval)+1)
+}
+
+func TestTrafficCounter_StreamSetup(t *testing.T) {
+	c := &trafficCounter{}
+	var _ k8s.SetupRecorder = c
+
+	c.StreamSetup(30 * time.Millisecond)
+	c.StreamSetup(12 * time.Millisecond)
+	assert.Equal(t, 12*time.Millisecond, c.snapshot().SetupTime)
+}
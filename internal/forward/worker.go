@@ -2,6 +2,7 @@ package forward
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -13,6 +14,7 @@ import (
 	"github.com/lukaszraczylo/kportal/internal/httplog"
 	"github.com/lukaszraczylo/kportal/internal/k8s"
 	"github.com/lukaszraczylo/kportal/internal/logger"
+	"github.com/lukaszraczylo/kportal/internal/metrics"
 	"github.com/lukaszraczylo/kportal/internal/retry"
 )
 
@@ -29,6 +31,8 @@ type ForwardWorker struct {
 	reconnectChan   chan string
 	httpProxy       *httplog.Proxy
 	watchdog        *Watchdog
+	metrics         *metrics.Registry // optional, set by the manager
+	traffic         *trafficCounter
 	cancel          context.CancelFunc
 	doneChan        chan struct{}
 	portForwarder   *k8s.PortForwarder
@@ -37,6 +41,11 @@ type ForwardWorker struct {
 	forwardCancel   context.CancelFunc
 	stopChan        chan struct{}
 	lastPod         string
+	reconnectReason string // reason of the last TriggerReconnect, guarded by forwardCancelMu
+	backoffOpts     retry.Options
+	startDelay      time.Duration  // wait before the first connect, set by the manager's startup stagger
+	errRepeats      errorRepeats   // owned by the run goroutine
+	requests        requestCounter // HTTP requests per second, counted by the HTTP log proxy
 	forward         config.Forward
 	forwardCancelMu sync.Mutex
 	stopOnce        sync.Once // Guards close(stopChan) against concurrent Stop() calls
@@ -47,6 +56,11 @@ type ForwardWorker struct {
 func NewForwardWorker(fwd config.Forward, portForwarder *k8s.PortForwarder, verbose bool, statusUI StatusUpdater, healthChecker *healthcheck.Checker, watchdog *Watchdog) *ForwardWorker {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	traffic := &trafficCounter{}
+	if fwd.TraceBytes {
+		traffic.tracer = &byteTracer{}
+	}
+
 	return &ForwardWorker{
 		forward:       fwd,
 		portForwarder: portForwarder,
@@ -60,7 +74,9 @@ func NewForwardWorker(fwd config.Forward, portForwarder *k8s.PortForwarder, verb
 		statusUI:      statusUI,
 		healthChecker: healthChecker,
 		watchdog:      watchdog,
+		backoffOpts:   retry.DefaultOptions(),
 		startTime:     time.Now(),
+		traffic:       traffic,
 	}
 }
 
@@ -78,6 +94,7 @@ func (w *ForwardWorker) signalConnectionSuccess() {
 func (w *ForwardWorker) TriggerReconnect(reason string) {
 	// Cancel current forward if running
 	w.forwardCancelMu.Lock()
+	w.reconnectReason = reason
 	if w.forwardCancel != nil {
 		w.forwardCancel()
 	}
@@ -91,6 +108,30 @@ func (w *ForwardWorker) TriggerReconnect(reason string) {
 	}
 }
 
+// reconnectForNetworkChange restarts the tunnel over the new network if one
+// is open or being opened, and reports whether it did. A worker waiting out
+// a backoff is left alone: its next attempt uses the new network anyway.
+func (w *ForwardWorker) reconnectForNetworkChange() bool {
+	w.forwardCancelMu.Lock()
+	active := w.forwardCancel != nil
+	w.forwardCancelMu.Unlock()
+
+	if active {
+		w.TriggerReconnect(reconnectReasonNetworkChange)
+	}
+	return active
+}
+
+// takeReconnectReason returns the reason of the last TriggerReconnect and
+// clears it.
+func (w *ForwardWorker) takeReconnectReason() string {
+	w.forwardCancelMu.Lock()
+	defer w.forwardCancelMu.Unlock()
+	reason := w.reconnectReason
+	w.reconnectReason = ""
+	return reason
+}
+
 // Start begins the port-forward worker in a goroutine.
 // The worker will continuously retry on failures with exponential backoff.
 func (w *ForwardWorker) Start() {
@@ -152,6 +193,23 @@ func (w *ForwardWorker) run() {
 	// instead of each worker spawning its own heartbeat goroutine.
 	// This reduces goroutine count from 2N to N for N workers.
 
+	// A staggered start waits its turn, still showing Starting
+	if w.startDelay > 0 {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-time.After(w.startDelay):
+		}
+	}
+
+	// Protocols the port-forward API cannot carry will never connect, so
+	// report the failure once and idle until stopped instead of retrying.
+	if err := k8s.CheckProtocol(w.forward.GetProtocol()); err != nil {
+		w.reportFatalError(err)
+		<-w.ctx.Done()
+		return
+	}
+
 	// Start HTTP logging proxy if enabled
 	if err := w.startHTTPProxy(); err != nil {
 		logger.Error("Failed to start HTTP logging proxy", map[string]any{
@@ -161,7 +219,7 @@ func (w *ForwardWorker) run() {
 		// Continue without HTTP logging
 	}
 
-	backoff := retry.NewBackoff()
+	backoff := retry.NewBackoffWithOptions(w.backoffOpts)
 
 	for {
 		// Check if we should stop or reset backoff on successful connection
@@ -172,8 +230,10 @@ func (w *ForwardWorker) run() {
 			}
 			return
 		case <-w.successChan:
-			// Reset backoff after successful connection
+			// Reset backoff and the repeated error count after a
+			// successful connection
 			backoff.Reset()
+			w.errRepeats.reset()
 		default:
 		}
 
@@ -187,24 +247,32 @@ func (w *ForwardWorker) run() {
 		)
 
 		if err != nil {
-			logger.Error("Failed to resolve resource", map[string]any{
-				"forward_id": w.forward.ID(),
-				"context":    w.forward.GetContext(),
-				"namespace":  w.forward.GetNamespace(),
-				"resource":   w.forward.Resource,
-				"error":      err.Error(),
-			})
-			w.sleepWithBackoff(backoff)
+			if msg, ok := w.repeatedError(err); ok {
+				logger.Error("Failed to resolve resource", map[string]any{
+					"forward_id": w.forward.ID(),
+					"context":    w.forward.GetContext(),
+					"namespace":  w.forward.GetNamespace(),
+					"resource":   w.forward.Resource,
+					"error":      msg,
+				})
+			}
+			if !w.sleepWithBackoff(backoff, err) {
+				w.reportRetriesExhausted(backoff.Attempt(), err)
+				<-w.ctx.Done()
+				return
+			}
 			continue
 		}
 
-		// Check if pod changed (restart detected)
+		// Check if pod changed (restart detected, or the old pod was
+		// deleted and the resource resolved to a replacement)
 		if w.lastPod != "" && w.lastPod != podName {
 			if w.healthChecker != nil {
 				w.healthChecker.MarkReconnecting(w.forward.ID())
 			}
 			logger.Info("Pod restart detected, switching to new pod", map[string]any{
 				"forward_id": w.forward.ID(),
+				"resource":   w.forward.Resource,
 				"old_pod":    w.lastPod,
 				"new_pod":    podName,
 				"context":    w.forward.GetContext(),
@@ -223,10 +291,20 @@ func (w *ForwardWorker) run() {
 		}
 
 		w.lastPod = podName
+		if ui, ok := w.statusUI.(interface{ SetPod(id, pod string) }); ok {
+			ui.SetPod(w.forward.ID(), podName)
+		}
 
 		// Establish port-forward connection
 		err = w.establishForward(podName)
 
+		// A network change closed the tunnel on purpose; reconnect straight
+		// away over the new network rather than backing off
+		if w.ctx.Err() == nil && w.takeReconnectReason() == reconnectReasonNetworkChange {
+			w.reconnectAfterNetworkChange()
+			continue
+		}
+
 		if err != nil {
 			// Connection failed or was interrupted
 			if w.ctx.Err() != nil {
@@ -234,26 +312,49 @@ func (w *ForwardWorker) run() {
 				return
 			}
 
+			// An idle tunnel is closed on purpose; reconnect to the same pod
+			// straight away rather than treating it as a failure
+			if errors.Is(err, k8s.ErrIdleTimeout) {
+				logger.Info("Port-forward idle timeout reached, reconnecting", map[string]any{
+					"forward_id":   w.forward.ID(),
+					"idle_timeout": w.forward.GetIdleTimeout().String(),
+				})
+				if w.healthChecker != nil {
+					w.healthChecker.MarkReconnecting(w.forward.ID())
+				}
+				if w.metrics != nil {
+					w.metrics.IncReconnects(w.forward.ID())
+				}
+				continue
+			}
+
 			// Update status to reconnecting
 			if w.healthChecker != nil {
 				w.healthChecker.MarkReconnecting(w.forward.ID())
 			}
 
-			// Log the error
-			logger.Warn("Port-forward connection failed, will retry", map[string]any{
-				"forward_id": w.forward.ID(),
-				"context":    w.forward.GetContext(),
-				"namespace":  w.forward.GetNamespace(),
-				"resource":   w.forward.Resource,
-				"local_port": w.forward.LocalPort,
-				"error":      err.Error(),
-			})
+			// Log the error, coalescing repeats of the same one
+			if msg, ok := w.repeatedError(err); ok {
+				logger.Warn("Port-forward connection failed, will retry", map[string]any{
+					"forward_id": w.forward.ID(),
+					"context":    w.forward.GetContext(),
+					"namespace":  w.forward.GetNamespace(),
+					"resource":   w.forward.Resource,
+					"local_port": w.forward.LocalPort,
+					"error":      msg,
+				})
+			}
 
-			// Clear last pod so we re-resolve on next attempt
-			w.lastPod = ""
+			// Forget the pod so the next attempt re-resolves, picking a
+			// replacement if this one was deleted
+			w.forgetPod()
 
 			// Wait with backoff before retrying
-			w.sleepWithBackoff(backoff)
+			if !w.sleepWithBackoff(backoff, err) {
+				w.reportRetriesExhausted(backoff.Attempt(), err)
+				<-w.ctx.Done()
+				return
+			}
 			continue
 		}
 
@@ -263,9 +364,48 @@ func (w *ForwardWorker) run() {
 		}
 
 		// Connection closed unexpectedly, retry
-		log.Printf("[%s] Connection closed unexpectedly, retrying...", w.forward.ID())
-		w.lastPod = ""
-		w.sleepWithBackoff(backoff)
+		err = errors.New("connection closed unexpectedly")
+		if msg, ok := w.repeatedError(err); ok {
+			log.Printf("[%s] %s, retrying...", w.forward.ID(), msg)
+		}
+		w.forgetPod()
+		if !w.sleepWithBackoff(backoff, err) {
+			w.reportRetriesExhausted(backoff.Attempt(), err)
+			<-w.ctx.Done()
+			return
+		}
+	}
+}
+
+// reconnectAfterNetworkChange reports the reconnect a network change forced
+// and forgets the pod, so it is resolved again over the new network.
+func (w *ForwardWorker) reconnectAfterNetworkChange() {
+	logger.Info("Network changed, reconnecting port forward", map[string]any{
+		"forward_id": w.forward.ID(),
+	})
+	if w.healthChecker != nil {
+		w.healthChecker.MarkReconnecting(w.forward.ID())
+	}
+	if w.metrics != nil {
+		w.metrics.IncReconnects(w.forward.ID())
+	}
+	if w.statusUI != nil {
+		w.statusUI.UpdateStatus(w.forward.ID(), fmt.Sprintf("%s (%s)", healthcheck.StatusReconnect, reconnectReasonNetworkChange))
+	}
+	w.forgetPod()
+}
+
+// forgetPod drops the pod the forward was using from the resolver cache, so
+// the next attempt resolves the resource again. lastPod is kept so a switch
+// to a different pod is logged.
+func (w *ForwardWorker) forgetPod() {
+	if w.portForwarder != nil {
+		w.portForwarder.InvalidateResource(
+			w.forward.GetContext(),
+			w.forward.GetNamespace(),
+			w.forward.Resource,
+			w.forward.Selector,
+		)
 	}
 }
 
@@ -328,23 +468,34 @@ func (w *ForwardWorker) establishForward(podName string) error {
 
 	// Determine local port for k8s port-forward
 	// If HTTP logging is enabled, we bind to an internal port and the proxy listens on the user-facing port
+	// and bind address, leaving the tunnel on localhost
 	localPort := w.forward.LocalPort
+	bindAddress := w.forward.BindAddress
 	if w.httpProxy != nil {
 		localPort = w.httpProxy.GetTargetPort()
+		bindAddress = ""
 	}
 
 	// Create forward request
 	req := &k8s.ForwardRequest{
-		ContextName: w.forward.GetContext(),
-		Namespace:   w.forward.GetNamespace(),
-		Resource:    w.forward.Resource,
-		Selector:    w.forward.Selector,
-		LocalPort:   localPort,
-		RemotePort:  w.forward.Port,
-		StopChan:    stopChan,
-		ReadyChan:   readyChan,
-		Out:         out,
-		ErrOut:      errOut,
+		ContextName:  w.forward.GetContext(),
+		Namespace:    w.forward.GetNamespace(),
+		Resource:     w.forward.Resource,
+		Selector:     w.forward.Selector,
+		Container:    w.forward.Container,
+		PortName:     w.forward.PortName,
+		Protocol:     w.forward.GetProtocol(),
+		BindAddress:  bindAddress,
+		LocalPort:    localPort,
+		RemotePort:   w.forward.Port,
+		IdleTimeout:  w.forward.GetIdleTimeout(),
+		TCPKeepalive: w.forward.GetTCPKeepalive(),
+		DialTimeout:  w.forward.GetDialTimeout(),
+		StopChan:     stopChan,
+		ReadyChan:    readyChan,
+		Out:          out,
+		ErrOut:       errOut,
+		Traffic:      w.traffic,
 	}
 
 	// Start port forwarding in a goroutine
@@ -387,21 +538,152 @@ func (w *ForwardWorker) establishForward(podName string) error {
 	}
 }
 
-// sleepWithBackoff waits for the next backoff duration.
-// Returns early if the worker is stopped.
-func (w *ForwardWorker) sleepWithBackoff(backoff *retry.Backoff) {
+// reportFatalError surfaces an error that retrying cannot fix, marking the
+// forward as failed in the UI with the error message attached.
+func (w *ForwardWorker) reportFatalError(err error) {
+	logger.Error("Port-forward cannot be established", map[string]any{
+		"forward_id": w.forward.ID(),
+		"protocol":   w.forward.GetProtocol(),
+		"error":      err.Error(),
+	})
+
+	if w.statusUI == nil {
+		return
+	}
+	w.statusUI.UpdateStatus(w.forward.ID(), string(healthcheck.StatusUnhealthy))
+	if ui, ok := w.statusUI.(interface{ SetError(id, msg string) }); ok {
+		ui.SetError(w.forward.ID(), err.Error())
+	}
+}
+
+// sleepWithBackoff waits for the next backoff duration, showing it in the
+// forward's Reconnecting status along with the error that caused the retry.
+// A context missing from kubeconfig is shown as "Context not found" instead,
+// and an error that keeps repeating carries its count. Returns early if the worker is stopped, and returns false without waiting
+// once reconnectMaxRetries consecutive attempts have failed.
+func (w *ForwardWorker) sleepWithBackoff(backoff *retry.Backoff, cause error) bool {
+	maxRetries := w.forward.ReconnectMaxRetries
+	if maxRetries > 0 && backoff.Attempt() >= maxRetries {
+		return false
+	}
+
 	delay := backoff.Next()
+	if w.metrics != nil {
+		w.metrics.IncReconnects(w.forward.ID())
+	}
 
 	if w.verbose {
 		log.Printf("[%s] Retrying in %v (attempt %d)", w.forward.ID(), delay, backoff.Attempt())
 	}
 
+	status := reconnectingStatus(delay, backoff.Attempt(), maxRetries)
+	var permErr *k8s.PermissionError
+	switch {
+	case errors.Is(cause, k8s.ErrContextNotFound):
+		// Keep retrying: the pool re-reads kubeconfig, so the forward
+		// recovers once the context is back
+		status = string(healthcheck.StatusContextNotFound)
+		cause = fmt.Errorf("context %q not found in kubeconfig; it may have been renamed or removed", w.forward.GetContext())
+		if w.healthChecker != nil {
+			w.healthChecker.MarkContextNotFound(w.forward.ID(), cause.Error())
+		}
+	case errors.As(cause, &permErr):
+		// Keep retrying too, so granting the permission is enough; the
+		// message names it instead of the wrapped API error
+		status = string(healthcheck.StatusPermissionDenied)
+		cause = permErr
+		if w.healthChecker != nil {
+			w.healthChecker.MarkPermissionDenied(w.forward.ID(), cause.Error())
+		}
+	case w.healthChecker != nil:
+		w.healthChecker.MarkReconnecting(w.forward.ID())
+	}
+	if w.statusUI != nil {
+		w.statusUI.UpdateStatus(w.forward.ID(), status)
+		if ui, ok := w.statusUI.(interface{ SetError(id, msg string) }); ok && cause != nil {
+			ui.SetError(w.forward.ID(), w.errRepeats.annotate(cause.Error(), time.Now()))
+		}
+	}
+
 	select {
 	case <-time.After(delay):
 		// Continue with retry
 	case <-w.ctx.Done():
 		// Worker stopped
 	}
+	return true
+}
+
+// repeatedError records err as the forward's latest failure. It returns the
+// message with its repeat count and whether to log it: identical
+// consecutive errors are logged at most once per repeatedErrorLogInterval.
+func (w *ForwardWorker) repeatedError(err error) (string, bool) {
+	now := time.Now()
+	logIt := w.errRepeats.record(err.Error(), now)
+	return w.errRepeats.annotate(err.Error(), now), logIt
+}
+
+// reconnectingStatus formats the Reconnecting status with the current
+// backoff, e.g. "Reconnecting (4s, attempt 3/5)" or, without a retry
+// limit, "Reconnecting (4s, attempt 3)".
+func reconnectingStatus(delay time.Duration, attempt, maxRetries int) string {
+	rounded := delay.Round(100 * time.Millisecond)
+	if rounded == 0 {
+		rounded = delay.Round(time.Millisecond)
+	}
+
+	if maxRetries > 0 {
+		return fmt.Sprintf("%s (%v, attempt %d/%d)", healthcheck.StatusReconnect, rounded, attempt, maxRetries)
+	}
+	return fmt.Sprintf("%s (%v, attempt %d)", healthcheck.StatusReconnect, rounded, attempt)
+}
+
+// reportRetriesExhausted marks the forward Failed after reconnectMaxRetries
+// consecutive failed attempts. The health checker stops probing it so the
+// terminal status is not overwritten.
+func (w *ForwardWorker) reportRetriesExhausted(attempts int, cause error) {
+	logger.Error("Giving up on port-forward after reconnect retries", map[string]any{
+		"forward_id": w.forward.ID(),
+		"attempts":   attempts,
+		"error":      cause.Error(),
+	})
+
+	if w.healthChecker != nil {
+		w.healthChecker.Unregister(w.forward.ID())
+	}
+	if w.statusUI == nil {
+		return
+	}
+	w.statusUI.UpdateStatus(w.forward.ID(), string(healthcheck.StatusFailed))
+	if ui, ok := w.statusUI.(interface{ SetError(id, msg string) }); ok {
+		ui.SetError(w.forward.ID(), fmt.Sprintf("gave up after %d attempts: %v", attempts, cause))
+	}
+}
+
+// TrafficStats returns the bytes moved and client connections open on the
+// forward since this worker was created. Reconnects do not reset them.
+func (w *ForwardWorker) TrafficStats() TrafficStats {
+	return w.traffic.snapshot()
+}
+
+// RequestHistory returns the forward's HTTP requests per second over the
+// last 30 seconds, oldest first. ok is false when the forward does not have
+// HTTP logging, which is what counts the requests. Requests are only
+// counted while the history keeps being read.
+func (w *ForwardWorker) RequestHistory() (counts []int64, ok bool) {
+	if !w.forward.IsHTTPLogEnabled() {
+		return nil, false
+	}
+	return w.requests.history(time.Now()), true
+}
+
+// Trace returns the byte traces of the forward's recent client connections,
+// oldest first. ok is false when the forward does not have traceBytes set.
+func (w *ForwardWorker) Trace() (traces []ConnTrace, ok bool) {
+	if w.traffic.tracer == nil {
+		return nil, false
+	}
+	return w.traffic.tracer.snapshot(), true
 }
 
 // GetForward returns the forward configuration for this worker.
@@ -440,6 +722,16 @@ func (w *ForwardWorker) startHTTPProxy() error {
 	if err != nil {
 		return fmt.Errorf("failed to create HTTP proxy: %w", err)
 	}
+	var observeMetrics func(status int)
+	if w.metrics != nil {
+		observeMetrics = w.metrics.HTTPStatusObserver(w.forward.ID())
+	}
+	proxy.SetStatusObserver(func(status int) {
+		w.requests.observe(time.Now())
+		if observeMetrics != nil {
+			observeMetrics(status)
+		}
+	})
 
 	if err := proxy.Start(); err != nil {
 		return fmt.Errorf("failed to start HTTP proxy: %w", err)
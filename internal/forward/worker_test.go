@@ -227,6 +227,31 @@ func TestForwardWorker_TriggerReconnect_NonBlocking(t *testing.T) {
 	}
 }
 
+func TestForwardWorker_ReconnectForNetworkChange(t *testing.T) {
+	fwd := config.Forward{
+		Resource:  "pod/my-app",
+		LocalPort: 8080,
+		Port:      80,
+	}
+
+	worker := NewForwardWorker(fwd, nil, false, nil, nil, nil)
+
+	// No tunnel open: the worker is left to its own retries
+	assert.False(t, worker.reconnectForNetworkChange())
+	assert.Empty(t, worker.reconnectChan)
+	assert.Empty(t, worker.takeReconnectReason())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	worker.forwardCancelMu.Lock()
+	worker.forwardCancel = cancel
+	worker.forwardCancelMu.Unlock()
+
+	assert.True(t, worker.reconnectForNetworkChange())
+	assert.Error(t, ctx.Err(), "the open tunnel is closed")
+	assert.Equal(t, reconnectReasonNetworkChange, worker.takeReconnectReason())
+	assert.Empty(t, worker.takeReconnectReason(), "the reason is cleared once taken")
+}
+
 // TestForwardWorker_Stop tests graceful stop
 func TestForwardWorker_Stop(t *testing.T) {
 	fwd := config.Forward{
@@ -1,11 +1,15 @@
 package forward
 
 import (
+	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/metrics"
+	"github.com/lukaszraczylo/kportal/internal/retry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -258,6 +262,95 @@ func TestSleepWithBackoffConcept(t *testing.T) {
 	})
 }
 
+func TestReconnectingStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		expected   string
+		delay      time.Duration
+		attempt    int
+		maxRetries int
+	}{
+		{"unlimited", "Reconnecting (4s, attempt 3)", 4 * time.Second, 3, 0},
+		{"with limit", "Reconnecting (4s, attempt 3/5)", 4 * time.Second, 3, 5},
+		{"rounds jitter", "Reconnecting (1.1s, attempt 1)", 1083 * time.Millisecond, 1, 0},
+		{"sub-step delay", "Reconnecting (40ms, attempt 1)", 40 * time.Millisecond, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, reconnectingStatus(tt.delay, tt.attempt, tt.maxRetries))
+		})
+	}
+}
+
+func TestSleepWithBackoff_RetryLimit(t *testing.T) {
+	fwd := config.Forward{Resource: "pod/app", Port: 80, LocalPort: 8080, ReconnectMaxRetries: 2}
+	status := &MockStatusUpdater{}
+	worker := NewForwardWorker(fwd, nil, false, status, nil, nil)
+	backoff := retry.NewBackoffWithOptions(retry.Options{
+		InitialDelay: time.Millisecond,
+		MaxDelay:     time.Millisecond,
+	})
+	cause := errors.New("dial failed")
+
+	assert.True(t, worker.sleepWithBackoff(backoff, cause))
+	assert.True(t, worker.sleepWithBackoff(backoff, cause))
+	assert.False(t, worker.sleepWithBackoff(backoff, cause), "third retry exceeds reconnectMaxRetries")
+	assert.Equal(t, 2, backoff.Attempt())
+
+	status.mu.Lock()
+	defer status.mu.Unlock()
+	require.Len(t, status.updates, 2)
+	assert.True(t, strings.HasPrefix(status.updates[1].Status, "Reconnecting ("))
+	assert.Contains(t, status.updates[1].Status, "attempt 2/2")
+	require.NotEmpty(t, status.errorSets)
+	assert.Equal(t, "dial failed", status.errorSets[0].Msg)
+}
+
+func TestSleepWithBackoff_CountsReconnects(t *testing.T) {
+	fwd := config.Forward{Resource: "pod/app", Port: 80, LocalPort: 8080}
+	fwd.SetContext("dev", "default")
+	worker := NewForwardWorker(fwd, nil, false, nil, nil, nil)
+	worker.metrics = metrics.NewRegistry()
+	backoff := retry.NewBackoffWithOptions(retry.Options{
+		InitialDelay: time.Millisecond,
+		MaxDelay:     time.Millisecond,
+	})
+
+	worker.sleepWithBackoff(backoff, nil)
+	worker.sleepWithBackoff(backoff, nil)
+
+	var out strings.Builder
+	require.NoError(t, worker.metrics.Write(&out))
+	assert.Contains(t, out.String(), `kportal_forward_reconnects_total{forward="`+fwd.ID()+`"} 2`)
+}
+
+func TestReportRetriesExhausted(t *testing.T) {
+	fwd := config.Forward{Resource: "pod/app", Port: 80, LocalPort: 8080, ReconnectMaxRetries: 3}
+	status := &MockStatusUpdater{}
+	worker := NewForwardWorker(fwd, nil, false, status, nil, nil)
+
+	worker.reportRetriesExhausted(3, errors.New("pod not found"))
+
+	status.mu.Lock()
+	defer status.mu.Unlock()
+	require.Len(t, status.updates, 1)
+	assert.Equal(t, "Failed", status.updates[0].Status)
+	require.Len(t, status.errorSets, 1)
+	assert.Equal(t, "gave up after 3 attempts: pod not found", status.errorSets[0].Msg)
+}
+
+func TestForwardWorker_ForgetPodKeepsLastPod(t *testing.T) {
+	fwd := config.Forward{Resource: "pod", Selector: "app=web", Port: 80, LocalPort: 8080}
+	worker := NewForwardWorker(fwd, nil, false, nil, nil, nil)
+	worker.lastPod = "web-1"
+
+	// No port forwarder: must not panic, and lastPod stays so a switch to a
+	// replacement pod is logged on the next attempt
+	assert.NotPanics(t, worker.forgetPod)
+	assert.Equal(t, "web-1", worker.lastPod)
+}
+
 func TestWorkerVerboseMode(t *testing.T) {
 	tests := []struct {
 		name        string
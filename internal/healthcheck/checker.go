@@ -5,6 +5,10 @@
 //   - tcp-dial: Simple TCP connection test (fast but less reliable)
 //   - data-transfer: Attempts to read data from the connection (more reliable)
 //
+// Forwards can also carry an HTTP probe (see HTTPProbe). It runs on its own
+// interval once the tunnel check passes and reports StatusProbeFailed when the
+// application behind a working tunnel answers with an unexpected status.
+//
 // Stale connection detection prevents issues during long-running operations
 // like database dumps by monitoring:
 //   - Connection age (default: 25 minutes, before k8s 30-minute timeout)
@@ -19,6 +23,8 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -35,20 +41,35 @@ var bufferPool = sync.Pool{
 	},
 }
 
+// probeClient performs HTTP health probes. Redirects are not followed so a
+// probe can expect a 3xx status, keep-alives are disabled so probes do not
+// hold tunnel connections open, and proxy environment variables are ignored.
+var probeClient = &http.Client{
+	Transport: &http.Transport{DisableKeepAlives: true},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
 const (
 	startupGracePeriod = 10 * time.Second
 	dataTransferSize   = 1024 // bytes to read in data transfer test
+	probeBodyDrainSize = 4096 // bytes of probe response body read before closing
 )
 
 // Status represents the health status of a port forward
 type Status string
 
 const (
-	StatusHealthy   Status = "Active"
-	StatusUnhealthy Status = "Error"
-	StatusStarting  Status = "Starting"
-	StatusReconnect Status = "Reconnecting"
-	StatusStale     Status = "Stale" // Connection is old or idle
+	StatusHealthy          Status = "Active"
+	StatusUnhealthy        Status = "Error"
+	StatusStarting         Status = "Starting"
+	StatusReconnect        Status = "Reconnecting"
+	StatusStale            Status = "Stale"             // Connection is old or idle
+	StatusProbeFailed      Status = "Unhealthy"         // Tunnel is up but the HTTP probe fails
+	StatusFailed           Status = "Failed"            // Worker gave up after reconnectMaxRetries
+	StatusContextNotFound  Status = "Context not found" // kubeconfig lacks the context; the worker keeps retrying
+	StatusPermissionDenied Status = "Permission denied" // RBAC denies an API call the forward needs; the worker keeps retrying
 )
 
 // CheckMethod represents the health check method
@@ -59,8 +80,19 @@ const (
 	CheckMethodDataTransfer CheckMethod = "data-transfer" // Try to read data from connection
 )
 
+// HTTPProbe configures an HTTP health probe for a registered port.
+type HTTPProbe struct {
+	Path           string
+	Interval       time.Duration
+	ExpectedStatus int
+}
+
 // PortHealth represents the health status of a single port
 type PortHealth struct {
+	probe          *HTTPProbe
+	lastProbe      time.Time
+	probeError     string
+	host           string // address the checks dial
 	LastCheck      time.Time
 	RegisteredAt   time.Time
 	ConnectionTime time.Time
@@ -144,10 +176,24 @@ func (c *Checker) SetEventBus(bus *events.Bus) {
 
 // Register adds a port to monitor
 func (c *Checker) Register(forwardID string, port int, callback StatusCallback) {
+	c.RegisterWithProbe(forwardID, port, nil, callback)
+}
+
+// RegisterWithProbe adds a port to monitor with an optional HTTP probe that
+// runs once the port passes the connectivity check.
+func (c *Checker) RegisterWithProbe(forwardID string, port int, probe *HTTPProbe, callback StatusCallback) {
+	c.RegisterAt(forwardID, "127.0.0.1", port, probe, callback)
+}
+
+// RegisterAt is RegisterWithProbe for a port listening on host rather than
+// 127.0.0.1, such as ::1 for a forward bound to the IPv6 loopback.
+func (c *Checker) RegisterAt(forwardID, host string, port int, probe *HTTPProbe, callback StatusCallback) {
 	c.mu.Lock()
 
 	now := time.Now()
 	c.ports[forwardID] = &PortHealth{
+		probe:          probe,
+		host:           host,
 		Port:           port,
 		LastCheck:      time.Time{},
 		Status:         StatusStarting,
@@ -164,6 +210,17 @@ func (c *Checker) Register(forwardID string, port int, callback StatusCallback)
 	go c.checkPort(forwardID)
 }
 
+// DelayStart extends the startup grace period of a registered forward by d,
+// for a worker that waits d before its first connect attempt.
+func (c *Checker) DelayStart(forwardID string, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if health, exists := c.ports[forwardID]; exists {
+		health.RegisteredAt = health.RegisteredAt.Add(d)
+	}
+}
+
 // MarkConnected marks a forward as having established a new connection.
 // This updates connection timestamps and triggers an immediate health check
 // to verify the connection is actually working.
@@ -179,6 +236,7 @@ func (c *Checker) MarkConnected(forwardID string) {
 	now := time.Now()
 	health.ConnectionTime = now
 	health.LastActivity = now
+	health.lastProbe = time.Time{} // probe the new connection right away
 	c.mu.Unlock()
 
 	// Trigger immediate health check to verify connection and update status
@@ -229,6 +287,47 @@ func (c *Checker) MarkReconnecting(forwardID string) {
 	c.markStatus(forwardID, StatusReconnect)
 }
 
+// MarkContextNotFound marks a forward whose context is missing from
+// kubeconfig (called by worker). The status and msg are kept until the
+// worker reports a new connection or another status.
+func (c *Checker) MarkContextNotFound(forwardID, msg string) {
+	c.markSticky(forwardID, StatusContextNotFound, msg)
+}
+
+// MarkPermissionDenied marks a forward whose credentials lack an RBAC
+// permission it needs (called by worker), msg naming the permission. Like
+// MarkContextNotFound, it is kept until the worker reports otherwise.
+func (c *Checker) MarkPermissionDenied(forwardID, msg string) {
+	c.markSticky(forwardID, StatusPermissionDenied, msg)
+}
+
+// markSticky sets a status with its reason that failed checks do not
+// replace, since they would only report the port being closed.
+func (c *Checker) markSticky(forwardID string, status Status, msg string) {
+	c.mu.Lock()
+
+	health, exists := c.ports[forwardID]
+	if !exists {
+		c.mu.Unlock()
+		return
+	}
+
+	oldStatus, oldMsg := health.Status, health.ErrorMessage
+	health.Status = status
+	health.ErrorMessage = msg
+	health.LastCheck = time.Now()
+	c.mu.Unlock()
+
+	if oldStatus != status || oldMsg != msg {
+		c.notifyStatusChange(forwardID, status, msg)
+	}
+}
+
+// isSticky reports whether status is set by markSticky.
+func isSticky(status Status) bool {
+	return status == StatusContextNotFound || status == StatusPermissionDenied
+}
+
 // MarkStarting marks a forward as starting (called by worker)
 func (c *Checker) MarkStarting(forwardID string) {
 	c.markStatus(forwardID, StatusStarting)
@@ -263,7 +362,7 @@ func (c *Checker) GetAllErrors() map[string]string {
 
 	errors := make(map[string]string)
 	for forwardID, health := range c.ports {
-		if health.Status == StatusUnhealthy && health.ErrorMessage != "" {
+		if (health.Status == StatusUnhealthy || health.Status == StatusProbeFailed || isSticky(health.Status)) && health.ErrorMessage != "" {
 			errors[forwardID] = health.ErrorMessage
 		}
 	}
@@ -330,11 +429,15 @@ func (c *Checker) checkPort(forwardID string) {
 		c.mu.RUnlock()
 		return
 	}
-	port := health.Port
+	addr := net.JoinHostPort(health.host, strconv.Itoa(health.Port))
 	oldStatus := health.Status
+	oldError := health.ErrorMessage
 	registeredAt := health.RegisteredAt
 	connectionTime := health.ConnectionTime
 	lastActivity := health.LastActivity
+	probe := health.probe
+	lastProbe := health.lastProbe
+	probeError := health.probeError
 	c.mu.RUnlock()
 
 	now := time.Now()
@@ -362,23 +465,53 @@ func (c *Checker) checkPort(forwardID string) {
 		var checkErr error
 		switch c.method {
 		case CheckMethodDataTransfer:
-			checkErr = c.checkDataTransfer(port)
+			checkErr = c.checkDataTransfer(addr)
 		case CheckMethodTCPDial:
-			checkErr = c.checkTCPDial(port)
+			checkErr = c.checkTCPDial(addr)
 		default:
-			checkErr = c.checkTCPDial(port)
+			checkErr = c.checkTCPDial(addr)
 		}
 
 		if checkErr != nil {
 			// Grace period: if forward is less than 10 seconds old, keep it as "Starting"
-			// This avoids scary "Error" messages during initial connection attempts
+			// This avoids scary "Error" messages during initial connection attempts.
+			// A reconnecting forward stays "Reconnecting" until the worker
+			// reports a new connection (MarkConnected) or gives up. One
+			// missing its context or a permission keeps that status and
+			// the reason.
 			timeSinceStart := now.Sub(registeredAt)
-			if timeSinceStart < startupGracePeriod {
+			errorMsg = checkErr.Error()
+			if oldStatus == StatusReconnect {
+				newStatus = StatusReconnect
+			} else if isSticky(oldStatus) {
+				newStatus = oldStatus
+				errorMsg = oldError
+			} else if timeSinceStart < startupGracePeriod {
 				newStatus = StatusStarting
 			} else {
 				newStatus = StatusUnhealthy
 			}
-			errorMsg = checkErr.Error()
+		}
+	}
+
+	// The tunnel works; check the application behind it when a probe is configured
+	probed := false
+	if newStatus == StatusHealthy && probe != nil {
+		if now.Sub(lastProbe) >= probe.Interval {
+			probeError = ""
+			if err := c.checkHTTP(addr, probe); err != nil {
+				probeError = err.Error()
+			}
+			probed = true
+		}
+
+		if probeError != "" {
+			if now.Sub(registeredAt) < startupGracePeriod {
+				newStatus = StatusStarting
+			} else {
+				newStatus = StatusProbeFailed
+			}
+			errorMsg = probeError
 		}
 	}
 
@@ -389,10 +522,14 @@ func (c *Checker) checkPort(forwardID string) {
 		health.Status = newStatus
 		health.LastCheck = now
 		health.ErrorMessage = errorMsg
+		if probed {
+			health.lastProbe = now
+			health.probeError = probeError
+		}
 
 		// Successful health check indicates connection is active
 		// This prevents false positives where healthy connections are marked as idle
-		if newStatus == StatusHealthy {
+		if newStatus == StatusHealthy || newStatus == StatusProbeFailed {
 			health.LastActivity = now
 		}
 	}
@@ -416,12 +553,12 @@ func (c *Checker) checkPort(forwardID string) {
 }
 
 // checkTCPDial performs a simple TCP dial test
-func (c *Checker) checkTCPDial(port int) error {
+func (c *Checker) checkTCPDial(addr string) error {
 	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
 	defer cancel()
 
 	var d net.Dialer
-	conn, err := d.DialContext(ctx, "tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	conn, err := d.DialContext(ctx, "tcp", addr)
 	if err != nil {
 		return err
 	}
@@ -430,12 +567,12 @@ func (c *Checker) checkTCPDial(port int) error {
 }
 
 // checkDataTransfer attempts to read data from the connection to verify tunnel health
-func (c *Checker) checkDataTransfer(port int) error {
+func (c *Checker) checkDataTransfer(addr string) error {
 	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
 	defer cancel()
 
 	var d net.Dialer
-	conn, err := d.DialContext(ctx, "tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	conn, err := d.DialContext(ctx, "tcp", addr)
 	if err != nil {
 		return err
 	}
@@ -473,6 +610,32 @@ func (c *Checker) checkDataTransfer(port int) error {
 	return fmt.Errorf("data transfer check failed: %w", err)
 }
 
+// checkHTTP requests the probe path on the local port and compares the
+// response status with the expected one.
+func (c *Checker) checkHTTP(addr string, probe *HTTPProbe) error {
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeout)
+	defer cancel()
+
+	url := "http://" + addr + probe.Path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("health probe %s: %w", probe.Path, err)
+	}
+	req.Header.Set("User-Agent", "kportal-healthcheck")
+
+	resp, err := probeClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("health probe %s failed: %w", probe.Path, err)
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, probeBodyDrainSize))
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != probe.ExpectedStatus {
+		return fmt.Errorf("health probe %s returned %d, expected %d", probe.Path, resp.StatusCode, probe.ExpectedStatus)
+	}
+	return nil
+}
+
 // notifyStatusChange calls the callback for a forward
 func (c *Checker) notifyStatusChange(forwardID string, status Status, errorMsg string) {
 	c.mu.RLock()
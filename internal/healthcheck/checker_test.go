@@ -3,7 +3,10 @@ package healthcheck
 import (
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -544,3 +547,258 @@ func TestCustomOptions(t *testing.T) {
 	assert.Equal(t, 5*time.Minute, checker.maxConnectionAge)
 	assert.Equal(t, 2*time.Minute, checker.maxIdleTime)
 }
+
+// newProbeChecker returns a checker whose loop never fires during a test and
+// an HTTP server whose response status is controlled by the returned value.
+func newProbeChecker(t *testing.T) (*Checker, int, *atomic.Int32, *atomic.Int32) {
+	t.Helper()
+
+	var status, hits atomic.Int32
+	status.Store(http.StatusOK)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		hits.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(srv.Close)
+
+	checker := NewCheckerWithOptions(CheckerOptions{
+		Interval: time.Hour,
+		Timeout:  time.Second,
+		Method:   CheckMethodTCPDial,
+	})
+	t.Cleanup(checker.Stop)
+
+	return checker, srv.Listener.Addr().(*net.TCPAddr).Port, &status, &hits
+}
+
+// skipGracePeriod backdates a registration so failures are reported
+// immediately instead of as Starting.
+func skipGracePeriod(c *Checker, forwardID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ports[forwardID].RegisteredAt = time.Now().Add(-2 * startupGracePeriod)
+}
+
+func TestHTTPProbe_StatusReflection(t *testing.T) {
+	checker, port, status, _ := newProbeChecker(t)
+
+	var mu sync.Mutex
+	var lastStatus Status
+	var lastError string
+	checker.RegisterWithProbe("fwd", port, &HTTPProbe{Path: "/healthz", ExpectedStatus: http.StatusOK}, func(_ string, s Status, msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lastStatus, lastError = s, msg
+	})
+	skipGracePeriod(checker, "fwd")
+
+	checker.checkPort("fwd")
+	got, _ := checker.GetStatus("fwd")
+	assert.Equal(t, StatusHealthy, got)
+
+	status.Store(http.StatusInternalServerError)
+	checker.checkPort("fwd")
+	got, _ = checker.GetStatus("fwd")
+	assert.Equal(t, StatusProbeFailed, got)
+	assert.Contains(t, checker.GetAllErrors()["fwd"], "returned 500, expected 200")
+
+	mu.Lock()
+	assert.Equal(t, StatusProbeFailed, lastStatus)
+	assert.Contains(t, lastError, "health probe /healthz returned 500")
+	mu.Unlock()
+
+	status.Store(http.StatusOK)
+	checker.checkPort("fwd")
+	got, _ = checker.GetStatus("fwd")
+	assert.Equal(t, StatusHealthy, got)
+}
+
+func TestHTTPProbe_ExpectedStatusAndGracePeriod(t *testing.T) {
+	checker, port, status, _ := newProbeChecker(t)
+	status.Store(http.StatusServiceUnavailable)
+
+	checker.RegisterWithProbe("fwd", port, &HTTPProbe{Path: "/healthz", ExpectedStatus: http.StatusNoContent}, nil)
+
+	// A failing probe right after start is reported as Starting
+	checker.checkPort("fwd")
+	got, _ := checker.GetStatus("fwd")
+	assert.Equal(t, StatusStarting, got)
+
+	status.Store(http.StatusNoContent)
+	checker.checkPort("fwd")
+	got, _ = checker.GetStatus("fwd")
+	assert.Equal(t, StatusHealthy, got)
+}
+
+func TestHTTPProbe_Interval(t *testing.T) {
+	checker, port, status, hits := newProbeChecker(t)
+
+	checker.RegisterWithProbe("fwd", port, &HTTPProbe{Path: "/healthz", Interval: time.Hour, ExpectedStatus: http.StatusOK}, nil)
+	skipGracePeriod(checker, "fwd")
+
+	// The registration check probes once; later checks reuse the result
+	require.Eventually(t, func() bool { return hits.Load() == 1 }, time.Second, 10*time.Millisecond)
+	status.Store(http.StatusInternalServerError)
+	checker.checkPort("fwd")
+	got, _ := checker.GetStatus("fwd")
+	assert.Equal(t, StatusHealthy, got)
+	assert.Equal(t, int32(1), hits.Load())
+
+	// A new connection is probed right away
+	checker.MarkConnected("fwd")
+	require.Eventually(t, func() bool {
+		got, _ := checker.GetStatus("fwd")
+		return got == StatusProbeFailed
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHTTPProbe_SkippedWhenTunnelDown(t *testing.T) {
+	checker, _, _, hits := newProbeChecker(t)
+
+	// Nothing listens on this port once the listener is closed
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	checker.RegisterWithProbe("fwd", port, &HTTPProbe{Path: "/healthz", ExpectedStatus: http.StatusOK}, nil)
+	skipGracePeriod(checker, "fwd")
+
+	checker.checkPort("fwd")
+	got, _ := checker.GetStatus("fwd")
+	assert.Equal(t, StatusUnhealthy, got)
+	assert.Equal(t, int32(0), hits.Load())
+}
+
+func TestCheckPort_ReconnectingPersistsOnFailure(t *testing.T) {
+	checker, _, _, _ := newProbeChecker(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	checker.Register("fwd", port, nil)
+	skipGracePeriod(checker, "fwd")
+	checker.MarkReconnecting("fwd")
+
+	// Failed checks while the worker backs off must not flip the status to
+	// Error, which would overwrite the worker's backoff status text
+	checker.checkPort("fwd")
+	checker.checkPort("fwd")
+	got, _ := checker.GetStatus("fwd")
+	assert.Equal(t, StatusReconnect, got)
+}
+
+func TestCheckPort_ContextNotFoundPersistsOnFailure(t *testing.T) {
+	checker, _, _, _ := newProbeChecker(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	var mu sync.Mutex
+	var calls int
+	var lastMsg string
+	checker.Register("fwd", port, func(_ string, s Status, msg string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if s == StatusContextNotFound {
+			calls++
+			lastMsg = msg
+		}
+	})
+	skipGracePeriod(checker, "fwd")
+	checker.MarkContextNotFound("fwd", `context "dev" not found in kubeconfig`)
+	checker.MarkContextNotFound("fwd", `context "dev" not found in kubeconfig`)
+
+	checker.checkPort("fwd")
+	got, _ := checker.GetStatus("fwd")
+	assert.Equal(t, StatusContextNotFound, got)
+	assert.Equal(t, `context "dev" not found in kubeconfig`, checker.GetAllErrors()["fwd"], "the reason outlasts failed dials")
+
+	mu.Lock()
+	assert.Equal(t, 1, calls, "repeated marks notify once")
+	assert.Equal(t, `context "dev" not found in kubeconfig`, lastMsg)
+	mu.Unlock()
+}
+
+func TestCheckPort_PermissionDeniedPersistsOnFailure(t *testing.T) {
+	checker, _, _, _ := newProbeChecker(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+
+	checker.Register("fwd", port, nil)
+	skipGracePeriod(checker, "fwd")
+	msg := "Permission denied: you need create pods/portforward in dev"
+	checker.MarkPermissionDenied("fwd", msg)
+
+	checker.checkPort("fwd")
+	got, _ := checker.GetStatus("fwd")
+	assert.Equal(t, StatusPermissionDenied, got)
+	assert.Equal(t, msg, checker.GetAllErrors()["fwd"])
+
+	checker.MarkReconnecting("fwd")
+	got, _ = checker.GetStatus("fwd")
+	assert.Equal(t, StatusReconnect, got, "another status from the worker replaces it")
+}
+
+func TestRegisterAt_IPv6Loopback(t *testing.T) {
+	ln, err := net.Listen("tcp", "[::1]:0")
+	if err != nil {
+		t.Skip("IPv6 loopback not available")
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Listener = ln
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	checker := NewCheckerWithOptions(CheckerOptions{
+		Interval: time.Hour,
+		Timeout:  time.Second,
+		Method:   CheckMethodTCPDial,
+	})
+	t.Cleanup(checker.Stop)
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	checker.RegisterAt("fwd", "::1", port, &HTTPProbe{Path: "/healthz", ExpectedStatus: http.StatusOK}, nil)
+	skipGracePeriod(checker, "fwd")
+
+	checker.checkPort("fwd")
+	got, _ := checker.GetStatus("fwd")
+	assert.Equal(t, StatusHealthy, got)
+}
+
+func TestChecker_DelayStart(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close()) // nothing listens, so every check fails
+
+	checker := NewCheckerWithOptions(CheckerOptions{Interval: time.Hour, Timeout: time.Second, Method: CheckMethodTCPDial})
+	t.Cleanup(checker.Stop)
+	checker.Register("fwd", port, func(string, Status, string) {})
+	skipGracePeriod(checker, "fwd")
+
+	checker.checkPort("fwd")
+	got, _ := checker.GetStatus("fwd")
+	assert.Equal(t, StatusUnhealthy, got)
+
+	// A worker waiting its staggered turn stays Starting
+	checker.DelayStart("fwd", 3*startupGracePeriod)
+	checker.checkPort("fwd")
+	got, _ = checker.GetStatus("fwd")
+	assert.Equal(t, StatusStarting, got)
+
+	checker.DelayStart("missing", time.Second) // unknown forwards are ignored
+}
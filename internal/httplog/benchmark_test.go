@@ -69,7 +69,7 @@ func BenchmarkReadBodyLimited(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		// Create a new ReadCloser for each iteration
 		body := io.NopCloser(bytes.NewReader(bodyData))
-		_, _ = transport.readBodyLimited(body, 2048)
+		_, _, _ = transport.readBodyLimited(body, -1, 2048)
 	}
 }
 
@@ -81,7 +81,7 @@ func BenchmarkReadBodyLimitedSmall(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		body := io.NopCloser(bytes.NewReader(bodyData))
-		_, _ = transport.readBodyLimited(body, 1024)
+		_, _, _ = transport.readBodyLimited(body, -1, 1024)
 	}
 }
 
@@ -93,7 +93,7 @@ func BenchmarkReadBodyLimitedLarge(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		body := io.NopCloser(bytes.NewReader(bodyData))
-		_, _ = transport.readBodyLimited(body, 65536)
+		_, _, _ = transport.readBodyLimited(body, -1, 65536)
 	}
 }
 
@@ -144,7 +144,7 @@ func BenchmarkTruncateBody(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = truncateBody(body, maxLen)
+		_ = truncateBody(body, len(body), maxLen)
 	}
 }
 
@@ -204,7 +204,7 @@ func BenchmarkReadBodyLimitedParallel(b *testing.B) {
 	b.RunParallel(func(pb *testing.PB) {
 		for pb.Next() {
 			body := io.NopCloser(bytes.NewReader(bodyData))
-			_, _ = transport.readBodyLimited(body, 8192)
+			_, _, _ = transport.readBodyLimited(body, -1, 8192)
 		}
 	})
 }
@@ -265,6 +265,6 @@ func BenchmarkCompleteFlow(b *testing.B) {
 
 		// Simulate body reading
 		body := io.NopCloser(bytes.NewReader(bodyData))
-		_, _ = transport.readBodyLimited(body, 2048)
+		_, _, _ = transport.readBodyLimited(body, -1, 2048)
 	}
 }
@@ -0,0 +1,133 @@
+package httplog
+
+import (
+	"encoding/binary"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// grpcFrameHeaderLen is the size of the prefix in front of every gRPC
+// message: a compressed flag byte and a big-endian uint32 length.
+const grpcFrameHeaderLen = 5
+
+// grpcStatusNames maps gRPC status codes to their canonical names
+var grpcStatusNames = [...]string{
+	"OK", "CANCELLED", "UNKNOWN", "INVALID_ARGUMENT", "DEADLINE_EXCEEDED",
+	"NOT_FOUND", "ALREADY_EXISTS", "PERMISSION_DENIED", "RESOURCE_EXHAUSTED",
+	"FAILED_PRECONDITION", "ABORTED", "OUT_OF_RANGE", "UNIMPLEMENTED",
+	"INTERNAL", "UNAVAILABLE", "DATA_LOSS", "UNAUTHENTICATED",
+}
+
+// IsGRPCContentType reports whether contentType is a gRPC content type, such
+// as application/grpc or application/grpc+proto. gRPC-Web uses a different
+// wire format and is not included.
+func IsGRPCContentType(contentType string) bool {
+	rest, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(contentType)), "application/grpc")
+	return ok && (rest == "" || rest[0] == '+' || rest[0] == ';')
+}
+
+// GRPCStatusName returns the canonical name of a gRPC status code, e.g.
+// "NOT_FOUND" for "5", or "" for codes outside the standard set.
+func GRPCStatusName(code string) string {
+	n, err := strconv.Atoi(code)
+	if err != nil || n < 0 || n >= len(grpcStatusNames) {
+		return ""
+	}
+	return grpcStatusNames[n]
+}
+
+// GRPCFrame is one length-prefixed message of a gRPC body
+type GRPCFrame struct {
+	Data       []byte // message bytes; shorter than Length if the capture ended first
+	Length     int    // declared message length
+	Compressed bool
+}
+
+// ParseGRPCFrames splits a captured gRPC body into its messages. A body cut
+// short by the log yields a final frame with partial Data; bytes too few to
+// form a frame header are ignored.
+func ParseGRPCFrames(body []byte) []GRPCFrame {
+	var frames []GRPCFrame
+	for len(body) >= grpcFrameHeaderLen {
+		length := int(binary.BigEndian.Uint32(body[1:grpcFrameHeaderLen]))
+		frame := GRPCFrame{Length: length, Compressed: body[0]&1 == 1}
+		body = body[grpcFrameHeaderLen:]
+		if length > len(body) {
+			length = len(body)
+		}
+		frame.Data, body = body[:length], body[length:]
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// grpcStatus returns the grpc-status and grpc-message of a response. They
+// normally arrive as trailers, or as headers for a trailers-only response.
+func grpcStatus(resp *http.Response) (status, message string) {
+	for _, h := range []http.Header{resp.Trailer, resp.Header} {
+		if status = h.Get("Grpc-Status"); status != "" {
+			return status, h.Get("Grpc-Message")
+		}
+	}
+	return "", ""
+}
+
+// streamCapture passes a body through unchanged while keeping up to max bytes
+// of it for the log. Unlike readBodyLimited it never reads ahead of the
+// consumer, so streaming calls keep flowing. done, if set, runs once when the
+// body ends or is closed.
+type streamCapture struct {
+	io.ReadCloser
+	done     func()
+	buf      []byte
+	max      int
+	size     int
+	once     sync.Once
+	mu       sync.Mutex
+	finished bool
+}
+
+func (c *streamCapture) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.mu.Lock()
+	c.size += n
+	if room := c.max - len(c.buf); room > 0 {
+		c.buf = append(c.buf, p[:min(n, room)]...)
+	}
+	if err != nil {
+		c.finished = true
+	}
+	c.mu.Unlock()
+	if err != nil {
+		c.finish()
+	}
+	return n, err
+}
+
+func (c *streamCapture) Close() error {
+	err := c.ReadCloser.Close()
+	c.finish()
+	return err
+}
+
+func (c *streamCapture) finish() {
+	if c.done != nil {
+		c.once.Do(c.done)
+	}
+}
+
+// captured returns the bytes kept so far and the full body size, or -1 while
+// the body is still streaming.
+func (c *streamCapture) captured() ([]byte, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	body := make([]byte, len(c.buf))
+	copy(body, c.buf)
+	if !c.finished {
+		return body, -1
+	}
+	return body, c.size
+}
@@ -0,0 +1,148 @@
+package httplog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// grpcFrame length-prefixes msg the way gRPC does on the wire
+func grpcFrame(msg string) []byte {
+	frame := make([]byte, grpcFrameHeaderLen, grpcFrameHeaderLen+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	return append(frame, msg...)
+}
+
+func TestIsGRPCContentType(t *testing.T) {
+	assert.True(t, IsGRPCContentType("application/grpc"))
+	assert.True(t, IsGRPCContentType("application/grpc+proto"))
+	assert.True(t, IsGRPCContentType("Application/GRPC; charset=utf-8"))
+	assert.False(t, IsGRPCContentType("application/grpc-web"))
+	assert.False(t, IsGRPCContentType("application/json"))
+	assert.False(t, IsGRPCContentType(""))
+}
+
+func TestGRPCStatusName(t *testing.T) {
+	assert.Equal(t, "OK", GRPCStatusName("0"))
+	assert.Equal(t, "NOT_FOUND", GRPCStatusName("5"))
+	assert.Equal(t, "UNAUTHENTICATED", GRPCStatusName("16"))
+	assert.Empty(t, GRPCStatusName("17"))
+	assert.Empty(t, GRPCStatusName("x"))
+}
+
+func TestParseGRPCFrames(t *testing.T) {
+	body := append(grpcFrame("hello"), grpcFrame("")...)
+	compressed := grpcFrame("zzzz")
+	compressed[0] = 1
+	body = append(body, compressed...)
+
+	frames := ParseGRPCFrames(body)
+	require.Len(t, frames, 3)
+	assert.Equal(t, GRPCFrame{Data: []byte("hello"), Length: 5}, frames[0])
+	assert.Equal(t, 0, frames[1].Length)
+	assert.True(t, frames[2].Compressed)
+
+	// A capture cut short mid-message keeps what it has
+	frames = ParseGRPCFrames(grpcFrame("truncated message")[:10])
+	require.Len(t, frames, 1)
+	assert.Equal(t, 17, frames[0].Length)
+	assert.Equal(t, []byte("trunc"), frames[0].Data)
+
+	assert.Empty(t, ParseGRPCFrames([]byte{0, 0}))
+}
+
+func TestRoundTrip_GRPC(t *testing.T) {
+	var received []byte
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+		_, _ = w.Write(grpcFrame("pong"))
+		w.Header().Set("Grpc-Status", "5")
+		w.Header().Set("Grpc-Message", "user%20not%20found")
+	}))
+	backend.Config.Protocols = new(http.Protocols)
+	backend.Config.Protocols.SetUnencryptedHTTP2(true)
+	backend.Start()
+	defer backend.Close()
+
+	p, _ := makeProxy(t, backend, struct {
+		filterPath  string
+		includeHdrs bool
+		maxBodyLen  int
+	}{includeHdrs: true})
+	entries := make(chan Entry, 2)
+	p.logger.AddCallback(func(e Entry) { entries <- e })
+
+	client := &http.Client{Transport: newH2CTransport()}
+	req, err := http.NewRequest(http.MethodPost, proxyURL(p)+"/users.v1.Users/Get", bytes.NewReader(grpcFrame("ping")))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("Te", "trailers")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, 2, resp.ProtoMajor, "the proxy speaks HTTP/2 to gRPC clients")
+	assert.Equal(t, grpcFrame("pong"), body)
+	assert.Equal(t, "5", resp.Trailer.Get("Grpc-Status"), "trailers reach the client")
+	assert.Equal(t, grpcFrame("ping"), received)
+
+	next := func() Entry {
+		select {
+		case e := <-entries:
+			return e
+		case <-time.After(2 * time.Second):
+			t.Fatal("no log entry")
+			return Entry{}
+		}
+	}
+
+	reqEntry := next()
+	assert.Equal(t, "request", reqEntry.Direction)
+	assert.True(t, reqEntry.GRPC)
+	assert.Equal(t, string(grpcFrame("ping")), reqEntry.Body)
+	assert.Equal(t, len(grpcFrame("ping")), reqEntry.BodySize)
+
+	respEntry := next()
+	assert.Equal(t, "response", respEntry.Direction)
+	assert.True(t, respEntry.GRPC)
+	assert.Equal(t, http.StatusOK, respEntry.StatusCode)
+	assert.Equal(t, "5", respEntry.GRPCStatus)
+	assert.Equal(t, "user not found", respEntry.GRPCMessage)
+	assert.Equal(t, string(grpcFrame("pong")), respEntry.Body)
+	assert.Equal(t, "5", respEntry.Headers["Grpc-Status"], "trailers are logged with the headers")
+	assert.False(t, respEntry.Timestamp.Before(reqEntry.Timestamp))
+}
+
+func TestStreamCapture(t *testing.T) {
+	var done int
+	c := &streamCapture{ReadCloser: io.NopCloser(bytes.NewReader([]byte("0123456789"))), max: 4}
+	c.done = func() { done++ }
+
+	buf := make([]byte, 3)
+	_, _ = c.Read(buf)
+	body, size := c.captured()
+	assert.Equal(t, []byte("012"), body)
+	assert.Equal(t, -1, size, "size is unknown while streaming")
+
+	rest, err := io.ReadAll(c)
+	require.NoError(t, err)
+	assert.Equal(t, "3456789", string(rest), "the body passes through whole")
+	require.NoError(t, c.Close())
+
+	body, size = c.captured()
+	assert.Equal(t, []byte("0123"), body)
+	assert.Equal(t, 10, size)
+	assert.Equal(t, 1, done)
+}
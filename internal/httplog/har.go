@@ -0,0 +1,293 @@
+package httplog
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// HAR is the root of an HTTP Archive 1.2 document.
+// See http://www.softwareishard.com/blog/har-12-spec/
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog holds the archived entries.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator identifies the application that produced the archive.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a single request/response pair.
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Time            int64       `json:"time"`
+}
+
+// HARRequest describes the captured request.
+type HARRequest struct {
+	PostData    *HARPostData `json:"postData,omitempty"`
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []HARNameVal `json:"cookies"`
+	Headers     []HARNameVal `json:"headers"`
+	QueryString []HARNameVal `json:"queryString"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+}
+
+// HARResponse describes the captured response.
+type HARResponse struct {
+	StatusText  string       `json:"statusText"`
+	HTTPVersion string       `json:"httpVersion"`
+	RedirectURL string       `json:"redirectURL"`
+	Cookies     []HARNameVal `json:"cookies"`
+	Headers     []HARNameVal `json:"headers"`
+	Content     HARContent   `json:"content"`
+	Status      int          `json:"status"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+}
+
+// HARNameVal is a header, cookie or query string pair.
+type HARNameVal struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is the request body.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// HARContent is the response body. Encoding is "base64" for binary bodies;
+// Comment notes bodies that were truncated at capture.
+type HARContent struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	Size     int    `json:"size"`
+}
+
+// HARTimings breaks down the request time. Only the total latency is
+// captured, so it is reported as wait time.
+type HARTimings struct {
+	Send    int64 `json:"send"`
+	Wait    int64 `json:"wait"`
+	Receive int64 `json:"receive"`
+}
+
+// NewHAR builds a HAR archive from captured log entries. Request and
+// response entries are paired by RequestID; requests still awaiting a
+// response and error entries are skipped. baseURL (e.g.
+// "http://localhost:8080") is prepended to each request path, and
+// appVersion is recorded as the creator version.
+func NewHAR(entries []Entry, baseURL, appVersion string) *HAR {
+	requests := make(map[string]Entry, len(entries))
+	for _, e := range entries {
+		if e.Direction == "request" {
+			requests[e.RequestID] = e
+		}
+	}
+
+	harEntries := make([]HAREntry, 0, len(entries)/2)
+	for _, resp := range entries {
+		if resp.Direction != "response" {
+			continue
+		}
+		req, ok := requests[resp.RequestID]
+		if !ok {
+			continue
+		}
+		harEntries = append(harEntries, newHAREntry(req, resp, baseURL))
+	}
+
+	return &HAR{
+		Log: HARLog{
+			Version: "1.2",
+			Creator: HARCreator{Name: "kportal", Version: appVersion},
+			Entries: harEntries,
+		},
+	}
+}
+
+// WriteHARFile writes the HAR archive for entries to path.
+// Returns the number of request/response pairs written.
+func WriteHARFile(path string, entries []Entry, baseURL, appVersion string) (int, error) {
+	har := NewHAR(entries, baseURL, appVersion)
+
+	data, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode HAR: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return 0, fmt.Errorf("failed to write HAR file: %w", err)
+	}
+
+	return len(har.Log.Entries), nil
+}
+
+// newHAREntry converts a request/response pair into a HAR entry.
+func newHAREntry(req, resp Entry, baseURL string) HAREntry {
+	started := req.Timestamp
+	if started.IsZero() {
+		started = resp.Timestamp.Add(-time.Duration(resp.LatencyMs) * time.Millisecond)
+	}
+
+	request := HARRequest{
+		Method:      req.Method,
+		URL:         strings.TrimSuffix(baseURL, "/") + req.Path,
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []HARNameVal{},
+		Headers:     harHeaders(req.Headers),
+		QueryString: []HARNameVal{},
+		HeadersSize: -1,
+		BodySize:    req.BodySize,
+	}
+	if req.Body != "" {
+		body, note := SplitTruncated(req.Body)
+		text, encoding := harBody(DecodeBody(body, req.Headers))
+		request.PostData = &HARPostData{
+			MimeType: headerValue(req.Headers, "Content-Type"),
+			Text:     text,
+			Comment:  note,
+		}
+		if encoding != "" {
+			// postData has no encoding field; note it for consumers
+			request.PostData.Comment = strings.TrimSpace(encoding + " " + note)
+		}
+	}
+
+	body, note := SplitTruncated(resp.Body)
+	text, encoding := harBody(DecodeBody(body, resp.Headers))
+	contentSize := resp.BodySize
+	if contentSize < 0 {
+		contentSize = len(body) // Truncated with unknown length
+	}
+	response := HARResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: "HTTP/1.1",
+		Cookies:     []HARNameVal{},
+		Headers:     harHeaders(resp.Headers),
+		Content: HARContent{
+			Size:     contentSize,
+			MimeType: headerValue(resp.Headers, "Content-Type"),
+			Text:     text,
+			Encoding: encoding,
+			Comment:  note,
+		},
+		RedirectURL: headerValue(resp.Headers, "Location"),
+		HeadersSize: -1,
+		BodySize:    resp.BodySize,
+	}
+
+	return HAREntry{
+		StartedDateTime: started.UTC().Format(time.RFC3339Nano),
+		Time:            resp.LatencyMs,
+		Request:         request,
+		Response:        response,
+		Timings:         HARTimings{Send: 0, Wait: resp.LatencyMs, Receive: 0},
+	}
+}
+
+// harBody returns body as HAR text, base64-encoding it if it is not valid UTF-8.
+func harBody(body string) (text, encoding string) {
+	if utf8.ValidString(body) {
+		return body, ""
+	}
+	return base64.StdEncoding.EncodeToString([]byte(body)), "base64"
+}
+
+// harHeaders converts a flattened header map into a name-sorted HAR list.
+func harHeaders(h map[string]string) []HARNameVal {
+	headers := make([]HARNameVal, 0, len(h))
+	for name, value := range h {
+		headers = append(headers, HARNameVal{Name: name, Value: value})
+	}
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].Name < headers[j].Name
+	})
+	return headers
+}
+
+// headerValue looks up a header case-insensitively.
+func headerValue(h map[string]string, name string) string {
+	if v, ok := h[name]; ok {
+		return v
+	}
+	for k, v := range h {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+// DecodeBody reverses the Content-Encoding of a captured body.
+// Unsupported encodings and corrupt data return the body unchanged.
+func DecodeBody(body string, headers map[string]string) string {
+	enc := headerValue(headers, "Content-Encoding")
+	if enc == "" || body == "" {
+		return body
+	}
+
+	var reader io.ReadCloser
+	switch enc {
+	case "gzip":
+		gz, err := gzip.NewReader(bytes.NewReader([]byte(body)))
+		if err != nil {
+			return body
+		}
+		reader = gz
+	case "deflate":
+		reader = flate.NewReader(bytes.NewReader([]byte(body)))
+	case "br":
+		reader = io.NopCloser(brotli.NewReader(bytes.NewReader([]byte(body))))
+	case "zstd":
+		zr, err := zstd.NewReader(bytes.NewReader([]byte(body)), zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return body
+		}
+		reader = zr.IOReadCloser()
+	default:
+		// compress (LZW) is obsolete and not decoded
+		return body
+	}
+	defer func() { _ = reader.Close() }()
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return body
+	}
+	return string(decoded)
+}
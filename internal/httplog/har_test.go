@@ -0,0 +1,172 @@
+package httplog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipString(t *testing.T, s string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.String()
+}
+
+func TestNewHAR_PairsRequestsAndResponses(t *testing.T) {
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	entries := []Entry{
+		{
+			Timestamp: started,
+			RequestID: "1",
+			Direction: "request",
+			Method:    "POST",
+			Path:      "/api/users",
+			Headers:   map[string]string{"Content-Type": "application/json", "Accept": "*/*"},
+			Body:      `{"name":"a"}`,
+			BodySize:  12,
+		},
+		{
+			RequestID: "2",
+			Direction: "request",
+			Method:    "GET",
+			Path:      "/pending",
+		},
+		{
+			Timestamp:  started.Add(42 * time.Millisecond),
+			RequestID:  "1",
+			Direction:  "response",
+			Method:     "POST",
+			Path:       "/api/users",
+			StatusCode: 201,
+			Headers:    map[string]string{"Content-Type": "application/json", "Content-Encoding": "gzip"},
+			Body:       gzipString(t, `{"id":1}`),
+			BodySize:   28,
+			LatencyMs:  42,
+		},
+		{
+			RequestID: "3",
+			Direction: "error",
+			Method:    "GET",
+			Path:      "/broken",
+			Error:     "connection refused",
+		},
+	}
+
+	har := NewHAR(entries, "http://localhost:8080/", "1.2.3")
+
+	assert.Equal(t, "1.2", har.Log.Version)
+	assert.Equal(t, HARCreator{Name: "kportal", Version: "1.2.3"}, har.Log.Creator)
+	require.Len(t, har.Log.Entries, 1, "pending requests and errors must be skipped")
+
+	e := har.Log.Entries[0]
+	assert.Equal(t, "2026-01-02T03:04:05Z", e.StartedDateTime)
+	assert.Equal(t, int64(42), e.Time)
+	assert.Equal(t, HARTimings{Wait: 42}, e.Timings)
+
+	assert.Equal(t, "POST", e.Request.Method)
+	assert.Equal(t, "http://localhost:8080/api/users", e.Request.URL)
+	assert.Equal(t, []HARNameVal{
+		{Name: "Accept", Value: "*/*"},
+		{Name: "Content-Type", Value: "application/json"},
+	}, e.Request.Headers)
+	require.NotNil(t, e.Request.PostData)
+	assert.Equal(t, `{"name":"a"}`, e.Request.PostData.Text)
+	assert.Equal(t, "application/json", e.Request.PostData.MimeType)
+
+	assert.Equal(t, 201, e.Response.Status)
+	assert.Equal(t, "Created", e.Response.StatusText)
+	assert.Equal(t, `{"id":1}`, e.Response.Content.Text, "body should be decompressed")
+	assert.Empty(t, e.Response.Content.Encoding)
+	assert.Equal(t, 28, e.Response.Content.Size)
+}
+
+func TestNewHAR_BinaryBodyIsBase64(t *testing.T) {
+	binary := string([]byte{0x89, 'P', 'N', 'G', 0xff, 0x00})
+	entries := []Entry{
+		{RequestID: "1", Direction: "request", Method: "GET", Path: "/logo.png"},
+		{
+			RequestID:  "1",
+			Direction:  "response",
+			StatusCode: 200,
+			Headers:    map[string]string{"Content-Type": "image/png"},
+			Body:       binary,
+			BodySize:   len(binary),
+		},
+	}
+
+	har := NewHAR(entries, "http://localhost:8080", "dev")
+	require.Len(t, har.Log.Entries, 1)
+
+	content := har.Log.Entries[0].Response.Content
+	assert.Equal(t, "base64", content.Encoding)
+	decoded, err := base64.StdEncoding.DecodeString(content.Text)
+	require.NoError(t, err)
+	assert.Equal(t, binary, string(decoded))
+	assert.Nil(t, har.Log.Entries[0].Request.PostData)
+}
+
+func TestWriteHARFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.har")
+	entries := []Entry{
+		{RequestID: "1", Direction: "request", Method: "GET", Path: "/"},
+		{RequestID: "1", Direction: "response", StatusCode: 204},
+	}
+
+	count, err := WriteHARFile(path, entries, "http://localhost:9000", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var har HAR
+	require.NoError(t, json.Unmarshal(data, &har))
+	require.Len(t, har.Log.Entries, 1)
+	assert.Equal(t, "http://localhost:9000/", har.Log.Entries[0].Request.URL)
+
+	// Required HAR arrays must be present even when empty
+	assert.Contains(t, string(data), `"cookies": []`)
+	assert.Contains(t, string(data), `"queryString": []`)
+}
+
+func TestDecodeBody(t *testing.T) {
+	assert.Equal(t, "plain", DecodeBody("plain", nil))
+	assert.Equal(t, "hello", DecodeBody(gzipString(t, "hello"), map[string]string{"Content-Encoding": "gzip"}))
+	assert.Equal(t, "hello", DecodeBody(gzipString(t, "hello"), map[string]string{"content-encoding": "gzip"}))
+	assert.Equal(t, "not gzip", DecodeBody("not gzip", map[string]string{"Content-Encoding": "gzip"}))
+	assert.Equal(t, "lzw", DecodeBody("lzw", map[string]string{"Content-Encoding": "compress"}))
+}
+
+func TestDecodeBody_Brotli(t *testing.T) {
+	var buf bytes.Buffer
+	w := brotli.NewWriter(&buf)
+	_, err := w.Write([]byte(`{"hello":"brotli"}`))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, `{"hello":"brotli"}`, DecodeBody(buf.String(), map[string]string{"Content-Encoding": "br"}))
+}
+
+func TestDecodeBody_Zstd(t *testing.T) {
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := enc.EncodeAll([]byte(`{"hello":"zstd"}`), nil)
+	require.NoError(t, enc.Close())
+
+	assert.Equal(t, `{"hello":"zstd"}`, DecodeBody(string(compressed), map[string]string{"Content-Encoding": "zstd"}))
+	assert.Equal(t, "not zstd", DecodeBody("not zstd", map[string]string{"Content-Encoding": "zstd"}))
+}
@@ -7,16 +7,19 @@
 //   - Configurable body size limits to prevent memory issues
 //   - Callback-based notifications for real-time log viewing
 //   - Thread-safe operation for concurrent forwards
+//   - Buffered JSON-lines file output with size-based rotation
 //
 // Bodies are truncated if they exceed the configured maximum size
-// (default: 1MB) and marked as truncated in the log entry.
+// (default: 64KB) and end with a "...[truncated N bytes]" marker; BodySize
+// always records the full size.
 package httplog
 
 import (
 	"bytes"
 	"encoding/json"
 	"io"
-	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -31,18 +34,21 @@ var logBufferPool = sync.Pool{
 
 // Entry represents a single HTTP log entry
 type Entry struct {
-	Timestamp  time.Time         `json:"timestamp"`
-	Headers    map[string]string `json:"headers,omitempty"`
-	ForwardID  string            `json:"forward_id"`
-	RequestID  string            `json:"request_id"`
-	Direction  string            `json:"direction"`
-	Method     string            `json:"method,omitempty"`
-	Path       string            `json:"path,omitempty"`
-	Body       string            `json:"body,omitempty"`
-	Error      string            `json:"error,omitempty"`
-	StatusCode int               `json:"status_code,omitempty"`
-	BodySize   int               `json:"body_size"`
-	LatencyMs  int64             `json:"latency_ms,omitempty"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	ForwardID   string            `json:"forward_id"`
+	RequestID   string            `json:"request_id"`
+	Direction   string            `json:"direction"`
+	Method      string            `json:"method,omitempty"`
+	Path        string            `json:"path,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	GRPCStatus  string            `json:"grpc_status,omitempty"`  // gRPC status code from the response trailers
+	GRPCMessage string            `json:"grpc_message,omitempty"` // decoded grpc-message, if any
+	StatusCode  int               `json:"status_code,omitempty"`
+	BodySize    int               `json:"body_size"` // Full body size, -1 if truncated with unknown length
+	LatencyMs   int64             `json:"latency_ms,omitempty"`
+	GRPC        bool              `json:"grpc,omitempty"` // body is a sequence of length-prefixed gRPC messages
 }
 
 // LogCallback is a function that receives log entries
@@ -51,7 +57,7 @@ type LogCallback func(entry Entry)
 // Logger writes HTTP log entries to an output stream
 type Logger struct {
 	output     io.Writer
-	file       *os.File
+	file       *rotatingFile
 	forwardID  string
 	callbacks  []LogCallback
 	maxBodyLen int
@@ -62,6 +68,13 @@ type Logger struct {
 // If logFile is empty, logs only go to registered callbacks (no file output)
 // This prevents stdout corruption when running in TUI mode
 func NewLogger(forwardID, logFile string, maxBodyLen int) (*Logger, error) {
+	return NewLoggerWithRotation(forwardID, logFile, maxBodyLen, RotateOptions{})
+}
+
+// NewLoggerWithRotation creates a new HTTP logger whose log file is rotated
+// according to rotation. File writes are buffered and performed off the
+// caller's goroutine; they are flushed when the logger is closed.
+func NewLoggerWithRotation(forwardID, logFile string, maxBodyLen int, rotation RotateOptions) (*Logger, error) {
 	l := &Logger{
 		forwardID:  forwardID,
 		maxBodyLen: maxBodyLen,
@@ -72,8 +85,7 @@ func NewLogger(forwardID, logFile string, maxBodyLen int) (*Logger, error) {
 		// Log entries are delivered via callbacks to the UI
 		l.output = io.Discard
 	} else {
-		// #nosec G304 -- logFile is from config validation, not arbitrary user input
-		f, err := os.OpenFile(logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		f, err := openRotatingFile(logFile, rotation)
 		if err != nil {
 			return nil, err
 		}
@@ -106,10 +118,24 @@ var stringBuilderPool = sync.Pool{
 	},
 }
 
-// truncateBody truncates a body string to maxLen, adding a suffix if truncated.
+// truncatedMarkerPrefix starts the note appended to truncated bodies.
+const truncatedMarkerPrefix = "...[truncated"
+
+// truncateBody cuts body to maxLen and appends a marker with the number of
+// bytes dropped. size is the full body size as reported in Entry.BodySize;
+// when it exceeds the captured body (or is -1 for unknown), the body was
+// already cut short by the proxy and is marked even if within maxLen.
 // Uses a pooled buffer to avoid allocations during truncation.
-func truncateBody(body string, maxLen int) string {
-	if len(body) <= maxLen {
+func truncateBody(body string, size, maxLen int) string {
+	if size >= 0 && size < len(body) {
+		size = len(body) // Size not reported, use what was captured
+	}
+
+	kept := body
+	if len(kept) > maxLen {
+		kept = kept[:maxLen]
+	}
+	if size >= 0 && size <= len(kept) {
 		return body
 	}
 
@@ -119,20 +145,38 @@ func truncateBody(body string, maxLen int) string {
 	defer stringBuilderPool.Put(buf)
 
 	// Write truncated content
-	buf.WriteString(body[:maxLen])
-	buf.WriteString("...(truncated)")
+	buf.WriteString(kept)
+	buf.WriteString(truncatedMarkerPrefix)
+	if size >= 0 {
+		buf.WriteByte(' ')
+		buf.WriteString(strconv.Itoa(size - len(kept)))
+		buf.WriteString(" bytes")
+	}
+	buf.WriteByte(']')
 	return buf.String()
 }
 
+// SplitTruncated separates a captured body from its truncation marker.
+// Returns the body content and the marker without its leading "...", e.g.
+// "[truncated 1024 bytes]", or an empty note if the body is complete.
+func SplitTruncated(body string) (content, note string) {
+	idx := strings.LastIndex(body, truncatedMarkerPrefix)
+	if idx < 0 || !strings.HasSuffix(body, "]") {
+		return body, ""
+	}
+	return body[:idx], body[idx+len("..."):]
+}
+
 // Log writes a log entry as JSON using a pooled buffer to reduce allocations.
+// The entry is stamped with the current time unless it already carries one.
 func (l *Logger) Log(entry Entry) error {
 	entry.ForwardID = l.forwardID
-	entry.Timestamp = time.Now()
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
 
 	// Truncate body if too large using pooled buffer
-	if len(entry.Body) > l.maxBodyLen {
-		entry.Body = truncateBody(entry.Body, l.maxBodyLen)
-	}
+	entry.Body = truncateBody(entry.Body, entry.BodySize, l.maxBodyLen)
 
 	// Get a buffer from the pool
 	buf := logBufferPool.Get().(*bytes.Buffer)
@@ -157,7 +201,7 @@ func (l *Logger) Log(entry Entry) error {
 	return err
 }
 
-// Close closes the logger
+// Close flushes pending file writes and closes the logger
 func (l *Logger) Close() error {
 	if l.file != nil {
 		return l.file.Close()
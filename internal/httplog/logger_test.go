@@ -86,7 +86,7 @@ func TestLogger_Log(t *testing.T) {
 		RequestID: "req-1",
 		Method:    "POST",
 		Path:      "/api/users",
-		BodySize:  42,
+		BodySize:  15,
 		Body:      `{"name":"test"}`,
 	})
 	require.NoError(t, err)
@@ -101,7 +101,7 @@ func TestLogger_Log(t *testing.T) {
 	assert.Equal(t, "req-1", entry.RequestID)
 	assert.Equal(t, "POST", entry.Method)
 	assert.Equal(t, "/api/users", entry.Path)
-	assert.Equal(t, 42, entry.BodySize)
+	assert.Equal(t, 15, entry.BodySize)
 	assert.Equal(t, `{"name":"test"}`, entry.Body)
 	assert.False(t, entry.Timestamp.IsZero())
 }
@@ -168,6 +168,7 @@ func TestLogger_BodyTruncation(t *testing.T) {
 		name        string
 		body        string
 		maxBodyLen  int
+		bodySize    int
 		expectTrunc bool
 	}{
 		{name: "body under limit", maxBodyLen: 100, body: "short", expectTrunc: false},
@@ -175,6 +176,8 @@ func TestLogger_BodyTruncation(t *testing.T) {
 		{name: "body over limit", maxBodyLen: 5, body: "this is too long", expectTrunc: true},
 		{name: "empty body", maxBodyLen: 100, body: "", expectTrunc: false},
 		{name: "zero max", maxBodyLen: 0, body: "any", expectTrunc: true},
+		{name: "body size over captured body", maxBodyLen: 100, body: "short", bodySize: 1000, expectTrunc: true},
+		{name: "unknown body size", maxBodyLen: 100, body: "short", bodySize: -1, expectTrunc: true},
 	}
 
 	for _, tt := range tests {
@@ -186,13 +189,13 @@ func TestLogger_BodyTruncation(t *testing.T) {
 				output:     &buf,
 			}
 
-			_ = l.Log(Entry{Body: tt.body})
+			_ = l.Log(Entry{Body: tt.body, BodySize: tt.bodySize})
 
 			var entry Entry
 			_ = json.Unmarshal(buf.Bytes(), &entry)
 
 			if tt.expectTrunc {
-				assert.Contains(t, entry.Body, "...(truncated)")
+				assert.Contains(t, entry.Body, "...[truncated")
 			} else {
 				assert.NotContains(t, entry.Body, "truncated")
 			}
@@ -200,6 +203,22 @@ func TestLogger_BodyTruncation(t *testing.T) {
 	}
 }
 
+// TestTruncateBody_Marker tests the truncation marker records dropped bytes
+func TestTruncateBody_Marker(t *testing.T) {
+	assert.Equal(t, "abc...[truncated 7 bytes]", truncateBody("abcdefghij", 10, 3))
+	assert.Equal(t, "abc...[truncated 97 bytes]", truncateBody("abc", 100, 10))
+	assert.Equal(t, "abc...[truncated]", truncateBody("abc", -1, 10))
+	assert.Equal(t, "abc", truncateBody("abc", 3, 10))
+
+	content, note := SplitTruncated("abc...[truncated 97 bytes]")
+	assert.Equal(t, "abc", content)
+	assert.Equal(t, "[truncated 97 bytes]", note)
+
+	content, note = SplitTruncated("complete body")
+	assert.Equal(t, "complete body", content)
+	assert.Empty(t, note)
+}
+
 // TestLogger_Callbacks tests callback registration and invocation
 func TestLogger_Callbacks(t *testing.T) {
 	l := &Logger{
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/localaddr"
 	"github.com/lukaszraczylo/kportal/internal/logger"
 )
 
@@ -39,10 +41,12 @@ var readBufferPool = sync.Pool{
 
 // Proxy is an HTTP reverse proxy with logging capabilities
 type Proxy struct {
-	listener     net.Listener
 	logger       *Logger
 	server       *http.Server
+	onStatus     func(status int) // optional: sees the status of every proxied response
+	listeners    []net.Listener   // one per address of bindAddress
 	forwardID    string
+	bindAddress  string
 	filterPath   string
 	localPort    int
 	targetPort   int
@@ -59,13 +63,18 @@ func NewProxy(fwd *config.Forward, targetPort int) (*Proxy, error) {
 		return nil, fmt.Errorf("HTTP log config is nil")
 	}
 
-	logger, err := NewLogger(fwd.ID(), httpCfg.LogFile, fwd.GetHTTPLogMaxBodySize())
+	rotation := RotateOptions{
+		MaxSize:  fwd.GetHTTPLogMaxFileSize(),
+		MaxFiles: fwd.GetHTTPLogMaxFiles(),
+	}
+	logger, err := NewLoggerWithRotation(fwd.ID(), httpCfg.LogFile, fwd.GetHTTPLogMaxBodySize(), rotation)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create logger: %w", err)
 	}
 
 	return &Proxy{
 		localPort:   fwd.LocalPort,
+		bindAddress: fwd.BindAddress,
 		targetPort:  targetPort,
 		logger:      logger,
 		forwardID:   fwd.ID(),
@@ -74,6 +83,20 @@ func NewProxy(fwd *config.Forward, targetPort int) (*Proxy, error) {
 	}, nil
 }
 
+// SetStatusObserver registers fn to be called with the status code of every
+// proxied response, including paths excluded by filterPath. Upstream errors
+// are reported as 502. Must be called before Start.
+func (p *Proxy) SetStatusObserver(fn func(status int)) {
+	p.onStatus = fn
+}
+
+// observeStatus reports a response status to the observer, if any.
+func (p *Proxy) observeStatus(status int) {
+	if p.onStatus != nil {
+		p.onStatus(status)
+	}
+}
+
 // Start starts the HTTP proxy server
 func (p *Proxy) Start() error {
 	p.mu.Lock()
@@ -82,13 +105,13 @@ func (p *Proxy) Start() error {
 		return fmt.Errorf("proxy already running")
 	}
 
-	// Create listener
-	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", p.localPort))
+	// Create listeners, on both loopbacks unless a bind address is set
+	listeners, err := localaddr.Listen(p.bindAddress, p.localPort)
 	if err != nil {
 		p.mu.Unlock()
 		return fmt.Errorf("failed to listen on port %d: %w", p.localPort, err)
 	}
-	p.listener = ln
+	p.listeners = listeners
 
 	// Create reverse proxy
 	director := func(req *http.Request) {
@@ -99,30 +122,39 @@ func (p *Proxy) Start() error {
 	proxy := &httputil.ReverseProxy{
 		Director: director,
 		Transport: &loggingTransport{
-			proxy:     p,
-			transport: http.DefaultTransport,
+			proxy:         p,
+			transport:     http.DefaultTransport,
+			grpcTransport: newH2CTransport(),
 		},
 		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
 			p.logError(r, err)
+			p.observeStatus(http.StatusBadGateway)
 			w.WriteHeader(http.StatusBadGateway)
 			_, _ = w.Write([]byte("Proxy error: " + err.Error()))
 		},
 	}
 
+	// Accept cleartext HTTP/2 alongside HTTP/1.1, as gRPC clients require it
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
 	p.server = &http.Server{
 		Handler:           proxy,
 		ReadHeaderTimeout: 10 * time.Second,
+		Protocols:         protocols,
 	}
 
 	p.running = true
 	p.mu.Unlock()
 
-	// Start serving (blocking)
-	go func() {
-		if err := p.server.Serve(ln); err != nil && err != http.ErrServerClosed {
-			logger.Debug("HTTP proxy serve error (will be replaced on reconnect)", map[string]any{"error": err.Error()})
-		}
-	}()
+	// Serve every listener; Shutdown closes them all
+	for _, ln := range listeners {
+		go func(ln net.Listener) {
+			if err := p.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+				logger.Debug("HTTP proxy serve error (will be replaced on reconnect)", map[string]any{"error": err.Error()})
+			}
+		}(ln)
+	}
 
 	return nil
 }
@@ -156,8 +188,18 @@ func (p *Proxy) Stop() error {
 
 // loggingTransport wraps http.RoundTripper to log requests and responses
 type loggingTransport struct {
-	proxy     *Proxy
-	transport http.RoundTripper
+	proxy         *Proxy
+	transport     http.RoundTripper
+	grpcTransport http.RoundTripper // cleartext HTTP/2, for gRPC calls
+}
+
+// newH2CTransport returns a transport that speaks HTTP/2 with prior
+// knowledge over plain TCP, as gRPC servers expect
+func newH2CTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Protocols = new(http.Protocols)
+	transport.Protocols.SetUnencryptedHTTP2(true)
+	return transport
 }
 
 func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -166,18 +208,25 @@ func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 
 	// Check if we should log this request based on path filter
 	if !t.proxy.shouldLog(req.URL.Path) {
-		return t.transport.RoundTrip(req)
+		resp, err := t.transport.RoundTrip(req)
+		if err == nil {
+			t.proxy.observeStatus(resp.StatusCode)
+		}
+		return resp, err
 	}
 
 	startTime := time.Now()
 	maxBodySize := t.proxy.logger.GetMaxBodyLen()
 
+	if IsGRPCContentType(req.Header.Get("Content-Type")) {
+		return t.roundTripGRPC(req, reqID, startTime, maxBodySize)
+	}
+
 	// Read request body with size limit to prevent memory exhaustion
 	var reqBody []byte
 	var reqBodySize int
 	if req.Body != nil {
-		reqBody, reqBodySize = t.readBodyLimited(req.Body, maxBodySize)
-		req.Body = io.NopCloser(bytes.NewBuffer(reqBody))
+		reqBody, reqBodySize, req.Body = t.readBodyLimited(req.Body, req.ContentLength, maxBodySize)
 	}
 
 	// Log request
@@ -201,13 +250,13 @@ func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	if err != nil {
 		return nil, err
 	}
+	t.proxy.observeStatus(resp.StatusCode)
 
 	// Read response body with size limit to prevent memory exhaustion
 	var respBody []byte
 	var respBodySize int
 	if resp.Body != nil {
-		respBody, respBodySize = t.readBodyLimited(resp.Body, maxBodySize)
-		resp.Body = io.NopCloser(bytes.NewBuffer(respBody))
+		respBody, respBodySize, resp.Body = t.readBodyLimited(resp.Body, resp.ContentLength, maxBodySize)
 	}
 
 	latency := time.Since(startTime)
@@ -233,17 +282,98 @@ func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	return resp, nil
 }
 
-// readBodyLimited reads a body with a size limit to prevent memory exhaustion.
-// Returns the body content (up to maxSize bytes) and the actual content length.
-// If the body exceeds maxSize, it reads only maxSize bytes for logging but
-// consumes the entire body to get the true size for BodySize reporting.
+// roundTripGRPC proxies a gRPC call over cleartext HTTP/2. Calls may stream
+// in both directions, so bodies are captured as they flow instead of being
+// read up front, and the request and response are logged together once the
+// response ends and its grpc-status trailer is known.
+func (t *loggingTransport) roundTripGRPC(req *http.Request, reqID string, startTime time.Time, maxBodySize int) (*http.Response, error) {
+	reqBody := &streamCapture{max: maxBodySize}
+	if req.Body != nil {
+		reqBody.ReadCloser = req.Body
+		req.Body = reqBody
+	} else {
+		reqBody.finished = true
+	}
+
+	resp, err := t.grpcTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.proxy.observeStatus(resp.StatusCode)
+
+	respBody := &streamCapture{ReadCloser: resp.Body, max: maxBodySize}
+	respBody.done = func() {
+		t.logGRPC(req, resp, reqBody, respBody, reqID, startTime)
+	}
+	resp.Body = respBody
+	return resp, nil
+}
+
+// logGRPC logs a finished gRPC call as a request entry followed by a
+// response entry. Trailers are included with the response headers.
+func (t *loggingTransport) logGRPC(req *http.Request, resp *http.Response, reqBody, respBody *streamCapture, reqID string, startTime time.Time) {
+	latency := time.Since(startTime)
+
+	body, size := reqBody.captured()
+	reqEntry := Entry{
+		Timestamp: startTime,
+		RequestID: reqID,
+		Direction: "request",
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		BodySize:  size,
+		Body:      string(body),
+		GRPC:      true,
+	}
+	if t.proxy.includeHdrs {
+		reqEntry.Headers = flattenHeaders(req.Header)
+	}
+	_ = t.proxy.logger.Log(reqEntry)
+
+	status, message := grpcStatus(resp)
+	if decoded, err := url.PathUnescape(message); err == nil {
+		message = decoded
+	}
+	body, size = respBody.captured()
+	respEntry := Entry{
+		RequestID:   reqID,
+		Direction:   "response",
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		StatusCode:  resp.StatusCode,
+		BodySize:    size,
+		Body:        string(body),
+		LatencyMs:   latency.Milliseconds(),
+		GRPC:        true,
+		GRPCStatus:  status,
+		GRPCMessage: message,
+	}
+	if t.proxy.includeHdrs {
+		respEntry.Headers = flattenHeaders(resp.Header)
+		for k, v := range flattenHeaders(resp.Trailer) {
+			respEntry.Headers[k] = v
+		}
+	}
+	_ = t.proxy.logger.Log(respEntry)
+}
+
+// readBodyLimited captures up to maxSize bytes of body for logging and
+// returns a replacement body that still yields the complete, unmodified
+// content to the other side of the proxy. Only the captured prefix is held in
+// memory; the remainder streams through untouched.
+//
+// The returned size is the full body length: the number of bytes read when
+// the body fits within maxSize, otherwise contentLength, or -1 when the body
+// was truncated and its length is unknown (e.g. chunked encoding).
 // Uses sync.Pool to reuse buffers and reduce allocations.
-func (t *loggingTransport) readBodyLimited(body io.ReadCloser, maxSize int) ([]byte, int) {
+func (t *loggingTransport) readBodyLimited(body io.ReadCloser, contentLength int64, maxSize int) ([]byte, int, io.ReadCloser) {
 	// Get a buffer from the pool for accumulating body content
 	bufPtr := bufferPool.Get().(*[]byte)
-	buf := *bufPtr
-	buf = buf[:0] // Reset length but keep capacity
-	defer bufferPool.Put(bufPtr)
+	buf := (*bufPtr)[:0] // Reset length but keep capacity
+	defer func() {
+		*bufPtr = buf[:0] // Keep any growth for the next caller
+		bufferPool.Put(bufPtr)
+	}()
 
 	// Get a pooled read buffer to eliminate per-read allocation
 	tmpPtr := readBufferPool.Get().(*[]byte)
@@ -251,59 +381,39 @@ func (t *loggingTransport) readBodyLimited(body io.ReadCloser, maxSize int) ([]b
 	defer readBufferPool.Put(tmpPtr)
 
 	// Read up to maxSize+1 to detect if there's more
-	limitedReader := io.LimitReader(body, int64(maxSize+1))
-
-	// Read into the pooled buffer
-	var totalRead int
+	limitedReader := io.LimitReader(body, int64(maxSize)+1)
 	for {
 		n, err := limitedReader.Read(tmp)
 		if n > 0 {
 			buf = append(buf, tmp[:n]...)
-			totalRead += n
 		}
 		if err != nil {
 			break
 		}
 	}
 
-	actualSize := len(buf)
-	wasTruncated := actualSize > maxSize
-
-	// If we read exactly maxSize+1, there might be more data
-	// Discard the rest but count the bytes for accurate BodySize
-	if wasTruncated {
-		// Count remaining bytes without storing them
-		remaining, _ := io.Copy(io.Discard, body)
-		actualSize = maxSize + int(remaining)
-		// Return a copy of just the maxSize bytes for logging
-		resultPtr := bufferPool.Get().(*[]byte)
-		result := *resultPtr
-		result = result[:maxSize]
-		copy(result, buf)
-		return result, actualSize
-	}
-
-	// For small results, allocate minimally. For larger results, use pooled buffer.
-	resultLen := len(buf)
-	var result []byte
-	if resultLen <= 4096 {
-		// Small body: allocate exact size to avoid holding large buffers
-		result = make([]byte, resultLen)
-		copy(result, buf)
-	} else {
-		// Larger body: try to use pooled buffer
-		resultPtr := bufferPool.Get().(*[]byte)
-		result = *resultPtr
-		if cap(result) >= resultLen {
-			result = result[:resultLen]
-			copy(result, buf)
-		} else {
-			// Pooled buffer too small, allocate new and don't return to pool
-			result = make([]byte, resultLen)
-			copy(result, buf)
-		}
+	// Copy out of the pooled buffer; the copy backs both the log entry and
+	// the replayed body
+	read := make([]byte, len(buf))
+	copy(read, buf)
+
+	if len(read) <= maxSize {
+		return read, len(read), replayBody{Reader: bytes.NewReader(read), Closer: body}
 	}
-	return result, actualSize
+
+	// Over the limit: replay what was read, then stream the rest
+	size := -1
+	if contentLength >= 0 {
+		size = int(contentLength)
+	}
+	replay := replayBody{Reader: io.MultiReader(bytes.NewReader(read), body), Closer: body}
+	return read[:maxSize], size, replay
+}
+
+// replayBody re-serves a partially consumed body while closing the original.
+type replayBody struct {
+	io.Reader
+	io.Closer
 }
 
 // shouldLog checks if the request path matches the filter
@@ -370,10 +480,11 @@ var redactedHeaderSubstrings = []string{
 	"apikey",
 }
 
-// redactedValue is the placeholder written in place of any sensitive header
+// RedactedValue is the placeholder written in place of any sensitive header
 // value. The header name itself is preserved so operators can see which
-// sensitive headers were present without leaking their contents.
-const redactedValue = "[REDACTED]"
+// sensitive headers were present without leaking their contents. Exported
+// so consumers such as request replay can tell a redacted value apart.
+const RedactedValue = "[REDACTED]"
 
 // shouldRedactHeader reports whether the given header name should have its
 // value redacted before being recorded. The check is case-insensitive and
@@ -400,7 +511,7 @@ func flattenHeaders(h http.Header) map[string]string {
 	result := make(map[string]string, len(h))
 	for k, v := range h {
 		if shouldRedactHeader(k) {
-			result[k] = redactedValue
+			result[k] = RedactedValue
 			continue
 		}
 		result[k] = strings.Join(v, ", ")
@@ -68,7 +68,8 @@ func TestLoggerBodyTruncation(t *testing.T) {
 	err = json.Unmarshal(buf.Bytes(), &entry)
 	require.NoError(t, err)
 
-	assert.Equal(t, "this is a ...(truncated)", entry.Body)
+	assert.Equal(t, "this is a ...[truncated 40 bytes]", entry.Body)
+	assert.Equal(t, 50, entry.BodySize, "BodySize keeps the full size")
 }
 
 func TestProxyShouldLog(t *testing.T) {
@@ -302,7 +303,7 @@ func TestProxy_StartStop(t *testing.T) {
 	err := proxy.Start()
 	require.NoError(t, err)
 	assert.True(t, proxy.running)
-	assert.NotNil(t, proxy.listener)
+	assert.NotEmpty(t, proxy.listeners)
 	assert.NotNil(t, proxy.server)
 
 	// Double start should fail
@@ -334,7 +335,7 @@ func TestProxy_Start_PortInUse(t *testing.T) {
 	defer func() { _ = proxy1.Stop() }()
 
 	// Get the actual port
-	addr := proxy1.listener.Addr().(*net.TCPAddr)
+	addr := proxy1.listeners[0].Addr().(*net.TCPAddr)
 	usedPort := addr.Port
 
 	// Try to start second proxy on same port
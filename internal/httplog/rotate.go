@@ -0,0 +1,248 @@
+package httplog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/logger"
+)
+
+const (
+	// rotateQueueSize is the number of pending entries buffered before new
+	// entries are dropped. Dropping keeps a slow disk off the proxy hot path.
+	rotateQueueSize = 1024
+
+	// rotateFlushInterval bounds how long an entry may sit in the write buffer.
+	rotateFlushInterval = time.Second
+)
+
+// RotateOptions configures size-based rotation of an HTTP log file.
+type RotateOptions struct {
+	// MaxSize is the size in bytes at which the file is rotated.
+	// Zero disables rotation.
+	MaxSize int64
+	// MaxFiles is the number of rotated files (file.1 ... file.N) to keep.
+	MaxFiles int
+}
+
+// rotatingFile is a buffered JSON-lines file writer with size-based rotation.
+// Write never blocks on disk I/O: lines are queued to a background goroutine,
+// and dropped when the queue is full.
+type rotatingFile struct {
+	file    *os.File
+	buf     *bufio.Writer
+	queue   chan []byte
+	done    chan struct{}
+	path    string
+	opts    RotateOptions
+	size    int64
+	dropped uint64
+	closeMu sync.Mutex
+	closed  bool
+	// rotateFailed stops rotation after a failed attempt, which would
+	// otherwise be retried, and fail again, for every line
+	rotateFailed bool
+}
+
+// openRotatingFile opens path for appending and starts the writer goroutine.
+func openRotatingFile(path string, opts RotateOptions) (*rotatingFile, error) {
+	f, err := openLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close() // Already returning the stat error
+		return nil, err
+	}
+
+	r := &rotatingFile{
+		file:  f,
+		buf:   bufio.NewWriterSize(f, 64*1024),
+		queue: make(chan []byte, rotateQueueSize),
+		done:  make(chan struct{}),
+		path:  path,
+		opts:  opts,
+		size:  info.Size(),
+	}
+	go r.run()
+
+	return r, nil
+}
+
+// openLogFile opens path for appending, creating it if needed.
+func openLogFile(path string) (*os.File, error) {
+	// #nosec G304 -- logFile is from config validation, not arbitrary user input
+	return os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+}
+
+// Write queues a copy of p for writing. It never blocks; if the queue is
+// full the line is dropped and counted. Writes after Close are dropped.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.closeMu.Lock()
+	defer r.closeMu.Unlock()
+
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+
+	line := make([]byte, len(p))
+	copy(line, p)
+
+	select {
+	case r.queue <- line:
+	default:
+		atomic.AddUint64(&r.dropped, 1)
+	}
+	return len(p), nil
+}
+
+// Dropped returns the number of lines discarded because the queue was full.
+func (r *rotatingFile) Dropped() uint64 {
+	return atomic.LoadUint64(&r.dropped)
+}
+
+// Close drains queued lines, flushes the buffer and closes the file.
+func (r *rotatingFile) Close() error {
+	r.closeMu.Lock()
+	if r.closed {
+		r.closeMu.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.queue)
+	r.closeMu.Unlock()
+
+	<-r.done
+
+	if dropped := r.Dropped(); dropped > 0 {
+		logger.Warn("HTTP log file writer dropped entries", map[string]any{
+			"file":    r.path,
+			"dropped": dropped,
+		})
+	}
+
+	if r.file == nil {
+		return nil // A failed rotation left no file to close
+	}
+	if err := r.buf.Flush(); err != nil {
+		_ = r.file.Close() // Already returning the flush error
+		return err
+	}
+	return r.file.Close()
+}
+
+// run writes queued lines until the queue is closed, flushing periodically.
+func (r *rotatingFile) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(rotateFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case line, ok := <-r.queue:
+			if !ok {
+				return
+			}
+			r.writeLine(line)
+		case <-ticker.C:
+			if err := r.buf.Flush(); err != nil {
+				logger.Debug("HTTP log file flush failed", map[string]any{"file": r.path, "error": err.Error()})
+			}
+		}
+	}
+}
+
+// writeLine appends line, rotating first if it would exceed MaxSize.
+func (r *rotatingFile) writeLine(line []byte) {
+	if r.opts.MaxSize > 0 && !r.rotateFailed && r.size > 0 && r.size+int64(len(line)) > r.opts.MaxSize {
+		if err := r.rotate(); err != nil {
+			r.rotateFailed = true
+			logger.Warn("HTTP log file rotation failed, no longer rotating", map[string]any{"file": r.path, "error": err.Error()})
+		}
+	}
+
+	n, err := r.buf.Write(line)
+	r.size += int64(n)
+	if err != nil {
+		logger.Debug("HTTP log file write failed", map[string]any{"file": r.path, "error": err.Error()})
+	}
+}
+
+// rotate shifts path.N-1 -> path.N ... path -> path.1, deletes files beyond
+// MaxFiles and reopens path. The file is reopened even if shifting fails so
+// that logging continues. If path cannot be reopened, the old file is
+// reopened where the shift left it; only if that fails too are further
+// lines discarded.
+func (r *rotatingFile) rotate() error {
+	if err := r.buf.Flush(); err != nil {
+		// Drop the lines that could not be written; later ones are retried
+		r.buf.Reset(r.file)
+		return err
+	}
+	// Windows cannot rename an open file, so it is closed before the shift
+	_ = r.file.Close() // Flushed above; the file is reopened below
+
+	shiftErr := r.shift()
+
+	err := r.reopen(r.path)
+	if err == nil {
+		return shiftErr
+	}
+
+	// Keep appending to the old file where the shift moved it. shift renames
+	// path last, so after a shift error the old file is the one at path that
+	// just failed to open, and with MaxFiles 0 the shift deleted it.
+	if shiftErr != nil || r.opts.MaxFiles <= 0 || r.reopen(r.rotatedName(1)) != nil {
+		r.file = nil
+		r.buf.Reset(io.Discard)
+	}
+	return err
+}
+
+// reopen opens path for appending as the current file.
+func (r *rotatingFile) reopen(path string) error {
+	f, err := openLogFile(path)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close() // Already returning the stat error
+		return err
+	}
+	r.file = f
+	r.buf.Reset(f)
+	r.size = info.Size()
+	return nil
+}
+
+// shift moves the current file out of the way, keeping at most MaxFiles.
+func (r *rotatingFile) shift() error {
+	if r.opts.MaxFiles <= 0 {
+		if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	_ = os.Remove(r.rotatedName(r.opts.MaxFiles)) // Oldest file may not exist yet
+	for i := r.opts.MaxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(r.rotatedName(i), r.rotatedName(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(r.path, r.rotatedName(1))
+}
+
+// rotatedName returns the file name of the n-th rotated file.
+func (r *rotatingFile) rotatedName(n int) string {
+	return fmt.Sprintf("%s.%d", r.path, n)
+}
@@ -0,0 +1,145 @@
+package httplog
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRotatingFile_Rotates tests size-based rotation keeps at most MaxFiles
+func TestRotatingFile_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.log")
+	line := strings.Repeat("x", 9) + "\n"
+
+	r, err := openRotatingFile(path, RotateOptions{MaxSize: 25, MaxFiles: 2})
+	require.NoError(t, err)
+
+	// Two lines fit per file; seven lines produce four files, of which the
+	// current file and two rotated files are kept.
+	for i := 0; i < 7; i++ {
+		_, err := r.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.Close())
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, line, string(current))
+
+	for _, name := range []string{path + ".1", path + ".2"} {
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, line+line, string(data))
+	}
+
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "files beyond MaxFiles should be removed")
+}
+
+// TestRotatingFile_RotationFailsInReadOnlyDir tests a failed rotation keeps
+// appending to the current file instead of retrying for every line
+func TestRotatingFile_RotationFailsInReadOnlyDir(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("Directory permissions are not enforced")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "http.log")
+	line := strings.Repeat("x", 9) + "\n"
+
+	r, err := openRotatingFile(path, RotateOptions{MaxSize: 25, MaxFiles: 2})
+	require.NoError(t, err)
+	require.NoError(t, os.Chmod(dir, 0o500))
+	t.Cleanup(func() { _ = os.Chmod(dir, 0o700) })
+
+	for i := 0; i < 7; i++ {
+		_, err := r.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.Close())
+
+	assert.True(t, r.rotateFailed)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat(line, 7), string(data), "every line is kept in the unrotated file")
+	_, err = os.Stat(path + ".1")
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestRotatingFile_ReopenFails tests a rotation that cannot reopen any file
+// stops rotating and discards lines instead of writing to the closed file
+func TestRotatingFile_ReopenFails(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows cannot remove the directory of an open file")
+	}
+	dir := filepath.Join(t.TempDir(), "logs")
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	line := strings.Repeat("x", 9) + "\n"
+
+	r, err := openRotatingFile(filepath.Join(dir, "http.log"), RotateOptions{MaxSize: 25, MaxFiles: 2})
+	require.NoError(t, err)
+	require.NoError(t, os.RemoveAll(dir))
+
+	for i := 0; i < 7; i++ {
+		_, err := r.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.Close())
+
+	assert.True(t, r.rotateFailed, "rotation is not retried for every line")
+	assert.Nil(t, r.file)
+}
+
+// TestRotatingFile_NoRotation tests a zero MaxSize never rotates
+func TestRotatingFile_NoRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.log")
+
+	r, err := openRotatingFile(path, RotateOptions{})
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		_, err := r.Write([]byte("line\n"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 100, strings.Count(string(data), "\n"))
+
+	_, err = os.Stat(path + ".1")
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestRotatingFile_WriteAfterClose tests writes after Close are rejected
+func TestRotatingFile_WriteAfterClose(t *testing.T) {
+	r, err := openRotatingFile(filepath.Join(t.TempDir(), "http.log"), RotateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.NoError(t, r.Close(), "Close should be idempotent")
+
+	_, err = r.Write([]byte("late\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+// TestLogger_RotationThroughLog tests the logger writes JSON lines via the rotating file
+func TestLogger_RotationThroughLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.log")
+
+	l, err := NewLoggerWithRotation("fwd", path, 1024, RotateOptions{MaxSize: 1, MaxFiles: 1})
+	require.NoError(t, err)
+	require.NoError(t, l.Log(Entry{Direction: "request", Path: "/a"}))
+	require.NoError(t, l.Log(Entry{Direction: "request", Path: "/b"}))
+	require.NoError(t, l.Close())
+
+	rotated, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Contains(t, string(rotated), `"/a"`)
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), `"/b"`)
+}
@@ -9,9 +9,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -62,7 +64,7 @@ func makeProxy(t *testing.T, backend *httptest.Server, opts struct {
 
 // proxyURL returns the URL of the proxy's listening address.
 func proxyURL(p *Proxy) string {
-	addr := p.listener.Addr().String()
+	addr := p.listeners[0].Addr().String()
 	return "http://" + addr
 }
 
@@ -212,11 +214,11 @@ func TestRoundTrip_IncludeHeaders(t *testing.T) {
 	require.NoError(t, json.Unmarshal([]byte(lines[1]), &respEntry))
 
 	// Sensitive request header must be redacted
-	assert.Equal(t, redactedValue, reqEntry.Headers["Authorization"])
+	assert.Equal(t, RedactedValue, reqEntry.Headers["Authorization"])
 	// Benign request header must be visible
 	assert.Equal(t, "visible", reqEntry.Headers["X-Custom"])
 	// Sensitive response header must be redacted
-	assert.Equal(t, redactedValue, respEntry.Headers["Set-Cookie"])
+	assert.Equal(t, RedactedValue, respEntry.Headers["Set-Cookie"])
 }
 
 // TestRoundTrip_NoHeaders verifies that when includeHdrs is false no header
@@ -270,7 +272,7 @@ func TestRoundTrip_BackendDown_LogsError(t *testing.T) {
 	defer func() { _ = p.Stop() }()
 
 	// The proxy should return 502 when backend is unreachable
-	resp, err := http.Get("http://" + p.listener.Addr().String() + "/failing")
+	resp, err := http.Get("http://" + p.listeners[0].Addr().String() + "/failing")
 	require.NoError(t, err)
 	defer func() { _ = resp.Body.Close() }()
 	_, _ = io.ReadAll(resp.Body)
@@ -344,9 +346,13 @@ func TestReadBodyLimited_SmallBody(t *testing.T) {
 	data := []byte("hello world")
 	body := io.NopCloser(bytes.NewReader(data))
 
-	result, size := transport.readBodyLimited(body, 1024)
+	result, size, replay := transport.readBodyLimited(body, -1, 1024)
 	assert.Equal(t, data, result)
 	assert.Equal(t, len(data), size)
+
+	replayed, err := io.ReadAll(replay)
+	require.NoError(t, err)
+	assert.Equal(t, data, replayed)
 }
 
 // TestReadBodyLimited_EmptyBody verifies that an empty body returns an empty
@@ -355,16 +361,14 @@ func TestReadBodyLimited_EmptyBody(t *testing.T) {
 	transport := &loggingTransport{}
 	body := io.NopCloser(bytes.NewReader([]byte{}))
 
-	result, size := transport.readBodyLimited(body, 1024)
+	result, size, _ := transport.readBodyLimited(body, -1, 1024)
 	assert.Empty(t, result)
 	assert.Equal(t, 0, size)
 }
 
 // TestReadBodyLimited_TruncatedBody verifies the truncation path: when the
-// body exceeds maxSize, the returned slice contains exactly maxSize bytes.
-// The reported size is maxSize + (remaining bytes after the maxSize+1 read),
-// which due to the implementation consuming one extra sentinel byte equals
-// len(data)-1 for a body whose length > maxSize.
+// body exceeds maxSize, the returned slice contains exactly maxSize bytes,
+// the size comes from the content length, and the replayed body is complete.
 func TestReadBodyLimited_TruncatedBody(t *testing.T) {
 	transport := &loggingTransport{}
 	maxSize := 10
@@ -372,13 +376,30 @@ func TestReadBodyLimited_TruncatedBody(t *testing.T) {
 	data := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ1234")
 	body := io.NopCloser(bytes.NewReader(data))
 
-	result, size := transport.readBodyLimited(body, maxSize)
+	result, size, replay := transport.readBodyLimited(body, int64(len(data)), maxSize)
 	assert.Equal(t, maxSize, len(result), "returned slice must be exactly maxSize bytes")
 	assert.Equal(t, string(data[:maxSize]), string(result), "first maxSize bytes must match")
-	// Implementation reads maxSize+1 sentinel bytes, then drains the rest.
-	// The sentinel byte is consumed and not included in the "remaining" count,
-	// so reported size == maxSize + (len(data) - maxSize - 1) == len(data) - 1.
-	assert.Equal(t, len(data)-1, size, "reported size is total length minus the consumed sentinel byte")
+	assert.Equal(t, len(data), size, "reported size is the full content length")
+
+	replayed, err := io.ReadAll(replay)
+	require.NoError(t, err)
+	assert.Equal(t, data, replayed, "the proxied body must not be truncated")
+}
+
+// TestReadBodyLimited_TruncatedUnknownLength verifies a truncated body
+// without a content length reports size -1.
+func TestReadBodyLimited_TruncatedUnknownLength(t *testing.T) {
+	transport := &loggingTransport{}
+	data := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ1234")
+	body := io.NopCloser(bytes.NewReader(data))
+
+	result, size, replay := transport.readBodyLimited(body, -1, 10)
+	assert.Equal(t, "ABCDEFGHIJ", string(result))
+	assert.Equal(t, -1, size)
+
+	replayed, err := io.ReadAll(replay)
+	require.NoError(t, err)
+	assert.Equal(t, data, replayed)
 }
 
 // TestReadBodyLimited_ExactlyMaxSize ensures that a body equal to maxSize bytes
@@ -389,41 +410,40 @@ func TestReadBodyLimited_ExactlyMaxSize(t *testing.T) {
 	data := []byte("ABCDE") // exactly maxSize
 	body := io.NopCloser(bytes.NewReader(data))
 
-	result, size := transport.readBodyLimited(body, maxSize)
+	result, size, _ := transport.readBodyLimited(body, -1, maxSize)
 	assert.Equal(t, data, result)
 	assert.Equal(t, maxSize, size)
-	assert.NotContains(t, string(result), "...(truncated)")
 }
 
-// TestReadBodyLimited_LargeBodyOverPoolThreshold exercises the branch in
-// readBodyLimited where resultLen > 4096, which uses the pooled-buffer path
-// for larger-than-small results. The body is 5000 bytes, well over the 4096
-// small-body threshold but under maxSize so no truncation occurs.
+// TestReadBodyLimited_LargeBodyOverPoolThreshold exercises a body larger than
+// the pooled buffer's initial capacity but under maxSize, so no truncation
+// occurs.
 func TestReadBodyLimited_LargeBodyOverPoolThreshold(t *testing.T) {
 	transport := &loggingTransport{}
-	data := bytes.Repeat([]byte("x"), 5000) // > 4096, under maxSize
+	data := bytes.Repeat([]byte("x"), 10000) // > 8KB pool capacity, under maxSize
 	body := io.NopCloser(bytes.NewReader(data))
 
-	result, size := transport.readBodyLimited(body, 65536)
-	assert.Equal(t, 5000, len(result))
-	assert.Equal(t, 5000, size)
+	result, size, _ := transport.readBodyLimited(body, -1, 65536)
+	assert.Equal(t, 10000, len(result))
+	assert.Equal(t, 10000, size)
 	assert.Equal(t, data, result)
 }
 
 // TestReadBodyLimited_ZeroMaxSize covers the edge where maxSize == 0: every
-// non-empty body is "over limit". The returned slice is empty (0 bytes). The
-// reported size is the number of bytes drained after the sentinel read, which
-// is len(data)-1 because the LimitReader reads 1 sentinel byte (maxSize+1=1)
-// that is consumed and lost from the remaining count.
+// non-empty body is "over limit". The returned slice is empty and the full
+// body is still replayed.
 func TestReadBodyLimited_ZeroMaxSize(t *testing.T) {
 	transport := &loggingTransport{}
 	data := []byte("some data") // 9 bytes
 	body := io.NopCloser(bytes.NewReader(data))
 
-	result, size := transport.readBodyLimited(body, 0)
+	result, size, replay := transport.readBodyLimited(body, int64(len(data)), 0)
 	assert.Equal(t, 0, len(result))
-	// sentinel consumes 1 byte; remaining = 8; actualSize = 0 + 8 = 8
-	assert.Equal(t, len(data)-1, size)
+	assert.Equal(t, len(data), size)
+
+	replayed, err := io.ReadAll(replay)
+	require.NoError(t, err)
+	assert.Equal(t, data, replayed)
 }
 
 // TestReadBodyLimited_Callback exercises the transport inside a running proxy
@@ -451,15 +471,20 @@ func TestReadBodyLimited_ViaCallback(t *testing.T) {
 	resp, err := http.Get(proxyURL(p) + "/data")
 	require.NoError(t, err)
 	defer func() { _ = resp.Body.Close() }()
-	_, _ = io.ReadAll(resp.Body)
+	received, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	// The client must receive the full body even though the log is capped
+	assert.Len(t, received, 200)
 
 	// Give callbacks a moment (they run synchronously inside Log's mutex)
 	require.Eventually(t, func() bool { return len(entries) >= 2 }, time.Second, 5*time.Millisecond)
 
 	respEntry := entries[1] // second entry is the response
 	assert.Equal(t, "response", respEntry.Direction)
-	// Body was 200 bytes but maxBodyLen is 100 → BodySize should be ≥100
-	assert.GreaterOrEqual(t, respEntry.BodySize, 100)
+	// Body was 200 bytes but maxBodyLen is 100 → captured 100, BodySize is the full 200
+	assert.Equal(t, 200, respEntry.BodySize)
+	assert.Equal(t, strings.Repeat("R", 100)+"...[truncated 100 bytes]", respEntry.Body)
 }
 
 // TestRoundTrip_NilRequestBody confirms no panic when req.Body is nil (GET
@@ -508,3 +533,48 @@ func TestRoundTrip_NilResponseBody(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
+
+// TestRoundTrip_StatusObserver verifies every proxied response reaches the
+// status observer, including filtered paths and upstream failures.
+func TestRoundTrip_StatusObserver(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	backendPort := backend.Listener.Addr().(*net.TCPAddr).Port
+
+	var mu sync.Mutex
+	var statuses []int
+	p := &Proxy{
+		targetPort: backendPort,
+		logger:     &Logger{forwardID: "test-rt", maxBodyLen: 1024, output: io.Discard},
+		forwardID:  "test-rt",
+		filterPath: "/api/*",
+	}
+	p.SetStatusObserver(func(status int) {
+		mu.Lock()
+		statuses = append(statuses, status)
+		mu.Unlock()
+	})
+	require.NoError(t, p.Start())
+	t.Cleanup(func() { _ = p.Stop() })
+
+	for _, path := range []string{"/api/users", "/missing"} {
+		resp, err := http.Get(proxyURL(p) + path)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	backend.Close()
+	resp, err := http.Get(proxyURL(p) + "/api/users")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []int{http.StatusOK, http.StatusNotFound, http.StatusBadGateway}, statuses)
+}
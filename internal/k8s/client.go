@@ -12,38 +12,142 @@
 package k8s
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
+// ErrContextNotFound is returned, wrapped, for a context kubeconfig does not
+// have, e.g. after it was renamed or removed.
+var ErrContextNotFound = errors.New("context not found in kubeconfig")
+
 // ClientPool manages Kubernetes clients per context with thread-safe access.
 type ClientPool struct {
-	loader  clientcmd.ClientConfig
+	loader  clientcmd.ClientConfig // kubeconfig as read once; replaced by Reload
+	limiter *concurrencyLimiter    // bounds concurrent discovery and resolve calls
 	clients map[string]kubernetes.Interface
 	configs map[string]*rest.Config
-	mu      sync.RWMutex
+	// kubeconfigPath, when set, is the only kubeconfig file read,
+	// overriding KUBECONFIG and ~/.kube/config
+	kubeconfigPath string
+	mu             sync.RWMutex
+	// inCluster makes InClusterContext available even when kubeconfig has
+	// contexts
+	inCluster bool
 }
 
 // NewClientPool creates a new ClientPool instance.
 func NewClientPool() (*ClientPool, error) {
-	// Load kubeconfig using default loading rules
-	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-	configOverrides := &clientcmd.ConfigOverrides{}
-
-	loader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
-
 	return &ClientPool{
 		clients: make(map[string]kubernetes.Interface),
 		configs: make(map[string]*rest.Config),
-		loader:  loader,
+		loader:  newKubeconfigLoader(""),
+		limiter: newConcurrencyLimiter(DefaultContextConcurrency, 0),
 	}, nil
 }
 
+// newKubeconfigLoader returns a loader for kubeconfig using the default
+// loading rules, as kubectl does: every file listed in KUBECONFIG, merged in
+// order with the first definition of a name winning, or ~/.kube/config when
+// it is unset. A non-empty path replaces both, like kubectl --kubeconfig.
+// It reads the files on first use and caches them.
+func newKubeconfigLoader(path string) clientcmd.ClientConfig {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = path
+	configOverrides := &clientcmd.ConfigOverrides{}
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
+}
+
+// Reload re-reads kubeconfig and drops every cached client and config, so
+// renamed or removed contexts are noticed. Running tunnels keep their
+// connections; the next connect uses the new kubeconfig.
+func (p *ClientPool) Reload() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.loader = newKubeconfigLoader(p.kubeconfigPath)
+	p.clients = make(map[string]kubernetes.Interface)
+	p.configs = make(map[string]*rest.Config)
+}
+
+// SetKubeconfig makes the pool read only the kubeconfig file at path instead
+// of KUBECONFIG or ~/.kube/config. An empty path restores the default
+// discovery. Cached clients and configs are dropped, as in Reload.
+func (p *ClientPool) SetKubeconfig(path string) {
+	p.mu.Lock()
+	p.kubeconfigPath = path
+	p.mu.Unlock()
+
+	p.Reload()
+}
+
+// SetInCluster makes InClusterContext, backed by the pod's service account,
+// available whatever kubeconfig has. Without it the context is only added
+// when kubeconfig has no contexts and kportal runs in a pod.
+func (p *ClientPool) SetInCluster(enabled bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.inCluster = enabled
+	delete(p.clients, InClusterContext)
+	delete(p.configs, InClusterContext)
+}
+
+// kubeconfig returns the current loader's raw kubeconfig, with
+// InClusterContext added when it is in use.
+func (p *ClientPool) kubeconfig() (clientcmdapi.Config, error) {
+	p.mu.RLock()
+	loader := p.loader
+	inCluster := p.inCluster
+	p.mu.RUnlock()
+
+	rawConfig, err := loader.RawConfig()
+	if err != nil {
+		return rawConfig, err
+	}
+	return withInClusterContext(rawConfig, inCluster), nil
+}
+
+// HasContext reports whether kubeconfig has a context named contextName.
+func (p *ClientPool) HasContext(contextName string) (bool, error) {
+	rawConfig, err := p.kubeconfig()
+	if err != nil {
+		return false, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	_, exists := rawConfig.Contexts[contextName]
+	return exists, nil
+}
+
+// SetConcurrency limits concurrent discovery and resolve API calls to
+// perContext per context and total across all contexts. Zero disables
+// either limit. Calls already waiting keep the previous limits.
+func (p *ClientPool) SetConcurrency(perContext, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.limiter = newConcurrencyLimiter(perContext, total)
+}
+
+// acquire waits for a free slot for an API call in contextName. The
+// returned release must be called when the call is done.
+func (p *ClientPool) acquire(ctx context.Context, contextName string) (func(), error) {
+	p.mu.RLock()
+	limiter := p.limiter
+	p.mu.RUnlock()
+
+	return limiter.acquire(ctx, contextName)
+}
+
 // GetClient returns a Kubernetes client for the given context.
 // Clients are cached and reused across multiple calls.
 // This method is thread-safe.
@@ -119,7 +223,7 @@ func (p *ClientPool) GetRestConfig(contextName string) (*rest.Config, error) {
 }
 
 // getRestConfig creates a REST config for the given context.
-// This is an internal method that should only be called with a lock held.
+// This is an internal method that should only be called with the write lock held.
 func (p *ClientPool) getRestConfig(contextName string) (*rest.Config, error) {
 	// Load the raw kubeconfig
 	rawConfig, err := p.loader.RawConfig()
@@ -127,9 +231,24 @@ func (p *ClientPool) getRestConfig(contextName string) (*rest.Config, error) {
 		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
-	// Check if the context exists
+	if contextName == InClusterContext && usesInCluster(rawConfig, p.inCluster) {
+		config, err := inClusterConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load in-cluster config: %w", err)
+		}
+		return config, nil
+	}
+
+	// A missing context may have been added since kubeconfig was read, e.g.
+	// by renaming it back, so re-read it once before giving up
+	if _, exists := rawConfig.Contexts[contextName]; !exists {
+		p.loader = newKubeconfigLoader(p.kubeconfigPath)
+		if rawConfig, err = p.loader.RawConfig(); err != nil {
+			return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+		}
+	}
 	if _, exists := rawConfig.Contexts[contextName]; !exists {
-		return nil, fmt.Errorf("context %s not found in kubeconfig", contextName)
+		return nil, fmt.Errorf("%w: %s", ErrContextNotFound, contextName)
 	}
 
 	// Create config overrides for the specific context
@@ -154,7 +273,7 @@ func (p *ClientPool) getRestConfig(contextName string) (*rest.Config, error) {
 
 // GetCurrentContext returns the name of the current context from kubeconfig.
 func (p *ClientPool) GetCurrentContext() (string, error) {
-	rawConfig, err := p.loader.RawConfig()
+	rawConfig, err := p.kubeconfig()
 	if err != nil {
 		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
@@ -162,9 +281,29 @@ func (p *ClientPool) GetCurrentContext() (string, error) {
 	return rawConfig.CurrentContext, nil
 }
 
+// CheckReachable verifies that the API server behind a context answers.
+// Any API response counts, including Forbidden, so users without
+// cluster-wide namespace access are not reported as unreachable.
+func (p *ClientPool) CheckReachable(ctx context.Context, contextName string) error {
+	client, err := p.GetClient(contextName)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		var status apierrors.APIStatus
+		if errors.As(err, &status) {
+			return nil
+		}
+		return fmt.Errorf("cluster for context %s is unreachable: %w", contextName, err)
+	}
+	return nil
+}
+
 // ListContexts returns a list of all available contexts from kubeconfig.
 func (p *ClientPool) ListContexts() ([]string, error) {
-	rawConfig, err := p.loader.RawConfig()
+	rawConfig, err := p.kubeconfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
@@ -199,14 +338,14 @@ func (p *ClientPool) RemoveContext(contextName string) {
 
 // GetNamespace returns the default namespace for the given context.
 func (p *ClientPool) GetNamespace(contextName string) (string, error) {
-	rawConfig, err := p.loader.RawConfig()
+	rawConfig, err := p.kubeconfig()
 	if err != nil {
 		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
 	context, exists := rawConfig.Contexts[contextName]
 	if !exists {
-		return "", fmt.Errorf("context %s not found", contextName)
+		return "", fmt.Errorf("%w: %s", ErrContextNotFound, contextName)
 	}
 
 	// Return the namespace from the context, or "default" if not specified
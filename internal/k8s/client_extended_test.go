@@ -1,6 +1,10 @@
 package k8s
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
@@ -222,7 +226,7 @@ func TestClientPool_GetClient_NotFound(t *testing.T) {
 
 	// Try to get client for non-existent context without setting test client
 	_, err = pool.GetClient("non-existent-context")
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrContextNotFound)
 	assert.Contains(t, err.Error(), "not found in kubeconfig")
 }
 
@@ -232,10 +236,79 @@ func TestClientPool_GetRestConfig_NotFound(t *testing.T) {
 
 	// Try to get rest config for non-existent context
 	_, err = pool.GetRestConfig("non-existent-context")
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrContextNotFound)
 	assert.Contains(t, err.Error(), "not found in kubeconfig")
 }
 
+// writeKubeconfig writes a kubeconfig with one context per name, all
+// pointing at the same cluster.
+func writeKubeconfig(t *testing.T, path string, contexts ...string) {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString("apiVersion: v1\nkind: Config\nclusters:\n- name: c\n  cluster:\n    server: https://127.0.0.1:6443\nusers:\n- name: u\n  user:\n    token: t\ncontexts:\n")
+	for _, name := range contexts {
+		fmt.Fprintf(&b, "- name: %s\n  context:\n    cluster: c\n    user: u\n", name)
+	}
+	require.NoError(t, os.WriteFile(path, []byte(b.String()), 0o600))
+}
+
+func TestClientPool_ContextRenamed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	writeKubeconfig(t, path, "dev")
+	t.Setenv("KUBECONFIG", path)
+
+	pool, err := NewClientPool()
+	require.NoError(t, err)
+
+	ok, err := pool.HasContext("dev")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, err = pool.GetRestConfig("dev")
+	require.NoError(t, err)
+
+	writeKubeconfig(t, path, "dev-renamed")
+
+	// A missing context re-reads kubeconfig before failing
+	_, err = pool.GetRestConfig("dev-renamed")
+	require.NoError(t, err)
+
+	// Reload drops the cached config of the old name
+	pool.Reload()
+	ok, err = pool.HasContext("dev")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, err = pool.GetRestConfig("dev")
+	assert.ErrorIs(t, err, ErrContextNotFound)
+}
+
+func TestClientPool_SetKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+	envPath := filepath.Join(dir, "env")
+	writeKubeconfig(t, envPath, "from-env")
+	t.Setenv("KUBECONFIG", envPath)
+	explicit := filepath.Join(dir, "explicit")
+	writeKubeconfig(t, explicit, "from-flag")
+
+	pool, err := NewClientPool()
+	require.NoError(t, err)
+	_, err = pool.GetRestConfig("from-env")
+	require.NoError(t, err)
+
+	// The explicit file replaces KUBECONFIG and drops cached configs
+	pool.SetKubeconfig(explicit)
+	ok, err := pool.HasContext("from-flag")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	_, err = pool.GetRestConfig("from-env")
+	assert.ErrorIs(t, err, ErrContextNotFound)
+
+	// An empty path goes back to the default discovery
+	pool.SetKubeconfig("")
+	ok, err = pool.HasContext("from-env")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
 func TestClientPool_DoubleCheckCache(t *testing.T) {
 	pool := setupTestPool(t, "test-context")
 
@@ -3,10 +3,13 @@ package k8s
 import (
 	"context"
 	"fmt"
-	"net"
 	"sort"
 	"strings"
 
+	"github.com/lukaszraczylo/kportal/internal/localaddr"
+	"github.com/lukaszraczylo/kportal/internal/portowner"
+
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -14,7 +17,8 @@ import (
 )
 
 // Discovery provides cluster introspection capabilities for the UI wizards.
-// It queries the Kubernetes API to list contexts, namespaces, pods, and services.
+// It queries the Kubernetes API to list contexts, namespaces, pods, services
+// and workloads (deployments and statefulsets).
 type Discovery struct {
 	pool *ClientPool
 }
@@ -45,6 +49,7 @@ type ContainerInfo struct {
 type PortInfo struct {
 	Name       string
 	Protocol   string
+	Container  string // container declaring the port (pod ports only)
 	Port       int32
 	TargetPort int32
 }
@@ -57,6 +62,17 @@ type ServiceInfo struct {
 	Ports     []PortInfo
 }
 
+// WorkloadInfo contains information about a deployment or statefulset.
+// Ports are the container ports declared in its pod template.
+type WorkloadInfo struct {
+	Name          string
+	Namespace     string
+	Kind          string
+	Ports         []PortInfo
+	Replicas      int32
+	ReadyReplicas int32
+}
+
 // ListContexts returns all available Kubernetes contexts from kubeconfig.
 func (d *Discovery) ListContexts() ([]string, error) {
 	return d.pool.ListContexts()
@@ -75,9 +91,11 @@ func (d *Discovery) ListNamespaces(ctx context.Context, contextName string) ([]s
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	nsList, err := client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	nsList, err := limited(ctx, d.pool, contextName, func() (*corev1.NamespaceList, error) {
+		return client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		return nil, fmt.Errorf("failed to list namespaces: %w", permissionError(err, "list", "namespaces", ""))
 	}
 
 	namespaces := make([]string, 0, len(nsList.Items))
@@ -99,9 +117,11 @@ func (d *Discovery) ListPods(ctx context.Context, contextName, namespace string)
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	podList, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	podList, err := limited(ctx, d.pool, contextName, func() (*corev1.PodList, error) {
+		return client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+		return nil, fmt.Errorf("failed to list pods: %w", permissionError(err, "list", "pods", namespace))
 	}
 
 	pods := make([]PodInfo, 0)
@@ -160,11 +180,13 @@ func (d *Discovery) ListPodsWithSelector(ctx context.Context, contextName, names
 		return nil, fmt.Errorf("selector cannot be empty")
 	}
 
-	podList, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: selector,
+	podList, err := limited(ctx, d.pool, contextName, func() (*corev1.PodList, error) {
+		return client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector,
+		})
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods with selector: %w", err)
+		return nil, fmt.Errorf("failed to list pods with selector: %w", permissionError(err, "list", "pods", namespace))
 	}
 
 	pods := make([]PodInfo, 0)
@@ -268,16 +290,24 @@ func (d *Discovery) ListServices(ctx context.Context, contextName, namespace str
 		return nil, fmt.Errorf("failed to get client: %w", err)
 	}
 
-	svcList, err := client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	svcList, err := limited(ctx, d.pool, contextName, func() (*corev1.ServiceList, error) {
+		return client.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list services: %w", err)
+		return nil, fmt.Errorf("failed to list services: %w", permissionError(err, "list", "services", namespace))
 	}
 
 	services := make([]ServiceInfo, 0, len(svcList.Items))
 	for _, svc := range svcList.Items {
 		ports := make([]PortInfo, 0, len(svc.Spec.Ports))
 		for _, port := range svc.Spec.Ports {
-			targetPort := d.resolveTargetPort(ctx, client, namespace, &svc, &port)
+			// Named target ports may look up a backing pod
+			targetPort, err := limited(ctx, d.pool, contextName, func() (int32, error) {
+				return d.resolveTargetPort(ctx, client, namespace, &svc, &port), nil
+			})
+			if err != nil {
+				targetPort = port.Port
+			}
 
 			ports = append(ports, PortInfo{
 				Name:       port.Name,
@@ -303,20 +333,105 @@ func (d *Discovery) ListServices(ctx context.Context, contextName, namespace str
 	return services, nil
 }
 
+// ListWorkloads returns all workloads of the given kind ("deployment" or
+// "statefulset") in the namespace, sorted by name.
+func (d *Discovery) ListWorkloads(ctx context.Context, contextName, namespace, kind string) ([]WorkloadInfo, error) {
+	client, err := d.pool.GetClient(contextName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+
+	var workloads []WorkloadInfo
+	switch kind {
+	case "deployment":
+		list, err := limited(ctx, d.pool, contextName, func() (*appsv1.DeploymentList, error) {
+			return client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployments: %w", permissionError(err, "list", "deployments", namespace))
+		}
+		workloads = make([]WorkloadInfo, 0, len(list.Items))
+		for i := range list.Items {
+			dep := &list.Items[i]
+			workloads = append(workloads, WorkloadInfo{
+				Name:          dep.Name,
+				Namespace:     dep.Namespace,
+				Kind:          kind,
+				Ports:         templatePorts(&dep.Spec.Template),
+				Replicas:      dep.Status.Replicas,
+				ReadyReplicas: dep.Status.ReadyReplicas,
+			})
+		}
+	case "statefulset":
+		list, err := limited(ctx, d.pool, contextName, func() (*appsv1.StatefulSetList, error) {
+			return client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list statefulsets: %w", permissionError(err, "list", "statefulsets", namespace))
+		}
+		workloads = make([]WorkloadInfo, 0, len(list.Items))
+		for i := range list.Items {
+			sts := &list.Items[i]
+			workloads = append(workloads, WorkloadInfo{
+				Name:          sts.Name,
+				Namespace:     sts.Namespace,
+				Kind:          kind,
+				Ports:         templatePorts(&sts.Spec.Template),
+				Replicas:      sts.Status.Replicas,
+				ReadyReplicas: sts.Status.ReadyReplicas,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported workload type: %s", kind)
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		return workloads[i].Name < workloads[j].Name
+	})
+
+	return workloads, nil
+}
+
+// templatePorts returns the unique container ports declared in a pod template.
+func templatePorts(template *corev1.PodTemplateSpec) []PortInfo {
+	containers := make([]ContainerInfo, 0, len(template.Spec.Containers))
+	for _, container := range template.Spec.Containers {
+		ports := make([]PortInfo, 0, len(container.Ports))
+		for _, port := range container.Ports {
+			ports = append(ports, PortInfo{
+				Name:     port.Name,
+				Port:     port.ContainerPort,
+				Protocol: string(port.Protocol),
+			})
+		}
+		containers = append(containers, ContainerInfo{
+			Name:  container.Name,
+			Ports: ports,
+		})
+	}
+
+	return GetUniquePorts([]PodInfo{{Containers: containers}})
+}
+
 // GetUniquePorts extracts unique ports from a list of pods.
-// Returns a sorted list of port numbers with their names (if available).
+// Returns a sorted list of port numbers with their names (if available)
+// and the container declaring them.
 func GetUniquePorts(pods []PodInfo) []PortInfo {
-	portMap := make(map[int32]string)
+	portMap := make(map[int32]PortInfo)
 
 	for _, pod := range pods {
 		for _, container := range pod.Containers {
 			for _, port := range container.Ports {
 				// Prefer named ports
 				if _, ok := portMap[port.Port]; !ok || port.Name != "" {
-					if port.Name != "" {
-						portMap[port.Port] = port.Name
-					} else if !ok {
-						portMap[port.Port] = fmt.Sprintf("port-%d", port.Port)
+					name := port.Name
+					if name == "" {
+						name = fmt.Sprintf("port-%d", port.Port)
+					}
+					portMap[port.Port] = PortInfo{
+						Name:      name,
+						Port:      port.Port,
+						Container: container.Name,
 					}
 				}
 			}
@@ -325,11 +440,8 @@ func GetUniquePorts(pods []PodInfo) []PortInfo {
 
 	// Convert to slice
 	ports := make([]PortInfo, 0, len(portMap))
-	for port, name := range portMap {
-		ports = append(ports, PortInfo{
-			Name: name,
-			Port: port,
-		})
+	for _, port := range portMap {
+		ports = append(ports, port)
 	}
 
 	// Sort by port number
@@ -340,22 +452,22 @@ func GetUniquePorts(pods []PodInfo) []PortInfo {
 	return ports
 }
 
-// CheckPortAvailability checks if a local port is available.
+// CheckPortAvailability checks if a local port is available on both loopback
+// families, as a forward bound to localhost listens on each.
 // Returns: available (bool), processInfo (string), error
+// processInfo names the process holding the port, e.g. "nginx (PID 1234)",
+// falling back to the bind error when no listener can be found.
 func CheckPortAvailability(port int) (bool, string, error) {
 	if port < 1 || port > 65535 {
 		return false, "", fmt.Errorf("invalid port: %d", port)
 	}
 
-	// Try to listen on the port
-	addr := fmt.Sprintf(":%d", port)
-	listener, err := net.Listen("tcp", addr)
-	if err != nil {
-		// Port is in use - return error details
+	if err := localaddr.Probe("", port); err != nil {
+		// Port is in use - report who holds it, or why binding failed
+		if owner := portowner.Lookup(port); owner != "unknown" {
+			return false, owner, nil
+		}
 		return false, err.Error(), nil
 	}
-
-	// Port is available, close the listener
-	_ = listener.Close()
 	return true, "", nil
 }
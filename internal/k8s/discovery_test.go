@@ -2,9 +2,13 @@ package k8s
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -306,3 +310,52 @@ func TestGetUniquePorts(t *testing.T) {
 	assert.Equal(t, "metrics", ports[1].Name)
 	assert.Equal(t, "grpc", ports[2].Name)
 }
+
+// writeNamedKubeconfig writes a kubeconfig with its own cluster and user,
+// named after name, and a context per entry of namespaces (context name to
+// namespace). It returns the file's path.
+func writeNamedKubeconfig(t *testing.T, dir, name string, namespaces map[string]string) string {
+	t.Helper()
+	data := "apiVersion: v1\nkind: Config\n" +
+		"clusters:\n- name: " + name + "\n  cluster:\n    server: https://" + name + ".example:6443\n" +
+		"users:\n- name: " + name + "\n  user:\n    token: t\n" +
+		"contexts:\n"
+	for ctx, ns := range namespaces {
+		data += "- name: " + ctx + "\n  context:\n    cluster: " + name + "\n    user: " + name + "\n    namespace: " + ns + "\n"
+	}
+	path := filepath.Join(dir, name+".yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func TestDiscovery_ListContexts_MultipleKubeconfigs(t *testing.T) {
+	dir := t.TempDir()
+	a := writeNamedKubeconfig(t, dir, "a", map[string]string{"alpha": "alpha-ns", "shared": "from-a"})
+	b := writeNamedKubeconfig(t, dir, "b", map[string]string{"beta": "beta-ns", "shared": "from-b"})
+	t.Setenv("KUBECONFIG", a+string(os.PathListSeparator)+b)
+
+	pool, err := NewClientPool()
+	require.NoError(t, err)
+
+	contexts, err := NewDiscovery(pool).ListContexts()
+	require.NoError(t, err)
+	sort.Strings(contexts)
+	assert.Equal(t, []string{"alpha", "beta", "shared"}, contexts, "contexts from every file are visible")
+
+	// Clients are built from the file that defines the context
+	config, err := pool.GetRestConfig("beta")
+	require.NoError(t, err)
+	assert.Equal(t, "https://b.example:6443", config.Host)
+
+	// As with kubectl, the first file to define a context wins
+	ns, err := pool.GetNamespace("shared")
+	require.NoError(t, err)
+	assert.Equal(t, "from-a", ns)
+
+	t.Setenv("KUBECONFIG", b+string(os.PathListSeparator)+a)
+	pool, err = NewClientPool()
+	require.NoError(t, err)
+	ns, err = pool.GetNamespace("shared")
+	require.NoError(t, err)
+	assert.Equal(t, "from-b", ns)
+}
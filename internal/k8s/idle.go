@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrIdleTimeout is returned by Forward when the tunnel carried no traffic
+// for the request's IdleTimeout and was closed so the caller can reconnect.
+var ErrIdleTimeout = errors.New("port-forward idle timeout")
+
+// maxIdleCheckInterval bounds how late an idle tunnel is noticed.
+const maxIdleCheckInterval = time.Second
+
+// idleTracker records when a tunnel last moved data. It sits in front of the
+// request's TrafficCounter, if any, so byte counts still reach it.
+type idleTracker struct {
+	next    TrafficCounter // optional
+	stop    chan struct{}  // closed when the tunnel should shut down
+	last    atomic.Int64   // unix nanoseconds of the last traffic
+	timeout time.Duration
+	expired atomic.Bool
+}
+
+func newIdleTracker(timeout time.Duration, next TrafficCounter) *idleTracker {
+	t := &idleTracker{
+		next:    next,
+		stop:    make(chan struct{}),
+		timeout: timeout,
+	}
+	t.last.Store(time.Now().UnixNano())
+	return t
+}
+
+func (t *idleTracker) AddSent(n int) {
+	t.touch(n)
+	if t.next != nil {
+		t.next.AddSent(n)
+	}
+}
+
+func (t *idleTracker) AddReceived(n int) {
+	t.touch(n)
+	if t.next != nil {
+		t.next.AddReceived(n)
+	}
+}
+
+func (t *idleTracker) ConnOpened() {
+	if conns, ok := t.next.(ConnectionCounter); ok {
+		conns.ConnOpened()
+	}
+}
+
+func (t *idleTracker) ConnClosed() {
+	if conns, ok := t.next.(ConnectionCounter); ok {
+		conns.ConnClosed()
+	}
+}
+
+func (t *idleTracker) TunnelOpened() {
+	if tunnels, ok := t.next.(TunnelCounter); ok {
+		tunnels.TunnelOpened()
+	}
+}
+
+func (t *idleTracker) StreamSetup(d time.Duration) {
+	if setup, ok := t.next.(SetupRecorder); ok {
+		setup.StreamSetup(d)
+	}
+}
+
+func (t *idleTracker) TraceConnection() StreamTrace {
+	if tracer, ok := t.next.(ConnectionTracer); ok {
+		return tracer.TraceConnection()
+	}
+	return nil
+}
+
+func (t *idleTracker) touch(n int) {
+	if n > 0 {
+		t.last.Store(time.Now().UnixNano())
+	}
+}
+
+// watch closes t.stop when parent is closed or the tunnel has been idle for
+// the timeout. It returns without closing t.stop once done is closed, i.e.
+// when the forward ended on its own.
+func (t *idleTracker) watch(parent, done <-chan struct{}) {
+	interval := min(t.timeout/4, maxIdleCheckInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-parent:
+			close(t.stop)
+			return
+		case <-done:
+			return
+		case now := <-ticker.C:
+			if now.Sub(time.Unix(0, t.last.Load())) >= t.timeout {
+				t.expired.Store(true)
+				close(t.stop)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleTracker_ExpiresWithoutTraffic(t *testing.T) {
+	tracker := newIdleTracker(100*time.Millisecond, nil)
+	parent, done := make(chan struct{}), make(chan struct{})
+	defer close(done)
+	go tracker.watch(parent, done)
+
+	select {
+	case <-tracker.stop:
+		assert.True(t, tracker.expired.Load())
+	case <-time.After(2 * time.Second):
+		t.Fatal("idle tunnel was not stopped")
+	}
+}
+
+func TestIdleTracker_TrafficKeepsAlive(t *testing.T) {
+	counter := &fakeTrafficCounter{}
+	tracker := newIdleTracker(200*time.Millisecond, counter)
+	parent, done := make(chan struct{}), make(chan struct{})
+	go tracker.watch(parent, done)
+
+	// Keep the tunnel busy for well past the timeout
+	for range 8 {
+		time.Sleep(50 * time.Millisecond)
+		tracker.AddSent(10)
+		tracker.AddReceived(0) // an empty read is not traffic
+	}
+	select {
+	case <-tracker.stop:
+		t.Fatal("busy tunnel was stopped")
+	default:
+	}
+	assert.Equal(t, int64(80), counter.sent.Load(), "traffic still reaches the wrapped counter")
+
+	close(done)
+	time.Sleep(250 * time.Millisecond)
+	select {
+	case <-tracker.stop:
+		t.Fatal("stop should stay open once the forward has ended")
+	default:
+	}
+	assert.False(t, tracker.expired.Load())
+}
+
+func TestIdleTracker_ParentStop(t *testing.T) {
+	tracker := newIdleTracker(time.Hour, nil)
+	parent, done := make(chan struct{}), make(chan struct{})
+	defer close(done)
+	go tracker.watch(parent, done)
+
+	close(parent)
+	select {
+	case <-tracker.stop:
+		assert.False(t, tracker.expired.Load(), "a requested stop is not an idle timeout")
+	case <-time.After(2 * time.Second):
+		t.Fatal("stop was not propagated")
+	}
+}
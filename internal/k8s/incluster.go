@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// InClusterContext is the context name of the cluster kportal runs in when
+// it talks to the API server with the pod's service account.
+const InClusterContext = "in-cluster"
+
+var (
+	// serviceAccountDir holds the token and namespace Kubernetes mounts
+	// into every pod. Overridden in tests.
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	// inClusterConfig builds the service account REST config. Overridden
+	// in tests.
+	inClusterConfig = rest.InClusterConfig
+)
+
+// inClusterEnvironment reports whether kportal runs in a pod with a service
+// account token mounted.
+func inClusterEnvironment() bool {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" || os.Getenv("KUBERNETES_SERVICE_PORT") == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(serviceAccountDir, "token"))
+	return err == nil
+}
+
+// inClusterNamespace returns the namespace of the pod kportal runs in, or
+// "default" when it is not mounted.
+func inClusterNamespace() string {
+	data, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if ns := strings.TrimSpace(string(data)); err == nil && ns != "" {
+		return ns
+	}
+	return corev1.NamespaceDefault
+}
+
+// usesInCluster reports whether InClusterContext is available next to the
+// contexts of rawConfig: always when forced, otherwise only when kubeconfig
+// has no contexts and kportal runs in a pod.
+func usesInCluster(rawConfig clientcmdapi.Config, forced bool) bool {
+	return forced || (len(rawConfig.Contexts) == 0 && inClusterEnvironment())
+}
+
+// withInClusterContext returns rawConfig with InClusterContext added, and
+// made current when no context is, if usesInCluster allows it. rawConfig
+// itself is not modified, as its maps are shared with the loader's cache.
+func withInClusterContext(rawConfig clientcmdapi.Config, forced bool) clientcmdapi.Config {
+	if !usesInCluster(rawConfig, forced) {
+		return rawConfig
+	}
+
+	contexts := make(map[string]*clientcmdapi.Context, len(rawConfig.Contexts)+1)
+	for name, ctx := range rawConfig.Contexts {
+		contexts[name] = ctx
+	}
+	contexts[InClusterContext] = &clientcmdapi.Context{Namespace: inClusterNamespace()}
+	rawConfig.Contexts = contexts
+	if rawConfig.CurrentContext == "" {
+		rawConfig.CurrentContext = InClusterContext
+	}
+	return rawConfig
+}
@@ -0,0 +1,96 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+// fakeInCluster makes the test look like it runs in a pod of namespace ns,
+// with kubeconfig pointing at path.
+func fakeInCluster(t *testing.T, ns, kubeconfig string) {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("token"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "namespace"), []byte(ns+"\n"), 0o600))
+
+	origDir, origConfig := serviceAccountDir, inClusterConfig
+	serviceAccountDir = dir
+	inClusterConfig = func() (*rest.Config, error) {
+		return &rest.Config{Host: "https://10.96.0.1:443", BearerToken: "token"}, nil
+	}
+	t.Cleanup(func() { serviceAccountDir, inClusterConfig = origDir, origConfig })
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.96.0.1")
+	t.Setenv("KUBERNETES_SERVICE_PORT", "443")
+	t.Setenv("KUBECONFIG", kubeconfig)
+}
+
+func TestClientPool_InClusterFallback(t *testing.T) {
+	fakeInCluster(t, "apps", filepath.Join(t.TempDir(), "missing"))
+
+	pool, err := NewClientPool()
+	require.NoError(t, err)
+
+	contexts, err := pool.ListContexts()
+	require.NoError(t, err)
+	assert.Equal(t, []string{InClusterContext}, contexts)
+
+	current, err := pool.GetCurrentContext()
+	require.NoError(t, err)
+	assert.Equal(t, InClusterContext, current)
+
+	ns, err := pool.GetNamespace(InClusterContext)
+	require.NoError(t, err)
+	assert.Equal(t, "apps", ns, "the pod's namespace is the default")
+
+	config, err := pool.GetRestConfig(InClusterContext)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.96.0.1:443", config.Host)
+}
+
+func TestClientPool_InClusterNotInPod(t *testing.T) {
+	fakeInCluster(t, "apps", filepath.Join(t.TempDir(), "missing"))
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	pool, err := NewClientPool()
+	require.NoError(t, err)
+
+	contexts, err := pool.ListContexts()
+	require.NoError(t, err)
+	assert.Empty(t, contexts)
+	_, err = pool.GetRestConfig(InClusterContext)
+	assert.ErrorIs(t, err, ErrContextNotFound)
+}
+
+func TestClientPool_SetInCluster(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	writeKubeconfig(t, path, "dev")
+	fakeInCluster(t, "apps", path)
+
+	pool, err := NewClientPool()
+	require.NoError(t, err)
+
+	// A kubeconfig with contexts wins over autodetection
+	ok, err := pool.HasContext(InClusterContext)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	pool.SetInCluster(true)
+	contexts, err := pool.ListContexts()
+	require.NoError(t, err)
+	sort.Strings(contexts)
+	assert.Equal(t, []string{"dev", InClusterContext}, contexts)
+
+	config, err := pool.GetRestConfig(InClusterContext)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.96.0.1:443", config.Host)
+	config, err = pool.GetRestConfig("dev")
+	require.NoError(t, err)
+	assert.Equal(t, "https://127.0.0.1:6443", config.Host)
+}
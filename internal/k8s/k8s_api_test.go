@@ -1,16 +1,19 @@
 package k8s
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 // =============================================================================
@@ -31,6 +34,44 @@ func setupTestPool(t *testing.T, contextName string, objects ...runtime.Object)
 	return pool
 }
 
+// =============================================================================
+// ClientPool API Tests
+// =============================================================================
+
+func TestClientPool_CheckReachable(t *testing.T) {
+	tests := []struct {
+		listErr       error
+		name          string
+		errorContains string
+	}{
+		{name: "cluster answers"},
+		{name: "forbidden still reachable", listErr: apierrors.NewForbidden(corev1.Resource("namespaces"), "", errors.New("rbac"))},
+		{name: "network error", listErr: errors.New("dial tcp: connection refused"), errorContains: "cluster for context test-context is unreachable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, err := NewClientPool()
+			require.NoError(t, err)
+			fakeClient := fake.NewClientset()
+			if tt.listErr != nil {
+				fakeClient.PrependReactor("list", "namespaces", func(k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tt.listErr
+				})
+			}
+			pool.setTestClient("test-context", fakeClient)
+
+			err = pool.CheckReachable(t.Context(), "test-context")
+			if tt.errorContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
 // =============================================================================
 // Discovery API Tests
 // =============================================================================
@@ -462,6 +503,45 @@ func TestPortForwarder_GetPodForResource_Pod(t *testing.T) {
 	assert.Equal(t, "my-pod", podName)
 }
 
+func TestPortForwarder_InvalidateResource(t *testing.T) {
+	baseTime := time.Now()
+	pool := setupTestPool(t, "test-context",
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "my-app-1",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: baseTime},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+	client, err := pool.GetClient("test-context")
+	require.NoError(t, err)
+
+	pf := NewPortForwarder(pool, NewResourceResolver(pool))
+
+	podName, err := pf.GetPodForResource(t.Context(), "test-context", "default", "pod/my-app", "")
+	require.NoError(t, err)
+	assert.Equal(t, "my-app-1", podName)
+
+	require.NoError(t, client.CoreV1().Pods("default").Delete(t.Context(), "my-app-1", metav1.DeleteOptions{}))
+	_, err = client.CoreV1().Pods("default").Create(t.Context(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "my-app-2",
+			Namespace:         "default",
+			CreationTimestamp: metav1.Time{Time: baseTime.Add(time.Minute)},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	pf.InvalidateResource("test-context", "default", "pod/my-app", "")
+
+	podName, err = pf.GetPodForResource(t.Context(), "test-context", "default", "pod/my-app", "")
+	require.NoError(t, err)
+	assert.Equal(t, "my-app-2", podName)
+}
+
 func TestPortForwarder_GetPodForResource_Service(t *testing.T) {
 	pool := setupTestPool(t, "test-context",
 		&corev1.Pod{
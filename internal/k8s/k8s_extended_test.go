@@ -105,7 +105,7 @@ func TestPortForwarder_ForwardRequestValidation(t *testing.T) {
 		},
 		{
 			name:        "unsupported resource type",
-			resource:    "deployment/my-deployment",
+			resource:    "configmap/x",
 			expectedErr: true,
 			errContains: "unsupported resource type",
 		},
@@ -282,6 +282,9 @@ func TestGetUniquePorts_MultipleContainers(t *testing.T) {
 	}
 	assert.Contains(t, ports, int32(8080))
 	assert.Contains(t, ports, int32(9090))
+
+	assert.Equal(t, "app", result[0].Container)
+	assert.Equal(t, "sidecar", result[1].Container)
 }
 
 func TestGetUniquePorts_DuplicateAcrossPods(t *testing.T) {
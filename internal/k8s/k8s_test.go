@@ -2,7 +2,9 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -404,6 +406,8 @@ func TestCheckPortAvailability_PortInUse(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, available)
 	assert.NotEmpty(t, processInfo)
+	// The holder is this test process, not the raw bind error
+	assert.Contains(t, processInfo, fmt.Sprintf("PID %d", os.Getpid()))
 }
 
 // =============================================================================
@@ -489,7 +493,7 @@ func TestResourceResolver_Resolve_UnsupportedType(t *testing.T) {
 	r := NewResourceResolver(pool)
 
 	ctx := context.Background()
-	result, err := r.Resolve(ctx, "test-context", "default", "deployment/my-deploy", "")
+	result, err := r.Resolve(ctx, "test-context", "default", "configmap/x", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported resource type")
@@ -608,6 +612,62 @@ func TestResourceResolver_ClearCache(t *testing.T) {
 	r.cacheMu.RUnlock()
 }
 
+func TestResourceResolver_CacheMaxEntries(t *testing.T) {
+	pool, err := NewClientPool()
+	require.NoError(t, err)
+	r := NewResourceResolver(pool)
+	r.SetCacheMaxEntries(2)
+
+	r.putInCache("key1", "value1")
+	time.Sleep(time.Millisecond)
+	r.putInCache("key2", "value2")
+	time.Sleep(time.Millisecond)
+
+	// A hit makes key1 the most recently used, so key2 is evicted
+	assert.Equal(t, "value1", r.getFromCache("key1"))
+	r.putInCache("key3", "value3")
+
+	assert.Equal(t, "value1", r.getFromCache("key1"))
+	assert.Empty(t, r.getFromCache("key2"))
+	assert.Equal(t, "value3", r.getFromCache("key3"))
+
+	// Replacing an entry does not evict another
+	r.putInCache("key3", "value3b")
+	assert.Equal(t, "value1", r.getFromCache("key1"))
+
+	// Lowering the cap trims the cache now
+	r.SetCacheMaxEntries(1)
+	r.cacheMu.RLock()
+	assert.Len(t, r.cache, 1)
+	r.cacheMu.RUnlock()
+
+	// No cap
+	r.SetCacheMaxEntries(0)
+	for i := range 5 {
+		r.putInCache(fmt.Sprintf("key-%d", i), "value")
+	}
+	r.cacheMu.RLock()
+	assert.Len(t, r.cache, 6)
+	r.cacheMu.RUnlock()
+}
+
+func TestResourceResolver_CacheMaxEntries_EvictsExpiredFirst(t *testing.T) {
+	pool, err := NewClientPool()
+	require.NoError(t, err)
+	r := NewResourceResolver(pool)
+	r.SetCacheMaxEntries(2)
+
+	r.SetCacheTTL(time.Millisecond)
+	r.putInCache("old", "value")
+	r.SetCacheTTL(time.Minute)
+	time.Sleep(5 * time.Millisecond)
+	r.putInCache("fresh", "value")
+	r.putInCache("new", "value")
+
+	assert.Equal(t, "value", r.getFromCache("fresh"))
+	assert.Equal(t, "value", r.getFromCache("new"))
+}
+
 func TestResourceResolver_InvalidateCache(t *testing.T) {
 	pool, err := NewClientPool()
 	require.NoError(t, err)
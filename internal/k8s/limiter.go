@@ -0,0 +1,89 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultContextConcurrency is the default limit on concurrent API calls
+// made by discovery and resource resolution in a single context.
+const DefaultContextConcurrency = 5
+
+// concurrencyLimiter bounds concurrent API calls per context and, when
+// total is set, across all contexts, so starting many forwards at once does
+// not burst a cluster's rate limits.
+type concurrencyLimiter struct {
+	total      chan struct{}            // nil when only contexts are limited
+	perContext map[string]chan struct{} // created on first use
+	mu         sync.Mutex
+	limit      int // per context; 0 means unlimited
+}
+
+// newConcurrencyLimiter returns a limiter allowing perContext calls per
+// context and total calls overall. Zero disables either limit.
+func newConcurrencyLimiter(perContext, total int) *concurrencyLimiter {
+	l := &concurrencyLimiter{
+		perContext: make(map[string]chan struct{}),
+		limit:      perContext,
+	}
+	if total > 0 {
+		l.total = make(chan struct{}, total)
+	}
+	return l
+}
+
+// acquire blocks until a call in contextName may start, or ctx is done.
+// The returned release must be called once the call has finished.
+func (l *concurrencyLimiter) acquire(ctx context.Context, contextName string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	var slot chan struct{}
+	if l.limit > 0 {
+		l.mu.Lock()
+		slot = l.perContext[contextName]
+		if slot == nil {
+			slot = make(chan struct{}, l.limit)
+			l.perContext[contextName] = slot
+		}
+		l.mu.Unlock()
+
+		select {
+		case slot <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if l.total != nil {
+		select {
+		case l.total <- struct{}{}:
+		case <-ctx.Done():
+			if slot != nil {
+				<-slot
+			}
+			return nil, ctx.Err()
+		}
+	}
+
+	return func() {
+		if l.total != nil {
+			<-l.total
+		}
+		if slot != nil {
+			<-slot
+		}
+	}, nil
+}
+
+// limited runs call once pool has a free slot for contextName
+func limited[T any](ctx context.Context, pool *ClientPool, contextName string, call func() (T, error)) (T, error) {
+	release, err := pool.acquire(ctx, contextName)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer release()
+	return call()
+}
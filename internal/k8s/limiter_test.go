@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runConcurrent runs one call through l per entry of contexts and returns
+// the highest number running at once
+func runConcurrent(t *testing.T, l *concurrencyLimiter, contexts []string) int {
+	t.Helper()
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for _, contextName := range contexts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := l.acquire(context.Background(), contextName)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer release()
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+		}()
+	}
+	wg.Wait()
+	return int(peak.Load())
+}
+
+func TestConcurrencyLimiter_PerContext(t *testing.T) {
+	l := newConcurrencyLimiter(2, 0)
+
+	assert.LessOrEqual(t, runConcurrent(t, l, []string{"a", "a", "a", "a", "a", "a"}), 2)
+	// Each context has its own slots
+	assert.Greater(t, runConcurrent(t, l, []string{"a", "a", "b", "b", "c", "c"}), 2)
+}
+
+func TestConcurrencyLimiter_Total(t *testing.T) {
+	l := newConcurrencyLimiter(0, 3)
+
+	assert.LessOrEqual(t, runConcurrent(t, l, []string{"a", "b", "c", "d", "e", "f"}), 3)
+}
+
+func TestConcurrencyLimiter_ContextDone(t *testing.T) {
+	l := newConcurrencyLimiter(1, 0)
+	release, err := l.acquire(context.Background(), "a")
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, "a")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestConcurrencyLimiter_TotalContextDone(t *testing.T) {
+	l := newConcurrencyLimiter(1, 1)
+	release, err := l.acquire(context.Background(), "a")
+	require.NoError(t, err)
+
+	// "b" gets its context slot but times out waiting for the total one
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, "b")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	release()
+
+	// The context slot was handed back, so "b" can go now
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	release, err = l.acquire(ctx, "b")
+	require.NoError(t, err)
+	release()
+}
+
+func TestConcurrencyLimiter_Nil(t *testing.T) {
+	var l *concurrencyLimiter
+	release, err := l.acquire(context.Background(), "a")
+	require.NoError(t, err)
+	release()
+
+	// A pool built without NewClientPool has no limiter
+	pool := &ClientPool{}
+	got, err := limited(context.Background(), pool, "a", func() (int, error) { return 42, nil })
+	require.NoError(t, err)
+	assert.Equal(t, 42, got)
+}
+
+func TestClientPool_SetConcurrency(t *testing.T) {
+	pool := &ClientPool{}
+	pool.SetConcurrency(1, 0)
+
+	release, err := pool.acquire(context.Background(), "a")
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = limited(ctx, pool, "a", func() (int, error) { return 1, nil })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
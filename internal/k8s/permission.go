@@ -0,0 +1,57 @@
+package k8s
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// PermissionError reports an API call RBAC denied, naming the permission
+// the credentials lack so the user knows what to ask for.
+type PermissionError struct {
+	Err       error  // the Forbidden error returned by the API server
+	Verb      string // e.g. "list", "create"
+	Resource  string // e.g. "pods", "pods/portforward"
+	Namespace string // empty for cluster-scoped resources
+}
+
+func (e *PermissionError) Error() string {
+	return "Permission denied: you need " + MissingPermission(e.Verb, e.Resource, e.Namespace)
+}
+
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// MissingPermission formats an RBAC permission, e.g. "create
+// pods/portforward in dev", or "list namespaces (cluster-wide)" without a
+// namespace.
+func MissingPermission(verb, resource, namespace string) string {
+	if namespace == "" {
+		return fmt.Sprintf("%s %s (cluster-wide)", verb, resource)
+	}
+	return fmt.Sprintf("%s %s in %s", verb, resource, namespace)
+}
+
+// permissionError returns err as a PermissionError for verb on resource
+// when the API server answered Forbidden, and err unchanged otherwise.
+func permissionError(err error, verb, resource, namespace string) error {
+	if !apierrors.IsForbidden(err) {
+		return err
+	}
+	return &PermissionError{Err: err, Verb: verb, Resource: resource, Namespace: namespace}
+}
+
+// upgradeDialer remembers why the port-forward connection upgrade failed:
+// ForwardPorts reports it only as text, which loses a Forbidden status.
+type upgradeDialer struct {
+	dialer httpstream.Dialer
+	err    error
+}
+
+func (d *upgradeDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.dialer.Dial(protocols...)
+	d.err = err
+	return conn, protocol, err
+}
@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestMissingPermission(t *testing.T) {
+	assert.Equal(t, "create pods/portforward in dev", MissingPermission("create", "pods/portforward", "dev"))
+	assert.Equal(t, "list namespaces (cluster-wide)", MissingPermission("list", "namespaces", ""))
+}
+
+func TestPermissionError(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no access"))
+
+	err := permissionError(forbidden, "create", "pods/portforward", "dev")
+	var permErr *PermissionError
+	require.ErrorAs(t, err, &permErr)
+	assert.Equal(t, "Permission denied: you need create pods/portforward in dev", permErr.Error())
+	assert.True(t, apierrors.IsForbidden(err), "the API error stays reachable")
+
+	other := errors.New("connection refused")
+	assert.Equal(t, other, permissionError(other, "list", "pods", "dev"))
+	assert.Nil(t, permissionError(nil, "list", "pods", "dev"))
+}
+
+func TestDiscovery_ListPods_Forbidden(t *testing.T) {
+	pool, err := NewClientPool()
+	require.NoError(t, err)
+	client := fake.NewClientset()
+	client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("RBAC"))
+	})
+	pool.setTestClient("dev", client)
+
+	_, err = NewDiscovery(pool).ListPods(context.Background(), "dev", "apps")
+	var permErr *PermissionError
+	require.ErrorAs(t, err, &permErr)
+	assert.Equal(t, "list", permErr.Verb)
+	assert.Equal(t, "pods", permErr.Resource)
+	assert.Equal(t, "apps", permErr.Namespace)
+}
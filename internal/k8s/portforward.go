@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -11,14 +12,36 @@ import (
 	"time"
 
 	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/localaddr"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 )
 
+// ErrUDPNotSupported is returned when a UDP forward is requested. The
+// Kubernetes portforward subresource only multiplexes TCP streams, so
+// datagrams cannot be tunneled to the pod.
+var ErrUDPNotSupported = errors.New("UDP forwarding is not supported: the Kubernetes port-forward API only tunnels TCP")
+
+// CheckProtocol returns an error if the given forward protocol cannot be
+// tunneled through the Kubernetes port-forward API. An empty protocol is
+// treated as TCP.
+func CheckProtocol(protocol string) error {
+	switch protocol {
+	case "", config.ProtocolTCP:
+		return nil
+	case config.ProtocolUDP:
+		return ErrUDPNotSupported
+	default:
+		return fmt.Errorf("unsupported protocol: %s", protocol)
+	}
+}
+
 // PortForwarder handles Kubernetes port-forwarding operations.
 type PortForwarder struct {
 	clientPool   *ClientPool
@@ -49,22 +72,35 @@ func (pf *PortForwarder) SetDialTimeout(timeout time.Duration) {
 
 // ForwardRequest contains the parameters for a port-forward request.
 type ForwardRequest struct {
-	Out         io.Writer
-	ErrOut      io.Writer
-	StopChan    chan struct{}
-	ReadyChan   chan struct{}
-	ContextName string
-	Namespace   string
-	Resource    string
-	Selector    string
-	LocalPort   int
-	RemotePort  int
+	Out          io.Writer
+	ErrOut       io.Writer
+	Traffic      TrafficCounter // optional: receives bytes moved through the tunnel
+	StopChan     chan struct{}
+	ReadyChan    chan struct{}
+	ContextName  string
+	Namespace    string
+	Resource     string
+	Selector     string
+	Container    string // optional: container whose declared ports are used
+	PortName     string // optional: named service or container port, translated to RemotePort
+	Protocol     string
+	BindAddress  string // optional: local IP to listen on; empty listens on both loopbacks
+	LocalPort    int
+	RemotePort   int
+	IdleTimeout  time.Duration // optional: close the tunnel after this long without traffic
+	TCPKeepalive time.Duration // optional: overrides SetTCPKeepalive for this forward
+	DialTimeout  time.Duration // optional: overrides SetDialTimeout for this forward
 }
 
 // Forward establishes a port-forward connection to a Kubernetes resource.
 // It supports both pod and service forwarding.
 // The connection runs until StopChan is closed or an error occurs.
+// UDP requests fail immediately with ErrUDPNotSupported.
 func (pf *PortForwarder) Forward(ctx context.Context, req *ForwardRequest) error {
+	if err := CheckProtocol(req.Protocol); err != nil {
+		return err
+	}
+
 	// Resolve the resource to an actual pod name
 	resolvedResource, err := pf.resolver.Resolve(ctx, req.ContextName, req.Namespace, req.Resource, req.Selector)
 	if err != nil {
@@ -93,27 +129,32 @@ func (pf *PortForwarder) Forward(ctx context.Context, req *ForwardRequest) error
 
 // forwardToPod establishes a port-forward to a specific pod.
 func (pf *PortForwarder) forwardToPod(ctx context.Context, req *ForwardRequest, podName string) error {
-	// Get Kubernetes client and config
+	// Get Kubernetes client
 	client, err := pf.clientPool.GetClient(req.ContextName)
 	if err != nil {
 		return fmt.Errorf("failed to get client: %w", err)
 	}
 
-	config, err := pf.clientPool.GetRestConfig(req.ContextName)
-	if err != nil {
-		return fmt.Errorf("failed to get rest config: %w", err)
-	}
-
 	// Verify pod exists and is running
 	pod, err := client.CoreV1().Pods(req.Namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to get pod: %w", err)
+		return fmt.Errorf("failed to get pod: %w", permissionError(err, "get", "pods", req.Namespace))
 	}
 
 	if pod.Status.Phase != corev1.PodRunning {
 		return fmt.Errorf("pod is not running (current phase: %s)", pod.Status.Phase)
 	}
 
+	req, err = withContainerPort(req, pod)
+	if err != nil {
+		return err
+	}
+
+	config, err := pf.clientPool.GetRestConfig(req.ContextName)
+	if err != nil {
+		return fmt.Errorf("failed to get rest config: %w", err)
+	}
+
 	// Build the port-forward URL
 	reqURL := client.CoreV1().RESTClient().Post().
 		Resource("pods").
@@ -138,7 +179,7 @@ func (pf *PortForwarder) forwardToService(ctx context.Context, req *ForwardReque
 	// Get the service
 	service, err := client.CoreV1().Services(req.Namespace).Get(ctx, serviceName, metav1.GetOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to get service: %w", err)
+		return fmt.Errorf("failed to get service: %w", permissionError(err, "get", "services", req.Namespace))
 	}
 
 	// Get pods backing the service using label selector
@@ -150,7 +191,7 @@ func (pf *PortForwarder) forwardToService(ctx context.Context, req *ForwardReque
 		LabelSelector: selector,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to list pods for service: %w", err)
+		return fmt.Errorf("failed to list pods for service: %w", permissionError(err, "list", "pods", req.Namespace))
 	}
 
 	// Find first running pod
@@ -167,6 +208,16 @@ func (pf *PortForwarder) forwardToService(ctx context.Context, req *ForwardReque
 		return fmt.Errorf("no running pods found for service %s", serviceName)
 	}
 
+	req, err = withServicePort(req, service, targetPod)
+	if err != nil {
+		return err
+	}
+
+	req, err = withContainerPort(req, targetPod)
+	if err != nil {
+		return err
+	}
+
 	// Forward to the pod
 	config, err := pf.clientPool.GetRestConfig(req.ContextName)
 	if err != nil {
@@ -183,6 +234,50 @@ func (pf *PortForwarder) forwardToService(ctx context.Context, req *ForwardReque
 	return pf.executePortForward(config, reqURL, req)
 }
 
+// withServicePort translates req's port name to the current target port of
+// the service port with that name, looked up on every connect so a service
+// that renumbers its targetPort keeps working. Names that match no service
+// port are left for withContainerPort to look up on the pod.
+// req is copied rather than modified when the port name is translated.
+func withServicePort(req *ForwardRequest, service *corev1.Service, pod *corev1.Pod) (*ForwardRequest, error) {
+	if req.PortName == "" {
+		return req, nil
+	}
+
+	port, found, err := ResolveServicePort(service, pod, req.Container, req.PortName)
+	if err != nil || !found {
+		return req, err
+	}
+	if req.RemotePort != 0 && req.RemotePort != port {
+		return nil, fmt.Errorf("port %s resolves to %d for service %s, but port %d is configured", req.PortName, port, service.Name, req.RemotePort)
+	}
+
+	resolved := *req
+	resolved.RemotePort = port
+	resolved.PortName = ""
+	return &resolved, nil
+}
+
+// withContainerPort resolves req's container and port name against pod.
+// req is copied rather than modified when the remote port changes.
+func withContainerPort(req *ForwardRequest, pod *corev1.Pod) (*ForwardRequest, error) {
+	if req.Container == "" && req.PortName == "" {
+		return req, nil
+	}
+
+	port, err := ResolveContainerPort(pod, req.Container, req.PortName, req.RemotePort)
+	if err != nil {
+		return nil, err
+	}
+	if port == req.RemotePort {
+		return req, nil
+	}
+
+	resolved := *req
+	resolved.RemotePort = port
+	return &resolved, nil
+}
+
 // executePortForward performs the actual port-forward operation.
 func (pf *PortForwarder) executePortForward(config *rest.Config, url *url.URL, req *ForwardRequest) error {
 	// Clone the rest.Config before mutating. ClientPool.GetRestConfig returns a
@@ -197,10 +292,17 @@ func (pf *PortForwarder) executePortForward(config *rest.Config, url *url.URL, r
 		// Create a custom dialer with configurable timeout and keepalive
 		// - Timeout: How long to wait for connection to establish
 		// - KeepAlive: TCP keepalive helps OS detect dead connections at network layer
+		// The request may override both for a single forward.
 		dialer := &net.Dialer{
 			Timeout:   pf.dialTimeout,  // Configurable dial timeout
 			KeepAlive: pf.tcpKeepalive, // Configurable keepalive interval
 		}
+		if req.DialTimeout > 0 {
+			dialer.Timeout = req.DialTimeout
+		}
+		if req.TCPKeepalive > 0 {
+			dialer.KeepAlive = req.TCPKeepalive
+		}
 		cfg.Dial = dialer.DialContext
 	}
 
@@ -210,8 +312,26 @@ func (pf *PortForwarder) executePortForward(config *rest.Config, url *url.URL, r
 		return fmt.Errorf("failed to create round tripper: %w", err)
 	}
 
+	// Close the tunnel once it has carried no traffic for IdleTimeout
+	counter := req.Traffic
+	stopChan := req.StopChan
+	var idle *idleTracker
+	if req.IdleTimeout > 0 {
+		idle = newIdleTracker(req.IdleTimeout, req.Traffic)
+		counter = idle
+		stopChan = idle.stop
+
+		done := make(chan struct{})
+		defer close(done)
+		go idle.watch(req.StopChan, done)
+	}
+
 	// Create dialer
-	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+	upgrade := &upgradeDialer{dialer: spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)}
+	var dialer httpstream.Dialer = upgrade
+	if counter != nil {
+		dialer = &countingDialer{dialer: dialer, counter: counter}
+	}
 
 	// Set up port forwarding
 	ports := []string{fmt.Sprintf("%d:%d", req.LocalPort, req.RemotePort)}
@@ -227,19 +347,35 @@ func (pf *PortForwarder) executePortForward(config *rest.Config, url *url.URL, r
 	}
 
 	// Create port forwarder
-	fw, err := portforward.New(dialer, ports, req.StopChan, req.ReadyChan, out, errOut)
+	addresses := []string{localaddr.Normalize(req.BindAddress)}
+	fw, err := portforward.NewOnAddresses(dialer, addresses, ports, stopChan, req.ReadyChan, out, errOut)
 	if err != nil {
 		return fmt.Errorf("failed to create port forwarder: %w", err)
 	}
 
 	// Start forwarding (blocks until stopped or error)
 	if err := fw.ForwardPorts(); err != nil {
+		// ForwardPorts flattens a failed upgrade to text, so a denied
+		// pods/portforward is recognized from the dial error itself
+		if apierrors.IsForbidden(upgrade.err) {
+			err = permissionError(upgrade.err, "create", "pods/portforward", req.Namespace)
+		}
 		return fmt.Errorf("port forward failed: %w", err)
 	}
 
+	if idle != nil && idle.expired.Load() {
+		return fmt.Errorf("%w: no traffic for %v", ErrIdleTimeout, req.IdleTimeout)
+	}
 	return nil
 }
 
+// InvalidateResource forgets the pod a resource resolved to, so the next
+// GetPodForResource or Forward picks a pod afresh. Call it when a forward
+// to that pod fails or drops.
+func (pf *PortForwarder) InvalidateResource(contextName, namespace, resource, selector string) {
+	pf.resolver.Invalidate(contextName, namespace, resource, selector)
+}
+
 // GetPodForResource returns the pod name that would be used for forwarding.
 // This is useful for logging and debugging.
 func (pf *PortForwarder) GetPodForResource(ctx context.Context, contextName, namespace, resource, selector string) (string, error) {
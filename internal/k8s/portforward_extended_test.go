@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // =============================================================================
@@ -110,7 +111,7 @@ func TestPortForwarder_Forward_UnsupportedResourceType(t *testing.T) {
 		StopChan:    stopChan,
 		ContextName: "test-context",
 		Namespace:   "default",
-		Resource:    "deployment/my-deploy",
+		Resource:    "configmap/x",
 		LocalPort:   8080,
 		RemotePort:  80,
 	}
@@ -159,7 +160,7 @@ func TestPortForwarder_GetPodForResource_UnsupportedType(t *testing.T) {
 	r := NewResourceResolver(pool)
 	pf := NewPortForwarder(pool, r)
 
-	_, err := pf.GetPodForResource(t.Context(), "test-context", "default", "deployment/my-deploy", "")
+	_, err := pf.GetPodForResource(t.Context(), "test-context", "default", "configmap/x", "")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported resource type")
 }
@@ -341,3 +342,121 @@ func TestPortForwarder_GetPodForResource_ServicePendingPod(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "no running pods found for service")
 }
+
+func TestCheckProtocol(t *testing.T) {
+	assert.NoError(t, CheckProtocol(""))
+	assert.NoError(t, CheckProtocol("tcp"))
+	assert.ErrorIs(t, CheckProtocol("udp"), ErrUDPNotSupported)
+
+	err := CheckProtocol("sctp")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported protocol")
+}
+
+func TestPortForwarder_Forward_UDPRejected(t *testing.T) {
+	pool := setupTestPool(t, "test-context")
+
+	r := NewResourceResolver(pool)
+	pf := NewPortForwarder(pool, r)
+
+	req := &ForwardRequest{
+		StopChan:    make(chan struct{}),
+		ContextName: "test-context",
+		Namespace:   "kube-system",
+		Resource:    "service/kube-dns",
+		Protocol:    "udp",
+		LocalPort:   5353,
+		RemotePort:  53,
+	}
+
+	// Rejected before resolution, so the missing service is never looked up
+	err := pf.Forward(t.Context(), req)
+	assert.ErrorIs(t, err, ErrUDPNotSupported)
+}
+
+func TestPortForwarder_Forward_ContainerPortNotFound(t *testing.T) {
+	pool := setupTestPool(t, "test-context",
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pod",
+				Namespace: "default",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+
+	r := NewResourceResolver(pool)
+	pf := NewPortForwarder(pool, r)
+
+	req := &ForwardRequest{
+		StopChan:    make(chan struct{}),
+		ContextName: "test-context",
+		Namespace:   "default",
+		Resource:    "pod/test-pod",
+		Container:   "app",
+		PortName:    "grpc",
+		LocalPort:   8080,
+	}
+
+	err := pf.Forward(t.Context(), req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "port grpc not found on container app")
+}
+
+func TestWithContainerPort(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+			},
+		},
+	}
+
+	plain := &ForwardRequest{RemotePort: 80}
+	got, err := withContainerPort(plain, pod)
+	require.NoError(t, err)
+	assert.Same(t, plain, got, "requests without container or port name are passed through")
+
+	named := &ForwardRequest{PortName: "http"}
+	got, err = withContainerPort(named, pod)
+	require.NoError(t, err)
+	assert.Equal(t, 8080, got.RemotePort)
+	assert.Equal(t, 0, named.RemotePort, "original request is not modified")
+}
+
+func TestWithServicePort(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1"}}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "grpc", Port: 9090, TargetPort: intstr.FromInt32(50051)}},
+		},
+	}
+
+	plain := &ForwardRequest{RemotePort: 80}
+	got, err := withServicePort(plain, service, pod)
+	require.NoError(t, err)
+	assert.Same(t, plain, got, "requests without a port name are passed through")
+
+	containerName := &ForwardRequest{PortName: "http"}
+	got, err = withServicePort(containerName, service, pod)
+	require.NoError(t, err)
+	assert.Same(t, containerName, got, "names that are not service ports are left for the pod lookup")
+
+	named := &ForwardRequest{PortName: "grpc"}
+	got, err = withServicePort(named, service, pod)
+	require.NoError(t, err)
+	assert.Equal(t, 50051, got.RemotePort)
+	assert.Empty(t, got.PortName, "the translated name must not be looked up again on the pod")
+	assert.Equal(t, "grpc", named.PortName, "original request is not modified")
+
+	_, err = withServicePort(&ForwardRequest{PortName: "grpc", RemotePort: 8080}, service, pod)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "port grpc resolves to 50051 for service api, but port 8080 is configured")
+}
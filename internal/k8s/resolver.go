@@ -8,13 +8,17 @@ import (
 	"sync"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
 	// Default cache TTL for resolved resources
 	defaultCacheTTL = 30 * time.Second
+	// Default cap on cached resolutions; the least recently used go first
+	defaultCacheMaxEntries = 1000
 )
 
 // ResolvedResource represents a resolved Kubernetes resource.
@@ -27,37 +31,57 @@ type ResolvedResource struct {
 // cacheEntry stores a cached resolution result with expiry.
 type cacheEntry struct {
 	expiresAt time.Time
+	lastUsed  time.Time // set on put and on every cache hit, for eviction
 	resource  ResolvedResource
 }
 
 // ResourceResolver resolves Kubernetes resources with caching.
 // It handles prefix matching for pods and label selector resolution.
 type ResourceResolver struct {
-	clientPool *ClientPool
-	cache      map[string]cacheEntry // key: contextName/namespace/resource -> resolved name
-	cacheMu    sync.RWMutex
-	cacheTTL   time.Duration
+	clientPool      *ClientPool
+	cache           map[string]cacheEntry // key: contextName/namespace/resource -> resolved name
+	cacheMu         sync.RWMutex
+	cacheTTL        time.Duration
+	cacheMaxEntries int // 0 means unbounded
 }
 
 // NewResourceResolver creates a new ResourceResolver instance.
 func NewResourceResolver(clientPool *ClientPool) *ResourceResolver {
 	return &ResourceResolver{
-		clientPool: clientPool,
-		cache:      make(map[string]cacheEntry),
-		cacheTTL:   defaultCacheTTL,
+		clientPool:      clientPool,
+		cache:           make(map[string]cacheEntry),
+		cacheTTL:        defaultCacheTTL,
+		cacheMaxEntries: defaultCacheMaxEntries,
 	}
 }
 
 // SetCacheTTL sets the cache TTL for resolved resources.
 func (r *ResourceResolver) SetCacheTTL(ttl time.Duration) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
 	r.cacheTTL = ttl
 }
 
+// SetCacheMaxEntries caps the number of cached resolutions. When the cache
+// is full, expired entries are dropped first and then the least recently
+// used one. 0 removes the cap. A cache above the new cap is trimmed now.
+func (r *ResourceResolver) SetCacheMaxEntries(n int) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	r.cacheMaxEntries = n
+	for n > 0 && len(r.cache) > n {
+		r.evictLocked()
+	}
+}
+
 // Resolve resolves a resource name to an actual pod or service name.
 // It supports:
 // - pod/prefix: Prefix matching (e.g., "pod/my-app" matches "my-app-xyz789")
 // - pod + selector: Label selector matching (e.g., "pod" with selector "app=nginx")
 // - service/name: Direct service name (no resolution needed)
+// - deployment/name, statefulset/name: Newest running pod matching the workload's selector
 func (r *ResourceResolver) Resolve(ctx context.Context, contextName, namespace, resource, selector string) (string, error) {
 	// Parse resource type and name
 	parts := strings.SplitN(resource, "/", 2)
@@ -71,6 +95,14 @@ func (r *ResourceResolver) Resolve(ctx context.Context, contextName, namespace,
 		return resource, nil
 	}
 
+	// Workloads resolve to one of their pods via the workload's selector
+	if resourceType == "deployment" || resourceType == "statefulset" {
+		if len(parts) < 2 || parts[1] == "" {
+			return "", fmt.Errorf("invalid %s resource format: %s", resourceType, resource)
+		}
+		return r.resolveWorkload(ctx, contextName, namespace, resourceType, parts[1])
+	}
+
 	// Handle pod resolution
 	if resourceType == "pod" {
 		if len(parts) == 2 {
@@ -94,7 +126,7 @@ func (r *ResourceResolver) Resolve(ctx context.Context, contextName, namespace,
 // It returns the newest running pod that matches the prefix.
 func (r *ResourceResolver) resolvePodPrefix(ctx context.Context, contextName, namespace, prefix string) (string, error) {
 	// Check cache first
-	cacheKey := fmt.Sprintf("%s/%s/pod/%s", contextName, namespace, prefix)
+	cacheKey := resourceCacheKey(contextName, namespace, "pod/"+prefix, "")
 	if cached := r.getFromCache(cacheKey); cached != "" {
 		return fmt.Sprintf("pod/%s", cached), nil
 	}
@@ -106,16 +138,18 @@ func (r *ResourceResolver) resolvePodPrefix(ctx context.Context, contextName, na
 	}
 
 	// List all pods in the namespace
-	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	pods, err := limited(ctx, r.clientPool, contextName, func() (*corev1.PodList, error) {
+		return client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to list pods: %w", err)
+		return "", fmt.Errorf("failed to list pods: %w", permissionError(err, "list", "pods", namespace))
 	}
 
 	// Find pods matching the prefix
 	var matchingPods []*corev1.Pod
 	for i := range pods.Items {
 		pod := &pods.Items[i]
-		if strings.HasPrefix(pod.Name, prefix) && pod.Status.Phase == corev1.PodRunning {
+		if strings.HasPrefix(pod.Name, prefix) && isRunning(pod) {
 			matchingPods = append(matchingPods, pod)
 		}
 	}
@@ -124,23 +158,19 @@ func (r *ResourceResolver) resolvePodPrefix(ctx context.Context, contextName, na
 		return "", fmt.Errorf("no running pods found matching prefix '%s' in namespace %s", prefix, namespace)
 	}
 
-	// Sort by creation timestamp (newest first)
-	sort.Slice(matchingPods, func(i, j int) bool {
-		return matchingPods[i].CreationTimestamp.After(matchingPods[j].CreationTimestamp.Time)
-	})
-
 	// Return the newest pod
-	resolvedName := matchingPods[0].Name
+	resolvedName := newestPod(matchingPods).Name
 	r.putInCache(cacheKey, resolvedName)
 
 	return fmt.Sprintf("pod/%s", resolvedName), nil
 }
 
 // resolvePodSelector resolves a pod name using label selectors.
-// It returns the first running pod matching the selector.
+// It returns the newest running pod matching the selector; pods created at
+// the same time keep the order returned by the API.
 func (r *ResourceResolver) resolvePodSelector(ctx context.Context, contextName, namespace, selector string) (string, error) {
 	// Check cache first
-	cacheKey := fmt.Sprintf("%s/%s/pod?selector=%s", contextName, namespace, selector)
+	cacheKey := resourceCacheKey(contextName, namespace, "pod", selector)
 	if cached := r.getFromCache(cacheKey); cached != "" {
 		return fmt.Sprintf("pod/%s", cached), nil
 	}
@@ -152,65 +182,269 @@ func (r *ResourceResolver) resolvePodSelector(ctx context.Context, contextName,
 	}
 
 	// List pods matching the selector
-	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: selector,
+	pods, err := limited(ctx, r.clientPool, contextName, func() (*corev1.PodList, error) {
+		return client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: selector,
+		})
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to list pods with selector '%s': %w", selector, err)
+		return "", fmt.Errorf("failed to list pods with selector '%s': %w", selector, permissionError(err, "list", "pods", namespace))
 	}
 
-	// Find first running pod
+	var runningPods []*corev1.Pod
 	for i := range pods.Items {
 		pod := &pods.Items[i]
-		if pod.Status.Phase == corev1.PodRunning {
-			resolvedName := pod.Name
-			r.putInCache(cacheKey, resolvedName)
-			return fmt.Sprintf("pod/%s", resolvedName), nil
+		if isRunning(pod) {
+			runningPods = append(runningPods, pod)
+		}
+	}
+
+	if len(runningPods) == 0 {
+		return "", fmt.Errorf("no running pods found matching selector '%s' in namespace %s", selector, namespace)
+	}
+
+	resolvedName := newestPod(runningPods).Name
+	r.putInCache(cacheKey, resolvedName)
+
+	return fmt.Sprintf("pod/%s", resolvedName), nil
+}
+
+// resolveWorkload resolves a deployment or statefulset to the newest running
+// pod matching its spec.selector.
+func (r *ResourceResolver) resolveWorkload(ctx context.Context, contextName, namespace, kind, name string) (string, error) {
+	// Check cache first
+	cacheKey := resourceCacheKey(contextName, namespace, kind+"/"+name, "")
+	if cached := r.getFromCache(cacheKey); cached != "" {
+		return fmt.Sprintf("pod/%s", cached), nil
+	}
+
+	// Get Kubernetes client
+	client, err := r.clientPool.GetClient(contextName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get client: %w", err)
+	}
+
+	var labelSelector *metav1.LabelSelector
+	switch kind {
+	case "deployment":
+		deployment, err := limited(ctx, r.clientPool, contextName, func() (*appsv1.Deployment, error) {
+			return client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get deployment %s: %w", name, permissionError(err, "get", "deployments", namespace))
+		}
+		labelSelector = deployment.Spec.Selector
+	case "statefulset":
+		statefulSet, err := limited(ctx, r.clientPool, contextName, func() (*appsv1.StatefulSet, error) {
+			return client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get statefulset %s: %w", name, permissionError(err, "get", "statefulsets", namespace))
+		}
+		labelSelector = statefulSet.Spec.Selector
+	default:
+		return "", fmt.Errorf("unsupported workload type: %s", kind)
+	}
+
+	if labelSelector == nil {
+		return "", fmt.Errorf("%s %s has no selector", kind, name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return "", fmt.Errorf("invalid selector on %s %s: %w", kind, name, err)
+	}
+	if selector.Empty() {
+		return "", fmt.Errorf("%s %s has no selector", kind, name)
+	}
+
+	resolved, err := r.resolvePodSelector(ctx, contextName, namespace, selector.String())
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", kind, name, err)
+	}
+
+	r.putInCache(cacheKey, strings.TrimPrefix(resolved, "pod/"))
+	return resolved, nil
+}
+
+// isRunning reports whether pod can take a new forward: it is running and
+// not being deleted. A terminating pod keeps the Running phase until its
+// containers stop, so forwarding to it would break again within seconds.
+func isRunning(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil
+}
+
+// newestPod returns the most recently created pod. Pods with equal creation
+// timestamps keep their original order.
+func newestPod(pods []*corev1.Pod) *corev1.Pod {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].CreationTimestamp.After(pods[j].CreationTimestamp.Time)
+	})
+	return pods[0]
+}
+
+// ResolveContainerPort returns the container port to forward to on pod.
+// When portName is set it is looked up among the declared ports of the named
+// container (or of all containers if container is empty) and translated to its
+// number. Otherwise port is returned, after checking that container exists and
+// declares it when the container has any declared ports.
+func ResolveContainerPort(pod *corev1.Pod, container, portName string, port int) (int, error) {
+	containers := pod.Spec.Containers
+	if container != "" {
+		containers = nil
+		for i := range pod.Spec.Containers {
+			if pod.Spec.Containers[i].Name == container {
+				containers = pod.Spec.Containers[i : i+1]
+				break
+			}
+		}
+		if containers == nil {
+			return 0, fmt.Errorf("container %s not found in pod %s", container, pod.Name)
+		}
+	}
+
+	if portName == "" {
+		if container == "" || len(containers[0].Ports) == 0 {
+			return port, nil
+		}
+		for _, p := range containers[0].Ports {
+			if int(p.ContainerPort) == port {
+				return port, nil
+			}
+		}
+		return 0, fmt.Errorf("port %d is not declared on container %s in pod %s", port, container, pod.Name)
+	}
+
+	resolved := 0
+	var owner string
+	for _, c := range containers {
+		for _, p := range c.Ports {
+			if p.Name != portName {
+				continue
+			}
+			if resolved != 0 && int(p.ContainerPort) != resolved {
+				return 0, fmt.Errorf("port %s is ambiguous in pod %s (containers %s and %s), set container", portName, pod.Name, owner, c.Name)
+			}
+			resolved, owner = int(p.ContainerPort), c.Name
+		}
+	}
+	if resolved == 0 {
+		if container != "" {
+			return 0, fmt.Errorf("port %s not found on container %s in pod %s", portName, container, pod.Name)
 		}
+		return 0, fmt.Errorf("port %s not found in pod %s", portName, pod.Name)
 	}
+	if port != 0 && port != resolved {
+		return 0, fmt.Errorf("port %s resolves to %d in pod %s, but port %d is configured", portName, resolved, pod.Name, port)
+	}
+
+	return resolved, nil
+}
 
-	return "", fmt.Errorf("no running pods found matching selector '%s' in namespace %s", selector, namespace)
+// ResolveServicePort returns the pod port that the service port named
+// portName targets on pod. found is false when the service has no port with
+// that name. A named targetPort is looked up among the pod's container ports,
+// restricted to container when it is set.
+func ResolveServicePort(service *corev1.Service, pod *corev1.Pod, container, portName string) (port int, found bool, err error) {
+	for _, sp := range service.Spec.Ports {
+		if sp.Name != portName {
+			continue
+		}
+
+		switch {
+		case sp.TargetPort.Type == intstr.String && sp.TargetPort.StrVal != "":
+			port, err = ResolveContainerPort(pod, container, sp.TargetPort.StrVal, 0)
+			if err != nil {
+				return 0, true, fmt.Errorf("service %s port %s: %w", service.Name, portName, err)
+			}
+			return port, true, nil
+		case sp.TargetPort.Type == intstr.Int && sp.TargetPort.IntVal != 0:
+			return int(sp.TargetPort.IntVal), true, nil
+		default:
+			// Kubernetes defaults an unset targetPort to the service port
+			return int(sp.Port), true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// resourceCacheKey returns the cache key Resolve uses for a resource, or ""
+// for services, which are not cached.
+func resourceCacheKey(contextName, namespace, resource, selector string) string {
+	resourceType, name, hasName := strings.Cut(resource, "/")
+	switch {
+	case resourceType == "service":
+		return ""
+	case resourceType == "pod" && !hasName:
+		return fmt.Sprintf("%s/%s/pod?selector=%s", contextName, namespace, selector)
+	default:
+		return fmt.Sprintf("%s/%s/%s/%s", contextName, namespace, resourceType, name)
+	}
 }
 
 // getFromCache retrieves a cached resolution result if it exists and hasn't expired.
-// Expired entries are removed to prevent memory growth over time.
+// Expired entries are removed to prevent memory growth over time. A hit
+// marks the entry as recently used.
 func (r *ResourceResolver) getFromCache(key string) string {
-	r.cacheMu.RLock()
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
 	entry, exists := r.cache[key]
 	if !exists {
-		r.cacheMu.RUnlock()
 		return ""
 	}
 
-	// Check if expired
-	if time.Now().After(entry.expiresAt) {
-		r.cacheMu.RUnlock()
-		// Upgrade to write lock and delete expired entry
-		r.cacheMu.Lock()
-		// Double-check entry still exists and is still expired (may have been updated)
-		if expiredEntry, ok := r.cache[key]; ok && time.Now().After(expiredEntry.expiresAt) {
-			delete(r.cache, key)
-		}
-		r.cacheMu.Unlock()
+	now := time.Now()
+	if now.After(entry.expiresAt) {
+		delete(r.cache, key)
 		return ""
 	}
 
-	name := entry.resource.Name
-	r.cacheMu.RUnlock()
-	return name
+	entry.lastUsed = now
+	r.cache[key] = entry
+	return entry.resource.Name
 }
 
-// putInCache stores a resolution result in the cache with TTL.
+// putInCache stores a resolution result in the cache with TTL, evicting
+// an entry first when the cache is full.
 func (r *ResourceResolver) putInCache(key, value string) {
 	r.cacheMu.Lock()
 	defer r.cacheMu.Unlock()
 
+	if _, exists := r.cache[key]; !exists && r.cacheMaxEntries > 0 && len(r.cache) >= r.cacheMaxEntries {
+		r.evictLocked()
+	}
+
+	now := time.Now()
 	r.cache[key] = cacheEntry{
 		resource: ResolvedResource{
 			Name:      value,
-			Timestamp: time.Now(),
+			Timestamp: now,
 		},
-		expiresAt: time.Now().Add(r.cacheTTL),
+		expiresAt: now.Add(r.cacheTTL),
+		lastUsed:  now,
+	}
+}
+
+// evictLocked makes room for one entry: it drops every expired entry or,
+// if none has expired, the least recently used one.
+// Caller must hold cacheMu.
+func (r *ResourceResolver) evictLocked() {
+	now := time.Now()
+	var oldestKey string
+	var oldest time.Time
+	expired := false
+	for key, entry := range r.cache {
+		if now.After(entry.expiresAt) {
+			delete(r.cache, key)
+			expired = true
+			continue
+		}
+		if oldestKey == "" || entry.lastUsed.Before(oldest) {
+			oldestKey, oldest = key, entry.lastUsed
+		}
+	}
+	if !expired && oldestKey != "" {
+		delete(r.cache, oldestKey)
 	}
 }
 
@@ -222,6 +456,32 @@ func (r *ResourceResolver) ClearCache() {
 	r.cache = make(map[string]cacheEntry)
 }
 
+// Invalidate drops the cached pod for a resource so the next Resolve lists
+// pods again. Other entries in the namespace that resolved to the same pod,
+// such as the selector behind a deployment, are dropped too.
+func (r *ResourceResolver) Invalidate(contextName, namespace, resource, selector string) {
+	key := resourceCacheKey(contextName, namespace, resource, selector)
+	if key == "" {
+		return
+	}
+
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	entry, exists := r.cache[key]
+	if !exists {
+		return
+	}
+	delete(r.cache, key)
+
+	prefix := fmt.Sprintf("%s/%s/", contextName, namespace)
+	for k, e := range r.cache {
+		if strings.HasPrefix(k, prefix) && e.resource.Name == entry.resource.Name {
+			delete(r.cache, k)
+		}
+	}
+}
+
 // InvalidateCache invalidates cache entries for a specific resource.
 func (r *ResourceResolver) InvalidateCache(contextName, namespace, resource string) {
 	r.cacheMu.Lock()
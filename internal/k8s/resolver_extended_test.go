@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -428,3 +429,333 @@ func TestDiscovery_ListServices_NoBackingPods(t *testing.T) {
 	// When no backing pods, falls back to service port
 	assert.Equal(t, int32(80), services[0].Ports[0].TargetPort)
 }
+
+// =============================================================================
+// Workload Resolution Tests
+// =============================================================================
+
+func TestResourceResolver_ResolveDeployment_NewestRunningPod(t *testing.T) {
+	baseTime := time.Now()
+	labels := map[string]string{"app": "api"}
+
+	pool := setupTestPool(t, "test-context",
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+			},
+		},
+		createTestPod("api-old", "default", labels, corev1.PodRunning, baseTime.Add(-time.Hour)),
+		createTestPod("api-new", "default", labels, corev1.PodRunning, baseTime),
+		createTestPod("api-pending", "default", labels, corev1.PodPending, baseTime.Add(time.Minute)),
+		createTestPod("other", "default", map[string]string{"app": "other"}, corev1.PodRunning, baseTime.Add(time.Hour)),
+	)
+
+	r := NewResourceResolver(pool)
+
+	result, err := r.Resolve(t.Context(), "test-context", "default", "deployment/api", "")
+	require.NoError(t, err)
+	assert.Equal(t, "pod/api-new", result)
+
+	// Cached under the workload key
+	assert.Equal(t, "api-new", r.getFromCache("test-context/default/deployment/api"))
+}
+
+func TestResourceResolver_ResolveStatefulSet(t *testing.T) {
+	labels := map[string]string{"app": "db"}
+
+	pool := setupTestPool(t, "test-context",
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "data"},
+			Spec: appsv1.StatefulSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+			},
+		},
+		createTestPod("postgres-0", "data", labels, corev1.PodRunning, time.Now()),
+	)
+
+	r := NewResourceResolver(pool)
+
+	result, err := r.Resolve(t.Context(), "test-context", "data", "statefulset/postgres", "")
+	require.NoError(t, err)
+	assert.Equal(t, "pod/postgres-0", result)
+}
+
+func TestResourceResolver_ResolveWorkload_Errors(t *testing.T) {
+	pool := setupTestPool(t, "test-context",
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "noselector", Namespace: "default"},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "idle"}},
+			},
+		},
+	)
+
+	r := NewResourceResolver(pool)
+
+	tests := []struct {
+		name          string
+		resource      string
+		errorContains string
+	}{
+		{"missing deployment", "deployment/missing", "failed to get deployment missing"},
+		{"missing statefulset", "statefulset/missing", "failed to get statefulset missing"},
+		{"no selector", "deployment/noselector", "has no selector"},
+		{"no running pods", "deployment/idle", "no running pods found matching selector 'app=idle'"},
+		{"missing name", "deployment/", "invalid deployment resource format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Resolve(t.Context(), "test-context", "default", tt.resource, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+// =============================================================================
+// Pod Reselection Tests
+// =============================================================================
+
+func TestResourceResolver_Invalidate_ReselectsAfterPodDeletion(t *testing.T) {
+	baseTime := time.Now()
+	labels := map[string]string{"app": "web"}
+
+	pool := setupTestPool(t, "test-context",
+		createTestPod("web-1", "default", labels, corev1.PodRunning, baseTime),
+	)
+	client, err := pool.GetClient("test-context")
+	require.NoError(t, err)
+
+	r := NewResourceResolver(pool)
+
+	result, err := r.Resolve(t.Context(), "test-context", "default", "pod", "app=web")
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-1", result)
+
+	// Roll the pod: web-1 is deleted and web-2 replaces it
+	require.NoError(t, client.CoreV1().Pods("default").Delete(t.Context(), "web-1", metav1.DeleteOptions{}))
+	_, err = client.CoreV1().Pods("default").Create(t.Context(),
+		createTestPod("web-2", "default", labels, corev1.PodRunning, baseTime.Add(time.Minute)), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// The stale pod is served from cache until invalidated
+	result, err = r.Resolve(t.Context(), "test-context", "default", "pod", "app=web")
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-1", result)
+
+	r.Invalidate("test-context", "default", "pod", "app=web")
+
+	result, err = r.Resolve(t.Context(), "test-context", "default", "pod", "app=web")
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-2", result)
+}
+
+func TestResourceResolver_Invalidate_ClearsWorkloadSelector(t *testing.T) {
+	r := NewResourceResolver(setupTestPool(t, "test-context"))
+	r.putInCache("test-context/default/deployment/api", "api-1")
+	r.putInCache("test-context/default/pod?selector=app=api", "api-1")
+	r.putInCache("test-context/default/pod/worker", "worker-1")
+	r.putInCache("test-context/other/pod/api", "api-1")
+
+	r.Invalidate("test-context", "default", "deployment/api", "")
+
+	assert.Empty(t, r.getFromCache("test-context/default/deployment/api"))
+	assert.Empty(t, r.getFromCache("test-context/default/pod?selector=app=api"), "selector behind the deployment resolved to the same pod")
+	assert.Equal(t, "worker-1", r.getFromCache("test-context/default/pod/worker"))
+	assert.Equal(t, "api-1", r.getFromCache("test-context/other/pod/api"), "other namespace should not be affected")
+
+	// Services are never cached, so invalidating one is a no-op
+	assert.NotPanics(t, func() { r.Invalidate("test-context", "default", "service/api", "") })
+}
+
+func TestResourceResolver_SkipsTerminatingPods(t *testing.T) {
+	baseTime := time.Now()
+	labels := map[string]string{"app": "web"}
+
+	terminating := createTestPod("web-old", "default", labels, corev1.PodRunning, baseTime.Add(time.Minute))
+	terminating.DeletionTimestamp = &metav1.Time{Time: baseTime}
+
+	pool := setupTestPool(t, "test-context",
+		terminating,
+		createTestPod("web-new", "default", labels, corev1.PodRunning, baseTime),
+	)
+
+	r := NewResourceResolver(pool)
+
+	result, err := r.Resolve(t.Context(), "test-context", "default", "pod/web", "")
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-new", result)
+
+	result, err = r.Resolve(t.Context(), "test-context", "default", "pod", "app=web")
+	require.NoError(t, err)
+	assert.Equal(t, "pod/web-new", result)
+}
+
+func TestResourceCacheKey(t *testing.T) {
+	tests := []struct {
+		resource string
+		selector string
+		expected string
+	}{
+		{"pod/my-app", "", "ctx/ns/pod/my-app"},
+		{"pod", "app=web", "ctx/ns/pod?selector=app=web"},
+		{"deployment/api", "", "ctx/ns/deployment/api"},
+		{"statefulset/db", "", "ctx/ns/statefulset/db"},
+		{"service/api", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resourceCacheKey("ctx", "ns", tt.resource, tt.selector))
+		})
+	}
+}
+
+func TestDiscovery_ListWorkloads(t *testing.T) {
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+				{Name: "sidecar", Ports: []corev1.ContainerPort{{ContainerPort: 9090}}},
+			},
+		},
+	}
+
+	pool := setupTestPool(t, "test-context",
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Template: template},
+			Status:     appsv1.DeploymentStatus{Replicas: 2, ReadyReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		},
+	)
+
+	d := NewDiscovery(pool)
+
+	deployments, err := d.ListWorkloads(t.Context(), "test-context", "default", "deployment")
+	require.NoError(t, err)
+	require.Len(t, deployments, 2)
+	assert.Equal(t, "api", deployments[0].Name, "workloads are sorted by name")
+	assert.Equal(t, "web", deployments[1].Name)
+	assert.Equal(t, "deployment", deployments[1].Kind)
+	assert.Equal(t, int32(2), deployments[1].Replicas)
+	assert.Equal(t, int32(1), deployments[1].ReadyReplicas)
+	assert.Equal(t, []PortInfo{{Name: "http", Port: 8080, Container: "app"}, {Name: "port-9090", Port: 9090, Container: "sidecar"}}, deployments[1].Ports)
+
+	statefulSets, err := d.ListWorkloads(t.Context(), "test-context", "default", "statefulset")
+	require.NoError(t, err)
+	require.Len(t, statefulSets, 1)
+	assert.Equal(t, "db", statefulSets[0].Name)
+
+	_, err = d.ListWorkloads(t.Context(), "test-context", "default", "daemonset")
+	assert.Error(t, err)
+}
+
+func TestResolveContainerPort(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+				{Name: "sidecar", Ports: []corev1.ContainerPort{{Name: "metrics", ContainerPort: 9090}, {Name: "http", ContainerPort: 8081}}},
+				{Name: "worker"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		container     string
+		portName      string
+		errorContains string
+		port          int
+		expected      int
+	}{
+		{name: "numeric port without container", port: 1234, expected: 1234},
+		{name: "port name on container", container: "app", portName: "http", expected: 8080},
+		{name: "port name on other container", container: "sidecar", portName: "http", expected: 8081},
+		{name: "unique port name without container", portName: "metrics", expected: 9090},
+		{name: "port name matching configured number", container: "app", portName: "http", port: 8080, expected: 8080},
+		{name: "declared numeric port on container", container: "sidecar", port: 9090, expected: 9090},
+		{name: "container without declared ports", container: "worker", port: 5000, expected: 5000},
+		{name: "unknown container", container: "db", portName: "http", errorContains: "container db not found in pod web-1"},
+		{name: "port name missing on container", container: "app", portName: "metrics", errorContains: "port metrics not found on container app"},
+		{name: "unknown port name", portName: "grpc", errorContains: "port grpc not found in pod web-1"},
+		{name: "ambiguous port name", portName: "http", errorContains: "ambiguous"},
+		{name: "port name conflicting with number", container: "app", portName: "http", port: 80, errorContains: "resolves to 8080"},
+		{name: "undeclared numeric port", container: "app", port: 9090, errorContains: "port 9090 is not declared on container app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, err := ResolveContainerPort(pod, tt.container, tt.portName, tt.port)
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, port)
+		})
+	}
+}
+
+func TestResolveServicePort(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-1"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Ports: []corev1.ContainerPort{{Name: "grpc-port", ContainerPort: 9000}}},
+			},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "api"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt32(8080)},
+				{Name: "grpc", Port: 9090, TargetPort: intstr.FromString("grpc-port")},
+				{Name: "http2", Port: 8443},
+				{Name: "broken", Port: 7000, TargetPort: intstr.FromString("missing")},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		portName      string
+		errorContains string
+		expected      int
+		found         bool
+	}{
+		{name: "numeric target port", portName: "http", expected: 8080, found: true},
+		{name: "named target port", portName: "grpc", expected: 9000, found: true},
+		{name: "unset target port defaults to service port", portName: "http2", expected: 8443, found: true},
+		{name: "not a service port", portName: "grpc-port", found: false},
+		{name: "named target port missing on pod", portName: "broken", errorContains: "service api port broken: port missing not found in pod api-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			port, found, err := ResolveServicePort(service, pod, "", tt.portName)
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected, port)
+		})
+	}
+}
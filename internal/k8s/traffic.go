@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// TrafficCounter receives the number of bytes moved through a forward's
+// tunnel. Implementations must be safe for concurrent use, as every client
+// connection on the local port reports from its own goroutines.
+type TrafficCounter interface {
+	AddSent(n int)     // Bytes written from the local client to the pod
+	AddReceived(n int) // Bytes read from the pod back to the local client
+}
+
+// ConnectionCounter is optionally implemented by a TrafficCounter that also
+// tracks how many client connections are open on the forward's local port.
+type ConnectionCounter interface {
+	ConnOpened() // A client connected and its data stream was created
+	ConnClosed() // The client's data stream was removed
+}
+
+// TunnelCounter is optionally implemented by a TrafficCounter that counts
+// the port-forward connections (tunnels) opened to the API server. Every
+// client connection is a stream pair on the tunnel open at the time, so
+// client connections per tunnel show how much one tunnel is reused.
+type TunnelCounter interface {
+	TunnelOpened() // A port-forward connection was upgraded
+}
+
+// SetupRecorder is optionally implemented by a TrafficCounter that tracks
+// how long a new client connection's data stream took to open. Opening it
+// is a round trip through the API server to the kubelet, so this is the
+// setup latency of the tunnel for any protocol.
+type SetupRecorder interface {
+	StreamSetup(d time.Duration)
+}
+
+// ConnectionTracer is optionally implemented by a TrafficCounter that
+// records the bytes of individual client connections. TraceConnection is
+// called as each connection's data stream opens; a nil StreamTrace leaves
+// that connection untraced.
+type ConnectionTracer interface {
+	TraceConnection() StreamTrace
+}
+
+// StreamTrace sees the bytes of one client connection as they pass. The
+// slices are only valid for the duration of the call.
+type StreamTrace interface {
+	Sent(p []byte)     // Written from the local client to the pod
+	Received(p []byte) // Read from the pod back to the local client
+	Close()            // The connection's data stream was removed
+}
+
+// countingDialer wraps a port-forward dialer so the data streams of every
+// connection it opens report their traffic to counter.
+type countingDialer struct {
+	dialer  httpstream.Dialer
+	counter TrafficCounter
+}
+
+func (d *countingDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	conn, protocol, err := d.dialer.Dial(protocols...)
+	if err != nil {
+		return nil, protocol, err
+	}
+	if tunnels, ok := d.counter.(TunnelCounter); ok {
+		tunnels.TunnelOpened()
+	}
+	return &countingConnection{
+		Connection: conn,
+		counter:    d.counter,
+		wrapped:    make(map[httpstream.Stream]httpstream.Stream),
+	}, protocol, nil
+}
+
+// countingConnection wraps the data streams it creates in countingStream.
+// Error streams carry no user traffic and are passed through untouched.
+type countingConnection struct {
+	httpstream.Connection
+	counter TrafficCounter
+	wrapped map[httpstream.Stream]httpstream.Stream // wrapper -> original
+	mu      sync.Mutex
+}
+
+func (c *countingConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	if headers.Get(corev1.StreamType) != corev1.StreamTypeData {
+		return c.Connection.CreateStream(headers)
+	}
+
+	started := time.Now()
+	stream, err := c.Connection.CreateStream(headers)
+	if err != nil {
+		return stream, err
+	}
+	if setup, ok := c.counter.(SetupRecorder); ok {
+		setup.StreamSetup(time.Since(started))
+	}
+
+	wrapper := &countingStream{Stream: stream, counter: c.counter}
+	if tracer, ok := c.counter.(ConnectionTracer); ok {
+		wrapper.trace = tracer.TraceConnection()
+	}
+	c.mu.Lock()
+	c.wrapped[wrapper] = stream
+	c.mu.Unlock()
+	if conns, ok := c.counter.(ConnectionCounter); ok {
+		conns.ConnOpened()
+	}
+	return wrapper, nil
+}
+
+// RemoveStreams hands the original streams back to the underlying
+// connection, which only recognises the stream types it created. Each
+// removed data stream ends one client connection.
+func (c *countingConnection) RemoveStreams(streams ...httpstream.Stream) {
+	originals := make([]httpstream.Stream, 0, len(streams))
+	var traces []StreamTrace
+	closed := 0
+	c.mu.Lock()
+	for _, stream := range streams {
+		if original, ok := c.wrapped[stream]; ok {
+			delete(c.wrapped, stream)
+			if trace := stream.(*countingStream).trace; trace != nil {
+				traces = append(traces, trace)
+			}
+			stream = original
+			closed++
+		}
+		originals = append(originals, stream)
+	}
+	c.mu.Unlock()
+	c.Connection.RemoveStreams(originals...)
+
+	for _, trace := range traces {
+		trace.Close()
+	}
+
+	if conns, ok := c.counter.(ConnectionCounter); ok {
+		for range closed {
+			conns.ConnClosed()
+		}
+	}
+}
+
+// countingStream reports bytes read from and written to a data stream,
+// and passes them to its trace, if any.
+type countingStream struct {
+	httpstream.Stream
+	counter TrafficCounter
+	trace   StreamTrace // optional
+}
+
+func (s *countingStream) Read(p []byte) (int, error) {
+	n, err := s.Stream.Read(p)
+	s.counter.AddReceived(n)
+	if s.trace != nil && n > 0 {
+		s.trace.Received(p[:n])
+	}
+	return n, err
+}
+
+func (s *countingStream) Write(p []byte) (int, error) {
+	n, err := s.Stream.Write(p)
+	s.counter.AddSent(n)
+	if s.trace != nil && n > 0 {
+		s.trace.Sent(p[:n])
+	}
+	return n, err
+}
@@ -0,0 +1,188 @@
+package k8s
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+type fakeStream struct {
+	headers http.Header
+	in      *bytes.Reader
+	out     bytes.Buffer
+}
+
+func (s *fakeStream) Read(p []byte) (int, error)  { return s.in.Read(p) }
+func (s *fakeStream) Write(p []byte) (int, error) { return s.out.Write(p) }
+func (s *fakeStream) Close() error                { return nil }
+func (s *fakeStream) Reset() error                { return nil }
+func (s *fakeStream) Headers() http.Header        { return s.headers }
+func (s *fakeStream) Identifier() uint32          { return 0 }
+
+type fakeConnection struct {
+	removed []httpstream.Stream
+	payload []byte
+}
+
+func (c *fakeConnection) CreateStream(headers http.Header) (httpstream.Stream, error) {
+	return &fakeStream{headers: headers, in: bytes.NewReader(c.payload)}, nil
+}
+func (c *fakeConnection) Close() error                   { return nil }
+func (c *fakeConnection) CloseChan() <-chan bool         { return nil }
+func (c *fakeConnection) SetIdleTimeout(_ time.Duration) {}
+func (c *fakeConnection) RemoveStreams(streams ...httpstream.Stream) {
+	c.removed = append(c.removed, streams...)
+}
+
+type fakeDialer struct {
+	conn *fakeConnection
+}
+
+func (d *fakeDialer) Dial(_ ...string) (httpstream.Connection, string, error) {
+	return d.conn, "portforward.k8s.io", nil
+}
+
+type fakeTrafficCounter struct {
+	sent     atomic.Int64
+	received atomic.Int64
+}
+
+func (c *fakeTrafficCounter) AddSent(n int)     { c.sent.Add(int64(n)) }
+func (c *fakeTrafficCounter) AddReceived(n int) { c.received.Add(int64(n)) }
+
+type fakeConnectionCounter struct {
+	fakeTrafficCounter
+	open    atomic.Int64
+	setups  atomic.Int64
+	tunnels atomic.Int64
+}
+
+func (c *fakeConnectionCounter) ConnOpened()                 { c.open.Add(1) }
+func (c *fakeConnectionCounter) ConnClosed()                 { c.open.Add(-1) }
+func (c *fakeConnectionCounter) StreamSetup(_ time.Duration) { c.setups.Add(1) }
+func (c *fakeConnectionCounter) TunnelOpened()               { c.tunnels.Add(1) }
+
+func TestCountingDialer_CountsDataStreams(t *testing.T) {
+	underlying := &fakeConnection{payload: []byte("hello from the pod")}
+	counter := &fakeTrafficCounter{}
+	dialer := &countingDialer{dialer: &fakeDialer{conn: underlying}, counter: counter}
+
+	conn, protocol, err := dialer.Dial("portforward.k8s.io")
+	require.NoError(t, err)
+	assert.Equal(t, "portforward.k8s.io", protocol)
+
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	errorStream, err := conn.CreateStream(headers)
+	require.NoError(t, err)
+	_, isCounting := errorStream.(*countingStream)
+	assert.False(t, isCounting, "error streams are not counted")
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := conn.CreateStream(headers)
+	require.NoError(t, err)
+
+	_, err = dataStream.Write([]byte("GET / HTTP/1.1\r\n\r\n"))
+	require.NoError(t, err)
+	received, err := io.ReadAll(dataStream)
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello from the pod", string(received))
+	assert.Equal(t, int64(18), counter.sent.Load())
+	assert.Equal(t, int64(18), counter.received.Load())
+
+	// The underlying connection must get back the streams it created
+	conn.RemoveStreams(errorStream, dataStream)
+	require.Len(t, underlying.removed, 2)
+	assert.Same(t, errorStream, underlying.removed[0])
+	_, isCounting = underlying.removed[1].(*countingStream)
+	assert.False(t, isCounting, "data stream should be unwrapped before removal")
+}
+
+func TestCountingDialer_CountsOpenConnections(t *testing.T) {
+	counter := &fakeConnectionCounter{}
+	dialer := &countingDialer{dialer: &fakeDialer{conn: &fakeConnection{}}, counter: newIdleTracker(time.Minute, counter)}
+
+	conn, _, err := dialer.Dial("portforward.k8s.io")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counter.tunnels.Load(), "each dial opens one tunnel")
+
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	errorStream, err := conn.CreateStream(headers)
+	require.NoError(t, err)
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	first, err := conn.CreateStream(headers)
+	require.NoError(t, err)
+	second, err := conn.CreateStream(headers)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), counter.open.Load(), "only data streams are client connections")
+	assert.Equal(t, int64(2), counter.setups.Load(), "setup time is recorded per client connection")
+
+	conn.RemoveStreams(first, errorStream)
+	assert.Equal(t, int64(1), counter.open.Load())
+
+	// Removing a stream twice must not close the connection twice
+	conn.RemoveStreams(first, second)
+	assert.Equal(t, int64(0), counter.open.Load())
+}
+
+type fakeTrace struct {
+	sent, received bytes.Buffer
+	closed         int
+}
+
+func (t *fakeTrace) Sent(p []byte)     { t.sent.Write(p) }
+func (t *fakeTrace) Received(p []byte) { t.received.Write(p) }
+func (t *fakeTrace) Close()            { t.closed++ }
+
+type fakeTracer struct {
+	fakeTrafficCounter
+	traces []*fakeTrace
+}
+
+func (c *fakeTracer) TraceConnection() StreamTrace {
+	trace := &fakeTrace{}
+	c.traces = append(c.traces, trace)
+	return trace
+}
+
+func TestCountingDialer_TracesConnections(t *testing.T) {
+	counter := &fakeTracer{}
+	underlying := &fakeConnection{payload: []byte("+OK ready\r\n")}
+	dialer := &countingDialer{dialer: &fakeDialer{conn: underlying}, counter: newIdleTracker(time.Minute, counter)}
+
+	conn, _, err := dialer.Dial("portforward.k8s.io")
+	require.NoError(t, err)
+
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	_, err = conn.CreateStream(headers)
+	require.NoError(t, err)
+	require.Empty(t, counter.traces, "error streams are not traced")
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := conn.CreateStream(headers)
+	require.NoError(t, err)
+	require.Len(t, counter.traces, 1)
+
+	_, err = dataStream.Write([]byte("PING\r\n"))
+	require.NoError(t, err)
+	_, err = io.ReadAll(dataStream)
+	require.NoError(t, err)
+
+	trace := counter.traces[0]
+	assert.Equal(t, "PING\r\n", trace.sent.String())
+	assert.Equal(t, "+OK ready\r\n", trace.received.String())
+
+	conn.RemoveStreams(dataStream)
+	assert.Equal(t, 1, trace.closed)
+}
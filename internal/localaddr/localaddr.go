@@ -0,0 +1,120 @@
+// Package localaddr resolves the local addresses a forward listens on and
+// checks them for port conflicts. A forward bound to localhost listens on
+// both 127.0.0.1 and ::1, so a port is only free when it is free on both
+// families; otherwise clients resolving localhost to the other family reach
+// whatever holds it there.
+package localaddr
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Localhost is the default bind address: both loopback addresses
+const Localhost = "localhost"
+
+// loopbacks are the addresses Localhost listens on, IPv4 first
+var loopbacks = []string{"127.0.0.1", "::1"}
+
+// Normalize returns bindAddress as listeners take it: Localhost for "", and
+// IPv6 literals without their brackets.
+func Normalize(bindAddress string) string {
+	if bindAddress == "" {
+		return Localhost
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(bindAddress, "["), "]")
+}
+
+// Addresses returns the IP addresses a listener on bindAddress binds
+func Addresses(bindAddress string) []string {
+	addr := Normalize(bindAddress)
+	if addr == Localhost {
+		return append([]string(nil), loopbacks...)
+	}
+	return []string{addr}
+}
+
+// DialHost returns the address a local client dials to reach a listener on
+// bindAddress. Wildcard addresses are reached through their loopback.
+func DialHost(bindAddress string) string {
+	addr := Normalize(bindAddress)
+	switch addr {
+	case Localhost, "0.0.0.0":
+		return loopbacks[0]
+	case "::":
+		return loopbacks[1]
+	}
+	return addr
+}
+
+// Listen listens on port at every address of bindAddress. For Localhost a
+// loopback family the host does not support is skipped, as kubectl
+// port-forward does; any other failure closes the listeners opened so far
+// and is returned. Port 0 takes an ephemeral port on the first address and
+// reuses it on the rest.
+func Listen(bindAddress string, port int) ([]net.Listener, error) {
+	localhost := Normalize(bindAddress) == Localhost
+
+	var listeners []net.Listener
+	for _, addr := range Addresses(bindAddress) {
+		ln, err := net.Listen("tcp", net.JoinHostPort(addr, strconv.Itoa(port)))
+		if err == nil {
+			listeners = append(listeners, ln)
+			if port == 0 {
+				port = ln.Addr().(*net.TCPAddr).Port
+			}
+			continue
+		}
+		if localhost && !familySupported(addr) {
+			continue
+		}
+		closeAll(listeners)
+		return nil, err
+	}
+
+	if len(listeners) == 0 {
+		return nil, fmt.Errorf("no loopback address available for port %d", port)
+	}
+	return listeners, nil
+}
+
+// Probe checks port can be bound at every address of bindAddress, returning
+// the bind error if not. For Localhost the wildcard address is probed too,
+// since some systems let a loopback bind succeed while another process
+// holds the port on all interfaces.
+func Probe(bindAddress string, port int) error {
+	if Normalize(bindAddress) == Localhost {
+		ln, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+		if err != nil {
+			return err
+		}
+		_ = ln.Close() // Best-effort cleanup; only the bind result matters
+	}
+
+	listeners, err := Listen(bindAddress, port)
+	if err != nil {
+		return err
+	}
+	closeAll(listeners)
+	return nil
+}
+
+// familySupported reports whether the host can listen on addr at all, by
+// binding an ephemeral port.
+func familySupported(addr string) bool {
+	ln, err := net.Listen("tcp", net.JoinHostPort(addr, "0"))
+	if err != nil {
+		return false
+	}
+	_ = ln.Close()
+	return true
+}
+
+// closeAll closes listeners that were never served
+func closeAll(listeners []net.Listener) {
+	for _, ln := range listeners {
+		_ = ln.Close() // Best-effort cleanup; nothing was accepted yet
+	}
+}
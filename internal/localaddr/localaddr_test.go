@@ -0,0 +1,94 @@
+package localaddr
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, Localhost, Normalize(""))
+	assert.Equal(t, Localhost, Normalize("localhost"))
+	assert.Equal(t, "::1", Normalize("[::1]"))
+	assert.Equal(t, "::1", Normalize("::1"))
+	assert.Equal(t, "0.0.0.0", Normalize("0.0.0.0"))
+}
+
+func TestAddresses(t *testing.T) {
+	assert.Equal(t, []string{"127.0.0.1", "::1"}, Addresses(""))
+	assert.Equal(t, []string{"fd00::5"}, Addresses("[fd00::5]"))
+}
+
+func TestDialHost(t *testing.T) {
+	tests := map[string]string{
+		"":          "127.0.0.1",
+		"localhost": "127.0.0.1",
+		"0.0.0.0":   "127.0.0.1",
+		"::":        "::1",
+		"[::1]":     "::1",
+		"10.0.0.5":  "10.0.0.5",
+	}
+	for bind, want := range tests {
+		assert.Equal(t, want, DialHost(bind), bind)
+	}
+}
+
+// freePort returns a port that is currently free on 127.0.0.1
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	require.NoError(t, ln.Close())
+	return port
+}
+
+func TestListen_Localhost(t *testing.T) {
+	port := freePort(t)
+
+	listeners, err := Listen("", port)
+	require.NoError(t, err)
+	defer closeAll(listeners)
+
+	want := 1
+	if familySupported("::1") {
+		want = 2
+	}
+	assert.Len(t, listeners, want, "one listener per supported loopback family")
+}
+
+func TestListen_EphemeralPortShared(t *testing.T) {
+	listeners, err := Listen("", 0)
+	require.NoError(t, err)
+	defer closeAll(listeners)
+
+	port := listeners[0].Addr().(*net.TCPAddr).Port
+	for _, ln := range listeners {
+		assert.Equal(t, port, ln.Addr().(*net.TCPAddr).Port, "every family listens on the same port")
+	}
+}
+
+func TestProbe_TakenOnIPv6Only(t *testing.T) {
+	if !familySupported("::1") {
+		t.Skip("IPv6 loopback not available")
+	}
+
+	ln, err := net.Listen("tcp", "[::1]:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	assert.Error(t, Probe("", port), "a port held on ::1 is not free for localhost")
+}
+
+func TestProbe_Explicit(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	assert.Error(t, Probe("127.0.0.1", port))
+	assert.NoError(t, Probe("127.0.0.1", freePort(t)))
+}
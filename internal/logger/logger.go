@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"time"
 )
@@ -167,6 +168,34 @@ func levelToString(level Level) string {
 	}
 }
 
+// ParseLevel parses a level name: debug, info, warn (or warning) or error,
+// in any case.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q: must be debug, info, warn or error", s)
+	}
+}
+
+// OpenFile opens path for appending log output, creating it with 0600
+// permissions as logs may hold cluster and resource names. The caller closes
+// the file on shutdown.
+func OpenFile(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return f, nil
+}
+
 // Global logger for backward compatibility
 var globalLogger *Logger
 
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -405,6 +407,34 @@ func TestLevelToString(t *testing.T) {
 	}
 }
 
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Level
+		wantErr bool
+	}{
+		{input: "debug", want: LevelDebug},
+		{input: "INFO", want: LevelInfo},
+		{input: "warn", want: LevelWarn},
+		{input: "Warning", want: LevelWarn},
+		{input: " error ", want: LevelError},
+		{input: "trace", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			level, err := ParseLevel(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, level)
+		})
+	}
+}
+
 func TestJSONFieldTypes(t *testing.T) {
 	tests := []struct {
 		fields map[string]interface{}
@@ -519,3 +549,34 @@ func TestInitWithCustomOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kportal.log")
+
+	f, err := OpenFile(path)
+	require.NoError(t, err)
+	New(LevelInfo, FormatJSON, f).Info("first")
+	require.NoError(t, f.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// Reopening appends rather than truncating
+	f, err = OpenFile(path)
+	require.NoError(t, err)
+	New(LevelInfo, FormatJSON, f).Info("second")
+	require.NoError(t, f.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"message":"first"`)
+	assert.Contains(t, lines[1], `"message":"second"`)
+}
+
+func TestOpenFile_Error(t *testing.T) {
+	_, err := OpenFile(filepath.Join(t.TempDir(), "missing", "kportal.log"))
+	assert.Error(t, err)
+}
@@ -5,7 +5,9 @@
 // The Publisher manages mDNS service registrations using zeroconf:
 //   - Registers hostnames when forwards become active
 //   - Unregisters hostnames when forwards are stopped
-//   - Provides service discovery via the _kportal._tcp service type
+//   - Provides service discovery via an SRV record per alias pointing at the
+//     forward's local port, under a configurable service type (default
+//     _kportal._tcp), with a TXT record carrying forward/context/namespace
 //
 // mDNS discovery commands:
 //
@@ -20,6 +22,7 @@ import (
 	"time"
 
 	"github.com/grandcat/zeroconf"
+	"github.com/lukaszraczylo/kportal/internal/config"
 	"github.com/lukaszraczylo/kportal/internal/logger"
 )
 
@@ -36,21 +39,36 @@ const (
 // Publisher manages mDNS hostname registrations for port forwards.
 // It allows forwards with aliases to be accessible via <alias>.local hostnames.
 type Publisher struct {
-	servers  map[string]*zeroconf.Server
-	aliases  map[string]string
-	localIPs []string
-	mu       sync.RWMutex
-	enabled  bool
+	servers     map[string]*zeroconf.Server
+	aliases     map[string]string
+	serviceType string
+	localIPs    []string
+	mu          sync.RWMutex
+	enabled     bool
+}
+
+// registration is the mDNS record set published for one forward:
+// an SRV record <instance>.<service>.<domain> -> <host>.<domain>:<port>,
+// A records for host, and a TXT record with forward metadata.
+type registration struct {
+	instance string
+	service  string
+	domain   string
+	host     string
+	ips      []string
+	text     []string
+	port     int
 }
 
 // NewPublisher creates a new mDNS Publisher.
 // If enabled is false, all registration calls will be no-ops.
 func NewPublisher(enabled bool) *Publisher {
 	p := &Publisher{
-		servers:  make(map[string]*zeroconf.Server),
-		aliases:  make(map[string]string),
-		enabled:  enabled,
-		localIPs: getLocalIPs(),
+		servers:     make(map[string]*zeroconf.Server),
+		aliases:     make(map[string]string),
+		serviceType: config.DefaultMDNSServiceType,
+		enabled:     enabled,
+		localIPs:    getLocalIPs(),
 	}
 
 	if enabled {
@@ -63,10 +81,24 @@ func NewPublisher(enabled bool) *Publisher {
 	return p
 }
 
+// SetServiceType sets the DNS-SD service type (e.g. "_kportal._tcp") used
+// for subsequent registrations. An empty value restores the default.
+func (p *Publisher) SetServiceType(serviceType string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if serviceType == "" {
+		serviceType = config.DefaultMDNSServiceType
+	}
+	p.serviceType = serviceType
+}
+
 // Register publishes an mDNS hostname for a forward.
-// The hostname will be <alias>.local and will resolve to 127.0.0.1.
+// The hostname will be <alias>.local and will resolve to 127.0.0.1, with an
+// SRV record pointing at localPort and a TXT record carrying the forward ID,
+// context and namespace.
 // If the forward has no alias or mDNS is disabled, this is a no-op.
-func (p *Publisher) Register(forwardID, alias string, localPort int) error {
+func (p *Publisher) Register(forwardID, alias, contextName, namespace string, localPort int) error {
 	if !p.enabled || alias == "" {
 		return nil
 	}
@@ -83,17 +115,16 @@ func (p *Publisher) Register(forwardID, alias string, localPort int) error {
 		return nil
 	}
 
-	// Register the mDNS service
-	// We use a generic service type and rely on the hostname registration
+	reg := newRegistration(p.serviceType, forwardID, alias, contextName, namespace, localPort)
 	server, err := zeroconf.RegisterProxy(
-		alias,                 // Instance name (shown in service discovery)
-		"_kportal._tcp",       // Service type (custom for kportal)
-		"local.",              // Domain
-		localPort,             // Port
-		alias,                 // Hostname (will be <alias>.local)
-		[]string{"127.0.0.1"}, // IPs to resolve to
-		[]string{fmt.Sprintf("forward=%s", forwardID)}, // TXT records
-		nil, // interfaces (nil = all)
+		reg.instance, // Instance name (shown in service discovery)
+		reg.service,  // Service type for the SRV record
+		reg.domain,   // Domain
+		reg.port,     // SRV port
+		reg.host,     // Hostname (will be <alias>.local)
+		reg.ips,      // IPs to resolve to
+		reg.text,     // TXT records
+		nil,          // interfaces (nil = all)
 	)
 	if err != nil {
 		return fmt.Errorf("failed to register mDNS for %s: %w", alias, err)
@@ -110,12 +141,34 @@ func (p *Publisher) Register(forwardID, alias string, localPort int) error {
 	logger.Info("mDNS hostname registered", map[string]interface{}{
 		"forward_id": forwardID,
 		"hostname":   GetHostname(alias),
+		"service":    reg.service,
 		"port":       localPort,
 	})
 
 	return nil
 }
 
+// newRegistration builds the record set advertised for a forward.
+func newRegistration(serviceType, forwardID, alias, contextName, namespace string, localPort int) registration {
+	text := []string{"forward=" + forwardID}
+	if contextName != "" {
+		text = append(text, "context="+contextName)
+	}
+	if namespace != "" {
+		text = append(text, "namespace="+namespace)
+	}
+
+	return registration{
+		instance: alias,
+		service:  serviceType,
+		domain:   mdnsDomain + ".",
+		host:     alias,
+		ips:      []string{"127.0.0.1"},
+		text:     text,
+		port:     localPort,
+	}
+}
+
 // Unregister removes the mDNS hostname for a forward.
 func (p *Publisher) Unregister(forwardID string) {
 	if !p.enabled {
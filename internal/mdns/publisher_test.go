@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
 )
 
 // Note: Tests that actually register mDNS services require network I/O
@@ -14,7 +16,7 @@ func TestNewPublisher_Disabled(t *testing.T) {
 	p := NewPublisher(false)
 
 	// When disabled, Register should succeed but be a no-op
-	err := p.Register("forward-1", "test-alias", 8080)
+	err := p.Register("forward-1", "test-alias", "dev", "default", 8080)
 	assert.NoError(t, err)
 }
 
@@ -29,7 +31,7 @@ func TestNewPublisher_Enabled(t *testing.T) {
 func TestRegister_WhenDisabled_NoOp(t *testing.T) {
 	p := NewPublisher(false)
 
-	err := p.Register("forward-1", "test-alias", 8080)
+	err := p.Register("forward-1", "test-alias", "dev", "default", 8080)
 
 	assert.NoError(t, err)
 	// Unregister should also be safe when disabled
@@ -40,7 +42,7 @@ func TestRegister_EmptyAlias_NoOp(t *testing.T) {
 	p := NewPublisher(true)
 	defer p.Stop()
 
-	err := p.Register("forward-1", "", 8080)
+	err := p.Register("forward-1", "", "dev", "default", 8080)
 
 	assert.NoError(t, err)
 }
@@ -86,6 +88,40 @@ func TestGetLocalIPs(t *testing.T) {
 	}
 }
 
+func TestNewRegistration(t *testing.T) {
+	reg := newRegistration("_kportal._tcp", "dev/default/service/api:80", "api", "dev", "default", 8080)
+
+	assert.Equal(t, "api", reg.instance)
+	assert.Equal(t, "_kportal._tcp", reg.service)
+	assert.Equal(t, "local.", reg.domain)
+	assert.Equal(t, "api", reg.host)
+	assert.Equal(t, 8080, reg.port, "SRV record must point at the local port")
+	assert.Equal(t, []string{"127.0.0.1"}, reg.ips)
+	assert.Equal(t, []string{
+		"forward=dev/default/service/api:80",
+		"context=dev",
+		"namespace=default",
+	}, reg.text)
+}
+
+func TestNewRegistration_OmitsEmptyMetadata(t *testing.T) {
+	reg := newRegistration("_http._tcp", "fwd-1", "web", "", "", 3000)
+
+	assert.Equal(t, "_http._tcp", reg.service)
+	assert.Equal(t, []string{"forward=fwd-1"}, reg.text)
+}
+
+func TestSetServiceType(t *testing.T) {
+	p := NewPublisher(false)
+	assert.Equal(t, config.DefaultMDNSServiceType, p.serviceType)
+
+	p.SetServiceType("_http._tcp")
+	assert.Equal(t, "_http._tcp", p.serviceType)
+
+	p.SetServiceType("")
+	assert.Equal(t, config.DefaultMDNSServiceType, p.serviceType)
+}
+
 func TestGetHostname(t *testing.T) {
 	hostname := GetHostname("myapp")
 	assert.Equal(t, "myapp.local", hostname)
@@ -102,7 +138,7 @@ func TestRegister_Integration(t *testing.T) {
 	p := NewPublisher(true)
 	defer p.Stop()
 
-	err := p.Register("forward-1", "test-service", 8080)
+	err := p.Register("forward-1", "test-service", "dev", "default", 8080)
 	assert.NoError(t, err)
 
 	// Verify by checking that unregister doesn't panic
@@ -118,11 +154,11 @@ func TestRegister_Duplicate_Idempotent_Integration(t *testing.T) {
 	defer p.Stop()
 
 	// First registration
-	err := p.Register("forward-1", "test-service", 8080)
+	err := p.Register("forward-1", "test-service", "dev", "default", 8080)
 	assert.NoError(t, err)
 
 	// Second registration with same ID should be idempotent
-	err = p.Register("forward-1", "test-service", 8080)
+	err = p.Register("forward-1", "test-service", "dev", "default", 8080)
 	assert.NoError(t, err)
 }
 
@@ -134,9 +170,9 @@ func TestRegister_MultipleForwards_Integration(t *testing.T) {
 	p := NewPublisher(true)
 	defer p.Stop()
 
-	err1 := p.Register("forward-1", "service-a", 8080)
-	err2 := p.Register("forward-2", "service-b", 8081)
-	err3 := p.Register("forward-3", "service-c", 8082)
+	err1 := p.Register("forward-1", "service-a", "dev", "default", 8080)
+	err2 := p.Register("forward-2", "service-b", "dev", "default", 8081)
+	err3 := p.Register("forward-3", "service-c", "dev", "default", 8082)
 
 	assert.NoError(t, err1)
 	assert.NoError(t, err2)
@@ -151,13 +187,13 @@ func TestUnregister_Success_Integration(t *testing.T) {
 	p := NewPublisher(true)
 	defer p.Stop()
 
-	err := p.Register("forward-1", "test-service", 8080)
+	err := p.Register("forward-1", "test-service", "dev", "default", 8080)
 	assert.NoError(t, err)
 
 	// Unregister should not panic and should handle it gracefully
 	p.Unregister("forward-1")
 
 	// Re-registering should work after unregister
-	err = p.Register("forward-1", "test-service-2", 8080)
+	err = p.Register("forward-1", "test-service-2", "dev", "default", 8080)
 	assert.NoError(t, err)
 }
@@ -0,0 +1,225 @@
+// Package metrics exposes per-forward Prometheus metrics in the text
+// exposition format. It has no dependency on the Prometheus client library;
+// the handful of counters and gauges kportal reports are rendered directly.
+//
+// Every series is labelled with the forward ID:
+//
+//	kportal_forward_up{forward="..."}                                    gauge
+//	kportal_forward_reconnects_total{forward="..."}                      counter
+//	kportal_forward_bytes_total{forward="...",direction="sent|received"} counter
+//	kportal_forward_http_requests_total{forward="...",class="2xx"}       counter
+//	kportal_forward_tunnels_total{forward="..."}                         counter
+//	kportal_forward_streams_total{forward="..."}                         counter
+//
+// HTTP request counts are only reported for forwards with httpLog enabled.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// statusClasses are the HTTP status class labels, indexed by status / 100.
+// Index 0 collects anything outside 100-599.
+var statusClasses = [6]string{"other", "1xx", "2xx", "3xx", "4xx", "5xx"}
+
+// Traffic counts bytes moved through one forward's tunnel, and the tunnels
+// and client connection streams opened. It satisfies k8s.TrafficCounter,
+// k8s.ConnectionCounter and k8s.TunnelCounter.
+type Traffic struct {
+	sent     atomic.Int64
+	received atomic.Int64
+	tunnels  atomic.Uint64
+	streams  atomic.Uint64
+}
+
+// AddSent records n bytes written from the local client to the pod.
+func (t *Traffic) AddSent(n int) {
+	if n > 0 {
+		t.sent.Add(int64(n))
+	}
+}
+
+// AddReceived records n bytes read from the pod back to the local client.
+func (t *Traffic) AddReceived(n int) {
+	if n > 0 {
+		t.received.Add(int64(n))
+	}
+}
+
+// ConnOpened records a client connection's stream pair being opened.
+func (t *Traffic) ConnOpened() {
+	t.streams.Add(1)
+}
+
+// ConnClosed does nothing: only opened streams are counted.
+func (t *Traffic) ConnClosed() {}
+
+// TunnelOpened records a port-forward connection being opened.
+func (t *Traffic) TunnelOpened() {
+	t.tunnels.Add(1)
+}
+
+// forwardMetrics holds the series for a single forward.
+type forwardMetrics struct {
+	traffic    Traffic
+	requests   [len(statusClasses)]atomic.Uint64
+	reconnects atomic.Uint64
+	up         atomic.Bool
+	http       atomic.Bool // set once an HTTP observer is attached
+}
+
+// Registry tracks metrics for all forwards and renders them on request.
+// It is safe for concurrent use.
+type Registry struct {
+	forwards map[string]*forwardMetrics
+	mu       sync.RWMutex
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{forwards: make(map[string]*forwardMetrics)}
+}
+
+// get returns the metrics for id, creating them on first use.
+func (r *Registry) get(id string) *forwardMetrics {
+	r.mu.RLock()
+	m, ok := r.forwards[id]
+	r.mu.RUnlock()
+	if ok {
+		return m
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if m, ok = r.forwards[id]; !ok {
+		m = &forwardMetrics{}
+		r.forwards[id] = m
+	}
+	return m
+}
+
+// SetUp records whether the forward is up.
+func (r *Registry) SetUp(id string, up bool) {
+	r.get(id).up.Store(up)
+}
+
+// IncReconnects counts one reconnect attempt for the forward.
+func (r *Registry) IncReconnects(id string) {
+	r.get(id).reconnects.Add(1)
+}
+
+// Traffic returns the byte counter for the forward.
+func (r *Registry) Traffic(id string) *Traffic {
+	return &r.get(id).traffic
+}
+
+// HTTPStatusObserver returns a function that counts one HTTP response with
+// the given status code for the forward. Request series for the forward are
+// reported from the moment the observer is created.
+func (r *Registry) HTTPStatusObserver(id string) func(status int) {
+	m := r.get(id)
+	m.http.Store(true)
+	return func(status int) {
+		class := 0
+		if status >= 100 && status < 600 {
+			class = status / 100
+		}
+		m.requests[class].Add(1)
+	}
+}
+
+// Remove drops all series for a forward that no longer exists.
+func (r *Registry) Remove(id string) {
+	r.mu.Lock()
+	delete(r.forwards, id)
+	r.mu.Unlock()
+}
+
+// snapshot returns the forward IDs in sorted order with their metrics.
+func (r *Registry) snapshot() ([]string, map[string]*forwardMetrics) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.forwards))
+	forwards := make(map[string]*forwardMetrics, len(r.forwards))
+	for id, m := range r.forwards {
+		ids = append(ids, id)
+		forwards[id] = m
+	}
+	sort.Strings(ids)
+	return ids, forwards
+}
+
+// Write renders all metrics in the Prometheus text exposition format.
+func (r *Registry) Write(w io.Writer) error {
+	ids, forwards := r.snapshot()
+	var bw strings.Builder
+
+	writeHeader(&bw, "kportal_forward_up", "gauge", "Whether the forward is connected and healthy (1) or not (0).")
+	for _, id := range ids {
+		up := 0
+		if forwards[id].up.Load() {
+			up = 1
+		}
+		fmt.Fprintf(&bw, "kportal_forward_up{forward=\"%s\"} %d\n", escapeLabel(id), up)
+	}
+
+	writeHeader(&bw, "kportal_forward_reconnects_total", "counter", "Number of reconnect attempts made by the forward.")
+	for _, id := range ids {
+		fmt.Fprintf(&bw, "kportal_forward_reconnects_total{forward=\"%s\"} %d\n", escapeLabel(id), forwards[id].reconnects.Load())
+	}
+
+	writeHeader(&bw, "kportal_forward_bytes_total", "counter", "Bytes transferred through the forward's tunnel.")
+	for _, id := range ids {
+		t := &forwards[id].traffic
+		fmt.Fprintf(&bw, "kportal_forward_bytes_total{forward=\"%s\",direction=\"sent\"} %d\n", escapeLabel(id), t.sent.Load())
+		fmt.Fprintf(&bw, "kportal_forward_bytes_total{forward=\"%s\",direction=\"received\"} %d\n", escapeLabel(id), t.received.Load())
+	}
+
+	writeHeader(&bw, "kportal_forward_tunnels_total", "counter", "Port-forward connections opened by the forward, one per connect or reconnect.")
+	for _, id := range ids {
+		fmt.Fprintf(&bw, "kportal_forward_tunnels_total{forward=\"%s\"} %d\n", escapeLabel(id), forwards[id].traffic.tunnels.Load())
+	}
+
+	writeHeader(&bw, "kportal_forward_streams_total", "counter", "Client connections carried by the forward's tunnels, each over its own stream pair.")
+	for _, id := range ids {
+		fmt.Fprintf(&bw, "kportal_forward_streams_total{forward=\"%s\"} %d\n", escapeLabel(id), forwards[id].traffic.streams.Load())
+	}
+
+	writeHeader(&bw, "kportal_forward_http_requests_total", "counter", "HTTP requests proxied by the forward, by response status class.")
+	for _, id := range ids {
+		m := forwards[id]
+		if !m.http.Load() {
+			continue
+		}
+		for class, name := range statusClasses {
+			fmt.Fprintf(&bw, "kportal_forward_http_requests_total{forward=\"%s\",class=\"%s\"} %d\n", escapeLabel(id), name, m.requests[class].Load())
+		}
+	}
+
+	_, err := io.WriteString(w, bw.String())
+	return err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = r.Write(w) // The client went away; nothing to report it to
+}
+
+func writeHeader(w *strings.Builder, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// labelEscaper escapes a label value as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
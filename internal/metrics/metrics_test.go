@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T, reg *Registry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, reg.Write(&buf))
+	return buf.String()
+}
+
+func TestRegistry_Write(t *testing.T) {
+	reg := NewRegistry()
+	reg.SetUp("dev/default/service/api:8080", true)
+	reg.SetUp("dev/default/pod/db:5432", false)
+	reg.IncReconnects("dev/default/pod/db:5432")
+	reg.IncReconnects("dev/default/pod/db:5432")
+
+	traffic := reg.Traffic("dev/default/service/api:8080")
+	traffic.AddSent(100)
+	traffic.AddReceived(2048)
+	traffic.AddSent(-1) // ignored
+	traffic.TunnelOpened()
+	for range 3 {
+		traffic.ConnOpened()
+		traffic.ConnClosed()
+	}
+
+	observe := reg.HTTPStatusObserver("dev/default/service/api:8080")
+	observe(200)
+	observe(204)
+	observe(404)
+	observe(502)
+	observe(0)
+
+	out := render(t, reg)
+
+	assert.Contains(t, out, "# TYPE kportal_forward_up gauge\n")
+	assert.Contains(t, out, "# TYPE kportal_forward_reconnects_total counter\n")
+	assert.Contains(t, out, `kportal_forward_up{forward="dev/default/service/api:8080"} 1`)
+	assert.Contains(t, out, `kportal_forward_up{forward="dev/default/pod/db:5432"} 0`)
+	assert.Contains(t, out, `kportal_forward_reconnects_total{forward="dev/default/pod/db:5432"} 2`)
+	assert.Contains(t, out, `kportal_forward_bytes_total{forward="dev/default/service/api:8080",direction="sent"} 100`)
+	assert.Contains(t, out, `kportal_forward_bytes_total{forward="dev/default/service/api:8080",direction="received"} 2048`)
+	assert.Contains(t, out, `kportal_forward_tunnels_total{forward="dev/default/service/api:8080"} 1`)
+	assert.Contains(t, out, `kportal_forward_streams_total{forward="dev/default/service/api:8080"} 3`)
+	assert.Contains(t, out, `kportal_forward_streams_total{forward="dev/default/pod/db:5432"} 0`)
+	assert.Contains(t, out, `kportal_forward_http_requests_total{forward="dev/default/service/api:8080",class="2xx"} 2`)
+	assert.Contains(t, out, `kportal_forward_http_requests_total{forward="dev/default/service/api:8080",class="4xx"} 1`)
+	assert.Contains(t, out, `kportal_forward_http_requests_total{forward="dev/default/service/api:8080",class="5xx"} 1`)
+	assert.Contains(t, out, `kportal_forward_http_requests_total{forward="dev/default/service/api:8080",class="other"} 1`)
+	assert.Contains(t, out, `kportal_forward_http_requests_total{forward="dev/default/service/api:8080",class="1xx"} 0`)
+
+	// Forwards without an HTTP observer report no request series
+	assert.NotContains(t, out, `kportal_forward_http_requests_total{forward="dev/default/pod/db:5432"`)
+
+	// Series are sorted by forward ID
+	assert.Less(t,
+		strings.Index(out, `kportal_forward_up{forward="dev/default/pod/db:5432"}`),
+		strings.Index(out, `kportal_forward_up{forward="dev/default/service/api:8080"}`))
+}
+
+func TestRegistry_Remove(t *testing.T) {
+	reg := NewRegistry()
+	reg.SetUp("a", true)
+	reg.SetUp("b", true)
+	reg.Remove("a")
+
+	out := render(t, reg)
+	assert.NotContains(t, out, `forward="a"`)
+	assert.Contains(t, out, `kportal_forward_up{forward="b"} 1`)
+}
+
+func TestRegistry_EscapesLabels(t *testing.T) {
+	reg := NewRegistry()
+	reg.SetUp("a\"b\\c\nd", true)
+
+	assert.Contains(t, render(t, reg), `kportal_forward_up{forward="a\"b\\c\nd"} 1`)
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	reg := NewRegistry()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				reg.IncReconnects("fwd")
+				reg.Traffic("fwd").AddSent(1)
+				_ = reg.Write(io.Discard)
+			}
+		}()
+	}
+	wg.Wait()
+
+	out := render(t, reg)
+	assert.Contains(t, out, `kportal_forward_reconnects_total{forward="fwd"} 1000`)
+	assert.Contains(t, out, `kportal_forward_bytes_total{forward="fwd",direction="sent"} 1000`)
+}
+
+func TestServer_ServesMetrics(t *testing.T) {
+	reg := NewRegistry()
+	reg.SetUp("fwd", true)
+
+	srv := NewServer("127.0.0.1:0", reg)
+	assert.Nil(t, srv.Addr(), "no address before Start")
+	require.NoError(t, srv.Start())
+
+	resp, err := http.Get("http://" + srv.Addr().String() + "/metrics")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain; version=0.0.4")
+	assert.Contains(t, string(body), `kportal_forward_up{forward="fwd"} 1`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, srv.Shutdown(ctx))
+
+	_, err = http.Get("http://" + srv.Addr().String() + "/metrics")
+	assert.Error(t, err, "server should refuse connections after shutdown")
+}
+
+func TestServer_StartPortInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	srv := NewServer(ln.Addr().String(), NewRegistry())
+	err = srv.Start()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to listen on")
+}
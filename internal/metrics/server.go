@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/logger"
+)
+
+// readHeaderTimeout bounds how long a scrape may take to send its headers.
+const readHeaderTimeout = 5 * time.Second
+
+// Server serves a Registry on /metrics.
+type Server struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewServer creates a Server that will listen on addr once started.
+func NewServer(addr string, reg *Registry) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", reg)
+
+	return &Server{
+		server: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// Start binds the listen address and serves in the background. Binding
+// happens before Start returns, so a port conflict is reported here.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.server.Addr, err)
+	}
+	s.listener = ln
+
+	go func() {
+		if err := s.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Metrics server stopped", map[string]any{
+				"addr":  ln.Addr().String(),
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	return nil
+}
+
+// Addr returns the address the server is listening on, or nil before Start.
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Shutdown stops the server, waiting for in-flight scrapes until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
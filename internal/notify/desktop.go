@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// desktopTimeout bounds how long the platform's notification command may run.
+const desktopTimeout = 10 * time.Second
+
+// windowsBalloonScript shows a tray balloon. The title and message come from
+// the environment so they need no PowerShell quoting.
+const windowsBalloonScript = `Add-Type -AssemblyName System.Windows.Forms
+$n = New-Object System.Windows.Forms.NotifyIcon
+$n.Icon = [System.Drawing.SystemIcons]::Information
+$n.Visible = $true
+$n.ShowBalloonTip(5000, $env:KPORTAL_NOTIFY_TITLE, $env:KPORTAL_NOTIFY_MESSAGE, 'None')
+Start-Sleep -Seconds 5
+$n.Dispose()`
+
+// Desktop sends notifications through the platform's own tooling:
+// osascript on macOS, notify-send on Linux and the BSDs, and a PowerShell
+// tray balloon on Windows.
+type Desktop struct{}
+
+// Send shows a desktop notification and waits for the command to finish.
+func (Desktop) Send(title, message string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), desktopTimeout)
+	defer cancel()
+
+	cmd, err := desktopCommand(ctx, runtime.GOOS, title, message)
+	if err != nil {
+		return err
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if text := strings.TrimSpace(string(out)); text != "" {
+			return fmt.Errorf("%s: %w: %s", cmd.Path, err, text)
+		}
+		return fmt.Errorf("%s: %w", cmd.Path, err)
+	}
+	return nil
+}
+
+// desktopCommand builds the notification command for goos.
+func desktopCommand(ctx context.Context, goos, title, message string) (*exec.Cmd, error) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		return exec.CommandContext(ctx, "osascript", "-e", script), nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return exec.CommandContext(ctx, "notify-send", "--app-name=kportal", title, message), nil
+	case "windows":
+		cmd := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", windowsBalloonScript)
+		cmd.Env = append(os.Environ(), "KPORTAL_NOTIFY_TITLE="+title, "KPORTAL_NOTIFY_MESSAGE="+message)
+		return cmd, nil
+	default:
+		return nil, fmt.Errorf("desktop notifications are not supported on %s", goos)
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
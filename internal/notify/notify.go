@@ -0,0 +1,167 @@
+// Package notify tells people and systems about forward status changes:
+// desktop notifications when forwards go down or recover, so kportal can
+// run minimized without the TUI being watched, and webhooks for team
+// dashboards and alerting.
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/healthcheck"
+	"github.com/lukaszraczylo/kportal/internal/logger"
+)
+
+// Change is a forward's status change. It is also the JSON payload webhooks
+// receive.
+type Change struct {
+	Time  time.Time `json:"timestamp"`
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`      // alias or resource and local port, e.g. "api (:8080)"
+	From  string    `json:"oldStatus"` // empty for the first status of a forward
+	To    string    `json:"newStatus"`
+	Error string    `json:"error,omitempty"` // latest error since the forward was last up
+}
+
+// Sender delivers a notification, e.g. as a desktop notification.
+type Sender interface {
+	Send(title, message string) error
+}
+
+// Notifier turns forward status changes into notifications through a
+// Sender. Changes are debounced: a forward that flaps back within the
+// debounce window is not notified at all. It is safe for concurrent use.
+type Notifier struct {
+	sender   Sender
+	forwards map[string]*forwardState
+	debounce time.Duration
+	mu       sync.Mutex
+}
+
+// forwardState tracks one forward between notifications.
+type forwardState struct {
+	timer        *time.Timer // pending notification; nil when none
+	name         string
+	status       string // latest up or down status
+	err          string // error of the latest down status
+	down         bool   // whether status is a down status
+	notifiedDown bool   // whether the last notification said the forward is down
+}
+
+// New creates a Notifier that sends through sender once a forward has been
+// down, or back up, for debounce.
+func New(sender Sender, debounce time.Duration) *Notifier {
+	return &Notifier{
+		sender:   sender,
+		forwards: make(map[string]*forwardState),
+		debounce: debounce,
+	}
+}
+
+// StatusChanged records a forward's new status. Statuses that are neither
+// up nor down, such as Starting or Stale, leave the forward as it was.
+func (n *Notifier) StatusChanged(change Change) {
+	down, known := classify(change.To)
+	if !known {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	fwd, exists := n.forwards[change.ID]
+	if !exists {
+		// Forwards start out up, so one coming up is not notified
+		fwd = &forwardState{}
+		n.forwards[change.ID] = fwd
+	}
+	fwd.name = change.Name
+	fwd.status = change.To
+	fwd.err = change.Error
+	fwd.down = down
+
+	if fwd.down != fwd.notifiedDown && fwd.timer == nil {
+		id := change.ID
+		fwd.timer = time.AfterFunc(n.debounce, func() { n.fire(id) })
+	}
+}
+
+// Removed forgets a forward, dropping any pending notification.
+func (n *Notifier) Removed(id string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if fwd, exists := n.forwards[id]; exists {
+		if fwd.timer != nil {
+			fwd.timer.Stop()
+		}
+		delete(n.forwards, id)
+	}
+}
+
+// Stop drops every pending notification.
+func (n *Notifier) Stop() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for id, fwd := range n.forwards {
+		if fwd.timer != nil {
+			fwd.timer.Stop()
+		}
+		delete(n.forwards, id)
+	}
+}
+
+// fire sends the notification for id once the debounce window has passed,
+// unless the forward has since returned to the state last notified.
+func (n *Notifier) fire(id string) {
+	n.mu.Lock()
+	fwd, exists := n.forwards[id]
+	if !exists {
+		n.mu.Unlock()
+		return
+	}
+	fwd.timer = nil
+	if fwd.down == fwd.notifiedDown {
+		n.mu.Unlock()
+		return
+	}
+	fwd.notifiedDown = fwd.down
+
+	title := "kportal: " + fwd.name + " recovered"
+	message := fmt.Sprintf("%s is %s again", fwd.name, fwd.status)
+	if fwd.down {
+		title = "kportal: " + fwd.name + " is down"
+		message = fmt.Sprintf("%s is %s", fwd.name, fwd.status)
+		if fwd.err != "" {
+			message += ": " + fwd.err
+		}
+	}
+	n.mu.Unlock()
+
+	if err := n.sender.Send(title, message); err != nil {
+		logger.Warn("Failed to send notification", map[string]interface{}{
+			"forward_id": id,
+			"error":      err.Error(),
+		})
+	}
+}
+
+// classify reports whether status means the forward is down, and whether
+// it is an up or down status at all. Reconnecting counts as down, in any of
+// its "Reconnecting (4s, attempt 3)" forms: a quick reconnect is debounced.
+func classify(status string) (down, known bool) {
+	if strings.HasPrefix(status, string(healthcheck.StatusReconnect)) {
+		return true, true
+	}
+	switch healthcheck.Status(status) {
+	case healthcheck.StatusHealthy:
+		return false, true
+	case healthcheck.StatusUnhealthy, healthcheck.StatusFailed, healthcheck.StatusProbeFailed,
+		healthcheck.StatusContextNotFound, healthcheck.StatusPermissionDenied:
+		return true, true
+	}
+	return false, false
+}
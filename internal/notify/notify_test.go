@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSender collects the notifications sent to it.
+type recordingSender struct {
+	sent []string
+	err  error
+	mu   sync.Mutex
+}
+
+func (r *recordingSender) Send(title, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, title+" | "+message)
+	return r.err
+}
+
+func (r *recordingSender) notifications() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.sent...)
+}
+
+func TestNotifier_DownAndRecovered(t *testing.T) {
+	sender := &recordingSender{}
+	n := New(sender, 10*time.Millisecond)
+
+	n.StatusChanged(Change{ID: "api:8080", Name: "api (:8080)", To: "Starting"})
+	n.StatusChanged(Change{ID: "api:8080", Name: "api (:8080)", To: "Active"})
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, sender.notifications(), "coming up for the first time is not notified")
+
+	n.StatusChanged(Change{ID: "api:8080", Name: "api (:8080)", To: "Error", Error: "connection refused"})
+	n.StatusChanged(Change{ID: "api:8080", Name: "api (:8080)", To: "Stale"})
+	require.Eventually(t, func() bool { return len(sender.notifications()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "kportal: api (:8080) is down | api (:8080) is Error: connection refused", sender.notifications()[0])
+
+	n.StatusChanged(Change{ID: "api:8080", Name: "api (:8080)", To: "Active"})
+	require.Eventually(t, func() bool { return len(sender.notifications()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "kportal: api (:8080) recovered | api (:8080) is Active again", sender.notifications()[1])
+}
+
+func TestNotifier_DebouncesFlaps(t *testing.T) {
+	sender := &recordingSender{}
+	n := New(sender, 50*time.Millisecond)
+
+	n.StatusChanged(Change{ID: "db:5432", Name: "db (:5432)", To: "Active"})
+	n.StatusChanged(Change{ID: "db:5432", Name: "db (:5432)", To: "Error"})
+	n.StatusChanged(Change{ID: "db:5432", Name: "db (:5432)", To: "Active"})
+	n.StatusChanged(Change{ID: "db:5432", Name: "db (:5432)", To: "Reconnecting (network change)"})
+	n.StatusChanged(Change{ID: "db:5432", Name: "db (:5432)", To: "Active"})
+
+	time.Sleep(150 * time.Millisecond)
+	assert.Empty(t, sender.notifications(), "a flap back within the window is not notified")
+}
+
+func TestNotifier_Removed(t *testing.T) {
+	sender := &recordingSender{}
+	n := New(sender, 20*time.Millisecond)
+
+	n.StatusChanged(Change{ID: "db:5432", Name: "db (:5432)", To: "Failed"})
+	n.Removed("db:5432")
+	n.StatusChanged(Change{ID: "web:80", Name: "web (:80)", To: "Permission denied"})
+	n.Stop()
+
+	time.Sleep(80 * time.Millisecond)
+	assert.Empty(t, sender.notifications())
+}
+
+func TestNotifier_SendErrorIsNotFatal(t *testing.T) {
+	sender := &recordingSender{err: errors.New("no notification daemon")}
+	n := New(sender, 0)
+
+	n.StatusChanged(Change{ID: "api:8080", Name: "api (:8080)", To: "Context not found"})
+	require.Eventually(t, func() bool { return len(sender.notifications()) == 1 }, time.Second, 5*time.Millisecond)
+
+	n.StatusChanged(Change{ID: "api:8080", Name: "api (:8080)", To: "Active"})
+	require.Eventually(t, func() bool { return len(sender.notifications()) == 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		status string
+		down   bool
+		known  bool
+	}{
+		{status: "Active", known: true},
+		{status: "Error", down: true, known: true},
+		{status: "Failed", down: true, known: true},
+		{status: "Unhealthy", down: true, known: true},
+		{status: "Context not found", down: true, known: true},
+		{status: "Permission denied", down: true, known: true},
+		{status: "Reconnecting", down: true, known: true},
+		{status: "Reconnecting (4s, attempt 3)", down: true, known: true},
+		{status: "Starting"},
+		{status: "Stale"},
+		{status: "Disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			down, known := classify(tt.status)
+			assert.Equal(t, tt.down, down)
+			assert.Equal(t, tt.known, known)
+		})
+	}
+}
+
+func TestDesktopCommand(t *testing.T) {
+	ctx := context.Background()
+
+	cmd, err := desktopCommand(ctx, "linux", "kportal: api is down", "api is Error")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"notify-send", "--app-name=kportal", "kportal: api is down", "api is Error"}, cmd.Args)
+
+	cmd, err = desktopCommand(ctx, "darwin", `say "hi"`, `back\slash`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"osascript", "-e", `display notification "back\\slash" with title "say \"hi\""`}, cmd.Args)
+
+	cmd, err = desktopCommand(ctx, "windows", "title", "message")
+	require.NoError(t, err)
+	assert.Contains(t, cmd.Env, "KPORTAL_NOTIFY_TITLE=title")
+	assert.Contains(t, cmd.Env, "KPORTAL_NOTIFY_MESSAGE=message")
+
+	_, err = desktopCommand(ctx, "plan9", "title", "message")
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/logger"
+)
+
+const (
+	// webhookQueueSize is how many changes may wait for delivery to one
+	// webhook; more are dropped so forwarding never blocks on a slow endpoint.
+	webhookQueueSize = 100
+
+	// webhookRetryDelay is the wait before the first retry, doubled for
+	// each further one.
+	webhookRetryDelay = 500 * time.Millisecond
+)
+
+// Webhook POSTs every status change as JSON to a URL. Deliveries happen in
+// the background, in order, each with a timeout and retries; failures are
+// logged and never block the caller.
+type Webhook struct {
+	ctx        context.Context
+	client     *http.Client
+	cancel     context.CancelFunc
+	queue      chan Change
+	done       chan struct{}
+	headers    map[string]string
+	url        string
+	retries    int
+	retryDelay time.Duration
+}
+
+// NewWebhook creates a Webhook posting to url with headers, giving each
+// attempt timeout and retrying a failed delivery up to retries times, and
+// starts its delivery loop. Stop ends it.
+func NewWebhook(url string, headers map[string]string, timeout time.Duration, retries int) *Webhook {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Webhook{
+		ctx:        ctx,
+		cancel:     cancel,
+		client:     &http.Client{Timeout: timeout},
+		queue:      make(chan Change, webhookQueueSize),
+		done:       make(chan struct{}),
+		headers:    headers,
+		url:        url,
+		retries:    retries,
+		retryDelay: webhookRetryDelay,
+	}
+	go w.deliverLoop()
+	return w
+}
+
+// StatusChanged queues change for delivery. It never blocks: with the queue
+// full the change is dropped and logged.
+func (w *Webhook) StatusChanged(change Change) {
+	select {
+	case w.queue <- change:
+	default:
+		logger.Warn("Webhook queue full, dropping status change", map[string]interface{}{
+			"url":        w.url,
+			"forward_id": change.ID,
+			"status":     change.To,
+		})
+	}
+}
+
+// Removed does nothing: webhooks only report status changes.
+func (w *Webhook) Removed(string) {}
+
+// Stop ends the delivery loop, dropping changes not yet delivered, and
+// waits for it to exit.
+func (w *Webhook) Stop() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *Webhook) deliverLoop() {
+	defer close(w.done)
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case change := <-w.queue:
+			if err := w.deliver(change); err != nil && w.ctx.Err() == nil {
+				logger.Warn("Failed to deliver webhook", map[string]interface{}{
+					"url":        w.url,
+					"forward_id": change.ID,
+					"status":     change.To,
+					"error":      err.Error(),
+				})
+			}
+		}
+	}
+}
+
+// deliver posts change, retrying with a doubling delay.
+func (w *Webhook) deliver(change Change) error {
+	body, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	delay := w.retryDelay
+	for attempt := 0; ; attempt++ {
+		err = w.post(body)
+		if err == nil || attempt >= w.retries {
+			return err
+		}
+
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-w.ctx.Done():
+			return w.ctx.Err()
+		}
+	}
+}
+
+// post sends one attempt. Any status outside 2xx is an error.
+func (w *Webhook) post(body []byte) error {
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kportal")
+	for name, value := range w.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook_PostsChanges(t *testing.T) {
+	var mu sync.Mutex
+	var received []map[string]any
+	var headers http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		received = append(received, payload)
+		headers = r.Header.Clone()
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	hook := NewWebhook(server.URL, map[string]string{"Authorization": "Bearer secret"}, time.Second, 0)
+	defer hook.Stop()
+
+	at := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	hook.StatusChanged(Change{Time: at, ID: "api:8080", Name: "api (:8080)", From: "Active", To: "Error", Error: "connection refused"})
+	hook.StatusChanged(Change{Time: at, ID: "api:8080", Name: "api (:8080)", From: "Error", To: "Active"})
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]any{
+		"timestamp": "2026-10-16T09:30:00Z",
+		"id":        "api:8080",
+		"name":      "api (:8080)",
+		"oldStatus": "Active",
+		"newStatus": "Error",
+		"error":     "connection refused",
+	}, received[0])
+	assert.Equal(t, "Active", received[1]["newStatus"], "changes are delivered in order")
+	assert.NotContains(t, received[1], "error")
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "Bearer secret", headers.Get("Authorization"))
+}
+
+func TestWebhook_Retries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	hook := NewWebhook(server.URL, nil, time.Second, 2)
+	hook.retryDelay = time.Millisecond
+	defer hook.Stop()
+
+	hook.StatusChanged(Change{ID: "api:8080", To: "Error"})
+	require.Eventually(t, func() bool { return attempts.Load() == 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestWebhook_GivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	hook := NewWebhook(server.URL, nil, time.Second, 1)
+	hook.retryDelay = time.Millisecond
+
+	err := hook.deliver(Change{ID: "api:8080", To: "Error"})
+	assert.ErrorContains(t, err, "unexpected status 500")
+	assert.Equal(t, int32(2), attempts.Load(), "one attempt and one retry")
+	hook.Stop()
+}
+
+func TestWebhook_NeverBlocks(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	hook := NewWebhook(server.URL, nil, time.Second, 0)
+	defer hook.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		for range webhookQueueSize * 2 {
+			hook.StatusChanged(Change{ID: "api:8080", To: "Error"})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("StatusChanged blocked on a slow webhook")
+	}
+}
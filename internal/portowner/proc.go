@@ -0,0 +1,97 @@
+package portowner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// tcpListenState is the st column value of a listening socket in /proc/net/tcp
+	tcpListenState = "0A"
+	// minProcNetFields is the minimum number of fields in a /proc/net/tcp row (up to inode)
+	minProcNetFields = 10
+)
+
+// lookupProc finds listeners on port by matching socket inodes from
+// /proc/net/tcp{,6} against each process's open file descriptors. Without
+// root only the caller's own processes are visible, which still covers the
+// common case of a forgotten dev server.
+func lookupProc(port int) []processInfo {
+	inodes := make(map[string]bool)
+	for _, table := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		data, err := os.ReadFile(table) // #nosec G304 -- fixed procfs paths
+		if err != nil {
+			continue
+		}
+		for _, inode := range listeningInodes(string(data), port) {
+			inodes[inode] = true
+		}
+	}
+	if len(inodes) == 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+
+	var processes []processInfo
+	for _, entry := range entries {
+		pid := entry.Name()
+		if !isValidPID(pid) || !holdsSocket(pid, inodes) {
+			continue
+		}
+		name := ""
+		if comm, err := os.ReadFile(filepath.Join("/proc", pid, "comm")); err == nil {
+			name = strings.TrimSpace(string(comm))
+		}
+		processes = append(processes, processInfo{
+			pid:     pid,
+			name:    name,
+			isValid: true,
+		})
+	}
+	return processes
+}
+
+// holdsSocket reports whether process pid has one of the socket inodes open.
+func holdsSocket(pid string, inodes map[string]bool) bool {
+	fdDir := filepath.Join("/proc", pid, "fd")
+	fds, err := os.ReadDir(fdDir)
+	if err != nil {
+		return false
+	}
+	for _, fd := range fds {
+		target, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+		if err != nil {
+			continue
+		}
+		if inode, ok := strings.CutPrefix(target, "socket:["); ok && inodes[strings.TrimSuffix(inode, "]")] {
+			return true
+		}
+	}
+	return false
+}
+
+// listeningInodes returns the socket inodes of listening rows for port in a
+// /proc/net/tcp or /proc/net/tcp6 table.
+// Row format: sl local_address rem_address st ... uid timeout inode
+func listeningInodes(table string, port int) []string {
+	suffix := fmt.Sprintf(":%04X", port)
+
+	var inodes []string
+	for _, line := range strings.Split(table, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < minProcNetFields || fields[3] != tcpListenState {
+			continue
+		}
+		if !strings.HasSuffix(fields[1], suffix) || fields[9] == "0" {
+			continue
+		}
+		inodes = append(inodes, fields[9])
+	}
+	return inodes
+}
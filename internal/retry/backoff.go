@@ -1,6 +1,7 @@
 // Package retry provides exponential backoff with jitter for retry logic.
-// It implements a backoff sequence of 1s → 2s → 4s → 8s → 10s (max),
-// with 10% random jitter to prevent thundering herd problems.
+// By default it implements a backoff sequence of 1s → 2s → 4s → 8s → 10s (max),
+// with 10% random jitter to prevent thundering herd problems. The initial
+// delay, maximum delay and jitter can be changed with NewBackoffWithOptions.
 //
 // Basic usage:
 //
@@ -24,57 +25,89 @@ import (
 
 const (
 	// Backoff intervals: 1s → 2s → 4s → 8s → 10s (max)
-	initialDelay = 1 * time.Second
-	maxDelay     = 10 * time.Second
-	jitterPct    = 0.1 // 10% jitter
+	DefaultInitialDelay = 1 * time.Second
+	DefaultMaxDelay     = 10 * time.Second
+	DefaultJitter       = 0.1 // 10% jitter
 	// maxAttempt caps the exponent to prevent math.Pow overflow
-	// 2^30 seconds is ~34 years, well above maxDelay, so this is safe
+	// 2^30 seconds is ~34 years, well above any sane max delay, so this is safe
 	maxAttempt = 30
 )
 
+// Options configures a Backoff. Zero delays fall back to the defaults.
+type Options struct {
+	InitialDelay time.Duration // Delay before the first retry
+	MaxDelay     time.Duration // Upper bound for the delay before jitter
+	Jitter       float64       // Random ± fraction of the delay, 0 to 1
+}
+
+// DefaultOptions returns the 1s → 10s schedule with 10% jitter.
+func DefaultOptions() Options {
+	return Options{
+		InitialDelay: DefaultInitialDelay,
+		MaxDelay:     DefaultMaxDelay,
+		Jitter:       DefaultJitter,
+	}
+}
+
 // Backoff implements exponential backoff with jitter for retry logic.
-// The backoff sequence is: 1s → 2s → 4s → 8s → 10s (max, then stays at 10s).
+// The default sequence is: 1s → 2s → 4s → 8s → 10s (max, then stays at 10s).
 type Backoff struct {
 	rng     *rand.Rand
+	opts    Options
 	attempt int
 }
 
-// NewBackoff creates a new Backoff instance with a seeded random number generator.
+// NewBackoff creates a new Backoff instance with the default schedule and a
+// seeded random number generator.
 func NewBackoff() *Backoff {
+	return NewBackoffWithOptions(DefaultOptions())
+}
+
+// NewBackoffWithOptions creates a new Backoff instance with a custom schedule.
+func NewBackoffWithOptions(opts Options) *Backoff {
+	if opts.InitialDelay <= 0 {
+		opts.InitialDelay = DefaultInitialDelay
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = DefaultMaxDelay
+	}
+	if opts.MaxDelay < opts.InitialDelay {
+		opts.MaxDelay = opts.InitialDelay
+	}
+	opts.Jitter = math.Max(0, math.Min(opts.Jitter, 1))
+
 	return &Backoff{
 		attempt: 0,
+		opts:    opts,
 		// #nosec G404 -- math/rand is appropriate for backoff jitter; cryptographic randomness not needed
 		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
 // Next returns the next backoff duration and increments the attempt counter.
-// The duration follows exponential backoff: 1s → 2s → 4s → 8s → 10s (max).
-// A 10% jitter is added to prevent thundering herd effects.
+// The duration follows the exponential schedule of Delay, plus random jitter
+// to prevent thundering herd effects.
 func (b *Backoff) Next() time.Duration {
-	// Cap attempt to prevent overflow in math.Pow
-	attempt := b.attempt
-	if attempt > maxAttempt {
-		attempt = maxAttempt
-	}
-
-	// Calculate base delay: 2^attempt seconds
-	exp := math.Pow(2, float64(attempt))
-	delay := time.Duration(exp) * time.Second
-
-	// Cap at max delay
-	if delay > maxDelay {
-		delay = maxDelay
-	}
-
-	// Add jitter (±10%)
-	jitter := b.calculateJitter(delay)
-	delay += jitter
+	delay := b.Delay(b.attempt)
+	delay += b.calculateJitter(delay)
 
 	b.attempt++
 	return delay
 }
 
+// Delay returns the delay before jitter for a zero-based attempt:
+// InitialDelay * 2^attempt, capped at MaxDelay.
+func (b *Backoff) Delay(attempt int) time.Duration {
+	// Cap attempt to prevent overflow in math.Pow
+	attempt = max(0, min(attempt, maxAttempt))
+
+	delay := float64(b.opts.InitialDelay) * math.Pow(2, float64(attempt))
+	if delay > float64(b.opts.MaxDelay) {
+		return b.opts.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
 // Reset resets the backoff to the initial state.
 func (b *Backoff) Reset() {
 	b.attempt = 0
@@ -86,9 +119,9 @@ func (b *Backoff) Attempt() int {
 }
 
 // calculateJitter adds random jitter to prevent synchronized retries.
-// Returns a value between -jitterPct*delay and +jitterPct*delay.
+// Returns a value between -Jitter*delay and +Jitter*delay.
 func (b *Backoff) calculateJitter(delay time.Duration) time.Duration {
-	maxJitter := float64(delay) * jitterPct
+	maxJitter := float64(delay) * b.opts.Jitter
 	// Generate random value in range [-maxJitter, +maxJitter]
 	jitter := (b.rng.Float64()*2 - 1) * maxJitter
 	return time.Duration(jitter)
@@ -167,3 +167,63 @@ func TestBackoff_ExponentialProgression(t *testing.T) {
 		assert.LessOrEqual(t, ratio, 2.5, "exponential growth should be ~2x")
 	}
 }
+
+func TestBackoff_Delay_Schedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		expected []time.Duration
+	}{
+		{
+			name:     "defaults",
+			opts:     DefaultOptions(),
+			expected: []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second},
+		},
+		{
+			name:     "custom base and max",
+			opts:     Options{InitialDelay: 250 * time.Millisecond, MaxDelay: 3 * time.Second},
+			expected: []time.Duration{250 * time.Millisecond, 500 * time.Millisecond, 1 * time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second},
+		},
+		{
+			name:     "zero delays fall back to defaults",
+			opts:     Options{},
+			expected: []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second},
+		},
+		{
+			name:     "max below base is raised to base",
+			opts:     Options{InitialDelay: 5 * time.Second, MaxDelay: 1 * time.Second},
+			expected: []time.Duration{5 * time.Second, 5 * time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBackoffWithOptions(tt.opts)
+			for attempt, want := range tt.expected {
+				assert.Equal(t, want, b.Delay(attempt), "attempt %d", attempt)
+			}
+		})
+	}
+}
+
+func TestBackoff_Delay_LargeAttempt(t *testing.T) {
+	b := NewBackoffWithOptions(Options{InitialDelay: time.Second, MaxDelay: time.Hour})
+	assert.Equal(t, time.Hour, b.Delay(1000), "huge attempts must not overflow")
+	assert.Equal(t, time.Second, b.Delay(-1), "negative attempts use the initial delay")
+}
+
+func TestBackoff_NoJitter(t *testing.T) {
+	b := NewBackoffWithOptions(Options{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: 0})
+	assert.Equal(t, 100*time.Millisecond, b.Next())
+	assert.Equal(t, 200*time.Millisecond, b.Next())
+	assert.Equal(t, 400*time.Millisecond, b.Next())
+}
+
+func TestBackoff_CustomJitter(t *testing.T) {
+	b := NewBackoffWithOptions(Options{InitialDelay: time.Second, MaxDelay: time.Second, Jitter: 0.5})
+	for i := 0; i < 50; i++ {
+		delay := b.Next()
+		assert.GreaterOrEqual(t, delay, 500*time.Millisecond)
+		assert.LessOrEqual(t, delay, 1500*time.Millisecond)
+	}
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sparklineWidth is how many characters the activity sparkline spans. Each
+// character sums the requests of as many seconds as fit.
+const sparklineWidth = 15
+
+// sparklineLevels are the characters of the activity sparkline, lowest
+// first.
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// ActivityProvider returns a forward's HTTP requests per second, oldest
+// first. ok is false when the forward is not running or does not have HTTP
+// logging, which is what counts the requests.
+type ActivityProvider func(forwardID string) (counts []int64, ok bool)
+
+// SetActivityProvider sets the function the activity column is read from
+func (ui *BubbleTeaUI) SetActivityProvider(provider ActivityProvider) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.activityProvider = provider
+}
+
+// toggleActivity shows or hides the activity column. While shown it is
+// refreshed with the traffic columns, every trafficRefreshInterval.
+func (m model) toggleActivity() (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	m.ui.showActivity = !m.ui.showActivity
+	show := m.ui.showActivity
+	m.ui.mu.Unlock()
+
+	if show {
+		m.ui.refreshActivity()
+	}
+	return m, m.ui.restartTrafficTick()
+}
+
+// refreshActivity copies the provider's request counts into every
+// forward's status. Forwards without HTTP logging show no activity.
+func (ui *BubbleTeaUI) refreshActivity() {
+	ui.mu.RLock()
+	provider := ui.activityProvider
+	ids := append([]string(nil), ui.forwardOrder...)
+	ui.mu.RUnlock()
+
+	if provider == nil {
+		return
+	}
+
+	// The provider is queried outside the lock so rendering is not blocked
+	latest := make(map[string][]int64, len(ids))
+	for _, id := range ids {
+		if counts, ok := provider(id); ok {
+			latest[id] = counts
+		}
+	}
+
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	for _, id := range ids {
+		if fwd, ok := ui.forwards[id]; ok {
+			fwd.Requests = latest[id]
+		}
+	}
+}
+
+// sparkline renders per-second request counts as a sparkline of
+// sparklineWidth characters scaled to the busiest one, or "-" without
+// counts.
+func sparkline(counts []int64) string {
+	if len(counts) == 0 {
+		return "-"
+	}
+
+	// Sum the seconds into the characters, the newest ones last
+	per := (len(counts) + sparklineWidth - 1) / sparklineWidth
+	sums := make([]int64, 0, sparklineWidth)
+	for end := len(counts); end > 0; end -= per {
+		var sum int64
+		for _, n := range counts[max(end-per, 0):end] {
+			sum += n
+		}
+		sums = append(sums, sum)
+	}
+
+	var peak int64
+	for _, sum := range sums {
+		if sum > peak {
+			peak = sum
+		}
+	}
+
+	var b strings.Builder
+	top := int64(len(sparklineLevels) - 1)
+	for i := len(sums) - 1; i >= 0; i-- {
+		level := int64(0)
+		if peak > 0 {
+			level = sums[i] * top / peak
+			// Any request at all rises above the baseline
+			if sums[i] > 0 && level == 0 {
+				level = 1
+			}
+		}
+		b.WriteRune(sparklineLevels[level])
+	}
+	return b.String()
+}
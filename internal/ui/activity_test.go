@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		counts []int64
+	}{
+		{name: "no counts", want: "-"},
+		{name: "idle", want: "▁▁▁▁▁▁▁▁▁▁▁▁▁▁▁", counts: make([]int64, 30)},
+		{name: "busiest is full height", want: "▁▁▁▁▁▁▁▁▁▁▁▁▁▁█", counts: append(make([]int64, 28), 3, 4)},
+		{name: "any request rises", want: "▂▁▁▁▁▁▁▁▁▁▁▁▁▁█", counts: append(append([]int64{1}, make([]int64, 28)...), 100)},
+		{name: "short history", want: "▁▄█", counts: []int64{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sparkline(tt.counts))
+		})
+	}
+}
+
+func TestToggleActivity(t *testing.T) {
+	m := newTestModelWithForward()
+	calls := 0
+	m.ui.SetActivityProvider(func(id string) ([]int64, bool) {
+		calls++
+		return []int64{0, 2, 4}, id == "test-id"
+	})
+	assert.NotContains(t, m.renderMainView(), "ACTIVITY")
+
+	_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	require.NotNil(t, cmd, "shown column should schedule a refresh")
+
+	view := m.renderMainView()
+	assert.Contains(t, view, "ACTIVITY")
+	assert.Contains(t, view, "▁▄█")
+	assert.NotContains(t, view, "CONNS", "traffic columns stay hidden")
+
+	_, cmd = m.Update(trafficTickMsg{seq: m.ui.trafficSeq})
+	assert.NotNil(t, cmd, "refresh keeps polling while shown")
+	assert.Equal(t, 2, calls)
+
+	_, cmd = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	assert.Nil(t, cmd)
+	assert.NotContains(t, m.renderMainView(), "ACTIVITY")
+}
+
+func TestToggleActivity_SharesTrafficPolling(t *testing.T) {
+	m := newTestModelWithForward()
+	m.ui.SetActivityProvider(func(string) ([]int64, bool) { return nil, false })
+
+	_, _ = m.toggleTraffic()
+	_, cmd := m.toggleActivity()
+	require.NotNil(t, cmd)
+
+	// Hiding the traffic columns keeps the activity column polling
+	_, cmd = m.toggleTraffic()
+	assert.NotNil(t, cmd)
+	_, cmd = m.Update(trafficTickMsg{seq: m.ui.trafficSeq})
+	assert.NotNil(t, cmd)
+	assert.Nil(t, m.ui.forwards["test-id"].Requests, "forwards without HTTP logging show no activity")
+}
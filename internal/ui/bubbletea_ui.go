@@ -22,8 +22,10 @@ package ui
 import (
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -74,10 +76,16 @@ type BubbleTeaUI struct {
 	forwards            map[string]*ForwardStatus
 	benchmarkState      *BenchmarkState
 	httpLogSubscriber   HTTPLogSubscriber
+	trafficProvider     TrafficProvider
+	activityProvider    ActivityProvider
+	traceProvider       TraceProvider
 	disabledMap         map[string]bool
+	hiddenColumns       map[int]bool // forwards table columns shown or hidden with the 1-5 keys or hiddenColumns; others follow the width
 	toggleCallback      func(id string, enable bool)
 	httpLogCleanup      func()
 	httpLogState        *HTTPLogState
+	traceState          *traceState  // byte trace panel, nil when closed
+	detailState         *detailState // detail panel, nil when closed
 	errors              map[string]string
 	mutator             *config.Mutator
 	removeWizard        *RemoveWizardState
@@ -88,13 +96,32 @@ type BubbleTeaUI struct {
 	deleteConfirmID     string
 	deleteConfirmAlias  string
 	version             string
+	reloadBanner        string // transient config reload outcome, "" when hidden
+	mainFilter          string // main view filter text, "" shows every forward
+	reselectID          string // selected forward that was removed, selected again if added back
+	copyMessage         string // transient copy/open outcome in the footer
 	forwardOrder        []string
+	pausedIDs           []string // forwards stopped by pause-all, restarted on resume
 	viewMode            ViewMode
 	deleteConfirmCursor int
 	selectedIndex       int
+	reloadBannerSeq     int
+	trafficSeq          int
+	httpLogMaxEntries   int           // entries kept by the HTTP log view, 0 for the default
+	httpLogSlow         time.Duration // latency coloring thresholds, 0 for the defaults
+	httpLogVerySlow     time.Duration
+	traceSeq            int
 	mu                  sync.RWMutex
 	deleteConfirming    bool
 	updateAvailable     bool
+	reloadBannerFailed  bool
+	mainFilterActive    bool // filter text is being typed
+	mdnsEnabled         bool
+	showTraffic         bool // traffic columns are shown in the main view
+	showActivity        bool // request-rate sparkline column is shown in the main view
+	paused              bool // every forward was stopped with pause-all
+	readOnly            bool // the config must not be changed: add, edit, delete and paste are disabled
+	httpLogLatencyColor bool // HTTP log rows are colored by latency instead of status
 }
 
 // bubbletea model
@@ -138,6 +165,31 @@ func (ui *BubbleTeaUI) SetHTTPLogSubscriber(subscriber HTTPLogSubscriber) {
 	ui.httpLogSubscriber = subscriber
 }
 
+// SetHTTPLogMaxEntries sets how many entries the HTTP log view keeps, 0
+// for the default. An open log view drops its oldest entries beyond the
+// new cap, so it can be called again when the config is reloaded.
+func (ui *BubbleTeaUI) SetHTTPLogMaxEntries(n int) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.httpLogMaxEntries = n
+	if ui.httpLogState != nil {
+		ui.httpLogState.maxEntries = httpLogEntryCap(n)
+		ui.httpLogState.trimEntries()
+	}
+}
+
+// SetHTTPLogLatencyThresholds sets the latencies at which the HTTP log view
+// colors rows as slow and very slow when latency coloring is on, 0 for the
+// defaults.
+func (ui *BubbleTeaUI) SetHTTPLogLatencyThresholds(slow, verySlow time.Duration) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.httpLogSlow = slow
+	ui.httpLogVerySlow = verySlow
+}
+
 // SetUpdateAvailable sets the update notification to be displayed
 func (ui *BubbleTeaUI) SetUpdateAvailable(version, url string) {
 	ui.mu.Lock()
@@ -195,19 +247,37 @@ func (ui *BubbleTeaUI) AddForward(id string, fwd *config.Forward) {
 	}
 
 	status := &ForwardStatus{
-		Context:    fwd.GetContext(),
-		Namespace:  fwd.GetNamespace(),
-		Alias:      alias,
-		Type:       resourceType,
-		Resource:   resourceName,
-		HTTPLog:    fwd.HTTPLog,
-		RemotePort: fwd.Port,
-		LocalPort:  fwd.LocalPort,
-		Status:     "Starting",
+		Context:             fwd.GetContext(),
+		Namespace:           fwd.GetNamespace(),
+		Alias:               alias,
+		Type:                resourceType,
+		Resource:            resourceName,
+		ResourceSpec:        fwd.Resource,
+		Selector:            fwd.Selector,
+		Protocol:            fwd.GetProtocol(),
+		HTTPLog:             fwd.HTTPLog,
+		HealthCheck:         fwd.HealthCheck,
+		Enabled:             fwd.Enabled,
+		MDNSPublish:         fwd.MDNSPublish,
+		Tags:                fwd.Tags,
+		Container:           fwd.Container,
+		PortName:            fwd.PortName,
+		RemotePort:          fwd.Port,
+		LocalPort:           fwd.LocalPort,
+		Status:              "Starting",
+		ReconnectMaxRetries: fwd.ReconnectMaxRetries,
+		IdleTimeout:         fwd.IdleTimeout,
+		TCPKeepalive:        fwd.TCPKeepalive,
+		DialTimeout:         fwd.DialTimeout,
+		Scheme:              fwd.Scheme,
+		BindAddress:         fwd.BindAddress,
+		TraceBytes:          fwd.TraceBytes,
+		Order:               fwd.Order,
+		History:             []StatusEvent{{At: time.Now(), Status: "Starting"}},
 	}
 
 	ui.forwards[id] = status
-	ui.forwardOrder = append(ui.forwardOrder, id)
+	ui.insertForward(id)
 	ui.mu.Unlock()
 
 	if ui.program != nil {
@@ -219,7 +289,7 @@ func (ui *BubbleTeaUI) AddForward(id string, fwd *config.Forward) {
 func (ui *BubbleTeaUI) UpdateStatus(id string, status string) {
 	ui.mu.Lock()
 	if fwd, ok := ui.forwards[id]; ok {
-		fwd.Status = status
+		fwd.setStatus(status, time.Now())
 	}
 	// Only clear error when forward becomes Active again
 	// This keeps error visible during Reconnecting/Starting states
@@ -237,6 +307,9 @@ func (ui *BubbleTeaUI) UpdateStatus(id string, status string) {
 func (ui *BubbleTeaUI) SetError(id, msg string) {
 	ui.mu.Lock()
 	ui.errors[id] = msg
+	if fwd, ok := ui.forwards[id]; ok {
+		fwd.recordError(msg, time.Now())
+	}
 	ui.mu.Unlock()
 
 	if ui.program != nil {
@@ -244,6 +317,16 @@ func (ui *BubbleTeaUI) SetError(id, msg string) {
 	}
 }
 
+// SetPod records the pod a forward's tunnel connects to
+func (ui *BubbleTeaUI) SetPod(id, pod string) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	if fwd, ok := ui.forwards[id]; ok {
+		fwd.Pod = pod
+	}
+}
+
 // Remove removes a forward
 func (ui *BubbleTeaUI) Remove(id string) {
 	ui.mu.Lock()
@@ -252,25 +335,17 @@ func (ui *BubbleTeaUI) Remove(id string) {
 	// Clear any error associated with this forward
 	delete(ui.errors, id)
 
-	// Remove from order
-	removedIndex := -1
-	for i, fid := range ui.forwardOrder {
-		if fid == id {
-			removedIndex = i
-			ui.forwardOrder = append(ui.forwardOrder[:i], ui.forwardOrder[i+1:]...)
-			break
-		}
-	}
-
-	// Adjust selectedIndex if necessary
-	if removedIndex >= 0 {
-		// If we removed the selected item or an item before it, adjust
-		if ui.selectedIndex >= len(ui.forwardOrder) {
-			ui.selectedIndex = len(ui.forwardOrder) - 1
-		}
-		// Ensure selectedIndex is never negative
-		if ui.selectedIndex < 0 {
-			ui.selectedIndex = 0
+	// Remove from order, keeping the selection on the same forward. A
+	// reload can remove and add back the selected one, so it is selected
+	// again if it returns.
+	selected := ui.selectedForwardID()
+	if i := slices.Index(ui.forwardOrder, id); i >= 0 {
+		ui.forwardOrder = slices.Delete(ui.forwardOrder, i, i+1)
+		if selected == id {
+			ui.reselectID = id
+			ui.clampSelection()
+		} else {
+			ui.selectForward(selected)
 		}
 	}
 
@@ -287,8 +362,21 @@ func (ui *BubbleTeaUI) Remove(id string) {
 
 // Bubble Tea Model Implementation
 
+// uptimeRefreshInterval is how often the main view redraws so the uptime
+// column keeps counting.
+const uptimeRefreshInterval = time.Second
+
+// uptimeTickMsg redraws the main view to advance the uptime column
+type uptimeTickMsg struct{}
+
+func scheduleUptimeTick() tea.Cmd {
+	return tea.Tick(uptimeRefreshInterval, func(t time.Time) tea.Msg {
+		return uptimeTickMsg{}
+	})
+}
+
 func (m model) Init() tea.Cmd {
-	return nil
+	return scheduleUptimeTick()
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -316,6 +404,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleBenchmarkKeys(msg)
 		case ViewModeHTTPLog:
 			return m.handleHTTPLogKeys(msg)
+		case ViewModeTrace:
+			return m.handleTraceKeys(msg)
+		case ViewModeDetail:
+			return m.handleDetailKeys(msg)
 		}
 
 	// Forward management messages (always update main view data)
@@ -331,12 +423,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handlePodsLoaded(msg)
 	case ServicesLoadedMsg:
 		return m.handleServicesLoaded(msg)
+	case WorkloadsLoadedMsg:
+		return m.handleWorkloadsLoaded(msg)
 	case SelectorValidatedMsg:
 		return m.handleSelectorValidated(msg)
 	case PortCheckedMsg:
 		return m.handlePortChecked(msg)
 	case ForwardSavedMsg:
 		return m.handleForwardSaved(msg)
+	case BatchForwardsSavedMsg:
+		return m.handleBatchForwardsSaved(msg)
+	case QuickAddedMsg:
+		return m.handleQuickAdded(msg)
 	case ForwardsRemovedMsg:
 		return m.handleForwardsRemoved(msg)
 	case WizardCompleteMsg:
@@ -356,8 +454,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case HTTPLogEntryMsg:
 		return m.handleHTTPLogEntry(msg)
 
+	case ConfigReloadMsg:
+		return m.handleConfigReload(msg)
+
+	case clearReloadBannerMsg:
+		return m.handleClearReloadBanner(msg)
+
+	case trafficTickMsg:
+		return m.handleTrafficTick(msg)
+
+	case traceTickMsg:
+		return m.handleTraceTick(msg)
+
+	case uptimeTickMsg:
+		// The detail panel's traffic is refreshed with the uptime
+		m.ui.refreshDetail()
+		return m, scheduleUptimeTick()
+
+	case httpLogReplayMsg:
+		return m.handleHTTPLogReplay(msg)
+
 	case clearCopyMessageMsg:
 		m.ui.mu.Lock()
+		m.ui.copyMessage = ""
 		if m.ui.httpLogState != nil {
 			m.ui.httpLogState.copyMessage = ""
 		}
@@ -406,9 +525,15 @@ func (m model) View() string {
 	case ViewModeBenchmark:
 		modal := m.renderBenchmark()
 		return overlayContent(mainView, modal, termWidth, termHeight)
+	case ViewModeDetail:
+		modal := m.renderDetail()
+		return overlayContent(mainView, modal, termWidth, termHeight)
 	case ViewModeHTTPLog:
 		// HTTP Log is full-screen, don't overlay on main view
 		return m.renderHTTPLog()
+	case ViewModeTrace:
+		// The byte trace is full-screen like the HTTP log
+		return m.renderTrace()
 	default:
 		return mainView
 	}
@@ -420,42 +545,56 @@ type mainViewColors struct {
 	active     lipgloss.Color
 	warning    lipgloss.Color
 	errorColor lipgloss.Color
+	unhealthy  lipgloss.Color
 	muted      lipgloss.Color
 	selectedBg lipgloss.Color
 	selectedFg lipgloss.Color
 }
 
-// defaultMainViewColors returns the default color palette
+// defaultMainViewColors returns the main view palette of the active theme
 func defaultMainViewColors() mainViewColors {
 	return mainViewColors{
-		header:     lipgloss.Color("220"), // Yellow
-		active:     lipgloss.Color("46"),  // Green
-		warning:    lipgloss.Color("220"), // Yellow
-		errorColor: lipgloss.Color("196"), // Red
-		muted:      lipgloss.Color("240"), // Gray
-		selectedBg: lipgloss.Color("240"), // Gray background
-		selectedFg: lipgloss.Color("230"), // Light foreground
+		header:     activeTheme.Header,
+		active:     activeTheme.Active,
+		warning:    activeTheme.Warning,
+		errorColor: activeTheme.Error,
+		unhealthy:  activeTheme.Unhealthy,
+		muted:      activeTheme.Muted,
+		selectedBg: activeTheme.SelectedBg,
+		selectedFg: activeTheme.SelectedFg,
 	}
 }
 
 // keyBinding represents a keyboard shortcut and its description
 type keyBinding struct {
-	key  string
-	desc string
+	key      string
+	desc     string
+	disabled bool // shown grayed out, e.g. New in read-only mode
 }
 
-// mainViewKeyBindings returns the key bindings for the main view
-func mainViewKeyBindings() []keyBinding {
+// mainViewKeyBindings returns the key bindings for the main view. In
+// read-only mode the keys that change the config are disabled.
+func mainViewKeyBindings(readOnly bool) []keyBinding {
 	return []keyBinding{
-		{"↑↓/jk", "Navigate"},
-		{"PgUp/Dn", "Page"},
-		{"Space", "Toggle"},
-		{"n", "New"},
-		{"e", "Edit"},
-		{"d", "Delete"},
-		{"b", "Bench"},
-		{"l", "Logs"},
-		{"q", "Quit"},
+		{"↑↓/jk", "Navigate", false},
+		{"PgUp/Dn", "Page", false},
+		{"Space", "Toggle", false},
+		{"Enter", "Details", false},
+		{"n", "New", readOnly},
+		{"e", "Edit", readOnly},
+		{"d", "Delete", readOnly},
+		{"p", "Paste add", readOnly},
+		{"b", "Bench", false},
+		{"l", "Logs", false},
+		{"x", "Trace", false},
+		{"y/Y", "Copy addr/URL", false},
+		{"o", "Open", false},
+		{"t", "Traffic", false},
+		{"a", "Activity", false},
+		{"1-5", "Columns", false},
+		{"P", "Pause all", false},
+		{"/", "Filter", false},
+		{"q", "Quit", false},
 	}
 }
 
@@ -472,10 +611,19 @@ func (m model) renderMainView() string {
 	// Render title header
 	b.WriteString(m.renderTitle(colors.header))
 
+	// Render the outcome of the last config reload, if still showing
+	b.WriteString(m.renderReloadBanner(colors, termWidth))
+
+	// Render the filter being typed or applied, if any
+	b.WriteString(m.renderMainFilter())
+
 	// Render forwards table or empty message
-	if len(m.ui.forwardOrder) == 0 {
+	switch {
+	case len(m.ui.forwardOrder) == 0:
 		b.WriteString(m.renderEmptyMessage(colors.muted))
-	} else {
+	case len(m.ui.visibleForwards()) == 0:
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("No forwards match filter. (%d total forwards)", len(m.ui.forwardOrder))) + "\n")
+	default:
 		b.WriteString(m.renderForwardsTable(colors))
 	}
 
@@ -503,6 +651,13 @@ func (m model) getTermDimensions() (width, height int) {
 	return
 }
 
+// narrow reports whether the terminal is narrower than NarrowTermWidth, so
+// views use their compact layouts
+func (m model) narrow() bool {
+	width, _ := m.getTermDimensions()
+	return width < NarrowTermWidth
+}
+
 // renderTitle renders the title bar with version and optional update notification
 func (m model) renderTitle(headerColor lipgloss.Color) string {
 	var b strings.Builder
@@ -518,11 +673,20 @@ func (m model) renderTitle(headerColor lipgloss.Color) string {
 	// Show update notification if available
 	if m.ui.updateAvailable {
 		updateStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("42")). // Green
+			Foreground(successColor).
 			Bold(true)
 		updateMsg := fmt.Sprintf("  Update available: v%s", m.ui.updateVersion)
 		b.WriteString(updateStyle.Render(updateMsg))
 	}
+	if m.ui.paused {
+		pausedStyle := lipgloss.NewStyle().
+			Foreground(warningColor).
+			Bold(true)
+		b.WriteString(pausedStyle.Render("  ⏸ PAUSED (P to resume)"))
+	}
+	if m.ui.readOnly {
+		b.WriteString(mutedStyle.Render("  READ-ONLY"))
+	}
 	b.WriteString("\n\n")
 
 	return b.String()
@@ -533,6 +697,10 @@ func (m model) renderTitle(headerColor lipgloss.Color) string {
 func (m model) renderEmptyMessage(mutedColor lipgloss.Color) string {
 	mutedStyle := lipgloss.NewStyle().Foreground(mutedColor)
 	hintStyle := lipgloss.NewStyle().Foreground(highlightColor)
+	if m.ui.readOnly {
+		return mutedStyle.Render("No forwards configured") + "\n\n" +
+			hintStyle.Render("  Read-only mode: add forwards to the config file.") + "\n"
+	}
 	return mutedStyle.Render("No forwards configured") + "\n\n" +
 		hintStyle.Render("  Press ") + selectedStyle.Render("n") +
 		hintStyle.Render(" to add your first port forward.") + "\n"
@@ -543,14 +711,16 @@ func (m model) renderForwardsTable(colors mainViewColors) string {
 	var b strings.Builder
 
 	// Build table rows
-	rows := m.buildTableRows()
+	narrow := m.narrow()
+	cols := m.ui.visibleColumns(narrow)
+	rows := m.buildTableRows(cols, narrow)
 
 	// Create table with styling (no borders for cleaner look)
 	t := table.New().
 		Border(lipgloss.HiddenBorder()).
-		Headers("CONTEXT", "NAMESPACE", "ALIAS", "TYPE", "RESOURCE", "REMOTE", "LOCAL", "STATUS").
+		Headers(pickColumns(mainTableHeaders, cols)...).
 		Rows(rows...).
-		StyleFunc(m.createTableStyleFunc(colors))
+		StyleFunc(m.createTableStyleFunc(colors, cols))
 
 	b.WriteString(t.Render())
 	b.WriteString("\n")
@@ -558,11 +728,18 @@ func (m model) renderForwardsTable(colors mainViewColors) string {
 	return b.String()
 }
 
-// buildTableRows builds the data rows for the forwards table
-func (m model) buildTableRows() [][]string {
+// buildTableRows builds the data rows for the forwards table, with the
+// cells of cols, truncated tighter when narrow
+func (m model) buildTableRows(cols []int, narrow bool) [][]string {
 	var rows [][]string
+	now := time.Now()
 
-	for _, id := range m.ui.forwardOrder {
+	namespaceWidth, aliasWidth, resourceWidth := ColumnWidthNamespace, ColumnWidthAlias, ColumnWidthResource
+	if narrow {
+		namespaceWidth, aliasWidth, resourceWidth = ColumnWidthNamespaceNarrow, ColumnWidthAliasNarrow, ColumnWidthResourceNarrow
+	}
+
+	for i, id := range m.ui.visibleForwards() {
 		fwd, ok := m.ui.forwards[id]
 		if !ok {
 			continue
@@ -570,26 +747,67 @@ func (m model) buildTableRows() [][]string {
 
 		statusIcon, statusText := m.getStatusIconAndText(id, fwd)
 
+		uptimeText := formatUptime(fwd.ConnectedSince, now)
+		if m.ui.isForwardDisabled(id) {
+			uptimeText = "-"
+		}
+
 		localPortText := fmt.Sprintf("%d", fwd.LocalPort)
 		if fwd.Status == "Active" && !m.ui.isForwardDisabled(id) {
 			localPortText = hyperlink(fmt.Sprintf("http://127.0.0.1:%d", fwd.LocalPort), fmt.Sprintf("%d→", fwd.LocalPort))
 		}
 
-		rows = append(rows, []string{
+		row := []string{
 			truncate(fwd.Context, ColumnWidthContext),
-			truncate(fwd.Namespace, ColumnWidthNamespace),
-			truncate(fwd.Alias, ColumnWidthAlias),
+			truncate(fwd.Namespace, namespaceWidth),
+			truncate(fwd.Alias, aliasWidth),
 			truncate(fwd.Type, ColumnWidthType),
-			truncate(fwd.Resource, ColumnWidthResource),
-			fmt.Sprintf("%d", fwd.RemotePort),
+			truncate(fwd.Resource, resourceWidth),
+			remotePortText(fwd),
 			localPortText,
 			statusIcon + " " + statusText,
-		})
+			uptimeText,
+		}
+		row = append(row,
+			formatBytes(fwd.BytesSent),
+			formatBytes(fwd.BytesReceived),
+			formatBytes(fwd.SendRate),
+			formatBytes(fwd.ReceiveRate),
+			fmt.Sprintf("%d", fwd.Connections),
+			formatSetupTime(fwd.SetupTime),
+			formatStreamReuse(fwd.Streams, fwd.Tunnels),
+			sparkline(fwd.Requests),
+		)
+		row = pickColumns(row, cols)
+
+		// Without colors the selected row has no highlight, so mark it
+		if noColor && i == m.ui.selectedIndex {
+			row[0] = "▸ " + row[0]
+		}
+		rows = append(rows, row)
 	}
 
 	return rows
 }
 
+// remotePortText returns the remote port column text. Named ports resolved
+// from the service or pod at connect time show their name.
+func remotePortText(fwd *ForwardStatus) string {
+	if fwd.RemotePort == 0 && fwd.PortName != "" {
+		return fwd.PortName
+	}
+	return fmt.Sprintf("%d", fwd.RemotePort)
+}
+
+// statusKind strips the detail suffix from a status such as
+// "Reconnecting (4s, attempt 3/5)" so it can be matched by kind.
+func statusKind(status string) string {
+	if i := strings.Index(status, " ("); i >= 0 {
+		return status[:i]
+	}
+	return status
+}
+
 // getStatusIconAndText returns the appropriate status icon and text for a forward
 func (m model) getStatusIconAndText(id string, fwd *ForwardStatus) (icon, text string) {
 	icon = "●"
@@ -599,20 +817,24 @@ func (m model) getStatusIconAndText(id string, fwd *ForwardStatus) (icon, text s
 		return "○", "Disabled"
 	}
 
-	switch fwd.Status {
+	switch statusKind(fwd.Status) {
 	case "Starting":
 		icon = "○"
 	case "Reconnecting":
 		icon = "◐"
-	case "Error":
+	case "Error", "Failed", "Context not found", "Permission denied":
 		icon = "✗"
+	case "Unhealthy":
+		icon = "▲"
 	}
 
 	return icon, text
 }
 
 // createTableStyleFunc creates the style function for the forwards table
-func (m model) createTableStyleFunc(colors mainViewColors) func(row, col int) lipgloss.Style {
+// showing cols
+func (m model) createTableStyleFunc(colors mainViewColors, cols []int) func(row, col int) lipgloss.Style {
+	visible := m.ui.visibleForwards()
 	return func(row, col int) lipgloss.Style {
 		// Header row
 		if row == table.HeaderRow {
@@ -624,14 +846,18 @@ func (m model) createTableStyleFunc(colors mainViewColors) func(row, col int) li
 
 		baseStyle := lipgloss.NewStyle().Padding(0, 1)
 
-		if row >= 0 && row < len(m.ui.forwardOrder) {
-			id := m.ui.forwardOrder[row]
+		if row >= 0 && row < len(visible) {
+			id := visible[row]
 			fwd, ok := m.ui.forwards[id]
 			isSelected := row == m.ui.selectedIndex
 			isDisabled := m.ui.isForwardDisabled(id)
 
-			// Selected row gets background highlight
+			// Selected row gets background highlight, or reverse video
+			// when the theme has no colors
 			if isSelected {
+				if colors.selectedBg == "" {
+					return baseStyle.Reverse(true)
+				}
 				return baseStyle.
 					Background(colors.selectedBg).
 					Foreground(colors.selectedFg)
@@ -643,14 +869,16 @@ func (m model) createTableStyleFunc(colors mainViewColors) func(row, col int) li
 			}
 
 			// Status column gets colored based on status
-			if col == ColumnStatus && ok {
-				switch fwd.Status {
+			if col < len(cols) && cols[col] == ColumnStatus && ok {
+				switch statusKind(fwd.Status) {
 				case "Active":
 					return baseStyle.Foreground(colors.active)
 				case "Starting", "Reconnecting":
 					return baseStyle.Foreground(colors.warning)
-				case "Error":
+				case "Error", "Failed", "Context not found", "Permission denied":
 					return baseStyle.Foreground(colors.errorColor)
+				case "Unhealthy":
+					return baseStyle.Foreground(colors.unhealthy)
 				}
 			}
 
@@ -669,20 +897,24 @@ func (m model) renderErrorSection(termWidth int) string {
 	b.WriteString("\n\n")
 	errorHeaderStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("196"))
+		Foreground(errorColor)
 
 	b.WriteString(errorHeaderStyle.Render("Errors:"))
 	b.WriteString("\n")
 
 	errorLineStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("196")).
+		Foreground(errorColor).
 		Width(width).
 		MaxWidth(width)
 
 	for id, errMsg := range m.ui.errors {
 		// Find the forward to display its alias
 		if fwd, ok := m.ui.forwards[id]; ok {
-			b.WriteString(m.renderErrorLine(fwd.Alias, errMsg, width, errorLineStyle))
+			label := fwd.Alias
+			if !fwd.LastErrorAt.IsZero() {
+				label += " (" + fwd.LastErrorAt.Format("15:04:05") + ")"
+			}
+			b.WriteString(m.renderErrorLine(label, errMsg, width, errorLineStyle))
 		}
 	}
 
@@ -752,7 +984,7 @@ func (m model) renderFooterWithSpacing(termWidth, termHeight int, content *strin
 	}
 
 	// Add footer at bottom
-	footerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	footerStyle := lipgloss.NewStyle().Foreground(activeTheme.Muted)
 	b.WriteString("\n")
 	for i, line := range footerLines {
 		if i > 0 {
@@ -764,10 +996,18 @@ func (m model) renderFooterWithSpacing(termWidth, termHeight int, content *strin
 	return b.String()
 }
 
-// buildFooterLines builds the footer lines that fit within terminal width
+// buildFooterLines builds the footer lines that fit within terminal width.
+// Narrow terminals get a single line with as many bindings as fit.
 func (m model) buildFooterLines(termWidth int) []string {
-	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
-	bindings := mainViewKeyBindings()
+	keyStyle := lipgloss.NewStyle().Foreground(activeTheme.Header)
+	disabledStyle := lipgloss.NewStyle().Foreground(activeTheme.Muted).Faint(true)
+	bindings := mainViewKeyBindings(m.ui.readOnly)
+	renderBinding := func(binding keyBinding) string {
+		if binding.disabled {
+			return disabledStyle.Render(binding.key + ": " + binding.desc)
+		}
+		return keyStyle.Render(binding.key) + ": " + binding.desc
+	}
 
 	var footerLines []string
 	var currentLine strings.Builder
@@ -776,15 +1016,24 @@ func (m model) buildFooterLines(termWidth int) []string {
 	// Calculate how much space we need for the total count suffix.
 	// Use lipgloss.Width for true display width (the "│" glyph is 3 bytes / 1 col).
 	totalSuffix := fmt.Sprintf("  │  Total: %d", len(m.ui.forwardOrder))
+	if m.ui.mainFilter != "" {
+		totalSuffix = fmt.Sprintf("  │  Showing: %d/%d", len(m.ui.visibleForwards()), len(m.ui.forwardOrder))
+	}
+	if m.ui.copyMessage != "" {
+		totalSuffix += "  │  " + m.ui.copyMessage
+	}
 	totalSuffixLen := lipgloss.Width(totalSuffix)
 
 	// Available width (account for some margin)
 	availableWidth := termWidth - 4
 
+	if termWidth < NarrowTermWidth {
+		return []string{compactFooterLine(bindings, renderBinding, availableWidth-totalSuffixLen) + totalSuffix}
+	}
+
 	for i, binding := range bindings {
 		// Build this binding's text
-		keyRendered := keyStyle.Render(binding.key)
-		bindingText := keyRendered + ": " + binding.desc
+		bindingText := renderBinding(binding)
 		// True display width: strips ANSI and counts wide/unicode glyphs (e.g. ↑↓)
 		// correctly, where len() would over-count multibyte runes and wrap early.
 		bindingVisualLen := lipgloss.Width(bindingText)
@@ -825,6 +1074,25 @@ func (m model) buildFooterLines(termWidth int) []string {
 	return footerLines
 }
 
+// compactFooterLine renders the bindings that fit in width on one line,
+// dropping those that do not but always keeping the last one, quit.
+func compactFooterLine(bindings []keyBinding, render func(keyBinding) string, width int) string {
+	last := render(bindings[len(bindings)-1])
+	remaining := width - lipgloss.Width(last)
+
+	var line strings.Builder
+	for _, binding := range bindings[:len(bindings)-1] {
+		text := render(binding) + "  "
+		if lipgloss.Width(text) > remaining {
+			continue
+		}
+		line.WriteString(text)
+		remaining -= lipgloss.Width(text)
+	}
+	line.WriteString(last)
+	return line.String()
+}
+
 // wrapText wraps text to the specified width, breaking at word boundaries
 func wrapText(text string, width int) string {
 	if len(text) <= width {
@@ -863,16 +1131,18 @@ func (ui *BubbleTeaUI) moveSelection(delta int) {
 	ui.mu.Lock()
 	defer ui.mu.Unlock()
 
-	if len(ui.forwardOrder) == 0 {
+	visible := ui.visibleForwards()
+	if len(visible) == 0 {
 		return
 	}
 
+	ui.reselectID = ""
 	ui.selectedIndex += delta
 	if ui.selectedIndex < 0 {
 		ui.selectedIndex = 0
 	}
-	if ui.selectedIndex >= len(ui.forwardOrder) {
-		ui.selectedIndex = len(ui.forwardOrder) - 1
+	if ui.selectedIndex >= len(visible) {
+		ui.selectedIndex = len(visible) - 1
 	}
 }
 
@@ -899,17 +1169,20 @@ func (m model) renderDeleteConfirmation() string {
 		Padding(0, 1)
 
 	buttonSelectedStyle := lipgloss.NewStyle().
-		Background(primaryColor).          // Pink/Magenta background
-		Foreground(lipgloss.Color("230")). // Light yellow text
+		Background(primaryColor).
+		Foreground(activeTheme.OnPrimary).
 		Bold(true).
 		Padding(0, 1)
+	if primaryColor == "" {
+		buttonSelectedStyle = buttonSelectedStyle.Reverse(true)
+	}
 
 	buttonUnselectedStyle := lipgloss.NewStyle().
 		Foreground(mutedColor). // Gray
 		Padding(0, 1)
 
 	deleteInfoStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("252")). // Light gray for info text
+		Foreground(activeTheme.Text).
 		Italic(true)
 
 	// Title
@@ -921,15 +1194,23 @@ func (m model) renderDeleteConfirmation() string {
 	b.WriteString(deleteInfoStyle.Render("  " + m.ui.deleteConfirmAlias))
 	b.WriteString("\n\n")
 
-	// Buttons
+	// Buttons; without colors the selected one is bracketed instead
+	yes, no := " Yes ", " No "
+	if noColor {
+		if m.ui.deleteConfirmCursor == 0 {
+			yes = "[Yes]"
+		} else {
+			no = "[No]"
+		}
+	}
 	if m.ui.deleteConfirmCursor == 0 {
-		b.WriteString(buttonSelectedStyle.Render(" Yes "))
+		b.WriteString(buttonSelectedStyle.Render(yes))
 		b.WriteString("  ")
-		b.WriteString(buttonUnselectedStyle.Render(" No "))
+		b.WriteString(buttonUnselectedStyle.Render(no))
 	} else {
-		b.WriteString(buttonUnselectedStyle.Render(" Yes "))
+		b.WriteString(buttonUnselectedStyle.Render(yes))
 		b.WriteString("  ")
-		b.WriteString(buttonSelectedStyle.Render(" No "))
+		b.WriteString(buttonSelectedStyle.Render(no))
 	}
 
 	b.WriteString("\n\n")
@@ -948,13 +1229,16 @@ func (m model) renderDeleteConfirmation() string {
 func (ui *BubbleTeaUI) toggleSelected() {
 	ui.mu.Lock()
 
-	if ui.selectedIndex < 0 || ui.selectedIndex >= len(ui.forwardOrder) {
+	visible := ui.visibleForwards()
+	if ui.selectedIndex < 0 || ui.selectedIndex >= len(visible) {
 		ui.mu.Unlock()
 		return
 	}
 
-	selectedID := ui.forwardOrder[ui.selectedIndex]
-	currentlyDisabled := ui.disabledMap[selectedID]
+	selectedID := visible[ui.selectedIndex]
+	// Forwards disabled in config arrive with a "Disabled" status rather
+	// than a disabledMap entry, so check both.
+	currentlyDisabled := ui.isForwardDisabled(selectedID)
 	newState := !currentlyDisabled
 	ui.disabledMap[selectedID] = newState
 
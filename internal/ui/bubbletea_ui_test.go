@@ -289,6 +289,14 @@ func TestBubbleTeaUI_Remove_AdjustsSelectedIndex(t *testing.T) {
 			expectedIndex:     2, // Adjusts down
 			expectedRemaining: 3,
 		},
+		{
+			name:              "remove item before selected in the middle",
+			forwards:          []string{"a", "b", "c", "d"},
+			selectedIndex:     2,
+			removeID:          "a",
+			expectedIndex:     1, // Still points to c
+			expectedRemaining: 3,
+		},
 	}
 
 	for _, tt := range tests {
@@ -772,6 +780,7 @@ func TestConstants(t *testing.T) {
 	assert.Equal(t, 120, DefaultTermWidth)
 	assert.Equal(t, 40, DefaultTermHeight)
 	assert.Equal(t, 7, ColumnStatus)
+	assert.Equal(t, 8, ColumnUptime)
 	assert.Equal(t, 14, ColumnWidthContext)
 	assert.Equal(t, 16, ColumnWidthNamespace)
 	assert.Equal(t, 18, ColumnWidthAlias)
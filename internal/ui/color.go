@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// noColor is set once colors are disabled; both UIs then print plain text.
+var noColor bool
+
+// NoColorFromEnv reports whether the NO_COLOR environment variable asks for
+// plain output. Any non-empty value counts, per https://no-color.org.
+func NoColorFromEnv() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// DisableColor switches every UI to plain text: lipgloss drops colors and
+// text attributes, the table UI drops its ANSI status colors, and terminal
+// hyperlinks are no longer emitted. Call it before any UI starts.
+func DisableColor() {
+	noColor = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+	applyTheme(noColorTheme(activeTheme.Name))
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+// disableColorForTest turns colors off and restores them when t ends.
+func disableColorForTest(t *testing.T) {
+	t.Helper()
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		noColor = false
+		lipgloss.SetColorProfile(profile)
+		SetTheme(DarkTheme())
+	})
+	DisableColor()
+}
+
+func TestNoColorFromEnv(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	assert.False(t, NoColorFromEnv(), "an empty NO_COLOR is ignored")
+
+	t.Setenv("NO_COLOR", "1")
+	assert.True(t, NoColorFromEnv())
+}
+
+func TestDisableColor_PlainText(t *testing.T) {
+	disableColorForTest(t)
+
+	assert.Empty(t, activeTheme.Primary)
+	assert.Equal(t, "8080→", hyperlink("http://127.0.0.1:8080", "8080→"))
+	assert.Equal(t, "✓ Active", formatStatusWithIndicator("Active"))
+	assert.Equal(t, "bold", selectedStyle.Render("bold"), "no escape sequences are emitted")
+}
+
+func TestDisableColor_MarksSelection(t *testing.T) {
+	disableColorForTest(t)
+
+	m := newTestModelWithForward()
+	assert.Contains(t, m.renderMainView(), "▸ ", "selected row is marked without a highlight")
+
+	m.ui.deleteConfirming = true
+	m.ui.deleteConfirmAlias = "my-app"
+	view := m.renderDeleteConfirmation()
+	assert.Contains(t, view, "[Yes]")
+	assert.NotContains(t, view, "[No]")
+}
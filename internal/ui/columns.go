@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+)
+
+// mainTableHeaders are the headers of the forwards table, in the order of
+// the Column* indices, followed by the traffic columns and the activity
+// column.
+var mainTableHeaders = []string{
+	"CONTEXT", "NAMESPACE", "ALIAS", "TYPE", "RESOURCE", "REMOTE", "LOCAL", "STATUS", "UPTIME",
+	"SENT", "RECV", "SEND/S", "RECV/S", "CONNS", "SETUP", "STREAMS",
+	"ACTIVITY",
+}
+
+const (
+	// baseColumnCount is the number of columns before the traffic columns.
+	baseColumnCount = ColumnUptime + 1
+	// activityColumn is the request-rate sparkline column, after the
+	// traffic columns.
+	activityColumn = baseColumnCount + 7
+)
+
+// hideableColumns are the columns that can be hidden, in the order of the
+// keys that toggle them: "1" toggles CONTEXT and "5" UPTIME. The alias,
+// ports and status identify a forward and are always shown.
+var hideableColumns = []int{ColumnContext, ColumnNamespace, ColumnType, ColumnResource, ColumnUptime}
+
+// narrowHiddenColumns are hidden on terminals narrower than NarrowTermWidth
+// unless shown with their key.
+var narrowHiddenColumns = []int{ColumnContext, ColumnType, ColumnUptime}
+
+// SetHiddenColumns hides the named columns of the forwards table, e.g.
+// "namespace", as listed by hiddenColumns in the config. Names are the
+// lowercase headers; unknown ones are ignored.
+func (ui *BubbleTeaUI) SetHiddenColumns(names []string) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.hiddenColumns = make(map[int]bool)
+	for _, name := range names {
+		for _, col := range hideableColumns {
+			if strings.EqualFold(name, mainTableHeaders[col]) {
+				ui.hiddenColumns[col] = true
+			}
+		}
+	}
+}
+
+// columnForKey returns the hideable column toggled by key, "1" to "5".
+func columnForKey(key string) (col int, ok bool) {
+	if len(key) != 1 || key[0] < '1' || int(key[0]-'1') >= len(hideableColumns) {
+		return 0, false
+	}
+	return hideableColumns[key[0]-'1'], true
+}
+
+// toggleColumn shows or hides col, as currently laid out for narrow.
+func (ui *BubbleTeaUI) toggleColumn(col int, narrow bool) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	hidden := ui.columnHidden(col, narrow)
+	if ui.hiddenColumns == nil {
+		ui.hiddenColumns = make(map[int]bool)
+	}
+	ui.hiddenColumns[col] = !hidden
+}
+
+// columnHidden reports whether col is hidden: as last toggled or
+// configured, or else by default for the terminal width. Callers must hold
+// ui.mu.
+func (ui *BubbleTeaUI) columnHidden(col int, narrow bool) bool {
+	if hidden, set := ui.hiddenColumns[col]; set {
+		return hidden
+	}
+	return narrow && slices.Contains(narrowHiddenColumns, col)
+}
+
+// visibleColumns returns the columns to render: those not hidden, then the
+// traffic and activity columns while shown. Callers must hold ui.mu.
+func (ui *BubbleTeaUI) visibleColumns(narrow bool) []int {
+	cols := make([]int, 0, len(mainTableHeaders))
+	for col := 0; col < baseColumnCount; col++ {
+		if !ui.columnHidden(col, narrow) {
+			cols = append(cols, col)
+		}
+	}
+	if ui.showTraffic {
+		for col := baseColumnCount; col < activityColumn; col++ {
+			cols = append(cols, col)
+		}
+	}
+	if ui.showActivity {
+		cols = append(cols, activityColumn)
+	}
+	return cols
+}
+
+// pickColumns returns the cells of row in cols.
+func pickColumns(row []string, cols []int) []string {
+	picked := make([]string, 0, len(cols))
+	for _, col := range cols {
+		picked = append(picked, row[col])
+	}
+	return picked
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToggleColumns(t *testing.T) {
+	m := newTestModelWithForward()
+	m.ui.forwards["test-id"].Namespace = "payments"
+	press := func(key string) {
+		m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+
+	view := m.renderMainView()
+	assert.Contains(t, view, "NAMESPACE")
+	assert.Contains(t, view, "payments")
+
+	press("2")
+	view = m.renderMainView()
+	assert.NotContains(t, view, "NAMESPACE")
+	assert.NotContains(t, view, "payments")
+	assert.Contains(t, view, "CONTEXT")
+
+	press("2")
+	assert.Contains(t, m.renderMainView(), "NAMESPACE")
+
+	press("9")
+	assert.Len(t, m.ui.visibleColumns(false), baseColumnCount, "keys past the hideable columns do nothing")
+}
+
+func TestSetHiddenColumns(t *testing.T) {
+	m := newTestModelWithForward()
+	m.ui.SetHiddenColumns([]string{"context", "Type", "uptime", "status", "bogus"})
+
+	assert.Equal(t, []int{ColumnNamespace, ColumnAlias, ColumnResource, ColumnRemote, ColumnLocal, ColumnStatus}, m.ui.visibleColumns(false),
+		"status cannot be hidden and unknown names are ignored")
+
+	m.ui.showTraffic = true
+	cols := m.ui.visibleColumns(false)
+	assert.Equal(t, activityColumn-3, len(cols), "traffic columns follow the base ones")
+
+	view := m.renderMainView()
+	assert.NotContains(t, view, "CONTEXT")
+	assert.NotContains(t, view, "UPTIME")
+	assert.Contains(t, view, "STREAMS")
+}
+
+func TestColumnForKey(t *testing.T) {
+	col, ok := columnForKey("1")
+	assert.True(t, ok)
+	assert.Equal(t, ColumnContext, col)
+
+	col, ok = columnForKey("5")
+	assert.True(t, ok)
+	assert.Equal(t, ColumnUptime, col)
+
+	for _, key := range []string{"0", "6", "12", "a"} {
+		_, ok = columnForKey(key)
+		assert.False(t, ok, key)
+	}
+}
+
+func TestNarrowLayout(t *testing.T) {
+	m := newTestModelWithForward()
+	m.termWidth = 80
+	m.ui.forwards["test-id"].Namespace = "payments-production"
+	m.ui.forwards["test-id"].Resource = "service/payments-gateway"
+
+	view := m.renderMainView()
+	assert.NotContains(t, view, "CONTEXT")
+	assert.NotContains(t, view, "UPTIME")
+	assert.Contains(t, view, "NAMESPACE")
+	assert.Contains(t, view, "payment...", "values are truncated tighter")
+	for _, line := range strings.Split(view, "\n") {
+		assert.LessOrEqual(t, lipgloss.Width(line), 80, line)
+	}
+
+	// A column hidden by the width is shown with its key, and stays shown
+	// once the terminal is wide again
+	m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	assert.Contains(t, m.renderMainView(), "CONTEXT")
+	m.termWidth = 120
+	assert.Contains(t, m.renderMainView(), "TYPE")
+	m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	assert.NotContains(t, m.renderMainView(), "CONTEXT")
+}
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -200,6 +202,7 @@ func TestCheckPortCmd_ConfigConflict(t *testing.T) {
 	assert.Equal(t, 8080, portMsg.port)
 	assert.False(t, portMsg.available, "Port should not be available (in config)")
 	assert.Contains(t, portMsg.message, "already assigned")
+	assert.Equal(t, "test-ctx/default/pod/my-app:8080", portMsg.usedBy)
 }
 
 // TestCheckPortCmd_ExcludeID_AllowsKeepingOwnPort verifies that in edit mode
@@ -238,6 +241,26 @@ func TestCheckPortCmd_ExcludeID_AllowsKeepingOwnPort(t *testing.T) {
 		"excludeID should suppress the config self-conflict, but got %q", portMsg.message)
 }
 
+// TestCheckPortCmd_PortHeldByProcess verifies an OS-level conflict names the
+// process holding the port rather than the raw bind error.
+func TestCheckPortCmd_PortHeldByProcess(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer func() {
+		_ = listener.Close() // Error ignored - best effort cleanup
+	}()
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	msg := checkPortCmd(port, "/nonexistent/path/.kportal.yaml", "")()
+
+	portMsg, ok := msg.(PortCheckedMsg)
+	require.True(t, ok, "Expected PortCheckedMsg")
+	assert.False(t, portMsg.available)
+	pid := fmt.Sprintf("PID %d", os.Getpid())
+	assert.Contains(t, portMsg.usedBy, pid)
+	assert.Contains(t, portMsg.message, pid)
+}
+
 // TestCheckPortCmd_InvalidConfig tests behavior with invalid config file
 func TestCheckPortCmd_InvalidConfig(t *testing.T) {
 	// Use a non-existent config path
@@ -291,7 +314,7 @@ func TestRunBenchmarkCmd_Cancellation(t *testing.T) {
 
 	progressCh := make(chan BenchmarkProgressMsg, 100)
 
-	cmd := runBenchmarkCmd(ctx, "fwd-123", 59997, "/", "GET", 1, 10, progressCh)
+	cmd := runBenchmarkCmd(ctx, "fwd-123", 59997, "/", "GET", nil, nil, 1, 10, 0, progressCh)
 
 	// Run with timeout to prevent hanging
 	done := make(chan bool, 1)
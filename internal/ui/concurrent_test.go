@@ -212,7 +212,7 @@ func TestConcurrent_AddRemoveAndUpdate(t *testing.T) {
 
 // TestConcurrent_HTTPLogEntries tests concurrent HTTP log entry additions
 func TestConcurrent_HTTPLogEntries(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 
 	var wg sync.WaitGroup
 	var mu sync.Mutex // Simulate the UI lock for entries
@@ -240,7 +240,7 @@ func TestConcurrent_HTTPLogEntries(t *testing.T) {
 
 // TestConcurrent_FilterWhileAdding tests filtering while entries are being added
 func TestConcurrent_FilterWhileAdding(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.filterMode = HTTPLogFilterErrors
 
 	var wg sync.WaitGroup
@@ -7,6 +7,10 @@ const (
 
 	// DefaultTermHeight is the fallback terminal height when not detected
 	DefaultTermHeight = 40
+
+	// NarrowTermWidth is the width below which the main view and the HTTP
+	// log switch to their compact layouts, e.g. in a split pane
+	NarrowTermWidth = 100
 )
 
 // Table column constants
@@ -20,6 +24,7 @@ const (
 	ColumnRemote    = 5
 	ColumnLocal     = 6
 	ColumnStatus    = 7
+	ColumnUptime    = 8
 
 	// Column widths for truncation
 	ColumnWidthContext   = 14
@@ -28,6 +33,11 @@ const (
 	ColumnWidthType      = 8
 	ColumnWidthResource  = 20
 
+	// Column widths for truncation on narrow terminals
+	ColumnWidthNamespaceNarrow = 10
+	ColumnWidthAliasNarrow     = 14
+	ColumnWidthResourceNarrow  = 16
+
 	// Error display widths
 	ErrorDisplayWidth = 118 // Slightly less than table width (120) for padding
 )
@@ -54,4 +64,9 @@ const (
 	// (prefix + the four fixed columns and their separators), used to size the
 	// remaining space for the path column responsively.
 	HTTPLogFixedCols = 48
+
+	// HTTPLogCompactRowFormat is the first line of an entry in the compact
+	// layout used on narrow terminals (TIME, METHOD, STATUS, LATENCY); the
+	// path follows on a line of its own.
+	HTTPLogCompactRowFormat = "%-10s  %-7s  %-6s  %s"
 )
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// copyMessageDuration is how long copy confirmations stay in the footer.
+const copyMessageDuration = 2 * time.Second
+
+// writeClipboard copies text to the system clipboard. Tests replace it.
+var writeClipboard = copyToClipboard
+
+// SetMDNSEnabled tells the UI whether forwards are published as
+// <alias>.local hostnames, so copied addresses can use them.
+func (ui *BubbleTeaUI) SetMDNSEnabled(enabled bool) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.mdnsEnabled = enabled
+}
+
+// forwardAddress returns the host:port a forward is reachable on: its
+// <alias>.local hostname when published over mDNS, localhost otherwise.
+// With withScheme set the address is returned as a URL using the forward's
+// configured scheme, http by default.
+// Caller must hold ui.mu.
+func (ui *BubbleTeaUI) forwardAddress(fwd *ForwardStatus, withScheme bool) string {
+	host := "localhost"
+	if ui.mdnsEnabled && fwd.Alias != "" && (fwd.MDNSPublish == nil || *fwd.MDNSPublish) {
+		host = fwd.Alias + ".local"
+	}
+
+	address := fmt.Sprintf("%s:%d", host, fwd.LocalPort)
+	if withScheme {
+		scheme := fwd.Scheme
+		if scheme == "" {
+			scheme = config.SchemeHTTP
+		}
+		return scheme + "://" + address
+	}
+	return address
+}
+
+// showFooterMessage shows message in the footer for copyMessageDuration.
+func (m model) showFooterMessage(message string) (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	m.ui.copyMessage = message
+	m.ui.mu.Unlock()
+
+	return m, tea.Tick(copyMessageDuration, func(t time.Time) tea.Msg {
+		return clearCopyMessageMsg{}
+	})
+}
+
+// copySelectedAddress copies the selected forward's address to the
+// clipboard and shows the outcome in the footer.
+func (m model) copySelectedAddress(withScheme bool) (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	visible := m.ui.visibleForwards()
+	if m.ui.selectedIndex < 0 || m.ui.selectedIndex >= len(visible) {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+	fwd, ok := m.ui.forwards[visible[m.ui.selectedIndex]]
+	if !ok {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+
+	address := m.ui.forwardAddress(fwd, withScheme)
+	m.ui.mu.Unlock()
+
+	// The clipboard tool runs outside the lock so rendering is not blocked
+	message := "Copied " + address
+	if err := writeClipboard(address); err != nil {
+		message = "Clipboard unavailable"
+	}
+
+	m.ui.mu.Lock()
+	m.ui.copyMessage = message
+	m.ui.mu.Unlock()
+
+	return m, tea.Tick(copyMessageDuration, func(t time.Time) tea.Msg {
+		return clearCopyMessageMsg{}
+	})
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubClipboard records clipboard writes, failing them with err if set.
+func stubClipboard(t *testing.T, err error) *[]string {
+	t.Helper()
+	var copied []string
+	original := writeClipboard
+	writeClipboard = func(text string) error {
+		copied = append(copied, text)
+		return err
+	}
+	t.Cleanup(func() { writeClipboard = original })
+	return &copied
+}
+
+func TestForwardAddress(t *testing.T) {
+	optOut := false
+	tests := []struct {
+		fwd         ForwardStatus
+		name        string
+		want        string
+		mdnsEnabled bool
+		withScheme  bool
+	}{
+		{ForwardStatus{Alias: "api", LocalPort: 8080}, "localhost", "localhost:8080", false, false},
+		{ForwardStatus{Alias: "api", LocalPort: 8080}, "url", "http://localhost:8080", false, true},
+		{ForwardStatus{Alias: "api", LocalPort: 8080}, "mdns", "api.local:8080", true, false},
+		{ForwardStatus{Alias: "api", LocalPort: 8080}, "mdns url", "http://api.local:8080", true, true},
+		{ForwardStatus{Alias: "api", LocalPort: 8443, Scheme: "https"}, "https url", "https://localhost:8443", false, true},
+		{ForwardStatus{Alias: "api", LocalPort: 8443, Scheme: "https"}, "https address", "localhost:8443", false, false},
+		{ForwardStatus{Alias: "api", LocalPort: 8080, MDNSPublish: &optOut}, "mdns opt-out", "localhost:8080", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui := NewBubbleTeaUI(nil, "1.0.0")
+			ui.SetMDNSEnabled(tt.mdnsEnabled)
+			assert.Equal(t, tt.want, ui.forwardAddress(&tt.fwd, tt.withScheme))
+		})
+	}
+}
+
+func TestCopySelectedAddress(t *testing.T) {
+	copied := stubClipboard(t, nil)
+	m := newTestModelWithForward()
+
+	_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	require.NotNil(t, cmd, "confirmation should schedule its own dismissal")
+	_, _ = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Y")})
+
+	assert.Equal(t, []string{"localhost:8080", "http://localhost:8080"}, *copied)
+	assert.Contains(t, m.renderMainView(), "Copied http://localhost:8080")
+
+	_, _ = m.Update(clearCopyMessageMsg{})
+	assert.NotContains(t, m.renderMainView(), "Copied")
+}
+
+func TestCopySelectedAddress_ClipboardUnavailable(t *testing.T) {
+	stubClipboard(t, errors.New("no clipboard tool found"))
+	m := newTestModelWithForward()
+
+	_, _ = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.Equal(t, "Clipboard unavailable", m.ui.copyMessage)
+}
+
+func TestCopySelectedAddress_NoForwards(t *testing.T) {
+	copied := stubClipboard(t, nil)
+	m := newTestModel()
+
+	_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	assert.Nil(t, cmd)
+	assert.Empty(t, *copied)
+}
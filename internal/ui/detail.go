@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// detailState holds the detail panel of one forward
+type detailState struct {
+	traffic    TrafficSample
+	forwardID  string
+	hasTraffic bool // the provider reported traffic on the last refresh
+}
+
+// openDetail opens the detail panel for the selected forward. Its traffic
+// is refreshed with every uptime tick until closed.
+func (m model) openDetail() (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	// Don't open the panel if another modal is active
+	if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil || m.ui.traceState != nil || m.ui.detailState != nil {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+
+	visible := m.ui.visibleForwards()
+	if m.ui.selectedIndex < 0 || m.ui.selectedIndex >= len(visible) {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+	selectedID := visible[m.ui.selectedIndex]
+	if _, ok := m.ui.forwards[selectedID]; !ok {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+
+	m.ui.viewMode = ViewModeDetail
+	m.ui.detailState = &detailState{forwardID: selectedID}
+	m.ui.mu.Unlock()
+
+	m.ui.refreshDetail()
+	return m, nil
+}
+
+// refreshDetail reads the open detail panel's traffic from the provider
+func (ui *BubbleTeaUI) refreshDetail() {
+	ui.mu.RLock()
+	provider := ui.trafficProvider
+	state := ui.detailState
+	ui.mu.RUnlock()
+
+	if provider == nil || state == nil {
+		return
+	}
+
+	// Read outside the lock; the provider takes the manager's locks
+	sample, ok := provider(state.forwardID)
+
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if ui.detailState != state {
+		return
+	}
+	state.traffic = sample
+	state.hasTraffic = ok
+}
+
+// handleDetailKeys handles keyboard input in the detail panel
+func (m model) handleDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "esc", "q", "enter":
+		m.ui.mu.Lock()
+		m.ui.viewMode = ViewModeMain
+		m.ui.detailState = nil
+		m.ui.mu.Unlock()
+		return m, tea.ClearScreen
+	}
+	return m, nil
+}
+
+// renderDetail renders the detail panel of the selected forward
+func (m model) renderDetail() string {
+	m.ui.mu.RLock()
+	defer m.ui.mu.RUnlock()
+
+	state := m.ui.detailState
+	if state == nil {
+		return ""
+	}
+	fwd, ok := m.ui.forwards[state.forwardID]
+	if !ok {
+		return wizardBoxStyle.Render(renderHeader("Forward Details", "") +
+			mutedStyle.Render("This forward was removed.") + "\n\n" +
+			wrapHelpText("Esc: Close", wizardHelpWidth(m.termWidth)))
+	}
+
+	var b strings.Builder
+	b.WriteString(renderHeader("Forward Details", breadcrumbStyle.Render(fwd.Alias)))
+
+	row := func(label, value string) {
+		if value == "" {
+			return
+		}
+		fmt.Fprintf(&b, "%s %s\n", mutedStyle.Render(fmt.Sprintf("%-13s", label+":")), value)
+	}
+
+	row("ID", state.forwardID)
+	row("Context", fwd.Context)
+	row("Namespace", fwd.Namespace)
+	row("Resource", fwd.ResourceSpec)
+	row("Selector", fwd.Selector)
+	row("Container", fwd.Container)
+	row("Pod", fwd.Pod)
+	row("Remote port", remotePortText(fwd))
+	row("Local", m.ui.forwardAddress(fwd, true))
+	row("Bind address", fwd.BindAddress)
+	row("Protocol", fwd.Protocol)
+	if len(fwd.Tags) > 0 {
+		row("Tags", strings.Join(fwd.Tags, ", "))
+	}
+	if fwd.HTTPLog != nil && fwd.HTTPLog.Enabled {
+		row("HTTP log", "on, press l in the list to view it")
+	}
+
+	now := time.Now()
+	_, statusText := m.getStatusIconAndText(state.forwardID, fwd)
+	b.WriteString("\n")
+	row("Status", statusText)
+	if !fwd.ConnectedSince.IsZero() {
+		row("Uptime", formatUptime(fwd.ConnectedSince, now))
+	}
+	if fwd.LastError != "" {
+		row("Last error", errorStyle.Render(fwd.LastError)+mutedStyle.Render(" at "+fwd.LastErrorAt.Format("15:04:05")))
+	}
+
+	if state.hasTraffic {
+		b.WriteString("\n")
+		row("Traffic", fmt.Sprintf("%s sent, %s received", formatBytes(state.traffic.Sent), formatBytes(state.traffic.Received)))
+		row("Rate", fmt.Sprintf("%s/s sent, %s/s received", formatBytes(state.traffic.SendRate), formatBytes(state.traffic.ReceiveRate)))
+		row("Connections", fmt.Sprintf("%d open, setup %s", state.traffic.Connections, formatSetupTime(state.traffic.SetupTime)))
+		row("Stream reuse", formatStreamReuse(state.traffic.Streams, state.traffic.Tunnels))
+	}
+
+	if len(fwd.History) > 0 {
+		b.WriteString("\n")
+		b.WriteString(mutedStyle.Render("Status history:"))
+		b.WriteString("\n")
+		for i := len(fwd.History) - 1; i >= 0; i-- {
+			event := fwd.History[i]
+			fmt.Fprintf(&b, "  %s  %s\n", mutedStyle.Render(event.At.Format("15:04:05")), event.Status)
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(wrapHelpText("Esc/Enter: Close", wizardHelpWidth(m.termWidth)))
+
+	return wizardBoxStyle.Render(b.String())
+}
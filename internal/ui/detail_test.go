@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+func TestDetailPanel(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.AddForward("api:8080", &config.Forward{
+		Resource:    "service/payments-gateway-with-a-long-name",
+		Port:        80,
+		LocalPort:   8080,
+		Alias:       "api",
+		BindAddress: "127.0.0.2",
+		Tags:        []string{"backend"},
+		HTTPLog:     &config.HTTPLogSpec{Enabled: true},
+	})
+	ui.SetTrafficProvider(func(id string) (TrafficSample, bool) {
+		return TrafficSample{Sent: 2048, Connections: 3, Streams: 12, Tunnels: 2}, id == "api:8080"
+	})
+	ui.SetPod("api:8080", "payments-7d9f-abcde")
+	ui.UpdateStatus("api:8080", "Active")
+	ui.SetError("api:8080", "connection refused")
+	ui.UpdateStatus("api:8080", "Reconnecting (2s, attempt 1)")
+	m := model{ui: ui, termWidth: 120, termHeight: 50}
+
+	_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	require.Equal(t, ViewModeDetail, ui.viewMode, "enter opens the panel instead of toggling")
+	assert.False(t, ui.isForwardDisabled("api:8080"))
+
+	view := m.View()
+	assert.Contains(t, view, "Forward Details")
+	assert.Contains(t, view, "service/payments-gateway-with-a-long-name", "the resource is not truncated")
+	assert.Contains(t, view, "payments-7d9f-abcde")
+	assert.Contains(t, view, "127.0.0.2")
+	assert.Contains(t, view, "backend")
+	assert.Contains(t, view, "connection refused")
+	assert.Contains(t, view, "2.0 KiB sent")
+	assert.Contains(t, view, "12/2")
+	assert.Contains(t, view, "press l in the list")
+	assert.Contains(t, view, "Starting")
+	assert.Contains(t, view, "Reconnecting (2s, attempt 1)")
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, ViewModeMain, ui.viewMode)
+	assert.Nil(t, ui.detailState)
+}
+
+func TestDetailPanel_ForwardRemoved(t *testing.T) {
+	m := newTestModelWithForward()
+	m.openDetail()
+	m.ui.Remove("test-id")
+
+	assert.Contains(t, m.renderDetail(), "This forward was removed.")
+
+	m.handleDetailKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	assert.Equal(t, ViewModeMain, m.ui.viewMode)
+}
+
+func TestRefreshDetail_IgnoresClosedPanel(t *testing.T) {
+	m := newTestModelWithForward()
+	calls := 0
+	m.ui.SetTrafficProvider(func(string) (TrafficSample, bool) {
+		calls++
+		return TrafficSample{Sent: int64(calls)}, true
+	})
+
+	m.ui.refreshDetail()
+	assert.Zero(t, calls, "nothing is read without an open panel")
+
+	m.openDetail()
+	_, _ = m.Update(uptimeTickMsg{})
+	assert.Equal(t, 2, calls, "opening and every uptime tick refresh the traffic")
+	assert.Equal(t, int64(2), m.ui.detailState.traffic.Sent)
+}
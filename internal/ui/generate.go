@@ -300,7 +300,7 @@ func (m *GenerateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case generateNamespacesLoadedMsg:
 		m.loading = false
 		if msg.err != nil {
-			m.loadErr = msg.err.Error()
+			m.loadErr = loadErrorText(msg.err)
 			return m, nil
 		}
 		m.namespaces = msg.namespaces
@@ -310,7 +310,7 @@ func (m *GenerateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case generateServicesLoadedMsg:
 		m.loading = false
 		if msg.err != nil {
-			m.loadErr = msg.err.Error()
+			m.loadErr = loadErrorText(msg.err)
 		}
 		m.servicesByNS = msg.servicesByNS
 		m.buildServiceOrder()
@@ -59,6 +59,9 @@ func (f *fakeDiscovery) ListPods(_ context.Context, _, _ string) ([]k8s.PodInfo,
 func (f *fakeDiscovery) ListPodsWithSelector(_ context.Context, _, _, _ string) ([]k8s.PodInfo, error) {
 	return nil, nil
 }
+func (f *fakeDiscovery) ListWorkloads(_ context.Context, _, _, _ string) ([]k8s.WorkloadInfo, error) {
+	return nil, nil
+}
 func (f *fakeDiscovery) ListServices(_ context.Context, _, ns string) ([]k8s.ServiceInfo, error) {
 	if f.listServicesEr != nil {
 		return nil, f.listServicesEr
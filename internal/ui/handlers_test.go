@@ -2,6 +2,8 @@ package ui
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -140,6 +142,23 @@ func TestHandleMainViewKeys_Toggle(t *testing.T) {
 	assert.GreaterOrEqual(t, toggleCallback.CallCount(), 1)
 }
 
+// TestHandleMainViewKeys_ReadOnly tests that config keys only explain read-only mode
+func TestHandleMainViewKeys_ReadOnly(t *testing.T) {
+	m := newTestModelWithForward()
+	m.ui.SetReadOnly(true)
+
+	for _, key := range []string{"n", "e", "d"} {
+		m.ui.copyMessage = ""
+		_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+
+		assert.NotNil(t, cmd, "key %q should schedule clearing the notice", key)
+		assert.Equal(t, readOnlyMessage, m.ui.copyMessage)
+		assert.Nil(t, m.ui.addWizard)
+		assert.Nil(t, m.ui.removeWizard)
+		assert.False(t, m.ui.deleteConfirming)
+	}
+}
+
 // TestHandleMainViewKeys_NewWizard tests 'n' key with dependencies
 func TestHandleMainViewKeys_NewWizard(t *testing.T) {
 	mockDiscovery := NewMockDiscovery()
@@ -415,7 +434,7 @@ func TestHandleHTTPLogKeys_Close(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("fwd-id", "alias")
+	ui.httpLogState = newHTTPLogState("fwd-id", "alias", 0)
 	ui.httpLogCleanup = mockSubscriber.Subscribe("fwd-id", func(entry HTTPLogEntry) {})
 	ui.mu.Unlock()
 
@@ -440,7 +459,7 @@ func TestHandleHTTPLogKeys_FilterCycle(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("fwd-id", "alias")
+	ui.httpLogState = newHTTPLogState("fwd-id", "alias", 0)
 	ui.mu.Unlock()
 
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
@@ -478,7 +497,7 @@ func TestHandleHTTPLogKeys_TextFilter(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("fwd-id", "alias")
+	ui.httpLogState = newHTTPLogState("fwd-id", "alias", 0)
 	ui.mu.Unlock()
 
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
@@ -492,12 +511,45 @@ func TestHandleHTTPLogKeys_TextFilter(t *testing.T) {
 	m.ui.mu.RUnlock()
 }
 
+// TestHandleHTTPLogKeys_ExportHAR tests 'e' writes completed entries to a HAR file
+func TestHandleHTTPLogKeys_ExportHAR(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.mu.Lock()
+	ui.viewMode = ViewModeHTTPLog
+	ui.forwards["fwd-id"] = &ForwardStatus{LocalPort: 8080}
+	ui.httpLogState = newHTTPLogState("fwd-id", "my api", 0)
+	ui.httpLogState.entries = []HTTPLogEntry{
+		{RequestID: "1", Direction: "response", Method: "GET", Path: "/ok", StatusCode: 200, LatencyMs: 5},
+		{RequestID: "2", Direction: "request", Method: "GET", Path: "/pending"},
+	}
+	ui.mu.Unlock()
+
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+	_, cmd := m.handleHTTPLogKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	assert.NotNil(t, cmd, "should schedule clearing the status message")
+
+	m.ui.mu.RLock()
+	msg := m.ui.httpLogState.copyMessage
+	m.ui.mu.RUnlock()
+	assert.Contains(t, msg, "Exported 1 requests to kportal-my-api-")
+
+	matches, err := filepath.Glob("kportal-my-api-*.har")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	data, err := os.ReadFile(matches[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "http://localhost:8080/ok")
+	assert.NotContains(t, string(data), "/pending")
+}
+
 // TestHandleHTTPLogKeys_ClearFilters tests 'c' to clear filters
 func TestHandleHTTPLogKeys_ClearFilters(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("fwd-id", "alias")
+	ui.httpLogState = newHTTPLogState("fwd-id", "alias", 0)
 	ui.httpLogState.filterMode = HTTPLogFilterErrors
 	ui.httpLogState.filterText = "api"
 	ui.mu.Unlock()
@@ -514,12 +566,53 @@ func TestHandleHTTPLogKeys_ClearFilters(t *testing.T) {
 	m.ui.mu.RUnlock()
 }
 
+// TestHandleHTTPLogKeys_ClearLog tests 'x' twice to clear the log buffer
+func TestHandleHTTPLogKeys_ClearLog(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.mu.Lock()
+	ui.viewMode = ViewModeHTTPLog
+	ui.httpLogState = newHTTPLogState("fwd-id", "alias", 0)
+	ui.httpLogState.entries = []HTTPLogEntry{
+		{Method: "GET", Path: "/a", StatusCode: 200, Direction: "response"},
+		{Method: "GET", Path: "/b", StatusCode: 200, Direction: "response"},
+	}
+	ui.httpLogState.cursor = 1
+	ui.httpLogState.autoScroll = false
+	ui.mu.Unlock()
+
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+	x := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+
+	// The first x only asks for confirmation
+	m.handleHTTPLogKeys(x)
+	assert.Len(t, m.ui.httpLogState.entries, 2)
+	assert.True(t, m.ui.httpLogState.clearConfirming)
+	assert.Contains(t, m.renderHTTPLog(), "Press x again to clear all 2 entries")
+
+	// Any other key cancels it
+	m.handleHTTPLogKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	assert.False(t, m.ui.httpLogState.clearConfirming)
+	m.handleHTTPLogKeys(x)
+	assert.Len(t, m.ui.httpLogState.entries, 2)
+
+	_, cmd := m.handleHTTPLogKeys(x)
+	assert.NotNil(t, cmd, "the confirmation message should be cleared later")
+	assert.Empty(t, m.ui.httpLogState.entries)
+	assert.Zero(t, m.ui.httpLogState.cursor)
+	assert.True(t, m.ui.httpLogState.autoScroll)
+	assert.Equal(t, "Cleared 2 entries", m.ui.httpLogState.copyMessage)
+
+	// An empty log has nothing to confirm
+	m.handleHTTPLogKeys(x)
+	assert.False(t, m.ui.httpLogState.clearConfirming)
+}
+
 // TestHandleHTTPLogEntry tests HTTP log entry handling
 func TestHandleHTTPLogEntry(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("fwd-id", "alias")
+	ui.httpLogState = newHTTPLogState("fwd-id", "alias", 0)
 	ui.httpLogState.autoScroll = true
 	ui.mu.Unlock()
 
@@ -736,6 +829,52 @@ func TestHandlePortChecked(t *testing.T) {
 	}
 }
 
+// TestHandlePortChecked_NamesHolder verifies the wizard error names the
+// process holding the port.
+func TestHandlePortChecked_NamesHolder(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.mu.Lock()
+	ui.viewMode = ViewModeAddWizard
+	ui.addWizard = newAddWizardState()
+	ui.addWizard.step = StepEnterLocalPort
+	ui.mu.Unlock()
+
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+	m.handlePortChecked(PortCheckedMsg{
+		port:    8080,
+		message: "✗ Port 8080 in use by nginx (PID 1234)",
+		usedBy:  "nginx (PID 1234)",
+	})
+
+	m.ui.mu.RLock()
+	defer m.ui.mu.RUnlock()
+	require.Error(t, m.ui.addWizard.error)
+	assert.Equal(t, "port 8080 is in use by nginx (PID 1234), stop it or choose another port", m.ui.addWizard.error.Error())
+	assert.Contains(t, m.renderEnterLocalPort(), "nginx (PID 1234)")
+}
+
+// TestHandlePortChecked_KeepsEditAlias verifies the alias of an edited
+// forward is pre-filled on the confirmation step.
+func TestHandlePortChecked_KeepsEditAlias(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.mu.Lock()
+	ui.viewMode = ViewModeAddWizard
+	ui.addWizard = newAddWizardState()
+	ui.addWizard.step = StepEnterLocalPort
+	ui.addWizard.isEditing = true
+	ui.addWizard.alias = "my-api"
+	ui.addWizard.textInput = "8080"
+	ui.mu.Unlock()
+
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+	m.handlePortChecked(PortCheckedMsg{port: 8080, available: true})
+
+	m.ui.mu.RLock()
+	defer m.ui.mu.RUnlock()
+	assert.Equal(t, StepConfirmation, m.ui.addWizard.step)
+	assert.Equal(t, "my-api", m.ui.addWizard.textInput)
+}
+
 // TestHandleForwardSaved tests forward save handler
 func TestHandleForwardSaved(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
@@ -862,7 +1001,7 @@ func TestModel_Update_ViewModeRouting(t *testing.T) {
 			case ViewModeBenchmark:
 				ui.benchmarkState = newBenchmarkState("id", "alias", 8080)
 			case ViewModeHTTPLog:
-				ui.httpLogState = newHTTPLogState("id", "alias")
+				ui.httpLogState = newHTTPLogState("id", "alias", 0)
 			}
 			ui.mu.Unlock()
 
@@ -1077,3 +1216,126 @@ func TestEditPrefill_PreservesHTTPLog(t *testing.T) {
 	assert.True(t, m.ui.addWizard.httpLogOriginal.IncludeHeaders)
 	assert.Equal(t, 4096, m.ui.addWizard.httpLogOriginal.MaxBodySize)
 }
+
+// TestEditPrefill_PreservesMDNSPublish verifies that an `mdnsPublish: false`
+// override survives opening the forward in the edit wizard.
+func TestEditPrefill_PreservesMDNSPublish(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	disco := &k8s.Discovery{}
+	ui.SetWizardDependencies(disco, &config.Mutator{}, "/path/to/config")
+
+	publish := false
+	fwd := &config.Forward{
+		Resource:    "pod/api",
+		Port:        8080,
+		LocalPort:   8080,
+		MDNSPublish: &publish,
+	}
+	ui.AddForward("api", fwd)
+
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}
+	m.handleMainViewKeys(keyMsg)
+
+	require.NotNil(t, m.ui.addWizard, "wizard should be active after 'e'")
+	require.NotNil(t, m.ui.addWizard.mdnsPublishOriginal)
+	assert.False(t, *m.ui.addWizard.mdnsPublishOriginal)
+}
+
+// TestEditPrefill_PreservesHealthCheck verifies that a forward's HTTP
+// health probe survives opening the forward in the edit wizard.
+func TestEditPrefill_PreservesHealthCheck(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	disco := &k8s.Discovery{}
+	ui.SetWizardDependencies(disco, &config.Mutator{}, "/path/to/config")
+
+	fwd := &config.Forward{
+		Resource:    "pod/api",
+		Port:        8080,
+		LocalPort:   8080,
+		HealthCheck: &config.ProbeSpec{Path: "/healthz", ExpectedStatus: 204},
+	}
+	ui.AddForward("api", fwd)
+
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}
+	m.handleMainViewKeys(keyMsg)
+
+	require.NotNil(t, m.ui.addWizard, "wizard should be active after 'e'")
+	require.NotNil(t, m.ui.addWizard.healthCheckOriginal)
+	assert.Equal(t, "/healthz", m.ui.addWizard.healthCheckOriginal.Path)
+}
+
+// TestEditPrefill_PreservesReconnectMaxRetries verifies that a forward's
+// reconnect retry limit, idle timeout and connection tuning survive opening
+// the forward in the edit wizard.
+func TestEditPrefill_PreservesReconnectMaxRetries(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	disco := &k8s.Discovery{}
+	ui.SetWizardDependencies(disco, &config.Mutator{}, "/path/to/config")
+
+	fwd := &config.Forward{
+		Resource:            "pod/api",
+		Port:                8080,
+		LocalPort:           8080,
+		ReconnectMaxRetries: 5,
+		IdleTimeout:         300,
+		TCPKeepalive:        "10s",
+		DialTimeout:         "5s",
+	}
+	ui.AddForward("api", fwd)
+
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}
+	m.handleMainViewKeys(keyMsg)
+
+	require.NotNil(t, m.ui.addWizard, "wizard should be active after 'e'")
+	assert.Equal(t, 5, m.ui.addWizard.reconnectMaxRetriesOriginal)
+	assert.Equal(t, 300, m.ui.addWizard.idleTimeoutOriginal)
+	assert.Equal(t, "10s", m.ui.addWizard.tcpKeepaliveOriginal)
+	assert.Equal(t, "5s", m.ui.addWizard.dialTimeoutOriginal)
+}
+
+// TestEditPrefill_PreservesContainerPort verifies that a forward's container
+// and named port survive the edit wizard only while the same named port is
+// picked again.
+func TestEditPrefill_PreservesContainerPort(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	disco := &k8s.Discovery{}
+	ui.SetWizardDependencies(disco, &config.Mutator{}, "/path/to/config")
+
+	fwd := &config.Forward{
+		Resource:  "pod/api",
+		Container: "app",
+		PortName:  "http",
+		LocalPort: 8080,
+	}
+	ui.AddForward("api", fwd)
+
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	keyMsg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}
+	m.handleMainViewKeys(keyMsg)
+
+	require.NotNil(t, m.ui.addWizard, "wizard should be active after 'e'")
+	assert.Equal(t, "app", m.ui.addWizard.containerOriginal)
+	assert.Equal(t, "http", m.ui.addWizard.portNameOriginal)
+
+	m.ui.addWizard.loading = false
+	m.ui.addWizard.inputMode = InputModeList
+	m.ui.addWizard.detectedPorts = []k8s.PortInfo{{Name: "http", Port: 8080, Container: "app"}}
+	m.ui.addWizard.cursor = 0
+	m.handleAddWizardEnter()
+	assert.Equal(t, 8080, m.ui.addWizard.remotePort)
+	assert.Equal(t, "http", m.ui.addWizard.portNameOriginal, "same named port keeps the port name")
+
+	m.ui.addWizard.step = StepEnterRemotePort
+	m.ui.addWizard.inputMode = InputModeText
+	m.ui.addWizard.textInput = "9090"
+	m.handleAddWizardEnter()
+	assert.Equal(t, 9090, m.ui.addWizard.remotePort)
+	assert.Empty(t, m.ui.addWizard.portNameOriginal, "manual port entry drops the port name")
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/lukaszraczylo/kportal/internal/httplog"
+)
+
+// curlCommand renders entry's request as a curl command against the
+// forward's local port. Text bodies are passed with --data-raw; binary and
+// compressed bodies are embedded as base64 and decoded into curl's stdin, so
+// the bytes sent match the original. A body the log truncated is left out
+// with a comment, as are headers that only describe the connection.
+func curlCommand(entry HTTPLogEntry, localPort int) string {
+	var comments []string
+	args := []string{"curl"}
+
+	// gRPC needs HTTP/2, which the forward accepts in cleartext
+	if entry.GRPC {
+		args = append(args, "--http2-prior-knowledge")
+	}
+
+	switch entry.Method {
+	case "", http.MethodGet:
+	case http.MethodHead:
+		args = append(args, "--head")
+	default:
+		args = append(args, "-X", entry.Method)
+	}
+	args = append(args, shellQuote(fmt.Sprintf("http://localhost:%d%s", localPort, entry.Path)))
+
+	names := make([]string, 0, len(entry.RequestHeaders))
+	for name := range entry.RequestHeaders {
+		if _, skip := replaySkippedHeaders[http.CanonicalHeaderKey(name)]; !skip {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := entry.RequestHeaders[name]
+		if value == httplog.RedactedValue {
+			comments = append(comments, fmt.Sprintf("# %s was redacted in the log; fill in its value", name))
+		}
+		args = append(args, "-H", shellQuote(name+": "+value))
+	}
+
+	var stdin string
+	body, truncated := httplog.SplitTruncated(entry.RequestBody)
+	switch {
+	case body == "":
+	case truncated != "":
+		comments = append(comments, fmt.Sprintf("# request body omitted: it was truncated in the log %s", truncated))
+	case isBinaryContent(body, entry.RequestHeaders) || entry.RequestHeaders["Content-Encoding"] != "":
+		comments = append(comments, fmt.Sprintf("# request body is binary (%d bytes), decoded from base64", len(body)))
+		stdin = "echo " + base64.StdEncoding.EncodeToString([]byte(body)) + " | base64 -d | "
+		args = append(args, "--data-binary", "@-")
+	default:
+		args = append(args, "--data-raw", shellQuote(body))
+	}
+
+	var b strings.Builder
+	for _, comment := range comments {
+		b.WriteString(comment)
+		b.WriteString("\n")
+	}
+	b.WriteString(stdin)
+	b.WriteString(strings.Join(args, " "))
+	return b.String()
+}
+
+// shellQuote single-quotes s for POSIX shells
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
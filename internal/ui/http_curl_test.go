@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"encoding/base64"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/kportal/internal/httplog"
+)
+
+func TestCurlCommand(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  string
+		entry HTTPLogEntry
+	}{
+		{
+			name:  "plain GET",
+			entry: HTTPLogEntry{Method: "GET", Path: "/health"},
+			want:  "curl 'http://localhost:8080/health'",
+		},
+		{
+			name:  "HEAD",
+			entry: HTTPLogEntry{Method: "HEAD", Path: "/"},
+			want:  "curl --head 'http://localhost:8080/'",
+		},
+		{
+			name: "POST with headers and text body",
+			entry: HTTPLogEntry{
+				Method: "POST",
+				Path:   "/api/items",
+				RequestHeaders: map[string]string{
+					"Content-Type":   "application/json",
+					"Content-Length": "22",
+					"X-Trace":        "abc",
+				},
+				RequestBody: `{"name":"it's mine"}`,
+			},
+			want: `curl -X POST 'http://localhost:8080/api/items' -H 'Content-Type: application/json' -H 'X-Trace: abc' --data-raw '{"name":"it'\''s mine"}'`,
+		},
+		{
+			name: "redacted header",
+			entry: HTTPLogEntry{
+				Method:         "GET",
+				Path:           "/me",
+				RequestHeaders: map[string]string{"Authorization": httplog.RedactedValue},
+			},
+			want: "# Authorization was redacted in the log; fill in its value\n" +
+				"curl 'http://localhost:8080/me' -H 'Authorization: [REDACTED]'",
+		},
+		{
+			name:  "truncated body",
+			entry: HTTPLogEntry{Method: "PUT", Path: "/upload", RequestBody: "abc...[truncated 10 bytes]"},
+			want: "# request body omitted: it was truncated in the log [truncated 10 bytes]\n" +
+				"curl -X PUT 'http://localhost:8080/upload'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, curlCommand(tt.entry, 8080))
+		})
+	}
+}
+
+func TestCurlCommand_BinaryBody(t *testing.T) {
+	body := string([]byte{0x1f, 0x8b, 0x08, 0x00, 0xff, 0x00})
+	entry := HTTPLogEntry{
+		Method:         "POST",
+		Path:           "/ingest",
+		RequestHeaders: map[string]string{"Content-Encoding": "gzip"},
+		RequestBody:    body,
+	}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	assert.Equal(t,
+		"# request body is binary (6 bytes), decoded from base64\n"+
+			"echo "+encoded+" | base64 -d | curl -X POST 'http://localhost:8080/ingest' -H 'Content-Encoding: gzip' --data-binary @-",
+		curlCommand(entry, 8080))
+}
+
+func TestHandleHTTPLogKeys_CopyCurl(t *testing.T) {
+	copied := stubClipboard(t, nil)
+	m := newModelWithHTTPLog()
+	m.ui.forwards["fwd-id"] = &ForwardStatus{LocalPort: 9090}
+	m.ui.httpLogState.entries = []HTTPLogEntry{{Method: "GET", Path: "/x", StatusCode: 200, Direction: "response"}}
+	m.ui.httpLogState.showingDetail = true
+
+	_, cmd := m.handleHTTPLogKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	assert.NotNil(t, cmd)
+	require.Len(t, *copied, 1)
+	assert.Equal(t, "curl 'http://localhost:9090/x'", (*copied)[0])
+	assert.Equal(t, "Copied curl command!", m.ui.httpLogState.copyMessage)
+}
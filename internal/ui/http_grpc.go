@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lukaszraczylo/kportal/internal/httplog"
+)
+
+// grpcPreviewBytes is how much of each gRPC message is shown as hex
+const grpcPreviewBytes = 16
+
+// formatGRPCStatus renders entry's gRPC status with its name and message,
+// colored by outcome
+func formatGRPCStatus(entry HTTPLogEntry) string {
+	if entry.GRPCStatus == "" {
+		return warningStyle.Render("none (call did not complete)")
+	}
+
+	status := entry.GRPCStatus
+	if name := httplog.GRPCStatusName(status); name != "" {
+		status += " " + name
+	}
+	if entry.GRPCMessage != "" {
+		status += ": " + entry.GRPCMessage
+	}
+	if entry.grpcFailed() {
+		return errorStyle.Render(status)
+	}
+	return successStyle.Render(status)
+}
+
+// grpcBodyLines describes a gRPC body message by message instead of printing
+// its protobuf bytes, which are not decoded
+func grpcBodyLines(body string) []string {
+	frames := httplog.ParseGRPCFrames([]byte(body))
+	lines := []string{mutedStyle.Render(fmt.Sprintf("    [gRPC: %d message(s), protobuf not decoded]", len(frames)))}
+
+	for i, frame := range frames {
+		var notes []string
+		if frame.Compressed {
+			notes = append(notes, "compressed")
+		}
+		if len(frame.Data) < frame.Length {
+			notes = append(notes, fmt.Sprintf("%d captured", len(frame.Data)))
+		}
+		line := fmt.Sprintf("    #%d  %d bytes", i+1, frame.Length)
+		if len(notes) > 0 {
+			line += " (" + strings.Join(notes, ", ") + ")"
+		}
+
+		preview := frame.Data
+		if len(preview) > grpcPreviewBytes {
+			preview = preview[:grpcPreviewBytes]
+		}
+		if len(preview) > 0 {
+			line += "  " + mutedStyle.Render(fmt.Sprintf("% x", preview))
+			if len(preview) < len(frame.Data) {
+				line += mutedStyle.Render(" ...")
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// grpcMessage length-prefixes msg the way gRPC does on the wire
+func grpcMessage(msg string) string {
+	prefix := make([]byte, 5)
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(msg)))
+	return string(prefix) + msg
+}
+
+func TestFormatGRPCStatus(t *testing.T) {
+	assert.Contains(t, formatGRPCStatus(HTTPLogEntry{GRPC: true, GRPCStatus: "0"}), "0 OK")
+	assert.Contains(t, formatGRPCStatus(HTTPLogEntry{GRPC: true, GRPCStatus: "5", GRPCMessage: "user not found"}),
+		"5 NOT_FOUND: user not found")
+	assert.Contains(t, formatGRPCStatus(HTTPLogEntry{GRPC: true}), "did not complete")
+}
+
+func TestGRPCBodyLines(t *testing.T) {
+	body := grpcMessage("\x0a\x05hello") + grpcMessage(strings.Repeat("x", 40))[:20]
+
+	lines := grpcBodyLines(body)
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "2 message(s)")
+	assert.Contains(t, lines[1], "#1  7 bytes")
+	assert.Contains(t, lines[1], "0a 05 68 65 6c 6c 6f")
+	assert.Contains(t, lines[2], "#2  40 bytes (15 captured)")
+	assert.NotContains(t, strings.Join(lines, "\n"), "hello", "message bytes are not printed raw")
+}
+
+func TestHTTPLogEntry_GRPCFailed(t *testing.T) {
+	state := newHTTPLogState("fwd-id", "svc", 0)
+	state.entries = []HTTPLogEntry{
+		{Path: "/ok", StatusCode: 200, GRPC: true, GRPCStatus: "0"},
+		{Path: "/denied", StatusCode: 200, GRPC: true, GRPCStatus: "7"},
+		{Path: "/plain", StatusCode: 200},
+	}
+
+	state.filterMode = HTTPLogFilterErrors
+	filtered := state.getFilteredEntries()
+	require.Len(t, filtered, 1, "gRPC errors count as errors despite HTTP 200")
+	assert.Equal(t, "/denied", filtered[0].Path)
+
+	assert.Equal(t, 1, summarizeHTTPLog(state.entries).errors)
+}
+
+func TestRenderHTTPLogDetail_GRPC(t *testing.T) {
+	m := newModelWithHTTPLog()
+	entry := HTTPLogEntry{
+		Method:       "POST",
+		Path:         "/users.v1.Users/Get",
+		StatusCode:   200,
+		Direction:    "response",
+		RequestBody:  grpcMessage("\x08\x01"),
+		ResponseBody: grpcMessage(""),
+		GRPCStatus:   "5",
+		GRPCMessage:  "user not found",
+		GRPC:         true,
+	}
+
+	result := m.renderHTTPLogDetail(entry, 120, 60)
+	assert.Contains(t, result, "gRPC Status: ")
+	assert.Contains(t, result, "NOT_FOUND: user not found")
+	assert.Contains(t, result, "[gRPC: 1 message(s), protobuf not decoded]")
+	assert.NotContains(t, result, "Binary data")
+}
+
+func TestCurlCommand_GRPC(t *testing.T) {
+	entry := HTTPLogEntry{Method: "POST", Path: "/svc/Method", GRPC: true}
+	assert.Equal(t, "curl --http2-prior-knowledge -X POST 'http://localhost:8080/svc/Method'", curlCommand(entry, 8080))
+}
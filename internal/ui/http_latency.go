@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// styleByLatency colors an HTTP log row by how long its request took:
+// warning at the slow threshold, error at the very slow one. Faster rows
+// keep the default text color.
+func (ui *BubbleTeaUI) styleByLatency(line string, latencyMs int64) string {
+	slow, verySlow := ui.httpLogSlow, ui.httpLogVerySlow
+	if slow <= 0 {
+		slow = config.DefaultHTTPLogSlowLatency
+	}
+	if verySlow <= 0 {
+		verySlow = config.DefaultHTTPLogVerySlowLatency
+	}
+
+	latency := time.Duration(latencyMs) * time.Millisecond
+	switch {
+	case latency >= verySlow:
+		return errorStyle.Render(line)
+	case latency >= slow:
+		return warningStyle.Render(line)
+	default:
+		return line
+	}
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStyleByLatency(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	assert.Equal(t, "row", ui.styleByLatency("row", 499))
+	assert.Equal(t, warningStyle.Render("row"), ui.styleByLatency("row", 500))
+	assert.Equal(t, errorStyle.Render("row"), ui.styleByLatency("row", 2000))
+
+	ui.SetHTTPLogLatencyThresholds(50*time.Millisecond, 100*time.Millisecond)
+	assert.Equal(t, warningStyle.Render("row"), ui.styleByLatency("row", 60))
+	assert.Equal(t, errorStyle.Render("row"), ui.styleByLatency("row", 100))
+}
+
+func TestHandleHTTPLogKeys_ToggleLatencyColors(t *testing.T) {
+	m := newModelWithHTTPLog()
+	m.ui.httpLogState.entries = []HTTPLogEntry{{Method: "GET", Path: "/slow", StatusCode: 200, LatencyMs: 3000, Direction: "response"}}
+	assert.NotContains(t, m.renderHTTPLog(), "[Latency colors]")
+
+	m.handleHTTPLogKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.True(t, m.ui.httpLogLatencyColor)
+	assert.Contains(t, m.renderHTTPLog(), "[Latency colors]")
+
+	m.handleHTTPLogKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.False(t, m.ui.httpLogLatencyColor)
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lukaszraczylo/kportal/internal/httplog"
+)
+
+// replayTimeout bounds a replayed request, including reading its response.
+const replayTimeout = 30 * time.Second
+
+// replaySkippedHeaders describe the original connection rather than the
+// request, so they are not replayed. Content-Length is set from the body.
+var replaySkippedHeaders = map[string]struct{}{
+	"Connection":        {},
+	"Content-Length":    {},
+	"Keep-Alive":        {},
+	"Proxy-Connection":  {},
+	"Te":                {},
+	"Trailer":           {},
+	"Transfer-Encoding": {},
+	"Upgrade":           {},
+}
+
+// httpLogReplayMsg reports the outcome of a replayed request
+type httpLogReplayMsg struct {
+	err      error
+	latency  time.Duration
+	status   int
+	redacted int // redacted headers left out of the replay
+}
+
+// buildReplayRequest reconstructs entry's request against the forward's
+// local port. The body is sent as the captured bytes, so binary and
+// compressed bodies go out exactly as they came in, together with their
+// Content-Encoding header. Redacted headers were never captured and are left
+// out; their number is returned. A body the log truncated cannot be
+// replayed.
+func buildReplayRequest(ctx context.Context, entry HTTPLogEntry, localPort int) (*http.Request, int, error) {
+	if entry.Method == "" {
+		return nil, 0, fmt.Errorf("entry has no request method")
+	}
+	body, truncated := httplog.SplitTruncated(entry.RequestBody)
+	if truncated != "" {
+		return nil, 0, fmt.Errorf("request body was truncated in the log %s", truncated)
+	}
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	url := fmt.Sprintf("http://localhost:%d%s", localPort, entry.Path)
+	req, err := http.NewRequestWithContext(ctx, entry.Method, url, reader)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	redacted := 0
+	for name, value := range entry.RequestHeaders {
+		if _, skip := replaySkippedHeaders[http.CanonicalHeaderKey(name)]; skip {
+			continue
+		}
+		if value == httplog.RedactedValue {
+			redacted++
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+	return req, redacted, nil
+}
+
+// replayHTTPRequestCmd re-issues entry's request to the forward's local
+// port. It goes through the forward's logging proxy, so the replay and its
+// response arrive in the log as a fresh entry.
+func replayHTTPRequestCmd(entry HTTPLogEntry, localPort int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), replayTimeout)
+		defer cancel()
+
+		req, redacted, err := buildReplayRequest(ctx, entry, localPort)
+		if err != nil {
+			return httpLogReplayMsg{err: err}
+		}
+
+		// Keep the original Accept-Encoding and redirects as they were, so
+		// the response matches what the client saw
+		transport := &http.Transport{DisableCompression: true}
+		if entry.GRPC {
+			// gRPC needs HTTP/2, which the forward accepts in cleartext
+			transport.Protocols = new(http.Protocols)
+			transport.Protocols.SetUnencryptedHTTP2(true)
+		}
+		defer transport.CloseIdleConnections()
+		client := &http.Client{
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+
+		start := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			return httpLogReplayMsg{err: err, redacted: redacted}
+		}
+		defer func() { _ = resp.Body.Close() }()
+		// Read the whole response so the proxy logs it complete
+		_, err = io.Copy(io.Discard, resp.Body)
+
+		return httpLogReplayMsg{err: err, status: resp.StatusCode, latency: time.Since(start), redacted: redacted}
+	}
+}
+
+// handleHTTPLogReplay shows the outcome of a replay in the log footer
+func (m model) handleHTTPLogReplay(msg httpLogReplayMsg) (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	defer m.ui.mu.Unlock()
+
+	state := m.ui.httpLogState
+	if state == nil {
+		return m, nil
+	}
+
+	if msg.err != nil {
+		state.copyMessage = "Replay failed: " + msg.err.Error()
+	} else {
+		state.copyMessage = fmt.Sprintf("Replayed: %d in %dms", msg.status, msg.latency.Milliseconds())
+	}
+	if msg.redacted > 0 {
+		state.copyMessage += fmt.Sprintf(" (%d redacted header(s) omitted)", msg.redacted)
+	}
+	return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+		return clearCopyMessageMsg{}
+	})
+}
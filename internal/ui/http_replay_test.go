@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/kportal/internal/httplog"
+)
+
+func gzipped(t *testing.T, s string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.String()
+}
+
+func TestBuildReplayRequest(t *testing.T) {
+	body := gzipped(t, `{"name":"kportal"}`)
+	entry := HTTPLogEntry{
+		Method: "POST",
+		Path:   "/api/items",
+		RequestHeaders: map[string]string{
+			"Content-Encoding":  "gzip",
+			"Content-Type":      "application/json",
+			"Content-Length":    "999",
+			"Connection":        "keep-alive",
+			"Authorization":     httplog.RedactedValue,
+			"X-Session-Token":   httplog.RedactedValue,
+			"X-Request-Purpose": "replay-test",
+		},
+		RequestBody: body,
+	}
+
+	req, redacted, err := buildReplayRequest(context.Background(), entry, 18080)
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "http://localhost:18080/api/items", req.URL.String())
+	assert.Equal(t, 2, redacted)
+	assert.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+	assert.Equal(t, "replay-test", req.Header.Get("X-Request-Purpose"))
+	assert.Empty(t, req.Header.Get("Authorization"), "redacted values are not sent")
+	assert.Empty(t, req.Header.Get("Connection"))
+	assert.Empty(t, req.Header.Get("Content-Length"))
+	assert.Equal(t, int64(len(body)), req.ContentLength)
+
+	sent, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(body), sent, "compressed bodies are replayed byte for byte")
+}
+
+func TestBuildReplayRequest_Errors(t *testing.T) {
+	_, _, err := buildReplayRequest(context.Background(), HTTPLogEntry{Path: "/"}, 18080)
+	assert.Error(t, err)
+
+	truncated := HTTPLogEntry{Method: "PUT", Path: "/upload", RequestBody: "partial...[truncated 4096 bytes]"}
+	_, _, err = buildReplayRequest(context.Background(), truncated, 18080)
+	assert.ErrorContains(t, err, "truncated")
+}
+
+func TestReplayHTTPRequestCmd(t *testing.T) {
+	var gotBody []byte
+	var gotEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotEncoding = r.Header.Get("Accept-Encoding")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+
+	binary := string([]byte{0x00, 0xff, 0x10, 0x80})
+	msg := replayHTTPRequestCmd(HTTPLogEntry{Method: "POST", Path: "/bin", RequestBody: binary}, port)()
+
+	replay, ok := msg.(httpLogReplayMsg)
+	require.True(t, ok)
+	require.NoError(t, replay.err)
+	assert.Equal(t, http.StatusAccepted, replay.status)
+	assert.Equal(t, []byte(binary), gotBody)
+	assert.Empty(t, gotEncoding, "no Accept-Encoding is added that the original did not send")
+}
+
+func TestHandleHTTPLogKeys_Replay(t *testing.T) {
+	m := newModelWithHTTPLog()
+	m.ui.httpLogState.entries = []HTTPLogEntry{{Method: "GET", Path: "/", StatusCode: 500, Direction: "response"}}
+	m.ui.httpLogState.showingDetail = true
+
+	// The forward is not in the table, so there is nowhere to send it
+	_, cmd := m.handleHTTPLogKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	assert.Nil(t, cmd)
+	assert.Contains(t, m.ui.httpLogState.copyMessage, "not running")
+
+	m.ui.forwards["fwd-id"] = &ForwardStatus{LocalPort: 18080}
+	_, cmd = m.handleHTTPLogKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "Replaying...", m.ui.httpLogState.copyMessage)
+}
+
+func TestHandleHTTPLogReplay(t *testing.T) {
+	m := newModelWithHTTPLog()
+
+	_, cmd := m.handleHTTPLogReplay(httpLogReplayMsg{status: 200, redacted: 1})
+	assert.NotNil(t, cmd, "the message is cleared later")
+	assert.Equal(t, "Replayed: 200 in 0ms (1 redacted header(s) omitted)", m.ui.httpLogState.copyMessage)
+
+	_, _ = m.handleHTTPLogReplay(httpLogReplayMsg{err: assert.AnError})
+	assert.Contains(t, m.ui.httpLogState.copyMessage, "Replay failed")
+}
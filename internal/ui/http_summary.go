@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lukaszraczylo/kportal/internal/httplog"
+)
+
+// httpLogSizeBuckets are the upper bounds of the response size histogram;
+// the last bucket holds everything larger
+var httpLogSizeBuckets = [...]struct {
+	label string
+	max   int
+}{
+	{"<1K", 1 << 10},
+	{"<10K", 10 << 10},
+	{"<100K", 100 << 10},
+	{"<1M", 1 << 20},
+}
+
+// httpLogSummary aggregates the entries shown in the HTTP log table
+type httpLogSummary struct {
+	sizes        [len(httpLogSizeBuckets) + 1]int // response size histogram
+	sentBytes    int64
+	recvBytes    int64
+	totalLatency int64
+	requests     int
+	errors       int
+}
+
+// summarizeHTTPLog aggregates entries, which are expected to be the filtered
+// entries of the table. Request sizes come from Content-Length where the
+// client sent one, since a logged body may be truncated; responses of
+// unknown size count towards the totals but not the histogram.
+func summarizeHTTPLog(entries []HTTPLogEntry) httpLogSummary {
+	var s httpLogSummary
+	for _, entry := range entries {
+		s.requests++
+		if entry.StatusCode >= 400 || entry.Error != "" || entry.grpcFailed() {
+			s.errors++
+		}
+		s.totalLatency += entry.LatencyMs
+		s.sentBytes += int64(requestSize(entry))
+
+		if entry.BodySize < 0 {
+			continue
+		}
+		s.recvBytes += int64(entry.BodySize)
+		bucket := len(httpLogSizeBuckets)
+		for i, b := range httpLogSizeBuckets {
+			if entry.BodySize < b.max {
+				bucket = i
+				break
+			}
+		}
+		s.sizes[bucket]++
+	}
+	return s
+}
+
+// requestSize returns the size of entry's request body
+func requestSize(entry HTTPLogEntry) int {
+	for name, value := range entry.RequestHeaders {
+		if strings.EqualFold(name, "Content-Length") {
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				return n
+			}
+		}
+	}
+	body, _ := httplog.SplitTruncated(entry.RequestBody)
+	return len(body)
+}
+
+// errorRate returns the share of failed requests as a percentage
+func (s httpLogSummary) errorRate() float64 {
+	if s.requests == 0 {
+		return 0
+	}
+	return float64(s.errors) * 100 / float64(s.requests)
+}
+
+// avgLatencyMs returns the mean request latency in milliseconds
+func (s httpLogSummary) avgLatencyMs() int64 {
+	if s.requests == 0 {
+		return 0
+	}
+	return s.totalLatency / int64(s.requests)
+}
+
+// String renders the summary as a single line for the top of the log view
+func (s httpLogSummary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Requests: %d  Errors: %d (%.1f%%)  Avg: %dms  Sent: %s  Recv: %s  Sizes:",
+		s.requests, s.errors, s.errorRate(), s.avgLatencyMs(),
+		formatBytes(s.sentBytes), formatBytes(s.recvBytes))
+	for i, count := range s.sizes {
+		label := ">=1M"
+		if i < len(httpLogSizeBuckets) {
+			label = httpLogSizeBuckets[i].label
+		}
+		fmt.Fprintf(&b, " %s %d", label, count)
+	}
+	return b.String()
+}
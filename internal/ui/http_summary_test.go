@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeHTTPLog(t *testing.T) {
+	entries := []HTTPLogEntry{
+		{StatusCode: 200, LatencyMs: 10, BodySize: 512, RequestBody: "abc"},
+		{StatusCode: 201, LatencyMs: 30, BodySize: 20 << 10, RequestHeaders: map[string]string{"content-length": "4096"}, RequestBody: "x...[truncated 4000 bytes]"},
+		{StatusCode: 404, LatencyMs: 20, BodySize: 0},
+		{StatusCode: 502, LatencyMs: 60, BodySize: 2 << 20},
+		{StatusCode: 200, LatencyMs: 0, BodySize: -1},
+	}
+
+	s := summarizeHTTPLog(entries)
+	assert.Equal(t, 5, s.requests)
+	assert.Equal(t, 2, s.errors)
+	assert.InDelta(t, 40.0, s.errorRate(), 0.001)
+	assert.Equal(t, int64(24), s.avgLatencyMs())
+	assert.Equal(t, int64(3+4096), s.sentBytes, "Content-Length wins over a truncated body")
+	assert.Equal(t, int64(512+20<<10+2<<20), s.recvBytes)
+	assert.Equal(t, [5]int{2, 0, 1, 0, 1}, s.sizes, "unknown sizes stay out of the histogram")
+
+	assert.Equal(t,
+		"Requests: 5  Errors: 2 (40.0%)  Avg: 24ms  Sent: 4.0 KiB  Recv: 2.0 MiB  Sizes: <1K 2 <10K 0 <100K 1 <1M 0 >=1M 1",
+		s.String())
+}
+
+func TestSummarizeHTTPLog_Empty(t *testing.T) {
+	s := summarizeHTTPLog(nil)
+	assert.Zero(t, s.errorRate())
+	assert.Zero(t, s.avgLatencyMs())
+}
@@ -8,7 +8,7 @@ import (
 
 // TestNewHTTPLogState tests the constructor
 func TestNewHTTPLogState(t *testing.T) {
-	state := newHTTPLogState("forward-123", "my-service")
+	state := newHTTPLogState("forward-123", "my-service", 0)
 
 	assert.Equal(t, "forward-123", state.forwardID)
 	assert.Equal(t, "my-service", state.forwardAlias)
@@ -22,7 +22,7 @@ func TestNewHTTPLogState(t *testing.T) {
 
 // TestHTTPLogState_GetFilteredEntries_NoFilter tests filtering with no filter
 func TestHTTPLogState_GetFilteredEntries_NoFilter(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/users", StatusCode: 200},
 		{Method: "POST", Path: "/api/orders", StatusCode: 201},
@@ -36,7 +36,7 @@ func TestHTTPLogState_GetFilteredEntries_NoFilter(t *testing.T) {
 
 // TestHTTPLogState_GetFilteredEntries_FiltersZeroStatusCode tests that entries without status codes are filtered
 func TestHTTPLogState_GetFilteredEntries_FiltersZeroStatusCode(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/users", StatusCode: 200},
 		{Method: "GET", Path: "/streaming", StatusCode: 0}, // No status (in-progress or error)
@@ -52,7 +52,7 @@ func TestHTTPLogState_GetFilteredEntries_FiltersZeroStatusCode(t *testing.T) {
 
 // TestHTTPLogState_GetFilteredEntries_Non200Filter tests non-2xx filter
 func TestHTTPLogState_GetFilteredEntries_Non200Filter(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.filterMode = HTTPLogFilterNon200
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/users", StatusCode: 200},
@@ -72,7 +72,7 @@ func TestHTTPLogState_GetFilteredEntries_Non200Filter(t *testing.T) {
 
 // TestHTTPLogState_GetFilteredEntries_ErrorsFilter tests 4xx/5xx filter
 func TestHTTPLogState_GetFilteredEntries_ErrorsFilter(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.filterMode = HTTPLogFilterErrors
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/users", StatusCode: 200},
@@ -93,7 +93,7 @@ func TestHTTPLogState_GetFilteredEntries_ErrorsFilter(t *testing.T) {
 
 // TestHTTPLogState_GetFilteredEntries_TextFilter tests text filtering
 func TestHTTPLogState_GetFilteredEntries_TextFilter(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.filterText = "users"
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/users", StatusCode: 200},
@@ -111,7 +111,7 @@ func TestHTTPLogState_GetFilteredEntries_TextFilter(t *testing.T) {
 
 // TestHTTPLogState_GetFilteredEntries_TextFilterCaseInsensitive tests case-insensitive text filtering
 func TestHTTPLogState_GetFilteredEntries_TextFilterCaseInsensitive(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.filterText = "API"
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/users", StatusCode: 200},
@@ -126,7 +126,7 @@ func TestHTTPLogState_GetFilteredEntries_TextFilterCaseInsensitive(t *testing.T)
 
 // TestHTTPLogState_GetFilteredEntries_TextFilterByMethod tests filtering by HTTP method
 func TestHTTPLogState_GetFilteredEntries_TextFilterByMethod(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.filterText = "POST"
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/users", StatusCode: 200},
@@ -144,7 +144,7 @@ func TestHTTPLogState_GetFilteredEntries_TextFilterByMethod(t *testing.T) {
 
 // TestHTTPLogState_GetFilteredEntries_CombinedFilters tests combining mode and text filters
 func TestHTTPLogState_GetFilteredEntries_CombinedFilters(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.filterMode = HTTPLogFilterErrors
 	state.filterText = "api"
 	state.entries = []HTTPLogEntry{
@@ -163,7 +163,7 @@ func TestHTTPLogState_GetFilteredEntries_CombinedFilters(t *testing.T) {
 
 // TestHTTPLogState_GetFilteredEntries_EmptyResult tests when no entries match
 func TestHTTPLogState_GetFilteredEntries_EmptyResult(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 	state.filterText = "nonexistent"
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/users", StatusCode: 200},
@@ -177,7 +177,7 @@ func TestHTTPLogState_GetFilteredEntries_EmptyResult(t *testing.T) {
 
 // TestHTTPLogState_GetFilterModeLabel tests filter mode labels
 func TestHTTPLogState_GetFilterModeLabel(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 
 	tests := []struct {
 		expected string
@@ -206,7 +206,7 @@ func TestHTTPLogState_FilterModeValues(t *testing.T) {
 
 // TestHTTPLogState_LargeEntrySet tests filtering performance with many entries
 func TestHTTPLogState_LargeEntrySet(t *testing.T) {
-	state := newHTTPLogState("fwd", "alias")
+	state := newHTTPLogState("fwd", "alias", 0)
 
 	// Add 1000 entries
 	for i := 0; i < 1000; i++ {
@@ -16,6 +16,7 @@ type DiscoveryInterface interface {
 	ListPods(ctx context.Context, contextName, namespace string) ([]k8s.PodInfo, error)
 	ListPodsWithSelector(ctx context.Context, contextName, namespace, selector string) ([]k8s.PodInfo, error)
 	ListServices(ctx context.Context, contextName, namespace string) ([]k8s.ServiceInfo, error)
+	ListWorkloads(ctx context.Context, contextName, namespace, kind string) ([]k8s.WorkloadInfo, error)
 }
 
 // MutatorInterface defines the interface for configuration mutation operations
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tagFilterPrefix starts a main view filter that matches a tag exactly,
+// e.g. "tag:payments".
+const tagFilterPrefix = "tag:"
+
+// visibleForwards returns the IDs shown in the main view, in display order:
+// every forward, or only those matching the main view filter.
+// selectedIndex indexes into this list.
+// Caller must hold ui.mu.
+func (ui *BubbleTeaUI) visibleForwards() []string {
+	if ui.mainFilter == "" {
+		return ui.forwardOrder
+	}
+
+	visible := make([]string, 0, len(ui.forwardOrder))
+	for _, id := range ui.forwardOrder {
+		fwd, ok := ui.forwards[id]
+		if !ok {
+			continue
+		}
+		if matchesMainFilter(fwd, ui.mainFilter) {
+			visible = append(visible, id)
+		}
+	}
+	return visible
+}
+
+// matchesMainFilter reports whether fwd is shown under filter: with a
+// "tag:" prefix when one of its tags equals the rest, ignoring case, or it
+// has any tag while the rest is still empty; otherwise when its alias,
+// resource, namespace or a tag contains filter.
+func matchesMainFilter(fwd *ForwardStatus, filter string) bool {
+	if tag, ok := strings.CutPrefix(filter, tagFilterPrefix); ok {
+		if tag == "" {
+			return len(fwd.Tags) > 0
+		}
+		for _, t := range fwd.Tags {
+			if strings.EqualFold(t, tag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matchesFilter(fwd.Alias, filter) ||
+		matchesFilter(fwd.Resource, filter) ||
+		matchesFilter(fwd.Namespace, filter) {
+		return true
+	}
+	for _, t := range fwd.Tags {
+		if matchesFilter(t, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// clampSelection keeps selectedIndex within the visible forwards.
+// Caller must hold ui.mu.
+func (ui *BubbleTeaUI) clampSelection() {
+	if n := len(ui.visibleForwards()); ui.selectedIndex >= n {
+		ui.selectedIndex = n - 1
+	}
+	if ui.selectedIndex < 0 {
+		ui.selectedIndex = 0
+	}
+}
+
+// handleMainFilterKeys handles typing into the main view filter
+func (m model) handleMainFilterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	defer m.ui.mu.Unlock()
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		// Cancel filter input, clear text
+		m.ui.mainFilterActive = false
+		m.ui.mainFilter = ""
+		m.ui.selectedIndex = 0
+	case "enter":
+		// Confirm filter
+		m.ui.mainFilterActive = false
+	case "backspace":
+		if len(m.ui.mainFilter) > 0 {
+			m.ui.mainFilter = m.ui.mainFilter[:len(m.ui.mainFilter)-1]
+			m.ui.selectedIndex = 0
+		}
+	default:
+		// Add character to filter
+		if len(msg.String()) == 1 {
+			char := rune(msg.String()[0])
+			if char >= 32 && char < 127 {
+				m.ui.mainFilter += string(char)
+				m.ui.selectedIndex = 0
+			}
+		}
+	}
+	return m, nil
+}
+
+// renderMainFilter renders the filter input line while typing, or the
+// applied filter afterwards. Caller must hold ui.mu.
+func (m model) renderMainFilter() string {
+	if m.ui.mainFilterActive {
+		return accentStyle.Render("Filter: ") + m.ui.mainFilter + accentStyle.Render("_") + "\n\n"
+	}
+	if m.ui.mainFilter != "" {
+		return accentStyle.Render(fmt.Sprintf("[Filter: \"%s\"]", m.ui.mainFilter)) +
+			mutedStyle.Render("  Esc to clear") + "\n\n"
+	}
+	return ""
+}
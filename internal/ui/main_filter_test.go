@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFilterTestModel returns a model with three forwards in two namespaces.
+func newFilterTestModel() model {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.AddForward("api", &config.Forward{Resource: "service/api", Port: 80, LocalPort: 8080, Alias: "api"})
+	ui.AddForward("db", &config.Forward{Resource: "service/postgres", Port: 5432, LocalPort: 5432, Alias: "db"})
+	ui.AddForward("cache", &config.Forward{Resource: "pod/redis", Port: 6379, LocalPort: 6379, Alias: "cache"})
+	ui.forwards["db"].Namespace = "data"
+	ui.forwards["cache"].Namespace = "data"
+	return model{ui: ui, termWidth: 120, termHeight: 40}
+}
+
+func typeKeys(m model, keys string) {
+	for _, r := range keys {
+		_, _ = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestMainFilter_MatchesAliasResourceNamespace(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"", []string{"api", "db", "cache"}},
+		{"API", []string{"api"}},          // alias, case-insensitive
+		{"postgres", []string{"db"}},      // resource
+		{"data", []string{"db", "cache"}}, // namespace
+		{"nothing", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			m := newFilterTestModel()
+			m.ui.mainFilter = tt.filter
+			assert.Equal(t, tt.want, m.ui.visibleForwards())
+		})
+	}
+}
+
+func TestMainFilter_Tags(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.AddForward("api", &config.Forward{Resource: "service/api", Port: 80, LocalPort: 8080, Alias: "api", Tags: []string{"payments", "backend"}})
+	ui.AddForward("web", &config.Forward{Resource: "service/web", Port: 80, LocalPort: 8081, Alias: "web", Tags: []string{"frontend"}})
+	ui.AddForward("db", &config.Forward{Resource: "service/postgres", Port: 5432, LocalPort: 5432, Alias: "db"})
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"end", []string{"api", "web"}},   // substring of a tag
+		{"tag:Payments", []string{"api"}}, // exact tag, case-insensitive
+		{"tag:pay", []string{}},           // tag: does not match substrings
+		{"tag:", []string{"api", "web"}},  // any tagged forward while typing
+		{"tag:postgres", []string{}},      // tag: ignores resource names
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			ui.mainFilter = tt.filter
+			assert.Equal(t, tt.want, ui.visibleForwards())
+		})
+	}
+}
+
+func TestMainFilter_TypeConfirmAndClear(t *testing.T) {
+	m := newFilterTestModel()
+
+	typeKeys(m, "/")
+	require.True(t, m.ui.mainFilterActive)
+	typeKeys(m, "dq") // 'q' is filter text here, not quit
+	_, _ = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Equal(t, "d", m.ui.mainFilter)
+	assert.Contains(t, m.renderMainView(), "Filter: d_")
+
+	_, _ = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.ui.mainFilterActive)
+	assert.Equal(t, "d", m.ui.mainFilter, "enter keeps the filter applied")
+	assert.Contains(t, m.renderMainView(), "Showing: 2/3")
+
+	_, _ = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Empty(t, m.ui.mainFilter)
+	assert.Contains(t, m.renderMainView(), "Total: 3")
+}
+
+func TestMainFilter_NavigationAndToggleUseFilteredList(t *testing.T) {
+	m := newFilterTestModel()
+	m.ui.mainFilter = "data"
+
+	m.ui.moveSelection(10)
+	assert.Equal(t, 1, m.ui.selectedIndex, "selection stops at the last visible forward")
+
+	m.ui.toggleSelected()
+	assert.True(t, m.ui.disabledMap["cache"])
+	assert.False(t, m.ui.disabledMap["api"])
+}
+
+func TestMainFilter_NoMatches(t *testing.T) {
+	m := newFilterTestModel()
+	m.ui.mainFilter = "nothing"
+
+	assert.Contains(t, m.renderMainView(), "No forwards match filter. (3 total forwards)")
+
+	// Actions on the selection do nothing when nothing is visible
+	m.ui.toggleSelected()
+	assert.Empty(t, m.ui.disabledMap)
+}
+
+func TestMainFilter_RemoveClampsSelection(t *testing.T) {
+	m := newFilterTestModel()
+	m.ui.mainFilter = "data"
+	m.ui.selectedIndex = 1
+
+	m.ui.Remove("cache")
+	assert.Equal(t, 0, m.ui.selectedIndex)
+}
@@ -16,12 +16,15 @@ type MockDiscovery struct {
 	ListContextsErr           error
 	GetCurrentContextErr      error
 	ListNamespacesErr         error
+	ListWorkloadsErr          error
 	LastSelector              string
 	CurrentContext            string
 	LastNamespace             string
 	LastContextName           string
+	LastWorkloadKind          string
 	PodsWithSelector          []k8s.PodInfo
 	Services                  []k8s.ServiceInfo
+	Workloads                 []k8s.WorkloadInfo
 	Pods                      []k8s.PodInfo
 	Namespaces                []string
 	Contexts                  []string
@@ -31,6 +34,7 @@ type MockDiscovery struct {
 	ListPodsCalls             int
 	ListPodsWithSelectorCalls int
 	ListServicesCalls         int
+	ListWorkloadsCalls        int
 	mu                        sync.Mutex
 }
 
@@ -94,6 +98,16 @@ func (m *MockDiscovery) ListServices(ctx context.Context, contextName, namespace
 	return m.Services, m.ListServicesErr
 }
 
+func (m *MockDiscovery) ListWorkloads(ctx context.Context, contextName, namespace, kind string) ([]k8s.WorkloadInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ListWorkloadsCalls++
+	m.LastContextName = contextName
+	m.LastNamespace = namespace
+	m.LastWorkloadKind = kind
+	return m.Workloads, m.ListWorkloadsErr
+}
+
 // MockMutator is a mock implementation of MutatorInterface for testing
 type MockMutator struct {
 	RemoveForwardByIDErr error
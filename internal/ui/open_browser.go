@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// launchBrowser opens a URL in the default browser. Tests replace it.
+var launchBrowser = openInBrowser
+
+// openInBrowser starts the platform's URL opener without waiting for it,
+// so a browser that stays in the foreground does not block the UI.
+func openInBrowser(url string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("xdg-open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// Reap the opener in the background so it does not linger as a zombie
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// openSelectedInBrowser opens the selected forward's URL in the default
+// browser and shows the outcome in the footer.
+func (m model) openSelectedInBrowser() (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	visible := m.ui.visibleForwards()
+	if m.ui.selectedIndex < 0 || m.ui.selectedIndex >= len(visible) {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+	fwd, ok := m.ui.forwards[visible[m.ui.selectedIndex]]
+	if !ok {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+
+	url := m.ui.forwardAddress(fwd, true)
+	m.ui.mu.Unlock()
+
+	message := "Opened " + url
+	if err := launchBrowser(url); err != nil {
+		message = "Browser unavailable"
+	}
+
+	m.ui.mu.Lock()
+	m.ui.copyMessage = message
+	m.ui.mu.Unlock()
+
+	return m, tea.Tick(copyMessageDuration, func(t time.Time) tea.Msg {
+		return clearCopyMessageMsg{}
+	})
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBrowser records opened URLs, failing them with err if set.
+func stubBrowser(t *testing.T, err error) *[]string {
+	t.Helper()
+	var opened []string
+	original := launchBrowser
+	launchBrowser = func(url string) error {
+		opened = append(opened, url)
+		return err
+	}
+	t.Cleanup(func() { launchBrowser = original })
+	return &opened
+}
+
+func TestOpenSelectedInBrowser(t *testing.T) {
+	opened := stubBrowser(t, nil)
+	m := newTestModelWithForward()
+
+	_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	require.NotNil(t, cmd, "confirmation should schedule its own dismissal")
+
+	assert.Equal(t, []string{"http://localhost:8080"}, *opened)
+	assert.Contains(t, m.renderMainView(), "Opened http://localhost:8080")
+}
+
+func TestOpenSelectedInBrowser_HTTPSScheme(t *testing.T) {
+	opened := stubBrowser(t, nil)
+	m := newTestModelWithForward()
+	for _, fwd := range m.ui.forwards {
+		fwd.Scheme = "https"
+	}
+
+	_, _ = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	assert.Equal(t, []string{"https://localhost:8080"}, *opened)
+}
+
+func TestOpenSelectedInBrowser_OpenerMissing(t *testing.T) {
+	stubBrowser(t, errors.New(`exec: "xdg-open": executable file not found in $PATH`))
+	m := newTestModelWithForward()
+
+	_, _ = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	assert.Equal(t, "Browser unavailable", m.ui.copyMessage)
+}
+
+func TestOpenSelectedInBrowser_NoForwards(t *testing.T) {
+	opened := stubBrowser(t, nil)
+	m := newTestModel()
+
+	_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	assert.Nil(t, cmd)
+	assert.Empty(t, *opened)
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// insertForward adds id to the forward list after every forward with the
+// same or a lower order, keeping the selection on the same forward, or
+// selecting id again when it was selected before a reload removed it.
+// Callers must hold ui.mu.
+func (ui *BubbleTeaUI) insertForward(id string) {
+	selected := ui.selectedForwardID()
+	if id == ui.reselectID {
+		selected, ui.reselectID = id, ""
+	}
+	key := config.OrderKey(ui.forwards[id].Order)
+
+	i := len(ui.forwardOrder)
+	for i > 0 && config.OrderKey(ui.forwards[ui.forwardOrder[i-1]].Order) > key {
+		i--
+	}
+	ui.forwardOrder = slices.Insert(ui.forwardOrder, i, id)
+	ui.selectForward(selected)
+}
+
+// SetForwardOrder updates the order of the listed forwards from forwards,
+// as configured after a reload, and sorts the list by it. Forwards with
+// the same order keep their place relative to each other.
+func (ui *BubbleTeaUI) SetForwardOrder(forwards []config.Forward) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	orders := make(map[string]int, len(forwards))
+	for i := range forwards {
+		orders[forwards[i].ID()] = forwards[i].Order
+	}
+	for id, fwd := range ui.forwards {
+		fwd.Order = orders[id]
+	}
+
+	selected := ui.selectedForwardID()
+	slices.SortStableFunc(ui.forwardOrder, func(a, b string) int {
+		return cmp.Compare(config.OrderKey(ui.forwards[a].Order), config.OrderKey(ui.forwards[b].Order))
+	})
+	ui.selectForward(selected)
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+func TestAddForward_Order(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	add := func(alias string, order int) {
+		ui.AddForward(alias+":80", &config.Forward{Resource: "service/" + alias, Port: 80, LocalPort: 80, Alias: alias, Order: order})
+	}
+
+	add("api", 0)
+	add("web", 2)
+	ui.selectedIndex = 0 // web, listed before api
+	add("db", 1)
+	add("cache", 2)
+	add("queue", 0)
+
+	assert.Equal(t, []string{"db:80", "web:80", "cache:80", "api:80", "queue:80"}, ui.forwardOrder)
+	assert.Equal(t, "web:80", ui.selectedForwardID(), "the selection stays on the same forward")
+}
+
+func TestSetForwardOrder(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	forwards := []config.Forward{
+		{Resource: "service/api", Port: 80, LocalPort: 80, Alias: "api"},
+		{Resource: "service/web", Port: 80, LocalPort: 81, Alias: "web"},
+		{Resource: "service/db", Port: 80, LocalPort: 82, Alias: "db"},
+	}
+	for i := range forwards {
+		ui.AddForward(forwards[i].ID(), &forwards[i])
+	}
+	ui.selectedIndex = 0 // api
+
+	forwards[2].Order = 1
+	ui.SetForwardOrder(forwards)
+
+	assert.Equal(t, []string{"db:82", "api:80", "web:81"}, ui.forwardOrder)
+	assert.Equal(t, 1, ui.forwards["db:82"].Order)
+	assert.Equal(t, "api:80", ui.selectedForwardID())
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// togglePauseAll stops every enabled forward, or starts again the ones it
+// stopped. Forwards that were already disabled stay disabled on resume. The
+// paused state lives only in the UI: nothing is written to the config.
+func (m model) togglePauseAll() (tea.Model, tea.Cmd) {
+	ui := m.ui
+	ui.mu.Lock()
+
+	var ids []string
+	enable := ui.paused
+	if ui.paused {
+		// Resume the forwards still paused; rows toggled back on by hand or
+		// removed since are left alone
+		for _, id := range ui.pausedIDs {
+			if _, ok := ui.forwards[id]; ok && ui.isForwardDisabled(id) {
+				ui.disabledMap[id] = false
+				ids = append(ids, id)
+			}
+		}
+		ui.pausedIDs = nil
+	} else {
+		for _, id := range ui.forwardOrder {
+			if !ui.isForwardDisabled(id) {
+				ui.disabledMap[id] = true
+				ids = append(ids, id)
+			}
+		}
+		ui.pausedIDs = ids
+	}
+	ui.paused = !ui.paused
+
+	ui.mu.Unlock()
+
+	// Call the toggle callback in a goroutine to avoid blocking the UI
+	if ui.toggleCallback != nil {
+		go func() {
+			for _, id := range ids {
+				ui.toggleCallback(id, enable)
+			}
+		}()
+	}
+	return m, nil
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// toggleRecorder records toggle callback calls, which arrive from goroutines
+type toggleRecorder struct {
+	calls map[string]bool
+	mu    sync.Mutex
+}
+
+func (r *toggleRecorder) toggle(id string, enable bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls[id] = enable
+}
+
+// waitFor waits until the recorder holds want
+func (r *toggleRecorder) waitFor(t *testing.T, want map[string]bool) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return assert.ObjectsAreEqual(want, r.calls)
+	}, time.Second, 5*time.Millisecond)
+	r.mu.Lock()
+	r.calls = make(map[string]bool)
+	r.mu.Unlock()
+}
+
+func newPauseTestModel(rec *toggleRecorder) model {
+	ui := NewBubbleTeaUI(rec.toggle, "1.0.0")
+	for _, id := range []string{"a", "b", "c"} {
+		ui.AddForward(id, &config.Forward{Resource: "pod/" + id, Port: 80, LocalPort: 8080})
+	}
+	return model{ui: ui, termWidth: 120, termHeight: 40}
+}
+
+func TestTogglePauseAll(t *testing.T) {
+	rec := &toggleRecorder{calls: make(map[string]bool)}
+	m := newPauseTestModel(rec)
+	pause := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")}
+
+	// "c" was disabled before pausing and must stay disabled on resume
+	m.ui.disabledMap["c"] = true
+
+	_, _ = m.handleMainViewKeys(pause)
+	rec.waitFor(t, map[string]bool{"a": false, "b": false})
+	assert.True(t, m.ui.paused)
+	assert.True(t, m.ui.isForwardDisabled("a"))
+	assert.True(t, m.ui.isForwardDisabled("b"))
+	assert.Contains(t, m.renderMainView(), "PAUSED")
+
+	_, _ = m.handleMainViewKeys(pause)
+	rec.waitFor(t, map[string]bool{"a": true, "b": true})
+	assert.False(t, m.ui.paused)
+	assert.False(t, m.ui.isForwardDisabled("a"))
+	assert.False(t, m.ui.isForwardDisabled("b"))
+	assert.True(t, m.ui.isForwardDisabled("c"))
+	assert.NotContains(t, m.renderMainView(), "PAUSED")
+}
+
+func TestTogglePauseAll_SkipsForwardsResumedByHand(t *testing.T) {
+	rec := &toggleRecorder{calls: make(map[string]bool)}
+	m := newPauseTestModel(rec)
+
+	_, _ = m.togglePauseAll()
+	rec.waitFor(t, map[string]bool{"a": false, "b": false, "c": false})
+
+	// "a" is toggled back on by hand and "b" is removed while paused
+	m.ui.disabledMap["a"] = false
+	m.ui.Remove("b")
+
+	_, _ = m.togglePauseAll()
+	rec.waitFor(t, map[string]bool{"c": true})
+}
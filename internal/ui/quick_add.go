@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// readClipboard returns the text on the system clipboard. Tests replace it.
+var readClipboard = pasteFromClipboard
+
+// forwardSpecPorts splits the ports off a forward spec: the remote port and
+// an optional local port at the end. Matching from the end keeps context
+// names containing colons, such as EKS ARNs, intact.
+var forwardSpecPorts = regexp.MustCompile(`^(.+?):(\d+)(?::(\d+))?$`)
+
+// errClipboardUnavailable is reported when the clipboard cannot be read
+var errClipboardUnavailable = errors.New("clipboard unavailable")
+
+// QuickAddedMsg is sent when a forward pasted from the clipboard has been
+// written to the config, or the clipboard could not be read or parsed
+type QuickAddedMsg struct {
+	err      error
+	resource string
+	local    int
+}
+
+// parseForwardSpec parses a context/namespace/resource:remote[:local] spec,
+// e.g. prod/default/service/api:80:8080. The resource is type/name, so the
+// namespace and context are the two segments before it; the context may
+// itself contain slashes. The local port defaults to the remote port, as
+// with kubectl port-forward.
+func parseForwardSpec(spec string) (contextName, namespace string, fwd config.Forward, err error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return "", "", fwd, errors.New("clipboard is empty")
+	}
+	if strings.ContainsAny(spec, " \t\r\n") {
+		return "", "", fwd, errors.New("expected a single context/namespace/resource:port spec")
+	}
+
+	match := forwardSpecPorts.FindStringSubmatch(spec)
+	if match == nil {
+		return "", "", fwd, fmt.Errorf("%q has no port, expected context/namespace/resource:remote[:local]", spec)
+	}
+	remote, err := strconv.Atoi(match[2])
+	if err != nil {
+		return "", "", fwd, fmt.Errorf("invalid remote port %q", match[2])
+	}
+	local := remote
+	if match[3] != "" {
+		if local, err = strconv.Atoi(match[3]); err != nil {
+			return "", "", fwd, fmt.Errorf("invalid local port %q", match[3])
+		}
+	}
+
+	parts := strings.Split(match[1], "/")
+	if len(parts) < 4 {
+		return "", "", fwd, fmt.Errorf("%q is missing a part, expected context/namespace/resource:remote[:local]", match[1])
+	}
+	n := len(parts)
+	contextName = strings.Join(parts[:n-3], "/")
+	namespace = parts[n-3]
+	for _, part := range []string{contextName, namespace, parts[n-2], parts[n-1]} {
+		if part == "" {
+			return "", "", fwd, fmt.Errorf("%q has an empty part, expected context/namespace/resource:remote[:local]", match[1])
+		}
+	}
+
+	fwd = config.Forward{
+		Resource:  parts[n-2] + "/" + parts[n-1],
+		Protocol:  "tcp",
+		Port:      remote,
+		LocalPort: local,
+	}
+	return contextName, namespace, fwd, nil
+}
+
+// quickAddFromClipboard adds the forward spec on the clipboard to the
+// config without opening the wizard. The config is validated as with any
+// other change, and the file watcher then starts the forward. The outcome,
+// including a spec that does not parse, is shown in the footer.
+func (m model) quickAddFromClipboard() (tea.Model, tea.Cmd) {
+	m.ui.mu.RLock()
+	mutator := m.ui.mutator
+	m.ui.mu.RUnlock()
+
+	if mutator == nil {
+		return m, nil
+	}
+
+	return m, func() tea.Msg {
+		text, err := readClipboard()
+		if err != nil {
+			return QuickAddedMsg{err: errClipboardUnavailable}
+		}
+		contextName, namespace, fwd, err := parseForwardSpec(text)
+		if err != nil {
+			return QuickAddedMsg{err: err}
+		}
+		err = mutator.AddForward(contextName, namespace, fwd)
+		return QuickAddedMsg{err: err, resource: fwd.Resource, local: fwd.LocalPort}
+	}
+}
+
+// handleQuickAdded shows the outcome of a quick add in the footer
+func (m model) handleQuickAdded(msg QuickAddedMsg) (tea.Model, tea.Cmd) {
+	if errors.Is(msg.err, errClipboardUnavailable) {
+		return m.showFooterMessage("Clipboard unavailable")
+	}
+	if msg.err != nil {
+		return m.showFooterMessage("Quick add failed: " + firstErrorLine(msg.err))
+	}
+	return m.showFooterMessage(fmt.Sprintf("Added %s on localhost:%d", msg.resource, msg.local))
+}
+
+// firstErrorLine condenses err to one line for the footer. Validation
+// errors span a numbered list under a heading; their first entry is kept.
+func firstErrorLine(err error) string {
+	text := err.Error()
+	if _, list, ok := strings.Cut(text, "\n1. "); ok {
+		first, _, _ := strings.Cut(list, "\n")
+		return first
+	}
+	first, _, _ := strings.Cut(text, "\n")
+	return strings.TrimSuffix(strings.TrimSpace(first), ":")
+}
+
+// pasteFromClipboard reads the system clipboard using OS-specific commands,
+// the counterpart of copyToClipboard.
+func pasteFromClipboard() (string, error) {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("pbpaste")
+	case "linux":
+		// Try xclip first, fall back to xsel
+		if _, err := exec.LookPath("xclip"); err == nil {
+			cmd = exec.Command("xclip", "-selection", "clipboard", "-o")
+		} else if _, err := exec.LookPath("xsel"); err == nil {
+			cmd = exec.Command("xsel", "--clipboard", "--output")
+		} else {
+			return "", fmt.Errorf("no clipboard tool found (install xclip or xsel)")
+		}
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", "Get-Clipboard")
+	default:
+		return "", fmt.Errorf("unsupported platform: %s", runtime.GOOS)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+// stubClipboardText makes clipboard reads return text, or fail with err if
+// set.
+func stubClipboardText(t *testing.T, text string, err error) {
+	t.Helper()
+	original := readClipboard
+	readClipboard = func() (string, error) { return text, err }
+	t.Cleanup(func() { readClipboard = original })
+}
+
+func TestParseForwardSpec(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      string
+		context   string
+		namespace string
+		resource  string
+		remote    int
+		local     int
+	}{
+		{"local port", "prod/default/service/api:80:8080", "prod", "default", "service/api", 80, 8080},
+		{"local defaults to remote", "prod/default/pod/web-0:5432\n", "prod", "default", "pod/web-0", 5432, 5432},
+		{"context with slashes and colons", "arn:aws:eks:eu-west-1:123456789012:cluster/main/payments/deployment/api:9090", "arn:aws:eks:eu-west-1:123456789012:cluster/main", "payments", "deployment/api", 9090, 9090},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contextName, namespace, fwd, err := parseForwardSpec(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.context, contextName)
+			assert.Equal(t, tt.namespace, namespace)
+			assert.Equal(t, tt.resource, fwd.Resource)
+			assert.Equal(t, tt.remote, fwd.Port)
+			assert.Equal(t, tt.local, fwd.LocalPort)
+			assert.Equal(t, "tcp", fwd.Protocol)
+		})
+	}
+
+	for _, spec := range []string{"", "prod/default/service/api", "default/service/api:80", "prod//service/api:80", "prod/default/service/api:http", "a/b/service/x:80\nc/d/service/y:81"} {
+		_, _, _, err := parseForwardSpec(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestQuickAddFromClipboard(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	initial := `contexts:
+  - name: ctx
+    namespaces:
+      - name: ns
+        forwards:
+          - resource: service/db
+            protocol: tcp
+            port: 5432
+            localPort: 5432
+`
+	require.NoError(t, os.WriteFile(cfgPath, []byte(initial), 0o600))
+	m := newTestModelWithForward()
+	m.ui.mutator = config.NewMutator(cfgPath)
+	press := func() tea.Msg {
+		_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+		require.NotNil(t, cmd)
+		return cmd()
+	}
+
+	stubClipboardText(t, "ctx/ns/service/api:80:8080", nil)
+	msg, ok := press().(QuickAddedMsg)
+	require.True(t, ok, "a valid spec is written to the config")
+	require.NoError(t, msg.err)
+	_, _ = m.Update(msg)
+	assert.Equal(t, "Added service/api on localhost:8080", m.ui.copyMessage)
+
+	cfg, err := config.LoadConfig(cfgPath)
+	require.NoError(t, err)
+	assert.Len(t, cfg.GetAllForwards(), 2)
+
+	stubClipboardText(t, "ctx/ns/service/web:80:5432", nil)
+	msg, ok = press().(QuickAddedMsg)
+	require.True(t, ok)
+	_, _ = m.Update(msg)
+	assert.Contains(t, m.ui.copyMessage, "Quick add failed: port 5432 is already in use")
+
+	stubClipboardText(t, "ctx/ns/service/Bad_Name:80:9000", nil)
+	_, _ = m.Update(press())
+	assert.Contains(t, m.ui.copyMessage, "Quick add failed: ")
+	assert.NotContains(t, m.ui.copyMessage, "\n", "validation errors are condensed to one line")
+
+	stubClipboardText(t, "not a spec", nil)
+	_, cmd := m.Update(press())
+	assert.NotNil(t, cmd, "the error toast schedules its own dismissal")
+	assert.Contains(t, m.ui.copyMessage, "Quick add failed: ")
+
+	stubClipboardText(t, "", errors.New("no clipboard tool found"))
+	_, _ = m.Update(press())
+	assert.Equal(t, "Clipboard unavailable", m.ui.copyMessage)
+
+	cfg, err = config.LoadConfig(cfgPath)
+	require.NoError(t, err)
+	assert.Len(t, cfg.GetAllForwards(), 2, "failed quick adds leave the config as it was")
+}
+
+func TestQuickAddFromClipboard_ReadOnly(t *testing.T) {
+	stubClipboardText(t, "ctx/ns/service/api:80:8080", nil)
+	m := newTestModelWithForward()
+	m.ui.mutator = config.NewMutator(filepath.Join(t.TempDir(), ".kportal.yaml"))
+	m.ui.SetReadOnly(true)
+
+	_, _ = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	assert.Equal(t, readOnlyMessage, m.ui.copyMessage)
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// readOnlyMessage is shown in the footer when a key that would change the
+// config is pressed in read-only mode.
+const readOnlyMessage = "Read-only: edit the config file to change forwards"
+
+// SetReadOnly turns read-only mode on or off. In read-only mode the add,
+// edit, delete and paste keys are grayed out and do nothing but explain why;
+// toggling, pausing and every viewer keep working, since they never write
+// the config.
+func (ui *BubbleTeaUI) SetReadOnly(readOnly bool) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.readOnly = readOnly
+}
+
+// isConfigKey reports whether key opens a wizard that writes the config,
+// or writes it directly as quick add does.
+func isConfigKey(key string) bool {
+	switch key {
+	case "n", "e", "d", "p":
+		return true
+	}
+	return false
+}
+
+// showReadOnlyNotice explains in the footer that the config cannot be
+// changed.
+func (m model) showReadOnlyNotice() (tea.Model, tea.Cmd) {
+	return m.showFooterMessage(readOnlyMessage)
+}
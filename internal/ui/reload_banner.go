@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// reloadBannerDuration is how long the config reload banner stays visible.
+const reloadBannerDuration = 4 * time.Second
+
+// ConfigReloadMsg is sent after the config watcher tried to reload the
+// configuration. Err is nil when the new configuration was applied.
+type ConfigReloadMsg struct {
+	Err error
+}
+
+// clearReloadBannerMsg dismisses the reload banner shown for seq, unless a
+// newer reload has replaced it since.
+type clearReloadBannerMsg struct {
+	seq int
+}
+
+// NotifyConfigReload shows a transient banner with the outcome of a config
+// reload. err is nil when the reload succeeded.
+func (ui *BubbleTeaUI) NotifyConfigReload(err error) {
+	if ui.program != nil {
+		ui.program.Send(ConfigReloadMsg{Err: err})
+	}
+}
+
+// handleConfigReload shows the reload banner and schedules its dismissal.
+func (m model) handleConfigReload(msg ConfigReloadMsg) (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	m.ui.reloadBannerSeq++
+	seq := m.ui.reloadBannerSeq
+	if msg.Err != nil {
+		// Multi-line errors are flattened to fit the single banner line
+		m.ui.reloadBanner = "Reload failed: " + strings.Join(strings.Fields(msg.Err.Error()), " ")
+		m.ui.reloadBannerFailed = true
+	} else {
+		m.ui.reloadBanner = "Config reloaded"
+		m.ui.reloadBannerFailed = false
+	}
+	m.ui.mu.Unlock()
+
+	return m, tea.Tick(reloadBannerDuration, func(t time.Time) tea.Msg {
+		return clearReloadBannerMsg{seq: seq}
+	})
+}
+
+// handleClearReloadBanner hides the banner if no newer reload replaced it.
+func (m model) handleClearReloadBanner(msg clearReloadBannerMsg) (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	if msg.seq == m.ui.reloadBannerSeq {
+		m.ui.reloadBanner = ""
+	}
+	m.ui.mu.Unlock()
+	return m, nil
+}
+
+// renderReloadBanner renders the reload banner, or nothing when none is
+// showing. Caller must hold ui.mu.
+func (m model) renderReloadBanner(colors mainViewColors, termWidth int) string {
+	if m.ui.reloadBanner == "" {
+		return ""
+	}
+
+	color := colors.active
+	if m.ui.reloadBannerFailed {
+		color = colors.errorColor
+	}
+	style := lipgloss.NewStyle().Bold(true).Foreground(color).Padding(0, 1)
+	return style.Render(truncate(m.ui.reloadBanner, errorWidth(termWidth))) + "\n\n"
+}
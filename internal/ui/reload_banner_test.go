@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigReloadBanner_Success(t *testing.T) {
+	m := newTestModel()
+
+	_, cmd := m.Update(ConfigReloadMsg{})
+	require.NotNil(t, cmd, "banner should schedule its own dismissal")
+	assert.Contains(t, m.renderMainView(), "Config reloaded")
+
+	_, _ = m.Update(clearReloadBannerMsg{seq: m.ui.reloadBannerSeq})
+	assert.NotContains(t, m.renderMainView(), "Config reloaded")
+}
+
+func TestConfigReloadBanner_Failure(t *testing.T) {
+	m := newTestModel()
+
+	_, _ = m.Update(ConfigReloadMsg{Err: errors.New("failed to parse YAML:\n  line 3: mapping values are not allowed")})
+	view := m.renderMainView()
+	assert.Contains(t, view, "Reload failed: failed to parse YAML: line 3: mapping values are not allowed")
+	assert.True(t, m.ui.reloadBannerFailed)
+}
+
+// TestConfigReloadBanner_NewerReloadKeepsBanner verifies a dismissal
+// scheduled by an older reload does not hide the banner of a newer one.
+func TestConfigReloadBanner_NewerReloadKeepsBanner(t *testing.T) {
+	m := newTestModel()
+
+	_, _ = m.Update(ConfigReloadMsg{Err: errors.New("config validation failed: bad port")})
+	stale := m.ui.reloadBannerSeq
+	_, _ = m.Update(ConfigReloadMsg{})
+
+	_, _ = m.Update(clearReloadBannerMsg{seq: stale})
+	view := m.renderMainView()
+	assert.Contains(t, view, "Config reloaded")
+	assert.NotContains(t, view, "Reload failed")
+}
+
+func TestNotifyConfigReload_NoProgram(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	assert.NotPanics(t, func() { ui.NotifyConfigReload(errors.New("boom")) })
+}
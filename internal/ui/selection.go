@@ -0,0 +1,23 @@
+package ui
+
+import "slices"
+
+// selectedForwardID returns the ID of the selected forward, or "" when
+// none is. Callers must hold ui.mu.
+func (ui *BubbleTeaUI) selectedForwardID() string {
+	visible := ui.visibleForwards()
+	if ui.selectedIndex < 0 || ui.selectedIndex >= len(visible) {
+		return ""
+	}
+	return visible[ui.selectedIndex]
+}
+
+// selectForward moves the selection to id if it is listed, or else keeps
+// the selection in range. Callers must hold ui.mu.
+func (ui *BubbleTeaUI) selectForward(id string) {
+	if i := slices.Index(ui.visibleForwards(), id); id != "" && i >= 0 {
+		ui.selectedIndex = i
+		return
+	}
+	ui.clampSelection()
+}
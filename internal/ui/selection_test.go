@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lukaszraczylo/kportal/internal/config"
+)
+
+func TestSelection_SurvivesReload(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	add := func(id string) {
+		ui.AddForward(id, &config.Forward{Resource: "pod/" + id, Port: 8080, LocalPort: 8080})
+	}
+	for _, id := range []string{"a", "b", "c", "d"} {
+		add(id)
+	}
+	ui.selectedIndex = 2 // c
+
+	// A reload removes and adds back forwards around and including the
+	// selected one
+	ui.Remove("a")
+	assert.Equal(t, "c", ui.selectedForwardID())
+	ui.Remove("c")
+	assert.Equal(t, "d", ui.selectedForwardID(), "the next forward is selected meanwhile")
+	add("e")
+	add("c")
+	assert.Equal(t, "c", ui.selectedForwardID(), "the forward is selected again once it is back")
+
+	// Once the user moves on, a returning forward leaves the selection alone
+	ui.Remove("c")
+	ui.moveSelection(-1)
+	selected := ui.selectedForwardID()
+	add("c")
+	assert.Equal(t, selected, ui.selectedForwardID())
+}
@@ -5,22 +5,112 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"github.com/lukaszraczylo/kportal/internal/config"
 )
 
+// statusHistorySize is how many status changes a forward keeps for the
+// detail panel.
+const statusHistorySize = 10
+
+// StatusEvent is a status a forward changed to
+type StatusEvent struct {
+	At     time.Time
+	Status string
+}
+
 // ForwardStatus represents the current status of a port forward
 type ForwardStatus struct {
-	HTTPLog    *config.HTTPLogSpec
-	Context    string
-	Namespace  string
-	Alias      string
-	Type       string
-	Resource   string
-	Status     string
-	RemotePort int
-	LocalPort  int
+	ConnectedSince      time.Time // when the tunnel last came up, zero while it is down
+	LastErrorAt         time.Time // when LastError was reported
+	HTTPLog             *config.HTTPLogSpec
+	HealthCheck         *config.ProbeSpec
+	Enabled             *bool
+	MDNSPublish         *bool
+	Tags                []string
+	History             []StatusEvent // latest status changes, oldest first
+	Requests            []int64       // HTTP requests per second, oldest first, refreshed while the activity column is shown
+	Context             string
+	Namespace           string
+	Alias               string
+	Type                string
+	Resource            string
+	ResourceSpec        string // resource as configured, e.g. "service/api"
+	Selector            string
+	Protocol            string
+	Container           string
+	PortName            string
+	TCPKeepalive        string
+	DialTimeout         string
+	Scheme              string // URL scheme as configured, "" means http
+	BindAddress         string // local IP as configured, "" means both loopbacks
+	Status              string
+	Pod                 string // pod the tunnel last connected to, if reported
+	Error               string // last error, cleared when the forward is Active again
+	LastError           string // last error, kept after the forward recovers
+	BytesSent           int64  // cumulative, refreshed while traffic columns are shown
+	BytesReceived       int64
+	SendRate            int64 // bytes/sec, refreshed with the byte counts
+	ReceiveRate         int64
+	Tunnels             int64         // port-forward connections opened
+	Streams             int64         // client connections carried by those tunnels
+	SetupTime           time.Duration // how long the latest client connection took to open
+	RemotePort          int
+	LocalPort           int
+	ReconnectMaxRetries int
+	IdleTimeout         int
+	Order               int  // display position as configured, 0 after the ordered forwards
+	Connections         int  // open client connections
+	TraceBytes          bool // byte trace is kept for the trace panel
+}
+
+// setStatus records a status change, starting the uptime clock when the
+// tunnel comes up and stopping it when the tunnel goes down.
+func (s *ForwardStatus) setStatus(status string, now time.Time) {
+	if status != s.Status || len(s.History) == 0 {
+		s.History = append(s.History, StatusEvent{At: now, Status: status})
+		if len(s.History) > statusHistorySize {
+			s.History = s.History[len(s.History)-statusHistorySize:]
+		}
+	}
+	s.Status = status
+	switch {
+	case !isConnectedStatus(status):
+		s.ConnectedSince = time.Time{}
+	case s.ConnectedSince.IsZero():
+		s.ConnectedSince = now
+	}
+}
+
+// recordError remembers the forward's most recent error and when it happened.
+func (s *ForwardStatus) recordError(msg string, now time.Time) {
+	s.LastError = msg
+	s.LastErrorAt = now
+}
+
+// isConnectedStatus reports whether the tunnel is up in the given status.
+// A failing HTTP probe or an aged connection still has a working tunnel.
+func isConnectedStatus(status string) bool {
+	switch statusKind(status) {
+	case "Active", "Unhealthy", "Stale":
+		return true
+	}
+	return false
+}
+
+// formatUptime renders how long a forward has been connected, truncated to
+// whole seconds (e.g. "2m13s"), or "-" when it is not connected.
+func formatUptime(since, now time.Time) string {
+	if since.IsZero() {
+		return "-"
+	}
+	uptime := now.Sub(since)
+	if uptime < 0 {
+		uptime = 0
+	}
+	return uptime.Truncate(time.Second).String()
 }
 
 // TableUI manages the terminal table display
@@ -78,8 +168,44 @@ func (t *TableUI) UpdateStatus(id string, status string) {
 	defer t.mu.Unlock()
 
 	if fwd, ok := t.forwards[id]; ok {
-		fwd.Status = status
+		fwd.setStatus(status, time.Now())
+		if status == "Active" {
+			fwd.Error = ""
+		}
+	}
+}
+
+// SetError records the last error for a forward
+func (t *TableUI) SetError(id, msg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if fwd, ok := t.forwards[id]; ok {
+		fwd.Error = msg
+		fwd.recordError(msg, time.Now())
+	}
+}
+
+// SetPod records the pod a forward's tunnel connects to
+func (t *TableUI) SetPod(id, pod string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if fwd, ok := t.forwards[id]; ok {
+		fwd.Pod = pod
+	}
+}
+
+// Snapshot returns a copy of every forward's status keyed by forward ID
+func (t *TableUI) Snapshot() map[string]ForwardStatus {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	snapshot := make(map[string]ForwardStatus, len(t.forwards))
+	for id, fwd := range t.forwards {
+		snapshot[id] = *fwd
 	}
+	return snapshot
 }
 
 // Render displays the current table
@@ -192,7 +318,11 @@ func (t *TableUI) Remove(id string) {
 // hyperlink wraps text in an OSC 8 terminal hyperlink escape sequence.
 // Clicking the text opens the URL in terminals that support it (Ghostty, iTerm2,
 // Windows Terminal, Kitty, WezTerm, etc.). Unsupported terminals show plain text.
+// With colors disabled the text is returned as is.
 func hyperlink(url, text string) string {
+	if noColor {
+		return text
+	}
 	return fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", url, text)
 }
 
@@ -215,33 +345,37 @@ func truncate(s string, maxLen int) string {
 
 // formatStatusWithIndicator adds color-coded indicator symbols to status
 func formatStatusWithIndicator(status string) string {
-	// Check if stdout is a terminal
-	if fileInfo, _ := os.Stdout.Stat(); (fileInfo.Mode() & os.ModeCharDevice) == 0 {
-		// Not a terminal, return plain text with simple indicator
-		switch status {
+	// Check if stdout is a terminal and colors are wanted
+	if fileInfo, _ := os.Stdout.Stat(); noColor || (fileInfo.Mode()&os.ModeCharDevice) == 0 {
+		// Return plain text with simple indicator
+		switch statusKind(status) {
 		case "Active":
 			return "✓ " + status
 		case "Starting":
 			return "⋯ " + status
 		case "Reconnecting":
 			return "↻ " + status
-		case "Error", "Failed":
+		case "Error", "Failed", "Context not found", "Permission denied":
 			return "✗ " + status
+		case "Unhealthy":
+			return "▲ " + status
 		default:
 			return status
 		}
 	}
 
 	// Terminal with color support
-	switch status {
+	switch statusKind(status) {
 	case "Active":
 		return "\033[32m●\033[0m " + status // Green circle
 	case "Starting":
 		return "\033[33m○\033[0m " + status // Yellow circle (hollow)
 	case "Reconnecting":
 		return "\033[33m◐\033[0m " + status // Yellow half-circle
-	case "Error", "Failed":
+	case "Error", "Failed", "Context not found", "Permission denied":
 		return "\033[31m●\033[0m " + status // Red circle
+	case "Unhealthy":
+		return "\033[38;5;208m▲\033[0m " + status // Orange triangle
 	default:
 		return status
 	}
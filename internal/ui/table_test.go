@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/lukaszraczylo/kportal/internal/config"
 	"github.com/stretchr/testify/assert"
@@ -98,6 +99,109 @@ func TestTableUI_UpdateStatus(t *testing.T) {
 	tui.UpdateStatus("nonexistent", "Active")
 }
 
+// TestTableUI_ErrorAndPod covers the optional status hooks used by the
+// forward workers and the snapshot taken from them.
+func TestTableUI_ErrorAndPod(t *testing.T) {
+	tui := NewTableUI(false)
+	fwd := &config.Forward{Resource: "pod/app", Port: 80, LocalPort: 8080}
+	tui.AddForward("id-1", fwd)
+
+	tui.SetPod("id-1", "app-7d9f")
+	tui.UpdateStatus("id-1", "Reconnecting (2s, attempt 1)")
+	tui.SetError("id-1", "connection refused")
+
+	snapshot := tui.Snapshot()
+	require.Contains(t, snapshot, "id-1")
+	assert.Equal(t, "app-7d9f", snapshot["id-1"].Pod)
+	assert.Equal(t, "connection refused", snapshot["id-1"].Error)
+
+	// The snapshot is a copy
+	tui.SetPod("id-1", "app-8e0a")
+	assert.Equal(t, "app-7d9f", snapshot["id-1"].Pod)
+
+	// Becoming Active clears the error but keeps the pod
+	tui.UpdateStatus("id-1", "Active")
+	got := tui.GetForward("id-1")
+	assert.Empty(t, got.Error)
+	assert.Equal(t, "app-8e0a", got.Pod)
+
+	// The last error and its time outlive recovery
+	assert.Equal(t, "connection refused", got.LastError)
+	assert.False(t, got.LastErrorAt.IsZero())
+	assert.False(t, got.ConnectedSince.IsZero())
+
+	// Unknown IDs must not panic
+	tui.SetPod("nonexistent", "x")
+	tui.SetError("nonexistent", "x")
+}
+
+// TestForwardStatus_SetStatus covers the uptime clock across status changes.
+func TestForwardStatus_SetStatus(t *testing.T) {
+	start := time.Date(2026, 5, 6, 12, 0, 0, 0, time.UTC)
+	fwd := &ForwardStatus{}
+
+	fwd.setStatus("Starting", start)
+	assert.True(t, fwd.ConnectedSince.IsZero())
+
+	fwd.setStatus("Active", start)
+	assert.Equal(t, start, fwd.ConnectedSince)
+
+	// Repeated health reports and a failing probe keep the tunnel's start time
+	fwd.setStatus("Active", start.Add(time.Minute))
+	fwd.setStatus("Unhealthy", start.Add(2*time.Minute))
+	assert.Equal(t, start, fwd.ConnectedSince)
+
+	fwd.setStatus("Reconnecting (2s, attempt 1)", start.Add(3*time.Minute))
+	assert.True(t, fwd.ConnectedSince.IsZero())
+
+	fwd.setStatus("Active", start.Add(4*time.Minute))
+	assert.Equal(t, start.Add(4*time.Minute), fwd.ConnectedSince)
+}
+
+// TestForwardStatus_History covers the status history kept for the detail
+// panel.
+func TestForwardStatus_History(t *testing.T) {
+	start := time.Date(2026, 5, 6, 12, 0, 0, 0, time.UTC)
+	fwd := &ForwardStatus{}
+
+	fwd.setStatus("Active", start)
+	fwd.setStatus("Active", start.Add(time.Minute))
+	fwd.setStatus("Error", start.Add(2*time.Minute))
+	assert.Equal(t, []StatusEvent{
+		{At: start, Status: "Active"},
+		{At: start.Add(2 * time.Minute), Status: "Error"},
+	}, fwd.History, "repeated statuses are recorded once")
+
+	for i := range statusHistorySize * 2 {
+		fwd.setStatus(fmt.Sprintf("Reconnecting (attempt %d)", i), start.Add(time.Hour))
+	}
+	assert.Len(t, fwd.History, statusHistorySize)
+	assert.Equal(t, fmt.Sprintf("Reconnecting (attempt %d)", statusHistorySize*2-1), fwd.History[statusHistorySize-1].Status)
+}
+
+// TestFormatUptime covers uptime rendering.
+func TestFormatUptime(t *testing.T) {
+	now := time.Date(2026, 5, 6, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		since time.Time
+		name  string
+		want  string
+	}{
+		{time.Time{}, "not connected", "-"},
+		{now, "just connected", "0s"},
+		{now.Add(-133*time.Second - 400*time.Millisecond), "minutes", "2m13s"},
+		{now.Add(-26 * time.Hour), "hours", "26h0m0s"},
+		{now.Add(time.Second), "clock skew", "0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatUptime(tt.since, now))
+		})
+	}
+}
+
 // TestTableUI_GetForward covers the lookup path.
 func TestTableUI_GetForward(t *testing.T) {
 	tui := NewTableUI(false)
@@ -166,7 +270,7 @@ func TestHyperlink(t *testing.T) {
 
 // TestFormatStatusWithIndicator covers all status branches.
 func TestFormatStatusWithIndicator(t *testing.T) {
-	statuses := []string{"Active", "Starting", "Reconnecting", "Error", "Failed", "Unknown"}
+	statuses := []string{"Active", "Starting", "Reconnecting", "Error", "Failed", "Context not found", "Permission denied", "Unhealthy", "Reconnecting (2s, attempt 1)", "Unknown"}
 	for _, s := range statuses {
 		t.Run(s, func(t *testing.T) {
 			result := formatStatusWithIndicator(s)
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Built-in theme names, selected with the `theme:` config key or -theme flag.
+const (
+	ThemeDark  = "dark"
+	ThemeLight = "light"
+)
+
+// Theme is the color palette the UI is drawn with. An empty color leaves
+// the terminal's default in place.
+type Theme struct {
+	Name string
+
+	// Wizard and modal palette
+	Primary   lipgloss.Color // selections, wizard titles
+	OnPrimary lipgloss.Color // text on a Primary background
+	Success   lipgloss.Color
+	Error     lipgloss.Color
+	Warning   lipgloss.Color
+	Muted     lipgloss.Color // hints, disabled rows, footer
+	Accent    lipgloss.Color // borders, spinners
+	Highlight lipgloss.Color // breadcrumbs, hints
+	Text      lipgloss.Color // input and info text
+
+	// Main view
+	Header     lipgloss.Color // title, table header, footer keys
+	Active     lipgloss.Color
+	Unhealthy  lipgloss.Color
+	SelectedBg lipgloss.Color // selected row; reverse video when empty
+	SelectedFg lipgloss.Color
+
+	// JSON syntax highlighting in the HTTP log viewer
+	JSONKey    lipgloss.Color
+	JSONString lipgloss.Color
+	JSONNumber lipgloss.Color
+	JSONBool   lipgloss.Color
+	JSONNull   lipgloss.Color
+}
+
+// DarkTheme returns the default palette, tuned for dark terminal backgrounds.
+func DarkTheme() Theme {
+	return Theme{
+		Name:       ThemeDark,
+		Primary:    lipgloss.Color("205"), // Pink/Magenta
+		OnPrimary:  lipgloss.Color("230"), // Light yellow
+		Success:    lipgloss.Color("42"),  // Green
+		Error:      lipgloss.Color("196"), // Red
+		Warning:    lipgloss.Color("220"), // Yellow
+		Muted:      lipgloss.Color("241"), // Gray
+		Accent:     lipgloss.Color("63"),  // Purple
+		Highlight:  lipgloss.Color("117"), // Light blue
+		Text:       lipgloss.Color("252"), // Light gray
+		Header:     lipgloss.Color("220"), // Yellow
+		Active:     lipgloss.Color("46"),  // Green
+		Unhealthy:  lipgloss.Color("208"), // Orange
+		SelectedBg: lipgloss.Color("240"), // Gray background
+		SelectedFg: lipgloss.Color("230"), // Light foreground
+		JSONKey:    lipgloss.Color("81"),  // Cyan
+		JSONString: lipgloss.Color("180"), // Light orange/tan
+		JSONNumber: lipgloss.Color("141"), // Light purple
+		JSONBool:   lipgloss.Color("209"), // Orange
+		JSONNull:   lipgloss.Color("243"), // Dark gray
+	}
+}
+
+// LightTheme returns a palette readable on light terminal backgrounds.
+func LightTheme() Theme {
+	return Theme{
+		Name:       ThemeLight,
+		Primary:    lipgloss.Color("125"), // Dark magenta
+		OnPrimary:  lipgloss.Color("231"), // White
+		Success:    lipgloss.Color("28"),  // Dark green
+		Error:      lipgloss.Color("160"), // Red
+		Warning:    lipgloss.Color("130"), // Dark orange
+		Muted:      lipgloss.Color("244"), // Mid gray
+		Accent:     lipgloss.Color("55"),  // Purple
+		Highlight:  lipgloss.Color("25"),  // Blue
+		Text:       lipgloss.Color("236"), // Dark gray
+		Header:     lipgloss.Color("130"), // Dark orange
+		Active:     lipgloss.Color("28"),  // Dark green
+		Unhealthy:  lipgloss.Color("166"), // Orange
+		SelectedBg: lipgloss.Color("252"), // Light gray background
+		SelectedFg: lipgloss.Color("232"), // Near black
+		JSONKey:    lipgloss.Color("30"),  // Teal
+		JSONString: lipgloss.Color("94"),  // Brown
+		JSONNumber: lipgloss.Color("91"),  // Purple
+		JSONBool:   lipgloss.Color("166"), // Orange
+		JSONNull:   lipgloss.Color("245"), // Gray
+	}
+}
+
+// noColorTheme keeps only text attributes such as bold, for NO_COLOR.
+func noColorTheme(name string) Theme {
+	return Theme{Name: name}
+}
+
+// SelectTheme returns the built-in theme called name, defaulting to dark.
+// With noColor set, as when the NO_COLOR environment variable is present,
+// every color is dropped whichever theme is named.
+func SelectTheme(name string, noColor bool) (Theme, error) {
+	var theme Theme
+	switch name {
+	case "", ThemeDark:
+		theme = DarkTheme()
+	case ThemeLight:
+		theme = LightTheme()
+	default:
+		return Theme{}, fmt.Errorf("unknown theme %q (use %s or %s)", name, ThemeDark, ThemeLight)
+	}
+	if noColor {
+		return noColorTheme(theme.Name), nil
+	}
+	return theme, nil
+}
+
+// activeTheme is the palette the package-level styles were built from.
+var activeTheme Theme
+
+func init() {
+	applyTheme(DarkTheme())
+}
+
+// SetTheme switches the UI to theme. Call it before the UI starts.
+func SetTheme(theme Theme) {
+	applyTheme(theme)
+}
+
+// applyTheme rebuilds every shared style from theme.
+func applyTheme(t Theme) {
+	activeTheme = t
+
+	primaryColor = t.Primary
+	successColor = t.Success
+	errorColor = t.Error
+	warningColor = t.Warning
+	mutedColor = t.Muted
+	accentColor = t.Accent
+	highlightColor = t.Highlight
+
+	wizardHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Primary).MarginBottom(0)
+	wizardStepStyle = lipgloss.NewStyle().Foreground(t.Muted).Italic(true)
+	breadcrumbStyle = lipgloss.NewStyle().Foreground(t.Highlight).Bold(true)
+	selectedStyle = lipgloss.NewStyle().Foreground(t.Primary).Bold(true)
+	successStyle = lipgloss.NewStyle().Foreground(t.Success).Bold(true)
+	errorStyle = lipgloss.NewStyle().Foreground(t.Error).Bold(true)
+	warningStyle = lipgloss.NewStyle().Foreground(t.Warning).Bold(true)
+	mutedStyle = lipgloss.NewStyle().Foreground(t.Muted)
+	helpStyle = lipgloss.NewStyle().Foreground(t.Muted).Italic(true)
+	spinnerStyle = lipgloss.NewStyle().Foreground(t.Accent).Bold(true)
+	accentStyle = lipgloss.NewStyle().Foreground(t.Accent).Bold(true)
+
+	inputStyle = lipgloss.NewStyle().Foreground(t.Text)
+	validInputStyle = lipgloss.NewStyle().Foreground(t.Success)
+
+	checkedBoxStyle = lipgloss.NewStyle().Foreground(t.Success).Bold(true)
+	uncheckedBoxStyle = lipgloss.NewStyle().Foreground(t.Muted)
+
+	jsonKeyStyle = lipgloss.NewStyle().Foreground(t.JSONKey)
+	jsonStringStyle = lipgloss.NewStyle().Foreground(t.JSONString)
+	jsonNumberStyle = lipgloss.NewStyle().Foreground(t.JSONNumber)
+	jsonBoolStyle = lipgloss.NewStyle().Foreground(t.JSONBool)
+	jsonNullStyle = lipgloss.NewStyle().Foreground(t.JSONNull)
+
+	wizardBoxStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Accent).
+		Padding(1, 2)
+}
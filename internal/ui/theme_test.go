@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectTheme(t *testing.T) {
+	theme, err := SelectTheme("", false)
+	require.NoError(t, err)
+	assert.Equal(t, DarkTheme(), theme, "dark is the default")
+
+	theme, err = SelectTheme(ThemeLight, false)
+	require.NoError(t, err)
+	assert.Equal(t, LightTheme(), theme)
+
+	_, err = SelectTheme("solarized", false)
+	assert.ErrorContains(t, err, `unknown theme "solarized"`)
+}
+
+func TestSelectTheme_NoColor(t *testing.T) {
+	theme, err := SelectTheme(ThemeLight, true)
+	require.NoError(t, err)
+	assert.Equal(t, Theme{Name: ThemeLight}, theme, "NO_COLOR drops every color")
+
+	_, err = SelectTheme("solarized", true)
+	assert.Error(t, err, "an unknown name is still rejected")
+}
+
+func TestSetTheme_RebuildsStyles(t *testing.T) {
+	t.Cleanup(func() { SetTheme(DarkTheme()) })
+
+	SetTheme(LightTheme())
+	assert.Equal(t, LightTheme().Primary, primaryColor)
+	assert.Equal(t, LightTheme().Header, defaultMainViewColors().header)
+	assert.Equal(t, LightTheme().Primary, selectedStyle.GetForeground())
+
+	noColor, err := SelectTheme(ThemeDark, true)
+	require.NoError(t, err)
+	SetTheme(noColor)
+	assert.Empty(t, defaultMainViewColors().selectedBg)
+	assert.True(t, selectedStyle.GetBold(), "text attributes survive NO_COLOR")
+}
@@ -0,0 +1,388 @@
+package ui
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// traceRefreshInterval is how often the trace panel is refreshed while open
+const traceRefreshInterval = time.Second
+
+// TraceRowFormat is the format of a connection row in the trace panel
+const TraceRowFormat = "%-5s %-12s %-10s %-10s %s"
+
+// TraceChunk is a run of bytes moving in one direction of a traced connection
+type TraceChunk struct {
+	Data   []byte
+	Offset time.Duration // since the connection opened
+	Sent   bool          // client to pod; false for pod to client
+}
+
+// TraceConn is a traced client connection as reported by a TraceProvider
+type TraceConn struct {
+	Opened   time.Time
+	Chunks   []TraceChunk // in the order they passed
+	Sent     int64        // all bytes sent, including those not captured
+	Received int64        // all bytes received, likewise
+	ID       int
+	Closed   bool
+}
+
+// TraceProvider returns the traced connections of a forward, oldest first.
+// ok is false when the forward is not running or is not traced.
+type TraceProvider func(forwardID string) (conns []TraceConn, ok bool)
+
+// traceTickMsg refreshes the trace panel opened as seq, unless it was
+// closed since.
+type traceTickMsg struct {
+	seq int
+}
+
+// traceState holds the trace panel of one forward
+type traceState struct {
+	conns         []TraceConn
+	forwardID     string
+	forwardAlias  string
+	cursor        int
+	detailID      int // connection shown in the hexdump
+	detailScroll  int
+	available     bool // the provider returned traces on the last refresh
+	traceBytes    bool // the forward is configured with traceBytes
+	showingDetail bool
+}
+
+// SetTraceProvider sets the function the trace panel is read from
+func (ui *BubbleTeaUI) SetTraceProvider(provider TraceProvider) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.traceProvider = provider
+}
+
+// openTrace opens the trace panel for the selected forward. It is refreshed
+// every traceRefreshInterval until closed.
+func (m model) openTrace() (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	// Don't open the panel if another modal is active
+	if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil || m.ui.traceState != nil {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+
+	visible := m.ui.visibleForwards()
+	if m.ui.selectedIndex < 0 || m.ui.selectedIndex >= len(visible) {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+	selectedID := visible[m.ui.selectedIndex]
+	selectedForward, ok := m.ui.forwards[selectedID]
+	if !ok {
+		m.ui.mu.Unlock()
+		return m, nil
+	}
+
+	m.ui.viewMode = ViewModeTrace
+	m.ui.traceState = &traceState{
+		forwardID:    selectedID,
+		forwardAlias: selectedForward.Alias,
+		traceBytes:   selectedForward.TraceBytes,
+	}
+	m.ui.traceSeq++
+	seq := m.ui.traceSeq
+	m.ui.mu.Unlock()
+
+	m.ui.refreshTrace()
+	return m, scheduleTraceTick(seq)
+}
+
+// handleTraceTick refreshes the trace panel and schedules the next refresh,
+// stopping once the panel is closed.
+func (m model) handleTraceTick(msg traceTickMsg) (tea.Model, tea.Cmd) {
+	m.ui.mu.RLock()
+	current := m.ui.traceState != nil && msg.seq == m.ui.traceSeq
+	m.ui.mu.RUnlock()
+
+	if !current {
+		return m, nil
+	}
+	m.ui.refreshTrace()
+	return m, scheduleTraceTick(msg.seq)
+}
+
+func scheduleTraceTick(seq int) tea.Cmd {
+	return tea.Tick(traceRefreshInterval, func(t time.Time) tea.Msg {
+		return traceTickMsg{seq: seq}
+	})
+}
+
+// refreshTrace copies the provider's traces into the open panel
+func (ui *BubbleTeaUI) refreshTrace() {
+	ui.mu.RLock()
+	provider := ui.traceProvider
+	state := ui.traceState
+	ui.mu.RUnlock()
+
+	if provider == nil || state == nil {
+		return
+	}
+
+	// Read outside the lock; the provider takes the tracer's locks
+	conns, ok := provider(state.forwardID)
+
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	if ui.traceState != state {
+		return
+	}
+	state.conns = conns
+	state.available = ok
+}
+
+// selectedConn returns the connection shown in the hexdump. ok is false
+// once its trace has been dropped for newer connections.
+func (s *traceState) selectedConn() (TraceConn, bool) {
+	for _, conn := range s.conns {
+		if conn.ID == s.detailID {
+			return conn, true
+		}
+	}
+	return TraceConn{}, false
+}
+
+// handleTraceKeys handles keyboard input in the trace panel
+func (m model) handleTraceKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	defer m.ui.mu.Unlock()
+
+	state := m.ui.traceState
+	if state == nil {
+		return m, nil
+	}
+
+	// If viewing a hexdump, handle detail view keys
+	if state.showingDetail {
+		switch msg.String() {
+		case "esc", "q", "enter":
+			state.showingDetail = false
+			state.detailScroll = 0
+		case "up", "k":
+			if state.detailScroll > 0 {
+				state.detailScroll--
+			}
+		case "down", "j":
+			state.detailScroll++
+		case "pgup", "ctrl+u":
+			state.detailScroll = max(state.detailScroll-20, 0)
+		case "pgdown", "ctrl+d":
+			state.detailScroll += 20
+		case "g":
+			state.detailScroll = 0
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "esc", "q":
+		m.ui.viewMode = ViewModeMain
+		m.ui.traceState = nil
+		return m, tea.ClearScreen
+
+	case "enter":
+		if state.cursor >= 0 && state.cursor < len(state.conns) {
+			state.showingDetail = true
+			state.detailID = state.conns[state.cursor].ID
+			state.detailScroll = 0
+		}
+
+	case "up", "k":
+		if state.cursor > 0 {
+			state.cursor--
+		}
+
+	case "down", "j":
+		if state.cursor < len(state.conns)-1 {
+			state.cursor++
+		}
+
+	case "home", "g":
+		state.cursor = 0
+
+	case "end", "G":
+		state.cursor = max(len(state.conns)-1, 0)
+	}
+
+	return m, nil
+}
+
+// renderTrace renders the full-screen trace panel
+func (m model) renderTrace() string {
+	m.ui.mu.Lock()
+	defer m.ui.mu.Unlock()
+
+	state := m.ui.traceState
+	if state == nil {
+		return ""
+	}
+
+	termWidth, termHeight := m.getTermDimensions()
+
+	if state.showingDetail {
+		return m.renderTraceDetail(state, termWidth, termHeight)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(wizardHeaderStyle.Render("Byte Trace"))
+	b.WriteString("  ")
+	b.WriteString(breadcrumbStyle.Render(state.forwardAlias))
+	b.WriteString("\n")
+
+	viewportHeight := max(termHeight-7, 5) // header, table header, separator, footer, help
+
+	if len(state.conns) == 0 {
+		b.WriteString("\n")
+		switch {
+		case !state.traceBytes:
+			b.WriteString(mutedStyle.Render("  Byte trace is off for this forward.\n"))
+			b.WriteString(mutedStyle.Render("  Enable with: traceBytes: true in .kportal.yaml\n"))
+		case !state.available:
+			b.WriteString(mutedStyle.Render("  Forward is not running.\n"))
+		default:
+			b.WriteString(mutedStyle.Render("  No connections traced yet.\n"))
+		}
+		for i := 0; i < viewportHeight-1; i++ {
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString("\n")
+		header := "  " + fmt.Sprintf(TraceRowFormat, "#", "OPENED", "SENT", "RECEIVED", "STATE")
+		b.WriteString(mutedStyle.Render(header))
+		b.WriteString("\n")
+		b.WriteString(mutedStyle.Render(strings.Repeat("─", termWidth-2)))
+		b.WriteString("\n")
+
+		state.cursor = min(max(state.cursor, 0), len(state.conns)-1)
+		start := max(state.cursor-viewportHeight+1, 0)
+		end := min(start+viewportHeight, len(state.conns))
+
+		for i := start; i < end; i++ {
+			conn := state.conns[i]
+			connState := "open"
+			if conn.Closed {
+				connState = "closed"
+			}
+			line := fmt.Sprintf(TraceRowFormat,
+				fmt.Sprintf("%d", conn.ID),
+				conn.Opened.Format("15:04:05.000"),
+				formatBytes(conn.Sent),
+				formatBytes(conn.Received),
+				connState)
+
+			if i == state.cursor {
+				b.WriteString(selectedStyle.Render("▸ "))
+			} else {
+				b.WriteString("  ")
+			}
+			if conn.Closed {
+				b.WriteString(mutedStyle.Render(line))
+			} else {
+				b.WriteString(line)
+			}
+			b.WriteString("\n")
+		}
+
+		for i := end - start; i < viewportHeight; i++ {
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("  %d connections", len(state.conns))))
+	b.WriteString("\n  ")
+	b.WriteString(wrapHelpText("↑/↓: Navigate  Enter: Hexdump  q: Close", termWidth-4))
+
+	return b.String()
+}
+
+// renderTraceDetail renders the hexdump of the selected connection
+func (m model) renderTraceDetail(state *traceState, termWidth, termHeight int) string {
+	var b strings.Builder
+
+	b.WriteString(wizardHeaderStyle.Render(fmt.Sprintf("Connection #%d", state.detailID)))
+	b.WriteString("  ")
+	b.WriteString(breadcrumbStyle.Render(state.forwardAlias))
+	b.WriteString("\n\n")
+
+	conn, ok := state.selectedConn()
+	var lines []string
+	if !ok {
+		lines = append(lines, mutedStyle.Render("  Trace dropped to make room for newer connections."))
+	} else {
+		lines = traceDumpLines(conn)
+	}
+
+	viewportHeight := max(termHeight-6, 5) // header, footer, help
+	maxScroll := max(len(lines)-viewportHeight, 0)
+	state.detailScroll = min(state.detailScroll, maxScroll)
+	scroll := state.detailScroll
+
+	end := min(scroll+viewportHeight, len(lines))
+	for i := scroll; i < end; i++ {
+		b.WriteString(truncate(lines[i], termWidth))
+		b.WriteString("\n")
+	}
+	for i := end - scroll; i < viewportHeight; i++ {
+		b.WriteString("\n")
+	}
+
+	if len(lines) > viewportHeight {
+		percent := 0
+		if maxScroll > 0 {
+			percent = (scroll * 100) / maxScroll
+		}
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("\n  [%d%%] ", percent)))
+	} else {
+		b.WriteString("\n  ")
+	}
+	b.WriteString(wrapHelpText("↑/↓/PgUp/PgDn: Scroll  g: Top  Esc: Back", termWidth-10))
+
+	return b.String()
+}
+
+// traceDumpLines renders conn's chunks in the order they passed, each as a
+// direction header followed by a hexdump
+func traceDumpLines(conn TraceConn) []string {
+	var lines []string
+	var sentKept, receivedKept int64
+
+	if len(conn.Chunks) == 0 {
+		lines = append(lines, mutedStyle.Render("  No bytes yet."))
+	}
+	for _, chunk := range conn.Chunks {
+		direction := "← pod to client"
+		if chunk.Sent {
+			direction = "→ client to pod"
+			sentKept += int64(len(chunk.Data))
+		} else {
+			receivedKept += int64(len(chunk.Data))
+		}
+		lines = append(lines, accentStyle.Render(fmt.Sprintf("  %s  %d bytes  +%s",
+			direction, len(chunk.Data), chunk.Offset.Round(time.Millisecond))))
+		for _, line := range strings.Split(strings.TrimSuffix(hex.Dump(chunk.Data), "\n"), "\n") {
+			lines = append(lines, "    "+line)
+		}
+		lines = append(lines, "")
+	}
+
+	if n := conn.Sent - sentKept; n > 0 {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("  → %s more sent, not captured", formatBytes(n))))
+	}
+	if n := conn.Received - receivedKept; n > 0 {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("  ← %s more received, not captured", formatBytes(n))))
+	}
+	return lines
+}
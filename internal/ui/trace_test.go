@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestModelWithTrace(conns []TraceConn) model {
+	m := newTestModelWithForward()
+	m.ui.forwards["test-id"].TraceBytes = true
+	m.ui.SetTraceProvider(func(id string) ([]TraceConn, bool) {
+		return conns, id == "test-id"
+	})
+	return m
+}
+
+func TestOpenTrace(t *testing.T) {
+	opened := time.Date(2026, 1, 2, 10, 11, 12, 0, time.UTC)
+	m := newTestModelWithTrace([]TraceConn{
+		{ID: 1, Opened: opened, Sent: 2048, Received: 10, Closed: true},
+		{ID: 2, Opened: opened, Sent: 5},
+	})
+
+	_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	require.NotNil(t, cmd, "an open panel should schedule a refresh")
+	assert.Equal(t, ViewModeTrace, m.ui.viewMode)
+
+	view := m.View()
+	assert.Contains(t, view, "Byte Trace")
+	assert.Contains(t, view, "my-app")
+	assert.Contains(t, view, "2.0 KiB")
+	assert.Contains(t, view, "closed")
+	assert.Contains(t, view, "2 connections")
+
+	_, cmd = m.handleTraceKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, ViewModeMain, m.ui.viewMode)
+	assert.Nil(t, m.ui.traceState)
+}
+
+func TestRenderTrace_Empty(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       string
+		traceBytes bool
+		running    bool
+	}{
+		{name: "not configured", want: "traceBytes: true", running: true},
+		{name: "not running", want: "Forward is not running", traceBytes: true},
+		{name: "no connections", want: "No connections traced yet", traceBytes: true, running: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestModelWithForward()
+			m.ui.forwards["test-id"].TraceBytes = tt.traceBytes
+			m.ui.SetTraceProvider(func(string) ([]TraceConn, bool) {
+				return nil, tt.running && tt.traceBytes
+			})
+
+			_, _ = m.openTrace()
+			assert.Contains(t, m.renderTrace(), tt.want)
+		})
+	}
+}
+
+func TestHandleTraceKeys_Hexdump(t *testing.T) {
+	m := newTestModelWithTrace([]TraceConn{
+		{ID: 1},
+		{
+			ID:       2,
+			Sent:     9000,
+			Received: 2,
+			Chunks: []TraceChunk{
+				{Data: []byte("PING\r\n"), Sent: true},
+				{Data: []byte("OK"), Offset: 3 * time.Millisecond},
+			},
+		},
+	})
+	_, _ = m.openTrace()
+
+	_, _ = m.handleTraceKeys(tea.KeyMsg{Type: tea.KeyDown})
+	_, _ = m.handleTraceKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, m.ui.traceState.showingDetail)
+
+	view := m.renderTrace()
+	assert.Contains(t, view, "Connection #2")
+	assert.Contains(t, view, "→ client to pod  6 bytes")
+	assert.Contains(t, view, "50 49 4e 47 0d 0a")
+	assert.Contains(t, view, "|PING..|")
+	assert.Contains(t, view, "← pod to client  2 bytes  +3ms")
+	assert.Contains(t, view, "8.8 KiB more sent, not captured")
+	assert.NotContains(t, view, "more received")
+
+	_, _ = m.handleTraceKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.ui.traceState.showingDetail)
+	assert.Equal(t, ViewModeTrace, m.ui.viewMode)
+}
+
+func TestRenderTrace_DroppedConnection(t *testing.T) {
+	m := newTestModelWithTrace([]TraceConn{{ID: 1}})
+	_, _ = m.openTrace()
+	_, _ = m.handleTraceKeys(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Connection 1 is dropped for newer ones while its hexdump is open
+	m.ui.SetTraceProvider(func(string) ([]TraceConn, bool) {
+		return []TraceConn{{ID: 21}}, true
+	})
+	m.ui.refreshTrace()
+
+	assert.Contains(t, m.renderTrace(), "Trace dropped")
+}
+
+func TestHandleTraceTick(t *testing.T) {
+	var conns []TraceConn
+	m := newTestModelWithForward()
+	m.ui.SetTraceProvider(func(string) ([]TraceConn, bool) {
+		return conns, true
+	})
+	_, _ = m.openTrace()
+
+	conns = []TraceConn{{ID: 1}}
+	_, cmd := m.Update(traceTickMsg{seq: m.ui.traceSeq})
+	assert.NotNil(t, cmd, "refresh keeps polling while open")
+	assert.Len(t, m.ui.traceState.conns, 1)
+
+	// A tick from an earlier panel must not start a second polling loop
+	_, cmd = m.Update(traceTickMsg{seq: m.ui.traceSeq - 1})
+	assert.Nil(t, cmd)
+
+	_, _ = m.handleTraceKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	_, cmd = m.Update(traceTickMsg{seq: m.ui.traceSeq})
+	assert.Nil(t, cmd, "a closed panel stops polling")
+}
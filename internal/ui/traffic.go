@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// trafficRefreshInterval is how often the traffic columns are refreshed
+// while they are shown.
+const trafficRefreshInterval = time.Second
+
+// TrafficSample is a forward's traffic as reported by a TrafficProvider
+type TrafficSample struct {
+	Sent        int64         // cumulative bytes sent
+	Received    int64         // cumulative bytes received
+	SendRate    int64         // bytes/sec sent over the recent window
+	ReceiveRate int64         // bytes/sec received over the recent window
+	Tunnels     int64         // port-forward connections opened
+	Streams     int64         // client connections carried by those tunnels
+	SetupTime   time.Duration // how long the latest connection took to open
+	Connections int           // open client connections
+}
+
+// TrafficProvider returns a forward's traffic. ok is false when the forward
+// is not running.
+type TrafficProvider func(forwardID string) (sample TrafficSample, ok bool)
+
+// trafficTickMsg refreshes the traffic columns for the polling loop started
+// as seq, unless the columns were toggled since.
+type trafficTickMsg struct {
+	seq int
+}
+
+// SetTrafficProvider sets the function the traffic columns are read from
+func (ui *BubbleTeaUI) SetTrafficProvider(provider TrafficProvider) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.trafficProvider = provider
+}
+
+// toggleTraffic shows or hides the traffic columns. While shown they are
+// refreshed every trafficRefreshInterval.
+func (m model) toggleTraffic() (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	m.ui.showTraffic = !m.ui.showTraffic
+	show := m.ui.showTraffic
+	m.ui.mu.Unlock()
+
+	if show {
+		m.ui.refreshTraffic()
+	}
+	return m, m.ui.restartTrafficTick()
+}
+
+// restartTrafficTick starts a new polling loop for the traffic and activity
+// columns, ending the previous one, or returns nil when neither is shown.
+func (ui *BubbleTeaUI) restartTrafficTick() tea.Cmd {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	ui.trafficSeq++
+	if !ui.showTraffic && !ui.showActivity {
+		return nil
+	}
+	return scheduleTrafficTick(ui.trafficSeq)
+}
+
+// handleTrafficTick refreshes the traffic and activity columns and
+// schedules the next refresh, stopping once both are hidden.
+func (m model) handleTrafficTick(msg trafficTickMsg) (tea.Model, tea.Cmd) {
+	m.ui.mu.RLock()
+	current := msg.seq == m.ui.trafficSeq
+	showTraffic := m.ui.showTraffic
+	showActivity := m.ui.showActivity
+	m.ui.mu.RUnlock()
+
+	if !current || (!showTraffic && !showActivity) {
+		return m, nil
+	}
+	if showTraffic {
+		m.ui.refreshTraffic()
+	}
+	if showActivity {
+		m.ui.refreshActivity()
+	}
+	return m, scheduleTrafficTick(msg.seq)
+}
+
+func scheduleTrafficTick(seq int) tea.Cmd {
+	return tea.Tick(trafficRefreshInterval, func(t time.Time) tea.Msg {
+		return trafficTickMsg{seq: seq}
+	})
+}
+
+// refreshTraffic copies the provider's counters into every forward's
+// status. Forwards that are not running show no traffic.
+func (ui *BubbleTeaUI) refreshTraffic() {
+	ui.mu.RLock()
+	provider := ui.trafficProvider
+	ids := append([]string(nil), ui.forwardOrder...)
+	ui.mu.RUnlock()
+
+	if provider == nil {
+		return
+	}
+
+	// The provider is queried outside the lock so rendering is not blocked
+	latest := make(map[string]TrafficSample, len(ids))
+	for _, id := range ids {
+		if sample, ok := provider(id); ok {
+			latest[id] = sample
+		}
+	}
+
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+	for _, id := range ids {
+		fwd, ok := ui.forwards[id]
+		if !ok {
+			continue
+		}
+		sample := latest[id]
+		fwd.BytesSent = sample.Sent
+		fwd.BytesReceived = sample.Received
+		fwd.SendRate = sample.SendRate
+		fwd.ReceiveRate = sample.ReceiveRate
+		fwd.Tunnels = sample.Tunnels
+		fwd.Streams = sample.Streams
+		fwd.SetupTime = sample.SetupTime
+		fwd.Connections = sample.Connections
+	}
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "1.5 KiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// formatStreamReuse renders client connections per tunnel as
+// "streams/tunnels", or "-" before the first tunnel.
+func formatStreamReuse(streams, tunnels int64) string {
+	if tunnels <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", streams, tunnels)
+}
+
+// formatSetupTime renders a connection setup time, or "-" before the first
+// connection.
+func formatSetupTime(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Millisecond:
+		return d.Round(time.Microsecond).String()
+	default:
+		return d.Round(time.Millisecond).String()
+	}
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		want string
+		n    int64
+	}{
+		{"0 B", 0},
+		{"1023 B", 1023},
+		{"1.0 KiB", 1024},
+		{"1.5 KiB", 1536},
+		{"5.0 MiB", 5 * 1024 * 1024},
+		{"2.0 GiB", 2 * 1024 * 1024 * 1024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatBytes(tt.n))
+		})
+	}
+}
+
+func TestToggleTraffic(t *testing.T) {
+	m := newTestModelWithForward()
+	m.ui.SetTrafficProvider(func(id string) (TrafficSample, bool) {
+		return TrafficSample{Sent: 2048, Received: 512, ReceiveRate: 3072, SetupTime: 42 * time.Millisecond, Connections: 3}, id == "test-id"
+	})
+	assert.NotContains(t, m.renderMainView(), "CONNS")
+
+	_, cmd := m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	require.NotNil(t, cmd, "shown columns should schedule a refresh")
+
+	view := m.renderMainView()
+	assert.Contains(t, view, "SENT")
+	assert.Contains(t, view, "CONNS")
+	assert.Contains(t, view, "2.0 KiB")
+	assert.Contains(t, view, "512 B")
+	assert.Contains(t, view, "3.0 KiB")
+	assert.Contains(t, view, "42ms")
+
+	_, cmd = m.handleMainViewKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	assert.Nil(t, cmd)
+	assert.NotContains(t, m.renderMainView(), "CONNS")
+}
+
+func TestHandleTrafficTick(t *testing.T) {
+	sent := int64(0)
+	m := newTestModelWithForward()
+	m.ui.SetTrafficProvider(func(string) (TrafficSample, bool) {
+		return TrafficSample{Sent: sent}, true
+	})
+	_, _ = m.toggleTraffic()
+
+	sent = 4096
+	_, cmd := m.Update(trafficTickMsg{seq: m.ui.trafficSeq})
+	assert.NotNil(t, cmd, "refresh keeps polling while shown")
+	assert.Equal(t, int64(4096), m.ui.forwards["test-id"].BytesSent)
+
+	// A tick from an earlier toggle must not start a second polling loop
+	_, cmd = m.Update(trafficTickMsg{seq: m.ui.trafficSeq - 1})
+	assert.Nil(t, cmd)
+}
+
+func TestRefreshTraffic_StoppedForwardShowsNoTraffic(t *testing.T) {
+	m := newTestModelWithForward()
+	m.ui.forwards["test-id"].BytesSent = 100
+	m.ui.forwards["test-id"].Connections = 1
+	m.ui.forwards["test-id"].SendRate = 10
+	m.ui.SetTrafficProvider(func(string) (TrafficSample, bool) {
+		return TrafficSample{}, false
+	})
+
+	m.ui.refreshTraffic()
+	assert.Zero(t, m.ui.forwards["test-id"].BytesSent)
+	assert.Zero(t, m.ui.forwards["test-id"].Connections)
+	assert.Zero(t, m.ui.forwards["test-id"].SendRate)
+}
+
+func TestFormatSetupTime(t *testing.T) {
+	assert.Equal(t, "-", formatSetupTime(0))
+	assert.Equal(t, "850µs", formatSetupTime(850*time.Microsecond))
+	assert.Equal(t, "43ms", formatSetupTime(42600*time.Microsecond))
+	assert.Equal(t, "1.2s", formatSetupTime(1200*time.Millisecond))
+}
+
+func TestFormatStreamReuse(t *testing.T) {
+	assert.Equal(t, "-", formatStreamReuse(0, 0))
+	assert.Equal(t, "0/1", formatStreamReuse(0, 1))
+	assert.Equal(t, "12/2", formatStreamReuse(12, 2))
+}
@@ -0,0 +1,299 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/k8s"
+)
+
+// localPortFree reports whether a local port can be bound right now.
+// Overridden in tests.
+var localPortFree = func(port int) bool {
+	available, _, err := k8s.CheckPortAvailability(port)
+	return err == nil && available
+}
+
+// BatchForwardsSavedMsg is sent when a batch of service forwards has been saved
+type BatchForwardsSavedMsg struct {
+	err      error
+	forwards []config.Forward
+	skipped  int
+}
+
+// batchServicePort is one selectable row of the batch checklist: a single
+// port of a service.
+type batchServicePort struct {
+	service string
+	port    k8s.PortInfo
+}
+
+// key identifies the row in AddWizardState.batchSelected
+func (r batchServicePort) key() string {
+	return fmt.Sprintf("%s:%d", r.service, r.port.Port)
+}
+
+// isTCP returns true if the port can be forwarded. The port-forward API
+// only tunnels TCP, so other protocols are listed but not selectable.
+func (r batchServicePort) isTCP() bool {
+	return r.port.Protocol == "" || strings.EqualFold(r.port.Protocol, "TCP")
+}
+
+// batchRows flattens the loaded services into one row per service port
+func (w *AddWizardState) batchRows() []batchServicePort {
+	var rows []batchServicePort
+	for _, svc := range w.services {
+		for _, port := range svc.Ports {
+			rows = append(rows, batchServicePort{service: svc.Name, port: port})
+		}
+	}
+	return rows
+}
+
+// toggleBatchRow toggles the row under the cursor
+func (w *AddWizardState) toggleBatchRow() {
+	rows := w.batchRows()
+	if w.cursor < 0 || w.cursor >= len(rows) || !rows[w.cursor].isTCP() {
+		return
+	}
+	if w.batchSelected == nil {
+		w.batchSelected = make(map[string]bool)
+	}
+	key := rows[w.cursor].key()
+	w.batchSelected[key] = !w.batchSelected[key]
+}
+
+// selectAllBatchRows selects every forwardable row
+func (w *AddWizardState) selectAllBatchRows() {
+	w.batchSelected = make(map[string]bool)
+	for _, row := range w.batchRows() {
+		if row.isTCP() {
+			w.batchSelected[row.key()] = true
+		}
+	}
+}
+
+// selectedBatchRows returns the selected rows in display order
+func (w *AddWizardState) selectedBatchRows() []batchServicePort {
+	var selected []batchServicePort
+	for _, row := range w.batchRows() {
+		if w.batchSelected[row.key()] {
+			selected = append(selected, row)
+		}
+	}
+	return selected
+}
+
+// handleBatchServiceKeys handles the batch checklist keys. Returns false for
+// keys the common wizard handling covers (navigation, Esc, Ctrl+C).
+// Caller must hold m.ui.mu.
+func (m model) handleBatchServiceKeys(msg tea.KeyMsg) (bool, tea.Cmd) {
+	wizard := m.ui.addWizard
+
+	switch msg.String() {
+	case " ":
+		wizard.toggleBatchRow()
+	case "a":
+		wizard.selectAllBatchRows()
+	case "n":
+		wizard.batchSelected = nil
+	case "enter":
+		if wizard.loading {
+			return true, nil
+		}
+		selected := wizard.selectedBatchRows()
+		if len(selected) == 0 {
+			return true, nil
+		}
+		wizard.loading = true
+		wizard.error = nil
+		return true, batchAddForwardsCmd(m.ui.mutator, m.ui.configPath, wizard.selectedContext, wizard.selectedNamespace, selected)
+	case "up", "k", "down", "j", "pgup", "ctrl+u", "pgdown", "ctrl+d", "esc", "ctrl+c":
+		return false, nil
+	}
+
+	// The checklist has no filter, so other keys are ignored
+	return true, nil
+}
+
+// batchAddForwardsCmd assigns free local ports to the selected service ports
+// and appends them to the config in a single write. Service ports already
+// forwarded in this namespace are skipped.
+func batchAddForwardsCmd(mutator *config.Mutator, configPath, contextName, namespace string, rows []batchServicePort) tea.Cmd {
+	return func() tea.Msg {
+		taken := make(map[int]bool)
+		configured := make(map[string]bool)
+		if cfg, err := config.LoadConfig(configPath); err == nil {
+			for _, fwd := range cfg.GetAllForwards() {
+				taken[fwd.LocalPort] = true
+				if fwd.GetContext() == contextName && fwd.GetNamespace() == namespace {
+					configured[fmt.Sprintf("%s:%d", fwd.Resource, fwd.Port)] = true
+				}
+			}
+		}
+
+		fwds, skipped := planBatchForwards(rows, taken, configured, localPortFree)
+		if len(fwds) == 0 {
+			return BatchForwardsSavedMsg{skipped: skipped}
+		}
+		if err := mutator.AddForwards(contextName, namespace, fwds); err != nil {
+			return BatchForwardsSavedMsg{err: err}
+		}
+		return BatchForwardsSavedMsg{forwards: fwds, skipped: skipped}
+	}
+}
+
+// planBatchForwards builds a forward per row, skipping rows already in the
+// config. Each gets the service port as its local port when that is
+// unprivileged and free, else the next free port from
+// GenerateDefaultStartingPort. taken is updated with the assigned ports.
+func planBatchForwards(rows []batchServicePort, taken map[int]bool, configured map[string]bool, free func(int) bool) ([]config.Forward, int) {
+	var fwds []config.Forward
+	skipped := 0
+	next := GenerateDefaultStartingPort
+
+	for _, row := range rows {
+		resource := "service/" + row.service
+		if configured[fmt.Sprintf("%s:%d", resource, row.port.Port)] {
+			skipped++
+			continue
+		}
+
+		localPort := int(row.port.Port)
+		if localPort < GenerateMinLocalPort || taken[localPort] || !free(localPort) {
+			for next <= GenerateMaxLocalPort && (taken[next] || !free(next)) {
+				next++
+			}
+			if next > GenerateMaxLocalPort {
+				break
+			}
+			localPort = next
+		}
+		taken[localPort] = true
+
+		fwds = append(fwds, config.Forward{
+			Resource:  resource,
+			Protocol:  "tcp",
+			Port:      int(row.port.Port),
+			LocalPort: localPort,
+			Alias:     row.service,
+		})
+	}
+	return fwds, skipped
+}
+
+func (m model) handleBatchForwardsSaved(msg BatchForwardsSavedMsg) (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	defer m.ui.mu.Unlock()
+
+	wizard := m.ui.addWizard
+	if wizard == nil {
+		return m, nil
+	}
+	wizard.loading = false
+
+	switch {
+	case msg.err != nil:
+		wizard.error = msg.err
+	case len(msg.forwards) == 0:
+		wizard.error = errors.New("all selected service ports are already configured")
+	default:
+		wizard.batchAdded = msg.forwards
+		wizard.batchSkipped = msg.skipped
+		wizard.step = StepSuccess
+		wizard.cursor = 0
+		wizard.inputMode = InputModeList
+	}
+
+	return m, nil
+}
+
+// renderBatchServices renders the batch service checklist
+func (m model) renderBatchServices(b *strings.Builder) {
+	wizard := m.ui.addWizard
+
+	b.WriteString("Select services to forward (Space to toggle):\n\n")
+
+	if wizard.loading && len(wizard.services) == 0 {
+		b.WriteString(spinnerStyle.Render("⣾ Loading services..."))
+		b.WriteString("\n")
+		return
+	}
+
+	rows := wizard.batchRows()
+	if len(rows) == 0 {
+		b.WriteString(mutedStyle.Render("No services found"))
+		b.WriteString("\n")
+		return
+	}
+
+	if wizard.scrollOffset > 0 {
+		b.WriteString(scrollUpIndicator())
+	}
+	end := wizard.scrollOffset + ViewportHeight
+	if end > len(rows) {
+		end = len(rows)
+	}
+	for i := wizard.scrollOffset; i < end; i++ {
+		row := rows[i]
+		label := fmt.Sprintf("%s:%d", row.service, row.port.Port)
+		if row.port.Name != "" {
+			label += fmt.Sprintf(" (%s)", row.port.Name)
+		}
+
+		checkbox := "[ ] "
+		if wizard.batchSelected[row.key()] {
+			checkbox = "[✓] "
+		}
+
+		switch {
+		case !row.isTCP():
+			line := fmt.Sprintf("[-] %s %s, skipped", label, row.port.Protocol)
+			if i == wizard.cursor {
+				b.WriteString(selectedStyle.Render("▸ ") + mutedStyle.Render(line))
+			} else {
+				b.WriteString(mutedStyle.Render("  " + line))
+			}
+		case i == wizard.cursor:
+			b.WriteString(selectedStyle.Render("▸ " + checkbox + label))
+		case wizard.batchSelected[row.key()]:
+			b.WriteString("  " + checkedBoxStyle.Render(checkbox) + label)
+		default:
+			b.WriteString("  " + uncheckedBoxStyle.Render(checkbox) + label)
+		}
+		b.WriteString("\n")
+	}
+	if end < len(rows) {
+		b.WriteString(scrollDownIndicator())
+	}
+
+	b.WriteString("\n")
+	if wizard.loading {
+		b.WriteString(spinnerStyle.Render("⣾ Saving forwards..."))
+	} else if wizard.error != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("✗ %v", wizard.error)))
+	} else {
+		fmt.Fprintf(b, "%d of %d selected", len(wizard.selectedBatchRows()), len(rows))
+	}
+	b.WriteString("\n")
+}
+
+// renderBatchSuccess renders the forwards added by a batch add
+func (m model) renderBatchSuccess(b *strings.Builder) {
+	wizard := m.ui.addWizard
+
+	fmt.Fprintf(b, "Added %d forwards to .kportal.yaml\n\n", len(wizard.batchAdded))
+	for _, fwd := range wizard.batchAdded {
+		b.WriteString(successStyle.Render(fmt.Sprintf("%s (localhost:%d → %s:%d)", fwd.Alias, fwd.LocalPort, fwd.Resource, fwd.Port)))
+		b.WriteString("\n")
+	}
+	if wizard.batchSkipped > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("%d already configured, skipped", wizard.batchSkipped)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("The port forwards will be active shortly."))
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBatchWizardModel returns a model on the batch checklist with two
+// services loaded: api (http, grpc) and dns (UDP only).
+func newBatchWizardModel() model {
+	m := newModelWithWizard(StepEnterResource)
+	w := m.ui.addWizard
+	w.selectedResourceType = ResourceTypeServiceBatch
+	w.inputMode = InputModeList
+	w.services = []k8s.ServiceInfo{
+		{Name: "api", Ports: []k8s.PortInfo{
+			{Name: "http", Port: 80, Protocol: "TCP"},
+			{Name: "grpc", Port: 9090, Protocol: "TCP"},
+		}},
+		{Name: "dns", Ports: []k8s.PortInfo{{Port: 53, Protocol: "UDP"}}},
+	}
+	return m
+}
+
+func TestBatchServiceKeys_Selection(t *testing.T) {
+	m := newBatchWizardModel()
+	w := m.ui.addWizard
+
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	assert.Equal(t, []string{"api:80"}, batchKeys(w.selectedBatchRows()))
+
+	// UDP rows cannot be selected
+	w.cursor = 2
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	assert.Len(t, w.selectedBatchRows(), 1)
+
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	assert.Equal(t, []string{"api:80", "api:9090"}, batchKeys(w.selectedBatchRows()))
+	assert.Empty(t, w.searchFilter, "letters must not start a filter")
+
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	assert.Empty(t, w.selectedBatchRows())
+
+	// Navigation still works through the common handler
+	w.cursor = 0
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, w.cursor)
+}
+
+func TestBatchServiceKeys_EnterNeedsSelection(t *testing.T) {
+	m := newBatchWizardModel()
+
+	_, cmd := m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.False(t, m.ui.addWizard.loading)
+
+	m.ui.addWizard.selectAllBatchRows()
+	_, cmd = m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.True(t, m.ui.addWizard.loading)
+}
+
+func TestPlanBatchForwards(t *testing.T) {
+	rows := []batchServicePort{
+		{service: "api", port: k8s.PortInfo{Port: 80}},
+		{service: "api", port: k8s.PortInfo{Port: 9090}},
+		{service: "db", port: k8s.PortInfo{Port: 5432}},
+		{service: "cache", port: k8s.PortInfo{Port: 6379}},
+		{service: "web", port: k8s.PortInfo{Port: 8080}},
+	}
+	taken := map[int]bool{10000: true, 6379: true}
+	configured := map[string]bool{"service/db:5432": true}
+	busy := map[int]bool{8080: true}
+	free := func(port int) bool { return !busy[port] }
+
+	fwds, skipped := planBatchForwards(rows, taken, configured, free)
+
+	assert.Equal(t, 1, skipped)
+	require.Len(t, fwds, 4)
+	// Privileged service port moves to the first free port from 10000
+	assert.Equal(t, config.Forward{Resource: "service/api", Protocol: "tcp", Port: 80, LocalPort: 10001, Alias: "api"}, fwds[0])
+	// Unprivileged, free service port is kept
+	assert.Equal(t, 9090, fwds[1].LocalPort)
+	// Taken in config and busy on the host both move on
+	assert.Equal(t, 10002, fwds[2].LocalPort)
+	assert.Equal(t, 10003, fwds[3].LocalPort)
+	assert.True(t, taken[10003])
+}
+
+func TestBatchAddForwardsCmd_SingleWrite(t *testing.T) {
+	orig := localPortFree
+	t.Cleanup(func() { localPortFree = orig })
+	localPortFree = func(int) bool { return true }
+
+	cfgPath := filepath.Join(t.TempDir(), ".kportal.yaml")
+	initial := `contexts:
+  - name: ctx
+    namespaces:
+      - name: ns
+        forwards:
+          - resource: service/api
+            protocol: tcp
+            port: 9090
+            localPort: 9090
+`
+	require.NoError(t, os.WriteFile(cfgPath, []byte(initial), 0o600))
+	mutator := config.NewMutator(cfgPath)
+
+	rows := []batchServicePort{
+		{service: "api", port: k8s.PortInfo{Port: 80}},
+		{service: "api", port: k8s.PortInfo{Port: 9090}},
+		{service: "db", port: k8s.PortInfo{Port: 5432}},
+	}
+	msg := batchAddForwardsCmd(mutator, cfgPath, "ctx", "ns", rows)()
+
+	saved, ok := msg.(BatchForwardsSavedMsg)
+	require.True(t, ok)
+	require.NoError(t, saved.err)
+	assert.Equal(t, 1, saved.skipped)
+	require.Len(t, saved.forwards, 2)
+
+	cfg, err := config.LoadConfig(cfgPath)
+	require.NoError(t, err)
+	assert.Len(t, cfg.GetAllForwards(), 3)
+}
+
+func TestHandleBatchForwardsSaved(t *testing.T) {
+	m := newBatchWizardModel()
+	m.ui.addWizard.loading = true
+
+	m.handleBatchForwardsSaved(BatchForwardsSavedMsg{skipped: 2})
+	assert.False(t, m.ui.addWizard.loading)
+	assert.Equal(t, StepEnterResource, m.ui.addWizard.step)
+	require.Error(t, m.ui.addWizard.error)
+	assert.Contains(t, m.renderEnterResource(), "already configured")
+
+	added := []config.Forward{
+		{Resource: "service/api", Port: 80, LocalPort: 10000, Alias: "api"},
+		{Resource: "service/api", Port: 9090, LocalPort: 9090, Alias: "api"},
+	}
+	m.ui.addWizard.error = nil
+	m.handleBatchForwardsSaved(BatchForwardsSavedMsg{forwards: added, skipped: 1})
+	assert.Equal(t, StepSuccess, m.ui.addWizard.step)
+
+	view := m.renderSuccess()
+	assert.Contains(t, view, "Added 2 forwards")
+	assert.Contains(t, view, "localhost:10000 → service/api:80")
+	assert.Contains(t, view, "1 already configured, skipped")
+}
+
+func TestRenderBatchServices(t *testing.T) {
+	m := newBatchWizardModel()
+	m.ui.addWizard.batchSelected = map[string]bool{"api:9090": true}
+
+	view := m.renderEnterResource()
+	assert.Contains(t, view, "api:80 (http)")
+	assert.Contains(t, view, "[✓] api:9090 (grpc)")
+	assert.Contains(t, view, "dns:53 UDP, skipped")
+	assert.Contains(t, view, "1 of 3 selected")
+	assert.Contains(t, view, "Space: Toggle")
+}
+
+func batchKeys(rows []batchServicePort) []string {
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		keys[i] = row.key()
+	}
+	return keys
+}
@@ -42,6 +42,12 @@ type ServicesLoadedMsg struct {
 	services []k8s.ServiceInfo
 }
 
+// WorkloadsLoadedMsg is sent when deployments or statefulsets have been loaded
+type WorkloadsLoadedMsg struct {
+	err       error
+	workloads []k8s.WorkloadInfo
+}
+
 // SelectorValidatedMsg is sent when a selector has been validated
 type SelectorValidatedMsg struct {
 	err   error
@@ -52,6 +58,7 @@ type SelectorValidatedMsg struct {
 // PortCheckedMsg is sent when a port's availability has been checked
 type PortCheckedMsg struct {
 	message   string
+	usedBy    string // forward or process holding the port, e.g. "nginx (PID 1234)"
 	port      int
 	available bool
 }
@@ -127,6 +134,20 @@ func loadServicesCmd(discovery *k8s.Discovery, contextName, namespace string) te
 	}
 }
 
+// loadWorkloadsCmd loads workloads of the given kind for the given context and namespace
+func loadWorkloadsCmd(discovery *k8s.Discovery, contextName, namespace, kind string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), k8sAPITimeout)
+		defer cancel()
+
+		workloads, err := discovery.ListWorkloads(ctx, contextName, namespace, kind)
+		if err != nil {
+			return WorkloadsLoadedMsg{err: err}
+		}
+		return WorkloadsLoadedMsg{workloads: workloads}
+	}
+}
+
 // validateSelectorCmd validates a label selector and returns matching pods
 func validateSelectorCmd(discovery *k8s.Discovery, contextName, namespace, selector string) tea.Cmd {
 	return func() tea.Msg {
@@ -167,6 +188,7 @@ func checkPortCmd(port int, configPath, excludeID string) tea.Cmd {
 					port:      port,
 					available: false,
 					message:   fmt.Sprintf("✗ Port %d already assigned to %s", port, fwd.ID()),
+					usedBy:    fwd.ID(),
 				}
 			}
 		}
@@ -174,19 +196,21 @@ func checkPortCmd(port int, configPath, excludeID string) tea.Cmd {
 		// Then check if port is available at OS level
 		available, processInfo, err := k8s.CheckPortAvailability(port)
 
-		msg := ""
+		msg, usedBy := "", ""
 		if err != nil {
 			msg = fmt.Sprintf("✗ Error: %v", err)
 		} else if available {
 			msg = fmt.Sprintf("✓ Port %d available", port)
 		} else {
 			msg = fmt.Sprintf("✗ Port %d in use by %s", port, processInfo)
+			usedBy = processInfo
 		}
 
 		return PortCheckedMsg{
 			port:      port,
 			available: available,
 			message:   msg,
+			usedBy:    usedBy,
 		}
 	}
 }
@@ -284,59 +308,107 @@ func listenBenchmarkProgressCmd(progressCh <-chan BenchmarkProgressMsg) tea.Cmd
 // runBenchmarkCmd runs a benchmark against the given port forward
 // It sends progress updates via tea.Batch until completion
 // The ctx parameter allows the benchmark to be cancelled from outside
-func runBenchmarkCmd(ctx context.Context, forwardID string, localPort int, urlPath, method string, concurrency, requests int, progressCh chan<- BenchmarkProgressMsg) tea.Cmd {
+func runBenchmarkCmd(ctx context.Context, forwardID string, localPort int, urlPath, method string, headers map[string]string, body []byte, concurrency, requests int, duration time.Duration, progressCh chan<- BenchmarkProgressMsg) tea.Cmd {
 	return func() tea.Msg {
 		runner := benchmark.NewRunner()
 
 		url := fmt.Sprintf("http://localhost:%d%s", localPort, urlPath)
 		cfg := benchmark.Config{
-			URL:         url,
-			Method:      method,
-			Concurrency: concurrency,
-			Requests:    requests,
-			Timeout:     30 * time.Second,
-			ProgressCallback: func(completed, total int) {
-				// Recover from panics in the callback
-				defer func() {
-					if r := recover(); r != nil {
-						logger.Debug("recovered from panic in progress callback", map[string]any{"panic": r})
-					}
-				}()
-				// Non-blocking send to progress channel
-				select {
-				case progressCh <- BenchmarkProgressMsg{
-					ForwardID: forwardID,
-					Completed: completed,
-					Total:     total,
-				}:
-				default:
-					// Drop if channel is full
-				}
-			},
+			URL:              url,
+			Method:           method,
+			Headers:          headers,
+			Body:             body,
+			Concurrency:      concurrency,
+			Requests:         requests,
+			Duration:         duration,
+			Timeout:          30 * time.Second,
+			ProgressCallback: benchmarkProgress(forwardID, progressCh),
 		}
 
-		// Use the provided context with a timeout as a safety limit
-		benchCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		benchCtx, cancel := benchmarkContext(ctx, duration)
 		defer cancel()
 
 		results, err := runner.Run(benchCtx, forwardID, cfg)
+		return benchmarkComplete(ctx, forwardID, results, err, progressCh)
+	}
+}
+
+// runTCPBenchmarkCmd runs a TCP connect benchmark against the given port
+// forward, for forwards that do not speak HTTP. A non-empty payload is sent
+// on every connection to time a round trip.
+func runTCPBenchmarkCmd(ctx context.Context, forwardID string, localPort int, payload []byte, concurrency, connections int, duration time.Duration, progressCh chan<- BenchmarkProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		runner := benchmark.NewRunner()
+
+		cfg := benchmark.TCPConfig{
+			Address:          fmt.Sprintf("localhost:%d", localPort),
+			Payload:          payload,
+			Concurrency:      concurrency,
+			Connections:      connections,
+			Duration:         duration,
+			ProgressCallback: benchmarkProgress(forwardID, progressCh),
+		}
+
+		benchCtx, cancel := benchmarkContext(ctx, duration)
+		defer cancel()
 
-		// Close the progress channel when done
-		close(progressCh)
+		results, err := runner.RunTCP(benchCtx, forwardID, cfg)
+		return benchmarkComplete(ctx, forwardID, results, err, progressCh)
+	}
+}
 
-		// Check if cancelled
-		if ctx.Err() != nil {
-			return BenchmarkCompleteMsg{
-				ForwardID: forwardID,
-				Results:   nil,
-				Error:     fmt.Errorf("benchmark cancelled"),
+// benchmarkProgress returns a progress callback that forwards updates to
+// progressCh without blocking
+func benchmarkProgress(forwardID string, progressCh chan<- BenchmarkProgressMsg) benchmark.ProgressCallback {
+	return func(completed, total int) {
+		// Recover from panics in the callback
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Debug("recovered from panic in progress callback", map[string]any{"panic": r})
 			}
+		}()
+		// Non-blocking send to progress channel
+		select {
+		case progressCh <- BenchmarkProgressMsg{
+			ForwardID: forwardID,
+			Completed: completed,
+			Total:     total,
+		}:
+		default:
+			// Drop if channel is full
 		}
+	}
+}
+
+// benchmarkContext derives the context a benchmark runs under from ctx,
+// with a timeout as a safety limit that leaves duration-mode runs room to
+// finish
+func benchmarkContext(ctx context.Context, duration time.Duration) (context.Context, context.CancelFunc) {
+	limit := 5 * time.Minute
+	if duration+time.Minute > limit {
+		limit = duration + time.Minute
+	}
+	return context.WithTimeout(ctx, limit)
+}
+
+// benchmarkComplete closes the progress channel and builds the completion
+// message, reporting a cancelled run if ctx was cancelled
+func benchmarkComplete(ctx context.Context, forwardID string, results *benchmark.Results, err error, progressCh chan<- BenchmarkProgressMsg) tea.Msg {
+	// Close the progress channel when done
+	close(progressCh)
 
+	// Check if cancelled
+	if ctx.Err() != nil {
 		return BenchmarkCompleteMsg{
 			ForwardID: forwardID,
-			Results:   results,
-			Error:     err,
+			Results:   nil,
+			Error:     fmt.Errorf("benchmark cancelled"),
 		}
 	}
+
+	return BenchmarkCompleteMsg{
+		ForwardID: forwardID,
+		Results:   results,
+		Error:     err,
+	}
 }
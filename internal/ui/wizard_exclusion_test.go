@@ -74,7 +74,7 @@ func TestWizardMutualExclusion_HTTPLogBlocksOthers(t *testing.T) {
 	// Activate HTTP log view
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("test-id", "my-app")
+	ui.httpLogState = newHTTPLogState("test-id", "my-app", 0)
 	ui.mu.Unlock()
 
 	ui.mu.RLock()
@@ -124,7 +124,7 @@ func TestWizardMutualExclusion_CheckActiveModal(t *testing.T) {
 		{
 			name: "http log active",
 			setupFunc: func(ui *BubbleTeaUI) {
-				ui.httpLogState = newHTTPLogState("id", "alias")
+				ui.httpLogState = newHTTPLogState("id", "alias", 0)
 			},
 			expectActive:   true,
 			activeModalStr: "httpLog",
@@ -216,7 +216,7 @@ func TestWizardCleanup_HTTPLogReset(t *testing.T) {
 	// Set up HTTP log with cleanup function
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("id", "alias")
+	ui.httpLogState = newHTTPLogState("id", "alias", 0)
 	ui.httpLogState.entries = []HTTPLogEntry{{Method: "GET", Path: "/"}}
 	ui.httpLogCleanup = func() { cleanupCalled = true }
 	ui.mu.Unlock()
@@ -3,6 +3,7 @@ package ui
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 	"strconv"
@@ -11,6 +12,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lukaszraczylo/kportal/internal/config"
+	"github.com/lukaszraczylo/kportal/internal/httplog"
 	"github.com/lukaszraczylo/kportal/internal/k8s"
 )
 
@@ -20,7 +22,7 @@ func isFilterableStep(step AddWizardStep) bool {
 	case StepSelectContext, StepSelectNamespace:
 		return true
 	case StepEnterResource:
-		// Only service selection is filterable (pod prefix and selector are text input)
+		// Only service and workload selection is filterable (pod prefix and selector are text input)
 		return true // We'll check resource type in the handler
 	default:
 		return false
@@ -34,6 +36,15 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleDeleteConfirmation(msg)
 	}
 
+	// While the filter is being typed, keys edit the filter text
+	if m.ui.mainFilterActive {
+		return m.handleMainFilterKeys(msg)
+	}
+
+	if m.ui.readOnly && isConfigKey(msg.String()) {
+		return m.showReadOnlyNotice()
+	}
+
 	switch msg.String() {
 	case "ctrl+c", "q":
 		return m, tea.Quit
@@ -50,13 +61,60 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "pgdown", "ctrl+d":
 		m.ui.moveSelection(10)
 
-	case " ", "enter":
+	case " ":
 		m.ui.toggleSelected()
 
+	case "enter": // Show everything about the selected forward
+		return m.openDetail()
+
+	case "/": // Filter the forward list
+		m.ui.mu.Lock()
+		m.ui.mainFilterActive = true
+		m.ui.mainFilter = ""
+		m.ui.selectedIndex = 0
+		m.ui.mu.Unlock()
+
+	case "y": // Copy the selected forward's host:port
+		return m.copySelectedAddress(false)
+
+	case "Y": // Copy the selected forward's URL
+		return m.copySelectedAddress(true)
+
+	case "o": // Open the selected forward's URL in the browser
+		return m.openSelectedInBrowser()
+
+	case "t": // Show or hide the traffic columns
+		return m.toggleTraffic()
+
+	case "a": // Show or hide the request-rate sparkline column
+		return m.toggleActivity()
+
+	case "1", "2", "3", "4", "5": // Show or hide a column
+		if col, ok := columnForKey(msg.String()); ok {
+			m.ui.toggleColumn(col, m.narrow())
+		}
+
+	case "x": // Open the byte trace of the selected forward
+		return m.openTrace()
+
+	case "P": // Pause or resume every forward
+		return m.togglePauseAll()
+
+	case "p": // Add the forward spec on the clipboard
+		return m.quickAddFromClipboard()
+
+	case "esc": // Clear an applied filter
+		m.ui.mu.Lock()
+		if m.ui.mainFilter != "" {
+			m.ui.mainFilter = ""
+			m.ui.selectedIndex = 0
+		}
+		m.ui.mu.Unlock()
+
 	case "n": // Enter add wizard
 		m.ui.mu.Lock()
 		// Don't create a new wizard if one is already active
-		if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil {
+		if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil || m.ui.traceState != nil {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
@@ -77,12 +135,13 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "e": // Edit selected forward
 		m.ui.mu.Lock()
 		// Don't create a new wizard if one is already active
-		if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil {
+		if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil || m.ui.traceState != nil {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
 
-		if len(m.ui.forwardOrder) == 0 {
+		visible := m.ui.visibleForwards()
+		if len(visible) == 0 {
 			// No forwards to edit
 			m.ui.mu.Unlock()
 			return m, nil
@@ -96,12 +155,12 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		// Get the currently selected forward
 		currentSelectedIndex := m.ui.selectedIndex
-		if currentSelectedIndex < 0 || currentSelectedIndex >= len(m.ui.forwardOrder) {
+		if currentSelectedIndex < 0 || currentSelectedIndex >= len(visible) {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
 
-		selectedID := m.ui.forwardOrder[currentSelectedIndex]
+		selectedID := visible[currentSelectedIndex]
 		selectedForward, ok := m.ui.forwards[selectedID]
 		if !ok {
 			m.ui.mu.Unlock()
@@ -120,12 +179,31 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.ui.addWizard.localPort = selectedForward.LocalPort
 		m.ui.addWizard.alias = selectedForward.Alias
 		m.ui.addWizard.httpLogOriginal = selectedForward.HTTPLog
+		m.ui.addWizard.healthCheckOriginal = selectedForward.HealthCheck
+		m.ui.addWizard.enabledOriginal = selectedForward.Enabled
+		m.ui.addWizard.mdnsPublishOriginal = selectedForward.MDNSPublish
+		m.ui.addWizard.tagsOriginal = selectedForward.Tags
+		m.ui.addWizard.containerOriginal = selectedForward.Container
+		m.ui.addWizard.portNameOriginal = selectedForward.PortName
+		m.ui.addWizard.reconnectMaxRetriesOriginal = selectedForward.ReconnectMaxRetries
+		m.ui.addWizard.idleTimeoutOriginal = selectedForward.IdleTimeout
+		m.ui.addWizard.tcpKeepaliveOriginal = selectedForward.TCPKeepalive
+		m.ui.addWizard.dialTimeoutOriginal = selectedForward.DialTimeout
+		m.ui.addWizard.schemeOriginal = selectedForward.Scheme
+		m.ui.addWizard.bindAddressOriginal = selectedForward.BindAddress
+		m.ui.addWizard.traceBytesOriginal = selectedForward.TraceBytes
+		m.ui.addWizard.orderOriginal = selectedForward.Order
 		m.ui.addWizard.httpLog = selectedForward.HTTPLog != nil && selectedForward.HTTPLog.Enabled
 
 		// Determine resource type from the resource string
-		if strings.HasPrefix(selectedForward.Type, "service") {
+		switch {
+		case strings.HasPrefix(selectedForward.Type, "service"):
 			m.ui.addWizard.selectedResourceType = ResourceTypeService
-		} else {
+		case selectedForward.Type == "deployment":
+			m.ui.addWizard.selectedResourceType = ResourceTypeDeployment
+		case selectedForward.Type == "statefulset":
+			m.ui.addWizard.selectedResourceType = ResourceTypeStatefulSet
+		default:
 			m.ui.addWizard.selectedResourceType = ResourceTypePodPrefix
 		}
 
@@ -140,10 +218,13 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.ui.addWizard.loading = true
 		m.ui.mu.Unlock()
 
-		// Load pods or services to detect available ports
+		// Load pods, services or workloads to detect available ports
 		if m.ui.addWizard.selectedResourceType == ResourceTypeService {
 			return m, loadServicesCmd(m.ui.discovery, selectedForward.Context, selectedForward.Namespace)
 		}
+		if kind := m.ui.addWizard.selectedResourceType.WorkloadKind(); kind != "" {
+			return m, loadWorkloadsCmd(m.ui.discovery, selectedForward.Context, selectedForward.Namespace, kind)
+		}
 		return m, loadPodsCmd(m.ui.discovery, selectedForward.Context, selectedForward.Namespace)
 
 	case "d": // Delete currently selected forward - show confirmation
@@ -155,7 +236,8 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		if len(m.ui.forwardOrder) == 0 {
+		visible := m.ui.visibleForwards()
+		if len(visible) == 0 {
 			// No forwards to delete
 			m.ui.mu.Unlock()
 			return m, nil
@@ -169,12 +251,12 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		// Get the currently selected forward
 		currentSelectedIndex := m.ui.selectedIndex
-		if currentSelectedIndex < 0 || currentSelectedIndex >= len(m.ui.forwardOrder) {
+		if currentSelectedIndex < 0 || currentSelectedIndex >= len(visible) {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
 
-		selectedID := m.ui.forwardOrder[currentSelectedIndex]
+		selectedID := visible[currentSelectedIndex]
 		selectedForward, ok := m.ui.forwards[selectedID]
 		if !ok {
 			m.ui.mu.Unlock()
@@ -193,23 +275,24 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "b": // Benchmark selected forward
 		m.ui.mu.Lock()
 		// Don't create benchmark view if another modal is active
-		if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil {
+		if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil || m.ui.traceState != nil {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
 
-		if len(m.ui.forwardOrder) == 0 {
+		visible := m.ui.visibleForwards()
+		if len(visible) == 0 {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
 
 		currentSelectedIndex := m.ui.selectedIndex
-		if currentSelectedIndex < 0 || currentSelectedIndex >= len(m.ui.forwardOrder) {
+		if currentSelectedIndex < 0 || currentSelectedIndex >= len(visible) {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
 
-		selectedID := m.ui.forwardOrder[currentSelectedIndex]
+		selectedID := visible[currentSelectedIndex]
 		selectedForward, ok := m.ui.forwards[selectedID]
 		if !ok {
 			m.ui.mu.Unlock()
@@ -228,23 +311,24 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "l": // View HTTP logs for selected forward
 		m.ui.mu.Lock()
 		// Don't create log view if another modal is active
-		if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil {
+		if m.ui.addWizard != nil || m.ui.removeWizard != nil || m.ui.benchmarkState != nil || m.ui.httpLogState != nil || m.ui.traceState != nil {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
 
-		if len(m.ui.forwardOrder) == 0 {
+		visible := m.ui.visibleForwards()
+		if len(visible) == 0 {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
 
 		currentSelectedIndex := m.ui.selectedIndex
-		if currentSelectedIndex < 0 || currentSelectedIndex >= len(m.ui.forwardOrder) {
+		if currentSelectedIndex < 0 || currentSelectedIndex >= len(visible) {
 			m.ui.mu.Unlock()
 			return m, nil
 		}
 
-		selectedID := m.ui.forwardOrder[currentSelectedIndex]
+		selectedID := visible[currentSelectedIndex]
 		selectedForward, ok := m.ui.forwards[selectedID]
 		if !ok {
 			m.ui.mu.Unlock()
@@ -253,7 +337,7 @@ func (m model) handleMainViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		// Create HTTP log state
 		m.ui.viewMode = ViewModeHTTPLog
-		m.ui.httpLogState = newHTTPLogState(selectedID, selectedForward.Alias)
+		m.ui.httpLogState = newHTTPLogState(selectedID, selectedForward.Alias, m.ui.httpLogMaxEntries)
 
 		// Capture subscriber and UI reference for the callback
 		subscriber := m.ui.httpLogSubscriber
@@ -339,6 +423,20 @@ func (m model) handleAddWizardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// In edit mode, r on the confirmation buttons re-enters the full flow so
+	// the forward can be repointed at another context, namespace or resource
+	if msg.String() == "r" && wizard.isEditing && wizard.step == StepConfirmation && wizard.confirmationFocus != FocusAlias {
+		wizard.restartFlow()
+		return m, loadContextsCmd(m.ui.discovery)
+	}
+
+	// The batch service checklist uses Space/a/n like the remove wizard
+	if wizard.step == StepEnterResource && wizard.selectedResourceType == ResourceTypeServiceBatch {
+		if handled, cmd := m.handleBatchServiceKeys(msg); handled {
+			return m, cmd
+		}
+	}
+
 	switch msg.String() {
 	case "ctrl+c":
 		// Hard cancel
@@ -354,7 +452,8 @@ func (m model) handleAddWizardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 		// In edit mode, Esc always cancels (don't navigate back through skipped steps)
-		if wizard.isEditing {
+		// unless the full flow was re-entered with r
+		if wizard.isEditing && !wizard.repointing {
 			m.ui.viewMode = ViewModeMain
 			m.ui.addWizard = nil
 			return m, tea.ClearScreen
@@ -376,7 +475,7 @@ func (m model) handleAddWizardKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			case StepSelectContext, StepSelectNamespace, StepSelectResourceType:
 				wizard.inputMode = InputModeList
 			case StepEnterResource:
-				if wizard.selectedResourceType == ResourceTypeService {
+				if wizard.selectedResourceType.isListSelection() {
 					wizard.inputMode = InputModeList
 				} else {
 					wizard.inputMode = InputModeText
@@ -540,15 +639,20 @@ func (m model) handleAddWizardEnter() (tea.Model, tea.Cmd) {
 		}
 
 	case StepSelectResourceType:
-		if wizard.cursor >= 0 && wizard.cursor < 3 {
-			wizard.selectedResourceType = ResourceType(wizard.cursor)
+		resourceTypes := wizard.resourceTypes()
+		if wizard.cursor >= 0 && wizard.cursor < len(resourceTypes) {
+			wizard.selectedResourceType = resourceTypes[wizard.cursor]
 			wizard.step = StepEnterResource
 			wizard.cursor = 0
 
-			if wizard.selectedResourceType == ResourceTypeService {
+			if wizard.selectedResourceType == ResourceTypeService || wizard.selectedResourceType == ResourceTypeServiceBatch {
 				wizard.inputMode = InputModeList
 				wizard.loading = true
 				return m, loadServicesCmd(m.ui.discovery, wizard.selectedContext, wizard.selectedNamespace)
+			} else if kind := wizard.selectedResourceType.WorkloadKind(); kind != "" {
+				wizard.inputMode = InputModeList
+				wizard.loading = true
+				return m, loadWorkloadsCmd(m.ui.discovery, wizard.selectedContext, wizard.selectedNamespace, kind)
 			} else {
 				wizard.inputMode = InputModeText
 				wizard.loading = true
@@ -603,6 +707,26 @@ func (m model) handleAddWizardEnter() (tea.Model, tea.Cmd) {
 				wizard.clearTextInput()
 				wizard.clearSearchFilter()
 
+				if len(wizard.detectedPorts) > 0 {
+					wizard.inputMode = InputModeList
+					wizard.cursor = 0
+				} else {
+					wizard.inputMode = InputModeText
+				}
+			}
+
+		case ResourceTypeDeployment, ResourceTypeStatefulSet:
+			filteredWorkloads := wizard.getFilteredWorkloads()
+			if wizard.cursor >= 0 && wizard.cursor < len(filteredWorkloads) {
+				wizard.resourceValue = filteredWorkloads[wizard.cursor].Name
+
+				// Ports come from the workload's pod template
+				wizard.detectedPorts = filteredWorkloads[wizard.cursor].Ports
+
+				wizard.step = StepEnterRemotePort
+				wizard.clearTextInput()
+				wizard.clearSearchFilter()
+
 				if len(wizard.detectedPorts) > 0 {
 					wizard.inputMode = InputModeList
 					wizard.cursor = 0
@@ -624,11 +748,21 @@ func (m model) handleAddWizardEnter() (tea.Model, tea.Cmd) {
 				// For services, use TargetPort (actual pod port) if available
 				// For pods, TargetPort is 0, so use Port (container port)
 				selectedPort := wizard.detectedPorts[wizard.cursor]
-				if selectedPort.TargetPort > 0 {
+				switch {
+				case wizard.selectedResourceType == ResourceTypeService && selectedPort.Name != "":
+					// Named service ports are stored by name and translated to
+					// the current targetPort on every connect
+					wizard.remotePort = 0
+					wizard.portNameOriginal = selectedPort.Name
+				case selectedPort.TargetPort > 0:
 					wizard.remotePort = int(selectedPort.TargetPort)
-				} else {
+				default:
 					wizard.remotePort = int(selectedPort.Port)
 				}
+				// Keep a configured port name only if the same named port was picked
+				if selectedPort.Name != wizard.portNameOriginal {
+					wizard.portNameOriginal = ""
+				}
 				wizard.step = StepEnterLocalPort
 				wizard.clearTextInput()
 				wizard.inputMode = InputModeText
@@ -641,6 +775,7 @@ func (m model) handleAddWizardEnter() (tea.Model, tea.Cmd) {
 				wizard.error = fmt.Errorf("invalid port number")
 			} else {
 				wizard.remotePort = port
+				wizard.portNameOriginal = ""
 				wizard.step = StepEnterLocalPort
 				wizard.clearTextInput()
 				wizard.error = nil
@@ -684,10 +819,24 @@ func (m model) handleAddWizardEnter() (tea.Model, tea.Cmd) {
 
 			// Build the forward config
 			fwd := config.Forward{
-				Protocol:  "tcp",
-				Port:      wizard.remotePort,
-				LocalPort: wizard.localPort,
-				Alias:     wizard.alias,
+				Protocol:            "tcp",
+				Port:                wizard.remotePort,
+				LocalPort:           wizard.localPort,
+				Alias:               wizard.alias,
+				Enabled:             wizard.enabledOriginal,     // keep `enabled: false` across edits
+				MDNSPublish:         wizard.mdnsPublishOriginal, // keep `mdnsPublish: false` across edits
+				Tags:                wizard.tagsOriginal,
+				Container:           wizard.containerOriginal,
+				PortName:            wizard.portNameOriginal,
+				HealthCheck:         wizard.healthCheckOriginal, // the wizard does not edit probes
+				ReconnectMaxRetries: wizard.reconnectMaxRetriesOriginal,
+				IdleTimeout:         wizard.idleTimeoutOriginal,
+				TCPKeepalive:        wizard.tcpKeepaliveOriginal,
+				DialTimeout:         wizard.dialTimeoutOriginal,
+				Scheme:              wizard.schemeOriginal,
+				BindAddress:         wizard.bindAddressOriginal,
+				TraceBytes:          wizard.traceBytesOriginal,
+				Order:               wizard.orderOriginal,
 			}
 
 			switch wizard.selectedResourceType {
@@ -698,6 +847,8 @@ func (m model) handleAddWizardEnter() (tea.Model, tea.Cmd) {
 				fwd.Selector = wizard.selector
 			case ResourceTypeService:
 				fwd.Resource = "service/" + wizard.resourceValue
+			case ResourceTypeDeployment, ResourceTypeStatefulSet:
+				fwd.Resource = wizard.selectedResourceType.WorkloadKind() + "/" + wizard.resourceValue
 			}
 
 			// HTTPLog: when toggled on, preserve any advanced fields the
@@ -900,6 +1051,38 @@ func (m model) handlePodsLoaded(msg PodsLoadedMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m model) handleWorkloadsLoaded(msg WorkloadsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.ui.mu.Lock()
+	defer m.ui.mu.Unlock()
+
+	if m.ui.addWizard != nil {
+		m.ui.addWizard.loading = false
+		m.ui.addWizard.error = msg.err
+		if msg.err == nil {
+			m.ui.addWizard.workloads = msg.workloads
+
+			// If we're at the remote port step (edit mode), detect ports now
+			if m.ui.addWizard.step == StepEnterRemotePort {
+				for _, wl := range msg.workloads {
+					if wl.Name == m.ui.addWizard.resourceValue {
+						m.ui.addWizard.detectedPorts = wl.Ports
+						if len(m.ui.addWizard.detectedPorts) > 0 {
+							m.ui.addWizard.inputMode = InputModeList
+							m.ui.addWizard.cursor = 0
+						} else {
+							m.ui.addWizard.inputMode = InputModeText
+							m.ui.addWizard.textInput = fmt.Sprintf("%d", m.ui.addWizard.remotePort)
+						}
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return m, nil
+}
+
 func (m model) handleServicesLoaded(msg ServicesLoadedMsg) (tea.Model, tea.Cmd) {
 	m.ui.mu.Lock()
 	defer m.ui.mu.Unlock()
@@ -962,12 +1145,18 @@ func (m model) handlePortChecked(msg PortCheckedMsg) (tea.Model, tea.Cmd) {
 		// Only proceed to confirmation if port is available
 		if msg.available {
 			m.ui.addWizard.step = StepConfirmation
-			m.ui.addWizard.clearTextInput()
+			// Pre-fill the alias field (non-empty only when editing)
+			m.ui.addWizard.textInput = m.ui.addWizard.alias
 			m.ui.addWizard.cursor = 0
 			m.ui.addWizard.inputMode = InputModeList
 		} else {
-			// Port is not available - show error and stay on local port step
-			m.ui.addWizard.error = fmt.Errorf("port %d is in use, please choose another port", msg.port)
+			// Port is not available - show error and stay on local port step,
+			// naming the holder so the user knows what to stop
+			if msg.usedBy != "" {
+				m.ui.addWizard.error = fmt.Errorf("port %d is in use by %s, stop it or choose another port", msg.port, msg.usedBy)
+			} else {
+				m.ui.addWizard.error = fmt.Errorf("port %d is in use, please choose another port", msg.port)
+			}
 		}
 	}
 
@@ -1029,15 +1218,17 @@ func (m model) handleBenchmarkKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.ui.benchmarkState = nil
 		return m, tea.ClearScreen
 
-	case "up", "k":
+	// j/k are not navigation keys here: every field is free text and
+	// headers or bodies routinely contain those letters
+	case "up":
 		if state.step == BenchmarkStepConfig && state.cursor > 0 {
 			state.cursor--
 			// Load current field value into textInput
 			state.textInput = m.getBenchmarkFieldValue(state.cursor)
 		}
 
-	case "down", "j":
-		if state.step == BenchmarkStepConfig && state.cursor < 3 {
+	case "down":
+		if state.step == BenchmarkStepConfig && state.cursor < len(state.fields())-1 {
 			state.cursor++
 			// Load current field value into textInput
 			state.textInput = m.getBenchmarkFieldValue(state.cursor)
@@ -1046,16 +1237,45 @@ func (m model) handleBenchmarkKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "tab":
 		// Tab also cycles through fields
 		if state.step == BenchmarkStepConfig {
-			state.cursor = (state.cursor + 1) % 4
+			state.cursor = (state.cursor + 1) % len(state.fields())
+			state.textInput = m.getBenchmarkFieldValue(state.cursor)
+		}
+
+	case "left", "right":
+		// Switch between HTTP and TCP; the field sets differ, so start over
+		if state.step == BenchmarkStepConfig {
+			if state.mode == BenchmarkModeHTTP {
+				state.mode = BenchmarkModeTCP
+			} else {
+				state.mode = BenchmarkModeHTTP
+			}
+			state.cursor = 0
 			state.textInput = m.getBenchmarkFieldValue(state.cursor)
+			state.error = nil
 		}
 
 	case "enter":
 		switch state.step {
 		case BenchmarkStepConfig:
+			var headers map[string]string
+			if state.mode == BenchmarkModeHTTP {
+				var err error
+				if headers, err = parseBenchmarkHeaders(state.headers); err != nil {
+					state.error = err
+					return m, nil
+				}
+			}
+			body, err := loadBenchmarkBody(state.body)
+			if err != nil {
+				state.error = err
+				return m, nil
+			}
+			state.error = nil
+
 			// Start running the benchmark
 			state.step = BenchmarkStepRunning
 			state.running = true
+			state.startedAt = time.Now()
 			state.progress = 0
 			state.total = state.requests
 			// Create progress channel with buffer for non-blocking sends
@@ -1063,11 +1283,13 @@ func (m model) handleBenchmarkKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Create cancellable context for the benchmark
 			ctx, cancel := context.WithCancel(context.Background())
 			state.cancelFunc = cancel
+			duration := time.Duration(state.duration) * time.Second
+			run := runBenchmarkCmd(ctx, state.forwardID, state.localPort, state.urlPath, state.method, headers, body, state.concurrency, state.requests, duration, state.progressCh)
+			if state.mode == BenchmarkModeTCP {
+				run = runTCPBenchmarkCmd(ctx, state.forwardID, state.localPort, body, state.concurrency, state.requests, duration, state.progressCh)
+			}
 			// Return batch command to run benchmark and listen for progress
-			return m, tea.Batch(
-				runBenchmarkCmd(ctx, state.forwardID, state.localPort, state.urlPath, state.method, state.concurrency, state.requests, state.progressCh),
-				listenBenchmarkProgressCmd(state.progressCh),
-			)
+			return m, tea.Batch(run, listenBenchmarkProgressCmd(state.progressCh))
 		case BenchmarkStepResults:
 			// Return to main view
 			m.ui.viewMode = ViewModeMain
@@ -1104,15 +1326,26 @@ func (m model) getBenchmarkFieldValue(cursor int) string {
 		return ""
 	}
 
-	switch cursor {
-	case 0:
+	fields := state.fields()
+	if cursor < 0 || cursor >= len(fields) {
+		return ""
+	}
+
+	switch fields[cursor] {
+	case benchmarkFieldURLPath:
 		return state.urlPath
-	case 1:
+	case benchmarkFieldMethod:
 		return state.method
-	case 2:
+	case benchmarkFieldConcurrency:
 		return fmt.Sprintf("%d", state.concurrency)
-	case 3:
+	case benchmarkFieldRequests:
 		return fmt.Sprintf("%d", state.requests)
+	case benchmarkFieldDuration:
+		return fmt.Sprintf("%d", state.duration)
+	case benchmarkFieldHeaders:
+		return state.headers
+	case benchmarkFieldBody:
+		return state.body
 	default:
 		return ""
 	}
@@ -1125,28 +1358,69 @@ func (m model) applyBenchmarkTextInput() {
 		return
 	}
 
-	switch state.cursor {
-	case 0: // URL path
+	switch state.field() {
+	case benchmarkFieldURLPath:
 		state.urlPath = state.textInput
-	case 1: // Method
+	case benchmarkFieldMethod:
 		state.method = strings.ToUpper(state.textInput)
-	case 2: // Concurrency
+	case benchmarkFieldConcurrency:
 		if val, err := strconv.Atoi(state.textInput); err == nil && val > 0 {
 			state.concurrency = val
-			// Cap concurrency at requests
-			if state.concurrency > state.requests {
+			// Cap concurrency at requests (duration mode has no request count)
+			if state.duration == 0 && state.concurrency > state.requests {
 				state.concurrency = state.requests
 			}
 		}
-	case 3: // Requests
+	case benchmarkFieldRequests:
 		if val, err := strconv.Atoi(state.textInput); err == nil && val > 0 {
 			state.requests = val
 			// Cap concurrency at requests
-			if state.concurrency > state.requests {
+			if state.duration == 0 && state.concurrency > state.requests {
 				state.concurrency = state.requests
 			}
 		}
+	case benchmarkFieldDuration:
+		if val, err := strconv.Atoi(state.textInput); err == nil && val >= 0 {
+			state.duration = val
+		}
+	case benchmarkFieldHeaders:
+		state.headers = state.textInput
+	case benchmarkFieldBody:
+		state.body = state.textInput
+	}
+}
+
+// parseBenchmarkHeaders parses "Name: value; Name2: value2" into a header map.
+func parseBenchmarkHeaders(input string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, part := range strings.Split(input, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header %q (expected Name: value)", part)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// loadBenchmarkBody returns the request body. A value starting with @ is
+// treated as a file path whose contents are sent, like curl's --data @file.
+func loadBenchmarkBody(input string) ([]byte, error) {
+	path, isFile := strings.CutPrefix(input, "@")
+	if !isFile {
+		return []byte(input), nil
+	}
+	// #nosec G304 -- path is typed interactively by the user running kportal
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body file: %w", err)
 	}
+	return data, nil
 }
 
 // handleHTTPLogKeys handles keyboard input in the HTTP log view
@@ -1244,10 +1518,44 @@ func (m model) handleHTTPLogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return m, nil
+		case "C":
+			// Copy the request as an equivalent curl command
+			if state.cursor < 0 || state.cursor >= len(filteredEntries) {
+				return m, nil
+			}
+			localPort := 0
+			if fwd, ok := m.ui.forwards[state.forwardID]; ok {
+				localPort = fwd.LocalPort
+			}
+			if err := writeClipboard(curlCommand(filteredEntries[state.cursor], localPort)); err == nil {
+				state.copyMessage = "Copied curl command!"
+			} else {
+				state.copyMessage = "Clipboard unavailable"
+			}
+			return m, tea.Tick(copyMessageDuration, func(t time.Time) tea.Msg {
+				return clearCopyMessageMsg{}
+			})
+		case "r":
+			// Re-issue the request; its response arrives as a new entry
+			if state.cursor < 0 || state.cursor >= len(filteredEntries) {
+				return m, nil
+			}
+			fwd, ok := m.ui.forwards[state.forwardID]
+			if !ok || fwd.LocalPort == 0 {
+				state.copyMessage = "Replay failed: forward is not running"
+				return m, nil
+			}
+			state.copyMessage = "Replaying..."
+			return m, replayHTTPRequestCmd(filteredEntries[state.cursor], fwd.LocalPort)
 		}
 		return m, nil
 	}
 
+	// A pending clear is confirmed by pressing x again; any other key
+	// cancels it
+	clearConfirming := state.clearConfirming
+	state.clearConfirming = false
+
 	switch msg.String() {
 	case "ctrl+c", "esc", "q":
 		// Cleanup subscription before closing
@@ -1322,6 +1630,11 @@ func (m model) handleHTTPLogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Toggle auto-scroll
 		state.autoScroll = !state.autoScroll
 
+	case "t":
+		// Toggle coloring rows by latency instead of status; kept across
+		// log views
+		m.ui.httpLogLatencyColor = !m.ui.httpLogLatencyColor
+
 	case "f":
 		// Cycle filter mode (skip Text mode when cycling - use '/' for text filter)
 		state.filterMode = (state.filterMode + 1) % 4
@@ -1343,11 +1656,96 @@ func (m model) handleHTTPLogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		state.filterText = ""
 		state.cursor = 0
 		state.scrollOffset = 0
+
+	case "e":
+		// Export the visible entries to a HAR file in the working directory
+		localPort := 0
+		if fwd, ok := m.ui.forwards[state.forwardID]; ok {
+			localPort = fwd.LocalPort
+		}
+		path, count, err := exportHAR(filteredEntries, state.forwardAlias, localPort, m.ui.version)
+		if err != nil {
+			state.copyMessage = "Export failed: " + err.Error()
+		} else {
+			state.copyMessage = fmt.Sprintf("Exported %d requests to %s", count, path)
+		}
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+			return clearCopyMessageMsg{}
+		})
+
+	case "x":
+		// Clear the log buffer, after a second x to confirm
+		if len(state.entries) == 0 {
+			return m, nil
+		}
+		if !clearConfirming {
+			state.clearConfirming = true
+			return m, nil
+		}
+		cleared := len(state.entries)
+		state.entries = make([]HTTPLogEntry, 0)
+		state.cursor = 0
+		state.scrollOffset = 0
+		state.autoScroll = true
+		state.copyMessage = fmt.Sprintf("Cleared %d entries", cleared)
+		return m, tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+			return clearCopyMessageMsg{}
+		})
 	}
 
 	return m, nil
 }
 
+// exportHAR writes completed entries to a timestamped HAR file in the
+// working directory. Requests still awaiting a response are skipped.
+// Returns the file name and the number of requests written.
+func exportHAR(entries []HTTPLogEntry, alias string, localPort int, appVersion string) (string, int, error) {
+	logEntries := make([]httplog.Entry, 0, len(entries)*2)
+	for _, e := range entries {
+		if e.Direction != "response" {
+			continue
+		}
+		logEntries = append(logEntries,
+			httplog.Entry{
+				Timestamp: e.StartedAt,
+				RequestID: e.RequestID,
+				Direction: "request",
+				Method:    e.Method,
+				Path:      e.Path,
+				Headers:   e.RequestHeaders,
+				Body:      e.RequestBody,
+				BodySize:  len(e.RequestBody),
+			},
+			httplog.Entry{
+				Timestamp:  e.StartedAt.Add(time.Duration(e.LatencyMs) * time.Millisecond),
+				RequestID:  e.RequestID,
+				Direction:  "response",
+				Method:     e.Method,
+				Path:       e.Path,
+				Headers:    e.ResponseHeaders,
+				Body:       e.ResponseBody,
+				BodySize:   e.BodySize,
+				StatusCode: e.StatusCode,
+				LatencyMs:  e.LatencyMs,
+			},
+		)
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, alias)
+	path := fmt.Sprintf("kportal-%s-%s.har", name, time.Now().Format("20060102-150405"))
+
+	count, err := httplog.WriteHARFile(path, logEntries, fmt.Sprintf("http://localhost:%d", localPort), appVersion)
+	if err != nil {
+		return "", 0, err
+	}
+	return path, count, nil
+}
+
 // handleHTTPLogEntry handles incoming HTTP log entries
 func (m model) handleHTTPLogEntry(msg HTTPLogEntryMsg) (tea.Model, tea.Cmd) {
 	m.ui.mu.Lock()
@@ -1373,6 +1771,8 @@ func (m model) handleHTTPLogEntry(msg HTTPLogEntryMsg) (tea.Model, tea.Cmd) {
 				state.entries[i].ResponseHeaders = entry.ResponseHeaders
 				state.entries[i].ResponseBody = entry.ResponseBody
 				state.entries[i].Error = entry.Error
+				state.entries[i].GRPCStatus = entry.GRPCStatus
+				state.entries[i].GRPCMessage = entry.GRPCMessage
 				return m, nil
 			}
 		}
@@ -1381,16 +1781,8 @@ func (m model) handleHTTPLogEntry(msg HTTPLogEntryMsg) (tea.Model, tea.Cmd) {
 	// For requests or unmatched responses, append as new entry
 	state.entries = append(state.entries, entry)
 
-	// Cap entries to prevent memory growth (keep last 10000 entries)
-	const maxEntries = 10000
-	if len(state.entries) > maxEntries {
-		// Remove oldest entries
-		state.entries = state.entries[len(state.entries)-maxEntries:]
-		// Adjust cursor if needed
-		if state.cursor >= len(state.entries) {
-			state.cursor = len(state.entries) - 1
-		}
-	}
+	// Cap entries to prevent memory growth (httpLogMaxEntries)
+	state.trimEntries()
 
 	// Auto-scroll to bottom if enabled
 	if state.autoScroll && len(state.entries) > 0 {
@@ -1445,24 +1837,41 @@ func (m model) handleBenchmarkComplete(msg BenchmarkCompleteMsg) (tea.Model, tea
 	} else if msg.Results != nil {
 		stats := msg.Results.CalculateStats()
 		state.results = &BenchmarkResults{
+			Mode:          state.mode,
 			TotalRequests: msg.Results.TotalRequests,
 			Successful:    msg.Results.Successful,
 			Failed:        msg.Results.Failed,
-			MinLatency:    float64(stats.MinLatency.Milliseconds()),
-			MaxLatency:    float64(stats.MaxLatency.Milliseconds()),
-			AvgLatency:    float64(stats.AvgLatency.Milliseconds()),
-			P50Latency:    float64(stats.P50Latency.Milliseconds()),
-			P95Latency:    float64(stats.P95Latency.Milliseconds()),
-			P99Latency:    float64(stats.P99Latency.Milliseconds()),
+			MinLatency:    durationMillis(stats.MinLatency),
+			MaxLatency:    durationMillis(stats.MaxLatency),
+			AvgLatency:    durationMillis(stats.AvgLatency),
+			P50Latency:    durationMillis(stats.P50Latency),
+			P75Latency:    durationMillis(stats.P75Latency),
+			P95Latency:    durationMillis(stats.P95Latency),
+			P99Latency:    durationMillis(stats.P99Latency),
+			P999Latency:   durationMillis(stats.P999Latency),
 			Throughput:    stats.Throughput,
 			BytesRead:     msg.Results.BytesRead,
 			StatusCodes:   msg.Results.StatusCodes,
 		}
+		if len(msg.Results.RoundTrips) > 0 {
+			rtt := msg.Results.CalculateRoundTripStats()
+			state.results.RoundTrips = len(msg.Results.RoundTrips)
+			state.results.RoundTripAvg = durationMillis(rtt.AvgLatency)
+			state.results.RoundTripP50 = durationMillis(rtt.P50Latency)
+			state.results.RoundTripP95 = durationMillis(rtt.P95Latency)
+			state.results.RoundTripP99 = durationMillis(rtt.P99Latency)
+		}
 	}
 
 	return m, nil
 }
 
+// durationMillis converts d to fractional milliseconds, so sub-millisecond
+// latencies such as local TCP connects do not show as zero
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
 // copyToClipboard copies text to the system clipboard using OS-specific commands.
 // This avoids CGO dependencies that cause issues in CI environments.
 func copyToClipboard(text string) error {
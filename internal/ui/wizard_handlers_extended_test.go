@@ -2,6 +2,7 @@ package ui
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -26,7 +27,7 @@ func newModelWithHTTPLog() model {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("fwd-id", "alias")
+	ui.httpLogState = newHTTPLogState("fwd-id", "alias", 0)
 	ui.mu.Unlock()
 	return model{ui: ui, termWidth: 120, termHeight: 40}
 }
@@ -244,6 +245,59 @@ func TestHandleAddWizardKeys_Esc_EditMode_AlwaysCancels(t *testing.T) {
 	m.ui.mu.RUnlock()
 }
 
+func TestHandleAddWizardKeys_EditMode_RestartsFlow(t *testing.T) {
+	m := newModelWithWizard(StepConfirmation)
+	w := m.ui.addWizard
+	w.isEditing = true
+	w.originalID = "orig-id"
+	w.confirmationFocus = FocusButtons
+	w.textInput = "my-api"
+	w.localPort = 8080
+	w.containerOriginal = "sidecar"
+	w.selectedResourceType = ResourceTypeService
+
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+
+	m.ui.mu.RLock()
+	defer m.ui.mu.RUnlock()
+	require.NotNil(t, m.ui.addWizard)
+	assert.Equal(t, StepSelectContext, w.step)
+	assert.True(t, w.isEditing)
+	assert.True(t, w.repointing)
+	assert.True(t, w.loading)
+	assert.Equal(t, "orig-id", w.originalID)
+	assert.Equal(t, "my-api", w.alias)
+	assert.Equal(t, 8080, w.localPort)
+	assert.Empty(t, w.containerOriginal)
+	assert.NotContains(t, w.resourceTypes(), ResourceTypeServiceBatch)
+}
+
+func TestHandleAddWizardKeys_EditMode_RTypedIntoAlias(t *testing.T) {
+	m := newModelWithWizard(StepConfirmation)
+	m.ui.addWizard.isEditing = true
+	m.ui.addWizard.confirmationFocus = FocusAlias
+
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+
+	m.ui.mu.RLock()
+	defer m.ui.mu.RUnlock()
+	assert.Equal(t, StepConfirmation, m.ui.addWizard.step)
+	assert.Equal(t, "r", m.ui.addWizard.textInput)
+}
+
+func TestHandleAddWizardKeys_Esc_Repointing_GoesBack(t *testing.T) {
+	m := newModelWithWizard(StepSelectNamespace)
+	m.ui.addWizard.isEditing = true
+	m.ui.addWizard.repointing = true
+
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyEsc})
+
+	m.ui.mu.RLock()
+	defer m.ui.mu.RUnlock()
+	require.NotNil(t, m.ui.addWizard)
+	assert.Equal(t, StepSelectContext, m.ui.addWizard.step)
+}
+
 // ---- handleAddWizardKeys: navigation ------------------------------------
 
 func TestHandleAddWizardKeys_Navigation(t *testing.T) {
@@ -465,6 +519,65 @@ func TestHandleAddWizardEnter_SelectResourceType_PodPrefix(t *testing.T) {
 	assert.NotNil(t, cmd)
 }
 
+func TestHandleAddWizardEnter_SelectResourceType_StatefulSet(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.discovery = &k8s.Discovery{}
+	ui.mu.Lock()
+	ui.viewMode = ViewModeAddWizard
+	w := newAddWizardState()
+	w.step = StepSelectResourceType
+	w.selectedContext = "ctx"
+	w.selectedNamespace = "ns"
+	w.cursor = 4 // ResourceTypeStatefulSet
+	ui.addWizard = w
+	ui.mu.Unlock()
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	_, cmd := m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyEnter})
+
+	ui.mu.RLock()
+	assert.Equal(t, StepEnterResource, ui.addWizard.step)
+	assert.Equal(t, ResourceTypeStatefulSet, ui.addWizard.selectedResourceType)
+	assert.Equal(t, InputModeList, ui.addWizard.inputMode)
+	assert.True(t, ui.addWizard.loading)
+	ui.mu.RUnlock()
+
+	// A cmd (loadWorkloadsCmd) is returned.
+	assert.NotNil(t, cmd)
+}
+
+func TestMoveCursor_ResourceTypeStep_ReachesWorkloads(t *testing.T) {
+	w := newAddWizardState()
+	w.step = StepSelectResourceType
+
+	w.moveCursor(10)
+	assert.Equal(t, len(wizardResourceTypes)-1, w.cursor)
+	assert.Equal(t, ResourceTypeServiceBatch, wizardResourceTypes[w.cursor])
+	assert.Equal(t, ResourceTypeStatefulSet, wizardResourceTypes[w.cursor-1])
+}
+
+// ---- handleAddWizardEnter: StepEnterResource Deployment ----------------
+
+func TestHandleAddWizardEnter_EnterResource_Deployment(t *testing.T) {
+	m := newModelWithWizard(StepEnterResource)
+	m.ui.addWizard.selectedResourceType = ResourceTypeDeployment
+	m.ui.addWizard.inputMode = InputModeList
+	m.ui.addWizard.workloads = []k8s.WorkloadInfo{
+		{Name: "api", Kind: "deployment", Ports: []k8s.PortInfo{{Name: "http", Port: 8080}}},
+		{Name: "worker", Kind: "deployment"},
+	}
+	m.ui.addWizard.searchFilter = "api"
+
+	m.handleAddWizardEnter()
+
+	assert.Equal(t, StepEnterRemotePort, m.ui.addWizard.step)
+	assert.Equal(t, "api", m.ui.addWizard.resourceValue)
+	assert.Equal(t, InputModeList, m.ui.addWizard.inputMode)
+	require.Len(t, m.ui.addWizard.detectedPorts, 1)
+	assert.Equal(t, int32(8080), m.ui.addWizard.detectedPorts[0].Port)
+	assert.Empty(t, m.ui.addWizard.searchFilter)
+}
+
 // ---- handleAddWizardEnter: StepEnterResource PodPrefix -----------------
 
 func TestHandleAddWizardEnter_EnterResource_PodPrefix(t *testing.T) {
@@ -584,6 +697,35 @@ func TestHandleAddWizardEnter_RemotePort_ListMode_SelectPort(t *testing.T) {
 	assert.Equal(t, 9090, m.ui.addWizard.remotePort) // TargetPort used
 }
 
+func TestHandleAddWizardEnter_RemotePort_ListMode_NamedServicePort(t *testing.T) {
+	m := newModelWithWizard(StepEnterRemotePort)
+	m.ui.addWizard.selectedResourceType = ResourceTypeService
+	m.ui.addWizard.inputMode = InputModeList
+	m.ui.addWizard.detectedPorts = []k8s.PortInfo{
+		{Port: 80, TargetPort: 8080},
+		{Name: "grpc", Port: 9090, TargetPort: 50051},
+	}
+	m.ui.addWizard.cursor = 1
+
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Stored by name so a renumbered targetPort is picked up at connect time
+	assert.Equal(t, StepEnterLocalPort, m.ui.addWizard.step)
+	assert.Equal(t, 0, m.ui.addWizard.remotePort)
+	assert.Equal(t, "grpc", m.ui.addWizard.portNameOriginal)
+	assert.Equal(t, "grpc", m.ui.addWizard.remotePortLabel())
+
+	// Going back and picking an unnamed port drops the name
+	m.ui.addWizard.step = StepEnterRemotePort
+	m.ui.addWizard.inputMode = InputModeList
+	m.ui.addWizard.cursor = 0
+	m.handleAddWizardKeys(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Equal(t, 8080, m.ui.addWizard.remotePort)
+	assert.Empty(t, m.ui.addWizard.portNameOriginal)
+	assert.Equal(t, "8080", m.ui.addWizard.remotePortLabel())
+}
+
 func TestHandleAddWizardEnter_RemotePort_ListMode_ManualEntry(t *testing.T) {
 	m := newModelWithWizard(StepEnterRemotePort)
 	m.ui.addWizard.inputMode = InputModeList
@@ -1026,7 +1168,7 @@ func TestHandleBenchmarkKeys_Tab(t *testing.T) {
 func TestHandleBenchmarkKeys_Tab_Wraps(t *testing.T) {
 	m := newModelWithBenchmark()
 	m.ui.benchmarkState.step = BenchmarkStepConfig
-	m.ui.benchmarkState.cursor = 3
+	m.ui.benchmarkState.cursor = len(m.ui.benchmarkState.fields()) - 1
 
 	keyMsg := tea.KeyMsg{Type: tea.KeyTab}
 	m.handleBenchmarkKeys(keyMsg)
@@ -1034,6 +1176,46 @@ func TestHandleBenchmarkKeys_Tab_Wraps(t *testing.T) {
 	assert.Equal(t, 0, m.ui.benchmarkState.cursor)
 }
 
+func TestHandleBenchmarkKeys_ToggleMode(t *testing.T) {
+	m := newModelWithBenchmark()
+	state := m.ui.benchmarkState
+	state.cursor = 5
+
+	m.handleBenchmarkKeys(tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, BenchmarkModeTCP, state.mode)
+	assert.Equal(t, 0, state.cursor, "the cursor moves back to the first field")
+	assert.Equal(t, benchmarkFieldConcurrency, state.field())
+	assert.Equal(t, "10", state.textInput)
+
+	// Typing edits the TCP fields by their own positions
+	state.cursor = 3
+	state.textInput = ""
+	for _, r := range "PING" {
+		m.handleBenchmarkKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	assert.Equal(t, "PING", state.body)
+
+	m.handleBenchmarkKeys(tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, 0, state.cursor, "tab wraps within the TCP fields")
+
+	m.handleBenchmarkKeys(tea.KeyMsg{Type: tea.KeyLeft})
+	assert.Equal(t, BenchmarkModeHTTP, state.mode)
+	assert.Equal(t, "/", state.textInput)
+}
+
+func TestHandleBenchmarkKeys_Enter_TCPIgnoresHeaders(t *testing.T) {
+	m := newModelWithBenchmark()
+	state := m.ui.benchmarkState
+	state.mode = BenchmarkModeTCP
+	state.headers = "no-colon" // not shown in TCP mode, so not validated
+
+	_, cmd := m.handleBenchmarkKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.NotNil(t, cmd)
+	assert.NoError(t, state.error)
+	assert.Equal(t, BenchmarkStepRunning, state.step)
+	state.cancelFunc()
+}
+
 func TestHandleBenchmarkKeys_Backspace(t *testing.T) {
 	m := newModelWithBenchmark()
 	m.ui.benchmarkState.step = BenchmarkStepConfig
@@ -1117,6 +1299,79 @@ func TestApplyBenchmarkTextInput_AllFields(t *testing.T) {
 	state.textInput = "50"
 	m.applyBenchmarkTextInput()
 	assert.Equal(t, 50, state.requests)
+
+	// Duration (cursor 4)
+	state.cursor = 4
+	state.textInput = "30"
+	m.applyBenchmarkTextInput()
+	assert.Equal(t, 30, state.duration)
+
+	// Headers (cursor 5)
+	state.cursor = 5
+	state.textInput = "Authorization: Bearer x"
+	m.applyBenchmarkTextInput()
+	assert.Equal(t, "Authorization: Bearer x", state.headers)
+
+	// Body (cursor 6)
+	state.cursor = 6
+	state.textInput = `{"k":1}`
+	m.applyBenchmarkTextInput()
+	assert.Equal(t, `{"k":1}`, state.body)
+}
+
+func TestHandleBenchmarkKeys_TypeJK_EditsText(t *testing.T) {
+	m := newModelWithBenchmark()
+	m.ui.benchmarkState.step = BenchmarkStepConfig
+	m.ui.benchmarkState.cursor = 5
+	m.ui.benchmarkState.textInput = ""
+
+	for _, r := range "jk" {
+		m.handleBenchmarkKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	assert.Equal(t, 5, m.ui.benchmarkState.cursor, "j/k must not move the cursor")
+	assert.Equal(t, "jk", m.ui.benchmarkState.headers)
+}
+
+func TestHandleBenchmarkKeys_Enter_InvalidHeaders(t *testing.T) {
+	m := newModelWithBenchmark()
+	m.ui.benchmarkState.step = BenchmarkStepConfig
+	m.ui.benchmarkState.headers = "no-colon"
+
+	_, cmd := m.handleBenchmarkKeys(tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Nil(t, cmd)
+	assert.Equal(t, BenchmarkStepConfig, m.ui.benchmarkState.step)
+	require.Error(t, m.ui.benchmarkState.error)
+	assert.Contains(t, m.ui.benchmarkState.error.Error(), "no-colon")
+}
+
+func TestParseBenchmarkHeaders(t *testing.T) {
+	headers, err := parseBenchmarkHeaders(" Authorization: Bearer a:b ; X-Trace:1;; ")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer a:b", "X-Trace": "1"}, headers)
+
+	headers, err = parseBenchmarkHeaders("")
+	require.NoError(t, err)
+	assert.Empty(t, headers)
+
+	_, err = parseBenchmarkHeaders(": value")
+	assert.Error(t, err)
+}
+
+func TestLoadBenchmarkBody(t *testing.T) {
+	body, err := loadBenchmarkBody(`{"a":1}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(body))
+
+	path := filepath.Join(t.TempDir(), "body.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"file":true}`), 0600))
+	body, err = loadBenchmarkBody("@" + path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"file":true}`, string(body))
+
+	_, err = loadBenchmarkBody("@" + filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
 }
 
 func TestApplyBenchmarkTextInput_ConcurrencyCappedAtRequests(t *testing.T) {
@@ -1129,6 +1384,17 @@ func TestApplyBenchmarkTextInput_ConcurrencyCappedAtRequests(t *testing.T) {
 	assert.Equal(t, 10, state.concurrency)
 }
 
+func TestApplyBenchmarkTextInput_DurationModeSkipsConcurrencyCap(t *testing.T) {
+	m := newModelWithBenchmark()
+	state := m.ui.benchmarkState
+	state.requests = 10
+	state.duration = 30
+	state.cursor = 2
+	state.textInput = "50"
+	m.applyBenchmarkTextInput()
+	assert.Equal(t, 50, state.concurrency)
+}
+
 func TestGetBenchmarkFieldValue_AllFields(t *testing.T) {
 	m := newModelWithBenchmark()
 	state := m.ui.benchmarkState
@@ -1424,6 +1690,37 @@ func TestHandleHTTPLogEntry_CapsAt10000(t *testing.T) {
 	assert.Equal(t, "/new", last.Path)
 }
 
+func TestHandleHTTPLogEntry_CapsAtMaxEntries(t *testing.T) {
+	m := newModelWithHTTPLog()
+	m.ui.httpLogState.maxEntries = 3
+
+	for _, path := range []string{"/1", "/2", "/3", "/4"} {
+		m.handleHTTPLogEntry(HTTPLogEntryMsg{Entry: HTTPLogEntry{Method: "GET", Path: path}})
+	}
+
+	require.Len(t, m.ui.httpLogState.entries, 3)
+	assert.Equal(t, "/2", m.ui.httpLogState.entries[0].Path)
+}
+
+func TestSetHTTPLogMaxEntries_TrimsOpenLog(t *testing.T) {
+	m := newModelWithHTTPLog()
+	for _, path := range []string{"/1", "/2", "/3", "/4"} {
+		m.ui.httpLogState.entries = append(m.ui.httpLogState.entries, HTTPLogEntry{Method: "GET", Path: path})
+	}
+	m.ui.httpLogState.cursor = 3
+
+	m.ui.SetHTTPLogMaxEntries(2)
+	require.Len(t, m.ui.httpLogState.entries, 2)
+	assert.Equal(t, "/3", m.ui.httpLogState.entries[0].Path)
+	assert.Equal(t, 1, m.ui.httpLogState.cursor)
+
+	// 0 restores the default; a newly opened log uses the configured cap
+	m.ui.SetHTTPLogMaxEntries(0)
+	assert.Equal(t, config.DefaultHTTPLogMaxEntries, m.ui.httpLogState.maxEntries)
+	m.ui.SetHTTPLogMaxEntries(7)
+	assert.Equal(t, 7, newHTTPLogState("id", "alias", m.ui.httpLogMaxEntries).maxEntries)
+}
+
 // ---- handleContextsLoaded: without discovery (nil) ---------------------
 
 // TestHandleContextsLoaded_NilDiscovery_UsesMessagesDirectly verifies that
@@ -1505,6 +1802,33 @@ func TestHandleServicesLoaded_EditMode_DetectsPorts(t *testing.T) {
 	ui.mu.RUnlock()
 }
 
+func TestHandleWorkloadsLoaded_EditMode_DetectsPorts(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.mu.Lock()
+	ui.viewMode = ViewModeAddWizard
+	w := newAddWizardState()
+	w.step = StepEnterRemotePort
+	w.isEditing = true
+	w.selectedResourceType = ResourceTypeStatefulSet
+	w.resourceValue = "postgres"
+	w.remotePort = 5432
+	ui.addWizard = w
+	ui.mu.Unlock()
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	workloads := []k8s.WorkloadInfo{
+		{Name: "postgres", Kind: "statefulset", Ports: []k8s.PortInfo{{Name: "pg", Port: 5432}}},
+	}
+	m.handleWorkloadsLoaded(WorkloadsLoadedMsg{workloads: workloads})
+
+	ui.mu.RLock()
+	assert.False(t, ui.addWizard.loading)
+	assert.Equal(t, workloads, ui.addWizard.workloads)
+	assert.Equal(t, InputModeList, ui.addWizard.inputMode)
+	assert.NotEmpty(t, ui.addWizard.detectedPorts)
+	ui.mu.RUnlock()
+}
+
 // ---- handleForwardSaved: error path ------------------------------------
 
 func TestHandleForwardSaved_Error(t *testing.T) {
@@ -1567,7 +1891,7 @@ func TestModel_Update_ClearCopyMessage(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("fwd-id", "alias")
+	ui.httpLogState = newHTTPLogState("fwd-id", "alias", 0)
 	ui.httpLogState.copyMessage = "Copied!"
 	ui.mu.Unlock()
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
@@ -1621,6 +1945,9 @@ func TestResourceType_StringAndDescription(t *testing.T) {
 		{"Pod (by name prefix)", "specific", ResourceTypePodPrefix},
 		{"Pod (by label selector)", "survives", ResourceTypePodSelector},
 		{"Service", "stable", ResourceTypeService},
+		{"Deployment", "deployment", ResourceTypeDeployment},
+		{"StatefulSet", "statefulset", ResourceTypeStatefulSet},
+		{"Services (batch)", "several services", ResourceTypeServiceBatch},
 		{"Unknown", "", ResourceType(99)},
 	}
 
@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lukaszraczylo/kportal/internal/config"
 	"github.com/lukaszraczylo/kportal/internal/k8s"
@@ -39,6 +41,8 @@ const (
 	ViewModeRemoveWizard
 	ViewModeBenchmark
 	ViewModeHTTPLog
+	ViewModeTrace
+	ViewModeDetail
 )
 
 // InputMode represents whether the wizard is in list selection or text input mode
@@ -78,8 +82,21 @@ const (
 	ResourceTypePodPrefix ResourceType = iota
 	ResourceTypePodSelector
 	ResourceTypeService
+	ResourceTypeDeployment
+	ResourceTypeStatefulSet
+	ResourceTypeServiceBatch
 )
 
+// wizardResourceTypes lists the resource types offered by the wizard, in display order
+var wizardResourceTypes = []ResourceType{
+	ResourceTypePodPrefix,
+	ResourceTypePodSelector,
+	ResourceTypeService,
+	ResourceTypeDeployment,
+	ResourceTypeStatefulSet,
+	ResourceTypeServiceBatch,
+}
+
 // String returns a human-readable name for the resource type
 func (r ResourceType) String() string {
 	switch r {
@@ -89,6 +106,12 @@ func (r ResourceType) String() string {
 		return "Pod (by label selector)"
 	case ResourceTypeService:
 		return "Service"
+	case ResourceTypeDeployment:
+		return "Deployment"
+	case ResourceTypeStatefulSet:
+		return "StatefulSet"
+	case ResourceTypeServiceBatch:
+		return "Services (batch)"
 	default:
 		return "Unknown"
 	}
@@ -103,42 +126,86 @@ func (r ResourceType) Description() string {
 		return "Flexible, survives pod restarts automatically"
 	case ResourceTypeService:
 		return "Most stable, load-balanced"
+	case ResourceTypeDeployment:
+		return "Newest running pod of the deployment"
+	case ResourceTypeStatefulSet:
+		return "Newest running pod of the statefulset"
+	case ResourceTypeServiceBatch:
+		return "Pick several services, local ports assigned automatically"
+	default:
+		return ""
+	}
+}
+
+// WorkloadKind returns the config resource type for workload resource types
+// ("deployment", "statefulset"), or "" for pods and services.
+func (r ResourceType) WorkloadKind() string {
+	switch r {
+	case ResourceTypeDeployment:
+		return "deployment"
+	case ResourceTypeStatefulSet:
+		return "statefulset"
 	default:
 		return ""
 	}
 }
 
+// isListSelection returns true if the resource is picked from a list
+// (services and workloads) rather than typed in.
+func (r ResourceType) isListSelection() bool {
+	return r == ResourceTypeService || r == ResourceTypeServiceBatch || r.WorkloadKind() != ""
+}
+
 // AddWizardState maintains the state for the add port forward wizard
 type AddWizardState struct {
-	error                error
-	httpLogOriginal      *config.HTTPLogSpec
-	resourceValue        string
-	originalID           string
-	portCheckMsg         string
-	alias                string
-	textInput            string
-	searchFilter         string
-	selector             string
-	selectedContext      string
-	selectedNamespace    string
-	services             []k8s.ServiceInfo
-	detectedPorts        []k8s.PortInfo
-	matchingPods         []k8s.PodInfo
-	contexts             []string
-	namespaces           []string
-	pods                 []k8s.PodInfo
-	localPort            int
-	selectedResourceType ResourceType
-	step                 AddWizardStep
-	scrollOffset         int
-	cursor               int
-	remotePort           int
-	inputMode            InputMode
-	confirmationFocus    ConfirmationFocus
-	portAvailable        bool
-	isEditing            bool
-	loading              bool
-	httpLog              bool
+	error                       error
+	httpLogOriginal             *config.HTTPLogSpec
+	healthCheckOriginal         *config.ProbeSpec
+	enabledOriginal             *bool
+	mdnsPublishOriginal         *bool
+	batchSelected               map[string]bool // service port rows picked in batch mode, by batchServicePort.key
+	resourceValue               string
+	originalID                  string
+	containerOriginal           string
+	portNameOriginal            string
+	tcpKeepaliveOriginal        string
+	dialTimeoutOriginal         string
+	schemeOriginal              string
+	bindAddressOriginal         string
+	portCheckMsg                string
+	alias                       string
+	textInput                   string
+	searchFilter                string
+	selector                    string
+	selectedContext             string
+	selectedNamespace           string
+	services                    []k8s.ServiceInfo
+	workloads                   []k8s.WorkloadInfo
+	detectedPorts               []k8s.PortInfo
+	matchingPods                []k8s.PodInfo
+	batchAdded                  []config.Forward // forwards written by the last batch add
+	tagsOriginal                []string
+	contexts                    []string
+	namespaces                  []string
+	pods                        []k8s.PodInfo
+	localPort                   int
+	selectedResourceType        ResourceType
+	reconnectMaxRetriesOriginal int
+	batchSkipped                int // selected rows skipped as already configured
+	idleTimeoutOriginal         int
+	orderOriginal               int
+	step                        AddWizardStep
+	scrollOffset                int
+	cursor                      int
+	remotePort                  int
+	inputMode                   InputMode
+	confirmationFocus           ConfirmationFocus
+	portAvailable               bool
+	isEditing                   bool
+	repointing                  bool // editing re-entered the full flow; Esc navigates back
+	loading                     bool
+	httpLog                     bool
+	traceBytesOriginal          bool
 }
 
 // newAddWizardState creates a new add wizard state initialized to the first step
@@ -165,10 +232,14 @@ func (w *AddWizardState) moveCursor(delta int) {
 	case StepSelectNamespace:
 		maxItems = len(w.getFilteredNamespaces())
 	case StepSelectResourceType:
-		maxItems = 3 // Three resource types
+		maxItems = len(w.resourceTypes())
 	case StepEnterResource:
 		if w.selectedResourceType == ResourceTypeService {
 			maxItems = len(w.getFilteredServices())
+		} else if w.selectedResourceType == ResourceTypeServiceBatch {
+			maxItems = len(w.batchRows())
+		} else if w.selectedResourceType.WorkloadKind() != "" {
+			maxItems = len(w.getFilteredWorkloads())
 		}
 	case StepEnterRemotePort:
 		if len(w.detectedPorts) > 0 {
@@ -223,6 +294,54 @@ func (w *AddWizardState) handleTextInput(char rune) {
 	}
 }
 
+// remotePortLabel returns the remote port as shown in the wizard: the port
+// name for named service ports, which have no fixed number, else the number.
+func (w *AddWizardState) remotePortLabel() string {
+	if w.remotePort == 0 && w.portNameOriginal != "" {
+		return w.portNameOriginal
+	}
+	return strconv.Itoa(w.remotePort)
+}
+
+// resourceTypes returns the resource types offered at the resource type
+// step. Batch mode adds new forwards, so it is hidden while editing.
+func (w *AddWizardState) resourceTypes() []ResourceType {
+	if !w.isEditing {
+		return wizardResourceTypes
+	}
+	types := make([]ResourceType, 0, len(wizardResourceTypes))
+	for _, rt := range wizardResourceTypes {
+		if rt != ResourceTypeServiceBatch {
+			types = append(types, rt)
+		}
+	}
+	return types
+}
+
+// restartFlow sends an edit back to context selection so the forward can be
+// repointed. The original ID, alias, local port and the settings the wizard
+// does not edit are kept; container and port name belong to the old
+// resource and are dropped.
+func (w *AddWizardState) restartFlow() {
+	w.alias = w.textInput
+	w.repointing = true
+	w.step = StepSelectContext
+	w.inputMode = InputModeList
+	w.confirmationFocus = FocusAlias
+	w.resetInput()
+	w.selector = ""
+	w.containerOriginal = ""
+	w.portNameOriginal = ""
+	w.portAvailable = false
+	w.portCheckMsg = ""
+	w.services = nil
+	w.workloads = nil
+	w.pods = nil
+	w.matchingPods = nil
+	w.detectedPorts = nil
+	w.loading = true
+}
+
 // clearTextInput clears the text input field
 func (w *AddWizardState) clearTextInput() {
 	w.textInput = ""
@@ -350,6 +469,20 @@ func (w *AddWizardState) getFilteredServices() []k8s.ServiceInfo {
 	return filtered
 }
 
+// getFilteredWorkloads returns workloads filtered by search string
+func (w *AddWizardState) getFilteredWorkloads() []k8s.WorkloadInfo {
+	if w.searchFilter == "" {
+		return w.workloads
+	}
+	filtered := []k8s.WorkloadInfo{}
+	for _, wl := range w.workloads {
+		if matchesFilter(wl.Name, w.searchFilter) {
+			filtered = append(filtered, wl)
+		}
+	}
+	return filtered
+}
+
 // clearSearchFilter clears the search filter and resets cursor/scroll
 func (w *AddWizardState) clearSearchFilter() {
 	w.searchFilter = ""
@@ -376,8 +509,35 @@ const (
 	BenchmarkStepResults
 )
 
+// BenchmarkMode selects what the benchmark wizard measures
+type BenchmarkMode int
+
+const (
+	BenchmarkModeHTTP BenchmarkMode = iota // HTTP requests
+	BenchmarkModeTCP                       // TCP connects, with an optional payload round trip
+)
+
+func (m BenchmarkMode) String() string {
+	if m == BenchmarkModeTCP {
+		return "TCP"
+	}
+	return "HTTP"
+}
+
+// Benchmark config form fields. TCP mode shows a subset, see fields.
+const (
+	benchmarkFieldURLPath = iota
+	benchmarkFieldMethod
+	benchmarkFieldConcurrency
+	benchmarkFieldRequests
+	benchmarkFieldDuration
+	benchmarkFieldHeaders
+	benchmarkFieldBody
+)
+
 // BenchmarkState maintains the state for the benchmark wizard
 type BenchmarkState struct {
+	startedAt    time.Time
 	error        error
 	results      *BenchmarkResults
 	cancelFunc   func()
@@ -387,11 +547,15 @@ type BenchmarkState struct {
 	forwardAlias string
 	urlPath      string
 	method       string
-	cursor       int
+	headers      string // "Name: value; Name2: value2"
+	body         string // literal body, or @path to read it from a file; the payload in TCP mode
+	cursor       int    // index into fields()
 	progress     int
 	total        int
 	step         BenchmarkStep
+	mode         BenchmarkMode
 	requests     int
+	duration     int // seconds; 0 sends a fixed number of requests instead
 	concurrency  int
 	localPort    int
 	running      bool
@@ -407,10 +571,40 @@ type BenchmarkResults struct {
 	MaxLatency    float64
 	AvgLatency    float64
 	P50Latency    float64
+	P75Latency    float64
 	P95Latency    float64
 	P99Latency    float64
+	P999Latency   float64
 	Throughput    float64
 	BytesRead     int64
+
+	// TCP mode only: payload round trips, in ms
+	RoundTrips   int
+	RoundTripAvg float64
+	RoundTripP50 float64
+	RoundTripP95 float64
+	RoundTripP99 float64
+	Mode         BenchmarkMode
+}
+
+// fields returns the config form fields shown in the current mode
+func (s *BenchmarkState) fields() []int {
+	if s.mode == BenchmarkModeTCP {
+		return []int{benchmarkFieldConcurrency, benchmarkFieldRequests, benchmarkFieldDuration, benchmarkFieldBody}
+	}
+	return []int{
+		benchmarkFieldURLPath, benchmarkFieldMethod, benchmarkFieldConcurrency, benchmarkFieldRequests,
+		benchmarkFieldDuration, benchmarkFieldHeaders, benchmarkFieldBody,
+	}
+}
+
+// field returns the field under the cursor
+func (s *BenchmarkState) field() int {
+	fields := s.fields()
+	if s.cursor < 0 || s.cursor >= len(fields) {
+		return -1
+	}
+	return fields[s.cursor]
 }
 
 // newBenchmarkState creates a new benchmark state for a forward
@@ -440,22 +634,25 @@ const (
 
 // HTTPLogState maintains the state for HTTP log viewing
 type HTTPLogState struct {
-	forwardID     string
-	forwardAlias  string
-	filterText    string
-	copyMessage   string
-	entries       []HTTPLogEntry
-	cursor        int
-	scrollOffset  int
-	filterMode    HTTPLogFilterMode
-	detailScroll  int
-	autoScroll    bool
-	filterActive  bool
-	showingDetail bool
+	forwardID       string
+	forwardAlias    string
+	filterText      string
+	copyMessage     string
+	entries         []HTTPLogEntry
+	cursor          int
+	scrollOffset    int
+	filterMode      HTTPLogFilterMode
+	detailScroll    int
+	maxEntries      int // oldest entries are dropped beyond this
+	autoScroll      bool
+	filterActive    bool
+	showingDetail   bool
+	clearConfirming bool // x was pressed once; a second x clears the entries
 }
 
 // HTTPLogEntry represents a single HTTP log entry for display
 type HTTPLogEntry struct {
+	StartedAt       time.Time // full request timestamp, used for HAR export
 	RequestHeaders  map[string]string
 	ResponseHeaders map[string]string
 	Method          string
@@ -466,22 +663,53 @@ type HTTPLogEntry struct {
 	RequestBody     string
 	ResponseBody    string
 	Error           string
+	GRPCStatus      string // gRPC status code, empty for plain HTTP or an unfinished call
+	GRPCMessage     string
 	StatusCode      int
 	LatencyMs       int64
 	BodySize        int
+	GRPC            bool // bodies are length-prefixed gRPC messages
 }
 
-// newHTTPLogState creates a new HTTP log viewing state
-func newHTTPLogState(forwardID, alias string) *HTTPLogState {
+// grpcFailed reports whether entry is a gRPC call that ended with a status
+// other than OK. gRPC errors travel in trailers under an HTTP 200.
+func (e HTTPLogEntry) grpcFailed() bool {
+	return e.GRPCStatus != "" && e.GRPCStatus != "0"
+}
+
+// newHTTPLogState creates a new HTTP log viewing state keeping at most
+// maxEntries entries; 0 means config.DefaultHTTPLogMaxEntries
+func newHTTPLogState(forwardID, alias string, maxEntries int) *HTTPLogState {
 	return &HTTPLogState{
 		forwardID:    forwardID,
 		forwardAlias: alias,
 		entries:      make([]HTTPLogEntry, 0),
+		maxEntries:   httpLogEntryCap(maxEntries),
 		autoScroll:   true,
 		filterMode:   HTTPLogFilterNone,
 	}
 }
 
+// httpLogEntryCap returns n, or config.DefaultHTTPLogMaxEntries when n is 0
+func httpLogEntryCap(n int) int {
+	if n <= 0 {
+		return config.DefaultHTTPLogMaxEntries
+	}
+	return n
+}
+
+// trimEntries drops the oldest entries beyond maxEntries
+func (s *HTTPLogState) trimEntries() {
+	if len(s.entries) <= s.maxEntries {
+		return
+	}
+	s.entries = s.entries[len(s.entries)-s.maxEntries:]
+	// Adjust cursor if needed
+	if s.cursor >= len(s.entries) {
+		s.cursor = len(s.entries) - 1
+	}
+}
+
 // getFilteredEntries returns entries matching the current filter
 // Only returns entries with status codes (responses) since requests don't have useful info
 func (s *HTTPLogState) getFilteredEntries() []HTTPLogEntry {
@@ -498,11 +726,11 @@ func (s *HTTPLogState) getFilteredEntries() []HTTPLogEntry {
 		// Apply filter mode
 		switch s.filterMode {
 		case HTTPLogFilterNon200:
-			if entry.StatusCode >= 200 && entry.StatusCode < 300 {
+			if entry.StatusCode >= 200 && entry.StatusCode < 300 && !entry.grpcFailed() {
 				continue
 			}
 		case HTTPLogFilterErrors:
-			if entry.StatusCode < 400 {
+			if entry.StatusCode < 400 && !entry.grpcFailed() {
 				continue
 			}
 		}
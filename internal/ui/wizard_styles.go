@@ -7,115 +7,58 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Color palette for wizards
+// Colors and styles shared by the wizards and modals. They are built from
+// the active theme by applyTheme; see theme.go.
 var (
-	primaryColor   = lipgloss.Color("205") // Pink/Magenta
-	successColor   = lipgloss.Color("42")  // Green
-	errorColor     = lipgloss.Color("196") // Red
-	warningColor   = lipgloss.Color("220") // Yellow
-	mutedColor     = lipgloss.Color("241") // Gray
-	accentColor    = lipgloss.Color("63")  // Purple
-	highlightColor = lipgloss.Color("117") // Light blue
-
-	// JSON syntax highlighting colors
-	jsonKeyColor    = lipgloss.Color("81")  // Cyan
-	jsonStringColor = lipgloss.Color("180") // Light orange/tan
-	jsonNumberColor = lipgloss.Color("141") // Light purple
-	jsonBoolColor   = lipgloss.Color("209") // Orange
-	jsonNullColor   = lipgloss.Color("243") // Dark gray
+	primaryColor   lipgloss.Color
+	successColor   lipgloss.Color
+	errorColor     lipgloss.Color
+	warningColor   lipgloss.Color
+	mutedColor     lipgloss.Color
+	accentColor    lipgloss.Color
+	highlightColor lipgloss.Color
 )
 
 // Text styles
 var (
-	wizardHeaderStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(primaryColor).
-				MarginBottom(0)
-
-	wizardStepStyle = lipgloss.NewStyle().
-			Foreground(mutedColor).
-			Italic(true)
-
-	breadcrumbStyle = lipgloss.NewStyle().
-			Foreground(highlightColor).
-			Bold(true)
-
-	selectedStyle = lipgloss.NewStyle().
-			Foreground(primaryColor).
-			Bold(true)
-
-	successStyle = lipgloss.NewStyle().
-			Foreground(successColor).
-			Bold(true)
-
-	errorStyle = lipgloss.NewStyle().
-			Foreground(errorColor).
-			Bold(true)
-
-	warningStyle = lipgloss.NewStyle().
-			Foreground(warningColor).
-			Bold(true)
-
-	mutedStyle = lipgloss.NewStyle().
-			Foreground(mutedColor)
-
-	helpStyle = lipgloss.NewStyle().
-			Foreground(mutedColor).
-			Italic(true)
-
-	spinnerStyle = lipgloss.NewStyle().
-			Foreground(accentColor).
-			Bold(true)
-
-	accentStyle = lipgloss.NewStyle().
-			Foreground(accentColor).
-			Bold(true)
+	wizardHeaderStyle lipgloss.Style
+	wizardStepStyle   lipgloss.Style
+	breadcrumbStyle   lipgloss.Style
+	selectedStyle     lipgloss.Style
+	successStyle      lipgloss.Style
+	errorStyle        lipgloss.Style
+	warningStyle      lipgloss.Style
+	mutedStyle        lipgloss.Style
+	helpStyle         lipgloss.Style
+	spinnerStyle      lipgloss.Style
+	accentStyle       lipgloss.Style
 )
 
 // Input styles
 var (
-	inputStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
-
-	validInputStyle = lipgloss.NewStyle().
-			Foreground(successColor)
+	inputStyle      lipgloss.Style
+	validInputStyle lipgloss.Style
 )
 
 // Checkbox styles
 var (
-	checkedBoxStyle = lipgloss.NewStyle().
-			Foreground(successColor).
-			Bold(true)
-
-	uncheckedBoxStyle = lipgloss.NewStyle().
-				Foreground(mutedColor)
+	checkedBoxStyle   lipgloss.Style
+	uncheckedBoxStyle lipgloss.Style
 )
 
 // JSON syntax highlighting styles
 var (
-	jsonKeyStyle = lipgloss.NewStyle().
-			Foreground(jsonKeyColor)
-
-	jsonStringStyle = lipgloss.NewStyle().
-			Foreground(jsonStringColor)
-
-	jsonNumberStyle = lipgloss.NewStyle().
-			Foreground(jsonNumberColor)
-
-	jsonBoolStyle = lipgloss.NewStyle().
-			Foreground(jsonBoolColor)
-
-	jsonNullStyle = lipgloss.NewStyle().
-			Foreground(jsonNullColor)
+	jsonKeyStyle    lipgloss.Style
+	jsonStringStyle lipgloss.Style
+	jsonNumberStyle lipgloss.Style
+	jsonBoolStyle   lipgloss.Style
+	jsonNullStyle   lipgloss.Style
 )
 
 // Container styles
 var (
 	// wizardBoxStyle creates a bordered modal box
-	wizardBoxStyle = lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(accentColor).
-		Padding(1, 2)
+	wizardBoxStyle lipgloss.Style
 )
 
 // Helper functions for rendering
@@ -1,20 +1,20 @@
 package ui
 
 import (
-	"bytes"
-	"compress/flate"
-	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/lukaszraczylo/kportal/internal/httplog"
 	"github.com/lukaszraczylo/kportal/internal/k8s"
 )
 
 // formatDetectedPort renders a discovered port for the port-selection list,
-// e.g. "8080", "80 → 8000" (service target differs), optionally "(http)".
+// e.g. "8080", "80 → 8000" (service target differs), optionally "(http)"
+// and the declaring container for pod ports, e.g. "[container: app]".
 func formatDetectedPort(port k8s.PortInfo) string {
 	desc := fmt.Sprintf("%d", port.Port)
 	if port.TargetPort > 0 && port.TargetPort != port.Port {
@@ -23,9 +23,36 @@ func formatDetectedPort(port k8s.PortInfo) string {
 	if port.Name != "" {
 		desc += fmt.Sprintf(" (%s)", port.Name)
 	}
+	if port.Container != "" {
+		desc += fmt.Sprintf(" [container: %s]", port.Container)
+	}
 	return desc
 }
 
+// loadErrorText describes a failed cluster call. An RBAC denial is just
+// the permission that is missing, without the calls it failed in.
+func loadErrorText(err error) string {
+	var permErr *k8s.PermissionError
+	if errors.As(err, &permErr) {
+		return permErr.Error()
+	}
+	return err.Error()
+}
+
+// isPermissionDenied reports whether err is an RBAC denial.
+func isPermissionDenied(err error) bool {
+	var permErr *k8s.PermissionError
+	return errors.As(err, &permErr)
+}
+
+// renderLoadError renders a failed cluster call for a wizard step.
+func renderLoadError(err error) string {
+	if isPermissionDenied(err) {
+		return errorStyle.Render("✗ " + loadErrorText(err))
+	}
+	return errorStyle.Render("✗ Error: " + loadErrorText(err))
+}
+
 // renderAddWizard renders the appropriate step of the add wizard
 func (m model) renderAddWizard() string {
 	if m.ui.addWizard == nil {
@@ -153,8 +180,12 @@ func (m model) renderSelectNamespace() string {
 	if wizard.loading {
 		b.WriteString(spinnerStyle.Render("⣾ Loading namespaces..."))
 	} else if wizard.error != nil {
-		b.WriteString(errorStyle.Render(fmt.Sprintf("✗ Error: %v\n", wizard.error)))
-		b.WriteString(mutedStyle.Render("\nCluster may be unreachable. Check context."))
+		b.WriteString(renderLoadError(wizard.error))
+		if isPermissionDenied(wizard.error) {
+			b.WriteString(mutedStyle.Render("\n\nAsk a cluster admin to grant it, or pick another context."))
+		} else {
+			b.WriteString(mutedStyle.Render("\n\nCluster may be unreachable. Check context."))
+		}
 	} else if len(wizard.namespaces) == 0 {
 		b.WriteString(mutedStyle.Render("No namespaces found"))
 	} else {
@@ -187,11 +218,7 @@ func (m model) renderSelectResourceType() string {
 
 	b.WriteString("Select Resource Type:\n\n")
 
-	resourceTypes := []ResourceType{
-		ResourceTypePodPrefix,
-		ResourceTypePodSelector,
-		ResourceTypeService,
-	}
+	resourceTypes := wizard.resourceTypes()
 
 	for i, rt := range resourceTypes {
 		prefix := "  "
@@ -230,6 +257,9 @@ func (m model) renderEnterResource() string {
 		// Show running pods for reference
 		if wizard.loading {
 			b.WriteString(spinnerStyle.Render("⣾ Loading pods..."))
+		} else if wizard.error != nil {
+			b.WriteString(renderLoadError(wizard.error))
+			b.WriteString("\n\n")
 		} else if len(wizard.pods) > 0 {
 			b.WriteString(mutedStyle.Render("Running pods:\n"))
 			showCount := 0
@@ -305,6 +335,8 @@ func (m model) renderEnterResource() string {
 
 		if wizard.loading {
 			b.WriteString(spinnerStyle.Render("⣾ Loading services..."))
+		} else if wizard.error != nil {
+			b.WriteString(renderLoadError(wizard.error))
 		} else if len(wizard.services) == 0 {
 			b.WriteString(mutedStyle.Render("No services found"))
 		} else {
@@ -319,14 +351,52 @@ func (m model) renderEnterResource() string {
 				b.WriteString(renderList(serviceNames, wizard.cursor, "  ", wizard.scrollOffset))
 			}
 		}
+
+	case ResourceTypeDeployment, ResourceTypeStatefulSet:
+		kind := wizard.selectedResourceType.WorkloadKind()
+		fmt.Fprintf(&b, "Select %s:\n\n", kind)
+
+		// Show search input if there's a filter active
+		if wizard.searchFilter != "" {
+			b.WriteString(renderTextInput("Filter: ", wizard.searchFilter, true))
+			b.WriteString("\n\n")
+		}
+
+		if wizard.loading {
+			b.WriteString(spinnerStyle.Render(fmt.Sprintf("⣾ Loading %ss...", kind)))
+		} else if wizard.error != nil {
+			b.WriteString(renderLoadError(wizard.error))
+		} else if len(wizard.workloads) == 0 {
+			b.WriteString(mutedStyle.Render(fmt.Sprintf("No %ss found", kind)))
+		} else {
+			filteredWorkloads := wizard.getFilteredWorkloads()
+			if len(filteredWorkloads) == 0 {
+				b.WriteString(mutedStyle.Render(fmt.Sprintf("No matching %ss", kind)))
+			} else {
+				names := make([]string, len(filteredWorkloads))
+				for i, wl := range filteredWorkloads {
+					names[i] = fmt.Sprintf("%s (%d/%d ready)", wl.Name, wl.ReadyReplicas, wl.Replicas)
+				}
+				b.WriteString(renderList(names, wizard.cursor, "  ", wizard.scrollOffset))
+			}
+		}
+
+	case ResourceTypeServiceBatch:
+		m.renderBatchServices(&b)
 	}
 
 	b.WriteString("\n")
 	// Show appropriate help text based on resource type and filter state
 	helpWidth := wizardHelpWidth(m.termWidth)
-	if wizard.selectedResourceType == ResourceTypeService {
+	if wizard.selectedResourceType == ResourceTypeServiceBatch {
+		b.WriteString(wrapHelpText("↑/↓: Navigate  Space: Toggle  a: All  n: None  Enter: Add selected  Esc: Back", helpWidth))
+	} else if wizard.selectedResourceType.isListSelection() {
 		if wizard.searchFilter != "" {
-			b.WriteString(wrapHelpText(fmt.Sprintf("↑/↓: Navigate  Enter: Select  Backspace: Clear filter (%d/%d)  Esc: Back", len(wizard.getFilteredServices()), len(wizard.services)), helpWidth))
+			shown, total := len(wizard.getFilteredServices()), len(wizard.services)
+			if wizard.selectedResourceType != ResourceTypeService {
+				shown, total = len(wizard.getFilteredWorkloads()), len(wizard.workloads)
+			}
+			b.WriteString(wrapHelpText(fmt.Sprintf("↑/↓: Navigate  Enter: Select  Backspace: Clear filter (%d/%d)  Esc: Back", shown, total), helpWidth))
 		} else {
 			b.WriteString(wrapHelpText("Type to filter  ↑/↓: Navigate  Enter: Select  Esc: Back  Ctrl+C: Cancel", helpWidth))
 		}
@@ -447,7 +517,7 @@ func (m model) renderEnterLocalPort() string {
 	}
 	b.WriteString(mutedStyle.Render(fmt.Sprintf("Resource: %s", resourceInfo)))
 	b.WriteString("\n")
-	b.WriteString(mutedStyle.Render(fmt.Sprintf("Remote port: %d", wizard.remotePort)))
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("Remote port: %s", wizard.remotePortLabel())))
 	b.WriteString("\n\n")
 
 	b.WriteString(renderTextInput("Local port: ", wizard.textInput, wizard.error == nil))
@@ -489,12 +559,14 @@ func (m model) renderConfirmation() string {
 		resourceInfo = fmt.Sprintf("pod/%s", wizard.resourceValue)
 	} else if wizard.selectedResourceType == ResourceTypeService {
 		resourceInfo = fmt.Sprintf("service/%s", wizard.resourceValue)
+	} else if kind := wizard.selectedResourceType.WorkloadKind(); kind != "" {
+		resourceInfo = fmt.Sprintf("%s/%s", kind, wizard.resourceValue)
 	}
 
 	fmt.Fprintf(&b, "  Context:      %s\n", wizard.selectedContext)
 	fmt.Fprintf(&b, "  Namespace:    %s\n", wizard.selectedNamespace)
 	fmt.Fprintf(&b, "  Resource:     %s\n", resourceInfo)
-	fmt.Fprintf(&b, "  Remote Port:  %d\n", wizard.remotePort)
+	fmt.Fprintf(&b, "  Remote Port:  %s\n", wizard.remotePortLabel())
 	fmt.Fprintf(&b, "  Local Port:   %d\n", wizard.localPort)
 	b.WriteString("  Protocol:     tcp\n")
 
@@ -532,7 +604,11 @@ func (m model) renderConfirmation() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(wrapHelpText("↑/↓/Tab: Navigate  h: Toggle HTTP Log  Enter: Confirm  Esc: Back", wizardHelpWidth(m.termWidth)))
+	help := "↑/↓/Tab: Navigate  h: Toggle HTTP Log  Enter: Confirm  Esc: Back"
+	if wizard.isEditing {
+		help = "↑/↓/Tab: Navigate  h: Toggle HTTP Log  r: Change context/resource  Enter: Confirm  Esc: Cancel"
+	}
+	b.WriteString(wrapHelpText(help, wizardHelpWidth(m.termWidth)))
 
 	return b.String()
 }
@@ -546,13 +622,15 @@ func (m model) renderSuccess() string {
 
 	if wizard.error != nil {
 		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", wizard.error)))
+	} else if wizard.batchAdded != nil {
+		m.renderBatchSuccess(&b)
 	} else {
 		b.WriteString("Added to .kportal.yaml\n\n")
 
-		forwardDesc := fmt.Sprintf("localhost:%d → %s:%d",
+		forwardDesc := fmt.Sprintf("localhost:%d → %s:%s",
 			wizard.localPort,
 			wizard.resourceValue,
-			wizard.remotePort)
+			wizard.remotePortLabel())
 
 		if wizard.alias != "" {
 			forwardDesc = fmt.Sprintf("%s (%s)", wizard.alias, forwardDesc)
@@ -705,55 +783,109 @@ func (m model) renderBenchmarkConfig() string {
 	state := m.ui.benchmarkState
 	var b strings.Builder
 
-	b.WriteString(renderHeader("HTTP Benchmark", ""))
+	b.WriteString(renderHeader(state.mode.String()+" Benchmark", ""))
 	fmt.Fprintf(&b, "Target: %s (localhost:%d)", breadcrumbStyle.Render(state.forwardAlias), state.localPort)
 	b.WriteString("\n\n")
 
-	b.WriteString("Configure benchmark parameters:")
+	// Mode selector; the active mode is highlighted
+	b.WriteString("Mode: ")
+	for _, mode := range []BenchmarkMode{BenchmarkModeHTTP, BenchmarkModeTCP} {
+		if mode == state.mode {
+			b.WriteString(selectedStyle.Render("[" + mode.String() + "]"))
+		} else {
+			b.WriteString(mutedStyle.Render(" " + mode.String() + " "))
+		}
+		b.WriteString(" ")
+	}
 	b.WriteString("\n\n")
 
-	fields := []struct {
-		label string
-		value string
-	}{
-		{"URL Path", state.urlPath},
-		{"Method", state.method},
-		{"Concurrency", fmt.Sprintf("%d", state.concurrency)},
-		{"Requests", fmt.Sprintf("%d", state.requests)},
-	}
+	b.WriteString("Configure benchmark parameters:")
+	b.WriteString("\n\n")
 
-	for i, field := range fields {
+	for i, field := range state.fields() {
+		label, value := benchmarkFieldLabel(field, state.mode), m.getBenchmarkFieldValue(i)
 		prefix := "  "
 		if i == state.cursor {
 			prefix = "▸ "
-			b.WriteString(selectedStyle.Render(fmt.Sprintf("%s%-12s", prefix, field.label+":")))
-			b.WriteString(validInputStyle.Render(field.value + "█"))
+			b.WriteString(selectedStyle.Render(fmt.Sprintf("%s%-12s", prefix, label+":")))
+			b.WriteString(validInputStyle.Render(value + "█"))
 		} else {
-			fmt.Fprintf(&b, "%s%-12s %s", prefix, field.label+":", field.value)
+			fmt.Fprintf(&b, "%s%-12s %s", prefix, label+":", value)
 		}
 		b.WriteString("\n")
 	}
 
+	unit := "requests"
+	if state.mode == BenchmarkModeTCP {
+		unit = "connections"
+	}
+	b.WriteString("\n")
+	if state.duration > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Will run for %ds with %d concurrent workers", state.duration, state.concurrency)))
+	} else {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Will send %d %s with %d concurrent workers", state.requests, unit, state.concurrency)))
+	}
 	b.WriteString("\n")
-	b.WriteString(mutedStyle.Render(fmt.Sprintf("Will send %d requests with %d concurrent workers", state.requests, state.concurrency)))
+	if state.mode == BenchmarkModeTCP {
+		b.WriteString(mutedStyle.Render("Duration: seconds, 0 = use Connections   Payload: text or @file, empty = connect only"))
+	} else {
+		b.WriteString(mutedStyle.Render("Duration: seconds, 0 = use Requests   Headers: Name: value; Name2: value2   Body: text or @file"))
+	}
 	b.WriteString("\n\n")
-	b.WriteString(wrapHelpText("↑/↓/Tab: Navigate  Type to edit  Enter: Run  Esc: Cancel", wizardHelpWidth(m.termWidth)))
+	if state.error != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("✗ %v", state.error)))
+		b.WriteString("\n\n")
+	}
+	b.WriteString(wrapHelpText("↑/↓/Tab: Navigate  ←/→: Mode  Type to edit  Enter: Run  Esc: Cancel", wizardHelpWidth(m.termWidth)))
 
 	return b.String()
 }
 
+// benchmarkFieldLabel returns the config form label of field in mode
+func benchmarkFieldLabel(field int, mode BenchmarkMode) string {
+	switch field {
+	case benchmarkFieldURLPath:
+		return "URL Path"
+	case benchmarkFieldMethod:
+		return "Method"
+	case benchmarkFieldConcurrency:
+		return "Concurrency"
+	case benchmarkFieldRequests:
+		if mode == BenchmarkModeTCP {
+			return "Connections"
+		}
+		return "Requests"
+	case benchmarkFieldDuration:
+		return "Duration"
+	case benchmarkFieldHeaders:
+		return "Headers"
+	case benchmarkFieldBody:
+		if mode == BenchmarkModeTCP {
+			return "Payload"
+		}
+		return "Body"
+	default:
+		return ""
+	}
+}
+
 func (m model) renderBenchmarkRunning() string {
 	state := m.ui.benchmarkState
 	var b strings.Builder
 
-	b.WriteString(renderHeader("HTTP Benchmark", ""))
+	b.WriteString(renderHeader(state.mode.String()+" Benchmark", ""))
 	fmt.Fprintf(&b, "Target: %s", breadcrumbStyle.Render(state.forwardAlias))
 	b.WriteString("\n\n")
 
-	// Progress bar
-	progress := float64(state.progress) / float64(state.total)
-	if state.total == 0 {
-		progress = 0
+	// Progress bar: elapsed time in duration mode, completed requests otherwise
+	var progress float64
+	if state.duration > 0 {
+		progress = time.Since(state.startedAt).Seconds() / float64(state.duration)
+	} else if state.total > 0 {
+		progress = float64(state.progress) / float64(state.total)
+	}
+	if progress > 1 {
+		progress = 1
 	}
 	barWidth := 30
 	filled := int(progress * float64(barWidth))
@@ -764,17 +896,36 @@ func (m model) renderBenchmarkRunning() string {
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 	percent := int(progress * 100)
 
+	unit := "requests"
+	if state.mode == BenchmarkModeTCP {
+		unit = "connections"
+	}
+
 	b.WriteString(spinnerStyle.Render("Running benchmark..."))
 	b.WriteString("\n\n")
 
 	fmt.Fprintf(&b, "  [%s] %d%%", successStyle.Render(bar), percent)
 	b.WriteString("\n")
-	b.WriteString(mutedStyle.Render(fmt.Sprintf("  %d / %d requests completed", state.progress, state.total)))
+	if state.duration > 0 {
+		elapsed := int(time.Since(state.startedAt).Seconds())
+		if elapsed > state.duration {
+			elapsed = state.duration
+		}
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  %ds / %ds elapsed, %d %s completed", elapsed, state.duration, state.progress, unit)))
+	} else {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  %d / %d %s completed", state.progress, state.total, unit)))
+	}
 	b.WriteString("\n\n")
 
-	b.WriteString(mutedStyle.Render(fmt.Sprintf("URL: http://localhost:%d%s", state.localPort, state.urlPath)))
-	b.WriteString("\n")
-	b.WriteString(mutedStyle.Render(fmt.Sprintf("Method: %s  Concurrency: %d", state.method, state.concurrency)))
+	if state.mode == BenchmarkModeTCP {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Address: localhost:%d", state.localPort)))
+		b.WriteString("\n")
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Concurrency: %d", state.concurrency)))
+	} else {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("URL: http://localhost:%d%s", state.localPort, state.urlPath)))
+		b.WriteString("\n")
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Method: %s  Concurrency: %d", state.method, state.concurrency)))
+	}
 	b.WriteString("\n\n")
 
 	b.WriteString(wrapHelpText("Please wait...", wizardHelpWidth(m.termWidth)))
@@ -812,7 +963,12 @@ func (m model) renderBenchmarkResults() string {
 		successRate = 0
 	}
 
-	fmt.Fprintf(&b, "Total Requests:  %d", r.TotalRequests)
+	tcp := r.Mode == BenchmarkModeTCP
+	if tcp {
+		fmt.Fprintf(&b, "Connections:     %d", r.TotalRequests)
+	} else {
+		fmt.Fprintf(&b, "Total Requests:  %d", r.TotalRequests)
+	}
 	b.WriteString("\n")
 	if r.Failed == 0 {
 		b.WriteString(successStyle.Render(fmt.Sprintf("Successful:      %d (%.1f%%)", r.Successful, successRate)))
@@ -827,8 +983,12 @@ func (m model) renderBenchmarkResults() string {
 	}
 	b.WriteString("\n\n")
 
-	// Latency stats
-	b.WriteString(breadcrumbStyle.Render("Latency (ms)"))
+	// Latency stats; in TCP mode these are connect times
+	if tcp {
+		b.WriteString(breadcrumbStyle.Render("Connect time (ms)"))
+	} else {
+		b.WriteString(breadcrumbStyle.Render("Latency (ms)"))
+	}
 	b.WriteString("\n")
 	fmt.Fprintf(&b, "  Min:    %.2f", r.MinLatency)
 	b.WriteString("\n")
@@ -838,15 +998,40 @@ func (m model) renderBenchmarkResults() string {
 	b.WriteString("\n")
 	fmt.Fprintf(&b, "  P50:    %.2f", r.P50Latency)
 	b.WriteString("\n")
+	fmt.Fprintf(&b, "  P75:    %.2f", r.P75Latency)
+	b.WriteString("\n")
 	fmt.Fprintf(&b, "  P95:    %.2f", r.P95Latency)
 	b.WriteString("\n")
 	fmt.Fprintf(&b, "  P99:    %.2f", r.P99Latency)
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "  P99.9:  %.2f", r.P999Latency)
+	if r.TotalRequests < 1000 {
+		// Too few samples to resolve the 99.9th percentile; it equals the max
+		b.WriteString(mutedStyle.Render("  (< 1000 requests, same as max)"))
+	}
 	b.WriteString("\n\n")
 
+	if r.RoundTrips > 0 {
+		b.WriteString(breadcrumbStyle.Render(fmt.Sprintf("Round trip (ms, %d)", r.RoundTrips)))
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "  Avg:    %.2f", r.RoundTripAvg)
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "  P50:    %.2f", r.RoundTripP50)
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "  P95:    %.2f", r.RoundTripP95)
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "  P99:    %.2f", r.RoundTripP99)
+		b.WriteString("\n\n")
+	}
+
 	// Throughput
 	b.WriteString(breadcrumbStyle.Render("Throughput"))
 	b.WriteString("\n")
-	fmt.Fprintf(&b, "  Requests/sec:  %.2f", r.Throughput)
+	if tcp {
+		fmt.Fprintf(&b, "  Conns/sec:     %.2f", r.Throughput)
+	} else {
+		fmt.Fprintf(&b, "  Requests/sec:  %.2f", r.Throughput)
+	}
 	b.WriteString("\n")
 	fmt.Fprintf(&b, "  Bytes read:    %d", r.BytesRead)
 	b.WriteString("\n")
@@ -892,6 +1077,13 @@ func (m model) renderHTTPLog() string {
 		termHeight = 40
 	}
 
+	// Narrow terminals show each entry on two lines, the path on the second
+	narrow := termWidth < NarrowTermWidth
+	linesPerEntry := 1
+	if narrow {
+		linesPerEntry = 2
+	}
+
 	// Get filtered entries
 	filteredEntries := state.getFilteredEntries()
 	totalEntries := len(filteredEntries)
@@ -925,8 +1117,18 @@ func (m model) renderHTTPLog() string {
 		b.WriteString("  ")
 		b.WriteString(successStyle.Render("[Auto-scroll]"))
 	}
+	if m.ui.httpLogLatencyColor {
+		b.WriteString("  ")
+		b.WriteString(accentStyle.Render("[Latency colors]"))
+	}
 	b.WriteString("\n")
 
+	// Summary of the entries in view, recomputed as new entries arrive
+	if totalEntries > 0 {
+		b.WriteString(mutedStyle.Render(truncate(summarizeHTTPLog(filteredEntries).String(), termWidth)))
+		b.WriteString("\n")
+	}
+
 	// Filter input line (if active)
 	if state.filterActive {
 		b.WriteString(accentStyle.Render("Search: "))
@@ -956,13 +1158,16 @@ func (m model) renderHTTPLog() string {
 		// Header
 		header := "  " + fmt.Sprintf(HTTPLogRowFormat,
 			"TIME", "METHOD", "STATUS", "LATENCY", "PATH")
+		if narrow {
+			header = "  " + fmt.Sprintf(HTTPLogCompactRowFormat, "TIME", "METHOD", "STATUS", "LATENCY")
+		}
 		b.WriteString(mutedStyle.Render(header))
 		b.WriteString("\n")
 		b.WriteString(mutedStyle.Render(strings.Repeat("─", termWidth-2)))
 		b.WriteString("\n")
 
 		// Calculate visible range
-		viewportHeight := termHeight - 8 // header, filter bar, table header, separator, footer, help
+		viewportHeight := (termHeight - 9) / linesPerEntry // header, summary, filter bar, table header, separator, footer, help
 		if viewportHeight < 5 {
 			viewportHeight = 5
 		}
@@ -992,8 +1197,12 @@ func (m model) renderHTTPLog() string {
 			end = totalEntries
 		}
 
-		// Calculate max path width (remaining space after the fixed columns)
+		// Calculate max path width (remaining space after the fixed columns,
+		// or the indent of the path line)
 		maxPathWidth := termWidth - HTTPLogFixedCols
+		if narrow {
+			maxPathWidth = termWidth - 6
+		}
 		if maxPathWidth < 10 {
 			maxPathWidth = 10
 		}
@@ -1026,6 +1235,13 @@ func (m model) renderHTTPLog() string {
 				statusStr,
 				latencyStr,
 				path)
+			if narrow {
+				line = fmt.Sprintf(HTTPLogCompactRowFormat,
+					entry.Timestamp,
+					entry.Method,
+					statusStr,
+					latencyStr) + "\n    " + path
+			}
 
 			// Selection prefix
 			prefix := "  "
@@ -1033,12 +1249,14 @@ func (m model) renderHTTPLog() string {
 				prefix = "▸ "
 			}
 
-			// Apply color based on status
+			// Apply color based on status, or latency when toggled with t
 			// 200s = normal text, 400s = warning (orange), 500s = error (red)
 			var styledLine string
-			if entry.StatusCode >= 500 {
+			if m.ui.httpLogLatencyColor {
+				styledLine = m.ui.styleByLatency(line, entry.LatencyMs)
+			} else if entry.StatusCode >= 500 {
 				styledLine = errorStyle.Render(line)
-			} else if entry.StatusCode >= 400 {
+			} else if entry.StatusCode >= 400 || entry.grpcFailed() {
 				styledLine = warningStyle.Render(line)
 			} else {
 				// 200s and other codes - normal text color
@@ -1056,7 +1274,7 @@ func (m model) renderHTTPLog() string {
 
 		// Pad remaining lines
 		linesRendered := end - start
-		for i := linesRendered; i < viewportHeight; i++ {
+		for i := linesRendered * linesPerEntry; i < viewportHeight*linesPerEntry; i++ {
 			b.WriteString("\n")
 		}
 	}
@@ -1071,8 +1289,19 @@ func (m model) renderHTTPLog() string {
 	b.WriteString("\n")
 
 	// Help line at bottom (wrap for smaller screens)
-	helpText := "↑/↓: Navigate  Enter: Details  a: Auto-scroll  f: Filter  /: Search  c: Clear  q: Close"
+	helpText := "↑/↓: Navigate  Enter: Details  a: Auto-scroll  f: Filter  /: Search  c: Clear filters  x: Clear log  t: Latency colors  e: Export HAR  q: Close"
+	if narrow {
+		// One line on narrow terminals; the other keys still work
+		helpText = "Enter: Details  f: Filter  /: Search  c: Clear  q: Close"
+	}
 	b.WriteString("  ")
+	if state.clearConfirming {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("Press x again to clear all %d entries, any other key to cancel", len(state.entries))))
+		b.WriteString("  ")
+	} else if state.copyMessage != "" {
+		b.WriteString(successStyle.Render(state.copyMessage))
+		b.WriteString("  ")
+	}
 	b.WriteString(wrapHelpText(helpText, termWidth-4))
 
 	return b.String()
@@ -1115,9 +1344,12 @@ func (m model) renderHTTPLogDetail(entry HTTPLogEntry, termWidth, termHeight int
 	// Request body
 	if entry.RequestBody != "" {
 		lines = append(lines, accentStyle.Render("  Request Body:"))
-		// Decompress if needed, then check if binary
-		reqBody := decompressContent(entry.RequestBody, entry.RequestHeaders)
-		if isBinaryContent(reqBody, entry.RequestHeaders) {
+		// Strip any truncation marker, decompress if needed, then check if binary
+		reqBody, truncNote := httplog.SplitTruncated(entry.RequestBody)
+		reqBody = decompressContent(reqBody, entry.RequestHeaders)
+		if entry.GRPC {
+			lines = append(lines, grpcBodyLines(reqBody)...)
+		} else if isBinaryContent(reqBody, entry.RequestHeaders) {
 			lines = append(lines, mutedStyle.Render("    [Binary data - not displayed]"))
 			if ct := entry.RequestHeaders["Content-Type"]; ct != "" {
 				lines = append(lines, mutedStyle.Render(fmt.Sprintf("    Content-Type: %s", ct)))
@@ -1130,6 +1362,9 @@ func (m model) renderHTTPLogDetail(entry HTTPLogEntry, termWidth, termHeight int
 				lines = append(lines, "    "+truncate(line, termWidth-6))
 			}
 		}
+		if truncNote != "" {
+			lines = append(lines, mutedStyle.Render("    "+truncNote))
+		}
 		lines = append(lines, "")
 	}
 
@@ -1148,6 +1383,9 @@ func (m model) renderHTTPLogDetail(entry HTTPLogEntry, termWidth, termHeight int
 		statusStr = successStyle.Render(statusStr)
 	}
 	lines = append(lines, fmt.Sprintf("  Status: %s", statusStr))
+	if entry.GRPC {
+		lines = append(lines, "  gRPC Status: "+formatGRPCStatus(entry))
+	}
 
 	// Timing
 	latencyStr := ""
@@ -1157,7 +1395,11 @@ func (m model) renderHTTPLogDetail(entry HTTPLogEntry, termWidth, termHeight int
 		latencyStr = fmt.Sprintf("%dms", entry.LatencyMs)
 	}
 	lines = append(lines, fmt.Sprintf("  Latency: %s", latencyStr))
-	lines = append(lines, fmt.Sprintf("  Body Size: %d bytes", entry.BodySize))
+	if entry.BodySize < 0 {
+		lines = append(lines, "  Body Size: unknown (truncated)")
+	} else {
+		lines = append(lines, fmt.Sprintf("  Body Size: %d bytes", entry.BodySize))
+	}
 	lines = append(lines, "")
 
 	// Response headers (sorted alphabetically)
@@ -1178,9 +1420,12 @@ func (m model) renderHTTPLogDetail(entry HTTPLogEntry, termWidth, termHeight int
 	// Response body
 	if entry.ResponseBody != "" {
 		lines = append(lines, accentStyle.Render("  Response Body:"))
-		// Decompress if needed, then check if binary
-		respBody := decompressContent(entry.ResponseBody, entry.ResponseHeaders)
-		if isBinaryContent(respBody, entry.ResponseHeaders) {
+		// Strip any truncation marker, decompress if needed, then check if binary
+		respBody, truncNote := httplog.SplitTruncated(entry.ResponseBody)
+		respBody = decompressContent(respBody, entry.ResponseHeaders)
+		if entry.GRPC {
+			lines = append(lines, grpcBodyLines(respBody)...)
+		} else if isBinaryContent(respBody, entry.ResponseHeaders) {
 			lines = append(lines, mutedStyle.Render("    [Binary data - not displayed]"))
 			if ct := entry.ResponseHeaders["Content-Type"]; ct != "" {
 				lines = append(lines, mutedStyle.Render(fmt.Sprintf("    Content-Type: %s", ct)))
@@ -1193,6 +1438,9 @@ func (m model) renderHTTPLogDetail(entry HTTPLogEntry, termWidth, termHeight int
 				lines = append(lines, "    "+truncate(line, termWidth-6))
 			}
 		}
+		if truncNote != "" {
+			lines = append(lines, mutedStyle.Render("    "+truncNote))
+		}
 		lines = append(lines, "")
 	}
 
@@ -1253,9 +1501,9 @@ func (m model) renderHTTPLogDetail(entry HTTPLogEntry, termWidth, termHeight int
 	if state.copyMessage != "" {
 		b.WriteString(successStyle.Render(state.copyMessage))
 		b.WriteString("  ")
-		b.WriteString(wrapHelpText("↑/↓: Scroll  c: Copy  Esc: Back", termWidth-10))
+		b.WriteString(wrapHelpText("↑/↓: Scroll  c: Copy  C: Copy curl  r: Replay  Esc: Back", termWidth-10))
 	} else {
-		b.WriteString(wrapHelpText("↑/↓/PgUp/PgDn: Scroll  g: Top  c: Copy response  Esc: Back", termWidth-10))
+		b.WriteString(wrapHelpText("↑/↓/PgUp/PgDn: Scroll  g: Top  c: Copy response  C: Copy as curl  r: Replay  Esc: Back", termWidth-10))
 	}
 
 	return b.String()
@@ -1264,36 +1512,7 @@ func (m model) renderHTTPLogDetail(entry HTTPLogEntry, termWidth, termHeight int
 // decompressContent attempts to decompress content based on Content-Encoding header.
 // Returns the decompressed content if successful, or original content if not compressed or on error.
 func decompressContent(content string, headers map[string]string) string {
-	enc := headers["Content-Encoding"]
-	if enc == "" {
-		return content
-	}
-
-	data := []byte(content)
-	var reader io.ReadCloser
-	var err error
-
-	switch enc {
-	case "gzip":
-		reader, err = gzip.NewReader(bytes.NewReader(data))
-		if err != nil {
-			return content // Return original on error
-		}
-		defer func() { _ = reader.Close() }()
-	case "deflate":
-		reader = flate.NewReader(bytes.NewReader(data))
-		defer func() { _ = reader.Close() }()
-	default:
-		// br (brotli), compress, zstd - not in stdlib, return original
-		return content
-	}
-
-	decompressed, err := io.ReadAll(reader)
-	if err != nil {
-		return content // Return original on error
-	}
-
-	return string(decompressed)
+	return httplog.DecodeBody(content, headers)
 }
 
 // isBinaryContent checks if content is binary and shouldn't be displayed as text
@@ -1405,14 +1624,14 @@ func colorizeLine(line string) string {
 		if colonIdx > 0 {
 			// This is a key-value line
 			key := trimmed[:colonIdx+1] // includes the closing quote
-			rest := trimmed[colonIdx+1:]
+			rest := trimmed[colonIdx+2:]
 
 			// Colorize the key (without quotes for cleaner look, or with - let's keep quotes)
 			result.WriteString(jsonKeyStyle.Render(key))
 			result.WriteString(":")
 
 			// rest starts after the colon
-			if len(rest) > 1 {
+			if rest != "" {
 				value := strings.TrimPrefix(rest, " ")
 				hasComma := strings.HasSuffix(value, ",")
 				if hasComma {
@@ -1,9 +1,15 @@
 package ui
 
 import (
+	"bytes"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/andybalholm/brotli"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klauspost/compress/zstd"
 	"github.com/lukaszraczylo/kportal/internal/benchmark"
 	"github.com/lukaszraczylo/kportal/internal/config"
 	"github.com/lukaszraczylo/kportal/internal/k8s"
@@ -113,6 +119,15 @@ func TestRenderSelectNamespace_Error(t *testing.T) {
 	assert.Contains(t, result, "unreachable")
 }
 
+func TestRenderSelectNamespace_PermissionDenied(t *testing.T) {
+	m := newModelWithWizard(StepSelectNamespace)
+	m.ui.addWizard.error = fmt.Errorf("failed to list namespaces: %w", &k8s.PermissionError{Err: assert.AnError, Verb: "list", Resource: "namespaces"})
+	result := m.renderSelectNamespace()
+	assert.Contains(t, result, "Permission denied: you need list namespaces (cluster-wide)")
+	assert.NotContains(t, result, "failed to list namespaces")
+	assert.NotContains(t, result, "unreachable")
+}
+
 func TestRenderSelectNamespace_NoNamespaces(t *testing.T) {
 	m := newModelWithWizard(StepSelectNamespace)
 	m.ui.addWizard.namespaces = []string{}
@@ -148,6 +163,29 @@ func TestRenderSelectResourceType_AllTypes(t *testing.T) {
 	assert.Contains(t, result, "Pod (by name prefix)")
 	assert.Contains(t, result, "Pod (by label selector)")
 	assert.Contains(t, result, "Service")
+	assert.Contains(t, result, "Deployment")
+	assert.Contains(t, result, "StatefulSet")
+}
+
+func TestRenderEnterResource_Deployment_List(t *testing.T) {
+	m := newModelWithWizard(StepEnterResource)
+	m.ui.addWizard.selectedResourceType = ResourceTypeDeployment
+	m.ui.addWizard.inputMode = InputModeList
+	m.ui.addWizard.workloads = []k8s.WorkloadInfo{
+		{Name: "api", Kind: "deployment", Replicas: 3, ReadyReplicas: 2},
+	}
+	result := m.renderEnterResource()
+	assert.Contains(t, result, "Select deployment")
+	assert.Contains(t, result, "api (2/3 ready)")
+	assert.Contains(t, result, "Type to filter")
+}
+
+func TestRenderConfirmation_StatefulSetResource(t *testing.T) {
+	m := newModelWithWizard(StepConfirmation)
+	m.ui.addWizard.selectedResourceType = ResourceTypeStatefulSet
+	m.ui.addWizard.resourceValue = "postgres"
+	result := m.renderConfirmation()
+	assert.Contains(t, result, "statefulset/postgres")
 }
 
 func TestRenderSelectResourceType_CursorHighlight(t *testing.T) {
@@ -238,6 +276,15 @@ func TestRenderEnterResource_Service_Empty(t *testing.T) {
 	assert.Contains(t, result, "No services")
 }
 
+func TestRenderEnterResource_Service_PermissionDenied(t *testing.T) {
+	m := newModelWithWizard(StepEnterResource)
+	m.ui.addWizard.selectedResourceType = ResourceTypeService
+	m.ui.addWizard.error = &k8s.PermissionError{Err: assert.AnError, Verb: "list", Resource: "services", Namespace: "default"}
+	result := m.renderEnterResource()
+	assert.Contains(t, result, "Permission denied: you need list services in default")
+	assert.NotContains(t, result, "No services")
+}
+
 func TestRenderEnterResource_Service_WithServices(t *testing.T) {
 	m := newModelWithWizard(StepEnterResource)
 	m.ui.addWizard.selectedResourceType = ResourceTypeService
@@ -301,6 +348,18 @@ func TestRenderEnterRemotePort_ListMode_WithPorts(t *testing.T) {
 	assert.Contains(t, result, "80")
 }
 
+func TestRenderEnterRemotePort_ListMode_ShowsContainer(t *testing.T) {
+	m := newModelWithWizard(StepEnterRemotePort)
+	m.ui.addWizard.inputMode = InputModeList
+	m.ui.addWizard.detectedPorts = []k8s.PortInfo{
+		{Port: 8080, Name: "http", Container: "app"},
+		{Port: 9090, Name: "metrics", Container: "sidecar"},
+	}
+	result := m.renderEnterRemotePort()
+	assert.Contains(t, result, "8080 (http) [container: app]")
+	assert.Contains(t, result, "9090 (metrics) [container: sidecar]")
+}
+
 func TestRenderEnterRemotePort_ListMode_ScrollIndicators(t *testing.T) {
 	m := newModelWithWizard(StepEnterRemotePort)
 	m.ui.addWizard.inputMode = InputModeList
@@ -570,6 +629,23 @@ func TestRenderBenchmarkConfig(t *testing.T) {
 	assert.Contains(t, result, "my-svc")
 }
 
+func TestRenderBenchmarkConfig_TCPMode(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.mu.Lock()
+	ui.viewMode = ViewModeBenchmark
+	ui.benchmarkState = newBenchmarkState("fwd-id", "postgres", 5432)
+	ui.benchmarkState.mode = BenchmarkModeTCP
+	ui.mu.Unlock()
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	result := m.renderBenchmarkConfig()
+	assert.Contains(t, result, "TCP Benchmark")
+	assert.Contains(t, result, "Connections")
+	assert.Contains(t, result, "Payload")
+	assert.NotContains(t, result, "URL Path")
+	assert.NotContains(t, result, "Headers")
+}
+
 func TestRenderBenchmarkRunning(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
@@ -589,6 +665,26 @@ func TestRenderBenchmarkRunning(t *testing.T) {
 	assert.Contains(t, result, "100")
 }
 
+func TestRenderBenchmarkRunning_DurationMode(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.mu.Lock()
+	ui.viewMode = ViewModeBenchmark
+	state := newBenchmarkState("fwd-id", "my-svc", 8080)
+	state.step = BenchmarkStepRunning
+	state.running = true
+	state.duration = 10
+	state.startedAt = time.Now().Add(-5 * time.Second)
+	state.progress = 42
+	ui.benchmarkState = state
+	ui.mu.Unlock()
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	result := m.renderBenchmarkRunning()
+	assert.Contains(t, result, "5s / 10s elapsed")
+	assert.Contains(t, result, "42 requests completed")
+	assert.Contains(t, result, "50%")
+}
+
 func TestRenderBenchmarkResults_WithError(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
@@ -632,8 +728,10 @@ func TestRenderBenchmarkResults_WithResults(t *testing.T) {
 		MaxLatency:    50.0,
 		AvgLatency:    10.0,
 		P50Latency:    8.0,
+		P75Latency:    20.0,
 		P95Latency:    40.0,
 		P99Latency:    48.0,
+		P999Latency:   50.0,
 		Throughput:    50.0,
 		BytesRead:     10240,
 		StatusCodes:   map[int]int{200: 95, 500: 5},
@@ -647,9 +745,42 @@ func TestRenderBenchmarkResults_WithResults(t *testing.T) {
 	assert.Contains(t, result, "Successful:")
 	assert.Contains(t, result, "Failed:")
 	assert.Contains(t, result, "P95")
+	assert.Contains(t, result, "P75:    20.00")
+	assert.Contains(t, result, "P99.9:  50.00")
+	assert.Contains(t, result, "same as max")
 	assert.Contains(t, result, "Status Codes")
 }
 
+func TestRenderBenchmarkResults_TCP(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.mu.Lock()
+	ui.viewMode = ViewModeBenchmark
+	state := newBenchmarkState("fwd-id", "redis", 6379)
+	state.step = BenchmarkStepResults
+	state.mode = BenchmarkModeTCP
+	state.results = &BenchmarkResults{
+		Mode:          BenchmarkModeTCP,
+		TotalRequests: 20,
+		Successful:    20,
+		AvgLatency:    0.42,
+		Throughput:    100,
+		RoundTrips:    20,
+		RoundTripP50:  1.25,
+	}
+	ui.benchmarkState = state
+	ui.mu.Unlock()
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	result := m.renderBenchmarkResults()
+	assert.Contains(t, result, "Connections:     20")
+	assert.Contains(t, result, "Connect time (ms)")
+	assert.Contains(t, result, "Avg:    0.42")
+	assert.Contains(t, result, "Round trip (ms, 20)")
+	assert.Contains(t, result, "P50:    1.25")
+	assert.Contains(t, result, "Conns/sec")
+	assert.NotContains(t, result, "Status Codes")
+}
+
 func TestRenderBenchmark_Dispatch(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
@@ -677,7 +808,7 @@ func TestRenderHTTPLog_NoEntries(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("fwd-id", "my-svc")
+	ui.httpLogState = newHTTPLogState("fwd-id", "my-svc", 0)
 	ui.mu.Unlock()
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
 
@@ -689,7 +820,7 @@ func TestRenderHTTPLog_WithEntries(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/test", StatusCode: 200, Timestamp: "12:00:00"},
 		{Method: "POST", Path: "/api/create", StatusCode: 500, Timestamp: "12:00:01"},
@@ -704,11 +835,45 @@ func TestRenderHTTPLog_WithEntries(t *testing.T) {
 	assert.Contains(t, result, "/api/test")
 }
 
+func TestRenderHTTPLog_Summary(t *testing.T) {
+	m := newModelWithHTTPLog()
+	state := m.ui.httpLogState
+	state.entries = []HTTPLogEntry{
+		{Method: "GET", Path: "/a", StatusCode: 200, LatencyMs: 10, BodySize: 100},
+		{Method: "GET", Path: "/b", StatusCode: 500, LatencyMs: 30, BodySize: 100},
+	}
+
+	assert.Contains(t, m.renderHTTPLog(), "Requests: 2  Errors: 1 (50.0%)  Avg: 20ms")
+
+	// The summary follows the filter, and new entries as they arrive
+	state.filterMode = HTTPLogFilterErrors
+	assert.Contains(t, m.renderHTTPLog(), "Requests: 1  Errors: 1 (100.0%)  Avg: 30ms")
+
+	state.entries = append(state.entries, HTTPLogEntry{Method: "GET", Path: "/c", StatusCode: 404, LatencyMs: 10})
+	assert.Contains(t, m.renderHTTPLog(), "Requests: 2  Errors: 2 (100.0%)  Avg: 20ms")
+}
+
+func TestRenderHTTPLog_Narrow(t *testing.T) {
+	m := newModelWithHTTPLog()
+	m.termWidth = 80
+	m.ui.httpLogState.entries = []HTTPLogEntry{
+		{Method: "GET", Path: "/api/v1/orders/12345/items?expand=product", StatusCode: 200, LatencyMs: 12, Timestamp: "12:00:00"},
+	}
+
+	result := m.renderHTTPLog()
+	assert.NotContains(t, result, "PATH", "the path has a line of its own")
+	assert.Contains(t, result, "\n    /api/v1/orders/12345/items?expand=product")
+	assert.Contains(t, result, "q: Close")
+	for _, line := range strings.Split(result, "\n") {
+		assert.LessOrEqual(t, lipgloss.Width(line), 80, line)
+	}
+}
+
 func TestRenderHTTPLog_FilterActive(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	state.filterActive = true
 	state.filterText = "test"
 	ui.httpLogState = state
@@ -723,7 +888,7 @@ func TestRenderHTTPLog_FilteredEntries(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	state.entries = []HTTPLogEntry{
 		{Method: "GET", Path: "/api/test", StatusCode: 200},
 	}
@@ -741,7 +906,7 @@ func TestRenderHTTPLog_AutoScrollIndicator(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	state.autoScroll = true
 	ui.httpLogState = state
 	ui.mu.Unlock()
@@ -755,7 +920,7 @@ func TestRenderHTTPLog_FilterIndicator(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	state.filterMode = HTTPLogFilterErrors
 	ui.httpLogState = state
 	ui.mu.Unlock()
@@ -769,7 +934,7 @@ func TestRenderHTTPLog_DetailView(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	state.entries = []HTTPLogEntry{
 		{
 			Method:     "GET",
@@ -802,7 +967,7 @@ func TestRenderHTTPLogDetail_HighLatency(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	ui.httpLogState = state
 	ui.mu.Unlock()
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
@@ -816,10 +981,33 @@ func TestRenderHTTPLogDetail_HighLatency(t *testing.T) {
 	assert.Contains(t, result, "2.50s")
 }
 
+func TestRenderHTTPLogDetail_TruncatedBody(t *testing.T) {
+	ui := NewBubbleTeaUI(nil, "1.0.0")
+	ui.mu.Lock()
+	ui.httpLogState = newHTTPLogState("fwd-id", "my-svc", 0)
+	ui.mu.Unlock()
+	m := model{ui: ui, termWidth: 120, termHeight: 40}
+
+	entry := HTTPLogEntry{
+		Method:       "GET",
+		Path:         "/big",
+		StatusCode:   200,
+		BodySize:     70000,
+		ResponseBody: "partial...[truncated 69993 bytes]",
+	}
+	result := m.renderHTTPLogDetail(entry, 120, 40)
+	assert.Contains(t, result, "70000 bytes")
+	assert.Contains(t, result, "[truncated 69993 bytes]")
+
+	entry.BodySize = -1
+	result = m.renderHTTPLogDetail(entry, 120, 40)
+	assert.Contains(t, result, "unknown (truncated)")
+}
+
 func TestRenderHTTPLogDetail_Status500(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	ui.httpLogState = state
 	ui.mu.Unlock()
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
@@ -832,7 +1020,7 @@ func TestRenderHTTPLogDetail_Status500(t *testing.T) {
 func TestRenderHTTPLogDetail_Status400(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	ui.httpLogState = state
 	ui.mu.Unlock()
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
@@ -845,7 +1033,7 @@ func TestRenderHTTPLogDetail_Status400(t *testing.T) {
 func TestRenderHTTPLogDetail_WithError(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	ui.httpLogState = state
 	ui.mu.Unlock()
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
@@ -858,7 +1046,7 @@ func TestRenderHTTPLogDetail_WithError(t *testing.T) {
 func TestRenderHTTPLogDetail_BinaryRequestBody(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	ui.httpLogState = state
 	ui.mu.Unlock()
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
@@ -878,7 +1066,7 @@ func TestRenderHTTPLogDetail_BinaryRequestBody(t *testing.T) {
 func TestRenderHTTPLogDetail_CopyMessage(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	state.copyMessage = "Copied!"
 	ui.httpLogState = state
 	ui.mu.Unlock()
@@ -892,7 +1080,7 @@ func TestRenderHTTPLogDetail_CopyMessage(t *testing.T) {
 func TestRenderHTTPLogDetail_ScrollIndicator(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
-	state := newHTTPLogState("fwd-id", "my-svc")
+	state := newHTTPLogState("fwd-id", "my-svc", 0)
 	// Force a long response body to make content exceed viewport.
 	bodyLines := strings.Repeat("a line of body content\n", 100)
 	state.entries = []HTTPLogEntry{{
@@ -941,6 +1129,19 @@ func TestRenderMainView_WithErrors(t *testing.T) {
 	result := m.renderMainView()
 	assert.Contains(t, result, "Errors:")
 	assert.Contains(t, result, "connection refused")
+	assert.Contains(t, result, "my-app ("+ui.forwards["id-1"].LastErrorAt.Format("15:04:05")+"):")
+}
+
+func TestRenderMainView_Uptime(t *testing.T) {
+	m := newTestModelWithForward()
+	assert.Contains(t, m.renderMainView(), "UPTIME")
+
+	m.ui.UpdateStatus("test-id", "Active")
+	m.ui.forwards["test-id"].ConnectedSince = time.Now().Add(-133*time.Second - 100*time.Millisecond)
+	assert.Contains(t, m.renderMainView(), "2m13s")
+
+	m.ui.UpdateStatus("test-id", "Reconnecting (2s, attempt 1)")
+	assert.NotContains(t, m.renderMainView(), "2m13s")
 }
 
 func TestRenderMainView_UpdateAvailable(t *testing.T) {
@@ -1048,7 +1249,7 @@ func TestModelView_HTTPLogFullScreen(t *testing.T) {
 	ui := NewBubbleTeaUI(nil, "1.0.0")
 	ui.mu.Lock()
 	ui.viewMode = ViewModeHTTPLog
-	ui.httpLogState = newHTTPLogState("fwd-id", "my-svc")
+	ui.httpLogState = newHTTPLogState("fwd-id", "my-svc", 0)
 	ui.mu.Unlock()
 	m := model{ui: ui, termWidth: 120, termHeight: 40}
 
@@ -1075,7 +1276,7 @@ func TestDecompressContent_NoEncoding(t *testing.T) {
 
 func TestDecompressContent_UnknownEncoding(t *testing.T) {
 	content := "hello world"
-	result := decompressContent(content, map[string]string{"Content-Encoding": "br"})
+	result := decompressContent(content, map[string]string{"Content-Encoding": "compress"})
 	assert.Equal(t, content, result)
 }
 
@@ -1092,6 +1293,37 @@ func TestDecompressContent_InvalidDeflate(t *testing.T) {
 	assert.NotEmpty(t, result)
 }
 
+func TestDecompressContent_Brotli(t *testing.T) {
+	var buf bytes.Buffer
+	w := brotli.NewWriter(&buf)
+	_, err := w.Write([]byte(`{"ok":true}`))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	headers := map[string]string{"Content-Encoding": "br", "Content-Type": "application/json"}
+	result := decompressContent(buf.String(), headers)
+	assert.Equal(t, `{"ok":true}`, result)
+	assert.False(t, isBinaryContent(result, headers))
+}
+
+func TestDecompressContent_Zstd(t *testing.T) {
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := enc.EncodeAll([]byte(`{"ok":true}`), nil)
+	require.NoError(t, enc.Close())
+
+	headers := map[string]string{"Content-Encoding": "zstd", "Content-Type": "application/json"}
+	result := decompressContent(string(compressed), headers)
+	assert.Equal(t, `{"ok":true}`, result)
+	assert.Contains(t, formatJSONContent(result, headers), `"ok": true`)
+}
+
+func TestDecompressContent_InvalidZstd(t *testing.T) {
+	content := "not-zstd-data"
+	result := decompressContent(content, map[string]string{"Content-Encoding": "zstd"})
+	assert.Equal(t, content, result)
+}
+
 // ----- isBinaryContent ---------------------------------------------------
 
 func TestIsBinaryContent_ImageContentType(t *testing.T) {
@@ -1338,9 +1570,15 @@ func TestBuildFooterLines_NormalWidth(t *testing.T) {
 
 func TestBuildFooterLines_NarrowTerminal(t *testing.T) {
 	m := newTestModel()
-	// Narrow terminal forces wrapping.
-	lines := m.buildFooterLines(40)
-	require.NotEmpty(t, lines)
+	// Narrow terminals keep the footer on one line, dropping bindings
+	// that do not fit but never quit.
+	for _, width := range []int{40, 80} {
+		lines := m.buildFooterLines(width)
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], "q: Quit")
+		assert.Contains(t, lines[0], "Total:")
+		assert.LessOrEqual(t, lipgloss.Width(lines[0]), width)
+	}
 }
 
 // ----- getTermDimensions ------------------------------------------------
@@ -1377,6 +1615,9 @@ func TestGetStatusIconAndText(t *testing.T) {
 		{"Starting", "○", "Starting", false},
 		{"Reconnecting", "◐", "Reconnecting", false},
 		{"Error", "✗", "Error", false},
+		{"Unhealthy", "▲", "Unhealthy", false},
+		{"Reconnecting (4s, attempt 3/5)", "◐", "Reconnecting (4s, attempt 3/5)", false},
+		{"Failed", "✗", "Failed", false},
 		{"Active", "○", "Disabled", true},
 	}
 
@@ -1402,16 +1643,26 @@ func TestGetStatusIconAndText(t *testing.T) {
 	}
 }
 
+func TestStatusKind(t *testing.T) {
+	assert.Equal(t, "Active", statusKind("Active"))
+	assert.Equal(t, "Reconnecting", statusKind("Reconnecting (4s, attempt 3/5)"))
+	assert.Equal(t, "Reconnecting", statusKind("Reconnecting (500ms, attempt 1)"))
+	assert.Equal(t, "", statusKind(""))
+}
+
 // ----- defaultMainViewColors / mainViewKeyBindings ----------------------
 
 func TestDefaultMainViewColors(t *testing.T) {
 	colors := defaultMainViewColors()
 	assert.NotEmpty(t, string(colors.header))
 	assert.NotEmpty(t, string(colors.active))
+	// Unhealthy must stand out from both Active and Error
+	assert.NotEqual(t, colors.active, colors.unhealthy)
+	assert.NotEqual(t, colors.errorColor, colors.unhealthy)
 }
 
 func TestMainViewKeyBindings(t *testing.T) {
-	bindings := mainViewKeyBindings()
+	bindings := mainViewKeyBindings(false)
 	require.NotEmpty(t, bindings)
 	// Spot-check a few expected bindings.
 	var keys []string
@@ -1420,6 +1671,15 @@ func TestMainViewKeyBindings(t *testing.T) {
 	}
 	assert.Contains(t, keys, "n")
 	assert.Contains(t, keys, "d")
+	for _, b := range bindings {
+		assert.False(t, b.disabled, "nothing is disabled outside read-only mode")
+	}
+}
+
+func TestMainViewKeyBindings_ReadOnly(t *testing.T) {
+	for _, b := range mainViewKeyBindings(true) {
+		assert.Equal(t, isConfigKey(b.key), b.disabled, "binding %q", b.key)
+	}
 }
 
 // ----- safeRecover -------------------------------------------------------
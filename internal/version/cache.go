@@ -0,0 +1,78 @@
+package version
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheTTL is how long a cached update check result is reused
+const DefaultCacheTTL = 24 * time.Hour
+
+// cacheEntry is the on-disk form of the last successful update check. The
+// release is cached rather than the verdict, so an upgraded kportal compares
+// its own version against it.
+type cacheEntry struct {
+	CheckedAt time.Time   `json:"checked_at"`
+	Release   ReleaseInfo `json:"release"`
+}
+
+// DefaultCachePath returns the update check cache file under the user cache
+// directory, or "" when there is none.
+func DefaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "kportal", "update-check.json")
+}
+
+// readCache returns the cached release when it is younger than the cache
+// TTL. A missing, unreadable or stale cache returns nil.
+func (c *Checker) readCache() *ReleaseInfo {
+	if c.cachePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.cachePath)
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+	age := time.Since(entry.CheckedAt)
+	if age < 0 || age >= c.cacheTTL || entry.Release.TagName == "" {
+		return nil
+	}
+	return &entry.Release
+}
+
+// writeCache records release as the latest check result. Best effort: a
+// cache that cannot be written only means the next launch checks again.
+func (c *Checker) writeCache(release *ReleaseInfo) {
+	if c.cachePath == "" {
+		return
+	}
+	data, err := json.Marshal(cacheEntry{CheckedAt: time.Now(), Release: *release})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.cachePath), 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.cachePath), ".update-check-*.tmp")
+	if err != nil {
+		return
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), c.cachePath)
+}
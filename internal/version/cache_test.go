@@ -0,0 +1,123 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingServer serves tag and counts the requests it receives
+func countingServer(t *testing.T, tag string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = json.NewEncoder(w).Encode(ReleaseInfo{TagName: tag, HTMLURL: "https://example.com/" + tag})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// writeCacheEntry writes a cache file checked at the given time
+func writeCacheEntry(t *testing.T, path, tag string, checkedAt time.Time) {
+	t.Helper()
+	data, err := json.Marshal(cacheEntry{CheckedAt: checkedAt, Release: ReleaseInfo{TagName: tag}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func TestCheckForUpdate_WritesCache(t *testing.T) {
+	srv, calls := countingServer(t, "v2.0.0")
+	c := makeCheckerWithServer(t, srv, "1.0.0")
+	c.cachePath = filepath.Join(t.TempDir(), "kportal", "update-check.json")
+	c.cacheTTL = time.Hour
+
+	require.NotNil(t, c.CheckForUpdate(context.Background()))
+	info := c.CheckForUpdate(context.Background())
+
+	require.NotNil(t, info, "second check is answered from the cache")
+	assert.Equal(t, "2.0.0", info.LatestVersion)
+	assert.Equal(t, "https://example.com/v2.0.0", info.ReleaseURL)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCheckForUpdate_FreshCacheSkipsRequest(t *testing.T) {
+	srv, calls := countingServer(t, "v3.0.0")
+	c := makeCheckerWithServer(t, srv, "1.0.0")
+	c.cachePath = filepath.Join(t.TempDir(), "update-check.json")
+	c.cacheTTL = time.Hour
+	writeCacheEntry(t, c.cachePath, "v2.0.0", time.Now().Add(-time.Minute))
+
+	info := c.CheckForUpdate(context.Background())
+
+	require.NotNil(t, info)
+	assert.Equal(t, "2.0.0", info.LatestVersion)
+	assert.Equal(t, int32(0), calls.Load())
+}
+
+func TestCheckForUpdate_CacheComparedWithCurrentVersion(t *testing.T) {
+	srv, calls := countingServer(t, "v2.0.0")
+	c := makeCheckerWithServer(t, srv, "2.0.0")
+	c.cachePath = filepath.Join(t.TempDir(), "update-check.json")
+	c.cacheTTL = time.Hour
+	writeCacheEntry(t, c.cachePath, "v2.0.0", time.Now())
+
+	assert.Nil(t, c.CheckForUpdate(context.Background()), "cached release is not newer than the running version")
+	assert.Equal(t, int32(0), calls.Load())
+}
+
+func TestCheckForUpdate_StaleCacheRechecks(t *testing.T) {
+	srv, calls := countingServer(t, "v3.0.0")
+	c := makeCheckerWithServer(t, srv, "1.0.0")
+	c.cachePath = filepath.Join(t.TempDir(), "update-check.json")
+	c.cacheTTL = time.Hour
+	writeCacheEntry(t, c.cachePath, "v2.0.0", time.Now().Add(-2*time.Hour))
+
+	info := c.CheckForUpdate(context.Background())
+
+	require.NotNil(t, info)
+	assert.Equal(t, "3.0.0", info.LatestVersion)
+	assert.Equal(t, int32(1), calls.Load())
+
+	cached := c.readCache()
+	require.NotNil(t, cached, "the fresh result replaces the stale one")
+	assert.Equal(t, "v3.0.0", cached.TagName)
+}
+
+func TestCheckForUpdate_CorruptCacheRechecks(t *testing.T) {
+	srv, calls := countingServer(t, "v2.0.0")
+	c := makeCheckerWithServer(t, srv, "1.0.0")
+	c.cachePath = filepath.Join(t.TempDir(), "update-check.json")
+	c.cacheTTL = time.Hour
+	require.NoError(t, os.WriteFile(c.cachePath, []byte("{not json"), 0o600))
+
+	require.NotNil(t, c.CheckForUpdate(context.Background()))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCheckForUpdate_FailureNotCached(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := makeCheckerWithServer(t, srv, "1.0.0")
+	c.cachePath = filepath.Join(t.TempDir(), "update-check.json")
+
+	assert.Nil(t, c.CheckForUpdate(context.Background()))
+	assert.NoFileExists(t, c.cachePath)
+}
+
+func TestNewChecker_NoCacheByDefault(t *testing.T) {
+	assert.Empty(t, NewChecker("o", "r", "1.0.0").cachePath)
+	assert.Equal(t, DefaultCacheTTL, NewChecker("o", "r", "1.0.0").cacheTTL)
+}
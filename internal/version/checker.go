@@ -10,13 +10,19 @@
 //	} else if info.UpdateAvailable {
 //	    fmt.Printf("Update available: %s -> %s\n", info.CurrentVersion, info.LatestVersion)
 //	}
+//
+// Set KPORTAL_NO_UPDATE_CHECK to skip update checks entirely; callers check
+// DisabledFromEnv before starting one.
 package version
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"os"
 	"strings"
 	"time"
 )
@@ -24,10 +30,28 @@ import (
 const (
 	// GitHubAPIURL is the GitHub API endpoint for releases
 	githubReleasesURL = "https://api.github.com/repos/%s/%s/releases/latest"
-	// requestTimeout is the timeout for HTTP requests
-	requestTimeout = 5 * time.Second
+	// DefaultTimeout is the timeout for each HTTP request
+	DefaultTimeout = 5 * time.Second
+	// DefaultRetryDelay is the pause before retrying a transient failure
+	DefaultRetryDelay = 1 * time.Second
+	// DisableEnv is the environment variable that turns update checks off
+	DisableEnv = "KPORTAL_NO_UPDATE_CHECK"
 )
 
+// DisabledFromEnv reports whether KPORTAL_NO_UPDATE_CHECK asks for update
+// checks to be skipped. Any non-empty value counts, as with NO_COLOR.
+func DisabledFromEnv() bool {
+	return os.Getenv(DisableEnv) != ""
+}
+
+// Options configures a Checker. Zero values fall back to the defaults.
+type Options struct {
+	CachePath  string        // File caching the last result; empty disables the cache
+	Timeout    time.Duration // Timeout for each request attempt
+	RetryDelay time.Duration // Pause before the single retry
+	CacheTTL   time.Duration // Age after which a cached result is re-checked
+}
+
 // ReleaseInfo contains information about a GitHub release
 type ReleaseInfo struct {
 	TagName string `json:"tag_name"`
@@ -45,20 +69,42 @@ type UpdateInfo struct {
 
 // Checker checks for new versions on GitHub
 type Checker struct {
-	client  *http.Client
-	owner   string
-	repo    string
-	current string
+	client     *http.Client
+	owner      string
+	repo       string
+	current    string
+	cachePath  string
+	retryDelay time.Duration
+	cacheTTL   time.Duration
 }
 
-// NewChecker creates a new version checker
+// NewChecker creates a new version checker with the default timeout and
+// retry delay
 func NewChecker(owner, repo, currentVersion string) *Checker {
+	return NewCheckerWithOptions(owner, repo, currentVersion, Options{})
+}
+
+// NewCheckerWithOptions creates a new version checker with the given request
+// timeout, retry delay and result cache
+func NewCheckerWithOptions(owner, repo, currentVersion string, opts Options) *Checker {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultRetryDelay
+	}
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = DefaultCacheTTL
+	}
 	return &Checker{
-		owner:   owner,
-		repo:    repo,
-		current: normalizeVersion(currentVersion),
+		owner:      owner,
+		repo:       repo,
+		current:    normalizeVersion(currentVersion),
+		cachePath:  opts.CachePath,
+		retryDelay: opts.RetryDelay,
+		cacheTTL:   opts.CacheTTL,
 		client: &http.Client{
-			Timeout: requestTimeout,
+			Timeout: opts.Timeout,
 		},
 	}
 }
@@ -66,10 +112,26 @@ func NewChecker(owner, repo, currentVersion string) *Checker {
 // CheckForUpdate checks if a newer version is available.
 // Returns nil if current version is up to date or if check fails.
 // This is designed to fail silently - network errors should not impact the user.
+// A transient failure (network error or 5xx response) is retried once.
+// With a cache path set, a result younger than the cache TTL is reused
+// without a request, and each successful fetch is cached.
 func (c *Checker) CheckForUpdate(ctx context.Context) *UpdateInfo {
-	release, err := c.fetchLatestRelease(ctx)
-	if err != nil {
-		return nil
+	release := c.readCache()
+	if release == nil {
+		var err error
+		release, err = c.fetchLatestRelease(ctx)
+		if err != nil && isTransient(err) && ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(c.retryDelay):
+			}
+			release, err = c.fetchLatestRelease(ctx)
+		}
+		if err != nil {
+			return nil
+		}
+		c.writeCache(release)
 	}
 
 	latestVersion := normalizeVersion(release.TagName)
@@ -104,7 +166,7 @@ func (c *Checker) fetchLatestRelease(ctx context.Context) (*ReleaseInfo, error)
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+		return nil, &statusError{code: resp.StatusCode}
 	}
 
 	var release ReleaseInfo
@@ -115,6 +177,27 @@ func (c *Checker) fetchLatestRelease(ctx context.Context) (*ReleaseInfo, error)
 	return &release, nil
 }
 
+// statusError is a non-200 response from the GitHub API
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("GitHub API returned status %d", e.code)
+}
+
+// isTransient reports whether a failed fetch is worth retrying: network
+// errors, including timeouts, and 5xx responses. Rate limits (403, 429) and
+// other 4xx responses will not clear within a retry delay.
+func isTransient(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
 // normalizeVersion removes 'v' or 'V' prefix and trims whitespace
 func normalizeVersion(v string) string {
 	v = strings.TrimSpace(v)
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ func makeCheckerWithServer(t *testing.T, srv *httptest.Server, currentVersion st
 		Timeout:   5 * time.Second,
 		Transport: &rewriteTransport{inner: srv.Client().Transport, base: srv.URL},
 	}
+	c.retryDelay = 10 * time.Millisecond
 	return c
 }
 
@@ -60,6 +62,26 @@ func TestNewChecker_FieldsSet(t *testing.T) {
 	assert.NotNil(t, c.client)
 }
 
+// TestNewCheckerWithOptions verifies the timeout and retry delay options,
+// and that zero values fall back to the defaults.
+func TestNewCheckerWithOptions(t *testing.T) {
+	c := NewCheckerWithOptions("o", "r", "1.0.0", Options{Timeout: 2 * time.Second, RetryDelay: 3 * time.Second})
+	assert.Equal(t, 2*time.Second, c.client.Timeout)
+	assert.Equal(t, 3*time.Second, c.retryDelay)
+
+	c = NewCheckerWithOptions("o", "r", "1.0.0", Options{})
+	assert.Equal(t, DefaultTimeout, c.client.Timeout)
+	assert.Equal(t, DefaultRetryDelay, c.retryDelay)
+}
+
+// TestDisabledFromEnv verifies any non-empty KPORTAL_NO_UPDATE_CHECK disables checks.
+func TestDisabledFromEnv(t *testing.T) {
+	t.Setenv(DisableEnv, "")
+	assert.False(t, DisabledFromEnv())
+	t.Setenv(DisableEnv, "1")
+	assert.True(t, DisabledFromEnv())
+}
+
 // TestNewChecker_NormalizesVersion ensures the v-prefix is stripped at construction.
 func TestNewChecker_NormalizesVersion(t *testing.T) {
 	cases := []struct {
@@ -143,6 +165,56 @@ func TestCheckForUpdate_NetworkError(t *testing.T) {
 	assert.Nil(t, info, "network error should return nil (fail silent)")
 }
 
+// TestCheckForUpdate_RetriesTransientFailure verifies a 5xx response is
+// retried once and the second answer is used.
+func TestCheckForUpdate_RetriesTransientFailure(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ReleaseInfo{TagName: "v2.0.0"})
+	}))
+	defer srv.Close()
+
+	c := makeCheckerWithServer(t, srv, "1.0.0")
+	info := c.CheckForUpdate(context.Background())
+	require.NotNil(t, info)
+	assert.Equal(t, "2.0.0", info.LatestVersion)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// TestCheckForUpdate_RetriesOnce verifies a failure that persists is retried
+// only once.
+func TestCheckForUpdate_RetriesOnce(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := makeCheckerWithServer(t, srv, "1.0.0")
+	assert.Nil(t, c.CheckForUpdate(context.Background()))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// TestCheckForUpdate_NoRetryOnClientError verifies 4xx responses, such as a
+// rate limit, are not retried.
+func TestCheckForUpdate_NoRetryOnClientError(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	c := makeCheckerWithServer(t, srv, "1.0.0")
+	assert.Nil(t, c.CheckForUpdate(context.Background()))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
 // TestCheckForUpdate_CancelledContext verifies nil is returned when the
 // context is already cancelled.
 func TestCheckForUpdate_CancelledContext(t *testing.T) {